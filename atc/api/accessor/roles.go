@@ -14,6 +14,9 @@ const (
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                     MemberRole,
 	atc.GetConfig:                      ViewerRole,
+	atc.ListConfigRevisions:            ViewerRole,
+	atc.GetConfigRevision:              ViewerRole,
+	atc.DiffConfigRevisions:            ViewerRole,
 	atc.GetCC:                          ViewerRole,
 	atc.GetBuild:                       ViewerRole,
 	atc.GetBuildPlan:                   ViewerRole,
@@ -27,10 +30,14 @@ var DefaultRoles = map[string]string{
 	atc.CreateJobBuild:                 OperatorRole,
 	atc.RerunJobBuild:                  OperatorRole,
 	atc.SetBuildComment:                OperatorRole,
+	atc.ApproveBuild:                   OperatorRole,
+	atc.RejectBuild:                    OperatorRole,
 	atc.ListAllJobs:                    ViewerRole,
 	atc.ListJobs:                       ViewerRole,
 	atc.ListJobBuilds:                  ViewerRole,
 	atc.ListJobInputs:                  ViewerRole,
+	atc.PreviewJobBuild:                ViewerRole,
+	atc.JobTestHistory:                 ViewerRole,
 	atc.GetJobBuild:                    ViewerRole,
 	atc.PauseJob:                       OperatorRole,
 	atc.UnpauseJob:                     OperatorRole,
@@ -100,8 +107,13 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                     OwnerRole,
 	atc.DestroyTeam:                    OwnerRole,
 	atc.ListTeamBuilds:                 ViewerRole,
+	atc.ListNotificationDeliveries:     MemberRole,
+	atc.GetSecretsReport:               MemberRole,
+	atc.GetPipelineSource:              ViewerRole,
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
+	atc.GetBuildArtifact:               ViewerRole,
+	atc.GetBuildTestResults:            ViewerRole,
 	atc.GetWall:                        ViewerRole,
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 	"github.com/concourse/concourse/atc/wrappa"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -50,6 +51,7 @@ var (
 }`
 
 	fakeWorkerPool          *apifakes.FakePool
+	fakeAlgorithm           *schedulerfakes.FakeAlgorithm
 	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
 	fakeContainerRepository *dbfakes.FakeContainerRepository
 	fakeDestroyer           *gcfakes.FakeDestroyer
@@ -141,6 +143,7 @@ var _ = BeforeEach(func() {
 	dbWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 
 	fakeWorkerPool = new(apifakes.FakePool)
+	fakeAlgorithm = new(schedulerfakes.FakeAlgorithm)
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
@@ -212,6 +215,7 @@ var _ = BeforeEach(func() {
 		constructedEventHandler.Construct,
 
 		fakeWorkerPool,
+		fakeAlgorithm,
 
 		sink,
 
@@ -12,6 +12,20 @@ import (
 )
 
 type FakePool struct {
+	CompatibleWorkersStub        func(context.Context, worker.Spec) ([]db.Worker, error)
+	compatibleWorkersMutex       sync.RWMutex
+	compatibleWorkersArgsForCall []struct {
+		arg1 context.Context
+		arg2 worker.Spec
+	}
+	compatibleWorkersReturns struct {
+		result1 []db.Worker
+		result2 error
+	}
+	compatibleWorkersReturnsOnCall map[int]struct {
+		result1 []db.Worker
+		result2 error
+	}
 	CreateVolumeForArtifactStub        func(context.Context, worker.Spec) (runtime.Volume, db.WorkerArtifact, error)
 	createVolumeForArtifactMutex       sync.RWMutex
 	createVolumeForArtifactArgsForCall []struct {
@@ -70,6 +84,71 @@ type FakePool struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePool) CompatibleWorkers(arg1 context.Context, arg2 worker.Spec) ([]db.Worker, error) {
+	fake.compatibleWorkersMutex.Lock()
+	ret, specificReturn := fake.compatibleWorkersReturnsOnCall[len(fake.compatibleWorkersArgsForCall)]
+	fake.compatibleWorkersArgsForCall = append(fake.compatibleWorkersArgsForCall, struct {
+		arg1 context.Context
+		arg2 worker.Spec
+	}{arg1, arg2})
+	stub := fake.CompatibleWorkersStub
+	fakeReturns := fake.compatibleWorkersReturns
+	fake.recordInvocation("CompatibleWorkers", []interface{}{arg1, arg2})
+	fake.compatibleWorkersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePool) CompatibleWorkersCallCount() int {
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
+	return len(fake.compatibleWorkersArgsForCall)
+}
+
+func (fake *FakePool) CompatibleWorkersCalls(stub func(context.Context, worker.Spec) ([]db.Worker, error)) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = stub
+}
+
+func (fake *FakePool) CompatibleWorkersArgsForCall(i int) (context.Context, worker.Spec) {
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
+	argsForCall := fake.compatibleWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePool) CompatibleWorkersReturns(result1 []db.Worker, result2 error) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = nil
+	fake.compatibleWorkersReturns = struct {
+		result1 []db.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePool) CompatibleWorkersReturnsOnCall(i int, result1 []db.Worker, result2 error) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = nil
+	if fake.compatibleWorkersReturnsOnCall == nil {
+		fake.compatibleWorkersReturnsOnCall = make(map[int]struct {
+			result1 []db.Worker
+			result2 error
+		})
+	}
+	fake.compatibleWorkersReturnsOnCall[i] = struct {
+		result1 []db.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePool) CreateVolumeForArtifact(arg1 context.Context, arg2 worker.Spec) (runtime.Volume, db.WorkerArtifact, error) {
 	fake.createVolumeForArtifactMutex.Lock()
 	ret, specificReturn := fake.createVolumeForArtifactReturnsOnCall[len(fake.createVolumeForArtifactArgsForCall)]
@@ -285,6 +364,8 @@ func (fake *FakePool) LocateVolumeReturnsOnCall(i int, result1 runtime.Volume, r
 func (fake *FakePool) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
 	fake.createVolumeForArtifactMutex.RLock()
 	defer fake.createVolumeForArtifactMutex.RUnlock()
 	fake.locateContainerMutex.RLock()
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"io"
 	"net/http"
@@ -262,4 +263,135 @@ var _ = Describe("ArtifactRepository API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_id", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.IDReturns(42)
+			build.TeamIDReturns(734)
+			build.TeamNameReturns("some-team")
+			build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+			build.PipelineIDReturns(0)
+			dbBuildFactory.BuildForAPIReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts/18")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the artifact is not found", func() {
+				BeforeEach(func() {
+					build.ArtifactReturns(nil, sql.ErrNoRows)
+				})
+
+				It("looks up the artifact on the build", func() {
+					Expect(build.ArtifactCallCount()).To(Equal(1))
+					Expect(build.ArtifactArgsForCall(0)).To(Equal(18))
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the artifact fails", func() {
+				BeforeEach(func() {
+					build.ArtifactReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the artifact is found", func() {
+				var fakeArtifact *dbfakes.FakeWorkerArtifact
+
+				BeforeEach(func() {
+					fakeArtifact = new(dbfakes.FakeWorkerArtifact)
+					fakeArtifact.NameReturns("report")
+					fakeArtifact.BuildIDReturns(42)
+					build.ArtifactReturns(fakeArtifact, nil)
+				})
+
+				Context("when the artifact belongs to another build", func() {
+					BeforeEach(func() {
+						fakeArtifact.BuildIDReturns(41)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeArtifact.VolumeCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the artifact volume is gone", func() {
+					BeforeEach(func() {
+						fakeArtifact.VolumeReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the artifact volume is found", func() {
+					var volume *runtimetest.Volume
+
+					BeforeEach(func() {
+						fakeVolume := new(dbfakes.FakeCreatedVolume)
+						fakeVolume.HandleReturns("some-handle")
+						fakeArtifact.VolumeReturns(fakeVolume, true, nil)
+
+						volume = runtimetest.NewVolume("volume").
+							WithContent(runtimetest.VolumeContent{
+								"report.xml": {Data: []byte("<testsuites/>")},
+							})
+
+						fakeWorkerPool.LocateVolumeReturns(volume, runtimetest.NewWorker("worker"), true, nil)
+					})
+
+					It("looks up the volume in the build's team", func() {
+						Expect(fakeArtifact.VolumeArgsForCall(0)).To(Equal(734))
+
+						_, teamID, handle := fakeWorkerPool.LocateVolumeArgsForCall(0)
+						Expect(teamID).To(Equal(734))
+						Expect(handle).To(Equal("some-handle"))
+					})
+
+					It("returns the artifact as a tarball attachment", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).Should(IncludeHeaderEntries(map[string]string{
+							"Content-Type":        "application/octet-stream",
+							"Content-Disposition": `attachment; filename="report.tgz"`,
+						}))
+
+						tarStream := runtimetest.VolumeContent{}
+						err := tarStream.StreamIn(context.Background(), ".", baggageclaim.GzipEncoding, 0, response.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(tarStream).To(Equal(volume.Content))
+					})
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,91 @@
+package artifactserver
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetBuildArtifact streams the contents of an artifact belonging to the build
+// as a gzipped tarball.
+func (s *Server) GetBuildArtifact(build db.BuildForAPI) http.Handler {
+	logger := s.logger.Session("get-build-artifact")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		artifactID, err := strconv.Atoi(r.FormValue(":artifact_id"))
+		if err != nil {
+			logger.Error("failed-to-get-artifact-id", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		artifact, err := build.Artifact(artifactID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logger.Info("artifact-not-found")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			logger.Error("failed-to-get-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if artifact.BuildID() != build.ID() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifactVolume, found, err := artifact.Volume(build.TeamID())
+		if err != nil {
+			logger.Error("failed-to-get-artifact-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("artifact-volume-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		volume, _, found, err := s.workerPool.LocateVolume(ctx, build.TeamID(), artifactVolume.Handle())
+		if err != nil {
+			logger.Error("failed-to-get-worker-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("worker-volume-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		reader, err := volume.StreamOut(ctx, "/", compression.NewGzipCompression())
+		if err != nil {
+			logger.Error("failed-to-stream-volume-contents", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer reader.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifact.Name()+".tgz"))
+
+		_, err = io.Copy(w, reader)
+		if err != nil {
+			logger.Error("failed-to-encode-artifact", err)
+		}
+	})
+}
@@ -1120,6 +1120,362 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/approve", func() {
+		var (
+			path     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			path = "/api/v1/builds/128/approve"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{
+					UserName:      "some-user",
+					Connector:     "github",
+					DisplayUserId: "some-user",
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildForAPIReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+					dbBuildFactory.BuildForAPIReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when getting the pending approvals fails", func() {
+						BeforeEach(func() {
+							build.PendingApprovalsReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the build is not waiting for approval", func() {
+						BeforeEach(func() {
+							build.PendingApprovalsReturns(nil, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not decide anything", func() {
+							Expect(build.DecideApprovalCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the build is waiting for more than one approval", func() {
+						BeforeEach(func() {
+							build.PendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan", Name: "qa"},
+								{PlanID: "other-plan", Name: "security"},
+							}, nil)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := io.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring("qa, security"))
+						})
+
+						Context("when a step is given", func() {
+							BeforeEach(func() {
+								path = "/api/v1/builds/128/approve?step=security"
+								build.DecideApprovalReturns(true, nil)
+							})
+
+							It("approves that step", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+								Expect(build.DecideApprovalCallCount()).To(Equal(1))
+								planID, approved, decidedBy := build.DecideApprovalArgsForCall(0)
+								Expect(planID).To(Equal(atc.PlanID("other-plan")))
+								Expect(approved).To(BeTrue())
+								Expect(decidedBy).To(Equal("some-user"))
+							})
+						})
+					})
+
+					Context("when the build is waiting for approval", func() {
+						BeforeEach(func() {
+							build.PendingApprovalsReturns([]db.BuildApproval{
+								{PlanID: "some-plan", Name: "qa"},
+							}, nil)
+							build.DecideApprovalReturns(true, nil)
+						})
+
+						It("approves the build as the user", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+							Expect(build.DecideApprovalCallCount()).To(Equal(1))
+							planID, approved, decidedBy := build.DecideApprovalArgsForCall(0)
+							Expect(planID).To(Equal(atc.PlanID("some-plan")))
+							Expect(approved).To(BeTrue())
+							Expect(decidedBy).To(Equal("some-user"))
+						})
+
+						Context("when the approvers are limited to a role", func() {
+							BeforeEach(func() {
+								build.PendingApprovalsReturns([]db.BuildApproval{
+									{PlanID: "some-plan", Name: "qa", Approvers: []string{"member"}},
+								}, nil)
+							})
+
+							Context("when the user has a lesser role", func() {
+								BeforeEach(func() {
+									fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"pipeline-operator"}})
+								})
+
+								It("returns 403", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+									Expect(build.DecideApprovalCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the user has a greater role", func() {
+								BeforeEach(func() {
+									fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+								})
+
+								It("returns 204", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+								})
+							})
+						})
+
+						Context("when the approvers are limited to users", func() {
+							BeforeEach(func() {
+								fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+							})
+
+							Context("when the user is listed", func() {
+								BeforeEach(func() {
+									build.PendingApprovalsReturns([]db.BuildApproval{
+										{PlanID: "some-plan", Name: "qa", Approvers: []string{"github:Some-User"}},
+									}, nil)
+								})
+
+								It("returns 204", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+								})
+							})
+
+							Context("when the user is not listed", func() {
+								BeforeEach(func() {
+									build.PendingApprovalsReturns([]db.BuildApproval{
+										{PlanID: "some-plan", Name: "qa", Approvers: []string{"github:someone-else"}},
+									}, nil)
+								})
+
+								It("returns 403", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+								})
+							})
+						})
+
+						Context("when it has already been decided", func() {
+							BeforeEach(func() {
+								build.DecideApprovalReturns(false, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							})
+						})
+
+						Context("when deciding fails", func() {
+							BeforeEach(func() {
+								build.DecideApprovalReturns(false, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/reject", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+			build.TeamNameReturns("some-team")
+			build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+			build.PendingApprovalsReturns([]db.BuildApproval{
+				{PlanID: "some-plan", Name: "qa"},
+			}, nil)
+			build.DecideApprovalReturns(true, nil)
+			dbBuildFactory.BuildForAPIReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/reject", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the build as the user", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(build.DecideApprovalCallCount()).To(Equal(1))
+			planID, approved, decidedBy := build.DecideApprovalArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan")))
+			Expect(approved).To(BeFalse())
+			Expect(decidedBy).To(Equal("some-user"))
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/test-results", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.IDReturns(128)
+			build.TeamNameReturns("some-team")
+			build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+			build.PipelineIDReturns(0)
+			dbBuildFactory.BuildForAPIReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/128/test-results")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the build has test results", func() {
+				BeforeEach(func() {
+					build.TestResultsReturns([]atc.TestResult{
+						{Step: "unit", Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+						{Step: "unit", Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "division by zero"},
+					}, nil)
+				})
+
+				It("returns 200 with the results and their summary", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"summary": {"total": 2, "passed": 1, "failed": 1, "skipped": 0},
+						"results": [
+							{"step": "unit", "suite": "math", "name": "adds", "status": "passed", "duration": 0.5},
+							{"step": "unit", "suite": "math", "name": "divides", "status": "failed", "message": "division by zero"}
+						]
+					}`))
+				})
+			})
+
+			Context("when the build has no test results", func() {
+				BeforeEach(func() {
+					build.TestResultsReturns(nil, nil)
+				})
+
+				It("returns an empty list", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"summary": {"total": 0, "passed": 0, "failed": 0, "skipped": 0},
+						"results": []
+					}`))
+				})
+			})
+
+			Context("when getting the test results fails", func() {
+				BeforeEach(func() {
+					build.TestResultsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
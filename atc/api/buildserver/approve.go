@@ -0,0 +1,134 @@
+package buildserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ApproveBuild(build db.BuildForAPI) http.Handler {
+	return s.decideApproval(build, true)
+}
+
+func (s *Server) RejectBuild(build db.BuildForAPI) http.Handler {
+	return s.decideApproval(build, false)
+}
+
+func (s *Server) decideApproval(build db.BuildForAPI, approved bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("decide-approval", build.LagerData())
+
+		approvals, err := build.PendingApprovals()
+		if err != nil {
+			logger.Error("failed-to-get-pending-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		stepName := r.URL.Query().Get("step")
+
+		var pending []db.BuildApproval
+		for _, approval := range approvals {
+			if stepName == "" || approval.Name == stepName {
+				pending = append(pending, approval)
+			}
+		}
+
+		if len(pending) == 0 {
+			w.WriteHeader(http.StatusConflict)
+			if stepName == "" {
+				fmt.Fprintf(w, "build is not waiting for approval")
+			} else {
+				fmt.Fprintf(w, "build is not waiting for approval of step '%s'", stepName)
+			}
+			return
+		}
+
+		if len(pending) > 1 {
+			var names []string
+			for _, approval := range pending {
+				names = append(names, approval.Name)
+			}
+
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "build is waiting for more than one approval; specify one of: %s", strings.Join(names, ", "))
+			return
+		}
+
+		approval := pending[0]
+
+		acc := accessor.GetAccessor(r)
+		if !canApprove(acc, build.TeamName(), approval.Approvers) {
+			logger.Info("not-an-approver", lager.Data{"step": approval.Name})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		decided, err := build.DecideApproval(approval.PlanID, approved, acc.UserInfo().DisplayUserId)
+		if err != nil {
+			logger.Error("failed-to-decide-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !decided {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "step '%s' has already been decided", approval.Name)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// approvalRoleRanks orders the roles that can approve, so that an approver
+// role also admits anyone with a more powerful role on the team.
+var approvalRoleRanks = map[string]int{
+	accessor.OperatorRole: 1,
+	accessor.MemberRole:   2,
+	accessor.OwnerRole:    3,
+}
+
+func canApprove(acc accessor.Access, teamName string, approvers []string) bool {
+	if len(approvers) == 0 || acc.IsAdmin() {
+		return true
+	}
+
+	userInfo := acc.UserInfo()
+
+	connector := userInfo.Connector
+	if connector == "cloudfoundry" {
+		connector = "cf"
+	}
+
+	for _, approver := range approvers {
+		if rank, isRole := approvalRoleRanks[approver]; isRole {
+			for _, role := range acc.TeamRoles()[teamName] {
+				if approvalRoleRanks[role] >= rank {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		// approvers are validated to be a role or 'connector:user' when the
+		// pipeline is set, so don't guess at anything else
+		approverConnector, approverUser, isUser := strings.Cut(approver, ":")
+		if !isUser || !strings.EqualFold(approverConnector, connector) || approverUser == "" {
+			continue
+		}
+
+		for _, user := range []string{userInfo.UserId, userInfo.UserName} {
+			if strings.EqualFold(approverUser, user) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
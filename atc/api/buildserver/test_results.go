@@ -0,0 +1,38 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildTestResults(build db.BuildForAPI) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-test-results", build.LagerData())
+
+		results, err := build.TestResults()
+		if err != nil {
+			logger.Error("failed-to-get-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if results == nil {
+			results = []atc.TestResult{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(atc.BuildTestResults{
+			Summary: atc.SummarizeTestResults(results),
+			Results: results,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -448,7 +448,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -479,7 +479,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -511,18 +511,34 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(initiallyPaused).To(BeTrue())
 						})
 
+						Context("when the user is known", func() {
+							BeforeEach(func() {
+								fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+							})
+
+							It("records them as the author of the config", func() {
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+								_, _, _, _, author := dbTeam.SavePipelineAsArgsForCall(0)
+								Expect(author).To(Equal(db.ConfigAuthor{
+									Source:    atc.ConfigSourceFly,
+									CreatedBy: "some-user",
+								}))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -537,7 +553,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -549,6 +565,113 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when the pipeline is managed by the team's pipeline source", func() {
+							BeforeEach(func() {
+								managedPipeline := new(dbfakes.FakePipeline)
+								managedPipeline.SourcePathReturns("pipelines/a-pipeline.yml")
+								dbTeam.PipelineReturns(managedPipeline, true, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							})
+
+							It("returns the error in the response body", func() {
+								Expect(io.ReadAll(response.Body)).To(Equal([]byte("pipeline is managed by the team's pipeline source (pipelines/a-pipeline.yml) and cannot be set directly")))
+							})
+
+							It("does not save it", func() {
+								Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when finding the pipeline fails", func() {
+							BeforeEach(func() {
+								dbTeam.PipelineReturns(nil, false, errors.New("oh no!"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when a job uses templates", func() {
+							BeforeEach(func() {
+								pipelineConfig.Templates = atc.StepTemplates{
+									{
+										Name:   "fetch",
+										Params: map[string]interface{}{"resource": nil},
+										Steps: []atc.Step{
+											{Config: &atc.GetStep{Name: "((param:resource))"}},
+										},
+									},
+								}
+
+								pipelineConfig.Jobs[0].PlanSequence = append(pipelineConfig.Jobs[0].PlanSequence,
+									atc.Step{
+										Config: &atc.UseTemplateStep{
+											Name:   "fetch",
+											Params: atc.Params{"resource": "some-resource"},
+										},
+									},
+									atc.Step{
+										Config: &atc.UseTemplateStep{Name: "notify"},
+									},
+								)
+
+								dbTeam.StepTemplatesReturns(atc.StepTemplates{
+									{
+										Name: "notify",
+										Steps: []atc.Step{
+											{Config: &atc.PutStep{Name: "some-resource"}},
+										},
+									},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves it with the templates expanded from the pipeline and the team", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								_, savedConfig, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
+
+								plan := savedConfig.Jobs[0].PlanSequence
+								Expect(plan[len(plan)-2].Config).To(Equal(&atc.UseTemplateStep{
+									Name:   "fetch",
+									Params: atc.Params{"resource": "some-resource"},
+									Steps: []atc.Step{
+										{Config: &atc.GetStep{Name: "some-resource"}},
+									},
+								}))
+								Expect(plan[len(plan)-1].Config).To(Equal(&atc.UseTemplateStep{
+									Name: "notify",
+									Steps: []atc.Step{
+										{Config: &atc.PutStep{Name: "some-resource"}},
+									},
+								}))
+							})
+
+							Context("when a template is unknown", func() {
+								BeforeEach(func() {
+									dbTeam.StepTemplatesReturns(nil)
+								})
+
+								It("returns 400 without saving it", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+									Expect(io.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"jobs.some-job: unknown template 'notify'"
+										]
+									}`))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+								})
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -578,7 +701,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 							})
 						})
 					})
@@ -609,9 +732,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineAsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -656,9 +779,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-								ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+								ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineAsArgsForCall(0)
 								Expect(ref.Name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -724,7 +847,7 @@ jobs:
 									})
 
 									It("passes validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 									})
 
 									It("returns 200 ok", func() {
@@ -740,7 +863,7 @@ jobs:
 									})
 
 									It("fail validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 									})
 
 									It("returns 400", func() {
@@ -965,9 +1088,9 @@ jobs:
 									})
 
 									It("passes validation and saves it un-interpolated", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-										ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+										ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineAsArgsForCall(0)
 										Expect(ref.Name).To(Equal("a-pipeline"))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -1015,7 +1138,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -1029,7 +1152,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineAsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -1070,7 +1193,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineAsCallCount()).To(BeZero())
 							})
 						})
 
@@ -1103,7 +1226,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -1115,9 +1238,9 @@ jobs:
 								})
 
 								It("saves an instanced pipeline", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+									Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-									ref, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									ref, _, _, _, _ := dbTeam.SavePipelineAsArgsForCall(0)
 									Expect(ref).To(Equal(atc.PipelineRef{
 										Name:         "a-pipeline",
 										InstanceVars: atc.InstanceVars{"branch": "feature"},
@@ -1175,7 +1298,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1215,9 +1338,9 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(1))
 
-						ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+						ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineAsArgsForCall(0)
 						Expect(ref.Name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1269,7 +1392,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1300,7 +1423,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1315,7 +1438,198 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineAsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/revisions")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the revisions are found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionsReturns([]atc.ConfigRevision{
+						{Version: 3, Source: atc.ConfigSourceSetPipeline, BuildID: 42, CreatedAt: 200},
+						{Version: 1, Source: atc.ConfigSourceFly, CreatedBy: "some-user", CreatedAt: 100},
+					}, nil)
+				})
+
+				It("returns them", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(io.ReadAll(response.Body)).To(MatchJSON(`[
+						{"version": 3, "source": "set_pipeline", "build_id": 42, "created_at": 200},
+						{"version": 1, "source": "fly", "created_by": "some-user", "created_at": 100}
+					]`))
+				})
+			})
+
+			Context("when getting the revisions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:version", func() {
+		var (
+			version  string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			version = "3"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/revisions/" + version)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the revision is found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(atc.ConfigRevision{
+						Version:   3,
+						Source:    atc.ConfigSourceFly,
+						CreatedAt: 200,
+						Config:    &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+					}, true, nil)
+				})
+
+				It("returns it with its config", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var revision atc.ConfigRevision
+					Expect(json.NewDecoder(response.Body).Decode(&revision)).To(Succeed())
+					Expect(revision.Version).To(Equal(3))
+					Expect(revision.Config.Jobs[0].Name).To(Equal("some-job"))
+
+					Expect(fakePipeline.ConfigRevisionArgsForCall(0)).To(Equal(db.ConfigVersion(3)))
+				})
+			})
+
+			Context("when the revision is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(atc.ConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is malformed", func() {
+				BeforeEach(func() {
+					version = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:version/diff", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			configs := map[db.ConfigVersion]atc.Config{
+				1: {Jobs: atc.JobConfigs{{Name: "some-job", Public: false}}},
+				2: {Jobs: atc.JobConfigs{{Name: "some-job", Public: true}}},
+				3: {Jobs: atc.JobConfigs{{Name: "some-job", Public: true}, {Name: "other-job"}}},
+			}
+
+			fakePipeline.ConfigRevisionsReturns([]atc.ConfigRevision{
+				{Version: 3}, {Version: 2}, {Version: 1},
+			}, nil)
+			fakePipeline.ConfigRevisionStub = func(version db.ConfigVersion) (atc.ConfigRevision, bool, error) {
+				config, found := configs[version]
+				if !found {
+					return atc.ConfigRevision{}, false, nil
+				}
+
+				return atc.ConfigRevision{Version: int(version), Config: &config}, true, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/config/revisions/3/diff" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("renders the changes since the previous revision", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			body, err := io.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring("job other-job has been added"))
+			Expect(string(body)).NotTo(ContainSubstring("job some-job has changed"))
+		})
+
+		Context("when the revision to compare with is given", func() {
+			BeforeEach(func() {
+				query = "?from=1"
+			})
+
+			It("renders the changes since that revision", func() {
+				body, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring("job some-job has changed"))
+				Expect(string(body)).To(ContainSubstring("job other-job has been added"))
+			})
+		})
+
+		Context("when the revision to compare with is not found", func() {
+			BeforeEach(func() {
+				query = "?from=7"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 			})
 		})
 	})
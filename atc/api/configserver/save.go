@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	. "github.com/concourse/concourse/atc/api/helpers"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
@@ -61,12 +62,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	warnings, errorMessages := configvalidate.Validate(config)
-	if len(errorMessages) > 0 {
-		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
-		HandleBadRequest(w, errorMessages...)
-		return
-	}
+	var warnings []atc.ConfigWarning
 
 	pipelineName := rata.Param(r, "pipeline_name")
 	warning, err := atc.ValidateIdentifier(pipelineName, "pipeline")
@@ -103,6 +99,49 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		session.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		session.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found && pipeline.SourcePath() != "" {
+		session.Info("ignoring-managed-pipeline", lager.Data{"path": pipeline.SourcePath()})
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "pipeline is managed by the team's pipeline source (%s) and cannot be set directly", pipeline.SourcePath())
+		return
+	}
+
+	err = config.ExpandTemplates(team.StepTemplates())
+	if err != nil {
+		session.Info("ignoring-invalid-templates", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, err.Error())
+		return
+	}
+
+	configWarnings, errorMessages := configvalidate.Validate(config)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+		HandleBadRequest(w, errorMessages...)
+		return
+	}
+
+	warnings = append(configWarnings, warnings...)
+
 	if checkCredentials {
 		variables := creds.NewVariables(s.secretManager, teamName, pipelineName, false)
 
@@ -115,20 +154,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saving")
 
-	team, found, err := s.teamFactory.FindTeam(teamName)
-	if err != nil {
-		session.Error("failed-to-find-team", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	if !found {
-		session.Debug("team-not-found")
-		w.WriteHeader(http.StatusNotFound)
-		return
+	author := db.ConfigAuthor{
+		Source:    atc.ConfigSourceFly,
+		CreatedBy: accessor.GetAccessor(r).UserInfo().DisplayUserId,
 	}
 
-	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
+	_, created, err := team.SavePipelineAs(pipelineRef, config, version, true, author)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
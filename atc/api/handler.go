@@ -38,6 +38,7 @@ import (
 type Pool interface {
 	artifactserver.Pool
 	containerserver.Pool
+	jobserver.Pool
 }
 
 func NewHandler(
@@ -65,6 +66,7 @@ func NewHandler(
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
 	workerPool Pool,
+	algorithm jobserver.Algorithm,
 
 	sink *lager.ReconfigurableSink,
 
@@ -92,7 +94,7 @@ func NewHandler(
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
 	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
-	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
+	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory, algorithm, workerPool)
 	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
@@ -104,7 +106,7 @@ func NewHandler(
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerPool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL, secretManager, varSourcePool)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
 	artifactServer := artifactserver.NewServer(logger, workerPool)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
@@ -114,6 +116,10 @@ func NewHandler(
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
 
+		atc.ListConfigRevisions: pipelineHandlerFactory.HandlerFor(pipelineServer.ListConfigRevisions),
+		atc.GetConfigRevision:   pipelineHandlerFactory.HandlerFor(pipelineServer.GetConfigRevision),
+		atc.DiffConfigRevisions: pipelineHandlerFactory.HandlerFor(pipelineServer.DiffConfigRevisions),
+
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
@@ -125,20 +131,26 @@ func NewHandler(
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.GetBuildArtifact:    buildHandlerFactory.HandlerFor(artifactServer.GetBuildArtifact),
+		atc.GetBuildTestResults: buildHandlerFactory.HandlerFor(buildServer.GetBuildTestResults),
 		atc.SetBuildComment:     buildHandlerFactory.HandlerFor(buildServer.SetBuildComment),
-
-		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.RerunJobBuild:  pipelineHandlerFactory.HandlerFor(jobServer.RerunJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.ScheduleJob:    pipelineHandlerFactory.HandlerFor(jobServer.ScheduleJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.ApproveBuild:        buildHandlerFactory.HandlerFor(buildServer.ApproveBuild),
+		atc.RejectBuild:         buildHandlerFactory.HandlerFor(buildServer.RejectBuild),
+
+		atc.ListAllJobs:     http.HandlerFunc(jobServer.ListAllJobs),
+		atc.ListJobs:        pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:          pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:   pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:   pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.PreviewJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.PreviewJobBuild),
+		atc.GetJobBuild:     pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:  pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.RerunJobBuild:   pipelineHandlerFactory.HandlerFor(jobServer.RerunJobBuild),
+		atc.PauseJob:        pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:      pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.ScheduleJob:     pipelineHandlerFactory.HandlerFor(jobServer.ScheduleJob),
+		atc.JobTestHistory:  pipelineHandlerFactory.HandlerFor(jobServer.JobTestHistory),
+		atc.JobBadge:        pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge: mainredirect.Handler{
 			Routes: atc.Routes,
 			Route:  atc.JobBadge,
@@ -224,6 +236,10 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.ListNotificationDeliveries: teamHandlerFactory.HandlerFor(teamServer.ListNotificationDeliveries),
+		atc.GetSecretsReport:           teamHandlerFactory.HandlerFor(teamServer.GetSecretsReport),
+		atc.GetPipelineSource:          teamHandlerFactory.HandlerFor(teamServer.GetPipelineSource),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
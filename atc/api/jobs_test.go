@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/atc/worker"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -504,6 +505,34 @@ var _ = Describe("Jobs API", func() {
 								})
 							})
 
+							Context("when the job's inputs could not be resolved", func() {
+								BeforeEach(func() {
+									fakeJob.SchedulingDiagnosticsReturns([]atc.SchedulingDiagnostic{
+										{
+											Input:     "some-name",
+											Resource:  "some-other-input",
+											PassedJob: "a",
+											Message:   "no version of some-other-input has passed job a",
+										},
+									})
+								})
+
+								It("returns why", func() {
+									var job atc.Job
+									err := json.NewDecoder(response.Body).Decode(&job)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(job.SchedulingDiagnostics).To(Equal([]atc.SchedulingDiagnostic{
+										{
+											Input:     "some-name",
+											Resource:  "some-other-input",
+											PassedJob: "a",
+											Message:   "no version of some-other-input has passed job a",
+										},
+									}))
+								})
+							})
+
 							Context("when getting the job's builds fails", func() {
 								BeforeEach(func() {
 									fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -1878,6 +1907,239 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/preview", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/preview")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the job is found", func() {
+				var preview atc.BuildPreview
+
+				BeforeEach(func() {
+					fakePipeline.JobReturns(fakeJob, true, nil)
+					fakePipeline.TeamIDReturns(42)
+					fakePipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+
+					resource := new(dbfakes.FakeResource)
+					resource.IDReturns(1)
+					resource.NameReturns("some-resource")
+					resource.TypeReturns("git")
+					resource.SourceReturns(atc.Source{"branch": "((branch))"})
+					fakePipeline.ResourcesReturns([]db.Resource{resource}, nil)
+
+					fakeJob.AlgorithmInputsReturns(db.InputConfigs{
+						{Name: "some-input", ResourceID: 1, Trigger: true},
+					}, nil)
+
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Trigger:  true,
+								},
+							},
+							{
+								Config: &atc.TaskStep{
+									Name: "some-task",
+									Config: &atc.TaskConfig{
+										Platform: "linux",
+										Run:      atc.TaskRunConfig{Path: "echo"},
+									},
+									Params: atc.TaskEnv{
+										"BRANCH": "((branch))",
+										"TOKEN":  "((token))",
+									},
+									Tags: atc.Tags{"some-tag"},
+								},
+							},
+						},
+					}, nil)
+				})
+
+				JustBeforeEach(func() {
+					if response.StatusCode == http.StatusOK {
+						preview = atc.BuildPreview{}
+						Expect(json.NewDecoder(response.Body).Decode(&preview)).To(Succeed())
+					}
+				})
+
+				Context("when computing the inputs fails", func() {
+					BeforeEach(func() {
+						fakeAlgorithm.ComputeReturns(nil, false, false, errors.New("disaster"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when an input cannot be resolved", func() {
+					BeforeEach(func() {
+						fakeAlgorithm.ComputeReturns(db.InputMapping{
+							"some-input": db.InputResult{ResolveError: "no versions"},
+						}, false, false, nil)
+
+						fakeJob.BuildInputsForMappingReturns([]db.BuildInput{
+							{Name: "some-input", ResolveError: "no versions"},
+						}, nil)
+					})
+
+					It("returns the input's error without a plan", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(preview.Resolved).To(BeFalse())
+						Expect(preview.Inputs).To(Equal([]atc.BuildPreviewInput{
+							{
+								Name:         "some-input",
+								Resource:     "some-resource",
+								Trigger:      true,
+								ResolveError: "no versions",
+							},
+						}))
+						Expect(preview.Plan).To(BeNil())
+					})
+				})
+
+				Context("when the inputs are resolved", func() {
+					BeforeEach(func() {
+						fakeAlgorithm.ComputeReturns(db.InputMapping{
+							"some-input": db.InputResult{
+								Input: &db.AlgorithmInput{
+									AlgorithmVersion: db.AlgorithmVersion{ResourceID: 1, Version: "some-md5"},
+								},
+								PassedBuildIDs: []int{7},
+							},
+						}, true, false, nil)
+
+						fakeJob.BuildInputsForMappingReturns([]db.BuildInput{
+							{
+								Name:       "some-input",
+								ResourceID: 1,
+								Version:    atc.Version{"ref": "abc"},
+							},
+						}, nil)
+
+						worker1 := new(dbfakes.FakeWorker)
+						worker1.NameReturns("worker-1")
+						fakeWorkerPool.CompatibleWorkersReturns([]db.Worker{worker1}, nil)
+					})
+
+					It("computes the inputs without saving them", func() {
+						Expect(fakeAlgorithm.ComputeCallCount()).To(Equal(1))
+						_, job, inputs := fakeAlgorithm.ComputeArgsForCall(0)
+						Expect(job).To(Equal(fakeJob))
+						Expect(inputs).To(HaveLen(1))
+
+						Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					})
+
+					It("returns the resolved versions", func() {
+						Expect(preview.Resolved).To(BeTrue())
+						Expect(preview.Inputs).To(Equal([]atc.BuildPreviewInput{
+							{
+								Name:           "some-input",
+								Resource:       "some-resource",
+								Trigger:        true,
+								Version:        atc.Version{"ref": "abc"},
+								PassedBuildIDs: []int{7},
+							},
+						}))
+					})
+
+					It("returns the plan with only the instance vars interpolated", func() {
+						Expect(preview.Plan).ToNot(BeNil())
+
+						var gets, tasks []atc.Plan
+						preview.Plan.Each(func(p *atc.Plan) {
+							if p.Get != nil {
+								gets = append(gets, *p)
+							}
+							if p.Task != nil {
+								tasks = append(tasks, *p)
+							}
+						})
+
+						Expect(gets).To(HaveLen(1))
+						Expect(gets[0].Get.Version).To(Equal(&atc.Version{"ref": "abc"}))
+						Expect(gets[0].Get.Source).To(Equal(atc.Source{"branch": "main"}))
+
+						Expect(tasks).To(HaveLen(1))
+						Expect(tasks[0].Task.Params).To(Equal(atc.TaskEnv{
+							"BRANCH": "main",
+							"TOKEN":  "((token))",
+						}))
+					})
+
+					It("returns the workers each step could run on", func() {
+						Expect(preview.Steps).To(HaveLen(2))
+						Expect(preview.Steps[0].Name).To(Equal("some-input"))
+						Expect(preview.Steps[0].Kind).To(Equal("get"))
+						Expect(preview.Steps[0].ResourceType).To(Equal("git"))
+						Expect(preview.Steps[0].Workers).To(Equal([]string{"worker-1"}))
+
+						Expect(preview.Steps[1].Name).To(Equal("some-task"))
+						Expect(preview.Steps[1].Kind).To(Equal("task"))
+						Expect(preview.Steps[1].Platform).To(Equal("linux"))
+
+						Expect(fakeWorkerPool.CompatibleWorkersCallCount()).To(Equal(2))
+						_, spec := fakeWorkerPool.CompatibleWorkersArgsForCall(1)
+						Expect(spec).To(Equal(worker.Spec{
+							Platform: "linux",
+							Tags:     atc.Tags{"some-tag"},
+							TeamID:   42,
+						}))
+					})
+
+					Context("when no workers are compatible with a step", func() {
+						BeforeEach(func() {
+							fakeWorkerPool.CompatibleWorkersReturns(nil, errors.New("no workers"))
+						})
+
+						It("returns the error for the step", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(preview.Steps[0].Workers).To(BeEmpty())
+							Expect(preview.Steps[0].Error).To(Equal("no workers"))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -2608,6 +2870,127 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-history", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/test-history" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(fakeJob, true, nil)
+					fakeJob.TestHistoryReturns([]atc.TestHistory{
+						{
+							Suite:      "math",
+							Name:       "divides",
+							Runs:       3,
+							Passed:     2,
+							Failed:     1,
+							Flaky:      true,
+							LastStatus: atc.TestStatusFailed,
+							LastBuild:  "7",
+						},
+					}, nil)
+				})
+
+				It("returns the history of the job's tests over the last 10 builds", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+					Expect(fakeJob.TestHistoryCallCount()).To(Equal(1))
+					Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(10))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"suite": "math",
+							"name": "divides",
+							"runs": 3,
+							"passed": 2,
+							"failed": 1,
+							"skipped": 0,
+							"flaky": true,
+							"last_status": "failed",
+							"last_build": "7"
+						}
+					]`))
+				})
+
+				Context("when the number of builds is given", func() {
+					BeforeEach(func() {
+						query = "?builds=25"
+					})
+
+					It("looks at that many builds", func() {
+						Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(25))
+					})
+				})
+
+				Context("when the number of builds is invalid", func() {
+					BeforeEach(func() {
+						query = "?builds=lots"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeJob.TestHistoryCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when getting the history fails", func() {
+					BeforeEach(func() {
+						fakeJob.TestHistoryReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
 })
 
 func fakeDBResourceType(t atc.ResourceType) *dbfakes.FakeResourceType {
@@ -0,0 +1,246 @@
+package jobserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+// PreviewJobBuild works out what a build of the job would run if it were
+// created now: the versions the scheduler would pick for its inputs, the
+// build's plan, and the workers each of its steps could be placed on.
+// Nothing is saved, so the job's next build is left alone.
+func (s *Server) PreviewJobBuild(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("preview-build")
+		ctx := lagerctx.NewContext(r.Context(), logger)
+
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		inputConfigs, err := job.AlgorithmInputs()
+		if err != nil {
+			logger.Error("failed-to-get-algorithm-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		inputMapping, resolved, _, err := s.algorithm.Compute(ctx, job, inputConfigs)
+		if err != nil {
+			logger.Error("failed-to-compute-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		buildInputs, err := job.BuildInputsForMapping(inputMapping)
+		if err != nil {
+			logger.Error("failed-to-get-input-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		preview := atc.BuildPreview{
+			Resolved: resolved,
+			Inputs:   []atc.BuildPreviewInput{},
+		}
+
+		for _, input := range buildInputs {
+			previewInput := atc.BuildPreviewInput{
+				Name:            input.Name,
+				Version:         input.Version,
+				FirstOccurrence: input.FirstOccurrence,
+				PassedBuildIDs:  inputMapping[input.Name].PassedBuildIDs,
+				ResolveError:    input.ResolveError,
+			}
+
+			for _, config := range inputConfigs {
+				if config.Name == input.Name {
+					previewInput.Trigger = config.Trigger
+
+					for _, resource := range resources {
+						if resource.ID() == config.ResourceID {
+							previewInput.Resource = resource.Name()
+						}
+					}
+				}
+			}
+
+			preview.Inputs = append(preview.Inputs, previewInput)
+		}
+
+		if resolved {
+			plan, err := s.previewPlan(pipeline, job, resources, buildInputs)
+			if err != nil {
+				logger.Error("failed-to-create-plan", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			preview.Plan = &plan
+			preview.Steps = s.previewSteps(ctx, pipeline.TeamID(), &plan)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(preview)
+		if err != nil {
+			logger.Error("failed-to-encode-build-preview", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) previewPlan(pipeline db.Pipeline, job db.Job, resources db.Resources, buildInputs []db.BuildInput) (atc.Plan, error) {
+	config, err := job.Config()
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	prototypes, err := pipeline.Prototypes()
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	schedulerResources := db.SchedulerResources{}
+	for _, resource := range resources {
+		schedulerResources = append(schedulerResources, db.SchedulerResource{
+			Name:                 resource.Name(),
+			Type:                 resource.Type(),
+			Source:               resource.Source(),
+			ExposeBuildCreatedBy: resource.Config().ExposeBuildCreatedBy,
+		})
+	}
+
+	planner := builds.NewPlanner(atc.NewPlanFactory(time.Now().Unix()))
+
+	plan, err := planner.Create(config.StepConfig(), schedulerResources, resourceTypes.Deserialize(), prototypes.Configs(), buildInputs, false)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return interpolateInstanceVars(plan, pipeline.InstanceVars())
+}
+
+// interpolateInstanceVars interpolates the pipeline's instance vars into the
+// plan. They are the only vars which aren't secret, so any others are left
+// in place.
+func interpolateInstanceVars(plan atc.Plan, instanceVars atc.InstanceVars) (atc.Plan, error) {
+	if len(instanceVars) == 0 {
+		return plan, nil
+	}
+
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	interpolated, err := vars.NewTemplate(payload).Evaluate(vars.StaticVariables(instanceVars), vars.EvaluateOpts{})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	var interpolatedPlan atc.Plan
+	err = yaml.Unmarshal(interpolated, &interpolatedPlan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return interpolatedPlan, nil
+}
+
+// previewSteps finds the workers that each of the plan's containers could
+// be placed on, using the same spec as the step would when it runs.
+func (s *Server) previewSteps(ctx context.Context, teamID int, plan *atc.Plan) []atc.BuildPreviewStep {
+	steps := []atc.BuildPreviewStep{}
+
+	plan.Each(func(p *atc.Plan) {
+		step := atc.BuildPreviewStep{ID: p.ID}
+
+		switch {
+		case p.Get != nil:
+			step.Name = p.Get.Name
+			step.Kind = "get"
+			step.ResourceType = p.Get.Type
+			step.BaseResourceType = p.Get.TypeImage.BaseType
+			step.Tags = p.Get.Tags
+		case p.Put != nil:
+			step.Name = p.Put.Name
+			step.Kind = "put"
+			step.ResourceType = p.Put.Type
+			step.BaseResourceType = p.Put.TypeImage.BaseType
+			step.Tags = p.Put.Tags
+		case p.Check != nil:
+			step.Name = p.Check.Name
+			step.Kind = "check"
+			step.ResourceType = p.Check.Type
+			step.BaseResourceType = p.Check.TypeImage.BaseType
+			step.Tags = p.Check.Tags
+		case p.Task != nil:
+			step.Name = p.Task.Name
+			step.Kind = "task"
+			step.Tags = p.Task.Tags
+
+			if p.Task.Config != nil {
+				step.Platform = p.Task.Config.Platform
+			}
+		default:
+			return
+		}
+
+		workers, err := s.workerPool.CompatibleWorkers(ctx, worker.Spec{
+			Platform:     step.Platform,
+			ResourceType: step.BaseResourceType,
+			Tags:         step.Tags,
+			TeamID:       teamID,
+		})
+		if err != nil {
+			lagerctx.FromContext(ctx).Info("no-compatible-workers", lager.Data{"step": step.Name, "error": err.Error()})
+			step.Error = err.Error()
+		}
+
+		step.Workers = []string{}
+		for _, worker := range workers {
+			step.Workers = append(step.Workers, worker.Name())
+		}
+
+		sort.Strings(step.Workers)
+
+		steps = append(steps, step)
+	})
+
+	return steps
+}
@@ -1,12 +1,23 @@
 package jobserver
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
 )
 
+type Algorithm interface {
+	Compute(context.Context, db.Job, db.InputConfigs) (db.InputMapping, bool, bool, error)
+}
+
+type Pool interface {
+	CompatibleWorkers(ctx context.Context, spec worker.Spec) ([]db.Worker, error)
+}
+
 type Server struct {
 	logger lager.Logger
 
@@ -15,6 +26,8 @@ type Server struct {
 	secretManager creds.Secrets
 	jobFactory    db.JobFactory
 	checkFactory  db.CheckFactory
+	algorithm     Algorithm
+	workerPool    Pool
 }
 
 func NewServer(
@@ -23,6 +36,8 @@ func NewServer(
 	secretManager creds.Secrets,
 	jobFactory db.JobFactory,
 	checkFactory db.CheckFactory,
+	algorithm Algorithm,
+	workerPool Pool,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -31,5 +46,7 @@ func NewServer(
 		secretManager: secretManager,
 		jobFactory:    jobFactory,
 		checkFactory:  checkFactory,
+		algorithm:     algorithm,
+		workerPool:    workerPool,
 	}
 }
@@ -0,0 +1,65 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// how many of the job's most recent builds with test results are looked at
+// if the request doesn't say
+const defaultTestHistoryBuilds = 10
+
+func (s *Server) JobTestHistory(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("job-test-history")
+
+		jobName := r.FormValue(":job_name")
+
+		builds := defaultTestHistoryBuilds
+		if urlBuilds := r.FormValue(atc.TestHistoryQueryBuilds); urlBuilds != "" {
+			var err error
+			builds, err = strconv.Atoi(urlBuilds)
+			if err != nil || builds <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		history, err := job.TestHistory(builds)
+		if err != nil {
+			logger.Error("failed-to-get-test-history", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if history == nil {
+			history = []atc.TestHistory{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(history)
+		if err != nil {
+			logger.Error("failed-to-encode-test-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
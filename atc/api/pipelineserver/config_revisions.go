@@ -0,0 +1,138 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigRevisions(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-config-revisions", lager.Data{"pipeline": pipeline.Name()})
+
+		revisions, err := pipeline.ConfigRevisions()
+		if err != nil {
+			logger.Error("failed-to-get-config-revisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(revisions)
+		if err != nil {
+			logger.Error("failed-to-encode-config-revisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetConfigRevision(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-config-revision", lager.Data{"pipeline": pipeline.Name()})
+
+		version, err := strconv.Atoi(rata.Param(r, "version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revision, found, err := pipeline.ConfigRevision(db.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(revision)
+		if err != nil {
+			logger.Error("failed-to-encode-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// DiffConfigRevisions renders the changes made by a revision of the config,
+// compared to the revision given by the 'from' query param or, by default,
+// the revision before it.
+func (s *Server) DiffConfigRevisions(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("diff-config-revisions", lager.Data{"pipeline": pipeline.Name()})
+
+		version, err := strconv.Atoi(rata.Param(r, "version"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		to, found, err := pipeline.ConfigRevision(db.ConfigVersion(version))
+		if err != nil {
+			logger.Error("failed-to-get-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fromVersion := 0
+		if from := r.URL.Query().Get("from"); from != "" {
+			fromVersion, err = strconv.Atoi(from)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		} else {
+			revisions, err := pipeline.ConfigRevisions()
+			if err != nil {
+				logger.Error("failed-to-get-config-revisions", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			// revisions are newest first, so the previous one follows it
+			for i, revision := range revisions {
+				if revision.Version == version && i+1 < len(revisions) {
+					fromVersion = revisions[i+1].Version
+				}
+			}
+		}
+
+		// the first revision is compared to an empty config
+		fromConfig := atc.Config{}
+		if fromVersion != 0 {
+			from, found, err := pipeline.ConfigRevision(db.ConfigVersion(fromVersion))
+			if err != nil {
+				logger.Error("failed-to-get-config-revision", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			fromConfig = *from.Config
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+
+		if !fromConfig.Diff(w, *to.Config) {
+			fmt.Fprintln(w, "no changes")
+		}
+	})
+}
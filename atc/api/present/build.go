@@ -31,6 +31,7 @@ func Build(build db.BuildForAPI, job db.Job, access accessor.Access) atc.Build {
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
 		CreatedBy:            build.CreatedBy(),
+		TestSummary:          build.TestSummary(),
 	}
 
 	showComments := false
@@ -71,6 +71,8 @@ func Job(
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
 
+		SchedulingDiagnostics: job.SchedulingDiagnostics(),
+
 		Groups: job.Tags(),
 	}
 
@@ -78,5 +80,9 @@ func Job(
 		atcJob.PausedAt = job.PausedAt().Unix()
 	}
 
+	if !job.ScheduleNextFire().IsZero() {
+		atcJob.NextFireTime = job.ScheduleNextFire().Unix()
+	}
+
 	return atcJob
 }
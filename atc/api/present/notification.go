@@ -0,0 +1,33 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func NotificationDelivery(delivery db.NotificationDelivery) atc.NotificationDelivery {
+	return atc.NotificationDelivery{
+		ID:             delivery.ID(),
+		Notification:   delivery.Notification(),
+		URL:            delivery.URL(),
+		BuildID:        delivery.BuildID(),
+		Status:         delivery.Status(),
+		Attempts:       delivery.Attempts(),
+		ResponseStatus: delivery.ResponseStatus(),
+		LastError:      delivery.LastError(),
+		CreatedAt:      delivery.CreatedAt().Unix(),
+		UpdatedAt:      delivery.UpdatedAt().Unix(),
+	}
+}
+
+// Notifications leaves out the secrets of the notifications, which are only
+// needed to sign their deliveries.
+func Notifications(notifications atc.NotificationConfigs) atc.NotificationConfigs {
+	var presented atc.NotificationConfigs
+	for _, notification := range notifications {
+		notification.Secret = ""
+		presented = append(presented, notification)
+	}
+
+	return presented
+}
@@ -10,5 +10,10 @@ func Team(team db.Team) atc.Team {
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
+
+		Notifications:      Notifications(team.Notifications()),
+		CredentialManagers: team.CredentialManagers(),
+		Templates:          team.StepTemplates(),
+		PipelineSource:     team.PipelineSource(),
 	}
 }
@@ -1644,13 +1644,13 @@ var _ = Describe("Resources API", func() {
 
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
 		var (
-			checkRequestBody atc.CheckRequestBody
-			response         *http.Response
-			fakeResource     *dbfakes.FakeResource
+			reqBody      []byte
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
 		)
 
 		BeforeEach(func() {
-			checkRequestBody = atc.CheckRequestBody{}
+			reqBody = []byte(`{"from":null}`)
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("resource-name")
@@ -1658,12 +1658,10 @@ var _ = Describe("Resources API", func() {
 		})
 
 		JustBeforeEach(func() {
-			reqPayload, err := json.Marshal(checkRequestBody)
-			Expect(err).NotTo(HaveOccurred())
-
-			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqBody))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("X-Github-Event", "push")
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -1716,20 +1714,127 @@ var _ = Describe("Resources API", func() {
 						fakePipeline.ResourceTypesReturns(fakeResourceTypes, nil)
 					})
 
-					It("checks with a nil version", func() {
-						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
-						_, actualResource, actualResourceTypes, actualFromVersion, manuallyTriggered, skipIntervalRecursively, toDb := dbCheckFactory.TryCreateCheckArgsForCall(0)
+					It("checks without passing on anything about the webhook", func() {
+						Expect(dbCheckFactory.TryCreateWebhookCheckCallCount()).To(Equal(1))
+						_, actualResource, actualResourceTypes, webhook := dbCheckFactory.TryCreateWebhookCheckArgsForCall(0)
 						Expect(actualResource).To(Equal(fakeResource))
 						Expect(actualResourceTypes).To(Equal(fakeResourceTypes))
-						Expect(actualFromVersion).To(BeNil())
-						Expect(manuallyTriggered).To(BeTrue())
-						Expect(skipIntervalRecursively).To(BeFalse())
-						Expect(toDb).To(BeTrue())
+						Expect(webhook).To(BeNil())
+					})
+
+					Context("when the resource asks for parts of the webhook", func() {
+						BeforeEach(func() {
+							reqBody = []byte(`{"ref":"refs/heads/main","commits":[{"id":"abc"}],"sender":{"login":"someone"}}`)
+							fakeResource.ConfigReturns(atc.ResourceConfig{
+								WebhookHeaders: []string{"x-github-event", "X-Github-Delivery"},
+								WebhookPayload: atc.WebhookPayload{
+									"ref":     "/ref",
+									"commit":  "/commits/0/id",
+									"missing": "/pusher/name",
+								},
+							})
+						})
+
+						It("passes on only those parts", func() {
+							_, _, _, webhook := dbCheckFactory.TryCreateWebhookCheckArgsForCall(0)
+							Expect(webhook).ToNot(BeNil())
+							Expect(webhook.Headers).To(Equal(map[string][]string{
+								"X-Github-Event": {"push"},
+							}))
+							Expect(webhook.Payload).To(MatchJSON(`{"ref":"refs/heads/main","commit":"abc"}`))
+						})
+
+						Context("when the payload is not json", func() {
+							BeforeEach(func() {
+								reqBody = []byte("ref=abc")
+								fakeResource.ConfigReturns(atc.ResourceConfig{
+									WebhookPayload: atc.WebhookPayload{"body": ""},
+								})
+							})
+
+							It("passes the payload as a string", func() {
+								_, _, _, webhook := dbCheckFactory.TryCreateWebhookCheckArgsForCall(0)
+								Expect(webhook.Payload).To(MatchJSON(`{"body":"ref=abc"}`))
+							})
+						})
+					})
+
+					Context("when the payload is too large", func() {
+						BeforeEach(func() {
+							reqBody = bytes.Repeat([]byte("a"), 10*1024*1024+1)
+						})
+
+						It("returns 413 without checking", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+							Expect(dbCheckFactory.TryCreateWebhookCheckCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the resource maps the payload to a version", func() {
+						BeforeEach(func() {
+							reqBody = []byte(`{"after":"abc","number":42}`)
+							fakeResource.ConfigReturns(atc.ResourceConfig{
+								WebhookVersion: atc.WebhookVersion{
+									"ref":    "/after",
+									"number": "/number",
+								},
+							})
+						})
+
+						Context("when the version is saved", func() {
+							BeforeEach(func() {
+								fakeResource.SaveVersionsReturns(true, nil)
+							})
+
+							It("saves the version without checking", func() {
+								Expect(fakeResource.SaveVersionsCallCount()).To(Equal(1))
+								_, versions := fakeResource.SaveVersionsArgsForCall(0)
+								Expect(versions).To(Equal([]atc.Version{{"ref": "abc", "number": "42"}}))
+
+								Expect(dbCheckFactory.TryCreateWebhookCheckCallCount()).To(Equal(0))
+							})
+
+							It("returns 200 with the version", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(io.ReadAll(response.Body)).To(MatchJSON(`{"ref":"abc","number":"42"}`))
+							})
+						})
+
+						Context("when the resource has not been checked yet", func() {
+							BeforeEach(func() {
+								fakeResource.SaveVersionsReturns(false, nil)
+							})
+
+							It("checks with the webhook", func() {
+								Expect(dbCheckFactory.TryCreateWebhookCheckCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when saving the version fails", func() {
+							BeforeEach(func() {
+								fakeResource.SaveVersionsReturns(false, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when the payload does not contain the version", func() {
+							BeforeEach(func() {
+								reqBody = []byte(`{"zen":"keep it logically awesome"}`)
+							})
+
+							It("checks with the webhook", func() {
+								Expect(fakeResource.SaveVersionsCallCount()).To(Equal(0))
+								Expect(dbCheckFactory.TryCreateWebhookCheckCallCount()).To(Equal(1))
+							})
+						})
 					})
 
 					Context("when checking fails", func() {
 						BeforeEach(func() {
-							dbCheckFactory.TryCreateCheckReturns(nil, false, errors.New("nope"))
+							dbCheckFactory.TryCreateWebhookCheckReturns(nil, false, errors.New("nope"))
 						})
 
 						It("returns 500", func() {
@@ -1739,7 +1844,7 @@ var _ = Describe("Resources API", func() {
 
 					Context("when checking does not create a new check", func() {
 						BeforeEach(func() {
-							dbCheckFactory.TryCreateCheckReturns(nil, false, nil)
+							dbCheckFactory.TryCreateWebhookCheckReturns(nil, false, nil)
 						})
 
 						It("returns 500", func() {
@@ -1759,7 +1864,7 @@ var _ = Describe("Resources API", func() {
 							fakeBuild.StartTimeReturns(time.Date(2001, 01, 01, 0, 0, 0, 0, time.UTC))
 							fakeBuild.EndTimeReturns(time.Date(2002, 01, 01, 0, 0, 0, 0, time.UTC))
 
-							dbCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+							dbCheckFactory.TryCreateWebhookCheckReturns(fakeBuild, true, nil)
 						})
 
 						It("returns 201", func() {
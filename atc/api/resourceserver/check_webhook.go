@@ -3,10 +3,13 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -60,6 +63,44 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		payload, err := readWebhookPayload(w, r)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				logger.Info("webhook-payload-too-large", lager.Data{"limit": tooLarge.Limit})
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			logger.Error("failed-to-read-webhook-payload", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if version, ok := dbResource.Config().WebhookVersion.Version(payload); ok {
+			saved, err := dbResource.SaveVersions(db.NewSpanContext(r.Context()), []atc.Version{version})
+			if err != nil {
+				logger.Error("failed-to-save-webhook-version", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			// if the resource has not been checked yet, fall back on a check
+			if saved {
+				logger.Debug("saved-webhook-version", lager.Data{"version": version})
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+
+				err = json.NewEncoder(w).Encode(version)
+				if err != nil {
+					logger.Error("failed-to-encode-version", err)
+				}
+
+				return
+			}
+		}
+
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -67,14 +108,11 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, created, err := s.checkFactory.TryCreateCheck(
+		build, created, err := s.checkFactory.TryCreateWebhookCheck(
 			lagerctx.NewContext(context.Background(), logger),
 			dbResource,
 			dbResourceTypes,
-			nil,
-			true,
-			false,
-			true,
+			atc.NewWebhook(dbResource.Config(), r.Header, payload),
 		)
 		if err != nil {
 			logger.Error("failed-to-create-check", err)
@@ -98,3 +136,27 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+// maxWebhookPayloadSize is the largest body of a webhook request that is
+// accepted.
+const maxWebhookPayloadSize = 10 * 1024 * 1024
+
+// readWebhookPayload returns the body of the request if it is JSON, or
+// otherwise the body as a JSON string. A body that is too large is refused
+// with an *http.MaxBytesError rather than cut short.
+func readWebhookPayload(w http.ResponseWriter, r *http.Request) (json.RawMessage, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	if json.Valid(body) {
+		return body, nil
+	}
+
+	return json.Marshal(string(body))
+}
@@ -188,6 +188,33 @@ var _ = Describe("Teams API", func() {
 					}
 				}`))
 			})
+
+			Context("when the team has notifications", func() {
+				BeforeEach(func() {
+					fakeTeam.NotificationsReturns(atc.NotificationConfigs{
+						{
+							Name:     "chat",
+							URL:      "https://chat.example.com/hook",
+							Secret:   "some-secret",
+							Statuses: []atc.BuildStatus{atc.StatusFailed},
+						},
+					})
+				})
+
+				It("returns them without their secrets", func() {
+					var team atc.Team
+					err := json.NewDecoder(response.Body).Decode(&team)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(team.Notifications).To(Equal(atc.NotificationConfigs{
+						{
+							Name:     "chat",
+							URL:      "https://chat.example.com/hook",
+							Statuses: []atc.BuildStatus{atc.StatusFailed},
+						},
+					}))
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -263,6 +290,191 @@ var _ = Describe("Teams API", func() {
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("updates notifications", func() {
+					Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateNotificationsArgsForCall(0)).To(BeEmpty())
+				})
+
+				Context("when notifications are configured", func() {
+					BeforeEach(func() {
+						atcTeam.Notifications = atc.NotificationConfigs{
+							{Name: "chat", URL: "https://chat.example.com/hook", Secret: "some-secret"},
+						}
+					})
+
+					It("saves them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateNotificationsArgsForCall(0)).To(Equal(atcTeam.Notifications))
+					})
+				})
+
+				Context("when a notification is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Notifications = atc.NotificationConfigs{
+							{Name: "chat", URL: "not-a-url"},
+						}
+					})
+
+					It("does not update the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateNotificationsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when updating notifications fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateNotificationsReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when credential managers are configured", func() {
+					BeforeEach(func() {
+						atcTeam.CredentialManagers = []string{"vault", "credhub"}
+					})
+
+					It("saves them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateCredentialManagersCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateCredentialManagersArgsForCall(0)).To(Equal([]string{"vault", "credhub"}))
+					})
+				})
+
+				Context("when var sources are configured", func() {
+					BeforeEach(func() {
+						atcTeam.VarSources = atc.VarSourceConfigs{
+							{
+								Name: "vault",
+								Type: "vault",
+								Config: map[string]interface{}{
+									"url":          "https://vault.example.com",
+									"client_token": "some-token",
+								},
+							},
+						}
+					})
+
+					It("saves them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateVarSourcesCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateVarSourcesArgsForCall(0)).To(Equal(atcTeam.VarSources))
+					})
+				})
+
+				Context("when the var sources are invalid", func() {
+					BeforeEach(func() {
+						atcTeam.VarSources = atc.VarSourceConfigs{
+							{Name: "bogus", Type: "bogus"},
+						}
+					})
+
+					It("returns 400 Bad Request without saving them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(io.ReadAll(response.Body)).To(ContainSubstring("unknown credential manager type: bogus"))
+						Expect(fakeTeam.UpdateVarSourcesCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when templates are configured", func() {
+					BeforeEach(func() {
+						atcTeam.Templates = atc.StepTemplates{
+							{
+								Name: "notify",
+								Steps: []atc.Step{
+									{Config: &atc.PutStep{Name: "slack"}},
+								},
+							},
+						}
+					})
+
+					It("saves them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateStepTemplatesCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateStepTemplatesArgsForCall(0)).To(Equal(atcTeam.Templates))
+					})
+				})
+
+				Context("when the templates are invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Templates = atc.StepTemplates{{Name: "notify"}}
+					})
+
+					It("returns 400 Bad Request without saving them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(io.ReadAll(response.Body)).To(ContainSubstring("templates.notify has no steps"))
+						Expect(fakeTeam.UpdateStepTemplatesCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a pipeline source is configured", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineSource = &atc.PipelineSourceConfig{
+							URI: "https://example.com/pipelines.git",
+							Dir: "pipelines",
+						}
+					})
+
+					It("saves it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdatePipelineSourceCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdatePipelineSourceArgsForCall(0)).To(Equal(atcTeam.PipelineSource))
+					})
+				})
+
+				Context("when the pipeline source is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineSource = &atc.PipelineSourceConfig{
+							URI: "https://example.com/pipelines.git",
+							Dir: "../pipelines",
+						}
+					})
+
+					It("returns 400 Bad Request without saving it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(io.ReadAll(response.Body)).To(Equal([]byte("invalid pipeline_source: pipeline source dir must be within the repository")))
+						Expect(fakeTeam.UpdatePipelineSourceCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the pipeline source is a path on the web node", func() {
+					BeforeEach(func() {
+						atcTeam.PipelineSource = &atc.PipelineSourceConfig{
+							URI: "file:///etc/concourse",
+						}
+					})
+
+					It("returns 400 Bad Request without saving it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(io.ReadAll(response.Body)).To(Equal([]byte("invalid pipeline_source: pipeline source uri must be an https, ssh or git url")))
+						Expect(fakeTeam.UpdatePipelineSourceCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when updating the pipeline source fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdatePipelineSourceReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when updating credential managers fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateCredentialManagersReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
 						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
@@ -790,4 +1002,358 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications/deliveries", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications/deliveries" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when getting the deliveries succeeds", func() {
+				BeforeEach(func() {
+					fakeDelivery := new(dbfakes.FakeNotificationDelivery)
+					fakeDelivery.IDReturns(3)
+					fakeDelivery.NotificationReturns("chat")
+					fakeDelivery.URLReturns("https://chat.example.com/hook")
+					fakeDelivery.BuildIDReturns(42)
+					fakeDelivery.StatusReturns(atc.NotificationDeliveryPending)
+					fakeDelivery.AttemptsReturns(1)
+					fakeDelivery.ResponseStatusReturns(502)
+					fakeDelivery.LastErrorReturns("unexpected response 502 Bad Gateway")
+					fakeDelivery.CreatedAtReturns(time.Unix(100, 0))
+					fakeDelivery.UpdatedAtReturns(time.Unix(200, 0))
+
+					fakeTeam.NotificationDeliveriesReturns([]db.NotificationDelivery{fakeDelivery}, nil)
+				})
+
+				It("returns the deliveries", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response).Should(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/json",
+					}))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 3,
+							"notification": "chat",
+							"url": "https://chat.example.com/hook",
+							"build_id": 42,
+							"status": "pending",
+							"attempts": 1,
+							"response_status": 502,
+							"last_error": "unexpected response 502 Bad Gateway",
+							"created_at": 100,
+							"updated_at": 200
+						}
+					]`))
+				})
+
+				It("defaults the limit", func() {
+					Expect(fakeTeam.NotificationDeliveriesCallCount()).To(Equal(1))
+					Expect(fakeTeam.NotificationDeliveriesArgsForCall(0)).To(Equal(atc.PaginationAPIDefaultLimit))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						queryParams = "?limit=5"
+					})
+
+					It("passes it through", func() {
+						Expect(fakeTeam.NotificationDeliveriesArgsForCall(0)).To(Equal(5))
+					})
+				})
+			})
+
+			Context("when getting the deliveries fails", func() {
+				BeforeEach(func() {
+					fakeTeam.NotificationDeliveriesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/secrets-report", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/secrets-report")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			var fakePipeline *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakePipeline = new(dbfakes.FakePipeline)
+				fakePipeline.NameReturns("some-pipeline")
+				fakeTeam.PipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+			})
+
+			Context("when the reports are made", func() {
+				BeforeEach(func() {
+					fakePipeline.SecretsReportReturns(atc.PipelineSecretsReport{
+						PipelineID:   1,
+						PipelineName: "some-pipeline",
+						Secrets: []atc.SecretReference{
+							{
+								Name:     "password",
+								Lookups:  []atc.SecretLookup{{Manager: "vault", Path: "/concourse/some-team/some-pipeline/password"}},
+								Resolved: true,
+								Manager:  "vault",
+								Path:     "/concourse/some-team/some-pipeline/password",
+							},
+						},
+						UnusedVarSources: []string{"some-var-source"},
+					}, nil)
+				})
+
+				It("returns the report of each pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response).Should(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/json",
+					}))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"pipeline_id": 1,
+							"pipeline_name": "some-pipeline",
+							"secrets": [
+								{
+									"name": "password",
+									"lookups": [{"manager": "vault", "path": "/concourse/some-team/some-pipeline/password"}],
+									"resolved": true,
+									"manager": "vault",
+									"path": "/concourse/some-team/some-pipeline/password"
+								}
+							],
+							"unused_var_sources": ["some-var-source"]
+						}
+					]`))
+				})
+
+				It("looks up the secrets with the global secrets and var source pool", func() {
+					Expect(fakePipeline.SecretsReportCallCount()).To(Equal(1))
+					_, secrets, pool := fakePipeline.SecretsReportArgsForCall(0)
+					Expect(secrets).To(Equal(fakeSecretManager))
+					Expect(pool).To(Equal(fakeVarSourcePool))
+				})
+			})
+
+			Context("when making a report fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SecretsReportReturns(atc.PipelineSecretsReport{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the pipelines fails", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelinesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipeline-source", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipeline-source")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when the team has no pipeline source", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineSourceReturns(nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the team has a pipeline source", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineSourceReturns(&atc.PipelineSourceConfig{
+						URI: "https://example.com/pipelines.git",
+					})
+				})
+
+				Context("when its pipelines have been synced", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineSourceStatusReturns(atc.PipelineSourceStatus{
+							Source:   atc.PipelineSourceConfig{URI: "https://example.com/pipelines.git"},
+							Commit:   "abc123",
+							SyncedAt: 1000,
+							Pipelines: []atc.ManagedPipelineStatus{
+								{Name: "some-pipeline", Path: "some-pipeline.yml"},
+								{Name: "broken", Path: "broken.yml", Drifted: true, Error: "invalid config: oops"},
+							},
+						}, true, nil)
+					})
+
+					It("returns the status of the latest sync", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).Should(IncludeHeaderEntries(map[string]string{
+							"Content-Type": "application/json",
+						}))
+
+						body, err := io.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"source": {"uri": "https://example.com/pipelines.git"},
+							"commit": "abc123",
+							"synced_at": 1000,
+							"pipelines": [
+								{"name": "some-pipeline", "path": "some-pipeline.yml", "drifted": false},
+								{"name": "broken", "path": "broken.yml", "drifted": true, "error": "invalid config: oops"}
+							]
+						}`))
+					})
+				})
+
+				Context("when its pipelines have not been synced yet", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineSourceStatusReturns(atc.PipelineSourceStatus{}, false, nil)
+					})
+
+					It("returns the source without any pipelines", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := io.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"source": {"uri": "https://example.com/pipelines.git"},
+							"pipelines": []
+						}`))
+					})
+				})
+
+				Context("when getting the status fails", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineSourceStatusReturns(atc.PipelineSourceStatus{}, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
 })
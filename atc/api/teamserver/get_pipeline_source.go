@@ -0,0 +1,44 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetPipelineSource(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-pipeline-source", lager.Data{"team": team.Name()})
+
+		source := team.PipelineSource()
+		if source == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status, found, err := team.PipelineSourceStatus()
+		if err != nil {
+			logger.Error("failed-to-get-pipeline-source-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			// the pipelines have not been synced yet
+			status = atc.PipelineSourceStatus{
+				Source:    *source,
+				Pipelines: []atc.ManagedPipelineStatus{},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(status)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-source-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
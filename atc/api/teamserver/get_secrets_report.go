@@ -0,0 +1,42 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetSecretsReport(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-secrets-report", lager.Data{"team": team.Name()})
+
+		pipelines, err := team.Pipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		reports := []atc.PipelineSecretsReport{}
+		for _, pipeline := range pipelines {
+			report, err := pipeline.SecretsReport(logger, s.secretManager, s.varSourcePool)
+			if err != nil {
+				logger.Error("failed-to-get-pipeline-secrets-report", err, lager.Data{"pipeline": pipeline.Name()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			reports = append(reports, report)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(reports)
+		if err != nil {
+			logger.Error("failed-to-encode-secrets-report", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListNotificationDeliveries(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-notification-deliveries")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		deliveries, err := team.NotificationDeliveries(limit)
+		if err != nil {
+			logger.Error("failed-to-get-notification-deliveries", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.NotificationDelivery{}
+		for _, delivery := range deliveries {
+			presented = append(presented, present.NotificationDelivery(delivery))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-notification-deliveries", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -2,23 +2,30 @@ package teamserver
 
 import (
 	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
-	externalURL string
+	logger        lager.Logger
+	teamFactory   db.TeamFactory
+	externalURL   string
+	secretManager creds.Secrets
+	varSourcePool creds.VarSourcePool
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	externalURL string,
+	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:        logger,
+		teamFactory:   teamFactory,
+		externalURL:   externalURL,
+		secretManager: secretManager,
+		varSourcePool: varSourcePool,
 	}
 }
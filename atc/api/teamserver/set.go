@@ -2,6 +2,7 @@ package teamserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/v3"
@@ -9,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/configvalidate"
 )
 
 type SetTeamResponse struct {
@@ -40,6 +42,29 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, err := configvalidate.ValidateVarSources(atcTeam.VarSources); err != nil {
+		hLog.Error("invalid-var-sources", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid var_sources: %s", err)
+		return
+	}
+
+	if _, err := configvalidate.ValidateStepTemplates(atcTeam.Templates); err != nil {
+		hLog.Error("invalid-templates", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid templates: %s", err)
+		return
+	}
+
+	if atcTeam.PipelineSource != nil {
+		if err := atcTeam.PipelineSource.Validate(); err != nil {
+			hLog.Error("invalid-pipeline-source", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid pipeline_source: %s", err)
+			return
+		}
+	}
+
 	atcTeam.Name = teamName
 
 	team, found, err := s.teamFactory.FindTeam(teamName)
@@ -59,6 +84,41 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		err = team.UpdateNotifications(atcTeam.Notifications)
+		if err != nil {
+			hLog.Error("failed-to-update-team-notifications", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = team.UpdateCredentialManagers(atcTeam.CredentialManagers)
+		if err != nil {
+			hLog.Error("failed-to-update-team-credential-managers", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = team.UpdateVarSources(atcTeam.VarSources)
+		if err != nil {
+			hLog.Error("failed-to-update-team-var-sources", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = team.UpdateStepTemplates(atcTeam.Templates)
+		if err != nil {
+			hLog.Error("failed-to-update-team-templates", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = team.UpdatePipelineSource(atcTeam.PipelineSource)
+		if err != nil {
+			hLog.Error("failed-to-update-team-pipeline-source", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 	} else if acc.IsAdmin() {
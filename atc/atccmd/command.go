@@ -13,12 +13,17 @@ import (
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api"
@@ -29,6 +34,7 @@ import (
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/buildlog"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/compression"
@@ -42,13 +48,16 @@ import (
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/pauser"
+	"github.com/concourse/concourse/atc/pipelinesource"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal/dexserver"
 	"github.com/concourse/concourse/skymarshal/legacyserver"
@@ -73,6 +82,11 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
@@ -86,6 +100,7 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/dummy"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
+	_ "github.com/concourse/concourse/atc/creds/sops"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
 )
@@ -153,6 +168,9 @@ type RunCommand struct {
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
 	PausePipelinesAfter                 int           `long:"pause-pipelines-after" default:"0" description:"The number of days after which a pipeline will be automatically paused if none of its jobs have run in more than the given number of days. A value of zero disables this component."`
 	PipelinePauserInterval              time.Duration `long:"pipeline-pauser-interval" default:"24h" hidden:"true" description:"The frequency on which the Pipeline Pauser component will be run to check if any pipelines need to be paused."`
+	PipelineSourceSyncInterval          time.Duration `long:"pipeline-source-sync-interval" default:"1m" description:"Interval on which to set the pipelines of teams with a pipeline source from their git repositories."`
+	PipelineSourceGitProtocols          []string      `long:"pipeline-source-git-protocol" default:"https" default:"ssh" default:"git" description:"Protocol over which git may clone the repositories of pipeline sources. Can be specified multiple times."`
+	PipelineSourceGitTimeout            time.Duration `long:"pipeline-source-git-timeout" default:"5m" description:"Timeout for each git command run to set the pipelines of a pipeline source."`
 
 	ContainerPlacementStrategyOptions worker.PlacementOptions `group:"Container Placement Strategy"`
 
@@ -198,6 +216,10 @@ type RunCommand struct {
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
 		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+
+		PublishedArtifactRetention time.Duration `long:"published-artifact-retention" default:"168h" description:"Period after which artifacts published by builds will be garbage collected. 0 means they are kept until their build is deleted."`
+
+		SecretAccessRetention time.Duration `long:"secret-access-retention" default:"2160h" description:"Period after which recorded secret accesses will be garbage collected. 0 means they are kept forever."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -225,7 +247,49 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
-	}
+
+		EnableSecretAccessAuditLog bool `long:"enable-secret-access-auditing" description:"Enable auditing of each secret read by a build. The build, step, var and secret path are written to the audit log and recorded in the database, but never the secret's value."`
+	}
+
+	BuildLogStore struct {
+		Dir              flag.Dir      `long:"dir" description:"Directory in which to keep the logs of finished builds instead of the database, e.g. one on a volume shared by all web nodes. If neither this nor --build-log-store-s3-bucket is set, build logs are only kept in the database."`
+		Compression      string        `long:"compression" default:"zstd" choice:"gzip" choice:"zstd" choice:"raw" description:"Compression algorithm for build logs in the store."`
+		ArchiveAfter     time.Duration `long:"archive-after" default:"10m" description:"Period after a build finishes before its logs are moved out of the database and into the store."`
+		ArchiveInterval  time.Duration `long:"archive-interval" default:"30s" description:"Interval on which to move the logs of finished builds into the store."`
+		ArchiveBatchSize int           `long:"archive-batch-size" default:"500" description:"Maximum number of builds whose logs are moved into the store on each interval."`
+
+		S3Bucket          string `long:"s3-bucket" description:"Bucket in which to keep the logs of finished builds instead of the database. Any object store with an S3-compatible API can be used, see --build-log-store-s3-endpoint."`
+		S3Prefix          string `long:"s3-prefix" description:"Prefix of the keys of the objects in the bucket, e.g. 'concourse/'."`
+		S3Region          string `long:"s3-region" description:"AWS region of the bucket."`
+		S3Endpoint        string `long:"s3-endpoint" description:"URL of the S3-compatible API to use instead of AWS, e.g. that of a MinIO server."`
+		S3ForcePathStyle  bool   `long:"s3-force-path-style" description:"Address the bucket in the path of requests rather than in the host name, as required by most S3-compatible object stores."`
+		S3AccessKeyID     string `long:"s3-access-key" description:"AWS access key ID. If not set, the credentials are taken from the environment or the instance profile."`
+		S3SecretAccessKey string `long:"s3-secret-key" description:"AWS secret access key."`
+	} `group:"Build Log Store" namespace:"build-log-store"`
+
+	Notifications struct {
+		Interval      time.Duration `long:"interval" default:"10s" description:"Interval on which to deliver the build notifications configured by teams."`
+		MaxAttempts   int           `long:"max-attempts" default:"5" description:"Number of times to attempt a notification delivery before moving it to the dead letters."`
+		RetryInterval time.Duration `long:"retry-interval" default:"30s" description:"Time to wait before retrying a failed notification delivery. Doubles with each attempt."`
+		Timeout       time.Duration `long:"timeout" default:"10s" description:"Timeout for each notification delivery."`
+
+		AllowedNetworks []notifications.Network `long:"allowed-network" description:"Network in CIDR notation to allow notifications to be delivered to even if it is denied, e.g. that of an internal chat server. Can be specified multiple times."`
+		DeniedNetworks  []notifications.Network `long:"denied-network" description:"Network in CIDR notation to deny notifications to be delivered to, in addition to the loopback, link-local and private networks. Can be specified multiple times."`
+	} `group:"Build Notifications" namespace:"notifications"`
+
+	KubernetesWorker struct {
+		Name          string            `long:"name" description:"Name of the worker as which to register a Kubernetes cluster, in which step containers are run as pods. If not set, no cluster is registered."`
+		InCluster     bool              `long:"in-cluster" description:"Use the service account of the web node's pod to talk to the cluster."`
+		ConfigPath    string            `long:"config-path" description:"Path to a kubeconfig file for the cluster, when running outside of it."`
+		Namespace     string            `long:"namespace" default:"concourse-workloads" description:"Namespace in which to create pods and persistent volume claims."`
+		Tags          []string          `long:"tag" description:"A tag to set on the worker. Can be specified multiple times."`
+		ResourceTypes map[string]string `long:"resource-type" value-name:"TYPE:IMAGE" description:"A resource type provided by the worker, with the image reference to run it with. Can be specified multiple times."`
+
+		StorageClass string `long:"storage-class" description:"Storage class of the persistent volume claims backing volumes. If not set, the cluster's default storage class is used."`
+		VolumeSize   string `long:"volume-size" default:"1Gi" description:"Storage requested by each persistent volume claim."`
+		AccessMode   string `long:"access-mode" default:"ReadWriteOnce" choice:"ReadWriteOnce" choice:"ReadWriteMany" choice:"ReadWriteOncePod" description:"Access mode of the persistent volume claims. ReadWriteMany is preferable if the storage class supports it, as volumes are mounted by the pods of multiple steps."`
+		HelperImage  string `long:"helper-image" default:"busybox" description:"Image used to copy volumes and to read image references from image artifacts. It must provide sh, cat and cp."`
+	} `group:"Kubernetes Worker" namespace:"kubernetes-worker"`
 
 	Syslog struct {
 		Hostname      string        `long:"syslog-hostname" description:"Client hostname with which the build logs will be sent to the syslog server." default:"atc-syslog-drainer"`
@@ -729,7 +793,27 @@ func (cmd *RunCommand) constructMembers(
 		return nil, err
 	}
 
-	backendComponents, err := cmd.backendComponents(logger, backendConn, lockFactory, secretManager, policyChecker, workerCache, checkBuildsChan)
+	// the accesses are recorded by every web node, as they're buffered in
+	// memory by the auditor until then
+	var secretAccessSink creds.SecretAccessSink
+	var secretAccessMembers []grouper.Member
+	if cmd.Auditor.EnableSecretAccessAuditLog {
+		secretAccessAuditor := auditor.NewSecretAccessAuditor(
+			logger.Session("secret-access-auditor"),
+			db.NewSecretAccessLog(backendConn),
+			clock.NewClock(),
+			5*time.Second,
+			100,
+		)
+
+		secretAccessSink = secretAccessAuditor
+		secretAccessMembers = append(secretAccessMembers, grouper.Member{
+			Name:   "secret-access-auditor",
+			Runner: secretAccessAuditor,
+		})
+	}
+
+	backendComponents, err := cmd.backendComponents(logger, backendConn, lockFactory, secretManager, policyChecker, workerCache, checkBuildsChan, secretAccessSink)
 	if err != nil {
 		return nil, err
 	}
@@ -749,7 +833,7 @@ func (cmd *RunCommand) constructMembers(
 		db.RealGoroutineCounter{})
 	bus := backendConn.Bus()
 
-	members := apiMembers
+	members := append(apiMembers, secretAccessMembers...)
 	components := append(backendComponents, gcComponents...)
 	for _, c := range components {
 		dbComponent, err := componentFactory.CreateOrUpdate(c.Component)
@@ -886,6 +970,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbResourceConfigFactory,
 		userFactory,
 		pool,
+		algorithm.New(db.NewVersionsDB(dbConn, algorithmLimitRows, schedulerCache)),
 		secretManager,
 		credsManagers,
 		accessFactory,
@@ -1021,6 +1106,7 @@ func (cmd *RunCommand) backendComponents(
 	policyChecker policy.Checker,
 	workerCache *db.WorkerCache,
 	checkBuildsChan chan db.Build,
+	secretAccessSink creds.SecretAccessSink,
 ) ([]RunnableComponent, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
@@ -1036,6 +1122,7 @@ func (cmd *RunCommand) backendComponents(
 
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
+	dbTaskCacheFactory := db.NewTaskCacheFactory(dbConn)
 
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, checkBuildsChan, util.NewSequenceGenerator(1))
@@ -1079,7 +1166,9 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		dbTaskCacheFactory,
 		secretManager,
+		secretAccessSink,
 		defaultLimits,
 		buildContainerStrategy,
 		noInputBuildContainerStrategy,
@@ -1187,6 +1276,74 @@ func (cmd *RunCommand) backendComponents(
 			},
 			Runnable: buildEventWatcher,
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentNotifier,
+				Interval: cmd.Notifications.Interval,
+			},
+			Runnable: notifications.NewNotifier(
+				db.NewNotificationDeliveryFactory(dbConn),
+				notifications.NewAddressPolicy(
+					cmd.Notifications.AllowedNetworks,
+					cmd.Notifications.DeniedNetworks,
+				).Client(cmd.Notifications.Timeout),
+				clock.NewClock(),
+				cmd.ExternalURL.String(),
+				cmd.Notifications.MaxAttempts,
+				cmd.Notifications.RetryInterval,
+				100,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentPipelineSourceSyncer,
+				Interval: cmd.PipelineSourceSyncInterval,
+			},
+			Runnable: pipelinesource.NewSyncer(
+				teamFactory,
+				clock.NewClock(),
+				os.TempDir(),
+				cmd.PipelineSourceGitProtocols,
+				cmd.PipelineSourceGitTimeout,
+			),
+		},
+	}
+
+	if cmd.buildLogStoreEnabled() {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildLogArchiver,
+				Interval: cmd.BuildLogStore.ArchiveInterval,
+			},
+			Runnable: gc.NewBuildLogArchiver(
+				dbBuildFactory,
+				cmd.BuildLogStore.ArchiveAfter,
+				cmd.BuildLogStore.ArchiveBatchSize,
+			),
+		})
+	}
+
+	if cmd.KubernetesWorker.Name != "" {
+		client, err := cmd.kubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentKubernetesWorker,
+				Interval: 10 * time.Second,
+			},
+			Runnable: k8sruntime.NewBeacon(
+				cmd.kubernetesWorker(),
+				client,
+				cmd.KubernetesWorker.Namespace,
+				30*time.Second,
+				db.NewWorkerFactory(dbConn, workerCache),
+				db.NewContainerRepository(dbConn),
+				db.NewVolumeRepository(dbConn),
+			),
+		})
 	}
 
 	if syslogDrainConfigured {
@@ -1218,6 +1375,106 @@ func (cmd *RunCommand) compression() compression.Compression {
 	}
 }
 
+func (cmd *RunCommand) buildLogStoreEnabled() bool {
+	return cmd.BuildLogStore.Dir != "" || cmd.BuildLogStore.S3Bucket != ""
+}
+
+func (cmd *RunCommand) buildLogStore() (db.BuildLogStore, error) {
+	var comp compression.Compression
+	if cmd.BuildLogStore.Compression == "gzip" {
+		comp = compression.NewGzipCompression()
+	} else if cmd.BuildLogStore.Compression == "raw" {
+		comp = compression.NewNoCompression()
+	} else {
+		comp = compression.NewZstdCompression()
+	}
+
+	if cmd.BuildLogStore.S3Bucket == "" {
+		return buildlog.NewFileStore(cmd.BuildLogStore.Dir.Path(), comp), nil
+	}
+
+	config := &aws.Config{
+		Region:           aws.String(cmd.BuildLogStore.S3Region),
+		S3ForcePathStyle: aws.Bool(cmd.BuildLogStore.S3ForcePathStyle),
+	}
+	if cmd.BuildLogStore.S3Endpoint != "" {
+		config.Endpoint = aws.String(cmd.BuildLogStore.S3Endpoint)
+	}
+	if cmd.BuildLogStore.S3AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(cmd.BuildLogStore.S3AccessKeyID, cmd.BuildLogStore.S3SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildlog.NewS3Store(s3.New(sess), cmd.BuildLogStore.S3Bucket, cmd.BuildLogStore.S3Prefix, comp), nil
+}
+
+func (cmd *RunCommand) kubernetesClient() (kubernetes.Interface, error) {
+	var config *rest.Config
+	var err error
+	if cmd.KubernetesWorker.InCluster {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", cmd.KubernetesWorker.ConfigPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes worker: %w", err)
+	}
+
+	config.QPS = 100
+	config.Burst = 100
+
+	return kubernetes.NewForConfig(config)
+}
+
+// kubernetesWorker is the worker as which the cluster is registered.
+func (cmd *RunCommand) kubernetesWorker() atc.Worker {
+	resourceTypes := []atc.WorkerResourceType{}
+	for name, image := range cmd.KubernetesWorker.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.WorkerResourceType{
+			Type:  name,
+			Image: image,
+		})
+	}
+
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		return resourceTypes[i].Type < resourceTypes[j].Type
+	})
+
+	return atc.Worker{
+		Name:          cmd.KubernetesWorker.Name,
+		Tags:          cmd.KubernetesWorker.Tags,
+		ResourceTypes: resourceTypes,
+	}
+}
+
+func (cmd *RunCommand) kubernetesWorkerRuntime() (*worker.KubernetesWorker, error) {
+	client, err := cmd.kubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeSize, err := resource.ParseQuantity(cmd.KubernetesWorker.VolumeSize)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes worker: invalid volume size: %w", err)
+	}
+
+	return &worker.KubernetesWorker{
+		Name:   cmd.KubernetesWorker.Name,
+		Client: client,
+		Config: k8sruntime.Config{
+			Namespace:        cmd.KubernetesWorker.Namespace,
+			StorageClass:     cmd.KubernetesWorker.StorageClass,
+			VolumeSize:       volumeSize,
+			VolumeAccessMode: corev1.PersistentVolumeAccessMode(cmd.KubernetesWorker.AccessMode),
+			HelperImage:      cmd.KubernetesWorker.HelperImage,
+		},
+	}, nil
+}
+
 func (cmd *RunCommand) streamer(cacheFactory db.ResourceCacheFactory) worker.Streamer {
 	return worker.NewStreamer(cacheFactory,
 		cmd.compression(),
@@ -1254,6 +1511,14 @@ func (cmd *RunCommand) constructPool(dbConn db.Conn, lockFactory lock.LockFactor
 		lockFactory,
 	)
 
+	var kubernetesWorker *worker.KubernetesWorker
+	if cmd.KubernetesWorker.Name != "" {
+		kubernetesWorker, err = cmd.kubernetesWorkerRuntime()
+		if err != nil {
+			return worker.Pool{}, err
+		}
+	}
+
 	return worker.NewPool(
 		worker.DefaultFactory{
 			DB:                                db,
@@ -1261,6 +1526,7 @@ func (cmd *RunCommand) constructPool(dbConn db.Conn, lockFactory lock.LockFactor
 			BaggageclaimResponseHeaderTimeout: cmd.BaggageclaimResponseHeaderTimeout,
 			HTTPRetryTimeout:                  5 * time.Minute,
 			Streamer:                          cmd.streamer(dbResourceCacheFactory),
+			Kubernetes:                        kubernetesWorker,
 		},
 		db,
 		workerVersion,
@@ -1283,6 +1549,7 @@ func (cmd *RunCommand) gcComponents(
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
+	dbSecretAccessLog := db.NewSecretAccessLog(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
 
@@ -1302,13 +1569,14 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorResourceCaches:    gc.NewResourceCacheCollector(dbResourceCacheLifecycle),
 		atc.ComponentCollectorTaskCaches:        gc.NewTaskCacheCollector(dbTaskCacheLifecycle),
 		atc.ComponentCollectorResourceCacheUses: gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
-		atc.ComponentCollectorArtifacts:         gc.NewArtifactCollector(dbArtifactLifecycle),
+		atc.ComponentCollectorArtifacts:         gc.NewArtifactCollector(dbArtifactLifecycle, cmd.GC.PublishedArtifactRetention),
 		atc.ComponentCollectorVolumes:           gc.NewVolumeCollector(dbVolumeRepository, cmd.GC.MissingGracePeriod),
 		atc.ComponentCollectorContainers:        gc.NewContainerCollector(dbContainerRepository, cmd.GC.MissingGracePeriod, cmd.GC.HijackGracePeriod),
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
 		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorSecretAccesses:    gc.NewSecretAccessCollector(dbSecretAccessLog, cmd.GC.SecretAccessRetention),
 	}
 
 	var components []RunnableComponent
@@ -1393,10 +1661,37 @@ func workerVersion() (version.Version, error) {
 }
 
 func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
-	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
-	for name, manager := range cmd.CredentialManagers {
+	order := cmd.CredentialManagement.Order
+	if len(order) == 0 {
+		// without an order, only the first configured credential manager is
+		// used
+		for name, manager := range cmd.CredentialManagers {
+			if manager.IsConfigured() {
+				order = []string{name}
+				break
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		return cmd.CredentialManagement.NewSecrets(noop.NewNoopFactory()), nil
+	}
+
+	return cmd.chainedSecretManager(logger, order)
+}
+
+// chainedSecretManager looks up credentials in each of the credential managers
+// given by name, in order.
+func (cmd *RunCommand) chainedSecretManager(logger lager.Logger, order []string) (creds.Secrets, error) {
+	chain := creds.SecretsChain{}
+	for _, name := range order {
+		manager, found := cmd.CredentialManagers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s'", name)
+		}
+
 		if !manager.IsConfigured() {
-			continue
+			return nil, fmt.Errorf("credential manager '%s' is not configured", name)
 		}
 
 		credsLogger := logger.Session("credential-manager", lager.Data{
@@ -1415,15 +1710,18 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
 		}
 
-		secretsFactory, err = manager.NewSecretsFactory(credsLogger)
+		secretsFactory, err := manager.NewSecretsFactory(credsLogger)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		chain = append(chain, creds.NamedSecrets{
+			Name:    name,
+			Secrets: cmd.CredentialManagement.NewSecrets(secretsFactory),
+		})
 	}
 
-	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
+	return chain, nil
 }
 
 func (cmd *RunCommand) newKey() *encryption.Key {
@@ -1648,6 +1946,13 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	if cmd.BuildLogStore.Dir != "" && cmd.BuildLogStore.S3Bucket != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("cannot specify both --build-log-store-dir and --build-log-store-s3-bucket"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1694,6 +1999,15 @@ func (cmd *RunCommand) constructDBConn(
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	if cmd.buildLogStoreEnabled() {
+		store, err := cmd.buildLogStore()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure build log store: %w", err)
+		}
+
+		dbConn = db.WithBuildLogStore(dbConn, store)
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConns)
 	dbConn.SetMaxIdleConns(idleConns)
@@ -1756,7 +2070,9 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskCacheFactory db.TaskCacheFactory,
 	secretManager creds.Secrets,
+	secretAccessSink creds.SecretAccessSink,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.PlacementStrategy,
 	noInputStrategy worker.PlacementStrategy,
@@ -1773,8 +2089,10 @@ func (cmd *RunCommand) constructEngine(
 				lockFactory,
 				teamFactory,
 				buildFactory,
+				secretManager,
 				resourceCacheFactory,
 				resourceConfigFactory,
+				taskCacheFactory,
 				defaultLimits,
 				strategy,
 				noInputStrategy,
@@ -1792,6 +2110,7 @@ func (cmd *RunCommand) constructEngine(
 		),
 		secretManager,
 		cmd.varSourcePool,
+		secretAccessSink,
 	)
 }
 
@@ -1961,6 +2280,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
 	workerPool worker.Pool,
+	alg *algorithm.Algorithm,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
@@ -2040,6 +2360,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		buildserver.NewEventHandler,
 
 		workerPool,
+		alg,
 
 		reconfigurableSink,
 
@@ -61,6 +61,8 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.SetBuildComment,
+		atc.ApproveBuild,
+		atc.RejectBuild,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
@@ -70,7 +72,9 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.GetBuildArtifact,
+		atc.GetBuildTestResults:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -84,6 +88,8 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.ListJobs,
 		atc.ListJobBuilds,
 		atc.ListJobInputs,
+		atc.PreviewJobBuild,
+		atc.JobTestHistory,
 		atc.GetJobBuild,
 		atc.PauseJob,
 		atc.UnpauseJob,
@@ -133,6 +139,9 @@ func (a *auditor) ValidateAction(action string) bool {
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
+		atc.ListConfigRevisions,
+		atc.GetConfigRevision,
+		atc.DiffConfigRevisions,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -152,6 +161,9 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListNotificationDeliveries,
+		atc.GetSecretsReport,
+		atc.GetPipelineSource,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -0,0 +1,159 @@
+package auditor
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// how long an access is remembered for, so that the same step reading the
+// same secret again isn't audited again. It only needs to outlast most steps,
+// and keeps the accesses of every build from being remembered forever.
+const secretAccessDedupeWindow = time.Hour
+
+// SecretAccessAuditor writes each secret read by a build to the audit log,
+// and records it in the database so that it can be queried later.
+//
+// A step often reads the same secret many times, e.g. for each var that
+// refers to it or on every retry, so only its first read of each secret is
+// audited. The accesses are recorded in batches rather than as they happen,
+// so that reading a secret never waits on the database: every flushInterval,
+// or as soon as batchSize of them are pending.
+//
+// It is an ifrit.Runner, which records the pending accesses once more when it
+// is signalled to stop.
+type SecretAccessAuditor struct {
+	logger        lager.Logger
+	accessLog     db.SecretAccessLog
+	clock         clock.Clock
+	flushInterval time.Duration
+	batchSize     int
+
+	lock    sync.Mutex
+	pending []creds.SecretAccess
+	seen    map[secretAccessKey]time.Time
+
+	full chan struct{}
+}
+
+type secretAccessKey struct {
+	buildID int
+	step    string
+	manager string
+	path    string
+}
+
+func NewSecretAccessAuditor(
+	logger lager.Logger,
+	accessLog db.SecretAccessLog,
+	clock clock.Clock,
+	flushInterval time.Duration,
+	batchSize int,
+) *SecretAccessAuditor {
+	return &SecretAccessAuditor{
+		logger:        logger,
+		accessLog:     accessLog,
+		clock:         clock,
+		flushInterval: flushInterval,
+		batchSize:     batchSize,
+
+		seen: map[secretAccessKey]time.Time{},
+		full: make(chan struct{}, 1),
+	}
+}
+
+func (a *SecretAccessAuditor) SecretAccessed(access creds.SecretAccess) {
+	key := secretAccessKey{
+		buildID: access.BuildID,
+		step:    access.StepName,
+		manager: access.Manager,
+		path:    access.Path,
+	}
+
+	a.lock.Lock()
+
+	if seenAt, seen := a.seen[key]; seen && a.clock.Since(seenAt) <= secretAccessDedupeWindow {
+		a.lock.Unlock()
+		return
+	}
+
+	a.seen[key] = a.clock.Now()
+	a.pending = append(a.pending, access)
+	full := len(a.pending) >= a.batchSize
+
+	a.lock.Unlock()
+
+	a.logger.Info("audit", lager.Data{
+		"action":   "SecretAccess",
+		"team":     access.TeamName,
+		"pipeline": access.PipelineName,
+		"job":      access.JobName,
+		"build_id": access.BuildID,
+		"build":    access.BuildName,
+		"step":     access.StepName,
+		"var":      access.VarName,
+		"manager":  access.Manager,
+		"path":     access.Path,
+	})
+
+	if full {
+		select {
+		case a.full <- struct{}{}:
+		default:
+			// a flush is already due
+		}
+	}
+}
+
+func (a *SecretAccessAuditor) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ticker := a.clock.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	for {
+		select {
+		case <-ticker.C():
+			a.flush()
+
+		case <-a.full:
+			a.flush()
+
+		case <-signals:
+			a.flush()
+			return nil
+		}
+	}
+}
+
+func (a *SecretAccessAuditor) flush() {
+	a.lock.Lock()
+
+	pending := a.pending
+	a.pending = nil
+
+	for key, seenAt := range a.seen {
+		if a.clock.Since(seenAt) > secretAccessDedupeWindow {
+			delete(a.seen, key)
+		}
+	}
+
+	a.lock.Unlock()
+
+	for len(pending) > 0 {
+		batch := pending[:min(len(pending), a.batchSize)]
+		pending = pending[len(batch):]
+
+		err := a.accessLog.Record(batch...)
+		if err != nil {
+			a.logger.Error("failed-to-record-secret-accesses", err, lager.Data{
+				"accesses": len(batch),
+			})
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package auditor_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessAuditor", func() {
+	var (
+		logger        *lagertest.TestLogger
+		fakeAccessLog *dbfakes.FakeSecretAccessLog
+		fakeClock     *fakeclock.FakeClock
+		access        creds.SecretAccess
+
+		secretAccessAuditor *auditor.SecretAccessAuditor
+		process             ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAccessLog = new(dbfakes.FakeSecretAccessLog)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+		access = creds.SecretAccess{
+			TeamName:  "main",
+			BuildID:   42,
+			BuildName: "1",
+			StepName:  "some-task",
+			VarName:   "some-var",
+			Manager:   "vault",
+			Path:      "/concourse/main/some-var",
+		}
+
+		secretAccessAuditor = auditor.NewSecretAccessAuditor(logger, fakeAccessLog, fakeClock, time.Second, 3)
+		process = ifrit.Invoke(secretAccessAuditor)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	recorded := func() []creds.SecretAccess {
+		var accesses []creds.SecretAccess
+		for i := 0; i < fakeAccessLog.RecordCallCount(); i++ {
+			accesses = append(accesses, fakeAccessLog.RecordArgsForCall(i)...)
+		}
+
+		return accesses
+	}
+
+	It("writes the access to the audit log", func() {
+		secretAccessAuditor.SecretAccessed(access)
+
+		logs := logger.Logs()
+		Expect(logs).To(HaveLen(1))
+		Expect(logs[0].Message).To(Equal("test.audit"))
+		Expect(logs[0].Data).To(HaveKeyWithValue("action", "SecretAccess"))
+		Expect(logs[0].Data).To(HaveKeyWithValue("step", "some-task"))
+		Expect(logs[0].Data).To(HaveKeyWithValue("var", "some-var"))
+		Expect(logs[0].Data).To(HaveKeyWithValue("path", "/concourse/main/some-var"))
+	})
+
+	It("records the access in the database on the next flush", func() {
+		secretAccessAuditor.SecretAccessed(access)
+		Consistently(fakeAccessLog.RecordCallCount).Should(BeZero())
+
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+		Eventually(recorded).Should(Equal([]creds.SecretAccess{access}))
+	})
+
+	It("records the accesses as soon as there is a batch of them", func() {
+		var accesses []creds.SecretAccess
+		for _, path := range []string{"a", "b", "c"} {
+			access.Path = path
+			accesses = append(accesses, access)
+
+			secretAccessAuditor.SecretAccessed(access)
+		}
+
+		Eventually(fakeAccessLog.RecordCallCount).Should(Equal(1))
+		Expect(fakeAccessLog.RecordArgsForCall(0)).To(Equal(accesses))
+	})
+
+	It("records the pending accesses when it is stopped", func() {
+		secretAccessAuditor.SecretAccessed(access)
+
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+
+		Expect(recorded()).To(Equal([]creds.SecretAccess{access}))
+	})
+
+	Context("when a step reads the same secret again", func() {
+		BeforeEach(func() {
+			secretAccessAuditor.SecretAccessed(access)
+
+			access.VarName = "other-var"
+			secretAccessAuditor.SecretAccessed(access)
+
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+			Eventually(fakeAccessLog.RecordCallCount).Should(Equal(1))
+		})
+
+		It("audits it only once", func() {
+			Expect(logger.Logs()).To(HaveLen(1))
+			Expect(recorded()).To(HaveLen(1))
+		})
+
+		It("audits it again once it has been forgotten", func() {
+			fakeClock.Increment(time.Hour)
+
+			secretAccessAuditor.SecretAccessed(access)
+			Expect(logger.Logs()).To(HaveLen(2))
+
+			fakeClock.Increment(time.Second)
+			Eventually(recorded).Should(HaveLen(2))
+		})
+	})
+
+	Context("when other steps read the same secret", func() {
+		BeforeEach(func() {
+			secretAccessAuditor.SecretAccessed(access)
+
+			access.StepName = "other-task"
+			secretAccessAuditor.SecretAccessed(access)
+
+			access.BuildID = 43
+			secretAccessAuditor.SecretAccessed(access)
+		})
+
+		It("audits each of them", func() {
+			Expect(logger.Logs()).To(HaveLen(3))
+			Eventually(recorded).Should(HaveLen(3))
+		})
+	})
+
+	Context("when recording the accesses fails", func() {
+		BeforeEach(func() {
+			fakeAccessLog.RecordReturns(errors.New("nope"))
+		})
+
+		It("logs the error", func() {
+			secretAccessAuditor.SecretAccessed(access)
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+			Eventually(logger.Logs).Should(HaveLen(2))
+
+			logs := logger.Logs()
+			Expect(logs[1].LogLevel).To(Equal(lager.ERROR))
+			Expect(logs[1].Message).To(Equal("test.failed-to-record-secret-accesses"))
+		})
+	})
+})
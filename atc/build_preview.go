@@ -0,0 +1,53 @@
+package atc
+
+// BuildPreview is what a build of a job would run if it were created now,
+// worked out without creating the build or saving the job's next inputs.
+type BuildPreview struct {
+	// Resolved is whether a version could be found for every input. The
+	// plan is only given when it is.
+	Resolved bool `json:"resolved"`
+
+	Inputs []BuildPreviewInput `json:"inputs"`
+
+	// Plan is the build's plan. Only the pipeline's instance vars are
+	// interpolated into it; vars from credential managers are left as they
+	// are so that no secrets are fetched.
+	Plan *Plan `json:"plan,omitempty"`
+
+	Steps []BuildPreviewStep `json:"steps,omitempty"`
+}
+
+type BuildPreviewInput struct {
+	Name            string  `json:"name"`
+	Resource        string  `json:"resource"`
+	Trigger         bool    `json:"trigger,omitempty"`
+	Version         Version `json:"version,omitempty"`
+	FirstOccurrence bool    `json:"first_occurrence,omitempty"`
+	PassedBuildIDs  []int   `json:"passed_build_ids,omitempty"`
+	ResolveError    string  `json:"resolve_error,omitempty"`
+}
+
+// BuildPreviewStep is a step of the plan which runs in a container, along
+// with the workers that it could be placed on.
+type BuildPreviewStep struct {
+	ID   PlanID `json:"id"`
+	Name string `json:"name"`
+
+	// Kind is the kind of step, e.g. "get" or "task".
+	Kind string `json:"kind"`
+
+	// ResourceType is the type of the resource for get, put and check
+	// steps, and BaseResourceType is the base type its image comes from.
+	ResourceType     string `json:"resource_type,omitempty"`
+	BaseResourceType string `json:"base_resource_type,omitempty"`
+
+	// Platform is the platform of a task, which is not known until the
+	// build runs if its config is loaded from a file.
+	Platform string `json:"platform,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+
+	Workers []string `json:"workers"`
+
+	// Error is why no worker could be found for the step.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,31 @@
+package buildlog
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+var extensions = map[baggageclaim.Encoding]string{
+	baggageclaim.GzipEncoding: ".json.gz",
+	baggageclaim.ZstdEncoding: ".json.zst",
+	baggageclaim.RawEncoding:  ".json",
+}
+
+var compressions = []compression.Compression{
+	compression.NewGzipCompression(),
+	compression.NewZstdCompression(),
+	compression.NewNoCompression(),
+}
+
+// ErrPrefixNotDirectory is returned by DeleteAll when the prefix does not
+// end with a "/", as it would otherwise delete the blobs of every directory
+// whose name starts with it.
+type ErrPrefixNotDirectory struct {
+	Prefix string
+}
+
+func (err ErrPrefixNotDirectory) Error() string {
+	return fmt.Sprintf("build log prefix '%s' does not end with '/'", err.Prefix)
+}
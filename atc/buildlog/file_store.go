@@ -0,0 +1,160 @@
+package buildlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+// FileStore keeps build logs as compressed files in a directory, e.g. one
+// on a volume that is shared by all of the web nodes.
+//
+// Logs are written with the configured compression, but logs written with
+// any of the other compressions can still be read, so that the compression
+// can be changed without losing access to older logs.
+type FileStore struct {
+	dir         string
+	compression compression.Compression
+}
+
+var _ db.BuildLogStore = (*FileStore)(nil)
+
+func NewFileStore(dir string, compression compression.Compression) *FileStore {
+	return &FileStore{
+		dir:         dir,
+		compression: compression,
+	}
+}
+
+func (s *FileStore) Put(ctx context.Context, name string, events io.Reader) error {
+	path := s.path(name, s.compression.Encoding())
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written log is
+	// never read back
+	file, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer, err := s.compression.NewWriter(file)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, events)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	// the rename atomically replaces logs that were put before with the same
+	// compression; logs put with other compressions are removed afterwards so
+	// that they do not shadow the new ones
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range compressions {
+		if c.Encoding() == s.compression.Encoding() {
+			continue
+		}
+
+		err := os.Remove(s.path(name, c.Encoding()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	for _, c := range compressions {
+		file, err := os.Open(s.path(name, c.Encoding()))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		reader, err := c.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("open %s build log: %w", c.Encoding(), err)
+		}
+
+		return &logReader{ReadCloser: reader, file: file}, nil
+	}
+
+	return nil, db.ErrBuildLogNotFound
+}
+
+func (s *FileStore) Delete(ctx context.Context, name string) error {
+	for _, c := range compressions {
+		err := os.Remove(s.path(name, c.Encoding()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) DeleteAll(ctx context.Context, prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return ErrPrefixNotDirectory{Prefix: prefix}
+	}
+
+	return os.RemoveAll(filepath.Join(s.dir, filepath.FromSlash(prefix)))
+}
+
+func (s *FileStore) path(name string, encoding baggageclaim.Encoding) string {
+	return filepath.Join(s.dir, filepath.FromSlash(name)) + extensions[encoding]
+}
+
+// logReader makes sure the file is closed along with the decompressing
+// reader, as not all of them close the underlying reader.
+type logReader struct {
+	io.ReadCloser
+
+	file *os.File
+}
+
+func (r *logReader) Close() error {
+	err := r.ReadCloser.Close()
+
+	fileErr := r.file.Close()
+	if err == nil && !errors.Is(fileErr, os.ErrClosed) {
+		err = fileErr
+	}
+
+	return err
+}
@@ -0,0 +1,156 @@
+package buildlog_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc/buildlog"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileStore", func() {
+	var (
+		ctx   context.Context
+		dir   string
+		store *buildlog.FileStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+		store = buildlog.NewFileStore(dir, compression.NewZstdCompression())
+	})
+
+	read := func(name string) string {
+		reader, err := store.Get(ctx, name)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(content)
+	}
+
+	It("reads back the logs that were put", func() {
+		err := store.Put(ctx, "pipeline_build_events_1/42", strings.NewReader(`{"event":"log"}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(read("pipeline_build_events_1/42")).To(Equal(`{"event":"log"}`))
+		Expect(filepath.Join(dir, "pipeline_build_events_1", "42.json.zst")).To(BeARegularFile())
+	})
+
+	It("overwrites logs that were put before", func() {
+		err := store.Put(ctx, "pipeline_build_events_1/42", strings.NewReader("old"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Put(ctx, "pipeline_build_events_1/42", strings.NewReader("new"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(read("pipeline_build_events_1/42")).To(Equal("new"))
+	})
+
+	It("leaves nothing behind when the logs fail to be read", func() {
+		err := store.Put(ctx, "pipeline_build_events_1/42", io.MultiReader(
+			strings.NewReader("partial"),
+			errReader{},
+		))
+		Expect(err).To(MatchError("disaster"))
+
+		_, err = store.Get(ctx, "pipeline_build_events_1/42")
+		Expect(err).To(Equal(db.ErrBuildLogNotFound))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "pipeline_build_events_1"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	Context("when the compression is changed", func() {
+		BeforeEach(func() {
+			oldStore := buildlog.NewFileStore(dir, compression.NewGzipCompression())
+
+			err := oldStore.Put(ctx, "pipeline_build_events_1/42", strings.NewReader("gzipped"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can still read the logs that were put before", func() {
+			Expect(read("pipeline_build_events_1/42")).To(Equal("gzipped"))
+		})
+
+		It("replaces the logs that were put before", func() {
+			err := store.Put(ctx, "pipeline_build_events_1/42", strings.NewReader("zstd"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(read("pipeline_build_events_1/42")).To(Equal("zstd"))
+			Expect(filepath.Join(dir, "pipeline_build_events_1", "42.json.gz")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Describe("Get", func() {
+		It("returns ErrBuildLogNotFound when there are no logs", func() {
+			_, err := store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the logs", func() {
+			err := store.Put(ctx, "pipeline_build_events_1/42", strings.NewReader("log"))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = store.Delete(ctx, "pipeline_build_events_1/42")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+		})
+
+		It("succeeds when there are no logs", func() {
+			err := store.Delete(ctx, "pipeline_build_events_1/42")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("DeleteAll", func() {
+		It("deletes all of the logs in the directory", func() {
+			for _, name := range []string{
+				"pipeline_build_events_1/42",
+				"pipeline_build_events_1/43",
+				"pipeline_build_events_11/44",
+			} {
+				err := store.Put(ctx, name, strings.NewReader(name))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			err := store.DeleteAll(ctx, "pipeline_build_events_1/")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+			_, err = store.Get(ctx, "pipeline_build_events_1/43")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+
+			Expect(read("pipeline_build_events_11/44")).To(Equal("pipeline_build_events_11/44"))
+		})
+
+		It("refuses a prefix that is not a directory", func() {
+			err := store.DeleteAll(ctx, "pipeline_build_events_1")
+			Expect(err).To(Equal(buildlog.ErrPrefixNotDirectory{Prefix: "pipeline_build_events_1"}))
+		})
+	})
+})
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("disaster")
+}
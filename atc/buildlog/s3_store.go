@@ -0,0 +1,218 @@
+package buildlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// maxDeleteObjects is the most keys that can be deleted by one DeleteObjects
+// request.
+const maxDeleteObjects = 1000
+
+// S3Store keeps build logs as compressed objects in an S3 bucket, or in a
+// bucket of any object store with an S3-compatible API (e.g. MinIO).
+//
+// Objects are named like the files of a FileStore, under an optional key
+// prefix, so that logs can be moved between the two stores by copying them.
+type S3Store struct {
+	client      s3iface.S3API
+	uploader    *s3manager.Uploader
+	bucket      string
+	prefix      string
+	compression compression.Compression
+}
+
+var _ db.BuildLogStore = (*S3Store)(nil)
+
+func NewS3Store(client s3iface.S3API, bucket string, prefix string, compression compression.Compression) *S3Store {
+	return &S3Store{
+		client:      client,
+		uploader:    s3manager.NewUploaderWithClient(client),
+		bucket:      bucket,
+		prefix:      prefix,
+		compression: compression,
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, events io.Reader) error {
+	reader, writer := io.Pipe()
+
+	go func() {
+		compressor, err := s.compression.NewWriter(writer)
+		if err != nil {
+			writer.CloseWithError(err)
+			return
+		}
+
+		_, err = io.Copy(compressor, events)
+		if err != nil {
+			writer.CloseWithError(err)
+			return
+		}
+
+		writer.CloseWithError(compressor.Close())
+	}()
+
+	// an object only becomes visible once it has been uploaded in full, so a
+	// partially written log is never read back
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name, s.compression)),
+		Body:   reader,
+	})
+
+	// unblock the compressing goroutine if the upload gave up early
+	reader.CloseWithError(err)
+
+	if err != nil {
+		return err
+	}
+
+	var others []string
+	for _, c := range compressions {
+		if c.Encoding() != s.compression.Encoding() {
+			others = append(others, s.key(name, c))
+		}
+	}
+
+	return s.deleteObjects(ctx, others)
+}
+
+func (s *S3Store) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	for _, c := range compressions {
+		output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(s.key(name, c)),
+		})
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		reader, err := c.NewReader(output.Body)
+		if err != nil {
+			output.Body.Close()
+			return nil, fmt.Errorf("open %s build log: %w", c.Encoding(), err)
+		}
+
+		return &objectReader{ReadCloser: reader, body: output.Body}, nil
+	}
+
+	return nil, db.ErrBuildLogNotFound
+}
+
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	var keys []string
+	for _, c := range compressions {
+		keys = append(keys, s.key(name, c))
+	}
+
+	return s.deleteObjects(ctx, keys)
+}
+
+func (s *S3Store) DeleteAll(ctx context.Context, prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return ErrPrefixNotDirectory{Prefix: prefix}
+	}
+
+	var deleteErr error
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		var keys []string
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+
+		deleteErr = s.deleteObjects(ctx, keys)
+		return deleteErr == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return deleteErr
+}
+
+func (s *S3Store) deleteObjects(ctx context.Context, keys []string) error {
+	for len(keys) > 0 {
+		batch := keys[:min(len(keys), maxDeleteObjects)]
+		keys = keys[len(batch):]
+
+		var objects []*s3.ObjectIdentifier
+		for _, key := range batch {
+			objects = append(objects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		output, err := s.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// deleting an object that does not exist succeeds, so any error here
+		// is a real one
+		if len(output.Errors) > 0 {
+			failure := output.Errors[0]
+			return fmt.Errorf("delete build log %s: %s", aws.StringValue(failure.Key), aws.StringValue(failure.Message))
+		}
+	}
+
+	return nil
+}
+
+func (s *S3Store) key(name string, c compression.Compression) string {
+	return s.prefix + name + extensions[c.Encoding()]
+}
+
+func isNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	switch awsErr.Code() {
+	case s3.ErrCodeNoSuchKey, "NotFound":
+		return true
+	default:
+		return false
+	}
+}
+
+// objectReader makes sure the object's body is closed along with the
+// decompressing reader, as not all of them close the underlying reader.
+type objectReader struct {
+	io.ReadCloser
+
+	body io.ReadCloser
+}
+
+func (r *objectReader) Close() error {
+	err := r.ReadCloser.Close()
+
+	bodyErr := r.body.Close()
+	if err == nil {
+		err = bodyErr
+	}
+
+	return err
+}
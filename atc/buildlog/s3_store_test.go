@@ -0,0 +1,304 @@
+package buildlog_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/concourse/atc/buildlog"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3Store", func() {
+	var (
+		ctx     context.Context
+		bucket  *fakeBucket
+		server  *httptest.Server
+		client  *s3.S3
+		store   *buildlog.S3Store
+		putLogs func(store *buildlog.S3Store, name string, content string)
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		bucket = &fakeBucket{name: "logs", objects: map[string][]byte{}}
+		server = httptest.NewServer(bucket)
+
+		sess, err := session.NewSession(&aws.Config{
+			Region:           aws.String("us-east-1"),
+			Endpoint:         aws.String(server.URL),
+			S3ForcePathStyle: aws.Bool(true),
+			Credentials:      credentials.NewStaticCredentials("access-key", "secret-key", ""),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		client = s3.New(sess)
+		store = buildlog.NewS3Store(client, "logs", "concourse/", compression.NewZstdCompression())
+
+		putLogs = func(store *buildlog.S3Store, name string, content string) {
+			err := store.Put(ctx, name, strings.NewReader(content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	read := func(name string) string {
+		reader, err := store.Get(ctx, name)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(content)
+	}
+
+	It("reads back the logs that were put", func() {
+		putLogs(store, "pipeline_build_events_1/42", `{"event":"log"}`)
+
+		Expect(read("pipeline_build_events_1/42")).To(Equal(`{"event":"log"}`))
+		Expect(bucket.keys()).To(ConsistOf("concourse/pipeline_build_events_1/42.json.zst"))
+	})
+
+	It("overwrites logs that were put before", func() {
+		putLogs(store, "pipeline_build_events_1/42", "old")
+		putLogs(store, "pipeline_build_events_1/42", "new")
+
+		Expect(read("pipeline_build_events_1/42")).To(Equal("new"))
+	})
+
+	It("leaves nothing behind when the logs fail to be read", func() {
+		err := store.Put(ctx, "pipeline_build_events_1/42", io.MultiReader(
+			strings.NewReader("partial"),
+			errReader{},
+		))
+		Expect(err).To(MatchError(ContainSubstring("disaster")))
+
+		_, err = store.Get(ctx, "pipeline_build_events_1/42")
+		Expect(err).To(Equal(db.ErrBuildLogNotFound))
+
+		Expect(bucket.keys()).To(BeEmpty())
+	})
+
+	Context("when the compression is changed", func() {
+		BeforeEach(func() {
+			oldStore := buildlog.NewS3Store(client, "logs", "concourse/", compression.NewGzipCompression())
+			putLogs(oldStore, "pipeline_build_events_1/42", "gzipped")
+		})
+
+		It("can still read the logs that were put before", func() {
+			Expect(read("pipeline_build_events_1/42")).To(Equal("gzipped"))
+		})
+
+		It("replaces the logs that were put before", func() {
+			putLogs(store, "pipeline_build_events_1/42", "zstd")
+
+			Expect(read("pipeline_build_events_1/42")).To(Equal("zstd"))
+			Expect(bucket.keys()).To(ConsistOf("concourse/pipeline_build_events_1/42.json.zst"))
+		})
+	})
+
+	Describe("Get", func() {
+		It("returns ErrBuildLogNotFound when there are no logs", func() {
+			_, err := store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the logs", func() {
+			putLogs(store, "pipeline_build_events_1/42", "log")
+
+			err := store.Delete(ctx, "pipeline_build_events_1/42")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+		})
+
+		It("succeeds when there are no logs", func() {
+			err := store.Delete(ctx, "pipeline_build_events_1/42")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("DeleteAll", func() {
+		It("deletes all of the logs in the directory", func() {
+			for _, name := range []string{
+				"pipeline_build_events_1/42",
+				"pipeline_build_events_1/43",
+				"pipeline_build_events_11/44",
+			} {
+				putLogs(store, name, name)
+			}
+
+			err := store.DeleteAll(ctx, "pipeline_build_events_1/")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Get(ctx, "pipeline_build_events_1/42")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+			_, err = store.Get(ctx, "pipeline_build_events_1/43")
+			Expect(err).To(Equal(db.ErrBuildLogNotFound))
+
+			Expect(read("pipeline_build_events_11/44")).To(Equal("pipeline_build_events_11/44"))
+		})
+
+		It("leaves the objects outside of the store's prefix alone", func() {
+			bucket.objects["pipeline_build_events_1/42.json"] = []byte("other")
+
+			err := store.DeleteAll(ctx, "pipeline_build_events_1/")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(bucket.keys()).To(ConsistOf("pipeline_build_events_1/42.json"))
+		})
+
+		It("refuses a prefix that is not a directory", func() {
+			err := store.DeleteAll(ctx, "pipeline_build_events_1")
+			Expect(err).To(Equal(buildlog.ErrPrefixNotDirectory{Prefix: "pipeline_build_events_1"}))
+		})
+	})
+})
+
+// fakeBucket serves the few requests of the S3 API that are made by the
+// store, for a single bucket with path-style addressing.
+type fakeBucket struct {
+	name string
+
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (b *fakeBucket) keys() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	keys := []string{}
+	for key := range b.objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucketName, key, _ := strings.Cut(path, "/")
+	Expect(bucketName).To(Equal(b.name))
+
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && query.Get("list-type") == "2":
+		prefix := query.Get("prefix")
+
+		result := listBucketResult{Name: b.name, Prefix: prefix, MaxKeys: 1000}
+		for key := range b.objects {
+			if strings.HasPrefix(key, prefix) {
+				result.Contents = append(result.Contents, listedObject{Key: key})
+			}
+		}
+
+		sort.Slice(result.Contents, func(i, j int) bool {
+			return result.Contents[i].Key < result.Contents[j].Key
+		})
+
+		result.KeyCount = len(result.Contents)
+
+		writeXML(w, http.StatusOK, result)
+
+	case r.Method == http.MethodPost && key == "" && query.Has("delete"):
+		var request deleteRequest
+		Expect(xml.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+
+		for _, object := range request.Objects {
+			delete(b.objects, object.Key)
+		}
+
+		writeXML(w, http.StatusOK, deleteResult{})
+
+	case r.Method == http.MethodPut && key != "":
+		content, err := io.ReadAll(r.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		b.objects[key] = content
+
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodGet && key != "":
+		content, found := b.objects[key]
+		if !found {
+			writeXML(w, http.StatusNotFound, errorResponse{
+				Code:    "NoSuchKey",
+				Message: "The specified key does not exist.",
+			})
+			return
+		}
+
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+
+	default:
+		Fail(fmt.Sprintf("unexpected request: %s %s", r.Method, r.URL))
+	}
+}
+
+func writeXML(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	Expect(xml.NewEncoder(w).Encode(body)).To(Succeed())
+}
+
+type listBucketResult struct {
+	XMLName     xml.Name       `xml:"ListBucketResult"`
+	Name        string         `xml:"Name"`
+	Prefix      string         `xml:"Prefix"`
+	KeyCount    int            `xml:"KeyCount"`
+	MaxKeys     int            `xml:"MaxKeys"`
+	IsTruncated bool           `xml:"IsTruncated"`
+	Contents    []listedObject `xml:"Contents"`
+}
+
+type listedObject struct {
+	Key string `xml:"Key"`
+}
+
+type deleteRequest struct {
+	Objects []listedObject `xml:"Object"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name `xml:"DeleteResult"`
+}
+
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
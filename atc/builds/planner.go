@@ -2,6 +2,7 @@ package builds
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -60,6 +61,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		Name:              step.Name,
 		Privileged:        step.Privileged,
 		Hermetic:          step.Hermetic,
+		Memoize:           step.Memoize,
 		Limits:            step.Limits,
 		Config:            step.Config,
 		ConfigPath:        step.ConfigPath,
@@ -279,13 +281,56 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	visitor.plan = visitor.planFactory.NewPlan(atc.LoadVarPlan{
 		Name:   step.Name,
 		File:   step.File,
+		Dir:    step.Dir,
 		Format: step.Format,
+		Select: step.Select,
 		Reveal: step.Reveal,
 	})
 
 	return nil
 }
 
+func (visitor *planVisitor) VisitSetVar(step *atc.SetVarStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.SetVarPlan{
+		Name:    step.Name,
+		File:    step.File,
+		Format:  step.Format,
+		Manager: step.Manager,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitUseTemplate(step *atc.UseTemplateStep) error {
+	if len(step.Steps) == 0 {
+		return fmt.Errorf("use_template(%s) has not been expanded; the pipeline must be set again", step.Name)
+	}
+
+	do := atc.DoPlan{}
+
+	for _, step := range step.Steps {
+		err := step.Config.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		do = append(do, visitor.plan)
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(do)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name:      step.Name,
+		Approvers: step.Approvers,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
@@ -313,6 +358,20 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitIf(step *atc.IfStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.IfPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	retryStep := make(atc.RetryPlan, step.Attempts)
 
@@ -330,111 +389,134 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	return nil
 }
 
-func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
-	plan := atc.OnSuccessPlan{}
+// withHook plans a step along with one of its hooks. A step that only runs if
+// a condition holds is planned with the condition around the hook as well, so
+// that the hook is skipped along with the step rather than run as though the
+// step had succeeded.
+//
+// Only an if: directly on the step is moved out; the condition of one within
+// an across: depends on the across vars, so the hook runs with the across
+// step as a whole.
+func (visitor *planVisitor) withHook(step atc.Plan, newPlan func(atc.Plan) atc.PlanConfig) atc.Plan {
+	if step.If != nil {
+		conditional := step
+		conditional.If = &atc.IfPlan{
+			Condition: step.If.Condition,
+			Step:      visitor.withHook(step.If.Step, newPlan),
+		}
+
+		return conditional
+	}
 
+	return visitor.planFactory.NewPlan(newPlan(step))
+}
+
+func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	stepPlan := visitor.plan
 
 	err = step.Hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	hookPlan := visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	visitor.plan = visitor.withHook(stepPlan, func(step atc.Plan) atc.PlanConfig {
+		return atc.OnSuccessPlan{Step: step, Next: hookPlan}
+	})
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
-	plan := atc.OnFailurePlan{}
-
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	stepPlan := visitor.plan
 
 	err = step.Hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	hookPlan := visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	visitor.plan = visitor.withHook(stepPlan, func(step atc.Plan) atc.PlanConfig {
+		return atc.OnFailurePlan{Step: step, Next: hookPlan}
+	})
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
-	plan := atc.OnAbortPlan{}
-
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	stepPlan := visitor.plan
 
 	err = step.Hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	hookPlan := visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	visitor.plan = visitor.withHook(stepPlan, func(step atc.Plan) atc.PlanConfig {
+		return atc.OnAbortPlan{Step: step, Next: hookPlan}
+	})
 
 	return nil
 }
 
 func (visitor *planVisitor) VisitOnError(step *atc.OnErrorStep) error {
-	plan := atc.OnErrorPlan{}
-
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	stepPlan := visitor.plan
 
 	err = step.Hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	hookPlan := visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	visitor.plan = visitor.withHook(stepPlan, func(step atc.Plan) atc.PlanConfig {
+		return atc.OnErrorPlan{Step: step, Next: hookPlan}
+	})
 
 	return nil
 }
-func (visitor *planVisitor) VisitEnsure(step *atc.EnsureStep) error {
-	plan := atc.EnsurePlan{}
 
+func (visitor *planVisitor) VisitEnsure(step *atc.EnsureStep) error {
 	err := step.Step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	stepPlan := visitor.plan
 
 	err = step.Hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	hookPlan := visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	visitor.plan = visitor.withHook(stepPlan, func(step atc.Plan) atc.PlanConfig {
+		return atc.EnsurePlan{Step: step, Next: hookPlan}
+	})
 
 	return nil
 }
@@ -2,6 +2,7 @@ package builds_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -1246,6 +1247,7 @@ var factoryTests = []PlannerTest{
 			Name:       "some-task",
 			Privileged: true,
 			Hermetic:   true,
+			Memoize:    true,
 			Config: &atc.TaskConfig{
 				Platform: "linux",
 				Run:      atc.TaskRunConfig{Path: "hello"},
@@ -1266,6 +1268,7 @@ var factoryTests = []PlannerTest{
 				"name": "some-task",
 				"privileged": true,
 				"hermetic": true,
+				"memoize": true,
 				"config": {
 					"platform": "linux",
 					"run": {"path": "hello"}
@@ -1472,6 +1475,60 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "load_var step loading a directory",
+
+		Config: &atc.LoadVarStep{
+			Name:   "some-var",
+			Dir:    "some-artifact/some-dir",
+			Select: "$.some-file.version",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"dir": "some-artifact/some-dir",
+				"select": "$.some-file.version"
+			}
+		}`,
+	},
+	{
+		Title: "set_var step",
+
+		Config: &atc.SetVarStep{
+			Name:    "some-var",
+			File:    "some-var-file",
+			Format:  "json",
+			Manager: "vault",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"set_var": {
+				"name": "some-var",
+				"file": "some-var-file",
+				"format": "json",
+				"manager": "vault"
+			}
+		}`,
+	},
+	{
+		Title: "approval step",
+
+		Config: &atc.ApprovalStep{
+			Name:      "qa",
+			Approvers: []string{"owner", "github:some-user"},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approval": {
+				"name": "qa",
+				"approvers": ["owner", "github:some-user"]
+			}
+		}`,
+	},
 	{
 		Title: "try step",
 
@@ -1537,6 +1594,44 @@ var factoryTests = []PlannerTest{
 			]
 		}`,
 	},
+	{
+		Title: "use_template step",
+
+		Config: &atc.UseTemplateStep{
+			Name:   "some-template",
+			Params: atc.Params{"some": "param"},
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "use_template step which has not been expanded",
+
+		Config: &atc.UseTemplateStep{
+			Name: "some-template",
+		},
+
+		Err: errors.New("use_template(some-template) has not been expanded; the pipeline must be set again"),
+	},
 	{
 		Title: "in_parallel step",
 
@@ -1651,6 +1746,31 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "if modifier",
+
+		Config: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "((branch)) == main",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"if": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"condition": "((branch)) == main"
+			}
+		}`,
+	},
 	{
 		Title: "attempts modifier",
 
@@ -1726,6 +1846,73 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "hooks of a step with an if modifier",
+
+		Config: &atc.EnsureStep{
+			Step: &atc.OnSuccessStep{
+				Step: &atc.IfStep{
+					Step: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+					Condition: "((branch)) == main",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "some-other-var",
+						File: "some-other-file",
+					},
+				},
+			},
+			Hook: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "some-ensured-var",
+					File: "some-ensured-file",
+				},
+			},
+		},
+
+		// the hooks are skipped along with the step when the condition does
+		// not hold
+		PlanJSON: `{
+			"id": "(unique)",
+			"if": {
+				"step": {
+					"id": "(unique)",
+					"ensure": {
+						"step": {
+							"id": "(unique)",
+							"on_success": {
+								"step": {
+									"id": "(unique)",
+									"load_var": {
+										"name": "some-var",
+										"file": "some-file"
+									}
+								},
+								"on_success": {
+									"id": "(unique)",
+									"load_var": {
+										"name": "some-other-var",
+										"file": "some-other-file"
+									}
+								}
+							}
+						},
+						"ensure": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-ensured-var",
+								"file": "some-ensured-file"
+							}
+						}
+					}
+				},
+				"condition": "((branch)) == main"
+			}
+		}`,
+	},
 	{
 		Title: "on_failure step",
 
@@ -7,7 +7,10 @@ const (
 	ComponentBuildTracker               = "tracker"
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
+	ComponentBuildLogArchiver           = "build_log_archiver"
+	ComponentNotifier                   = "notifier"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentKubernetesWorker           = "kubernetes_worker"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
 	ComponentCollectorBuilds            = "collector_builds"
@@ -21,7 +24,9 @@ const (
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
+	ComponentCollectorSecretAccesses    = "collector_secret_accesses"
 	ComponentPipelinePauser             = "pipeline_pauser"
+	ComponentPipelineSourceSyncer       = "pipeline_source_syncer"
 	ComponentBeingWatchedBuildMarker    = "being_watched_build_marker"
 )
 
@@ -1,6 +1,9 @@
 package compression_test
 
 import (
+	"bytes"
+	"io"
+
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/worker/baggageclaim"
 
@@ -13,6 +16,28 @@ var _ = Describe("Compression", func() {
 		comp compression.Compression
 	)
 
+	itRoundTrips := func() {
+		It("reads back what it wrote", func() {
+			buf := new(bytes.Buffer)
+
+			writer, err := comp.NewWriter(buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = writer.Write([]byte("some-content"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			reader, err := comp.NewReader(io.NopCloser(buf))
+			Expect(err).ToNot(HaveOccurred())
+
+			defer reader.Close()
+
+			content, err := io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("some-content"))
+		})
+	}
+
 	Describe("Gzip", func() {
 		BeforeEach(func() {
 			comp = compression.NewGzipCompression()
@@ -21,6 +46,8 @@ var _ = Describe("Compression", func() {
 		It("returns gzip", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.GzipEncoding))
 		})
+
+		itRoundTrips()
 	})
 
 	Describe("Zstd", func() {
@@ -31,5 +58,19 @@ var _ = Describe("Compression", func() {
 		It("returns zstd", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.ZstdEncoding))
 		})
+
+		itRoundTrips()
+	})
+
+	Describe("Raw", func() {
+		BeforeEach(func() {
+			comp = compression.NewNoCompression()
+		})
+
+		It("returns raw", func() {
+			Expect(comp.Encoding()).To(Equal(baggageclaim.RawEncoding))
+		})
+
+		itRoundTrips()
 	})
 })
@@ -21,6 +21,10 @@ func (c *gzipCompression) NewReader(reader io.ReadCloser) (io.ReadCloser, error)
 	return &gzipReader{reader: r}, nil
 }
 
+func (c *gzipCompression) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(writer), nil
+}
+
 func (c *gzipCompression) Encoding() baggageclaim.Encoding {
 	return baggageclaim.GzipEncoding
 }
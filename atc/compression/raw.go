@@ -16,6 +16,10 @@ func (c *noCompression) NewReader(reader io.ReadCloser) (io.ReadCloser, error) {
 	return &rawReader{reader: reader}, nil
 }
 
+func (c *noCompression) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	return &rawWriter{writer: writer}, nil
+}
+
 func (c *noCompression) Encoding() baggageclaim.Encoding {
 	return baggageclaim.RawEncoding
 }
@@ -31,3 +35,17 @@ func (zr *rawReader) Read(p []byte) (int, error) {
 func (zr *rawReader) Close() error {
 	return zr.reader.Close()
 }
+
+type rawWriter struct {
+	writer io.Writer
+}
+
+func (zw *rawWriter) Write(p []byte) (int, error) {
+	return zw.writer.Write(p)
+}
+
+// Close does not close the underlying writer, just like closing a gzip or
+// zstd writer doesn't.
+func (zw *rawWriter) Close() error {
+	return nil
+}
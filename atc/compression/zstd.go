@@ -21,6 +21,10 @@ func (c *zstdCompression) NewReader(reader io.ReadCloser) (io.ReadCloser, error)
 	return &zstdReader{decoder: d}, nil
 }
 
+func (c *zstdCompression) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(writer)
+}
+
 func (c *zstdCompression) Encoding() baggageclaim.Encoding {
 	return baggageclaim.ZstdEncoding
 }
@@ -0,0 +1,470 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// Condition is a parsed `if:` expression. Conditions are parsed when the
+// pipeline is configured and evaluated at runtime, against the build's vars
+// and metadata.
+//
+// The syntax is deliberately small:
+//
+//	((var))             the value of a var, e.g. ((.:branch)) or ((vault:flag))
+//	$BUILD_JOB_NAME     a build metadata value, as exposed to resources
+//	'foo' / "foo" / foo a string literal
+//	a == b, a != b      string comparison
+//	!a, a && b, a || b  boolean logic, with parentheses for grouping
+//
+// A value on its own is true unless it is false, empty, "false" or 0.
+type Condition struct {
+	source string
+	expr   conditionExpr
+}
+
+// ConditionMetadataNames are the build metadata values that can be referenced
+// in a Condition.
+var ConditionMetadataNames = []string{
+	"BUILD_ID",
+	"BUILD_NAME",
+	"BUILD_TEAM_ID",
+	"BUILD_TEAM_NAME",
+	"BUILD_JOB_ID",
+	"BUILD_JOB_NAME",
+	"BUILD_PIPELINE_ID",
+	"BUILD_PIPELINE_NAME",
+	"BUILD_PIPELINE_INSTANCE_VARS",
+	"BUILD_CREATED_BY",
+	"ATC_EXTERNAL_URL",
+}
+
+func ParseCondition(source string) (Condition, error) {
+	tokens, err := tokenizeCondition(source)
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition '%s': %w", source, err)
+	}
+
+	parser := &conditionParser{tokens: tokens}
+
+	expr, err := parser.parseOr()
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition '%s': %w", source, err)
+	}
+
+	if !parser.done() {
+		return Condition{}, fmt.Errorf("invalid condition '%s': unexpected '%s'", source, parser.peek().text)
+	}
+
+	return Condition{source: source, expr: expr}, nil
+}
+
+func (condition Condition) String() string {
+	return condition.source
+}
+
+// Evaluate resolves any vars and metadata referenced by the condition and
+// returns whether it holds.
+//
+// The metadata map is keyed by the names in ConditionMetadataNames; metadata
+// that is not set (e.g. BUILD_JOB_NAME for a one-off build) evaluates to an
+// empty string.
+func (condition Condition) Evaluate(variables vars.Variables, metadata map[string]string) (bool, error) {
+	val, err := condition.expr.eval(variables, metadata)
+	if err != nil {
+		return false, err
+	}
+
+	return truthy(val), nil
+}
+
+type conditionTokenKind int
+
+const (
+	tokenValue conditionTokenKind = iota
+	tokenVar
+	tokenMetadata
+	tokenEq
+	tokenNotEq
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type conditionToken struct {
+	kind conditionTokenKind
+	text string
+}
+
+func tokenizeCondition(source string) ([]conditionToken, error) {
+	var tokens []conditionToken
+
+	rest := source
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			return tokens, nil
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "(("):
+			end := strings.Index(rest, "))")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated var '%s'", rest)
+			}
+
+			tokens = append(tokens, conditionToken{kind: tokenVar, text: rest[2:end]})
+			rest = rest[end+2:]
+
+		case strings.HasPrefix(rest, "=="):
+			tokens = append(tokens, conditionToken{kind: tokenEq, text: "=="})
+			rest = rest[2:]
+
+		case strings.HasPrefix(rest, "!="):
+			tokens = append(tokens, conditionToken{kind: tokenNotEq, text: "!="})
+			rest = rest[2:]
+
+		case strings.HasPrefix(rest, "&&"):
+			tokens = append(tokens, conditionToken{kind: tokenAnd, text: "&&"})
+			rest = rest[2:]
+
+		case strings.HasPrefix(rest, "||"):
+			tokens = append(tokens, conditionToken{kind: tokenOr, text: "||"})
+			rest = rest[2:]
+
+		case rest[0] == '!':
+			tokens = append(tokens, conditionToken{kind: tokenNot, text: "!"})
+			rest = rest[1:]
+
+		case rest[0] == '(':
+			tokens = append(tokens, conditionToken{kind: tokenOpenParen, text: "("})
+			rest = rest[1:]
+
+		case rest[0] == ')':
+			tokens = append(tokens, conditionToken{kind: tokenCloseParen, text: ")"})
+			rest = rest[1:]
+
+		case rest[0] == '\'' || rest[0] == '"':
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string %s", rest)
+			}
+
+			tokens = append(tokens, conditionToken{kind: tokenValue, text: rest[1 : end+1]})
+			rest = rest[end+2:]
+
+		case rest[0] == '$':
+			word, remainder := splitConditionWord(rest[1:])
+			if !isConditionMetadata(word) {
+				return nil, fmt.Errorf("unknown build metadata '$%s'", word)
+			}
+
+			tokens = append(tokens, conditionToken{kind: tokenMetadata, text: word})
+			rest = remainder
+
+		default:
+			word, remainder := splitConditionWord(rest)
+			if word == "" {
+				return nil, fmt.Errorf("unexpected '%c'", rest[0])
+			}
+
+			tokens = append(tokens, conditionToken{kind: tokenValue, text: word})
+			rest = remainder
+		}
+	}
+}
+
+func splitConditionWord(s string) (string, string) {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`()!=&|'"$`, r)
+	})
+	if end == -1 {
+		return s, ""
+	}
+
+	return s[:end], s[end:]
+}
+
+func isConditionMetadata(name string) bool {
+	for _, n := range ConditionMetadataNames {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (parser *conditionParser) done() bool {
+	return parser.pos >= len(parser.tokens)
+}
+
+func (parser *conditionParser) peek() conditionToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *conditionParser) accept(kind conditionTokenKind) bool {
+	if parser.done() || parser.peek().kind != kind {
+		return false
+	}
+
+	parser.pos++
+	return true
+}
+
+func (parser *conditionParser) parseOr() (conditionExpr, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept(tokenOr) {
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orExpr{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *conditionParser) parseAnd() (conditionExpr, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept(tokenAnd) {
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andExpr{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *conditionParser) parseUnary() (conditionExpr, error) {
+	if parser.accept(tokenNot) {
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{operand}, nil
+	}
+
+	return parser.parseComparison()
+}
+
+func (parser *conditionParser) parseComparison() (conditionExpr, error) {
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.accept(tokenEq) {
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return eqExpr{left, right}, nil
+	}
+
+	if parser.accept(tokenNotEq) {
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{eqExpr{left, right}}, nil
+	}
+
+	return left, nil
+}
+
+func (parser *conditionParser) parseOperand() (conditionExpr, error) {
+	if parser.done() {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	token := parser.peek()
+	parser.pos++
+
+	switch token.kind {
+	case tokenOpenParen:
+		expr, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !parser.accept(tokenCloseParen) {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		return expr, nil
+
+	case tokenVar:
+		ref, err := vars.ParseReference(token.text)
+		if err != nil {
+			return nil, err
+		}
+
+		return varExpr{ref}, nil
+
+	case tokenMetadata:
+		return metadataExpr{token.text}, nil
+
+	case tokenValue:
+		return valueExpr{token.text}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected '%s'", token.text)
+	}
+}
+
+type conditionExpr interface {
+	eval(vars.Variables, map[string]string) (interface{}, error)
+}
+
+type valueExpr struct {
+	value string
+}
+
+func (expr valueExpr) eval(vars.Variables, map[string]string) (interface{}, error) {
+	return expr.value, nil
+}
+
+type varExpr struct {
+	ref vars.Reference
+}
+
+func (expr varExpr) eval(variables vars.Variables, _ map[string]string) (interface{}, error) {
+	val, found, err := variables.Get(expr.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, vars.UndefinedVarsError{Vars: []string{expr.ref.String()}}
+	}
+
+	return val, nil
+}
+
+type metadataExpr struct {
+	name string
+}
+
+func (expr metadataExpr) eval(_ vars.Variables, metadata map[string]string) (interface{}, error) {
+	return metadata[expr.name], nil
+}
+
+type notExpr struct {
+	operand conditionExpr
+}
+
+func (expr notExpr) eval(variables vars.Variables, metadata map[string]string) (interface{}, error) {
+	val, err := expr.operand.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return !truthy(val), nil
+}
+
+type andExpr struct {
+	left, right conditionExpr
+}
+
+func (expr andExpr) eval(variables vars.Variables, metadata map[string]string) (interface{}, error) {
+	left, err := expr.left.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if !truthy(left) {
+		return false, nil
+	}
+
+	right, err := expr.right.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return truthy(right), nil
+}
+
+type orExpr struct {
+	left, right conditionExpr
+}
+
+func (expr orExpr) eval(variables vars.Variables, metadata map[string]string) (interface{}, error) {
+	left, err := expr.left.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if truthy(left) {
+		return true, nil
+	}
+
+	right, err := expr.right.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return truthy(right), nil
+}
+
+type eqExpr struct {
+	left, right conditionExpr
+}
+
+func (expr eqExpr) eval(variables vars.Variables, metadata map[string]string) (interface{}, error) {
+	left, err := expr.left.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := expr.right.eval(variables, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return fmt.Sprint(left) == fmt.Sprint(right), nil
+}
+
+func truthy(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false" && v != "0"
+	case []interface{}:
+		return len(v) != 0
+	case map[string]interface{}:
+		return len(v) != 0
+	case map[interface{}]interface{}:
+		return len(v) != 0
+	default:
+		return fmt.Sprint(v) != "0"
+	}
+}
@@ -0,0 +1,97 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Condition", func() {
+	var (
+		variables vars.StaticVariables
+		metadata  map[string]string
+	)
+
+	BeforeEach(func() {
+		variables = vars.StaticVariables{
+			"branch":  "main",
+			"deploy":  true,
+			"count":   0,
+			"nothing": "",
+		}
+
+		metadata = map[string]string{
+			"BUILD_JOB_NAME":      "unit",
+			"BUILD_PIPELINE_NAME": "ci",
+		}
+	})
+
+	DescribeTable("evaluating",
+		func(source string, expected bool) {
+			condition, err := ParseCondition(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := condition.Evaluate(variables, metadata)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("a true var", "((deploy))", true),
+		Entry("a zero var", "((count))", false),
+		Entry("an empty var", "((nothing))", false),
+		Entry("a bare word", "yes", true),
+		Entry("a quoted false", "'false'", false),
+		Entry("equal strings", "((branch)) == 'main'", true),
+		Entry("equal bare words", "((branch)) == main", true),
+		Entry("unequal strings", `((branch)) == "release"`, false),
+		Entry("not equal", "((branch)) != release", true),
+		Entry("a var against a bool", "((deploy)) == true", true),
+		Entry("build metadata", "$BUILD_JOB_NAME == unit", true),
+		Entry("unset build metadata", "$BUILD_CREATED_BY == ''", true),
+		Entry("negation", "!((deploy))", false),
+		Entry("and", "((deploy)) && ((branch)) == main", true),
+		Entry("or", "((count)) || $BUILD_PIPELINE_NAME == ci", true),
+		Entry("and binds tighter than or", "true || false && false", true),
+		Entry("parentheses", "(true || false) && false", false),
+	)
+
+	It("short-circuits", func() {
+		condition, err := ParseCondition("false && ((undefined))")
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := condition.Evaluate(variables, metadata)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeFalse())
+	})
+
+	It("errors when a var is undefined", func() {
+		condition, err := ParseCondition("((undefined)) == foo")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = condition.Evaluate(variables, metadata)
+		Expect(err).To(Equal(vars.UndefinedVarsError{Vars: []string{"undefined"}}))
+	})
+
+	It("remembers its source", func() {
+		condition, err := ParseCondition("((branch)) == main")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(condition.String()).To(Equal("((branch)) == main"))
+	})
+
+	DescribeTable("parse errors",
+		func(source string, message string) {
+			_, err := ParseCondition(source)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("empty", "", "unexpected end of condition"),
+		Entry("unterminated var", "((foo", "unterminated var"),
+		Entry("unterminated string", "'foo", "unterminated string"),
+		Entry("unknown metadata", "$HOME == foo", "unknown build metadata '$HOME'"),
+		Entry("unbalanced parentheses", "(true", "missing ')'"),
+		Entry("dangling operator", "true &&", "unexpected end of condition"),
+		Entry("trailing tokens", "true false", "unexpected 'false'"),
+		Entry("stray equals", "= foo", "unexpected '='"),
+	)
+})
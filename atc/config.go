@@ -24,6 +24,7 @@ type Config struct {
 	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
 	Prototypes    Prototypes       `json:"prototypes,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
+	Templates     StepTemplates    `json:"templates,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
 }
 
@@ -36,6 +37,7 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Prototypes    interface{} `json:"prototypes,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
+		Templates     interface{} `json:"templates,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
 	}
 
@@ -92,6 +94,28 @@ func (c VarSourceConfigs) Lookup(name string) (VarSourceConfig, bool) {
 	return VarSourceConfig{}, false
 }
 
+// Override returns the var sources with any of the same name replaced by the
+// overrides, followed by the rest of the overrides. It is used to let a
+// pipeline override its team's var sources.
+func (c VarSourceConfigs) Override(overrides VarSourceConfigs) VarSourceConfigs {
+	merged := VarSourceConfigs{}
+	for _, vs := range c {
+		if override, found := overrides.Lookup(vs.Name); found {
+			merged = append(merged, override)
+		} else {
+			merged = append(merged, vs)
+		}
+	}
+
+	for _, vs := range overrides {
+		if _, found := c.Lookup(vs.Name); !found {
+			merged = append(merged, vs)
+		}
+	}
+
+	return merged
+}
+
 type pendingVarSource struct {
 	vs   VarSourceConfig
 	deps []string
@@ -176,18 +200,21 @@ func (c VarSourceConfigs) OrderByDependency() (VarSourceConfigs, error) {
 }
 
 type ResourceConfig struct {
-	Name                 string      `json:"name"`
-	OldName              string      `json:"old_name,omitempty"`
-	Public               bool        `json:"public,omitempty"`
-	WebhookToken         string      `json:"webhook_token,omitempty"`
-	Type                 string      `json:"type"`
-	Source               Source      `json:"source"`
-	CheckEvery           *CheckEvery `json:"check_every,omitempty"`
-	CheckTimeout         string      `json:"check_timeout,omitempty"`
-	Tags                 Tags        `json:"tags,omitempty"`
-	Version              Version     `json:"version,omitempty"`
-	Icon                 string      `json:"icon,omitempty"`
-	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+	Name                 string         `json:"name"`
+	OldName              string         `json:"old_name,omitempty"`
+	Public               bool           `json:"public,omitempty"`
+	WebhookToken         string         `json:"webhook_token,omitempty"`
+	WebhookVersion       WebhookVersion `json:"webhook_version,omitempty"`
+	WebhookHeaders       []string       `json:"webhook_headers,omitempty"`
+	WebhookPayload       WebhookPayload `json:"webhook_payload,omitempty"`
+	Type                 string         `json:"type"`
+	Source               Source         `json:"source"`
+	CheckEvery           *CheckEvery    `json:"check_every,omitempty"`
+	CheckTimeout         string         `json:"check_timeout,omitempty"`
+	Tags                 Tags           `json:"tags,omitempty"`
+	Version              Version        `json:"version,omitempty"`
+	Icon                 string         `json:"icon,omitempty"`
+	ExposeBuildCreatedBy bool           `json:"expose_build_created_by,omitempty"`
 }
 
 type ResourceType struct {
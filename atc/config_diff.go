@@ -106,6 +106,21 @@ func (index VarSourceIndex) FindEquivalent(obj interface{}) (interface{}, bool)
 	return VarSourceConfigs(index).Lookup(name(obj))
 }
 
+type TemplateIndex StepTemplates
+
+func (index TemplateIndex) Slice() []interface{} {
+	slice := make([]interface{}, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index TemplateIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
+	return StepTemplates(index).Lookup(name(obj))
+}
+
 type JobIndex JobConfigs
 
 func (index JobIndex) Slice() []interface{} {
@@ -275,6 +290,14 @@ func practicallyDifferent(a, b interface{}) bool {
 }
 
 func (c Config) Diff(out io.Writer, newConfig Config) bool {
+	// Configs fetched from the server have their templates expanded, but
+	// those being set do not yet.
+	return c.withoutTemplateExpansions().DiffExpanded(out, newConfig.withoutTemplateExpansions())
+}
+
+// DiffExpanded is like Diff, but also compares the steps expanded from
+// templates, for when both configs have been expanded.
+func (c Config) DiffExpanded(out io.Writer, newConfig Config) bool {
 	var diffExists bool
 
 	indent := gexec.NewPrefixedWriter("  ", out)
@@ -292,7 +315,7 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 	varSourceDiffs := diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources))
 	if len(varSourceDiffs) > 0 {
 		diffExists = true
-		fmt.Println("variable source:")
+		fmt.Fprintln(out, "variable source:")
 
 		for _, diff := range varSourceDiffs {
 			diff.Render(indent, "variable source")
@@ -319,6 +342,16 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	templateDiffs := diffIndices(TemplateIndex(c.Templates), TemplateIndex(newConfig.Templates))
+	if len(templateDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "templates:")
+
+		for _, diff := range templateDiffs {
+			diff.Render(indent, "template")
+		}
+	}
+
 	jobDiffs := diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))
 	if len(jobDiffs) > 0 {
 		diffExists = true
@@ -0,0 +1,28 @@
+package atc
+
+// ConfigSource is how a revision of a pipeline's config was saved.
+type ConfigSource string
+
+const (
+	// ConfigSourceFly is a config saved through the API, e.g. by
+	// `fly set-pipeline`.
+	ConfigSourceFly ConfigSource = "fly"
+
+	ConfigSourceSetPipeline    ConfigSource = "set_pipeline"
+	ConfigSourcePipelineSource ConfigSource = "pipeline_source"
+)
+
+// ConfigRevision is a config that was saved for a pipeline. A revision is
+// kept for each version of the config.
+type ConfigRevision struct {
+	Version   int          `json:"version"`
+	Source    ConfigSource `json:"source"`
+	CreatedBy string       `json:"created_by,omitempty"`
+	CreatedAt int64        `json:"created_at"`
+
+	// BuildID is the build whose set_pipeline step saved the config.
+	BuildID int `json:"build_id,omitempty"`
+
+	// Config is only included when a single revision is requested.
+	Config *Config `json:"config,omitempty"`
+}
@@ -145,6 +145,22 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("VarSourceConfigs.Override", func() {
+		teamVault := VarSourceConfig{Name: "vault", Type: "vault", Config: map[string]interface{}{"url": "https://team"}}
+		teamSsm := VarSourceConfig{Name: "ssm", Type: "ssm", Config: map[string]interface{}{"region": "us-east-1"}}
+		pipelineVault := VarSourceConfig{Name: "vault", Type: "vault", Config: map[string]interface{}{"url": "https://pipeline"}}
+		pipelineDummy := VarSourceConfig{Name: "dummy", Type: "dummy", Config: map[string]interface{}{}}
+
+		It("replaces the var sources of the same name, and adds the rest", func() {
+			merged := VarSourceConfigs{teamVault, teamSsm}.Override(VarSourceConfigs{pipelineDummy, pipelineVault})
+			Expect(merged).To(Equal(VarSourceConfigs{pipelineVault, teamSsm, pipelineDummy}))
+		})
+
+		It("keeps the var sources when there are no overrides", func() {
+			Expect(VarSourceConfigs{teamVault}.Override(nil)).To(Equal(VarSourceConfigs{teamVault}))
+		})
+	})
+
 	Describe("CheckEvery", func() {
 		Context("when unmarshaling", func() {
 			Context("check_every is never", func() {
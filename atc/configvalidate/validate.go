@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
@@ -74,6 +75,12 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, varSourcesWarnings...)
 
+	templatesWarnings, templatesErr := validateTemplates(c)
+	if templatesErr != nil {
+		errorMessages = append(errorMessages, formatErr("templates", templatesErr))
+	}
+	warnings = append(warnings, templatesWarnings...)
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -206,6 +213,32 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if len(resource.WebhookVersion) > 0 {
+			if resource.WebhookToken == "" {
+				errorMessages = append(errorMessages, identifier+" has a webhook_version but no webhook_token")
+			}
+
+			err := resource.WebhookVersion.Validate()
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has an invalid webhook_version: %s", identifier, err))
+			}
+		}
+
+		if len(resource.WebhookHeaders) > 0 && resource.WebhookToken == "" {
+			errorMessages = append(errorMessages, identifier+" has webhook_headers but no webhook_token")
+		}
+
+		if len(resource.WebhookPayload) > 0 {
+			if resource.WebhookToken == "" {
+				errorMessages = append(errorMessages, identifier+" has a webhook_payload but no webhook_token")
+			}
+
+			err := resource.WebhookPayload.Validate()
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has an invalid webhook_payload: %s", identifier, err))
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -404,11 +437,131 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 		warnings = append(warnings, validator.Warnings...)
 
 		errorMessages = append(errorMessages, validator.Errors...)
+
+		if job.IsMatrix() {
+			matrixWarnings, matrixErrors := validateMatrix(job, identifier)
+			warnings = append(warnings, matrixWarnings...)
+			errorMessages = append(errorMessages, matrixErrors...)
+		}
+
+		if job.Schedule != nil {
+			errorMessages = append(errorMessages, validateSchedule(*job.Schedule, identifier)...)
+		}
+	}
+
+	cellNames := map[string]string{}
+	for _, job := range c.Jobs {
+		cells, err := job.MatrixCells()
+		if err != nil {
+			// reported by validateMatrix
+			continue
+		}
+
+		for _, cell := range cells {
+			if other, exists := names[cell.Name]; exists {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf(
+						"jobs.%s.matrix has a cell with the same name as %s ('%s')",
+						job.Name, other, cell.Name))
+			} else if otherJob, exists := cellNames[cell.Name]; exists && otherJob != job.Name {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf(
+						"jobs.%s.matrix has a cell with the same name as a cell of jobs.%s ('%s')",
+						job.Name, otherJob, cell.Name))
+			} else {
+				// cells of the same job with the same name are reported by
+				// validateMatrix
+				cellNames[cell.Name] = job.Name
+			}
+		}
 	}
 
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateMatrix(job atc.JobConfig, identifier string) ([]atc.ConfigWarning, []string) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	seenVars := map[string]bool{}
+	for i, v := range job.Matrix {
+		varIdentifier := fmt.Sprintf("%s.matrix[%d]", identifier, i)
+
+		warning, err := atc.ValidateIdentifier(v.Var, varIdentifier)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if seenVars[v.Var] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: repeated var name '%s'", varIdentifier, v.Var))
+		}
+		seenVars[v.Var] = true
+
+		if len(v.Values) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: no values specified", varIdentifier))
+		}
+
+		// numbers and booleans are not written out as they appear in the YAML
+		// (e.g. 1.20 would become 1.2), so they must be quoted
+		for j, value := range v.Values {
+			if _, ok := value.(string); !ok {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.values[%d]: must be a string (quote numbers and booleans, e.g. \"1.20\")", varIdentifier, j))
+			}
+		}
+	}
+
+	if len(errorMessages) != 0 {
+		return warnings, errorMessages
+	}
+
+	cells, err := job.MatrixCells()
+	if err != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.matrix: %s", identifier, err))
+		return warnings, errorMessages
+	}
+
+	// the names of the cells are made of the values, which aren't identifiers
+	seenCells := map[string]bool{}
+	for _, cell := range cells {
+		warning, err := atc.ValidateIdentifier(cell.Name, identifier, ".matrix")
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if seenCells[cell.Name] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.matrix: more than one cell is named '%s'", identifier, cell.Name))
+		}
+		seenCells[cell.Name] = true
+	}
+
+	return warnings, errorMessages
+}
+
+func validateSchedule(schedule atc.ScheduleConfig, identifier string) []string {
+	var errorMessages []string
+
+	switch schedule.CatchUp {
+	case "", atc.ScheduleCatchUpOnce, atc.ScheduleCatchUpSkip:
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.schedule.catch_up: must be '%s' or '%s'", identifier, atc.ScheduleCatchUpOnce, atc.ScheduleCatchUpSkip))
+	}
+
+	next, err := schedule.Next(time.Now())
+	if err != nil {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.schedule: %s", identifier, err))
+	} else if next.IsZero() {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.schedule: cron expression '%s' never fires", identifier, schedule.Cron))
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -418,12 +571,18 @@ func compositeErr(errorMessages []string) error {
 }
 
 func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
+	return ValidateVarSources(c.VarSources)
+}
+
+// ValidateVarSources validates var sources on their own, e.g. those declared
+// for a team rather than in a pipeline.
+func ValidateVarSources(varSources atc.VarSourceConfigs) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
 
 	names := map[string]location{}
 
-	for i, varSource := range c.VarSources {
+	for i, varSource := range varSources {
 		location := location{section: "var_sources", index: i}
 		identifier := location.Identifier(varSource.Name)
 
@@ -439,7 +598,7 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 			// TODO: this check should eventually be removed once all credential managers
 			// are supported in pipeline. - @evanchaoli
 			switch varSource.Type {
-			case "vault", "dummy", "ssm", "secretsmanager":
+			case "vault", "dummy", "ssm", "secretsmanager", "sops":
 			default:
 				errorMessages = append(errorMessages, fmt.Sprintf("credential manager type %s is not supported in pipeline yet", varSource.Type))
 			}
@@ -465,13 +624,59 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 		}
 	}
 
-	if _, err := c.VarSources.OrderByDependency(); err != nil {
+	if _, err := varSources.OrderByDependency(); err != nil {
 		errorMessages = append(errorMessages, fmt.Sprintf("failed to order by dependency: %s", err.Error()))
 	}
 
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateTemplates(c atc.Config) ([]atc.ConfigWarning, error) {
+	return ValidateStepTemplates(c.Templates)
+}
+
+// ValidateStepTemplates validates step templates on their own, e.g. those
+// shared by a team rather than declared in a pipeline. The steps themselves
+// are validated where they are used, once their params are known.
+func ValidateStepTemplates(templates atc.StepTemplates) ([]atc.ConfigWarning, error) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	names := map[string]location{}
+
+	for i, template := range templates {
+		location := location{section: "templates", index: i}
+		identifier := location.Identifier(template.Name)
+
+		warning, err := atc.ValidateIdentifier(template.Name, identifier)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if other, exists := names[template.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s and %s have the same name ('%s')",
+					other, location, template.Name))
+		} else if template.Name != "" {
+			names[template.Name] = location
+		}
+
+		if len(template.Steps) == 0 {
+			errorMessages = append(errorMessages, identifier+" has no steps")
+		}
+	}
+
+	if err := templates.CheckCycles(); err != nil {
+		errorMessages = append(errorMessages, err.Error())
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
+
 func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 
@@ -509,6 +509,47 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid templates", func() {
+		Context("when templates have the same name", func() {
+			BeforeEach(func() {
+				config.Templates = atc.StepTemplates{
+					{Name: "unit-tests", Steps: []atc.Step{{Config: &atc.LoadVarStep{Name: "a", File: "a"}}}},
+					{Name: "unit-tests", Steps: []atc.Step{{Config: &atc.LoadVarStep{Name: "b", File: "b"}}}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("templates[0] and templates[1] have the same name ('unit-tests')"))
+			})
+		})
+
+		Context("when a template has no steps", func() {
+			BeforeEach(func() {
+				config.Templates = atc.StepTemplates{{Name: "unit-tests"}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("templates.unit-tests has no steps"))
+			})
+		})
+
+		Context("when templates use each other in a cycle", func() {
+			BeforeEach(func() {
+				config.Templates = atc.StepTemplates{
+					{Name: "a", Steps: []atc.Step{{Config: &atc.UseTemplateStep{Name: "b"}}}},
+					{Name: "b", Steps: []atc.Step{{Config: &atc.UseTemplateStep{Name: "a"}}}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("templates use each other in a cycle: a -> b -> a"))
+			})
+		})
+	})
+
 	Describe("invalid resources", func() {
 		Context("when a resource has no name", func() {
 			BeforeEach(func() {
@@ -555,6 +596,65 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has a webhook_version but no webhook_token", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookVersion = atc.WebhookVersion{"ref": "/after"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a webhook_version but no webhook_token"))
+			})
+		})
+
+		Context("when a resource has a webhook_version with an invalid json pointer", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookToken = "some-token"
+				config.Resources[0].WebhookVersion = atc.WebhookVersion{"ref": "after"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an invalid webhook_version"))
+			})
+		})
+
+		Context("when a resource has webhook_headers but no webhook_token", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookHeaders = []string{"X-GitHub-Event"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has webhook_headers but no webhook_token"))
+			})
+		})
+
+		Context("when a resource has a webhook_payload but no webhook_token", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookPayload = atc.WebhookPayload{"ref": "/ref"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a webhook_payload but no webhook_token"))
+			})
+		})
+
+		Context("when a resource has a webhook_payload with an invalid json pointer", func() {
+			BeforeEach(func() {
+				config.Resources[0].WebhookToken = "some-token"
+				config.Resources[0].WebhookPayload = atc.WebhookPayload{"ref": "ref"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an invalid webhook_payload"))
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -969,6 +1069,184 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Cron:     "0 9 * * mon-fri",
+					Location: "America/Toronto",
+					CatchUp:  atc.ScheduleCatchUpSkip,
+				}
+			})
+
+			Context("when the schedule is valid", func() {
+				BeforeEach(func() {
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when the cron expression is invalid", func() {
+				BeforeEach(func() {
+					job.Schedule.Cron = "0 9 * *"
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule: invalid cron expression '0 9 * *': expected 5 fields, got 4"))
+				})
+			})
+
+			Context("when the cron expression never fires", func() {
+				BeforeEach(func() {
+					job.Schedule.Cron = "0 0 31 feb *"
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule: cron expression '0 0 31 feb *' never fires"))
+				})
+			})
+
+			Context("when the location is unknown", func() {
+				BeforeEach(func() {
+					job.Schedule.Location = "Mars/Olympus_Mons"
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule: invalid location 'Mars/Olympus_Mons'"))
+				})
+			})
+
+			Context("when the catch-up policy is unknown", func() {
+				BeforeEach(func() {
+					job.Schedule.CatchUp = "all"
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule.catch_up: must be 'once' or 'skip'"))
+				})
+			})
+		})
+
+		Context("when a job has a matrix", func() {
+			BeforeEach(func() {
+				job.Matrix = []atc.MatrixVarConfig{
+					{Var: "go_version", Values: []interface{}{"1.21", "1.22"}},
+					{Var: "os", Values: []interface{}{"linux"}},
+				}
+			})
+
+			Context("when the matrix is valid", func() {
+				BeforeEach(func() {
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a matrix var has no values", func() {
+				BeforeEach(func() {
+					job.Matrix[1].Values = nil
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix[1]: no values specified"))
+				})
+			})
+
+			Context("when a matrix var is repeated", func() {
+				BeforeEach(func() {
+					job.Matrix[1].Var = "go_version"
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix[1]: repeated var name 'go_version'"))
+				})
+			})
+
+			Context("when a matrix value is a number", func() {
+				BeforeEach(func() {
+					// as parsed from an unquoted 1.20
+					job.Matrix[0].Values = []interface{}{"1.19", 1.2}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring(`jobs.some-other-job.matrix[0].values[1]: must be a string (quote numbers and booleans, e.g. "1.20")`))
+				})
+			})
+
+			Context("when a matrix value is not a scalar", func() {
+				BeforeEach(func() {
+					job.Matrix[1].Values = []interface{}{true, map[string]interface{}{"foo": "bar"}}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each value", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix[1].values[0]: must be a string"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix[1].values[1]: must be a string"))
+				})
+			})
+
+			Context("when two matrix cells have the same name", func() {
+				BeforeEach(func() {
+					job.Matrix[0].Values = []interface{}{"1.21-linux", "1.21"}
+					job.Matrix[1].Values = []interface{}{"linux", "linux-linux"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix: more than one cell is named 'some-other-job-1.21-linux-linux'"))
+				})
+			})
+
+			Context("when a matrix cell's name is not a valid identifier", func() {
+				BeforeEach(func() {
+					job.Matrix[1].Values = []interface{}{"Linux"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns a warning", func() {
+					Expect(errorMessages).To(HaveLen(0))
+					Expect(warnings).To(ContainElement(atc.ConfigWarning{
+						Type:    "invalid_identifier",
+						Message: "jobs.some-other-job.matrix: 'some-other-job-1.21-Linux' is not a valid identifier: illegal character 'L'",
+					}))
+				})
+			})
+
+			Context("when a matrix cell has the same name as another job", func() {
+				BeforeEach(func() {
+					config.Jobs = append(config.Jobs, job, atc.JobConfig{
+						Name: "some-other-job-1.22-linux",
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.matrix has a cell with the same name as jobs[3] ('some-other-job-1.22-linux')"))
+				})
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1779,6 +2057,26 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid condition in an if step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.IfStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: "((branch)) ==",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].if: invalid condition '((branch)) ==': unexpected end of condition"))
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1926,6 +2224,189 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a load_var has both a file and a dir", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarStep{
+							Name: "some-var",
+							File: "some-artifact/some-file.json",
+							Dir:  "some-artifact/some-dir",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(some-var): cannot specify both file and dir"))
+				})
+			})
+
+			Context("when a load_var has an invalid select", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.LoadVarStep{
+							Name:   "some-var",
+							File:   "some-artifact/some-file.json",
+							Select: "$.releases[0",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].load_var(some-var): invalid selector '$.releases[0': missing ']'"))
+				})
+			})
+
+			Context("when a use_template step expands to an invalid step", func() {
+				BeforeEach(func() {
+					config.Templates = atc.StepTemplates{
+						{
+							Name:   "fetch",
+							Params: map[string]interface{}{"resource": nil},
+							Steps: []atc.Step{
+								{Config: &atc.GetStep{Name: "((param:resource))"}},
+							},
+						},
+					}
+
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.UseTemplateStep{
+							Name:   "fetch",
+							Params: atc.Params{"resource": "bogus-resource"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error with the location in the template", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].use_template(fetch).steps[0].get(bogus-resource): unknown resource 'bogus-resource' (from templates.fetch.steps[0].get(bogus-resource))"))
+				})
+			})
+
+			Context("when a use_template step is missing params", func() {
+				BeforeEach(func() {
+					config.Templates = atc.StepTemplates{
+						{
+							Name:   "fetch",
+							Params: map[string]interface{}{"resource": nil},
+							Steps: []atc.Step{
+								{Config: &atc.GetStep{Name: "((param:resource))"}},
+							},
+						},
+					}
+
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.UseTemplateStep{Name: "fetch"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].use_template(fetch): template 'fetch' is missing params: resource"))
+				})
+			})
+
+			Context("when a use_template step uses a team template which has been expanded", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.UseTemplateStep{
+							Name: "shared",
+							Steps: []atc.Step{
+								{Config: &atc.LoadVarStep{Name: "some-var"}},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error with the location in the team template", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].use_template(shared).steps[0].load_var(some-var): no file specified (from team templates.shared.steps[0].load_var(some-var))"))
+				})
+			})
+
+			Context("when a set_var has no name or file defined", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.SetVarStep{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_var(): no file specified"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].set_var(): identifier cannot be an empty string"))
+				})
+			})
+
+			Context("when an approval has an unknown approver", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name:      "qa",
+							Approvers: []string{"member", "github:some-user", "admins"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(qa).approvers[2]: unknown role 'admins'; must be one of owner, member, pipeline-operator, or a user given as 'connector:user'"))
+				})
+			})
+
+			Context("when an approval has a role that can't approve", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name:      "qa",
+							Approvers: []string{"viewer"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(qa).approvers[0]: unknown role 'viewer'"))
+				})
+			})
+
+			Context("when an approval has an invalid user", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.ApprovalStep{
+							Name:      "qa",
+							Approvers: []string{":some-user", "github:", "github:some user"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each of them", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(qa).approvers[0]: invalid user ':some-user'; users must be given as 'connector:user'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(qa).approvers[1]: invalid user 'github:'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].approval(qa).approvers[2]: invalid user 'github:some user'"))
+				})
+			})
+
 			Context("when two load_var steps have same name", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
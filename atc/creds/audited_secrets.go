@@ -0,0 +1,129 @@
+package creds
+
+import (
+	"time"
+)
+
+// SecretAccess records that a build read a secret. It never includes the
+// secret's value.
+type SecretAccess struct {
+	TeamID       int
+	TeamName     string
+	PipelineID   int
+	PipelineName string
+	BuildID      int
+	BuildName    string
+	JobName      string
+	StepName     string
+
+	// VarName is the ((var)) which was being resolved, and Manager and Path
+	// are the credential manager and secret path it was resolved from.
+	VarName string
+	Manager string
+	Path    string
+
+	AccessedAt time.Time
+}
+
+//counterfeiter:generate . SecretAccessSink
+type SecretAccessSink interface {
+	SecretAccessed(SecretAccess)
+}
+
+// SecretAccessSinks sends each access to all of the sinks.
+type SecretAccessSinks []SecretAccessSink
+
+func (sinks SecretAccessSinks) SecretAccessed(access SecretAccess) {
+	for _, sink := range sinks {
+		sink.SecretAccessed(access)
+	}
+}
+
+type AuditedSecrets struct {
+	secrets Secrets
+	sink    SecretAccessSink
+	access  SecretAccess
+}
+
+// NewAuditedSecrets records each secret found by secrets to the sink, along
+// with the given access context. Each of the credential managers of
+// MultiSecrets is audited separately, so that they can still be reordered.
+func NewAuditedSecrets(secrets Secrets, sink SecretAccessSink, access SecretAccess) Secrets {
+	if _, ok := secrets.(MultiSecrets); !ok {
+		return &AuditedSecrets{
+			secrets: secrets,
+			sink:    sink,
+			access:  access,
+		}
+	}
+
+	audited := SecretsChain{}
+	for _, named := range Members(secrets, access.Manager) {
+		managerAccess := access
+		managerAccess.Manager = named.Name
+
+		audited = append(audited, NamedSecrets{
+			Name: named.Name,
+			Secrets: &AuditedSecrets{
+				secrets: named.Secrets,
+				sink:    sink,
+				access:  managerAccess,
+			},
+		})
+	}
+
+	return audited
+}
+
+// AuditVarSource audits the secrets of a pipeline's var_source in the same
+// context as the global secrets, if they are audited.
+func AuditVarSource(globalSecrets Secrets, secrets Secrets, varSource string) Secrets {
+	var audited *AuditedSecrets
+	if members := Members(globalSecrets, ""); len(members) > 0 {
+		audited, _ = members[0].Secrets.(*AuditedSecrets)
+	}
+
+	if audited == nil {
+		return secrets
+	}
+
+	access := audited.access
+	access.Manager = varSource
+
+	return &AuditedSecrets{
+		secrets: secrets,
+		sink:    audited.sink,
+		access:  access,
+	}
+}
+
+func (as *AuditedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, err := as.secrets.Get(secretPath)
+	if err == nil && found {
+		access := as.access
+		access.Path = secretPath
+		access.AccessedAt = time.Now()
+
+		as.sink.SecretAccessed(access)
+	}
+
+	return value, expiration, found, err
+}
+
+// Set writes the secret to the underlying secrets. Writes are not recorded, as
+// they are already subject to policy checks.
+func (as *AuditedSecrets) Set(secretPath string, value interface{}) error {
+	return SetSecret(as.secrets, secretPath, value)
+}
+
+func (as *AuditedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	return as.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+}
+
+// forVar returns a copy of the secrets which attributes accesses to the
+// given var.
+func (as *AuditedSecrets) forVar(varName string) *AuditedSecrets {
+	clone := *as
+	clone.access.VarName = varName
+	return &clone
+}
@@ -0,0 +1,111 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditedSecrets", func() {
+	var (
+		fakeSink *credsfakes.FakeSecretAccessSink
+		access   creds.SecretAccess
+	)
+
+	BeforeEach(func() {
+		fakeSink = new(credsfakes.FakeSecretAccessSink)
+		access = creds.SecretAccess{
+			TeamName:     "main",
+			PipelineName: "pipeline",
+			BuildID:      42,
+			StepName:     "some-task",
+		}
+	})
+
+	dummySecrets := func(name string, value string) creds.Secrets {
+		return dummy.NewSecretsFactory([]dummy.VarFlag{
+			{Name: name, Value: value},
+		}).NewSecrets()
+	}
+
+	It("records the var and the secret path it was found at, but not the value", func() {
+		secrets := creds.NewAuditedSecrets(dummySecrets("main/pipeline/foo", "some-value"), fakeSink, access)
+
+		value, found, err := creds.NewVariables(secrets, "main", "pipeline", false).Get(vars.Reference{Path: "foo"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-value"))
+
+		Expect(fakeSink.SecretAccessedCallCount()).To(Equal(1))
+		recorded := fakeSink.SecretAccessedArgsForCall(0)
+		Expect(recorded.AccessedAt).ToNot(BeZero())
+
+		recorded.AccessedAt = access.AccessedAt
+		Expect(recorded).To(Equal(creds.SecretAccess{
+			TeamName:     "main",
+			PipelineName: "pipeline",
+			BuildID:      42,
+			StepName:     "some-task",
+			VarName:      "foo",
+			Path:         "main/pipeline/foo",
+		}))
+	})
+
+	It("does not record secrets which are not found", func() {
+		secrets := creds.NewAuditedSecrets(dummySecrets("main/pipeline/foo", "some-value"), fakeSink, access)
+
+		_, found, err := creds.NewVariables(secrets, "main", "pipeline", false).Get(vars.Reference{Path: "bar"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		Expect(fakeSink.SecretAccessedCallCount()).To(Equal(0))
+	})
+
+	Context("when the secrets are a chain", func() {
+		var secrets creds.Secrets
+
+		BeforeEach(func() {
+			secrets = creds.NewAuditedSecrets(creds.SecretsChain{
+				{Name: "vault", Secrets: dummySecrets("main/pipeline/foo", "vault-foo")},
+				{Name: "credhub", Secrets: dummySecrets("main/bar", "credhub-bar")},
+			}, fakeSink, access)
+		})
+
+		It("remains a chain, recording the credential manager which found the secret", func() {
+			Expect(secrets).To(BeAssignableToTypeOf(creds.SecretsChain{}))
+
+			_, found, err := creds.NewVariables(secrets, "main", "pipeline", false).Get(vars.Reference{Path: "bar"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeSink.SecretAccessedCallCount()).To(Equal(1))
+			recorded := fakeSink.SecretAccessedArgsForCall(0)
+			Expect(recorded.Manager).To(Equal("credhub"))
+			Expect(recorded.Path).To(Equal("main/bar"))
+			Expect(recorded.VarName).To(Equal("bar"))
+		})
+
+		It("audits var_sources in the same context", func() {
+			varSource := creds.AuditVarSource(secrets, dummySecrets("baz", "some-value"), "some-var-source")
+
+			_, found, err := creds.NewVariables(varSource, "main", "pipeline", true).Get(vars.Reference{Path: "baz"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeSink.SecretAccessedCallCount()).To(Equal(1))
+			recorded := fakeSink.SecretAccessedArgsForCall(0)
+			Expect(recorded.BuildID).To(Equal(42))
+			Expect(recorded.Manager).To(Equal("some-var-source"))
+			Expect(recorded.Path).To(Equal("baz"))
+		})
+	})
+
+	It("leaves var_sources alone when the global secrets are not audited", func() {
+		varSource := dummySecrets("baz", "some-value")
+		Expect(creds.AuditVarSource(dummySecrets("foo", "bar"), varSource, "some-var-source")).To(BeIdenticalTo(varSource))
+	})
+})
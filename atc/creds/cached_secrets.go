@@ -77,3 +77,16 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
 	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
 }
+
+// Set writes the secret to the underlying secret manager and evicts it from
+// the cache, so that the new value is read back.
+func (cs *CachedSecrets) Set(secretPath string, value interface{}) error {
+	err := SetSecret(cs.secrets, secretPath, value)
+	if err != nil {
+		return err
+	}
+
+	cs.cache.Delete(secretPath)
+
+	return nil
+}
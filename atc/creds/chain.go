@@ -0,0 +1,101 @@
+package creds
+
+import (
+	"errors"
+	"time"
+)
+
+// NamedSecrets are the secrets of a credential manager in a SecretsChain.
+type NamedSecrets struct {
+	Name    string
+	Secrets Secrets
+}
+
+// MultiSecrets are made up of the secrets of a number of credential managers,
+// each of which has its own lookup paths.
+type MultiSecrets interface {
+	Secrets
+
+	Members() []NamedSecrets
+}
+
+// Members returns the credential managers that make up the secrets, which are
+// those of a MultiSecrets, or else the secrets themselves under the given
+// name. Anything which looks up secrets by their lookup paths has to do so
+// for each of them.
+func Members(secrets Secrets, name string) []NamedSecrets {
+	if multi, ok := secrets.(MultiSecrets); ok {
+		return multi.Members()
+	}
+
+	return []NamedSecrets{{Name: name, Secrets: secrets}}
+}
+
+// SecretsChain looks up secrets in each of a number of credential managers in
+// turn, e.g. to migrate from one credential manager to another.
+type SecretsChain []NamedSecrets
+
+func (chain SecretsChain) Members() []NamedSecrets {
+	return chain
+}
+
+// Get retrieves the value of an individual secret from the first credential
+// manager which has it.
+func (chain SecretsChain) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	for _, named := range chain {
+		value, expiration, found, err := named.Secrets.Get(secretPath)
+		if found || err != nil {
+			return value, expiration, found, err
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+// NewSecretLookupPaths can't give the lookup paths of a chain, as each of its
+// credential managers has its own. Its lookup paths fail rather than looking
+// up vars as they are; they have to be looked up through Members instead.
+func (chain SecretsChain) NewSecretLookupPaths(string, string, bool) []SecretLookupPath {
+	return []SecretLookupPath{chainLookupPath{}}
+}
+
+type chainLookupPath struct{}
+
+func (chainLookupPath) VariableToSecretPath(string) (string, error) {
+	return "", errors.New("the vars of a chain of credential managers must be looked up in each of them")
+}
+
+// Names returns the names of the credential managers in lookup order.
+func (chain SecretsChain) Names() []string {
+	names := []string{}
+	for _, named := range chain {
+		names = append(names, named.Name)
+	}
+
+	return names
+}
+
+// Reorder returns a chain which looks up the named credential managers first,
+// in the given order, followed by the rest in their original order. Names
+// which are not in the chain are ignored.
+func (chain SecretsChain) Reorder(precedence []string) SecretsChain {
+	reordered := SecretsChain{}
+	used := map[string]bool{}
+
+	for _, name := range precedence {
+		for _, named := range chain {
+			if named.Name == name && !used[name] {
+				reordered = append(reordered, named)
+				used[name] = true
+			}
+		}
+	}
+
+	for _, named := range chain {
+		if !used[named.Name] {
+			reordered = append(reordered, named)
+		}
+	}
+
+	return reordered
+}
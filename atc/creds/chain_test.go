@@ -0,0 +1,112 @@
+package creds_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretsChain", func() {
+	var chain creds.SecretsChain
+
+	BeforeEach(func() {
+		chain = creds.SecretsChain{
+			{
+				Name: "vault",
+				Secrets: dummy.NewSecretsFactory([]dummy.VarFlag{
+					{Name: "main/pipeline/foo", Value: "vault-foo"},
+				}).NewSecrets(),
+			},
+			{
+				Name: "credhub",
+				Secrets: dummy.NewSecretsFactory([]dummy.VarFlag{
+					{Name: "main/pipeline/foo", Value: "credhub-foo"},
+					{Name: "main/bar", Value: "credhub-bar"},
+				}).NewSecrets(),
+			},
+		}
+	})
+
+	get := func(secrets creds.Secrets, name string) interface{} {
+		value, found, err := creds.NewVariables(secrets, "main", "pipeline", false).Get(vars.Reference{Path: name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return value
+	}
+
+	It("looks up each credential manager in turn with its own lookup paths", func() {
+		Expect(get(chain, "foo")).To(Equal("vault-foo"))
+		Expect(get(chain, "bar")).To(Equal("credhub-bar"))
+	})
+
+	It("does not find missing secrets", func() {
+		_, found, err := creds.NewVariables(chain, "main", "pipeline", false).Get(vars.Reference{Path: "baz"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("does not look up vars as they are without its lookup paths", func() {
+		_, _, err := creds.VariableLookupFromSecrets{
+			Secrets:     chain,
+			LookupPaths: chain.NewSecretLookupPaths("main", "pipeline", false),
+		}.Get(vars.Reference{Path: "main/bar"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("Members", func() {
+		It("returns the credential managers of the chain", func() {
+			Expect(creds.Members(chain, "other")).To(Equal([]creds.NamedSecrets(chain)))
+		})
+
+		It("returns other secrets under the given name", func() {
+			secrets := chain[0].Secrets
+			Expect(creds.Members(secrets, "vault")).To(Equal([]creds.NamedSecrets{{Name: "vault", Secrets: secrets}}))
+		})
+	})
+
+	Describe("Reorder", func() {
+		It("looks up the given credential managers first", func() {
+			reordered := chain.Reorder([]string{"credhub"})
+			Expect(reordered.Names()).To(Equal([]string{"credhub", "vault"}))
+			Expect(get(reordered, "foo")).To(Equal("credhub-foo"))
+		})
+
+		It("ignores unknown credential managers", func() {
+			Expect(chain.Reorder([]string{"bogus", "vault"}).Names()).To(Equal([]string{"vault", "credhub"}))
+		})
+	})
+
+	Describe("VarSourcePool.TeamSecrets", func() {
+		var varSourcePool creds.VarSourcePool
+
+		BeforeEach(func() {
+			varSourcePool = creds.NewVarSourcePool(lagertest.NewTestLogger("test"), creds.CredentialManagementConfig{}, time.Minute, time.Minute, fakeclock.NewFakeClock(time.Now()))
+		})
+
+		AfterEach(func() {
+			varSourcePool.Close()
+		})
+
+		It("applies the team's precedence", func() {
+			secrets := varSourcePool.TeamSecrets(chain, []string{"credhub", "vault"})
+			Expect(get(secrets, "foo")).To(Equal("credhub-foo"))
+		})
+
+		It("keeps the cluster's order if the team has none", func() {
+			secrets := varSourcePool.TeamSecrets(chain, nil)
+			Expect(get(secrets, "foo")).To(Equal("vault-foo"))
+		})
+
+		It("leaves a single credential manager alone", func() {
+			single := chain[1].Secrets
+			Expect(varSourcePool.TeamSecrets(single, []string{"vault"})).To(Equal(single))
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeSecretAccessSink struct {
+	SecretAccessedStub        func(creds.SecretAccess)
+	secretAccessedMutex       sync.RWMutex
+	secretAccessedArgsForCall []struct {
+		arg1 creds.SecretAccess
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessSink) SecretAccessed(arg1 creds.SecretAccess) {
+	fake.secretAccessedMutex.Lock()
+	fake.secretAccessedArgsForCall = append(fake.secretAccessedArgsForCall, struct {
+		arg1 creds.SecretAccess
+	}{arg1})
+	stub := fake.SecretAccessedStub
+	fake.recordInvocation("SecretAccessed", []interface{}{arg1})
+	fake.secretAccessedMutex.Unlock()
+	if stub != nil {
+		fake.SecretAccessedStub(arg1)
+	}
+}
+
+func (fake *FakeSecretAccessSink) SecretAccessedCallCount() int {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	return len(fake.secretAccessedArgsForCall)
+}
+
+func (fake *FakeSecretAccessSink) SecretAccessedCalls(stub func(creds.SecretAccess)) {
+	fake.secretAccessedMutex.Lock()
+	defer fake.secretAccessedMutex.Unlock()
+	fake.SecretAccessedStub = stub
+}
+
+func (fake *FakeSecretAccessSink) SecretAccessedArgsForCall(i int) creds.SecretAccess {
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	argsForCall := fake.secretAccessedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessSink) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.secretAccessedMutex.RLock()
+	defer fake.secretAccessedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessSink) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.SecretAccessSink = new(FakeSecretAccessSink)
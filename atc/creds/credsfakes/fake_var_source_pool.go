@@ -38,6 +38,18 @@ type FakeVarSourcePool struct {
 	sizeReturnsOnCall map[int]struct {
 		result1 int
 	}
+	TeamSecretsStub        func(creds.Secrets, []string) creds.Secrets
+	teamSecretsMutex       sync.RWMutex
+	teamSecretsArgsForCall []struct {
+		arg1 creds.Secrets
+		arg2 []string
+	}
+	teamSecretsReturns struct {
+		result1 creds.Secrets
+	}
+	teamSecretsReturnsOnCall map[int]struct {
+		result1 creds.Secrets
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -185,6 +197,73 @@ func (fake *FakeVarSourcePool) SizeReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeVarSourcePool) TeamSecrets(arg1 creds.Secrets, arg2 []string) creds.Secrets {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.teamSecretsMutex.Lock()
+	ret, specificReturn := fake.teamSecretsReturnsOnCall[len(fake.teamSecretsArgsForCall)]
+	fake.teamSecretsArgsForCall = append(fake.teamSecretsArgsForCall, struct {
+		arg1 creds.Secrets
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.TeamSecretsStub
+	fakeReturns := fake.teamSecretsReturns
+	fake.recordInvocation("TeamSecrets", []interface{}{arg1, arg2Copy})
+	fake.teamSecretsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVarSourcePool) TeamSecretsCallCount() int {
+	fake.teamSecretsMutex.RLock()
+	defer fake.teamSecretsMutex.RUnlock()
+	return len(fake.teamSecretsArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) TeamSecretsCalls(stub func(creds.Secrets, []string) creds.Secrets) {
+	fake.teamSecretsMutex.Lock()
+	defer fake.teamSecretsMutex.Unlock()
+	fake.TeamSecretsStub = stub
+}
+
+func (fake *FakeVarSourcePool) TeamSecretsArgsForCall(i int) (creds.Secrets, []string) {
+	fake.teamSecretsMutex.RLock()
+	defer fake.teamSecretsMutex.RUnlock()
+	argsForCall := fake.teamSecretsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVarSourcePool) TeamSecretsReturns(result1 creds.Secrets) {
+	fake.teamSecretsMutex.Lock()
+	defer fake.teamSecretsMutex.Unlock()
+	fake.TeamSecretsStub = nil
+	fake.teamSecretsReturns = struct {
+		result1 creds.Secrets
+	}{result1}
+}
+
+func (fake *FakeVarSourcePool) TeamSecretsReturnsOnCall(i int, result1 creds.Secrets) {
+	fake.teamSecretsMutex.Lock()
+	defer fake.teamSecretsMutex.Unlock()
+	fake.TeamSecretsStub = nil
+	if fake.teamSecretsReturnsOnCall == nil {
+		fake.teamSecretsReturnsOnCall = make(map[int]struct {
+			result1 creds.Secrets
+		})
+	}
+	fake.teamSecretsReturnsOnCall[i] = struct {
+		result1 creds.Secrets
+	}{result1}
+}
+
 func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -194,6 +273,8 @@ func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateMutex.RUnlock()
 	fake.sizeMutex.RLock()
 	defer fake.sizeMutex.RUnlock()
+	fake.teamSecretsMutex.RLock()
+	defer fake.teamSecretsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
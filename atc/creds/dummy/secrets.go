@@ -2,12 +2,17 @@ package dummy
 
 import (
 	"path"
+	"sync"
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/vars"
 )
 
+// varsLock guards the vars, which are shared between all of the secrets made
+// by a factory and may be written to with Set.
+var varsLock sync.RWMutex
+
 type Secrets struct {
 	vars.StaticVariables
 
@@ -29,6 +34,9 @@ func (secrets *Secrets) NewSecretLookupPaths(teamName string, pipelineName strin
 }
 
 func (secrets *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	varsLock.RLock()
+	defer varsLock.RUnlock()
+
 	v, found, err := secrets.StaticVariables.Get(vars.Reference{Path: secretPath})
 	if err != nil {
 		return nil, nil, false, err
@@ -40,3 +48,14 @@ func (secrets *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, e
 
 	return nil, nil, false, nil
 }
+
+// Set stores the value in memory. It is only visible to the secrets made by
+// the same factory, and is lost when the web node restarts.
+func (secrets *Secrets) Set(secretPath string, value interface{}) error {
+	varsLock.Lock()
+	defer varsLock.Unlock()
+
+	secrets.StaticVariables[secretPath] = value
+
+	return nil
+}
@@ -50,6 +50,7 @@ func (example Example) Assert(vs vars.Variables) {
 
 var _ = Describe("Kubernetes", func() {
 	var fakeClientset *fake.Clientset
+	var secrets creds.Secrets
 	var vs vars.Variables
 
 	var secretName = "some-secret-name"
@@ -63,7 +64,8 @@ var _ = Describe("Kubernetes", func() {
 			"prefix-",
 		)
 
-		vs = creds.NewVariables(factory.NewSecrets(), "some-team", "some-pipeline", false)
+		secrets = factory.NewSecrets()
+		vs = creds.NewVariables(secrets, "some-team", "some-pipeline", false)
 	})
 
 	DescribeTable("var lookup", func(ex Example) {
@@ -145,4 +147,36 @@ var _ = Describe("Kubernetes", func() {
 			Result:   "some-field-value",
 		}),
 	)
+	Describe("Set", func() {
+		It("creates the secret, which can then be read back", func() {
+			err := creds.SetSecret(secrets, "prefix-some-team/some-pipeline."+secretName, "some-value")
+			Expect(err).ToNot(HaveOccurred())
+
+			value, found, err := vs.Get(vars.Reference{Path: secretName})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		})
+
+		It("updates an existing secret", func() {
+			_, err := fakeClientset.CoreV1().Secrets("prefix-some-team").Create(context.TODO(), &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: secretName,
+				},
+				Data: map[string][]byte{
+					"value": []byte("old-value"),
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = creds.SetSecret(secrets, "prefix-some-team/"+secretName, map[string]interface{}{"some-field": "new-value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			secret, err := fakeClientset.CoreV1().Secrets("prefix-some-team").Get(context.TODO(), secretName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string][]byte{
+				"some-field": []byte("new-value"),
+			}))
+		})
+	})
 })
@@ -95,3 +95,65 @@ func (secrets Secrets) findSecret(namespace, name string) (*v1.Secret, bool, err
 		return secret, true, err
 	}
 }
+
+// Set creates or updates the secret at the given [namespace]/[secret] path. A
+// map is stored with each of its fields as a key of the secret, and anything
+// else under the "value" key.
+func (secrets Secrets) Set(secretPath string, value interface{}) error {
+	parts := strings.Split(secretPath, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("unable to split kubernetes secret path into [namespace]/[secret]: %s", secretPath)
+	}
+
+	var namespace = parts[0]
+	var secretName = parts[1]
+
+	data := map[string][]byte{}
+	if fields, ok := value.(map[string]interface{}); ok {
+		for k, v := range fields {
+			str, err := creds.SecretString(v)
+			if err != nil {
+				return err
+			}
+
+			data[k] = []byte(str)
+		}
+	} else {
+		str, err := creds.SecretString(value)
+		if err != nil {
+			return err
+		}
+
+		data["value"] = []byte(str)
+	}
+
+	client := secrets.client.CoreV1().Secrets(namespace)
+
+	secret, found, err := secrets.findSecret(namespace, secretName)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		secret.Data = data
+		_, err = client.Update(context.TODO(), secret, metav1.UpdateOptions{})
+	} else {
+		_, err = client.Create(context.TODO(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: namespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+	}
+
+	if err != nil {
+		secrets.logger.Error("failed-to-write-secret", err, lager.Data{
+			"namespace":   namespace,
+			"secret-name": secretName,
+		})
+		return err
+	}
+
+	return nil
+}
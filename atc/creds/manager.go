@@ -25,6 +25,11 @@ type Managers map[string]Manager
 type CredentialManagementConfig struct {
 	RetryConfig SecretRetryConfig
 	CacheConfig SecretCacheConfig
+
+	// Order is the order in which credentials are looked up in the
+	// configured credential managers. If it is empty, only the first
+	// configured credential manager is used.
+	Order []string `long:"credential-manager" description:"Name of a credential manager to look up credentials in. Can be specified multiple times to look up credentials in each of them, in the given order."`
 }
 
 // NewSecrets creates a Secrets object from secretsFactory based on configs.
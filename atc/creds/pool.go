@@ -15,6 +15,11 @@ import (
 //counterfeiter:generate . VarSourcePool
 type VarSourcePool interface {
 	FindOrCreate(lager.Logger, map[string]interface{}, ManagerFactory) (Secrets, error)
+
+	// TeamSecrets applies a team's precedence of credential managers to the
+	// global secrets, if they are a chain of credential managers.
+	TeamSecrets(globalSecrets Secrets, precedence []string) Secrets
+
 	Size() int
 	Close()
 }
@@ -116,6 +121,15 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]i
 	return pool.pool[key].getSecrets(), nil
 }
 
+func (pool *varSourcePool) TeamSecrets(globalSecrets Secrets, precedence []string) Secrets {
+	members := Members(globalSecrets, "")
+	if len(members) < 2 || len(precedence) == 0 {
+		return globalSecrets
+	}
+
+	return SecretsChain(members).Reorder(precedence)
+}
+
 func (pool *varSourcePool) Close() {
 	pool.closeOnce.Do(func() {
 		close(pool.closed)
@@ -0,0 +1,76 @@
+package creds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+// Resolve looks up a reference the same way as the vars.Variables returned by
+// NewVariables, recording each of the secret paths it looks up along the way.
+// The manager is the name reported for the secrets, unless they are made up
+// of a number of credential managers.
+func Resolve(secrets Secrets, manager string, teamName string, pipelineName string, allowRootPath bool, ref vars.Reference) atc.SecretReference {
+	reference := atc.SecretReference{
+		Name:      ref.String(),
+		VarSource: ref.Source,
+		Lookups:   []atc.SecretLookup{},
+	}
+
+	for _, named := range Members(secrets, manager) {
+		resolve(&reference, named.Secrets, named.Name, teamName, pipelineName, allowRootPath, ref)
+		if reference.Resolved || reference.Error != "" {
+			break
+		}
+	}
+
+	return reference
+}
+
+func resolve(reference *atc.SecretReference, secrets Secrets, manager string, teamName string, pipelineName string, allowRootPath bool, ref vars.Reference) {
+	secretPaths := []string{}
+
+	lookupPaths := secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+	if len(lookupPaths) == 0 {
+		// same as VariableLookupFromSecrets, which falls back on looking up
+		// the var as is
+		secretPaths = append(secretPaths, ref.Path)
+	}
+
+	for _, rule := range lookupPaths {
+		secretPath, err := rule.VariableToSecretPath(ref.Path)
+		if err != nil {
+			reference.Error = err.Error()
+			return
+		}
+
+		secretPaths = append(secretPaths, secretPath)
+	}
+
+	for _, secretPath := range secretPaths {
+		reference.Lookups = append(reference.Lookups, atc.SecretLookup{
+			Manager: manager,
+			Path:    secretPath,
+		})
+
+		value, _, found, err := secrets.Get(secretPath)
+		if err != nil {
+			reference.Error = err.Error()
+			return
+		}
+
+		if !found {
+			continue
+		}
+
+		_, err = vars.Traverse(value, ref.String(), ref.Fields)
+		if err != nil {
+			reference.Error = err.Error()
+			return
+		}
+
+		reference.Resolved = true
+		reference.Manager = manager
+		reference.Path = secretPath
+		return
+	}
+}
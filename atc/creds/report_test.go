@@ -0,0 +1,74 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolve", func() {
+	var secrets creds.Secrets
+
+	BeforeEach(func() {
+		secrets = dummy.NewSecretsFactory([]dummy.VarFlag{
+			{Name: "main/foo", Value: "team-foo"},
+			{Name: "main/creds", Value: map[string]interface{}{"username": "admin"}},
+		}).NewSecrets()
+	})
+
+	It("records each lookup up to the one that resolves the reference", func() {
+		Expect(creds.Resolve(secrets, "dummy", "main", "pipeline", false, vars.Reference{Path: "foo"})).To(Equal(atc.SecretReference{
+			Name: "foo",
+			Lookups: []atc.SecretLookup{
+				{Manager: "dummy", Path: "main/pipeline/foo"},
+				{Manager: "dummy", Path: "main/foo"},
+			},
+			Resolved: true,
+			Manager:  "dummy",
+			Path:     "main/foo",
+		}))
+	})
+
+	It("records every lookup of a missing reference", func() {
+		reference := creds.Resolve(secrets, "dummy", "main", "pipeline", false, vars.Reference{Path: "bar"})
+		Expect(reference.Resolved).To(BeFalse())
+		Expect(reference.Lookups).To(HaveLen(3))
+		Expect(reference.Manager).To(BeEmpty())
+	})
+
+	It("resolves fields of the secret", func() {
+		reference := creds.Resolve(secrets, "dummy", "main", "", false, vars.Reference{Path: "creds", Fields: []string{"username"}})
+		Expect(reference.Resolved).To(BeTrue())
+		Expect(reference.Name).To(Equal("creds.username"))
+	})
+
+	It("reports missing fields of the secret as errors", func() {
+		reference := creds.Resolve(secrets, "dummy", "main", "", false, vars.Reference{Path: "creds", Fields: []string{"password"}})
+		Expect(reference.Resolved).To(BeFalse())
+		Expect(reference.Error).ToNot(BeEmpty())
+	})
+
+	Context("with a chain of credential managers", func() {
+		BeforeEach(func() {
+			secrets = creds.SecretsChain{
+				{Name: "vault", Secrets: dummy.NewSecretsFactory(nil).NewSecrets()},
+				{Name: "credhub", Secrets: secrets},
+			}
+		})
+
+		It("reports the credential manager which resolves the reference", func() {
+			reference := creds.Resolve(secrets, "", "main", "", false, vars.Reference{Path: "foo"})
+			Expect(reference.Resolved).To(BeTrue())
+			Expect(reference.Manager).To(Equal("credhub"))
+			Expect(reference.Lookups).To(Equal([]atc.SecretLookup{
+				{Manager: "vault", Path: "main/foo"},
+				{Manager: "vault", Path: "foo"},
+				{Manager: "credhub", Path: "main/foo"},
+			}))
+		})
+	})
+})
@@ -43,3 +43,9 @@ func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool
 func (rs RetryableSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
 	return rs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
 }
+
+// Set writes the secret to the underlying secret manager, if it is writable.
+// Writes are not retried, as they may not be idempotent.
+func (rs RetryableSecrets) Set(secretPath string, value interface{}) error {
+	return SetSecret(rs.secrets, secretPath, value)
+}
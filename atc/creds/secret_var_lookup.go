@@ -9,14 +9,29 @@ type VariableLookupFromSecrets struct {
 	LookupPaths []SecretLookupPath
 }
 
+// NewVariables looks up vars in each of the credential managers that make up
+// the secrets in turn, each with its own lookup paths.
 func NewVariables(secrets Secrets, teamName string, pipelineName string, allowRootPath bool) vars.Variables {
-	return VariableLookupFromSecrets{
-		Secrets:     secrets,
-		LookupPaths: secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath),
+	varss := []vars.Variables{}
+	for _, named := range Members(secrets, "") {
+		varss = append(varss, VariableLookupFromSecrets{
+			Secrets:     named.Secrets,
+			LookupPaths: named.Secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath),
+		})
 	}
+
+	if len(varss) == 1 {
+		return varss[0]
+	}
+
+	return vars.NewMultiVars(varss)
 }
 
 func (sl VariableLookupFromSecrets) Get(ref vars.Reference) (interface{}, bool, error) {
+	if audited, ok := sl.Secrets.(*AuditedSecrets); ok {
+		sl.Secrets = audited.forVar(ref.String())
+	}
+
 	val, found, err := sl.get(ref.Path)
 	if err != nil {
 		return nil, false, err
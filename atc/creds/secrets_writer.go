@@ -0,0 +1,135 @@
+package creds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNoPipelineToSetVar is returned when setting a var outside of a pipeline,
+// e.g. in a one-off build, where it would be written to a path shared by the
+// whole team.
+var ErrNoPipelineToSetVar = errors.New("vars can only be set by the builds of a pipeline")
+
+// A SecretsWriter is a credential manager which secrets can be written to,
+// e.g. by a set_var step. Only some credential managers implement it.
+//
+//counterfeiter:generate . SecretsWriter
+type SecretsWriter interface {
+	// Set stores the value at the secret path, replacing any existing value.
+	Set(secretPath string, value interface{}) error
+}
+
+// SecretsNotWritableError is returned when writing to a credential manager
+// which does not support it.
+type SecretsNotWritableError struct {
+	Manager string
+}
+
+func (err SecretsNotWritableError) Error() string {
+	if err.Manager == "" {
+		return "the credential manager does not support writing secrets"
+	}
+
+	return fmt.Sprintf("the credential manager '%s' does not support writing secrets", err.Manager)
+}
+
+// SetSecret writes the value to secrets if they are writable.
+func SetSecret(secrets Secrets, secretPath string, value interface{}) error {
+	writer, ok := secrets.(SecretsWriter)
+	if !ok {
+		return SecretsNotWritableError{}
+	}
+
+	return writer.Set(secretPath, value)
+}
+
+// SecretDestination is where a var will be written to.
+type SecretDestination struct {
+	Manager string
+	Path    string
+
+	secrets Secrets
+}
+
+// NewSecretDestination determines the credential manager and secret path
+// which a var will be written to. Of the credential managers that make up the
+// secrets, the named one is used, or else the first one. The var is written
+// to the first of the manager's lookup paths, i.e. the most specific one, so
+// that it takes precedence when it is read back.
+func NewSecretDestination(secrets Secrets, manager string, teamName string, pipelineName string, varName string) (SecretDestination, error) {
+	if pipelineName == "" {
+		return SecretDestination{}, ErrNoPipelineToSetVar
+	}
+
+	members := Members(secrets, manager)
+	if len(members) == 0 {
+		return SecretDestination{}, fmt.Errorf("no credential manager configured")
+	}
+
+	named := members[0]
+	if manager != "" {
+		found := false
+		for _, candidate := range members {
+			if candidate.Name == manager {
+				named = candidate
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return SecretDestination{}, fmt.Errorf("unknown credential manager '%s'", manager)
+		}
+	}
+
+	manager = named.Name
+	secrets = named.Secrets
+
+	if secrets == nil {
+		return SecretDestination{}, fmt.Errorf("no credential manager configured")
+	}
+
+	secretPath := varName
+	for _, lookupPath := range secrets.NewSecretLookupPaths(teamName, pipelineName, false) {
+		var err error
+		secretPath, err = lookupPath.VariableToSecretPath(varName)
+		if err != nil {
+			return SecretDestination{}, err
+		}
+
+		break
+	}
+
+	return SecretDestination{
+		Manager: manager,
+		Path:    secretPath,
+		secrets: secrets,
+	}, nil
+}
+
+// Set writes the value to the destination.
+func (dest SecretDestination) Set(value interface{}) error {
+	err := SetSecret(dest.secrets, dest.Path, value)
+	if notWritable, ok := err.(SecretsNotWritableError); ok {
+		notWritable.Manager = dest.Manager
+		return notWritable
+	}
+
+	return err
+}
+
+// SecretString converts a value to a string for credential managers which
+// can only store strings. Anything other than a string is stored as JSON.
+func SecretString(value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
@@ -0,0 +1,107 @@
+package creds_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/atc/creds/noop"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretDestination", func() {
+	var (
+		vaultSecrets   creds.Secrets
+		credhubSecrets creds.Secrets
+	)
+
+	BeforeEach(func() {
+		vaultSecrets = dummy.NewSecretsFactory(nil).NewSecrets()
+		credhubSecrets = dummy.NewSecretsFactory(nil).NewSecrets()
+	})
+
+	It("writes to the most specific lookup path, so the var is read back", func() {
+		dest, err := creds.NewSecretDestination(vaultSecrets, "", "main", "pipeline", "token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dest.Path).To(Equal("main/pipeline/token"))
+
+		err = dest.Set("some-token")
+		Expect(err).ToNot(HaveOccurred())
+
+		value, found, err := creds.NewVariables(vaultSecrets, "main", "pipeline", false).Get(vars.Reference{Path: "token"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-token"))
+	})
+
+	It("writes through the cache, evicting the old value", func() {
+		cached := creds.NewCachedSecrets(vaultSecrets, creds.SecretCacheConfig{Duration: time.Minute, DurationNotFound: time.Minute})
+		variables := creds.NewVariables(cached, "main", "pipeline", false)
+
+		_, found, err := variables.Get(vars.Reference{Path: "token"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		dest, err := creds.NewSecretDestination(cached, "", "main", "pipeline", "token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dest.Set("some-token")).To(Succeed())
+
+		value, found, err := variables.Get(vars.Reference{Path: "token"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-token"))
+	})
+
+	Context("with a chain", func() {
+		var chain creds.SecretsChain
+
+		BeforeEach(func() {
+			chain = creds.SecretsChain{
+				{Name: "vault", Secrets: vaultSecrets},
+				{Name: "credhub", Secrets: credhubSecrets},
+			}
+		})
+
+		It("defaults to the first credential manager", func() {
+			dest, err := creds.NewSecretDestination(chain, "", "main", "pipeline", "token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dest.Manager).To(Equal("vault"))
+			Expect(dest.Path).To(Equal("main/pipeline/token"))
+		})
+
+		It("uses the named credential manager", func() {
+			dest, err := creds.NewSecretDestination(chain, "credhub", "main", "pipeline", "token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dest.Manager).To(Equal("credhub"))
+
+			Expect(dest.Set("some-token")).To(Succeed())
+
+			_, _, found, err := credhubSecrets.Get("main/pipeline/token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("errors for an unknown credential manager", func() {
+			_, err := creds.NewSecretDestination(chain, "bogus", "main", "pipeline", "token")
+			Expect(err).To(MatchError("unknown credential manager 'bogus'"))
+		})
+	})
+
+	It("errors outside of a pipeline, rather than setting the var for the whole team", func() {
+		_, err := creds.NewSecretDestination(vaultSecrets, "", "main", "", "token")
+		Expect(err).To(Equal(creds.ErrNoPipelineToSetVar))
+	})
+
+	It("errors when the credential manager is not writable", func() {
+		dest, err := creds.NewSecretDestination(creds.SecretsChain{
+			{Name: "noop", Secrets: noop.Noop{}},
+		}, "", "main", "pipeline", "token")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = dest.Set("some-token")
+		Expect(err).To(Equal(creds.SecretsNotWritableError{Manager: "noop"}))
+	})
+})
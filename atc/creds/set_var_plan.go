@@ -0,0 +1,33 @@
+package creds
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+type SetVarPlan struct {
+	variablesResolver vars.Variables
+	rawPlan           atc.SetVarPlan
+}
+
+func NewSetVarPlan(variables vars.Variables, plan atc.SetVarPlan) SetVarPlan {
+	return SetVarPlan{
+		variablesResolver: variables,
+		rawPlan:           plan,
+	}
+}
+
+func (s SetVarPlan) Evaluate() (atc.SetVarPlan, error) {
+	var plan atc.SetVarPlan
+
+	// Name of set_var should not be interpolated.
+	name := s.rawPlan.Name
+
+	err := evaluate(s.variablesResolver, s.rawPlan, &plan)
+	if err != nil {
+		return atc.SetVarPlan{}, err
+	}
+	plan.Name = name
+
+	return plan, nil
+}
@@ -0,0 +1,40 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetVarPlan", func() {
+	var plan creds.SetVarPlan
+
+	BeforeEach(func() {
+		variables := vars.StaticVariables{
+			"var-name": "vn-is",
+			"filename": "fn-is",
+			"manager":  "vault",
+		}
+		plan = creds.NewSetVarPlan(variables, atc.SetVarPlan{
+			Name:    "some-((var-name))-ok",
+			File:    "some-((filename))-ok",
+			Manager: "((manager))",
+		})
+	})
+
+	Describe("Evaluate", func() {
+		It("parses variables", func() {
+			result, err := plan.Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.SetVarPlan{
+				Name:    "some-((var-name))-ok", // Name should not be interpolated.
+				File:    "some-fn-is-ok",
+				Manager: "vault",
+			}))
+		})
+	})
+})
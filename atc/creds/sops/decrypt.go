@@ -0,0 +1,450 @@
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"gopkg.in/yaml.v2"
+)
+
+// metadataKey is the key under which SOPS keeps the encrypted data key and
+// other metadata in each file.
+const metadataKey = "sops"
+
+var encryptedValueRegex = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+var ErrNotEncrypted = errors.New("file has no sops metadata")
+
+var ErrNoMAC = errors.New("file has no mac")
+
+var ErrMACMismatch = errors.New("file does not match its mac")
+
+type dataKeyError struct {
+	errs []error
+}
+
+func (err dataKeyError) Error() string {
+	if len(err.errs) == 0 {
+		return "no age or pgp keys to decrypt the data key with"
+	}
+
+	messages := []string{}
+	for _, e := range err.errs {
+		messages = append(messages, e.Error())
+	}
+
+	return "failed to decrypt the data key: " + strings.Join(messages, "; ")
+}
+
+// Keys are the private keys with which files are decrypted.
+type Keys struct {
+	Age []age.Identity
+	PGP openpgp.EntityList
+}
+
+type metadata struct {
+	Age []struct {
+		Recipient string `json:"recipient"`
+		Enc       string `json:"enc"`
+	} `json:"age"`
+
+	PGP []struct {
+		Fingerprint string `json:"fp"`
+		Enc         string `json:"enc"`
+	} `json:"pgp"`
+
+	LastModified     string `json:"lastmodified"`
+	MAC              string `json:"mac"`
+	MACOnlyEncrypted bool   `json:"mac_only_encrypted"`
+
+	UnencryptedSuffix       string `json:"unencrypted_suffix"`
+	EncryptedSuffix         string `json:"encrypted_suffix"`
+	UnencryptedRegex        string `json:"unencrypted_regex"`
+	EncryptedRegex          string `json:"encrypted_regex"`
+	UnencryptedCommentRegex string `json:"unencrypted_comment_regex"`
+	EncryptedCommentRegex   string `json:"encrypted_comment_regex"`
+}
+
+// macOnlyEncryptedInitialization is written to the MAC first when it is only
+// over the encrypted values, so that it differs from a MAC over all of them.
+// It is the SHA-256 of "sops", as sops uses.
+var macOnlyEncryptedInitialization = []byte{
+	0x8a, 0x3f, 0xd2, 0xad, 0x54, 0xce, 0x66, 0x52, 0x7b, 0x10, 0x34, 0xf3, 0xd1, 0x47, 0xbe, 0x0b,
+	0x0b, 0x97, 0x5b, 0x3b, 0xf4, 0x4f, 0x72, 0xc6, 0xfd, 0xad, 0xec, 0x81, 0x76, 0xf2, 0x7d, 0x69,
+}
+
+// decrypt decrypts a document read from a SOPS-encrypted YAML or JSON file,
+// returning it without its metadata.
+//
+// Each value is authenticated against its path in the document as it is
+// decrypted, and the document as a whole against its MAC, as sops does. The
+// MAC is over the values in the order they are in the file, which is why the
+// document is given as a yaml.MapSlice.
+func decrypt(doc yaml.MapSlice, keys Keys) (map[string]interface{}, error) {
+	var rawMetadata interface{}
+	found := false
+	for _, item := range doc {
+		if item.Key == metadataKey {
+			rawMetadata = item.Value
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, ErrNotEncrypted
+	}
+
+	var meta metadata
+	err := remarshal(plain(rawMetadata), &meta)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sops metadata: %w", err)
+	}
+
+	dataKey, err := meta.dataKey(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := meta.decrypter(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := map[string]interface{}{}
+	for _, item := range doc {
+		key := fmt.Sprint(item.Key)
+		if key == metadataKey {
+			continue
+		}
+
+		decrypted[key], err = d.decryptValue(item.Value, []string{key})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = meta.verify(dataKey, fmt.Sprintf("%X", d.mac.Sum(nil)))
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypted, nil
+}
+
+// verify checks the MAC computed over the decrypted document against the one
+// in the metadata, which is encrypted with the time the file was last
+// modified as its additional data.
+func (meta metadata) verify(dataKey []byte, mac string) error {
+	match := encryptedValueRegex.FindStringSubmatch(meta.MAC)
+	if match == nil {
+		return ErrNoMAC
+	}
+
+	lastModified, err := time.Parse(time.RFC3339, meta.LastModified)
+	if err != nil {
+		return fmt.Errorf("invalid sops metadata: lastmodified: %w", err)
+	}
+
+	expected, err := decryptString(match, dataKey, lastModified.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt the mac: %w", err)
+	}
+
+	if expected != mac {
+		return ErrMACMismatch
+	}
+
+	return nil
+}
+
+func (meta metadata) dataKey(keys Keys) ([]byte, error) {
+	var errs []error
+
+	if len(keys.Age) > 0 {
+		for _, entry := range meta.Age {
+			reader, err := age.Decrypt(armor.NewReader(strings.NewReader(entry.Enc)), keys.Age...)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("age recipient %s: %w", entry.Recipient, err))
+				continue
+			}
+
+			return io.ReadAll(reader)
+		}
+	}
+
+	if len(keys.PGP) > 0 {
+		for _, entry := range meta.PGP {
+			message, err := readArmoredMessage(entry.Enc, keys.PGP)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pgp key %s: %w", entry.Fingerprint, err))
+				continue
+			}
+
+			return message, nil
+		}
+	}
+
+	return nil, dataKeyError{errs}
+}
+
+func readArmoredMessage(enc string, keyring openpgp.EntityList) ([]byte, error) {
+	block, err := pgparmor.Decode(strings.NewReader(enc))
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := openpgp.ReadMessage(block.Body, keyring, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(message.UnverifiedBody)
+}
+
+func (meta metadata) decrypter(dataKey []byte) (*decrypter, error) {
+	// comments are not kept when the document is parsed, so there is no
+	// telling which values these would have left unencrypted
+	if meta.UnencryptedCommentRegex != "" || meta.EncryptedCommentRegex != "" {
+		return nil, errors.New("files encrypted with unencrypted_comment_regex or encrypted_comment_regex are not supported")
+	}
+
+	d := &decrypter{
+		dataKey:           dataKey,
+		mac:               sha512.New(),
+		macOnlyEncrypted:  meta.MACOnlyEncrypted,
+		unencryptedSuffix: meta.UnencryptedSuffix,
+		encryptedSuffix:   meta.EncryptedSuffix,
+	}
+
+	var err error
+	if meta.UnencryptedRegex != "" {
+		d.unencryptedRegex, err = regexp.Compile(meta.UnencryptedRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sops metadata: unencrypted_regex: %w", err)
+		}
+	}
+
+	if meta.EncryptedRegex != "" {
+		d.encryptedRegex, err = regexp.Compile(meta.EncryptedRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sops metadata: encrypted_regex: %w", err)
+		}
+	}
+
+	if d.macOnlyEncrypted {
+		d.mac.Write(macOnlyEncryptedInitialization)
+	}
+
+	return d, nil
+}
+
+// decrypter decrypts the values of a document, adding each of them to the
+// document's MAC as it goes.
+type decrypter struct {
+	dataKey          []byte
+	mac              hash.Hash
+	macOnlyEncrypted bool
+
+	unencryptedSuffix string
+	encryptedSuffix   string
+	unencryptedRegex  *regexp.Regexp
+	encryptedRegex    *regexp.Regexp
+}
+
+// encrypted returns whether sops encrypted the value at the given path, by
+// the same rules as sops applies.
+func (d *decrypter) encrypted(path []string) bool {
+	encrypted := true
+
+	if d.unencryptedSuffix != "" && anyKey(path, func(key string) bool { return strings.HasSuffix(key, d.unencryptedSuffix) }) {
+		encrypted = false
+	}
+
+	if d.encryptedSuffix != "" {
+		encrypted = anyKey(path, func(key string) bool { return strings.HasSuffix(key, d.encryptedSuffix) })
+	}
+
+	if d.unencryptedRegex != nil && anyKey(path, d.unencryptedRegex.MatchString) {
+		encrypted = false
+	}
+
+	if d.encryptedRegex != nil {
+		encrypted = anyKey(path, d.encryptedRegex.MatchString)
+	}
+
+	return encrypted
+}
+
+func anyKey(path []string, match func(string) bool) bool {
+	for _, key := range path {
+		if match(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addToMAC adds a value to the MAC unless it is only over the encrypted
+// values and the value was not encrypted.
+func (d *decrypter) addToMAC(value interface{}, encrypted bool) {
+	if !d.macOnlyEncrypted || encrypted {
+		d.mac.Write(macBytes(value))
+	}
+}
+
+func (d *decrypter) decryptValue(value interface{}, path []string) (interface{}, error) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		decrypted := map[string]interface{}{}
+		for _, item := range v {
+			key := fmt.Sprint(item.Key)
+
+			var err error
+			decrypted[key], err = d.decryptValue(item.Value, append(path[:len(path):len(path)], key))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return decrypted, nil
+
+	case []interface{}:
+		// the items of a list are encrypted with the path of the list
+		decrypted := []interface{}{}
+		for _, child := range v {
+			plain, err := d.decryptValue(child, path)
+			if err != nil {
+				return nil, err
+			}
+
+			decrypted = append(decrypted, plain)
+		}
+
+		return decrypted, nil
+
+	case nil:
+		// sops neither encrypts nor authenticates null values
+		return nil, nil
+
+	default:
+		encrypted := d.encrypted(path)
+		if !encrypted {
+			// e.g. a key with the unencrypted suffix
+			d.addToMAC(v, false)
+			return v, nil
+		}
+
+		plain, err := d.decryptLeaf(v, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt '%s': %w", strings.Join(path, "."), err)
+		}
+
+		d.addToMAC(plain, true)
+
+		return plain, nil
+	}
+}
+
+func (d *decrypter) decryptLeaf(value interface{}, path []string) (interface{}, error) {
+	ciphertext, ok := value.(string)
+	if !ok {
+		return nil, errors.New("value is not encrypted")
+	}
+
+	// sops leaves empty strings as they are
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	match := encryptedValueRegex.FindStringSubmatch(ciphertext)
+	if match == nil {
+		return nil, errors.New("value is not encrypted")
+	}
+
+	plaintext, err := decryptString(match, d.dataKey, strings.Join(path, ":")+":")
+	if err != nil {
+		return nil, err
+	}
+
+	return typedValue(plaintext, match[4])
+}
+
+// macBytes is how sops adds a value to the MAC.
+func macBytes(value interface{}) []byte {
+	switch v := value.(type) {
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		if v {
+			return []byte("True")
+		}
+		return []byte("False")
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+func decryptString(match []string, dataKey []byte, additionalData string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(match[1])
+	if err != nil {
+		return "", err
+	}
+
+	iv, err := base64.StdEncoding.DecodeString(match[2])
+	if err != nil {
+		return "", err
+	}
+
+	tag, err := base64.StdEncoding.DecodeString(match[3])
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func typedValue(plaintext string, valueType string) (interface{}, error) {
+	switch valueType {
+	case "str", "bytes":
+		return plaintext, nil
+	case "int":
+		return strconv.Atoi(plaintext)
+	case "float":
+		return strconv.ParseFloat(plaintext, 64)
+	case "bool":
+		return strconv.ParseBool(plaintext)
+	default:
+		return nil, fmt.Errorf("unknown value type '%s'", valueType)
+	}
+}
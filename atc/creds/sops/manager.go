@@ -0,0 +1,240 @@
+package sops
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const DefaultPipelineSecretTemplate = "{{.Team}}/{{.Pipeline}}/{{.Secret}}"
+const DefaultTeamSecretTemplate = "{{.Team}}/{{.Secret}}"
+const DefaultReloadInterval = 10 * time.Second
+
+type Manager struct {
+	Dir string `mapstructure:"dir" long:"dir" description:"Directory containing SOPS-encrypted YAML or JSON files. Each file's path within the directory, without its extension, is the path of its contents."`
+
+	// a var_source's dir is found within the var source dir, so that it can
+	// only read the files set aside for var sources on the web node
+	VarSourceDir string `mapstructure:"-" long:"var-source-dir" description:"Directory within which the dirs of var sources are found. var sources can only use SOPS if it is set."`
+
+	// the key files are only configurable cluster-wide, so that a var_source
+	// can't make use of the keys on the web node
+	AgeKeyFile string `mapstructure:"-" long:"age-key-file" description:"Path to a file containing age identities to decrypt the files with"`
+	AgeKey     string `mapstructure:"age_key" long:"age-key" description:"age identities to decrypt the files with"`
+	PGPKeyFile string `mapstructure:"-" long:"pgp-key-file" description:"Path to a file containing an armored PGP private keyring to decrypt the files with"`
+	PGPKey     string `mapstructure:"pgp_key" long:"pgp-key" description:"Armored PGP private keyring to decrypt the files with"`
+
+	PipelineSecretTemplate string        `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" description:"Path template used for pipeline specific secrets" default:"{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
+	TeamSecretTemplate     string        `mapstructure:"team_secret_template" long:"team-secret-template" description:"Path template used for team specific secrets" default:"{{.Team}}/{{.Secret}}"`
+	SharedPath             string        `mapstructure:"shared_path" long:"shared-path" description:"Path used for shared secrets"`
+	ReloadInterval         time.Duration `mapstructure:"reload_interval" long:"reload-interval" default:"10s" description:"Interval on which to check the files for changes"`
+
+	store *Store
+	done  chan struct{}
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"dir":                      manager.Dir,
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"shared_path":              manager.SharedPath,
+		"reload_interval":          manager.ReloadInterval.String(),
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	keys, err := manager.keys()
+	if err != nil {
+		log.Error("failed-to-parse-keys", err)
+		return err
+	}
+
+	manager.store = NewStore(manager.Dir, keys)
+
+	err = manager.store.Load()
+	if err != nil {
+		log.Error("failed-to-load-files", err)
+		return err
+	}
+
+	manager.done = make(chan struct{})
+	go manager.reload(log.Session("reload"), manager.store, manager.done)
+
+	return nil
+}
+
+func (manager *Manager) reload(logger lager.Logger, store *Store, done <-chan struct{}) {
+	interval := manager.ReloadInterval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := store.Changed()
+		if err != nil {
+			logger.Error("failed-to-check-files", err)
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		err = store.Load()
+		if err != nil {
+			logger.Error("failed-to-reload-files", err)
+			continue
+		}
+
+		logger.Info("reloaded")
+	}
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "Stat",
+	}
+
+	_, err := os.Stat(manager.Dir)
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Dir != ""
+}
+
+func (manager *Manager) Validate() error {
+	if manager.Dir == "" {
+		return errors.New("must provide a directory")
+	}
+
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	keys, err := manager.keys()
+	if err != nil {
+		return err
+	}
+
+	if len(keys.Age) == 0 && len(keys.PGP) == 0 {
+		return errors.New("must provide an age or pgp key")
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	if manager.store == nil {
+		return nil, errors.New("sops credential manager is not initialized")
+	}
+
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSopsFactory(NewSops(manager.store, []*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate}, manager.SharedPath)), nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+	if manager.done != nil {
+		close(manager.done)
+		manager.done = nil
+	}
+}
+
+func (manager *Manager) keys() (Keys, error) {
+	var keys Keys
+
+	ageKeys, err := readKey(manager.AgeKey, manager.AgeKeyFile)
+	if err != nil {
+		return Keys{}, err
+	}
+
+	for _, key := range ageKeys {
+		identities, err := age.ParseIdentities(strings.NewReader(key))
+		if err != nil {
+			return Keys{}, fmt.Errorf("invalid age key: %w", err)
+		}
+
+		keys.Age = append(keys.Age, identities...)
+	}
+
+	pgpKeys, err := readKey(manager.PGPKey, manager.PGPKeyFile)
+	if err != nil {
+		return Keys{}, err
+	}
+
+	for _, key := range pgpKeys {
+		keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(key))
+		if err != nil {
+			return Keys{}, fmt.Errorf("invalid pgp key: %w", err)
+		}
+
+		keys.PGP = append(keys.PGP, keyring...)
+	}
+
+	return keys, nil
+}
+
+func readKey(key string, keyFile string) ([]string, error) {
+	keys := []string{}
+	if key != "" {
+		keys = append(keys, key)
+	}
+
+	if keyFile != "" {
+		payload, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, string(payload))
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,87 @@
+package sops
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
+)
+
+type managerFactory struct {
+	// cluster is the cluster-wide manager, which has the var source dir
+	cluster *Manager
+}
+
+func init() {
+	creds.Register("sops", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("SOPS Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "sops"
+
+	factory.cluster = manager
+
+	return manager
+}
+
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &Manager{
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+		ReloadInterval:         DefaultReloadInterval,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		DecodeHook:  mapstructure.StringToTimeDurationHookFunc(),
+		Result:      &manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var root string
+	if factory.cluster != nil {
+		root = factory.cluster.VarSourceDir
+	}
+
+	manager.Dir, err = varSourceDir(root, manager.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// varSourceDir resolves the dir of a var source within the var source dir.
+// It can't be absolute or lead outside of it.
+func varSourceDir(root string, dir string) (string, error) {
+	if root == "" {
+		return "", errors.New("var sources can't use sops as no var source dir is configured")
+	}
+
+	if !filepath.IsLocal(dir) {
+		return "", fmt.Errorf("dir '%s' must be a relative path within the var source dir", dir)
+	}
+
+	return filepath.Join(root, dir), nil
+}
@@ -0,0 +1,363 @@
+package sops_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/sops"
+	"github.com/concourse/concourse/vars"
+	flags "github.com/jessevdk/go-flags"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		dir      string
+		identity *age.X25519Identity
+		entity   *openpgp.Entity
+		logger   *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		identity, err = age.GenerateX25519Identity()
+		Expect(err).ToNot(HaveOccurred())
+
+		entity, err = openpgp.NewEntity("concourse", "", "concourse@example.com", nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails on an empty manager", func() {
+			var manager sops.Manager
+			_, err := flags.ParseArgs(&manager, []string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if a directory is set", func() {
+			manager := sops.Manager{Dir: dir}
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		var manager sops.Manager
+
+		BeforeEach(func() {
+			manager = sops.Manager{}
+			_, err := flags.ParseArgs(&manager, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.Dir = dir
+			manager.AgeKey = identity.String()
+		})
+
+		It("passes with an age key", func() {
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("passes with a pgp key", func() {
+			manager.AgeKey = ""
+			manager.PGPKey = armoredPrivateKey(entity)
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("passes with an age key file", func() {
+			keyFile := filepath.Join(GinkgoT().TempDir(), "keys.txt")
+			Expect(os.WriteFile(keyFile, []byte(identity.String()+"\n"), 0600)).To(Succeed())
+
+			manager.AgeKey = ""
+			manager.AgeKeyFile = keyFile
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("fails without a key", func() {
+			manager.AgeKey = ""
+			Expect(manager.Validate()).To(MatchError("must provide an age or pgp key"))
+		})
+
+		It("fails with an invalid age key", func() {
+			manager.AgeKey = "bogus"
+			Expect(manager.Validate()).To(MatchError(ContainSubstring("invalid age key")))
+		})
+
+		It("fails without a directory", func() {
+			manager.Dir = ""
+			Expect(manager.Validate()).To(MatchError("must provide a directory"))
+		})
+
+		It("fails with an invalid secret template", func() {
+			manager.TeamSecretTemplate = "{{.Bogus}}"
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Describe("NewInstance()", func() {
+		var (
+			factory creds.ManagerFactory
+			root    string
+		)
+
+		BeforeEach(func() {
+			factory = sops.NewManagerFactory()
+			root = filepath.Dir(dir)
+
+			parser := flags.NewParser(&struct{}{}, flags.None)
+			parser.NamespaceDelimiter = "-"
+			factory.AddConfig(parser.Group)
+
+			_, err := parser.ParseArgs([]string{"--sops-var-source-dir", root})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("configures the manager", func() {
+			manager, err := factory.NewInstance(map[string]interface{}{
+				"dir":             filepath.Base(dir),
+				"age_key":         identity.String(),
+				"shared_path":     "shared",
+				"reload_interval": "1m",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager).To(Equal(&sops.Manager{
+				Dir:                    dir,
+				AgeKey:                 identity.String(),
+				SharedPath:             "shared",
+				PipelineSecretTemplate: sops.DefaultPipelineSecretTemplate,
+				TeamSecretTemplate:     sops.DefaultTeamSecretTemplate,
+				ReloadInterval:         time.Minute,
+			}))
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("does not allow key files to be configured", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"dir":          filepath.Base(dir),
+				"age_key_file": "/etc/concourse/age.txt",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails on unknown keys", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"dir":   filepath.Base(dir),
+				"bogus": "value",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("does not allow an absolute dir", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"dir": dir,
+			})
+			Expect(err).To(MatchError(ContainSubstring("must be a relative path within the var source dir")))
+		})
+
+		It("does not allow a dir outside of the var source dir", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"dir": "../etc",
+			})
+			Expect(err).To(MatchError(ContainSubstring("must be a relative path within the var source dir")))
+		})
+
+		It("fails if no var source dir is configured", func() {
+			_, err := sops.NewManagerFactory().NewInstance(map[string]interface{}{
+				"dir": filepath.Base(dir),
+			})
+			Expect(err).To(MatchError(ContainSubstring("no var source dir is configured")))
+		})
+	})
+
+	Describe("secrets", func() {
+		var (
+			manager *sops.Manager
+			secrets creds.Secrets
+		)
+
+		BeforeEach(func() {
+			writeEncrypted(filepath.Join(dir, "main", "pipeline.yml"), map[string]interface{}{
+				"foo": "pipeline-foo",
+			}, identity, nil)
+
+			writeEncrypted(filepath.Join(dir, "main.yaml"), map[string]interface{}{
+				"foo": "team-foo",
+				"bar": map[string]interface{}{
+					"username": "admin",
+					"port":     8080,
+					"enabled":  true,
+					"hosts":    []interface{}{"a", "b"},
+				},
+			}, nil, entity)
+
+			writeEncrypted(filepath.Join(dir, "shared.yml"), map[string]interface{}{
+				"baz": "shared-baz",
+			}, identity, entity)
+
+			manager = &sops.Manager{
+				Dir:                    dir,
+				AgeKey:                 identity.String(),
+				PGPKey:                 armoredPrivateKey(entity),
+				PipelineSecretTemplate: sops.DefaultPipelineSecretTemplate,
+				TeamSecretTemplate:     sops.DefaultTeamSecretTemplate,
+				SharedPath:             "shared",
+				ReloadInterval:         10 * time.Millisecond,
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(manager.Init(logger)).To(Succeed())
+
+			secretsFactory, err := manager.NewSecretsFactory(logger)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets = secretsFactory.NewSecrets()
+		})
+
+		AfterEach(func() {
+			manager.Close(logger)
+		})
+
+		lookup := func(teamName, pipelineName, name string, fields ...string) (interface{}, bool) {
+			value, found, err := creds.NewVariables(secrets, teamName, pipelineName, false).Get(vars.Reference{Path: name, Fields: fields})
+			Expect(err).ToNot(HaveOccurred())
+			return value, found
+		}
+
+		get := func(teamName, pipelineName, name string, fields ...string) interface{} {
+			value, found := lookup(teamName, pipelineName, name, fields...)
+			Expect(found).To(BeTrue())
+			return value
+		}
+
+		It("looks up pipeline secrets before team secrets", func() {
+			Expect(get("main", "pipeline", "foo")).To(Equal("pipeline-foo"))
+			Expect(get("main", "other", "foo")).To(Equal("team-foo"))
+			Expect(get("main", "", "foo")).To(Equal("team-foo"))
+		})
+
+		It("decrypts typed and nested values", func() {
+			Expect(get("main", "pipeline", "bar", "username")).To(Equal("admin"))
+			Expect(get("main", "pipeline", "bar", "port")).To(Equal(8080))
+			Expect(get("main", "pipeline", "bar", "enabled")).To(Equal(true))
+			Expect(get("main", "pipeline", "bar", "hosts")).To(Equal([]interface{}{"a", "b"}))
+		})
+
+		It("looks up shared secrets", func() {
+			Expect(get("other", "pipeline", "baz")).To(Equal("shared-baz"))
+		})
+
+		It("does not find the secrets of other teams", func() {
+			_, found := lookup("other", "pipeline", "foo")
+			Expect(found).To(BeFalse())
+		})
+
+		It("looks up secrets at the root when allowed", func() {
+			value, found, err := creds.NewVariables(secrets, "other", "", true).Get(vars.Reference{Path: "main/foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-foo"))
+		})
+
+		It("reloads files when they change", func() {
+			writeEncrypted(filepath.Join(dir, "main", "pipeline.yml"), map[string]interface{}{
+				"foo": "new-pipeline-foo",
+			}, identity, nil)
+
+			Eventually(func() interface{} {
+				value, _ := lookup("main", "pipeline", "foo")
+				return value
+			}).Should(Equal("new-pipeline-foo"))
+		})
+
+		It("keeps the previous secrets if a file fails to decrypt", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("foo: bar\n"), 0644)).To(Succeed())
+
+			Consistently(func() interface{} {
+				value, _ := lookup("main", "pipeline", "foo")
+				return value
+			}, 100*time.Millisecond).Should(Equal("pipeline-foo"))
+		})
+	})
+
+	Describe("Init()", func() {
+		It("fails if a file cannot be decrypted with the keys", func() {
+			other, err := age.GenerateX25519Identity()
+			Expect(err).ToNot(HaveOccurred())
+
+			writeEncrypted(filepath.Join(dir, "main.yml"), map[string]interface{}{
+				"foo": "bar",
+			}, other, nil)
+
+			manager := &sops.Manager{Dir: dir, AgeKey: identity.String()}
+			Expect(manager.Init(logger)).To(MatchError(ContainSubstring("failed to decrypt the data key")))
+		})
+
+		Context("when a file has been tampered with", func() {
+			var path string
+
+			BeforeEach(func() {
+				path = filepath.Join(dir, "main.yml")
+				writeEncrypted(path, map[string]interface{}{
+					"foo": "bar",
+					"baz": "qux",
+				}, identity, nil)
+			})
+
+			edit := func(change func(doc map[string]interface{})) {
+				payload, err := os.ReadFile(path)
+				Expect(err).ToNot(HaveOccurred())
+
+				var doc map[string]interface{}
+				Expect(yaml.Unmarshal(payload, &doc)).To(Succeed())
+
+				change(doc)
+
+				payload, err = yaml.Marshal(doc)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.WriteFile(path, payload, 0644)).To(Succeed())
+			}
+
+			It("fails if a value has been removed", func() {
+				edit(func(doc map[string]interface{}) {
+					delete(doc, "baz")
+				})
+
+				manager := &sops.Manager{Dir: dir, AgeKey: identity.String()}
+				Expect(manager.Init(logger)).To(MatchError(ContainSubstring("file does not match its mac")))
+			})
+
+			It("fails if the mac has been removed", func() {
+				edit(func(doc map[string]interface{}) {
+					delete(doc["sops"].(map[string]interface{}), "mac")
+				})
+
+				manager := &sops.Manager{Dir: dir, AgeKey: identity.String()}
+				Expect(manager.Init(logger)).To(MatchError(ContainSubstring("file has no mac")))
+			})
+		})
+
+		It("fails if files conflict", func() {
+			writeEncrypted(filepath.Join(dir, "main.yml"), map[string]interface{}{
+				"pipeline": "value",
+			}, identity, nil)
+
+			writeEncrypted(filepath.Join(dir, "main", "pipeline.yml"), map[string]interface{}{
+				"foo": "bar",
+			}, identity, nil)
+
+			manager := &sops.Manager{Dir: dir, AgeKey: identity.String()}
+			Expect(manager.Init(logger)).To(MatchError(ContainSubstring("conflicts")))
+		})
+	})
+})
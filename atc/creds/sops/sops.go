@@ -0,0 +1,59 @@
+package sops
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type Sops struct {
+	store           *Store
+	secretTemplates []*creds.SecretTemplate
+	sharedPath      string
+}
+
+func NewSops(store *Store, secretTemplates []*creds.SecretTemplate, sharedPath string) *Sops {
+	return &Sops{
+		store:           store,
+		secretTemplates: secretTemplates,
+		sharedPath:      sharedPath,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the decrypted files
+func (s *Sops) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range s.secretTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, lPath)
+		}
+	}
+	if s.sharedPath != "" {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(s.sharedPath+"/"))
+	}
+	if allowRootPath {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(""))
+	}
+	return lookupPaths
+}
+
+// Get retrieves the value of an individual secret. The files are decrypted up
+// front, so secrets have no expiration.
+func (s *Sops) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, found := s.store.Get(secretPath)
+	return value, nil, found, nil
+}
+
+type sopsFactory struct {
+	sops *Sops
+}
+
+func NewSopsFactory(sops *Sops) *sopsFactory {
+	return &sopsFactory{
+		sops: sops,
+	}
+}
+
+func (factory *sopsFactory) NewSecrets() creds.Secrets {
+	return factory.sops
+}
@@ -0,0 +1,177 @@
+package sops_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSops(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sops Creds Suite")
+}
+
+// writeEncrypted writes a SOPS-encrypted YAML file with the given contents,
+// encrypting its data key for the given age identity and/or PGP entity.
+func writeEncrypted(path string, contents map[string]interface{}, identity *age.X25519Identity, entity *openpgp.Entity) {
+	dataKey := make([]byte, 32)
+	_, err := rand.Read(dataKey)
+	Expect(err).ToNot(HaveOccurred())
+
+	// the keys are written in order, so that is the order of the MAC
+	mac := sha512.New()
+
+	doc := map[string]interface{}{}
+	for _, key := range sortedKeys(contents) {
+		doc[key] = encryptValue(contents[key], dataKey, []string{key}, mac)
+	}
+
+	lastModified := time.Now().UTC().Format(time.RFC3339)
+
+	metadata := map[string]interface{}{
+		"lastmodified": lastModified,
+		"mac":          encryptString(fmt.Sprintf("%X", mac.Sum(nil)), "str", dataKey, lastModified),
+	}
+
+	if identity != nil {
+		buf := new(bytes.Buffer)
+		armorWriter := agearmor.NewWriter(buf)
+		writer, err := age.Encrypt(armorWriter, identity.Recipient())
+		Expect(err).ToNot(HaveOccurred())
+		_, err = writer.Write(dataKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		Expect(armorWriter.Close()).To(Succeed())
+
+		metadata["age"] = []interface{}{
+			map[string]interface{}{
+				"recipient": identity.Recipient().String(),
+				"enc":       buf.String(),
+			},
+		}
+	}
+
+	if entity != nil {
+		buf := new(bytes.Buffer)
+		armorWriter, err := pgparmor.Encode(buf, "PGP MESSAGE", nil)
+		Expect(err).ToNot(HaveOccurred())
+		writer, err := openpgp.Encrypt(armorWriter, []*openpgp.Entity{entity}, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = writer.Write(dataKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		Expect(armorWriter.Close()).To(Succeed())
+
+		metadata["pgp"] = []interface{}{
+			map[string]interface{}{
+				"fp":  fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+				"enc": buf.String(),
+			},
+		}
+	}
+
+	doc["sops"] = metadata
+
+	payload, err := yaml.Marshal(doc)
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+	Expect(os.WriteFile(path, payload, 0644)).To(Succeed())
+}
+
+func encryptValue(value interface{}, dataKey []byte, path []string, mac hash.Hash) interface{} {
+	additionalData := strings.Join(path, ":") + ":"
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		encrypted := map[string]interface{}{}
+		for _, key := range sortedKeys(v) {
+			encrypted[key] = encryptValue(v[key], dataKey, append(path[:len(path):len(path)], key), mac)
+		}
+		return encrypted
+	case []interface{}:
+		encrypted := []interface{}{}
+		for _, child := range v {
+			encrypted = append(encrypted, encryptValue(child, dataKey, path, mac))
+		}
+		return encrypted
+	case string:
+		mac.Write([]byte(v))
+		return encryptString(v, "str", dataKey, additionalData)
+	case int:
+		mac.Write([]byte(fmt.Sprint(v)))
+		return encryptString(fmt.Sprint(v), "int", dataKey, additionalData)
+	case bool:
+		plaintext := "False"
+		if v {
+			plaintext = "True"
+		}
+		mac.Write([]byte(plaintext))
+		return encryptString(plaintext, "bool", dataKey, additionalData)
+	default:
+		Fail(fmt.Sprintf("unsupported value %T", value))
+		return nil
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func encryptString(plaintext string, valueType string, dataKey []byte, additionalData string) string {
+	iv := make([]byte, 32)
+	_, err := rand.Read(iv)
+	Expect(err).ToNot(HaveOccurred())
+
+	block, err := aes.NewCipher(dataKey)
+	Expect(err).ToNot(HaveOccurred())
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	Expect(err).ToNot(HaveOccurred())
+
+	sealed := gcm.Seal(nil, iv, []byte(plaintext), []byte(additionalData))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf(
+		"ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		valueType,
+	)
+}
+
+func armoredPrivateKey(entity *openpgp.Entity) string {
+	buf := new(bytes.Buffer)
+	writer, err := pgparmor.Encode(buf, openpgp.PrivateKeyType, nil)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(entity.SerializePrivate(writer, nil)).To(Succeed())
+	Expect(writer.Close()).To(Succeed())
+	return buf.String()
+}
@@ -0,0 +1,246 @@
+package sops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Store holds the decrypted contents of every encrypted file in a directory.
+//
+// Each file is placed in the tree at its path relative to the directory,
+// without its extension, so that e.g. the key 'foo' in 'main/pipeline.yml'
+// is found at 'main/pipeline/foo'.
+type Store struct {
+	dir  string
+	keys Keys
+
+	lock  sync.RWMutex
+	tree  map[string]interface{}
+	files map[string]fileStamp
+}
+
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func NewStore(dir string, keys Keys) *Store {
+	return &Store{
+		dir:  dir,
+		keys: keys,
+	}
+}
+
+// Get walks the tree along a '/'-separated path.
+func (s *Store) Get(secretPath string) (interface{}, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var node interface{} = s.tree
+	for _, segment := range strings.Split(strings.Trim(secretPath, "/"), "/") {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		node, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// Changed returns whether any file has been added, removed, or modified since
+// the store was last loaded.
+func (s *Store) Changed() (bool, error) {
+	files, err := s.stat()
+	if err != nil {
+		return false, err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if len(files) != len(s.files) {
+		return true, nil
+	}
+
+	for path, stamp := range files {
+		loaded, found := s.files[path]
+		if !found || loaded.size != stamp.size || !loaded.modTime.Equal(stamp.modTime) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Load decrypts every file in the directory. The previously loaded contents
+// are kept if any of them fails to decrypt.
+func (s *Store) Load() error {
+	files, err := s.stat()
+	if err != nil {
+		return err
+	}
+
+	tree := map[string]interface{}{}
+	for path := range files {
+		content, err := s.decryptFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		key := strings.Split(strings.TrimSuffix(path, filepath.Ext(path)), "/")
+
+		err = merge(tree, key, content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	s.lock.Lock()
+	s.tree = tree
+	s.files = files
+	s.lock.Unlock()
+
+	return nil
+}
+
+func (s *Store) stat() (map[string]fileStamp, error) {
+	files := map[string]fileStamp{}
+
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != s.dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yml", ".yaml", ".json":
+		default:
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)] = fileStamp{
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (s *Store) decryptFile(path string) (map[string]interface{}, error) {
+	payload, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.MapSlice
+	err = yaml.Unmarshal(payload, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypt(doc, s.keys)
+}
+
+// merge places the content of a file in the tree at the given key, merging it
+// with the content of any other files at the same key.
+func merge(tree map[string]interface{}, key []string, content map[string]interface{}) error {
+	for _, segment := range key {
+		child, found := tree[segment]
+		if !found {
+			child = map[string]interface{}{}
+			tree[segment] = child
+		}
+
+		var ok bool
+		tree, ok = child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("'%s' conflicts with a value of another file", segment)
+		}
+	}
+
+	for name, value := range content {
+		existing, found := tree[name]
+		if !found {
+			tree[name] = value
+			continue
+		}
+
+		existingMap, existingOk := existing.(map[string]interface{})
+		valueMap, valueOk := value.(map[string]interface{})
+		if !existingOk || !valueOk {
+			return fmt.Errorf("'%s' conflicts with a value of another file", name)
+		}
+
+		err := merge(existingMap, nil, valueMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// plain turns the ordered maps of a document into maps.
+func plain(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		m := map[string]interface{}{}
+		for _, item := range v {
+			m[fmt.Sprint(item.Key)] = plain(item.Value)
+		}
+		return m
+	case []interface{}:
+		items := []interface{}{}
+		for _, item := range v {
+			items = append(items, plain(item))
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+func remarshal(from interface{}, to interface{}) error {
+	payload, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, to)
+}
@@ -0,0 +1,27 @@
+#!/usr/bin/env bash
+# Regenerates the test vectors with the sops CLI (3.9.0 was used), from
+# values.yaml and the keys in keys/:
+#
+#   keys/age.txt  was generated with age-keygen
+#   keys/pgp.asc  was generated with gpg and exported with
+#                 gpg --armor --export-secret-keys
+#
+# The PGP key must be imported into the keyring that sops uses first.
+
+set -euo pipefail
+
+cd "$(dirname "$0")"
+
+age=$(grep -o 'age1.*' keys/age.txt)
+pgp=A7FD82D5BE58352A0010F7F1360F40787D36E113
+
+mkdir -p vectors/{age,pgp,mac-only-encrypted,encrypted-regex,json}
+
+sops --age "$age" -e values.yaml > vectors/age/values.yaml
+sops --pgp "$pgp" -e values.yaml > vectors/pgp/values.yaml
+sops --mac-only-encrypted --age "$age" -e values.yaml > vectors/mac-only-encrypted/values.yaml
+sops --encrypted-regex '^(password|token)$' --age "$age" -e values.yaml > vectors/encrypted-regex/values.yaml
+
+python3 -c 'import json, sys, yaml; json.dump(yaml.safe_load(sys.stdin), sys.stdout, indent=2)' < values.yaml > values.json
+sops --age "$age" -e values.json > vectors/json/values.json
+rm values.json
@@ -0,0 +1,162 @@
+package sops_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds/sops"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// The files in testdata/vectors were encrypted by the sops CLI itself, see
+// testdata/generate.sh, so that the decryption is checked against sops rather
+// than against the helpers of this suite.
+var _ = Describe("files encrypted by sops", func() {
+	var (
+		logger *lagertest.TestLogger
+		dir    string
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	decryptValues := func() (interface{}, error) {
+		manager := &sops.Manager{
+			Dir:                    dir,
+			AgeKeyFile:             filepath.Join("testdata", "keys", "age.txt"),
+			PGPKeyFile:             filepath.Join("testdata", "keys", "pgp.asc"),
+			PipelineSecretTemplate: sops.DefaultPipelineSecretTemplate,
+			TeamSecretTemplate:     sops.DefaultTeamSecretTemplate,
+			ReloadInterval:         time.Minute,
+		}
+
+		err := manager.Init(logger)
+		if err != nil {
+			return nil, err
+		}
+
+		defer manager.Close(logger)
+
+		secretsFactory, err := manager.NewSecretsFactory(logger)
+		Expect(err).ToNot(HaveOccurred())
+
+		value, _, found, err := secretsFactory.NewSecrets().Get("values")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return value, nil
+	}
+
+	// the contents of testdata/values.yaml
+	yamlValues := map[string]interface{}{
+		"password":  "hunter2",
+		"port":      5432,
+		"ratio":     0.75,
+		"version":   1.2,
+		"enabled":   true,
+		"disabled":  false,
+		"empty":     "",
+		"optional":  nil,
+		"multiline": "line one\nline two\n",
+		"database": map[string]interface{}{
+			"host": "db.example.com",
+			"credentials": map[string]interface{}{
+				"username": "admin",
+				"password": "s3cr3t",
+			},
+		},
+		"servers": []interface{}{
+			"alpha",
+			2,
+			3.5,
+			true,
+			map[string]interface{}{
+				"name":  "beta",
+				"token": "abc",
+			},
+		},
+		"description_unencrypted": "left in the clear",
+		"limits_unencrypted": map[string]interface{}{
+			"max":    10,
+			"ratio":  0.5,
+			"strict": true,
+			"tags":   []interface{}{"a", "b"},
+		},
+	}
+
+	DescribeTable("decrypting",
+		func(vector string, expected map[string]interface{}) {
+			dir = filepath.Join("testdata", "vectors", vector)
+
+			values, err := decryptValues()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(expected))
+		},
+		Entry("with an age key", "age", yamlValues),
+		Entry("with a pgp key", "pgp", yamlValues),
+		Entry("with a mac over only the encrypted values", "mac-only-encrypted", yamlValues),
+		Entry("with only the values matching a regex encrypted", "encrypted-regex", yamlValues),
+		// sops encrypts every number of a JSON file as a float
+		Entry("a JSON file", "json", withValues(yamlValues, map[string]interface{}{
+			"port": 5432.0,
+			"servers": []interface{}{
+				"alpha",
+				2.0,
+				3.5,
+				true,
+				map[string]interface{}{
+					"name":  "beta",
+					"token": "abc",
+				},
+			},
+		})),
+	)
+
+	Context("when an unencrypted value has been changed", func() {
+		tamper := func(vector string) {
+			dir = GinkgoT().TempDir()
+
+			original, err := os.ReadFile(filepath.Join("testdata", "vectors", vector, "values.yaml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			tampered := strings.Replace(string(original), "left in the clear", "changed in the clear", 1)
+			Expect(tampered).ToNot(Equal(string(original)))
+
+			Expect(os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(tampered), 0644)).To(Succeed())
+		}
+
+		It("fails if the mac is over all of the values", func() {
+			tamper("age")
+
+			_, err := decryptValues()
+			Expect(err).To(MatchError(sops.ErrMACMismatch))
+		})
+
+		It("succeeds if the mac is over only the encrypted values", func() {
+			tamper("mac-only-encrypted")
+
+			values, err := decryptValues()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("description_unencrypted", "changed in the clear"))
+		})
+	})
+})
+
+func withValues(values map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range values {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -106,3 +106,43 @@ func (s *Ssm) getParameterByPath(path string) (interface{}, *time.Time, bool, er
 	}
 	return value, nil, true, nil
 }
+
+// Set stores the value as a SecureString parameter, overwriting any existing
+// value. A map is stored as a parameter for each of its fields under the
+// path, which is how Get reads complex values.
+func (s *Ssm) Set(secretPath string, value interface{}) error {
+	if fields, ok := value.(map[string]interface{}); ok {
+		for k, v := range fields {
+			err := s.putParameter(strings.TrimRight(secretPath, "/")+"/"+k, v)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return s.putParameter(secretPath, value)
+}
+
+func (s *Ssm) putParameter(name string, value interface{}) error {
+	str, err := creds.SecretString(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.api.PutParameter(&ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(str),
+		Type:      aws.String(ssm.ParameterTypeSecureString),
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		s.log.Error("unable to write aws ssm secret", err, lager.Data{
+			"secretPath": name,
+		})
+		return err
+	}
+
+	return nil
+}
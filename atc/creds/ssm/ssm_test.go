@@ -44,6 +44,8 @@ type MockSsmService struct {
 
 	stubGetParameter             func(name string) (string, error)
 	stubGetParametersByPathPages func(path string) []mockPathResultPage
+
+	putParameterInputs []*ssm.PutParameterInput
 }
 
 func (mock *MockSsmService) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
@@ -84,6 +86,11 @@ func (mock *MockSsmService) GetParametersByPathPages(input *ssm.GetParametersByP
 	return nil
 }
 
+func (mock *MockSsmService) PutParameter(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+	mock.putParameterInputs = append(mock.putParameterInputs, input)
+	return &ssm.PutParameterOutput{}, nil
+}
+
 var _ = Describe("Ssm", func() {
 	var ssmAccess *Ssm
 	var variables vars.Variables
@@ -197,4 +204,28 @@ var _ = Describe("Ssm", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+	Describe("Set()", func() {
+		BeforeEach(func() {
+			mockService.putParameterInputs = nil
+		})
+
+		It("should put a secure string parameter", func() {
+			err := ssmAccess.Set("/concourse/alpha/cheery", "new value")
+			Expect(err).To(BeNil())
+			Expect(mockService.putParameterInputs).To(Equal([]*ssm.PutParameterInput{{
+				Name:      aws.String("/concourse/alpha/cheery"),
+				Value:     aws.String("new value"),
+				Type:      aws.String(ssm.ParameterTypeSecureString),
+				Overwrite: aws.Bool(true),
+			}}))
+		})
+
+		It("should put a parameter for each field of a complex value", func() {
+			err := ssmAccess.Set("/concourse/alpha/cheery", map[string]interface{}{"user": "admin"})
+			Expect(err).To(BeNil())
+			Expect(mockService.putParameterInputs).To(HaveLen(1))
+			Expect(mockService.putParameterInputs[0].Name).To(PointTo(Equal("/concourse/alpha/cheery/user")))
+			Expect(mockService.putParameterInputs[0].Value).To(PointTo(Equal("admin")))
+		})
+	})
 })
@@ -94,6 +94,26 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return secret, err
 }
 
+// Write must be called after a successful login has occurred or an
+// un-authorized client will be used.
+func (ac *APIClient) Write(path string, data map[string]interface{}) error {
+	path = sanitizePath(path)
+	mountPath, kv2, err := isKVv2(path, ac.client())
+	if err != nil {
+		return err
+	}
+
+	// If the path is under a kv2 mount, the data is written as a new version
+	// under the /data/ prefix
+	if kv2 {
+		path = addPrefixToVKVPath(path, mountPath, "data")
+		data = map[string]interface{}{"data": data}
+	}
+
+	_, err = ac.client().Logical().Write(strings.TrimSuffix(path, "/"), data)
+	return err
+}
+
 func (ac *APIClient) loginParams() map[string]interface{} {
 	loginParams := make(map[string]interface{})
 	for k, v := range ac.authConfig.Params {
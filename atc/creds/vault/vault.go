@@ -21,6 +21,12 @@ type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
 }
 
+// A SecretWriter writes a vault secret to the given path. It should be thread
+// safe!
+type SecretWriter interface {
+	Write(path string, data map[string]interface{}) error
+}
+
 // Vault converts a vault secret to our completely untyped secret
 // data.
 type Vault struct {
@@ -75,6 +81,30 @@ func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 	return secret.Data, expiration, true, nil
 }
 
+// Set writes the value to the secret path. A map is stored as the secret's
+// data, and anything else under the "value" key, which is how Get reads it.
+func (v Vault) Set(secretPath string, value interface{}) error {
+	writer, ok := v.SecretReader.(SecretWriter)
+	if !ok {
+		return creds.SecretsNotWritableError{}
+	}
+
+	if v.LoggedIn != nil {
+		select {
+		case <-v.LoggedIn:
+		case <-time.After(v.LoginTimeout):
+			return VaultLoginTimeout{}
+		}
+	}
+
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		data = map[string]interface{}{"value": value}
+	}
+
+	return writer.Write(secretPath, data)
+}
+
 func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
 	secret, err := v.SecretReader.Read(path)
 	if err != nil {
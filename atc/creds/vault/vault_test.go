@@ -2,6 +2,8 @@ package vault_test
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
 	"regexp"
 	"time"
 
@@ -36,6 +38,16 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type MockSecretReadWriter struct {
+	MockSecretReader
+	written map[string]map[string]interface{}
+}
+
+func (msrw *MockSecretReadWriter) Write(path string, data map[string]interface{}) error {
+	msrw.written[path] = data
+	return nil
+}
+
 func createMockV2Secret(value string) *vaultapi.Secret {
 	return &vaultapi.Secret{
 		Data: map[string]interface{}{
@@ -316,6 +328,46 @@ var _ = Describe("Vault", func() {
 			})
 		})
 	})
+
+	Describe("Set()", func() {
+		var msrw *MockSecretReadWriter
+
+		BeforeEach(func() {
+			msrw = &MockSecretReadWriter{
+				MockSecretReader: *msr,
+				written:          map[string]map[string]interface{}{},
+			}
+			v.SecretReader = msrw
+			loggedInCh <- struct{}{}
+		})
+
+		It("should store a string under the value key", func() {
+			err := v.Set("/concourse/team/token", "some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(msrw.written).To(Equal(map[string]map[string]interface{}{
+				"/concourse/team/token": {"value": "some-token"},
+			}))
+		})
+
+		It("should store a map as the secret data", func() {
+			err := v.Set("/concourse/team/creds", map[string]interface{}{"username": "admin"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(msrw.written).To(Equal(map[string]map[string]interface{}{
+				"/concourse/team/creds": {"username": "admin"},
+			}))
+		})
+
+		Context("when the secret reader cannot write", func() {
+			BeforeEach(func() {
+				v.SecretReader = msr
+			})
+
+			It("should return an error", func() {
+				err := v.Set("/concourse/team/token", "some-token")
+				Expect(err).To(Equal(creds.SecretsNotWritableError{}))
+			})
+		})
+	})
 })
 
 // The below tests use ghttp handlers to mock a real vault API to the api_client.
@@ -375,6 +427,24 @@ var _ = Describe("Vault KV2", func() {
 		server.Close()
 	})
 
+	Describe("Set()", func() {
+		It("should write the secret as a new version", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/concourse/data/team/pipeline/foo"),
+					func(w http.ResponseWriter, req *http.Request) {
+						body, err := io.ReadAll(req.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"data":{"value":"bar"}}`))
+					},
+					ghttp.RespondWith(204, ""),
+				),
+			)
+			err := v.Set("/concourse/team/pipeline/foo", "bar")
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Describe("Get()", func() {
 		It("should not return an expiration", func() {
 			server.AppendHandlers(
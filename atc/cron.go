@@ -0,0 +1,285 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression in the standard five field format
+// (minute, hour, day of month, month, day of week).
+//
+// Fields may be `*`, a value, a range (`1-5`), a step (`*/15`, `0-30/10`) or a
+// comma-separated list of any of these. Months and days of the week may also
+// be given by their three letter names (`jan`, `mon`), and Sunday may be
+// either 0 or 7. The usual shorthands (`@hourly`, `@daily`, `@weekly`,
+// `@monthly`, `@yearly`) are supported too.
+//
+// As with cron, when both the day of month and the day of week are
+// restricted, a time matches if either of them matches. A field counts as
+// unrestricted if it starts with `*`, so `*/2` is unrestricted too.
+//
+// Daylight saving transitions are also handled as by cron. A schedule with a
+// fixed minute and hour (e.g. `30 2 * * *`) fires once at the transition if
+// the clocks go forward past its time, and only the first time round if the
+// clocks go back over it. Any other schedule (e.g. `*/15 * * * *`) simply
+// fires at the times that occur.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	domRestricted bool
+	dowRestricted bool
+
+	fixedTime bool
+}
+
+// how far ahead Next will look before deciding that a schedule never fires,
+// e.g. for "0 0 30 2 *"
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+var cronShorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+func ParseCronSchedule(expr string) (CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if shorthand, found := cronShorthands[strings.ToLower(spec)]; found {
+		spec = shorthand
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return CronSchedule{}, fmt.Errorf("invalid cron expression '%s': expected %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+
+	bits := make([]uint64, len(cronFields))
+	for i, field := range cronFields {
+		var err error
+		bits[i], err = field.parse(fields[i])
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("invalid cron expression '%s': %s: %w", expr, field.name, err)
+		}
+	}
+
+	dow := bits[4]
+	if dow&(1<<7) != 0 {
+		// 7 is another way of saying Sunday
+		dow = (dow | 1) &^ (1 << 7)
+	}
+
+	return CronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    dow,
+
+		domRestricted: !strings.HasPrefix(fields[2], "*"),
+		dowRestricted: !strings.HasPrefix(fields[4], "*"),
+
+		fixedTime: !strings.HasPrefix(fields[0], "*") && !strings.HasPrefix(fields[1], "*"),
+	}, nil
+}
+
+func (field cronField) parse(spec string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepSpec)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", stepSpec)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeSpec == "*":
+			low, high = field.min, field.max
+
+		case strings.Contains(rangeSpec, "-"):
+			lowSpec, highSpec, _ := strings.Cut(rangeSpec, "-")
+
+			var err error
+			low, err = field.value(lowSpec)
+			if err != nil {
+				return 0, err
+			}
+
+			high, err = field.value(highSpec)
+			if err != nil {
+				return 0, err
+			}
+
+			if low > high {
+				return 0, fmt.Errorf("invalid range '%s'", rangeSpec)
+			}
+
+		default:
+			var err error
+			low, err = field.value(rangeSpec)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+			if hasStep {
+				// "5/15" means every 15 starting from 5
+				high = field.max
+			}
+		}
+
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (field cronField) value(spec string) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(spec, name) {
+			return field.min + i, nil
+		}
+	}
+
+	value, err := strconv.Atoi(spec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", spec)
+	}
+
+	if value < field.min || value > field.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", value, field.min, field.max)
+	}
+
+	return value, nil
+}
+
+// Next returns the first time after the given time that matches the
+// schedule, in the given time's location. The zero time is returned if the
+// schedule never fires.
+func (schedule CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	limit := after.Add(cronSearchLimit)
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+		if schedule.fixedTime && schedule.skippedBy(t) {
+			return t
+		}
+
+		if schedule.month&(1<<uint(t.Month())) == 0 {
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if !schedule.dayMatches(t) {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if schedule.hour&(1<<uint(t.Hour())) == 0 {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+
+		if schedule.minute&(1<<uint(t.Minute())) == 0 || (schedule.fixedTime && repeated(t)) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// advance guards against time.Date normalizing a wall clock time that falls
+// in a daylight saving gap to a time that is no later than where we started.
+func advance(from time.Time, to time.Time) time.Time {
+	for !to.After(from) {
+		to = to.Add(time.Hour)
+	}
+
+	return to
+}
+
+// skippedBy returns whether the clocks went forward at the given time past a
+// time that matches the schedule.
+//
+// Each of the ways that Next advances lands on the time of the transition, as
+// time.Date normalizes a wall clock time in the gap to it.
+func (schedule CronSchedule) skippedBy(t time.Time) bool {
+	// the wall clock times are compared in UTC, where they all exist
+	skipped := wallClock(t.Add(-time.Minute)).Add(time.Minute)
+	for end := wallClock(t); skipped.Before(end); skipped = skipped.Add(time.Minute) {
+		if schedule.matches(skipped) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repeated returns whether the clocks went back over the wall clock time of
+// the given time, i.e. whether it occurred before.
+func repeated(t time.Time) bool {
+	// the transition can't have been any longer ago than the clocks went back
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-2 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+
+	earlier := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+	return wallClock(earlier).Equal(wallClock(t))
+}
+
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func (schedule CronSchedule) matches(t time.Time) bool {
+	return schedule.month&(1<<uint(t.Month())) != 0 &&
+		schedule.dayMatches(t) &&
+		schedule.hour&(1<<uint(t.Hour())) != 0 &&
+		schedule.minute&(1<<uint(t.Minute())) != 0
+}
+
+func (schedule CronSchedule) dayMatches(t time.Time) bool {
+	domMatches := schedule.dom&(1<<uint(t.Day())) != 0
+	dowMatches := schedule.dow&(1<<uint(t.Weekday())) != 0
+
+	if schedule.domRestricted && schedule.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
@@ -0,0 +1,188 @@
+package atc_test
+
+import (
+	"time"
+
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronSchedule", func() {
+	// a Wednesday
+	from := time.Date(2024, time.January, 10, 12, 34, 56, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(expr string, expected time.Time) {
+			schedule, err := ParseCronSchedule(expr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schedule.Next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2024, time.January, 10, 12, 35, 0, 0, time.UTC)),
+		Entry("a step", "*/15 * * * *", time.Date(2024, time.January, 10, 12, 45, 0, 0, time.UTC)),
+		Entry("a stepped range", "0-20/10 * * * *", time.Date(2024, time.January, 10, 13, 0, 0, 0, time.UTC)),
+		Entry("a step from a value", "40/10 * * * *", time.Date(2024, time.January, 10, 12, 40, 0, 0, time.UTC)),
+		Entry("a list", "0 9,18 * * *", time.Date(2024, time.January, 10, 18, 0, 0, 0, time.UTC)),
+		Entry("a weekday range", "0 9 * * mon-fri", time.Date(2024, time.January, 11, 9, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 0 * * 7", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)),
+		Entry("a month name", "0 0 1 mar *", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("a leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 15 * fri", time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)),
+		Entry("day of week or day of month", "0 0 11 * fri", time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)),
+		Entry("only day of month", "0 0 15 * *", time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)),
+		Entry("only day of week", "0 0 * * fri", time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month with a step from * and day of week", "0 0 */2 * fri", time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("day of week with a step from * and day of month", "0 0 12 * */2", time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)),
+		Entry("every day of month as a range or day of week", "0 0 1-31 * fri", time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week in a restricted month", "0 0 31 mar mon", time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)),
+		Entry("@hourly", "@hourly", time.Date(2024, time.January, 10, 13, 0, 0, 0, time.UTC)),
+		Entry("@daily", "@daily", time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)),
+		Entry("@weekly", "@weekly", time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)),
+		Entry("@monthly", "@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("@yearly", "@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("a date that never occurs", "0 0 30 2 *", time.Time{}),
+	)
+
+	It("is evaluated in the given time's location", func() {
+		toronto, err := time.LoadLocation("America/Toronto")
+		Expect(err).ToNot(HaveOccurred())
+
+		schedule, err := ParseCronSchedule("0 9 * * *")
+		Expect(err).ToNot(HaveOccurred())
+
+		next := schedule.Next(from.In(toronto))
+		Expect(next).To(Equal(time.Date(2024, time.January, 10, 9, 0, 0, 0, toronto)))
+		Expect(next.UTC().Hour()).To(Equal(14))
+	})
+
+	Describe("daylight saving transitions", func() {
+		var toronto, santiago *time.Location
+
+		BeforeEach(func() {
+			var err error
+			toronto, err = time.LoadLocation("America/Toronto")
+			Expect(err).ToNot(HaveOccurred())
+
+			santiago, err = time.LoadLocation("America/Santiago")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		// every time the schedule fires from the given time, until the given
+		// time
+		fires := func(expr string, from time.Time, until time.Time) []time.Time {
+			schedule, err := ParseCronSchedule(expr)
+			Expect(err).ToNot(HaveOccurred())
+
+			var times []time.Time
+			for next := schedule.Next(from); next.Before(until); next = schedule.Next(next) {
+				times = append(times, next)
+			}
+
+			return times
+		}
+
+		// in Toronto, the clocks go forward from 2:00 EST to 3:00 EDT on
+		// 2024-03-10 and back from 2:00 EDT to 1:00 EST on 2024-11-03
+		est := time.FixedZone("EST", -5*60*60)
+		edt := time.FixedZone("EDT", -4*60*60)
+
+		Context("when the clocks go forward", func() {
+			It("fires a fixed time that is skipped at the transition", func() {
+				Expect(fires(
+					"30 2 * * *",
+					time.Date(2024, time.March, 9, 12, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 12, 0, 0, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.March, 10, 3, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 11, 2, 30, 0, 0, toronto),
+				}))
+			})
+
+			It("fires once for all of the fixed times that are skipped", func() {
+				Expect(fires(
+					"15,45 2,3 * * *",
+					time.Date(2024, time.March, 10, 0, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 12, 0, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.March, 10, 3, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 15, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 45, 0, 0, toronto),
+				}))
+			})
+
+			It("does not catch up on times that are skipped if the hour is not fixed", func() {
+				Expect(fires(
+					"30 * * * *",
+					time.Date(2024, time.March, 10, 1, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 4, 0, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.March, 10, 1, 30, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 30, 0, 0, toronto),
+				}))
+			})
+
+			It("carries on as usual for times that are not skipped", func() {
+				Expect(fires(
+					"*/20 * * * *",
+					time.Date(2024, time.March, 10, 1, 30, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 30, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.March, 10, 1, 40, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 0, 0, 0, toronto),
+					time.Date(2024, time.March, 10, 3, 20, 0, 0, toronto),
+				}))
+			})
+
+			It("fires at the transition if midnight is skipped", func() {
+				// the clocks go forward from 0:00 to 1:00 on 2024-09-08
+				Expect(fires(
+					"@daily",
+					time.Date(2024, time.September, 7, 12, 0, 0, 0, santiago),
+					time.Date(2024, time.September, 10, 0, 0, 0, 0, santiago),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.September, 8, 1, 0, 0, 0, santiago),
+					time.Date(2024, time.September, 9, 0, 0, 0, 0, santiago),
+				}))
+			})
+		})
+
+		Context("when the clocks go back", func() {
+			It("fires a fixed time that is repeated only once", func() {
+				Expect(fires(
+					"30 1 * * *",
+					time.Date(2024, time.November, 3, 0, 0, 0, 0, toronto),
+					time.Date(2024, time.November, 5, 0, 0, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.November, 3, 1, 30, 0, 0, edt).In(toronto),
+					time.Date(2024, time.November, 4, 1, 30, 0, 0, est).In(toronto),
+				}))
+			})
+
+			It("fires at the times that are repeated if the hour is not fixed", func() {
+				Expect(fires(
+					"30 * * * *",
+					time.Date(2024, time.November, 3, 0, 45, 0, 0, toronto),
+					time.Date(2024, time.November, 3, 3, 0, 0, 0, toronto),
+				)).To(Equal([]time.Time{
+					time.Date(2024, time.November, 3, 1, 30, 0, 0, edt).In(toronto),
+					time.Date(2024, time.November, 3, 1, 30, 0, 0, est).In(toronto),
+					time.Date(2024, time.November, 3, 2, 30, 0, 0, est).In(toronto),
+				}))
+			})
+		})
+	})
+
+	DescribeTable("parse errors",
+		func(expr string, message string) {
+			_, err := ParseCronSchedule(expr)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("too few fields", "* * * *", "expected 5 fields, got 4"),
+		Entry("an unknown shorthand", "@fortnightly", "expected 5 fields, got 1"),
+		Entry("out of range", "60 * * * *", "minute: value 60 out of range 0-59"),
+		Entry("an invalid value", "* * * foo *", "month: invalid value 'foo'"),
+		Entry("a backwards range", "* 5-1 * * *", "hour: invalid range '5-1'"),
+		Entry("an invalid step", "*/0 * * * *", "minute: invalid step '0'"),
+	)
+})
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -92,12 +93,17 @@ var buildsQuery = psql.Select(`
 		b.drained,
 		b.aborted,
 		b.completed,
+		b.events_archived,
 		b.inputs_ready,
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
 		b.span_context,
-		COALESCE(bc.comment, '')
+		COALESCE(bc.comment, ''),
+		bts.total,
+		bts.passed,
+		bts.failed,
+		bts.skipped
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -105,7 +111,8 @@ var buildsQuery = psql.Select(`
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
 	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id").
 	JoinClause("LEFT OUTER JOIN builds rb ON rb.id = b.rerun_of").
-	JoinClause("LEFT OUTER JOIN build_comments bc ON b.id = bc.build_id")
+	JoinClause("LEFT OUTER JOIN build_comments bc ON b.id = bc.build_id").
+	JoinClause("LEFT OUTER JOIN build_test_summaries bts ON b.id = bts.build_id")
 
 var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0)").
 	From("builds as b")
@@ -146,6 +153,7 @@ type Build interface {
 	PublicPlan() *json.RawMessage
 	HasPlan() bool
 	Comment() string
+	TestSummary() *atc.TestSummary
 	Status() BuildStatus
 	CreateTime() time.Time
 	StartTime() time.Time
@@ -185,10 +193,14 @@ type Build interface {
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
+	ArchiveEvents(context.Context) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
+	SaveTestResults(step string, results []atc.TestResult) error
+	TestResults() ([]atc.TestResult, error)
+
 	SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
@@ -201,6 +213,12 @@ type Build interface {
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 
+	RequestApproval(atc.PlanID, string, []string) error
+	Approval(atc.PlanID) (BuildApproval, bool, error)
+	ApprovalNotifier(atc.PlanID) (Notifier, error)
+	PendingApprovals() ([]BuildApproval, error)
+	DecideApproval(atc.PlanID, bool, string) (bool, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
 
@@ -233,6 +251,8 @@ type build struct {
 	teamName string
 	comment  string
 
+	testSummary *atc.TestSummary
+
 	jobID   int
 	jobName string
 
@@ -258,9 +278,10 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
-	drained   bool
-	aborted   bool
-	completed bool
+	drained        bool
+	aborted        bool
+	completed      bool
+	eventsArchived bool
 
 	spanContext SpanContext
 
@@ -376,6 +397,7 @@ func (b *build) StartTime() time.Time             { return b.startTime }
 func (b *build) EndTime() time.Time               { return b.endTime }
 func (b *build) ReapTime() time.Time              { return b.reapTime }
 func (b *build) Comment() string                  { return b.comment }
+func (b *build) TestSummary() *atc.TestSummary    { return b.testSummary }
 func (b *build) Status() BuildStatus              { return b.status }
 func (b *build) IsScheduled() bool                { return b.scheduled }
 func (b *build) IsDrained() bool                  { return b.drained }
@@ -799,6 +821,11 @@ WITH RECURSIVE pipelines_to_archive AS (
 		}
 	}
 
+	err = b.enqueueNotifications(tx, status, endTime)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -813,12 +840,23 @@ WITH RECURSIVE pipelines_to_archive AS (
 }
 
 // Variables creates variables for this build. If the build is a one-off build, it
-// just uses the global secrets manager. If it belongs to a pipeline, it combines
-// the global secrets manager with the pipeline's var_sources.
+// combines the global secrets manager with the team's var_sources. If it
+// belongs to a pipeline, it also includes the pipeline's var_sources.
 func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
 	// "fly execute" generated build will have no pipeline.
 	if b.pipelineID == 0 {
-		return creds.NewVariables(globalSecrets, b.teamName, b.pipelineName, false), nil
+		globalSecrets, err := teamSecrets(b.conn, b.teamID, globalSecrets, varSourcePool)
+		if err != nil {
+			return nil, err
+		}
+
+		varSources, err := teamVarSources(b.conn, b.teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		variables, _, err := varSourceVariables(logger, globalSecrets, varSourcePool, varSources, b.teamName, b.pipelineName)
+		return variables, err
 	}
 	pipeline, found, err := b.Pipeline()
 	if err != nil {
@@ -1142,6 +1180,15 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	if b.eventsArchived {
+		store := b.conn.BuildLogStore()
+		if store == nil {
+			return nil, errors.New("build events have been archived, but no build log store is configured")
+		}
+
+		return newArchivedBuildEventSource(store, buildLogName(b.eventsTable(), b.id), from), nil
+	}
+
 	return newBuildEventSource(
 		b.id,
 		b.eventsTable(),
@@ -1190,13 +1237,16 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 		conn: b.conn,
 	}
 
-	err := psql.Select("id", "name", "created_at").
+	var buildID sql.NullInt64
+	err := psql.Select("id", "name", "created_at", "build_id", "published").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": artifactID,
 		}).
 		RunWith(b.conn).
-		Scan(&artifact.id, &artifact.name, &artifact.createdAt)
+		Scan(&artifact.id, &artifact.name, &artifact.createdAt, &buildID, &artifact.published)
+
+	artifact.buildID = int(buildID.Int64)
 
 	return &artifact, err
 }
@@ -1204,7 +1254,7 @@ func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	artifacts := []WorkerArtifact{}
 
-	rows, err := psql.Select("id", "name", "created_at").
+	rows, err := psql.Select("id", "name", "created_at", "published").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"build_id": b.id,
@@ -1223,7 +1273,7 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 			buildID: b.id,
 		}
 
-		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt)
+		err = rows.Scan(&wa.id, &wa.name, &wa.createdAt, &wa.published)
 		if err != nil {
 			return nil, err
 		}
@@ -1801,7 +1851,12 @@ func (b *build) SavePipeline(
 
 	jobID := newNullInt64(b.jobID)
 	buildID := newNullInt64(b.id)
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID)
+	author := ConfigAuthor{Source: atc.ConfigSourceSetPipeline}
+	if b.createdBy != nil {
+		author.CreatedBy = *b.createdBy
+	}
+
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID, author)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1856,6 +1911,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		drained, aborted, completed                                                       bool
 		status                                                                            string
 		pipelineInstanceVars, comment                                                     sql.NullString
+		testsTotal, testsPassed, testsFailed, testsSkipped                                sql.NullInt64
 	)
 
 	err := row.Scan(
@@ -1886,12 +1942,17 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&drained,
 		&aborted,
 		&completed,
+		&b.eventsArchived,
 		&b.inputsReady,
 		&rerunOf,
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
 		&comment,
+		&testsTotal,
+		&testsPassed,
+		&testsFailed,
+		&testsSkipped,
 	)
 	if err != nil {
 		return err
@@ -1918,6 +1979,15 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.rerunNumber = int(rerunNumber.Int64)
 	b.comment = comment.String
 
+	if testsTotal.Valid {
+		b.testSummary = &atc.TestSummary{
+			Total:   int(testsTotal.Int64),
+			Passed:  int(testsPassed.Int64),
+			Failed:  int(testsFailed.Int64),
+			Skipped: int(testsSkipped.Int64),
+		}
+	}
+
 	var (
 		noncense      *string
 		decryptedPlan []byte
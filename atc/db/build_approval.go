@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BuildApproval is a request, made by an approval step, for someone to
+// approve or reject the build before it carries on.
+type BuildApproval struct {
+	BuildID     int
+	PlanID      atc.PlanID
+	Name        string
+	Approvers   []string
+	RequestedAt time.Time
+
+	// Approved is nil until the approval has been decided.
+	Approved  *bool
+	DecidedBy string
+	DecidedAt time.Time
+}
+
+func (approval BuildApproval) Decided() bool {
+	return approval.Approved != nil
+}
+
+var buildApprovalsQuery = psql.Select(
+	"build_id",
+	"plan_id",
+	"name",
+	"approvers",
+	"requested_at",
+	"approved",
+	"decided_by",
+	"decided_at",
+).From("build_approvals")
+
+// RequestApproval records that the approval step with the given plan ID and
+// name is waiting to be decided by one of the approvers. Requesting an
+// approval that already exists leaves it as it is, so that a decision is not
+// lost if the step is run again.
+func (b *build) RequestApproval(planID atc.PlanID, name string, approvers []string) error {
+	if approvers == nil {
+		approvers = []string{}
+	}
+
+	payload, err := json.Marshal(approvers)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name", "approvers").
+		Values(b.id, string(planID), name, string(payload)).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) Approval(planID atc.PlanID) (BuildApproval, bool, error) {
+	row := buildApprovalsQuery.
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	approval, err := scanBuildApproval(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+// PendingApprovals returns the approvals of the build that are still
+// waiting to be decided, oldest first.
+func (b *build) PendingApprovals() ([]BuildApproval, error) {
+	rows, err := buildApprovalsQuery.
+		Where(sq.Eq{
+			"build_id": b.id,
+			"approved": nil,
+		}).
+		OrderBy("requested_at ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var approvals []BuildApproval
+	for rows.Next() {
+		approval, err := scanBuildApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+// DecideApproval approves or rejects a pending approval. It returns false if
+// there is no such approval or if it has already been decided.
+func (b *build) DecideApproval(planID atc.PlanID, approved bool, decidedBy string) (bool, error) {
+	result, err := psql.Update("build_approvals").
+		Set("approved", approved).
+		Set("decided_by", decidedBy).
+		Set("decided_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+			"approved": nil,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, b.conn.Bus().Notify(buildApprovalChannel(b.id))
+}
+
+// ApprovalNotifier returns a Notifier that can be watched for when the
+// approval with the given plan ID has been decided.
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		var decided bool
+		err := psql.Select("approved IS NOT NULL").
+			From("build_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&decided)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return decided, err
+	})
+}
+
+func scanBuildApproval(row scannable) (BuildApproval, error) {
+	var (
+		approval  BuildApproval
+		planID    string
+		approvers string
+		approved  sql.NullBool
+		decidedBy sql.NullString
+		decidedAt sql.NullTime
+	)
+
+	err := row.Scan(&approval.BuildID, &planID, &approval.Name, &approvers, &approval.RequestedAt, &approved, &decidedBy, &decidedAt)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	err = json.Unmarshal([]byte(approvers), &approval.Approvers)
+	if err != nil {
+		return BuildApproval{}, fmt.Errorf("unmarshal approvers: %w", err)
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+
+	if approved.Valid {
+		approval.Approved = &approved.Bool
+		approval.DecidedBy = decidedBy.String
+		approval.DecidedAt = decidedAt.Time
+	}
+
+	return approval, nil
+}
+
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"math"
@@ -157,20 +158,9 @@ func (source *buildEventSource) collectEvents(from uint, completed bool) {
 			}
 		}
 
-		eventsQuery := psql.Select("event_id", "type", "version", "payload").
-			From(source.table)
-
-		var query sq.SelectBuilder
-		if source.buildID > math.MaxInt32 {
-			query = eventsQuery.Where(sq.Eq{"build_id": source.buildID})
-		} else {
-			query = eventsQuery.Where(sq.Or{
-				sq.Eq{"build_id": source.buildID},
-				sq.Eq{"build_id_old": source.buildID},
-			})
-		}
-
-		rows, err := query.
+		rows, err := psql.Select("event_id", "type", "version", "payload").
+			From(source.table).
+			Where(buildEventsCondition(source.buildID)).
 			Where(sq.Gt{"event_id": cursor}).
 			OrderBy("event_id ASC").
 			Limit(uint64(batchSize)).
@@ -229,7 +219,7 @@ func (source *buildEventSource) collectEvents(from uint, completed bool) {
 		}
 
 		if completed {
-			source.err = ErrEndOfBuildEventStream
+			source.err = source.collectArchivedEvents(cursor + 1)
 			close(source.events)
 			return
 		}
@@ -243,3 +233,56 @@ func (source *buildEventSource) collectEvents(from uint, completed bool) {
 		}
 	}
 }
+
+// collectArchivedEvents picks up from the build log store if the build's
+// events were archived while they were being read from the database.
+func (source *buildEventSource) collectArchivedEvents(from int) error {
+	store := source.conn.BuildLogStore()
+	if store == nil {
+		return ErrEndOfBuildEventStream
+	}
+
+	var archived bool
+	err := psql.Select("events_archived").
+		From("builds").
+		Where(sq.Eq{"id": source.buildID}).
+		RunWith(source.conn).
+		QueryRow().
+		Scan(&archived)
+	if err != nil {
+		return err
+	}
+
+	if !archived {
+		return ErrEndOfBuildEventStream
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-source.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err = readArchivedEvents(ctx, store, buildLogName(source.table, source.buildID), uint(from), source.events)
+	if err != nil {
+		return err
+	}
+
+	return ErrEndOfBuildEventStream
+}
+
+func buildEventsCondition(buildID int) sq.Sqlizer {
+	if buildID > math.MaxInt32 {
+		return sq.Eq{"build_id": buildID}
+	}
+
+	return sq.Or{
+		sq.Eq{"build_id": buildID},
+		sq.Eq{"build_id_old": buildID},
+	}
+}
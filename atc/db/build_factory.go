@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
@@ -45,6 +46,7 @@ type BuildForAPI interface {
 	HasPlan() bool
 
 	Comment() string
+	TestSummary() *atc.TestSummary
 	StartTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
@@ -58,12 +60,17 @@ type BuildForAPI interface {
 	IsRunning() bool
 
 	Artifacts() ([]WorkerArtifact, error)
+	Artifact(artifactID int) (WorkerArtifact, error)
+	TestResults() ([]atc.TestResult, error)
 	Events(uint) (EventSource, error)
 	Resources() ([]BuildInput, []BuildOutput, error)
 	Preparation() (BuildPreparation, bool, error)
 
 	MarkAsAborted() error
 	SetComment(string) error
+
+	PendingApprovals() ([]BuildApproval, error)
+	DecideApproval(atc.PlanID, bool, string) (bool, error)
 }
 
 //counterfeiter:generate . BuildFactory
@@ -76,6 +83,7 @@ type BuildFactory interface {
 	Build(int) (Build, bool, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	GetArchivableBuilds(completedFor time.Duration, limit int) ([]Build, error)
 
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
@@ -223,6 +231,25 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetArchivableBuilds returns the builds whose events can be moved to the
+// build log store, i.e. those that finished at least completedFor ago and
+// whose events haven't been archived or reaped yet.
+func (f *buildFactory) GetArchivableBuilds(completedFor time.Duration, limit int) ([]Build, error) {
+	query := buildsQuery.Where(
+		sq.Eq{
+			"b.completed":        true,
+			"b.events_archived":  false,
+			"b.reap_time":        nil,
+			"b.resource_id":      nil,
+			"b.resource_type_id": nil,
+		}).
+		Where(sq.Expr(fmt.Sprintf("now() - b.end_time > '%d seconds'::interval", int(completedFor.Seconds())))).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
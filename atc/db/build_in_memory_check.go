@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -238,6 +239,15 @@ func (b *inMemoryCheckBuildForApi) Comment() string {
 func (b *inMemoryCheckBuildForApi) Artifacts() ([]WorkerArtifact, error) {
 	return nil, errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuildForApi) Artifact(int) (WorkerArtifact, error) {
+	return nil, errors.New("not implemented for in memory build")
+}
+func (b *inMemoryCheckBuildForApi) TestSummary() *atc.TestSummary {
+	return nil
+}
+func (b *inMemoryCheckBuildForApi) TestResults() ([]atc.TestResult, error) {
+	return nil, errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuildForApi) Resources() ([]BuildInput, []BuildOutput, error) {
 	return nil, nil, errors.New("not implemented for in memory build")
 }
@@ -251,6 +261,14 @@ func (b *inMemoryCheckBuildForApi) SetComment(string) error {
 	return errors.New("not implemented for in memory build")
 }
 
+func (b *inMemoryCheckBuildForApi) PendingApprovals() ([]BuildApproval, error) {
+	return nil, nil
+}
+
+func (b *inMemoryCheckBuildForApi) DecideApproval(atc.PlanID, bool, string) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
+
 // inMemoryCheckBuild implements db.Build. It handles in-memory check builds
 // only, thus it just implement the necessary function of interface Build.
 type inMemoryCheckBuild struct {
@@ -535,6 +553,10 @@ func (b *inMemoryCheckBuild) SaveEvent(ev atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+func (b *inMemoryCheckBuild) ArchiveEvents(context.Context) error {
+	return errors.New("not implemented for in memory build")
+}
+
 // AbortNotifier returns nil because there is no way to abort a in-memory
 // check build. Say a in-memory build may run on ATC-a, but abort-build API call
 // might be received by ATC-b, there is not a channel for ATC-b to tell ATC-a to
@@ -546,6 +568,26 @@ func (b *inMemoryCheckBuild) AbortNotifier() (Notifier, error) {
 	return nil, nil
 }
 
+func (b *inMemoryCheckBuild) RequestApproval(atc.PlanID, string, []string) error {
+	return errors.New("not implemented for in memory build")
+}
+
+func (b *inMemoryCheckBuild) Approval(atc.PlanID) (BuildApproval, bool, error) {
+	return BuildApproval{}, false, nil
+}
+
+func (b *inMemoryCheckBuild) ApprovalNotifier(atc.PlanID) (Notifier, error) {
+	return nil, errors.New("not implemented for in memory build")
+}
+
+func (b *inMemoryCheckBuild) PendingApprovals() ([]BuildApproval, error) {
+	return nil, nil
+}
+
+func (b *inMemoryCheckBuild) DecideApproval(atc.PlanID, bool, string) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
+
 // ResourceCacheUser will use in-memory build's preId as key in order to avoid unnecessary
 // db init. To ensure preId is unique across all ATCs, also use build's create time in
 // the key.
@@ -645,9 +687,10 @@ func (b *inMemoryCheckBuild) SetInterceptible(bool) error {
 	return errors.New("not implemented for in memory build")
 }
 
-func (b *inMemoryCheckBuild) Artifact(int) (WorkerArtifact, error) {
-	return nil, errors.New("not implemented for in memory build")
+func (b *inMemoryCheckBuild) SaveTestResults(string, []atc.TestResult) error {
+	return errors.New("not implemented for in memory build")
 }
+
 func (b *inMemoryCheckBuild) Start(atc.Plan) (bool, error) {
 	return false, errors.New("not implemented for in memory build")
 }
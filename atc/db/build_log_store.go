@@ -0,0 +1,267 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+var ErrBuildLogNotFound = errors.New("build log not found")
+
+// BuildLogStore holds the events of finished builds once they have been
+// archived out of the database, as one blob per build.
+//
+// Blobs are named after the events table that they were archived from (e.g.
+// "pipeline_build_events_1/42"), so that the logs of a pipeline can be
+// removed along with its table.
+//
+//counterfeiter:generate . BuildLogStore
+type BuildLogStore interface {
+	Put(ctx context.Context, name string, events io.Reader) error
+
+	// Get returns ErrBuildLogNotFound if there is no blob with the given name.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// Delete and DeleteAll do not return an error if there is nothing to
+	// delete.
+	Delete(ctx context.Context, name string) error
+
+	// DeleteAll deletes every blob in a directory, e.g. all of those of a
+	// pipeline. The prefix names the directory and ends with a "/", so that
+	// deleting "team_build_events_1/" leaves the blobs of team 11 alone.
+	DeleteAll(ctx context.Context, prefix string) error
+}
+
+// WithBuildLogStore returns a wrapper of the DB connection through which
+// builds archive their events to, and read them back from, the given store.
+func WithBuildLogStore(conn Conn, store BuildLogStore) Conn {
+	return &buildLogStoreConn{
+		Conn:  conn,
+		store: store,
+	}
+}
+
+type buildLogStoreConn struct {
+	Conn
+
+	store BuildLogStore
+}
+
+func (c *buildLogStoreConn) BuildLogStore() BuildLogStore {
+	return c.store
+}
+
+func buildLogName(eventsTable string, buildID int) string {
+	return fmt.Sprintf("%s/%d", eventsTable, buildID)
+}
+
+// ArchiveEvents moves the events of a finished build out of the database and
+// into the build log store as a single blob, after which they are read back
+// from there. It does nothing if there is no build log store configured.
+func (b *build) ArchiveEvents(ctx context.Context) error {
+	store := b.conn.BuildLogStore()
+	if store == nil {
+		return nil
+	}
+
+	if !b.completed {
+		return errors.New("cannot archive the events of a build that has not completed")
+	}
+
+	if b.isForCheck() {
+		return errors.New("cannot archive the events of a check build")
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(b.writeEvents(writer))
+	}()
+
+	err := store.Put(ctx, buildLogName(b.eventsTable(), b.id), reader)
+
+	// make sure writeEvents returns if the store gave up reading early
+	reader.CloseWithError(err)
+
+	if err != nil {
+		return fmt.Errorf("put build log: %w", err)
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
+		Set("events_archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(buildEventsCondition(b.id)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = true
+
+	return nil
+}
+
+// writeEvents writes the build's events to the writer as a stream of JSON
+// encoded envelopes.
+func (b *build) writeEvents(writer io.Writer) error {
+	rows, err := psql.Select("event_id", "type", "version", "payload").
+		From(b.eventsTable()).
+		Where(buildEventsCondition(b.id)).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	encoder := json.NewEncoder(writer)
+	for rows.Next() {
+		var (
+			eventID int
+			t, v, p string
+		)
+
+		err := rows.Scan(&eventID, &t, &v, &p)
+		if err != nil {
+			return err
+		}
+
+		data := json.RawMessage(p)
+
+		err = encoder.Encode(event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+			EventID: strconv.Itoa(eventID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func newArchivedBuildEventSource(store BuildLogStore, name string, from uint) *archivedBuildEventSource {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	source := &archivedBuildEventSource{
+		events: make(chan event.Envelope, 2000),
+		cancel: cancel,
+		wg:     new(sync.WaitGroup),
+	}
+
+	source.wg.Add(1)
+	go func() {
+		defer source.wg.Done()
+
+		err := readArchivedEvents(ctx, store, name, from, source.events)
+		if err == nil {
+			err = ErrEndOfBuildEventStream
+		}
+
+		source.err = err
+		close(source.events)
+	}()
+
+	return source
+}
+
+// archivedBuildEventSource reads the events of a build back from the build
+// log store. The blob is only read as far as the events are consumed.
+type archivedBuildEventSource struct {
+	events chan event.Envelope
+	err    error
+
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+func (source *archivedBuildEventSource) Next() (event.Envelope, error) {
+	e, ok := <-source.events
+	if !ok {
+		return event.Envelope{}, source.err
+	}
+
+	return e, nil
+}
+
+func (source *archivedBuildEventSource) Close() error {
+	source.cancel()
+	source.wg.Wait()
+	return nil
+}
+
+// readArchivedEvents sends the events in the blob with an ID of at least
+// from. A blob that doesn't exist, e.g. because the build's logs have been
+// reaped, is treated as having no events.
+func readArchivedEvents(ctx context.Context, store BuildLogStore, name string, from uint, events chan<- event.Envelope) error {
+	blob, err := store.Get(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrBuildLogNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("get build log: %w", err)
+	}
+
+	defer blob.Close()
+
+	decoder := json.NewDecoder(blob)
+	for {
+		var ev event.Envelope
+		err := decoder.Decode(&ev)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("decode build log: %w", err)
+		}
+
+		eventID, err := strconv.Atoi(ev.EventID)
+		if err != nil {
+			return fmt.Errorf("decode build log: invalid event id '%s'", ev.EventID)
+		}
+
+		if eventID < int(from) {
+			continue
+		}
+
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			return ErrBuildEventStreamClosed
+		}
+	}
+}
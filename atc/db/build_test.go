@@ -14,6 +14,8 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildlog"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/dummy"
 	"github.com/concourse/concourse/atc/db"
@@ -1017,6 +1019,156 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Approvals", func() {
+		BeforeEach(func() {
+			err := build.RequestApproval("some-plan", "qa", []string{"owner", "github:some-user"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is pending until it is decided", func() {
+			approval, found, err := build.Approval("some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approval.Name).To(Equal("qa"))
+			Expect(approval.Approvers).To(Equal([]string{"owner", "github:some-user"}))
+			Expect(approval.Decided()).To(BeFalse())
+
+			pending, err := build.PendingApprovals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(HaveLen(1))
+			Expect(pending[0].PlanID).To(Equal(atc.PlanID("some-plan")))
+		})
+
+		It("can be decided once", func() {
+			decided, err := build.DecideApproval("some-plan", true, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decided).To(BeTrue())
+
+			decided, err = build.DecideApproval("some-plan", false, "some-other-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decided).To(BeFalse())
+
+			approval, found, err := build.Approval("some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approval.Decided()).To(BeTrue())
+			Expect(*approval.Approved).To(BeTrue())
+			Expect(approval.DecidedBy).To(Equal("some-user"))
+			Expect(approval.DecidedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			pending, err := build.PendingApprovals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeEmpty())
+		})
+
+		It("keeps the decision when requested again", func() {
+			_, err := build.DecideApproval("some-plan", false, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.RequestApproval("some-plan", "qa", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			approval, _, err := build.Approval("some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*approval.Approved).To(BeFalse())
+		})
+
+		It("notifies when it is decided", func() {
+			notifier, err := build.ApprovalNotifier("some-plan")
+			Expect(err).NotTo(HaveOccurred())
+
+			defer notifier.Close()
+
+			Consistently(notifier.Notify()).ShouldNot(Receive())
+
+			_, err = build.DecideApproval("some-plan", true, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+	})
+
+	Describe("Test results", func() {
+		It("has none to begin with", func() {
+			results, err := build.TestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			Expect(build.TestSummary()).To(BeNil())
+		})
+
+		Context("when results are saved for more than one step", func() {
+			BeforeEach(func() {
+				err := build.SaveTestResults("unit", []atc.TestResult{
+					{Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+					{Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "division by zero"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveTestResults("integration", []atc.TestResult{
+					{Suite: "api", Name: "logs in", Status: atc.TestStatusSkipped},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns all of them in the order they were saved", func() {
+				results, err := build.TestResults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(Equal([]atc.TestResult{
+					{Step: "unit", Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+					{Step: "unit", Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "division by zero"},
+					{Step: "integration", Suite: "api", Name: "logs in", Status: atc.TestStatusSkipped},
+				}))
+			})
+
+			It("sums them up in the build's test summary", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(build.TestSummary()).To(Equal(&atc.TestSummary{
+					Total:   3,
+					Passed:  1,
+					Failed:  1,
+					Skipped: 1,
+				}))
+			})
+
+			Context("when results are saved again for a step", func() {
+				BeforeEach(func() {
+					err := build.SaveTestResults("unit", []atc.TestResult{
+						{Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+						{Suite: "math", Name: "divides", Status: atc.TestStatusPassed},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("replaces the step's earlier results", func() {
+					results, err := build.TestResults()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(results).To(Equal([]atc.TestResult{
+						{Step: "integration", Suite: "api", Name: "logs in", Status: atc.TestStatusSkipped},
+						{Step: "unit", Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+						{Step: "unit", Suite: "math", Name: "divides", Status: atc.TestStatusPassed},
+					}))
+				})
+
+				It("does not count the earlier results in the build's test summary", func() {
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(build.TestSummary()).To(Equal(&atc.TestSummary{
+						Total:   3,
+						Passed:  2,
+						Failed:  0,
+						Skipped: 1,
+					}))
+				})
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		var marker *db.BuildBeingWatchedMarker
 		BeforeEach(func() {
@@ -1091,6 +1243,69 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ArchiveEvents", func() {
+		var (
+			store         *buildlog.FileStore
+			archivedBuild db.Build
+		)
+
+		BeforeEach(func() {
+			store = buildlog.NewFileStore(GinkgoT().TempDir(), compression.NewGzipCompression())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			storeBuildFactory := db.NewBuildFactory(db.WithBuildLogStore(dbConn, store), lockFactory, 0, 0)
+
+			var found bool
+			archivedBuild, found, err = storeBuildFactory.Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = archivedBuild.ArchiveEvents(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("moves the events out of the database", func() {
+			var count int
+			err := psql.Select("COUNT(*)").
+				From(fmt.Sprintf("pipeline_build_events_%d", build.PipelineID())).
+				Where(sq.Eq{"build_id": build.ID()}).
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("reads the events back from the store", func() {
+			found, err := archivedBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := archivedBuild.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some log"}, "1")))
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   archivedBuild.EndTime().Unix(),
+			}, "2")))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		var marker *db.BuildBeingWatchedMarker
 		BeforeEach(func() {
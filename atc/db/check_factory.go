@@ -39,6 +39,7 @@ type Checkable interface {
 //counterfeiter:generate . CheckFactory
 type CheckFactory interface {
 	TryCreateCheck(context.Context, Checkable, ResourceTypes, atc.Version, bool, bool, bool) (Build, bool, error)
+	TryCreateWebhookCheck(context.Context, Checkable, ResourceTypes, *atc.Webhook) (Build, bool, error)
 	Resources() ([]Resource, error)
 	ResourceTypesByPipeline() (map[int]ResourceTypes, error)
 	Drain()
@@ -80,6 +81,17 @@ func NewCheckFactory(
 }
 
 func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable, resourceTypes ResourceTypes, from atc.Version, manuallyTriggered bool, skipIntervalRecursively bool, toDB bool) (Build, bool, error) {
+	return c.tryCreateCheck(ctx, checkable, resourceTypes, from, manuallyTriggered, skipIntervalRecursively, toDB, nil)
+}
+
+// TryCreateWebhookCheck creates a check build, like a manually triggered
+// check, which passes the webhook request on to the resource, if there is
+// anything about it that the resource asked for.
+func (c *checkFactory) TryCreateWebhookCheck(ctx context.Context, checkable Checkable, resourceTypes ResourceTypes, webhook *atc.Webhook) (Build, bool, error) {
+	return c.tryCreateCheck(ctx, checkable, resourceTypes, nil, true, false, true, webhook)
+}
+
+func (c *checkFactory) tryCreateCheck(ctx context.Context, checkable Checkable, resourceTypes ResourceTypes, from atc.Version, manuallyTriggered bool, skipIntervalRecursively bool, toDB bool, webhook *atc.Webhook) (Build, bool, error) {
 	logger := lagerctx.FromContext(ctx)
 	sourceDefaults := atc.Source{}
 	parentType, found := resourceTypes.Parent(checkable)
@@ -116,6 +128,9 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 
 	deserializedResourceTypes := resourceTypes.Filter(checkable).Deserialize()
 	plan := checkable.CheckPlan(c.planFactory, deserializedResourceTypes, from, interval, sourceDefaults, skipInterval, skipIntervalRecursively)
+	if webhook != nil {
+		plan.Check.Webhook = webhook
+	}
 
 	if toDB {
 		build, created, err := checkable.CreateBuild(ctx, manuallyTriggered, plan)
@@ -2,11 +2,12 @@
 package dbfakes
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
 
-	lager "code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -84,6 +85,45 @@ type FakeBuild struct {
 	allAssociatedTeamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	ApprovalStub        func(atc.PlanID) (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalNotifierStub        func(atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ArchiveEventsStub        func(context.Context) error
+	archiveEventsMutex       sync.RWMutex
+	archiveEventsArgsForCall []struct {
+		arg1 context.Context
+	}
+	archiveEventsReturns struct {
+		result1 error
+	}
+	archiveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -150,6 +190,21 @@ type FakeBuild struct {
 	createdByReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	DecideApprovalStub        func(atc.PlanID, bool, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -372,6 +427,18 @@ type FakeBuild struct {
 	onCheckBuildStartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PendingApprovalsStub        func() ([]db.BuildApproval, error)
+	pendingApprovalsMutex       sync.RWMutex
+	pendingApprovalsArgsForCall []struct {
+	}
+	pendingApprovalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	pendingApprovalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -482,6 +549,19 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(atc.PlanID, string, []string) error
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 []string
+	}
+	requestApprovalReturns struct {
+		result1 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RerunNumberStub        func() int
 	rerunNumberMutex       sync.RWMutex
 	rerunNumberArgsForCall []struct {
@@ -646,6 +726,18 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveTestResultsStub        func(string, []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 string
+		arg2 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -763,6 +855,28 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestResultsStub        func() ([]atc.TestResult, error)
+	testResultsMutex       sync.RWMutex
+	testResultsArgsForCall []struct {
+	}
+	testResultsReturns struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	testResultsReturnsOnCall map[int]struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	TestSummaryStub        func() *atc.TestSummary
+	testSummaryMutex       sync.RWMutex
+	testSummaryArgsForCall []struct {
+	}
+	testSummaryReturns struct {
+		result1 *atc.TestSummary
+	}
+	testSummaryReturnsOnCall map[int]struct {
+		result1 *atc.TestSummary
+	}
 	TracingAttrsStub        func() tracing.Attrs
 	tracingAttrsMutex       sync.RWMutex
 	tracingAttrsArgsForCall []struct {
@@ -1087,6 +1201,198 @@ func (fake *FakeBuild) AllAssociatedTeamNamesReturnsOnCall(i int, result1 []stri
 	}{result1}
 }
 
+func (fake *FakeBuild) Approval(arg1 atc.PlanID) (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.ApprovalStub
+	fakeReturns := fake.approvalReturns
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(atc.PlanID) (db.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) atc.PlanID {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(arg1 atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.ApprovalNotifierStub
+	fakeReturns := fake.approvalNotifierReturns
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(atc.PlanID) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchiveEvents(arg1 context.Context) error {
+	fake.archiveEventsMutex.Lock()
+	ret, specificReturn := fake.archiveEventsReturnsOnCall[len(fake.archiveEventsArgsForCall)]
+	fake.archiveEventsArgsForCall = append(fake.archiveEventsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ArchiveEventsStub
+	fakeReturns := fake.archiveEventsReturns
+	fake.recordInvocation("ArchiveEvents", []interface{}{arg1})
+	fake.archiveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ArchiveEventsCallCount() int {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	return len(fake.archiveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchiveEventsCalls(stub func(context.Context) error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = stub
+}
+
+func (fake *FakeBuild) ArchiveEventsArgsForCall(i int) context.Context {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	argsForCall := fake.archiveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ArchiveEventsReturns(result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	fake.archiveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEventsReturnsOnCall(i int, result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	if fake.archiveEventsReturnsOnCall == nil {
+		fake.archiveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -1427,6 +1733,72 @@ func (fake *FakeBuild) CreatedByReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) DecideApproval(arg1 atc.PlanID, arg2 bool, arg3 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DecideApprovalStub
+	fakeReturns := fake.decideApprovalReturns
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2, arg3})
+	fake.decideApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) DecideApprovalCalls(stub func(atc.PlanID, bool, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuild) DecideApprovalArgsForCall(i int) (atc.PlanID, bool, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Delete() (bool, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -2571,6 +2943,62 @@ func (fake *FakeBuild) OnCheckBuildStartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PendingApprovals() ([]db.BuildApproval, error) {
+	fake.pendingApprovalsMutex.Lock()
+	ret, specificReturn := fake.pendingApprovalsReturnsOnCall[len(fake.pendingApprovalsArgsForCall)]
+	fake.pendingApprovalsArgsForCall = append(fake.pendingApprovalsArgsForCall, struct {
+	}{})
+	stub := fake.PendingApprovalsStub
+	fakeReturns := fake.pendingApprovalsReturns
+	fake.recordInvocation("PendingApprovals", []interface{}{})
+	fake.pendingApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) PendingApprovalsCallCount() int {
+	fake.pendingApprovalsMutex.RLock()
+	defer fake.pendingApprovalsMutex.RUnlock()
+	return len(fake.pendingApprovalsArgsForCall)
+}
+
+func (fake *FakeBuild) PendingApprovalsCalls(stub func() ([]db.BuildApproval, error)) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = stub
+}
+
+func (fake *FakeBuild) PendingApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = nil
+	fake.pendingApprovalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PendingApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = nil
+	if fake.pendingApprovalsReturnsOnCall == nil {
+		fake.pendingApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.pendingApprovalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -3116,6 +3544,74 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(arg1 atc.PlanID, arg2 string, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.RequestApprovalStub
+	fakeReturns := fake.requestApprovalReturns
+	fake.recordInvocation("RequestApproval", []interface{}{arg1, arg2, arg3Copy})
+	fake.requestApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalCalls(stub func(atc.PlanID, string, []string) error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = stub
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, string, []string) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	argsForCall := fake.requestApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) RerunNumber() int {
 	fake.rerunNumberMutex.Lock()
 	ret, specificReturn := fake.rerunNumberReturnsOnCall[len(fake.rerunNumberArgsForCall)]
@@ -3915,6 +4411,73 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveTestResults(arg1 string, arg2 []atc.TestResult) error {
+	var arg2Copy []atc.TestResult
+	if arg2 != nil {
+		arg2Copy = make([]atc.TestResult, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 string
+		arg2 []atc.TestResult
+	}{arg1, arg2Copy})
+	stub := fake.SaveTestResultsStub
+	fakeReturns := fake.saveTestResultsReturns
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1, arg2Copy})
+	fake.saveTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestResultsCalls(stub func(string, []atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeBuild) SaveTestResultsArgsForCall(i int) (string, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -4541,6 +5104,115 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TestResults() ([]atc.TestResult, error) {
+	fake.testResultsMutex.Lock()
+	ret, specificReturn := fake.testResultsReturnsOnCall[len(fake.testResultsArgsForCall)]
+	fake.testResultsArgsForCall = append(fake.testResultsArgsForCall, struct {
+	}{})
+	stub := fake.TestResultsStub
+	fakeReturns := fake.testResultsReturns
+	fake.recordInvocation("TestResults", []interface{}{})
+	fake.testResultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TestResultsCallCount() int {
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	return len(fake.testResultsArgsForCall)
+}
+
+func (fake *FakeBuild) TestResultsCalls(stub func() ([]atc.TestResult, error)) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = stub
+}
+
+func (fake *FakeBuild) TestResultsReturns(result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	fake.testResultsReturns = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestResultsReturnsOnCall(i int, result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	if fake.testResultsReturnsOnCall == nil {
+		fake.testResultsReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestResult
+			result2 error
+		})
+	}
+	fake.testResultsReturnsOnCall[i] = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestSummary() *atc.TestSummary {
+	fake.testSummaryMutex.Lock()
+	ret, specificReturn := fake.testSummaryReturnsOnCall[len(fake.testSummaryArgsForCall)]
+	fake.testSummaryArgsForCall = append(fake.testSummaryArgsForCall, struct {
+	}{})
+	stub := fake.TestSummaryStub
+	fakeReturns := fake.testSummaryReturns
+	fake.recordInvocation("TestSummary", []interface{}{})
+	fake.testSummaryMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TestSummaryCallCount() int {
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
+	return len(fake.testSummaryArgsForCall)
+}
+
+func (fake *FakeBuild) TestSummaryCalls(stub func() *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = stub
+}
+
+func (fake *FakeBuild) TestSummaryReturns(result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	fake.testSummaryReturns = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
+func (fake *FakeBuild) TestSummaryReturnsOnCall(i int, result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	if fake.testSummaryReturnsOnCall == nil {
+		fake.testSummaryReturnsOnCall = make(map[int]struct {
+			result1 *atc.TestSummary
+		})
+	}
+	fake.testSummaryReturnsOnCall[i] = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
 func (fake *FakeBuild) TracingAttrs() tracing.Attrs {
 	fake.tracingAttrsMutex.Lock()
 	ret, specificReturn := fake.tracingAttrsReturnsOnCall[len(fake.tracingAttrsArgsForCall)]
@@ -4673,6 +5345,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.adoptRerunInputsAndPipesMutex.RUnlock()
 	fake.allAssociatedTeamNamesMutex.RLock()
 	defer fake.allAssociatedTeamNamesMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
@@ -4685,6 +5363,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.createTimeMutex.RUnlock()
 	fake.createdByMutex.RLock()
 	defer fake.createdByMutex.RUnlock()
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.endTimeMutex.RLock()
@@ -4727,6 +5407,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.onCheckBuildStartMutex.RLock()
 	defer fake.onCheckBuildStartMutex.RUnlock()
+	fake.pendingApprovalsMutex.RLock()
+	defer fake.pendingApprovalsMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -4747,6 +5429,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
 	defer fake.rerunNumberMutex.RUnlock()
 	fake.rerunOfMutex.RLock()
@@ -4775,6 +5459,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setCommentMutex.RLock()
@@ -4797,6 +5483,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
 	fake.tracingAttrsMutex.RLock()
 	defer fake.tracingAttrsMutex.RUnlock()
 	fake.variablesMutex.RLock()
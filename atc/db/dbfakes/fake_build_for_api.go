@@ -6,7 +6,7 @@ import (
 	"sync"
 	"time"
 
-	lager "code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -22,6 +22,19 @@ type FakeBuildForAPI struct {
 	allAssociatedTeamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	ArtifactStub        func(int) (db.WorkerArtifact, error)
+	artifactMutex       sync.RWMutex
+	artifactArgsForCall []struct {
+		arg1 int
+	}
+	artifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	artifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	ArtifactsStub        func() ([]db.WorkerArtifact, error)
 	artifactsMutex       sync.RWMutex
 	artifactsArgsForCall []struct {
@@ -54,6 +67,21 @@ type FakeBuildForAPI struct {
 	createdByReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	DecideApprovalStub        func(atc.PlanID, bool, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -181,6 +209,18 @@ type FakeBuildForAPI struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PendingApprovalsStub        func() ([]db.BuildApproval, error)
+	pendingApprovalsMutex       sync.RWMutex
+	pendingApprovalsArgsForCall []struct {
+	}
+	pendingApprovalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	pendingApprovalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -394,6 +434,28 @@ type FakeBuildForAPI struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestResultsStub        func() ([]atc.TestResult, error)
+	testResultsMutex       sync.RWMutex
+	testResultsArgsForCall []struct {
+	}
+	testResultsReturns struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	testResultsReturnsOnCall map[int]struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	TestSummaryStub        func() *atc.TestSummary
+	testSummaryMutex       sync.RWMutex
+	testSummaryArgsForCall []struct {
+	}
+	testSummaryReturns struct {
+		result1 *atc.TestSummary
+	}
+	testSummaryReturnsOnCall map[int]struct {
+		result1 *atc.TestSummary
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -451,6 +513,70 @@ func (fake *FakeBuildForAPI) AllAssociatedTeamNamesReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) Artifact(arg1 int) (db.WorkerArtifact, error) {
+	fake.artifactMutex.Lock()
+	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
+	fake.artifactArgsForCall = append(fake.artifactArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.ArtifactStub
+	fakeReturns := fake.artifactReturns
+	fake.recordInvocation("Artifact", []interface{}{arg1})
+	fake.artifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) ArtifactCallCount() int {
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	return len(fake.artifactArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) ArtifactCalls(stub func(int) (db.WorkerArtifact, error)) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = stub
+}
+
+func (fake *FakeBuildForAPI) ArtifactArgsForCall(i int) int {
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
+	argsForCall := fake.artifactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildForAPI) ArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = nil
+	fake.artifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) ArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.artifactMutex.Lock()
+	defer fake.artifactMutex.Unlock()
+	fake.ArtifactStub = nil
+	if fake.artifactReturnsOnCall == nil {
+		fake.artifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.artifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildForAPI) Artifacts() ([]db.WorkerArtifact, error) {
 	fake.artifactsMutex.Lock()
 	ret, specificReturn := fake.artifactsReturnsOnCall[len(fake.artifactsArgsForCall)]
@@ -613,6 +739,72 @@ func (fake *FakeBuildForAPI) CreatedByReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) DecideApproval(arg1 atc.PlanID, arg2 bool, arg3 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 bool
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DecideApprovalStub
+	fakeReturns := fake.decideApprovalReturns
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2, arg3})
+	fake.decideApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) DecideApprovalCalls(stub func(atc.PlanID, bool, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuildForAPI) DecideApprovalArgsForCall(i int) (atc.PlanID, bool, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildForAPI) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildForAPI) EndTime() time.Time {
 	fake.endTimeMutex.Lock()
 	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
@@ -1266,6 +1458,62 @@ func (fake *FakeBuildForAPI) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) PendingApprovals() ([]db.BuildApproval, error) {
+	fake.pendingApprovalsMutex.Lock()
+	ret, specificReturn := fake.pendingApprovalsReturnsOnCall[len(fake.pendingApprovalsArgsForCall)]
+	fake.pendingApprovalsArgsForCall = append(fake.pendingApprovalsArgsForCall, struct {
+	}{})
+	stub := fake.PendingApprovalsStub
+	fakeReturns := fake.pendingApprovalsReturns
+	fake.recordInvocation("PendingApprovals", []interface{}{})
+	fake.pendingApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) PendingApprovalsCallCount() int {
+	fake.pendingApprovalsMutex.RLock()
+	defer fake.pendingApprovalsMutex.RUnlock()
+	return len(fake.pendingApprovalsArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) PendingApprovalsCalls(stub func() ([]db.BuildApproval, error)) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = stub
+}
+
+func (fake *FakeBuildForAPI) PendingApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = nil
+	fake.pendingApprovalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) PendingApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.pendingApprovalsMutex.Lock()
+	defer fake.pendingApprovalsMutex.Unlock()
+	fake.PendingApprovalsStub = nil
+	if fake.pendingApprovalsReturnsOnCall == nil {
+		fake.pendingApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.pendingApprovalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildForAPI) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2352,17 +2600,130 @@ func (fake *FakeBuildForAPI) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) TestResults() ([]atc.TestResult, error) {
+	fake.testResultsMutex.Lock()
+	ret, specificReturn := fake.testResultsReturnsOnCall[len(fake.testResultsArgsForCall)]
+	fake.testResultsArgsForCall = append(fake.testResultsArgsForCall, struct {
+	}{})
+	stub := fake.TestResultsStub
+	fakeReturns := fake.testResultsReturns
+	fake.recordInvocation("TestResults", []interface{}{})
+	fake.testResultsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) TestResultsCallCount() int {
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	return len(fake.testResultsArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) TestResultsCalls(stub func() ([]atc.TestResult, error)) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = stub
+}
+
+func (fake *FakeBuildForAPI) TestResultsReturns(result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	fake.testResultsReturns = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) TestResultsReturnsOnCall(i int, result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	if fake.testResultsReturnsOnCall == nil {
+		fake.testResultsReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestResult
+			result2 error
+		})
+	}
+	fake.testResultsReturnsOnCall[i] = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) TestSummary() *atc.TestSummary {
+	fake.testSummaryMutex.Lock()
+	ret, specificReturn := fake.testSummaryReturnsOnCall[len(fake.testSummaryArgsForCall)]
+	fake.testSummaryArgsForCall = append(fake.testSummaryArgsForCall, struct {
+	}{})
+	stub := fake.TestSummaryStub
+	fakeReturns := fake.testSummaryReturns
+	fake.recordInvocation("TestSummary", []interface{}{})
+	fake.testSummaryMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) TestSummaryCallCount() int {
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
+	return len(fake.testSummaryArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) TestSummaryCalls(stub func() *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = stub
+}
+
+func (fake *FakeBuildForAPI) TestSummaryReturns(result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	fake.testSummaryReturns = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) TestSummaryReturnsOnCall(i int, result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	if fake.testSummaryReturnsOnCall == nil {
+		fake.testSummaryReturnsOnCall = make(map[int]struct {
+			result1 *atc.TestSummary
+		})
+	}
+	fake.testSummaryReturnsOnCall[i] = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.allAssociatedTeamNamesMutex.RLock()
 	defer fake.allAssociatedTeamNamesMutex.RUnlock()
+	fake.artifactMutex.RLock()
+	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
 	fake.commentMutex.RLock()
 	defer fake.commentMutex.RUnlock()
 	fake.createdByMutex.RLock()
 	defer fake.createdByMutex.RUnlock()
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
@@ -2387,6 +2748,8 @@ func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	defer fake.markAsAbortedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.pendingApprovalsMutex.RLock()
+	defer fake.pendingApprovalsMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -2427,6 +2790,10 @@ func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -59,6 +59,24 @@ type FakeCheckFactory struct {
 		result2 bool
 		result3 error
 	}
+	TryCreateWebhookCheckStub        func(context.Context, db.Checkable, db.ResourceTypes, *atc.Webhook) (db.Build, bool, error)
+	tryCreateWebhookCheckMutex       sync.RWMutex
+	tryCreateWebhookCheckArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Checkable
+		arg3 db.ResourceTypes
+		arg4 *atc.Webhook
+	}
+	tryCreateWebhookCheckReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	tryCreateWebhookCheckReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -272,6 +290,76 @@ func (fake *FakeCheckFactory) TryCreateCheckReturnsOnCall(i int, result1 db.Buil
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCheckFactory) TryCreateWebhookCheck(arg1 context.Context, arg2 db.Checkable, arg3 db.ResourceTypes, arg4 *atc.Webhook) (db.Build, bool, error) {
+	fake.tryCreateWebhookCheckMutex.Lock()
+	ret, specificReturn := fake.tryCreateWebhookCheckReturnsOnCall[len(fake.tryCreateWebhookCheckArgsForCall)]
+	fake.tryCreateWebhookCheckArgsForCall = append(fake.tryCreateWebhookCheckArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Checkable
+		arg3 db.ResourceTypes
+		arg4 *atc.Webhook
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.TryCreateWebhookCheckStub
+	fakeReturns := fake.tryCreateWebhookCheckReturns
+	fake.recordInvocation("TryCreateWebhookCheck", []interface{}{arg1, arg2, arg3, arg4})
+	fake.tryCreateWebhookCheckMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCheckFactory) TryCreateWebhookCheckCallCount() int {
+	fake.tryCreateWebhookCheckMutex.RLock()
+	defer fake.tryCreateWebhookCheckMutex.RUnlock()
+	return len(fake.tryCreateWebhookCheckArgsForCall)
+}
+
+func (fake *FakeCheckFactory) TryCreateWebhookCheckCalls(stub func(context.Context, db.Checkable, db.ResourceTypes, *atc.Webhook) (db.Build, bool, error)) {
+	fake.tryCreateWebhookCheckMutex.Lock()
+	defer fake.tryCreateWebhookCheckMutex.Unlock()
+	fake.TryCreateWebhookCheckStub = stub
+}
+
+func (fake *FakeCheckFactory) TryCreateWebhookCheckArgsForCall(i int) (context.Context, db.Checkable, db.ResourceTypes, *atc.Webhook) {
+	fake.tryCreateWebhookCheckMutex.RLock()
+	defer fake.tryCreateWebhookCheckMutex.RUnlock()
+	argsForCall := fake.tryCreateWebhookCheckArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCheckFactory) TryCreateWebhookCheckReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.tryCreateWebhookCheckMutex.Lock()
+	defer fake.tryCreateWebhookCheckMutex.Unlock()
+	fake.TryCreateWebhookCheckStub = nil
+	fake.tryCreateWebhookCheckReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCheckFactory) TryCreateWebhookCheckReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.tryCreateWebhookCheckMutex.Lock()
+	defer fake.tryCreateWebhookCheckMutex.Unlock()
+	fake.TryCreateWebhookCheckStub = nil
+	if fake.tryCreateWebhookCheckReturnsOnCall == nil {
+		fake.tryCreateWebhookCheckReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.tryCreateWebhookCheckReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -283,6 +371,8 @@ func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.tryCreateCheckMutex.RLock()
 	defer fake.tryCreateCheckMutex.RUnlock()
+	fake.tryCreateWebhookCheckMutex.RLock()
+	defer fake.tryCreateWebhookCheckMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
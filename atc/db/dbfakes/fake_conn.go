@@ -39,6 +39,16 @@ type FakeConn struct {
 		result1 db.Tx
 		result2 error
 	}
+	BuildLogStoreStub        func() db.BuildLogStore
+	buildLogStoreMutex       sync.RWMutex
+	buildLogStoreArgsForCall []struct {
+	}
+	buildLogStoreReturns struct {
+		result1 db.BuildLogStore
+	}
+	buildLogStoreReturnsOnCall map[int]struct {
+		result1 db.BuildLogStore
+	}
 	BusStub        func() db.NotificationsBus
 	busMutex       sync.RWMutex
 	busArgsForCall []struct {
@@ -354,6 +364,59 @@ func (fake *FakeConn) BeginTxReturnsOnCall(i int, result1 db.Tx, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeConn) BuildLogStore() db.BuildLogStore {
+	fake.buildLogStoreMutex.Lock()
+	ret, specificReturn := fake.buildLogStoreReturnsOnCall[len(fake.buildLogStoreArgsForCall)]
+	fake.buildLogStoreArgsForCall = append(fake.buildLogStoreArgsForCall, struct {
+	}{})
+	stub := fake.BuildLogStoreStub
+	fakeReturns := fake.buildLogStoreReturns
+	fake.recordInvocation("BuildLogStore", []interface{}{})
+	fake.buildLogStoreMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) BuildLogStoreCallCount() int {
+	fake.buildLogStoreMutex.RLock()
+	defer fake.buildLogStoreMutex.RUnlock()
+	return len(fake.buildLogStoreArgsForCall)
+}
+
+func (fake *FakeConn) BuildLogStoreCalls(stub func() db.BuildLogStore) {
+	fake.buildLogStoreMutex.Lock()
+	defer fake.buildLogStoreMutex.Unlock()
+	fake.BuildLogStoreStub = stub
+}
+
+func (fake *FakeConn) BuildLogStoreReturns(result1 db.BuildLogStore) {
+	fake.buildLogStoreMutex.Lock()
+	defer fake.buildLogStoreMutex.Unlock()
+	fake.BuildLogStoreStub = nil
+	fake.buildLogStoreReturns = struct {
+		result1 db.BuildLogStore
+	}{result1}
+}
+
+func (fake *FakeConn) BuildLogStoreReturnsOnCall(i int, result1 db.BuildLogStore) {
+	fake.buildLogStoreMutex.Lock()
+	defer fake.buildLogStoreMutex.Unlock()
+	fake.BuildLogStoreStub = nil
+	if fake.buildLogStoreReturnsOnCall == nil {
+		fake.buildLogStoreReturnsOnCall = make(map[int]struct {
+			result1 db.BuildLogStore
+		})
+	}
+	fake.buildLogStoreReturnsOnCall[i] = struct {
+		result1 db.BuildLogStore
+	}{result1}
+}
+
 func (fake *FakeConn) Bus() db.NotificationsBus {
 	fake.busMutex.Lock()
 	ret, specificReturn := fake.busReturnsOnCall[len(fake.busArgsForCall)]
@@ -1312,6 +1375,8 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.beginMutex.RUnlock()
 	fake.beginTxMutex.RLock()
 	defer fake.beginTxMutex.RUnlock()
+	fake.buildLogStoreMutex.RLock()
+	defer fake.buildLogStoreMutex.RUnlock()
 	fake.busMutex.RLock()
 	defer fake.busMutex.RUnlock()
 	fake.closeMutex.RLock()
@@ -151,6 +151,20 @@ type FakeCreatedVolume struct {
 	pathReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PublishArtifactStub        func(string, int) (db.WorkerArtifact, error)
+	publishArtifactMutex       sync.RWMutex
+	publishArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	publishArtifactReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	publishArtifactReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	ResourceTypeStub        func() (*db.VolumeResourceType, error)
 	resourceTypeMutex       sync.RWMutex
 	resourceTypeArgsForCall []struct {
@@ -934,6 +948,71 @@ func (fake *FakeCreatedVolume) PathReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) PublishArtifact(arg1 string, arg2 int) (db.WorkerArtifact, error) {
+	fake.publishArtifactMutex.Lock()
+	ret, specificReturn := fake.publishArtifactReturnsOnCall[len(fake.publishArtifactArgsForCall)]
+	fake.publishArtifactArgsForCall = append(fake.publishArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.PublishArtifactStub
+	fakeReturns := fake.publishArtifactReturns
+	fake.recordInvocation("PublishArtifact", []interface{}{arg1, arg2})
+	fake.publishArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCreatedVolume) PublishArtifactCallCount() int {
+	fake.publishArtifactMutex.RLock()
+	defer fake.publishArtifactMutex.RUnlock()
+	return len(fake.publishArtifactArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) PublishArtifactCalls(stub func(string, int) (db.WorkerArtifact, error)) {
+	fake.publishArtifactMutex.Lock()
+	defer fake.publishArtifactMutex.Unlock()
+	fake.PublishArtifactStub = stub
+}
+
+func (fake *FakeCreatedVolume) PublishArtifactArgsForCall(i int) (string, int) {
+	fake.publishArtifactMutex.RLock()
+	defer fake.publishArtifactMutex.RUnlock()
+	argsForCall := fake.publishArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCreatedVolume) PublishArtifactReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.publishArtifactMutex.Lock()
+	defer fake.publishArtifactMutex.Unlock()
+	fake.PublishArtifactStub = nil
+	fake.publishArtifactReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatedVolume) PublishArtifactReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.publishArtifactMutex.Lock()
+	defer fake.publishArtifactMutex.Unlock()
+	fake.PublishArtifactStub = nil
+	if fake.publishArtifactReturnsOnCall == nil {
+		fake.publishArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.publishArtifactReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCreatedVolume) ResourceType() (*db.VolumeResourceType, error) {
 	fake.resourceTypeMutex.Lock()
 	ret, specificReturn := fake.resourceTypeReturnsOnCall[len(fake.resourceTypeArgsForCall)]
@@ -1347,6 +1426,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.parentHandleMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
+	fake.publishArtifactMutex.RLock()
+	defer fake.publishArtifactMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
@@ -55,6 +55,19 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	BuildInputsForMappingStub        func(db.InputMapping) ([]db.BuildInput, error)
+	buildInputsForMappingMutex       sync.RWMutex
+	buildInputsForMappingArgsForCall []struct {
+		arg1 db.InputMapping
+	}
+	buildInputsForMappingReturns struct {
+		result1 []db.BuildInput
+		result2 error
+	}
+	buildInputsForMappingReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 error
+	}
 	BuildsStub        func(db.Page) ([]db.BuildForAPI, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -464,6 +477,16 @@ type FakeJob struct {
 		result1 bool
 		result2 error
 	}
+	ScheduleNextFireStub        func() time.Time
+	scheduleNextFireMutex       sync.RWMutex
+	scheduleNextFireArgsForCall []struct {
+	}
+	scheduleNextFireReturns struct {
+		result1 time.Time
+	}
+	scheduleNextFireReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ScheduleRequestedTimeStub        func() time.Time
 	scheduleRequestedTimeMutex       sync.RWMutex
 	scheduleRequestedTimeArgsForCall []struct {
@@ -474,6 +497,16 @@ type FakeJob struct {
 	scheduleRequestedTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	SchedulingDiagnosticsStub        func() []atc.SchedulingDiagnostic
+	schedulingDiagnosticsMutex       sync.RWMutex
+	schedulingDiagnosticsArgsForCall []struct {
+	}
+	schedulingDiagnosticsReturns struct {
+		result1 []atc.SchedulingDiagnostic
+	}
+	schedulingDiagnosticsReturnsOnCall map[int]struct {
+		result1 []atc.SchedulingDiagnostic
+	}
 	SetHasNewInputsStub        func(bool) error
 	setHasNewInputsMutex       sync.RWMutex
 	setHasNewInputsArgsForCall []struct {
@@ -515,6 +548,19 @@ type FakeJob struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestHistoryStub        func(int) ([]atc.TestHistory, error)
+	testHistoryMutex       sync.RWMutex
+	testHistoryArgsForCall []struct {
+		arg1 int
+	}
+	testHistoryReturns struct {
+		result1 []atc.TestHistory
+		result2 error
+	}
+	testHistoryReturnsOnCall map[int]struct {
+		result1 []atc.TestHistory
+		result2 error
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -547,6 +593,17 @@ type FakeJob struct {
 	updateLastScheduledReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateScheduleNextFireStub        func(time.Time) error
+	updateScheduleNextFireMutex       sync.RWMutex
+	updateScheduleNextFireArgsForCall []struct {
+		arg1 time.Time
+	}
+	updateScheduleNextFireReturns struct {
+		result1 error
+	}
+	updateScheduleNextFireReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -741,6 +798,70 @@ func (fake *FakeJob) BuildReturnsOnCall(i int, result1 db.Build, result2 bool, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) BuildInputsForMapping(arg1 db.InputMapping) ([]db.BuildInput, error) {
+	fake.buildInputsForMappingMutex.Lock()
+	ret, specificReturn := fake.buildInputsForMappingReturnsOnCall[len(fake.buildInputsForMappingArgsForCall)]
+	fake.buildInputsForMappingArgsForCall = append(fake.buildInputsForMappingArgsForCall, struct {
+		arg1 db.InputMapping
+	}{arg1})
+	stub := fake.BuildInputsForMappingStub
+	fakeReturns := fake.buildInputsForMappingReturns
+	fake.recordInvocation("BuildInputsForMapping", []interface{}{arg1})
+	fake.buildInputsForMappingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) BuildInputsForMappingCallCount() int {
+	fake.buildInputsForMappingMutex.RLock()
+	defer fake.buildInputsForMappingMutex.RUnlock()
+	return len(fake.buildInputsForMappingArgsForCall)
+}
+
+func (fake *FakeJob) BuildInputsForMappingCalls(stub func(db.InputMapping) ([]db.BuildInput, error)) {
+	fake.buildInputsForMappingMutex.Lock()
+	defer fake.buildInputsForMappingMutex.Unlock()
+	fake.BuildInputsForMappingStub = stub
+}
+
+func (fake *FakeJob) BuildInputsForMappingArgsForCall(i int) db.InputMapping {
+	fake.buildInputsForMappingMutex.RLock()
+	defer fake.buildInputsForMappingMutex.RUnlock()
+	argsForCall := fake.buildInputsForMappingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) BuildInputsForMappingReturns(result1 []db.BuildInput, result2 error) {
+	fake.buildInputsForMappingMutex.Lock()
+	defer fake.buildInputsForMappingMutex.Unlock()
+	fake.BuildInputsForMappingStub = nil
+	fake.buildInputsForMappingReturns = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) BuildInputsForMappingReturnsOnCall(i int, result1 []db.BuildInput, result2 error) {
+	fake.buildInputsForMappingMutex.Lock()
+	defer fake.buildInputsForMappingMutex.Unlock()
+	fake.BuildInputsForMappingStub = nil
+	if fake.buildInputsForMappingReturnsOnCall == nil {
+		fake.buildInputsForMappingReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 error
+		})
+	}
+	fake.buildInputsForMappingReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Builds(arg1 db.Page) ([]db.BuildForAPI, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -2748,6 +2869,59 @@ func (fake *FakeJob) ScheduleBuildReturnsOnCall(i int, result1 bool, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeJob) ScheduleNextFire() time.Time {
+	fake.scheduleNextFireMutex.Lock()
+	ret, specificReturn := fake.scheduleNextFireReturnsOnCall[len(fake.scheduleNextFireArgsForCall)]
+	fake.scheduleNextFireArgsForCall = append(fake.scheduleNextFireArgsForCall, struct {
+	}{})
+	stub := fake.ScheduleNextFireStub
+	fakeReturns := fake.scheduleNextFireReturns
+	fake.recordInvocation("ScheduleNextFire", []interface{}{})
+	fake.scheduleNextFireMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) ScheduleNextFireCallCount() int {
+	fake.scheduleNextFireMutex.RLock()
+	defer fake.scheduleNextFireMutex.RUnlock()
+	return len(fake.scheduleNextFireArgsForCall)
+}
+
+func (fake *FakeJob) ScheduleNextFireCalls(stub func() time.Time) {
+	fake.scheduleNextFireMutex.Lock()
+	defer fake.scheduleNextFireMutex.Unlock()
+	fake.ScheduleNextFireStub = stub
+}
+
+func (fake *FakeJob) ScheduleNextFireReturns(result1 time.Time) {
+	fake.scheduleNextFireMutex.Lock()
+	defer fake.scheduleNextFireMutex.Unlock()
+	fake.ScheduleNextFireStub = nil
+	fake.scheduleNextFireReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleNextFireReturnsOnCall(i int, result1 time.Time) {
+	fake.scheduleNextFireMutex.Lock()
+	defer fake.scheduleNextFireMutex.Unlock()
+	fake.ScheduleNextFireStub = nil
+	if fake.scheduleNextFireReturnsOnCall == nil {
+		fake.scheduleNextFireReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.scheduleNextFireReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) ScheduleRequestedTime() time.Time {
 	fake.scheduleRequestedTimeMutex.Lock()
 	ret, specificReturn := fake.scheduleRequestedTimeReturnsOnCall[len(fake.scheduleRequestedTimeArgsForCall)]
@@ -2801,6 +2975,59 @@ func (fake *FakeJob) ScheduleRequestedTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeJob) SchedulingDiagnostics() []atc.SchedulingDiagnostic {
+	fake.schedulingDiagnosticsMutex.Lock()
+	ret, specificReturn := fake.schedulingDiagnosticsReturnsOnCall[len(fake.schedulingDiagnosticsArgsForCall)]
+	fake.schedulingDiagnosticsArgsForCall = append(fake.schedulingDiagnosticsArgsForCall, struct {
+	}{})
+	stub := fake.SchedulingDiagnosticsStub
+	fakeReturns := fake.schedulingDiagnosticsReturns
+	fake.recordInvocation("SchedulingDiagnostics", []interface{}{})
+	fake.schedulingDiagnosticsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) SchedulingDiagnosticsCallCount() int {
+	fake.schedulingDiagnosticsMutex.RLock()
+	defer fake.schedulingDiagnosticsMutex.RUnlock()
+	return len(fake.schedulingDiagnosticsArgsForCall)
+}
+
+func (fake *FakeJob) SchedulingDiagnosticsCalls(stub func() []atc.SchedulingDiagnostic) {
+	fake.schedulingDiagnosticsMutex.Lock()
+	defer fake.schedulingDiagnosticsMutex.Unlock()
+	fake.SchedulingDiagnosticsStub = stub
+}
+
+func (fake *FakeJob) SchedulingDiagnosticsReturns(result1 []atc.SchedulingDiagnostic) {
+	fake.schedulingDiagnosticsMutex.Lock()
+	defer fake.schedulingDiagnosticsMutex.Unlock()
+	fake.SchedulingDiagnosticsStub = nil
+	fake.schedulingDiagnosticsReturns = struct {
+		result1 []atc.SchedulingDiagnostic
+	}{result1}
+}
+
+func (fake *FakeJob) SchedulingDiagnosticsReturnsOnCall(i int, result1 []atc.SchedulingDiagnostic) {
+	fake.schedulingDiagnosticsMutex.Lock()
+	defer fake.schedulingDiagnosticsMutex.Unlock()
+	fake.SchedulingDiagnosticsStub = nil
+	if fake.schedulingDiagnosticsReturnsOnCall == nil {
+		fake.schedulingDiagnosticsReturnsOnCall = make(map[int]struct {
+			result1 []atc.SchedulingDiagnostic
+		})
+	}
+	fake.schedulingDiagnosticsReturnsOnCall[i] = struct {
+		result1 []atc.SchedulingDiagnostic
+	}{result1}
+}
+
 func (fake *FakeJob) SetHasNewInputs(arg1 bool) error {
 	fake.setHasNewInputsMutex.Lock()
 	ret, specificReturn := fake.setHasNewInputsReturnsOnCall[len(fake.setHasNewInputsArgsForCall)]
@@ -3021,6 +3248,70 @@ func (fake *FakeJob) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) TestHistory(arg1 int) ([]atc.TestHistory, error) {
+	fake.testHistoryMutex.Lock()
+	ret, specificReturn := fake.testHistoryReturnsOnCall[len(fake.testHistoryArgsForCall)]
+	fake.testHistoryArgsForCall = append(fake.testHistoryArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.TestHistoryStub
+	fakeReturns := fake.testHistoryReturns
+	fake.recordInvocation("TestHistory", []interface{}{arg1})
+	fake.testHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) TestHistoryCallCount() int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	return len(fake.testHistoryArgsForCall)
+}
+
+func (fake *FakeJob) TestHistoryCalls(stub func(int) ([]atc.TestHistory, error)) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = stub
+}
+
+func (fake *FakeJob) TestHistoryArgsForCall(i int) int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	argsForCall := fake.testHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) TestHistoryReturns(result1 []atc.TestHistory, result2 error) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = nil
+	fake.testHistoryReturns = struct {
+		result1 []atc.TestHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) TestHistoryReturnsOnCall(i int, result1 []atc.TestHistory, result2 error) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = nil
+	if fake.testHistoryReturnsOnCall == nil {
+		fake.testHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestHistory
+			result2 error
+		})
+	}
+	fake.testHistoryReturnsOnCall[i] = struct {
+		result1 []atc.TestHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -3196,6 +3487,67 @@ func (fake *FakeJob) UpdateLastScheduledReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) UpdateScheduleNextFire(arg1 time.Time) error {
+	fake.updateScheduleNextFireMutex.Lock()
+	ret, specificReturn := fake.updateScheduleNextFireReturnsOnCall[len(fake.updateScheduleNextFireArgsForCall)]
+	fake.updateScheduleNextFireArgsForCall = append(fake.updateScheduleNextFireArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.UpdateScheduleNextFireStub
+	fakeReturns := fake.updateScheduleNextFireReturns
+	fake.recordInvocation("UpdateScheduleNextFire", []interface{}{arg1})
+	fake.updateScheduleNextFireMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) UpdateScheduleNextFireCallCount() int {
+	fake.updateScheduleNextFireMutex.RLock()
+	defer fake.updateScheduleNextFireMutex.RUnlock()
+	return len(fake.updateScheduleNextFireArgsForCall)
+}
+
+func (fake *FakeJob) UpdateScheduleNextFireCalls(stub func(time.Time) error) {
+	fake.updateScheduleNextFireMutex.Lock()
+	defer fake.updateScheduleNextFireMutex.Unlock()
+	fake.UpdateScheduleNextFireStub = stub
+}
+
+func (fake *FakeJob) UpdateScheduleNextFireArgsForCall(i int) time.Time {
+	fake.updateScheduleNextFireMutex.RLock()
+	defer fake.updateScheduleNextFireMutex.RUnlock()
+	argsForCall := fake.updateScheduleNextFireArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) UpdateScheduleNextFireReturns(result1 error) {
+	fake.updateScheduleNextFireMutex.Lock()
+	defer fake.updateScheduleNextFireMutex.Unlock()
+	fake.UpdateScheduleNextFireStub = nil
+	fake.updateScheduleNextFireReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) UpdateScheduleNextFireReturnsOnCall(i int, result1 error) {
+	fake.updateScheduleNextFireMutex.Lock()
+	defer fake.updateScheduleNextFireMutex.Unlock()
+	fake.UpdateScheduleNextFireStub = nil
+	if fake.updateScheduleNextFireReturnsOnCall == nil {
+		fake.updateScheduleNextFireReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateScheduleNextFireReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3205,6 +3557,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.algorithmInputsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.buildInputsForMappingMutex.RLock()
+	defer fake.buildInputsForMappingMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
@@ -3275,8 +3629,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
 	defer fake.scheduleBuildMutex.RUnlock()
+	fake.scheduleNextFireMutex.RLock()
+	defer fake.scheduleNextFireMutex.RUnlock()
 	fake.scheduleRequestedTimeMutex.RLock()
 	defer fake.scheduleRequestedTimeMutex.RUnlock()
+	fake.schedulingDiagnosticsMutex.RLock()
+	defer fake.schedulingDiagnosticsMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
 	fake.tagsMutex.RLock()
@@ -3285,12 +3643,16 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.updateLastScheduledMutex.RLock()
 	defer fake.updateLastScheduledMutex.RUnlock()
+	fake.updateScheduleNextFireMutex.RLock()
+	defer fake.updateScheduleNextFireMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
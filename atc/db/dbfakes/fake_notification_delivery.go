@@ -0,0 +1,1117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationDelivery struct {
+	AttemptsStub        func() int
+	attemptsMutex       sync.RWMutex
+	attemptsArgsForCall []struct {
+	}
+	attemptsReturns struct {
+		result1 int
+	}
+	attemptsReturnsOnCall map[int]struct {
+		result1 int
+	}
+	BuildIDStub        func() int
+	buildIDMutex       sync.RWMutex
+	buildIDArgsForCall []struct {
+	}
+	buildIDReturns struct {
+		result1 int
+	}
+	buildIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ConfigStub        func() (atc.NotificationConfig, bool)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+	}
+	configReturns struct {
+		result1 atc.NotificationConfig
+		result2 bool
+	}
+	configReturnsOnCall map[int]struct {
+		result1 atc.NotificationConfig
+		result2 bool
+	}
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	FailStub        func(int, string) error
+	failMutex       sync.RWMutex
+	failArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	failReturns struct {
+		result1 error
+	}
+	failReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IDStub        func() int
+	iDMutex       sync.RWMutex
+	iDArgsForCall []struct {
+	}
+	iDReturns struct {
+		result1 int
+	}
+	iDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	LastErrorStub        func() string
+	lastErrorMutex       sync.RWMutex
+	lastErrorArgsForCall []struct {
+	}
+	lastErrorReturns struct {
+		result1 string
+	}
+	lastErrorReturnsOnCall map[int]struct {
+		result1 string
+	}
+	NotificationStub        func() string
+	notificationMutex       sync.RWMutex
+	notificationArgsForCall []struct {
+	}
+	notificationReturns struct {
+		result1 string
+	}
+	notificationReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PayloadStub        func() []byte
+	payloadMutex       sync.RWMutex
+	payloadArgsForCall []struct {
+	}
+	payloadReturns struct {
+		result1 []byte
+	}
+	payloadReturnsOnCall map[int]struct {
+		result1 []byte
+	}
+	ResponseStatusStub        func() int
+	responseStatusMutex       sync.RWMutex
+	responseStatusArgsForCall []struct {
+	}
+	responseStatusReturns struct {
+		result1 int
+	}
+	responseStatusReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RetryStub        func(int, string, time.Time) error
+	retryMutex       sync.RWMutex
+	retryArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 time.Time
+	}
+	retryReturns struct {
+		result1 error
+	}
+	retryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusStub        func() atc.NotificationDeliveryStatus
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 atc.NotificationDeliveryStatus
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 atc.NotificationDeliveryStatus
+	}
+	SucceededStub        func(int) error
+	succeededMutex       sync.RWMutex
+	succeededArgsForCall []struct {
+		arg1 int
+	}
+	succeededReturns struct {
+		result1 error
+	}
+	succeededReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TeamIDStub        func() int
+	teamIDMutex       sync.RWMutex
+	teamIDArgsForCall []struct {
+	}
+	teamIDReturns struct {
+		result1 int
+	}
+	teamIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	URLStub        func() string
+	uRLMutex       sync.RWMutex
+	uRLArgsForCall []struct {
+	}
+	uRLReturns struct {
+		result1 string
+	}
+	uRLReturnsOnCall map[int]struct {
+		result1 string
+	}
+	UpdatedAtStub        func() time.Time
+	updatedAtMutex       sync.RWMutex
+	updatedAtArgsForCall []struct {
+	}
+	updatedAtReturns struct {
+		result1 time.Time
+	}
+	updatedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationDelivery) Attempts() int {
+	fake.attemptsMutex.Lock()
+	ret, specificReturn := fake.attemptsReturnsOnCall[len(fake.attemptsArgsForCall)]
+	fake.attemptsArgsForCall = append(fake.attemptsArgsForCall, struct {
+	}{})
+	stub := fake.AttemptsStub
+	fakeReturns := fake.attemptsReturns
+	fake.recordInvocation("Attempts", []interface{}{})
+	fake.attemptsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) AttemptsCallCount() int {
+	fake.attemptsMutex.RLock()
+	defer fake.attemptsMutex.RUnlock()
+	return len(fake.attemptsArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) AttemptsCalls(stub func() int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = stub
+}
+
+func (fake *FakeNotificationDelivery) AttemptsReturns(result1 int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = nil
+	fake.attemptsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) AttemptsReturnsOnCall(i int, result1 int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = nil
+	if fake.attemptsReturnsOnCall == nil {
+		fake.attemptsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.attemptsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildID() int {
+	fake.buildIDMutex.Lock()
+	ret, specificReturn := fake.buildIDReturnsOnCall[len(fake.buildIDArgsForCall)]
+	fake.buildIDArgsForCall = append(fake.buildIDArgsForCall, struct {
+	}{})
+	stub := fake.BuildIDStub
+	fakeReturns := fake.buildIDReturns
+	fake.recordInvocation("BuildID", []interface{}{})
+	fake.buildIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) BuildIDCallCount() int {
+	fake.buildIDMutex.RLock()
+	defer fake.buildIDMutex.RUnlock()
+	return len(fake.buildIDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) BuildIDCalls(stub func() int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) BuildIDReturns(result1 int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = nil
+	fake.buildIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildIDReturnsOnCall(i int, result1 int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = nil
+	if fake.buildIDReturnsOnCall == nil {
+		fake.buildIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Config() (atc.NotificationConfig, bool) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+	}{})
+	stub := fake.ConfigStub
+	fakeReturns := fake.configReturns
+	fake.recordInvocation("Config", []interface{}{})
+	fake.configMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationDelivery) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) ConfigCalls(stub func() (atc.NotificationConfig, bool)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *FakeNotificationDelivery) ConfigReturns(result1 atc.NotificationConfig, result2 bool) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 atc.NotificationConfig
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDelivery) ConfigReturnsOnCall(i int, result1 atc.NotificationConfig, result2 bool) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationConfig
+			result2 bool
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 atc.NotificationConfig
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDelivery) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	stub := fake.CreatedAtStub
+	fakeReturns := fake.createdAtReturns
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Fail(arg1 int, arg2 string) error {
+	fake.failMutex.Lock()
+	ret, specificReturn := fake.failReturnsOnCall[len(fake.failArgsForCall)]
+	fake.failArgsForCall = append(fake.failArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FailStub
+	fakeReturns := fake.failReturns
+	fake.recordInvocation("Fail", []interface{}{arg1, arg2})
+	fake.failMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) FailCallCount() int {
+	fake.failMutex.RLock()
+	defer fake.failMutex.RUnlock()
+	return len(fake.failArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) FailCalls(stub func(int, string) error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = stub
+}
+
+func (fake *FakeNotificationDelivery) FailArgsForCall(i int) (int, string) {
+	fake.failMutex.RLock()
+	defer fake.failMutex.RUnlock()
+	argsForCall := fake.failArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotificationDelivery) FailReturns(result1 error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = nil
+	fake.failReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) FailReturnsOnCall(i int, result1 error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = nil
+	if fake.failReturnsOnCall == nil {
+		fake.failReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.failReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ID() int {
+	fake.iDMutex.Lock()
+	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
+	fake.iDArgsForCall = append(fake.iDArgsForCall, struct {
+	}{})
+	stub := fake.IDStub
+	fakeReturns := fake.iDReturns
+	fake.recordInvocation("ID", []interface{}{})
+	fake.iDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) IDCallCount() int {
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	return len(fake.iDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) IDCalls(stub func() int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) IDReturns(result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	fake.iDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) IDReturnsOnCall(i int, result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	if fake.iDReturnsOnCall == nil {
+		fake.iDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.iDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) LastError() string {
+	fake.lastErrorMutex.Lock()
+	ret, specificReturn := fake.lastErrorReturnsOnCall[len(fake.lastErrorArgsForCall)]
+	fake.lastErrorArgsForCall = append(fake.lastErrorArgsForCall, struct {
+	}{})
+	stub := fake.LastErrorStub
+	fakeReturns := fake.lastErrorReturns
+	fake.recordInvocation("LastError", []interface{}{})
+	fake.lastErrorMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) LastErrorCallCount() int {
+	fake.lastErrorMutex.RLock()
+	defer fake.lastErrorMutex.RUnlock()
+	return len(fake.lastErrorArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) LastErrorCalls(stub func() string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = stub
+}
+
+func (fake *FakeNotificationDelivery) LastErrorReturns(result1 string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = nil
+	fake.lastErrorReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) LastErrorReturnsOnCall(i int, result1 string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = nil
+	if fake.lastErrorReturnsOnCall == nil {
+		fake.lastErrorReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.lastErrorReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Notification() string {
+	fake.notificationMutex.Lock()
+	ret, specificReturn := fake.notificationReturnsOnCall[len(fake.notificationArgsForCall)]
+	fake.notificationArgsForCall = append(fake.notificationArgsForCall, struct {
+	}{})
+	stub := fake.NotificationStub
+	fakeReturns := fake.notificationReturns
+	fake.recordInvocation("Notification", []interface{}{})
+	fake.notificationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) NotificationCallCount() int {
+	fake.notificationMutex.RLock()
+	defer fake.notificationMutex.RUnlock()
+	return len(fake.notificationArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) NotificationCalls(stub func() string) {
+	fake.notificationMutex.Lock()
+	defer fake.notificationMutex.Unlock()
+	fake.NotificationStub = stub
+}
+
+func (fake *FakeNotificationDelivery) NotificationReturns(result1 string) {
+	fake.notificationMutex.Lock()
+	defer fake.notificationMutex.Unlock()
+	fake.NotificationStub = nil
+	fake.notificationReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) NotificationReturnsOnCall(i int, result1 string) {
+	fake.notificationMutex.Lock()
+	defer fake.notificationMutex.Unlock()
+	fake.NotificationStub = nil
+	if fake.notificationReturnsOnCall == nil {
+		fake.notificationReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.notificationReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Payload() []byte {
+	fake.payloadMutex.Lock()
+	ret, specificReturn := fake.payloadReturnsOnCall[len(fake.payloadArgsForCall)]
+	fake.payloadArgsForCall = append(fake.payloadArgsForCall, struct {
+	}{})
+	stub := fake.PayloadStub
+	fakeReturns := fake.payloadReturns
+	fake.recordInvocation("Payload", []interface{}{})
+	fake.payloadMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) PayloadCallCount() int {
+	fake.payloadMutex.RLock()
+	defer fake.payloadMutex.RUnlock()
+	return len(fake.payloadArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) PayloadCalls(stub func() []byte) {
+	fake.payloadMutex.Lock()
+	defer fake.payloadMutex.Unlock()
+	fake.PayloadStub = stub
+}
+
+func (fake *FakeNotificationDelivery) PayloadReturns(result1 []byte) {
+	fake.payloadMutex.Lock()
+	defer fake.payloadMutex.Unlock()
+	fake.PayloadStub = nil
+	fake.payloadReturns = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) PayloadReturnsOnCall(i int, result1 []byte) {
+	fake.payloadMutex.Lock()
+	defer fake.payloadMutex.Unlock()
+	fake.PayloadStub = nil
+	if fake.payloadReturnsOnCall == nil {
+		fake.payloadReturnsOnCall = make(map[int]struct {
+			result1 []byte
+		})
+	}
+	fake.payloadReturnsOnCall[i] = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ResponseStatus() int {
+	fake.responseStatusMutex.Lock()
+	ret, specificReturn := fake.responseStatusReturnsOnCall[len(fake.responseStatusArgsForCall)]
+	fake.responseStatusArgsForCall = append(fake.responseStatusArgsForCall, struct {
+	}{})
+	stub := fake.ResponseStatusStub
+	fakeReturns := fake.responseStatusReturns
+	fake.recordInvocation("ResponseStatus", []interface{}{})
+	fake.responseStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) ResponseStatusCallCount() int {
+	fake.responseStatusMutex.RLock()
+	defer fake.responseStatusMutex.RUnlock()
+	return len(fake.responseStatusArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) ResponseStatusCalls(stub func() int) {
+	fake.responseStatusMutex.Lock()
+	defer fake.responseStatusMutex.Unlock()
+	fake.ResponseStatusStub = stub
+}
+
+func (fake *FakeNotificationDelivery) ResponseStatusReturns(result1 int) {
+	fake.responseStatusMutex.Lock()
+	defer fake.responseStatusMutex.Unlock()
+	fake.ResponseStatusStub = nil
+	fake.responseStatusReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ResponseStatusReturnsOnCall(i int, result1 int) {
+	fake.responseStatusMutex.Lock()
+	defer fake.responseStatusMutex.Unlock()
+	fake.ResponseStatusStub = nil
+	if fake.responseStatusReturnsOnCall == nil {
+		fake.responseStatusReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.responseStatusReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Retry(arg1 int, arg2 string, arg3 time.Time) error {
+	fake.retryMutex.Lock()
+	ret, specificReturn := fake.retryReturnsOnCall[len(fake.retryArgsForCall)]
+	fake.retryArgsForCall = append(fake.retryArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.RetryStub
+	fakeReturns := fake.retryReturns
+	fake.recordInvocation("Retry", []interface{}{arg1, arg2, arg3})
+	fake.retryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) RetryCallCount() int {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	return len(fake.retryArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) RetryCalls(stub func(int, string, time.Time) error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = stub
+}
+
+func (fake *FakeNotificationDelivery) RetryArgsForCall(i int) (int, string, time.Time) {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	argsForCall := fake.retryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNotificationDelivery) RetryReturns(result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	fake.retryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) RetryReturnsOnCall(i int, result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	if fake.retryReturnsOnCall == nil {
+		fake.retryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.retryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Status() atc.NotificationDeliveryStatus {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) StatusCalls(stub func() atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeNotificationDelivery) StatusReturns(result1 atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 atc.NotificationDeliveryStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) StatusReturnsOnCall(i int, result1 atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationDeliveryStatus
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 atc.NotificationDeliveryStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Succeeded(arg1 int) error {
+	fake.succeededMutex.Lock()
+	ret, specificReturn := fake.succeededReturnsOnCall[len(fake.succeededArgsForCall)]
+	fake.succeededArgsForCall = append(fake.succeededArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.SucceededStub
+	fakeReturns := fake.succeededReturns
+	fake.recordInvocation("Succeeded", []interface{}{arg1})
+	fake.succeededMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) SucceededCallCount() int {
+	fake.succeededMutex.RLock()
+	defer fake.succeededMutex.RUnlock()
+	return len(fake.succeededArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) SucceededCalls(stub func(int) error) {
+	fake.succeededMutex.Lock()
+	defer fake.succeededMutex.Unlock()
+	fake.SucceededStub = stub
+}
+
+func (fake *FakeNotificationDelivery) SucceededArgsForCall(i int) int {
+	fake.succeededMutex.RLock()
+	defer fake.succeededMutex.RUnlock()
+	argsForCall := fake.succeededArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationDelivery) SucceededReturns(result1 error) {
+	fake.succeededMutex.Lock()
+	defer fake.succeededMutex.Unlock()
+	fake.SucceededStub = nil
+	fake.succeededReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) SucceededReturnsOnCall(i int, result1 error) {
+	fake.succeededMutex.Lock()
+	defer fake.succeededMutex.Unlock()
+	fake.SucceededStub = nil
+	if fake.succeededReturnsOnCall == nil {
+		fake.succeededReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.succeededReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) TeamID() int {
+	fake.teamIDMutex.Lock()
+	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
+	fake.teamIDArgsForCall = append(fake.teamIDArgsForCall, struct {
+	}{})
+	stub := fake.TeamIDStub
+	fakeReturns := fake.teamIDReturns
+	fake.recordInvocation("TeamID", []interface{}{})
+	fake.teamIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) TeamIDCallCount() int {
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
+	return len(fake.teamIDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) TeamIDCalls(stub func() int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) TeamIDReturns(result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	fake.teamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) TeamIDReturnsOnCall(i int, result1 int) {
+	fake.teamIDMutex.Lock()
+	defer fake.teamIDMutex.Unlock()
+	fake.TeamIDStub = nil
+	if fake.teamIDReturnsOnCall == nil {
+		fake.teamIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.teamIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) URL() string {
+	fake.uRLMutex.Lock()
+	ret, specificReturn := fake.uRLReturnsOnCall[len(fake.uRLArgsForCall)]
+	fake.uRLArgsForCall = append(fake.uRLArgsForCall, struct {
+	}{})
+	stub := fake.URLStub
+	fakeReturns := fake.uRLReturns
+	fake.recordInvocation("URL", []interface{}{})
+	fake.uRLMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) URLCallCount() int {
+	fake.uRLMutex.RLock()
+	defer fake.uRLMutex.RUnlock()
+	return len(fake.uRLArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) URLCalls(stub func() string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = stub
+}
+
+func (fake *FakeNotificationDelivery) URLReturns(result1 string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = nil
+	fake.uRLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) URLReturnsOnCall(i int, result1 string) {
+	fake.uRLMutex.Lock()
+	defer fake.uRLMutex.Unlock()
+	fake.URLStub = nil
+	if fake.uRLReturnsOnCall == nil {
+		fake.uRLReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.uRLReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAt() time.Time {
+	fake.updatedAtMutex.Lock()
+	ret, specificReturn := fake.updatedAtReturnsOnCall[len(fake.updatedAtArgsForCall)]
+	fake.updatedAtArgsForCall = append(fake.updatedAtArgsForCall, struct {
+	}{})
+	stub := fake.UpdatedAtStub
+	fakeReturns := fake.updatedAtReturns
+	fake.recordInvocation("UpdatedAt", []interface{}{})
+	fake.updatedAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtCallCount() int {
+	fake.updatedAtMutex.RLock()
+	defer fake.updatedAtMutex.RUnlock()
+	return len(fake.updatedAtArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtCalls(stub func() time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = stub
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtReturns(result1 time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = nil
+	fake.updatedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = nil
+	if fake.updatedAtReturnsOnCall == nil {
+		fake.updatedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.updatedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attemptsMutex.RLock()
+	defer fake.attemptsMutex.RUnlock()
+	fake.buildIDMutex.RLock()
+	defer fake.buildIDMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	fake.failMutex.RLock()
+	defer fake.failMutex.RUnlock()
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	fake.lastErrorMutex.RLock()
+	defer fake.lastErrorMutex.RUnlock()
+	fake.notificationMutex.RLock()
+	defer fake.notificationMutex.RUnlock()
+	fake.payloadMutex.RLock()
+	defer fake.payloadMutex.RUnlock()
+	fake.responseStatusMutex.RLock()
+	defer fake.responseStatusMutex.RUnlock()
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	fake.succeededMutex.RLock()
+	defer fake.succeededMutex.RUnlock()
+	fake.teamIDMutex.RLock()
+	defer fake.teamIDMutex.RUnlock()
+	fake.uRLMutex.RLock()
+	defer fake.uRLMutex.RUnlock()
+	fake.updatedAtMutex.RLock()
+	defer fake.updatedAtMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationDelivery) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationDelivery = new(FakeNotificationDelivery)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationDeliveryFactory struct {
+	PendingDeliveriesStub        func(int) ([]db.NotificationDelivery, error)
+	pendingDeliveriesMutex       sync.RWMutex
+	pendingDeliveriesArgsForCall []struct {
+		arg1 int
+	}
+	pendingDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	pendingDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveries(arg1 int) ([]db.NotificationDelivery, error) {
+	fake.pendingDeliveriesMutex.Lock()
+	ret, specificReturn := fake.pendingDeliveriesReturnsOnCall[len(fake.pendingDeliveriesArgsForCall)]
+	fake.pendingDeliveriesArgsForCall = append(fake.pendingDeliveriesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.PendingDeliveriesStub
+	fakeReturns := fake.pendingDeliveriesReturns
+	fake.recordInvocation("PendingDeliveries", []interface{}{arg1})
+	fake.pendingDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesCallCount() int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	return len(fake.pendingDeliveriesArgsForCall)
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesCalls(stub func(int) ([]db.NotificationDelivery, error)) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = stub
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesArgsForCall(i int) int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	argsForCall := fake.pendingDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	fake.pendingDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	if fake.pendingDeliveriesReturnsOnCall == nil {
+		fake.pendingDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.pendingDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationDeliveryFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationDeliveryFactory = new(FakeNotificationDeliveryFactory)
@@ -87,6 +87,33 @@ type FakePipeline struct {
 		result1 atc.Config
 		result2 error
 	}
+	ConfigRevisionStub        func(db.ConfigVersion) (atc.ConfigRevision, bool, error)
+	configRevisionMutex       sync.RWMutex
+	configRevisionArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	configRevisionReturns struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	configRevisionReturnsOnCall map[int]struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	ConfigRevisionsStub        func() ([]atc.ConfigRevision, error)
+	configRevisionsMutex       sync.RWMutex
+	configRevisionsArgsForCall []struct {
+	}
+	configRevisionsReturns struct {
+		result1 []atc.ConfigRevision
+		result2 error
+	}
+	configRevisionsReturnsOnCall map[int]struct {
+		result1 []atc.ConfigRevision
+		result2 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -511,6 +538,21 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	SecretsReportStub        func(lager.Logger, creds.Secrets, creds.VarSourcePool) (atc.PipelineSecretsReport, error)
+	secretsReportMutex       sync.RWMutex
+	secretsReportArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 creds.Secrets
+		arg3 creds.VarSourcePool
+	}
+	secretsReportReturns struct {
+		result1 atc.PipelineSecretsReport
+		result2 error
+	}
+	secretsReportReturnsOnCall map[int]struct {
+		result1 atc.PipelineSecretsReport
+		result2 error
+	}
 	SetParentIDsStub        func(int, int) error
 	setParentIDsMutex       sync.RWMutex
 	setParentIDsArgsForCall []struct {
@@ -559,6 +601,27 @@ type FakePipeline struct {
 	setResourceConfigScopeForResourceTypeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetSourcePathStub        func(string) error
+	setSourcePathMutex       sync.RWMutex
+	setSourcePathArgsForCall []struct {
+		arg1 string
+	}
+	setSourcePathReturns struct {
+		result1 error
+	}
+	setSourcePathReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SourcePathStub        func() string
+	sourcePathMutex       sync.RWMutex
+	sourcePathArgsForCall []struct {
+	}
+	sourcePathReturns struct {
+		result1 string
+	}
+	sourcePathReturnsOnCall map[int]struct {
+		result1 string
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -579,6 +642,16 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TemplatesStub        func() atc.StepTemplates
+	templatesMutex       sync.RWMutex
+	templatesArgsForCall []struct {
+	}
+	templatesReturns struct {
+		result1 atc.StepTemplates
+	}
+	templatesReturnsOnCall map[int]struct {
+		result1 atc.StepTemplates
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -970,6 +1043,129 @@ func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigRevision(arg1 db.ConfigVersion) (atc.ConfigRevision, bool, error) {
+	fake.configRevisionMutex.Lock()
+	ret, specificReturn := fake.configRevisionReturnsOnCall[len(fake.configRevisionArgsForCall)]
+	fake.configRevisionArgsForCall = append(fake.configRevisionArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	stub := fake.ConfigRevisionStub
+	fakeReturns := fake.configRevisionReturns
+	fake.recordInvocation("ConfigRevision", []interface{}{arg1})
+	fake.configRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) ConfigRevisionCallCount() int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	return len(fake.configRevisionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionCalls(stub func(db.ConfigVersion) (atc.ConfigRevision, bool, error)) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = stub
+}
+
+func (fake *FakePipeline) ConfigRevisionArgsForCall(i int) db.ConfigVersion {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	argsForCall := fake.configRevisionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ConfigRevisionReturns(result1 atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	fake.configRevisionReturns = struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisionReturnsOnCall(i int, result1 atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	if fake.configRevisionReturnsOnCall == nil {
+		fake.configRevisionReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configRevisionReturnsOnCall[i] = struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisions() ([]atc.ConfigRevision, error) {
+	fake.configRevisionsMutex.Lock()
+	ret, specificReturn := fake.configRevisionsReturnsOnCall[len(fake.configRevisionsArgsForCall)]
+	fake.configRevisionsArgsForCall = append(fake.configRevisionsArgsForCall, struct {
+	}{})
+	stub := fake.ConfigRevisionsStub
+	fakeReturns := fake.configRevisionsReturns
+	fake.recordInvocation("ConfigRevisions", []interface{}{})
+	fake.configRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigRevisionsCallCount() int {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	return len(fake.configRevisionsArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionsCalls(stub func() ([]atc.ConfigRevision, error)) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = stub
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturns(result1 []atc.ConfigRevision, result2 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	fake.configRevisionsReturns = struct {
+		result1 []atc.ConfigRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturnsOnCall(i int, result1 []atc.ConfigRevision, result2 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	if fake.configRevisionsReturnsOnCall == nil {
+		fake.configRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ConfigRevision
+			result2 error
+		})
+	}
+	fake.configRevisionsReturnsOnCall[i] = struct {
+		result1 []atc.ConfigRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -3056,6 +3252,72 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) SecretsReport(arg1 lager.Logger, arg2 creds.Secrets, arg3 creds.VarSourcePool) (atc.PipelineSecretsReport, error) {
+	fake.secretsReportMutex.Lock()
+	ret, specificReturn := fake.secretsReportReturnsOnCall[len(fake.secretsReportArgsForCall)]
+	fake.secretsReportArgsForCall = append(fake.secretsReportArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 creds.Secrets
+		arg3 creds.VarSourcePool
+	}{arg1, arg2, arg3})
+	stub := fake.SecretsReportStub
+	fakeReturns := fake.secretsReportReturns
+	fake.recordInvocation("SecretsReport", []interface{}{arg1, arg2, arg3})
+	fake.secretsReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SecretsReportCallCount() int {
+	fake.secretsReportMutex.RLock()
+	defer fake.secretsReportMutex.RUnlock()
+	return len(fake.secretsReportArgsForCall)
+}
+
+func (fake *FakePipeline) SecretsReportCalls(stub func(lager.Logger, creds.Secrets, creds.VarSourcePool) (atc.PipelineSecretsReport, error)) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = stub
+}
+
+func (fake *FakePipeline) SecretsReportArgsForCall(i int) (lager.Logger, creds.Secrets, creds.VarSourcePool) {
+	fake.secretsReportMutex.RLock()
+	defer fake.secretsReportMutex.RUnlock()
+	argsForCall := fake.secretsReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) SecretsReportReturns(result1 atc.PipelineSecretsReport, result2 error) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = nil
+	fake.secretsReportReturns = struct {
+		result1 atc.PipelineSecretsReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SecretsReportReturnsOnCall(i int, result1 atc.PipelineSecretsReport, result2 error) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = nil
+	if fake.secretsReportReturnsOnCall == nil {
+		fake.secretsReportReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSecretsReport
+			result2 error
+		})
+	}
+	fake.secretsReportReturnsOnCall[i] = struct {
+		result1 atc.PipelineSecretsReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) SetParentIDs(arg1 int, arg2 int) error {
 	fake.setParentIDsMutex.Lock()
 	ret, specificReturn := fake.setParentIDsReturnsOnCall[len(fake.setParentIDsArgsForCall)]
@@ -3304,6 +3566,120 @@ func (fake *FakePipeline) SetResourceConfigScopeForResourceTypeReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *FakePipeline) SetSourcePath(arg1 string) error {
+	fake.setSourcePathMutex.Lock()
+	ret, specificReturn := fake.setSourcePathReturnsOnCall[len(fake.setSourcePathArgsForCall)]
+	fake.setSourcePathArgsForCall = append(fake.setSourcePathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SetSourcePathStub
+	fakeReturns := fake.setSourcePathReturns
+	fake.recordInvocation("SetSourcePath", []interface{}{arg1})
+	fake.setSourcePathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) SetSourcePathCallCount() int {
+	fake.setSourcePathMutex.RLock()
+	defer fake.setSourcePathMutex.RUnlock()
+	return len(fake.setSourcePathArgsForCall)
+}
+
+func (fake *FakePipeline) SetSourcePathCalls(stub func(string) error) {
+	fake.setSourcePathMutex.Lock()
+	defer fake.setSourcePathMutex.Unlock()
+	fake.SetSourcePathStub = stub
+}
+
+func (fake *FakePipeline) SetSourcePathArgsForCall(i int) string {
+	fake.setSourcePathMutex.RLock()
+	defer fake.setSourcePathMutex.RUnlock()
+	argsForCall := fake.setSourcePathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) SetSourcePathReturns(result1 error) {
+	fake.setSourcePathMutex.Lock()
+	defer fake.setSourcePathMutex.Unlock()
+	fake.SetSourcePathStub = nil
+	fake.setSourcePathReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SetSourcePathReturnsOnCall(i int, result1 error) {
+	fake.setSourcePathMutex.Lock()
+	defer fake.setSourcePathMutex.Unlock()
+	fake.SetSourcePathStub = nil
+	if fake.setSourcePathReturnsOnCall == nil {
+		fake.setSourcePathReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSourcePathReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SourcePath() string {
+	fake.sourcePathMutex.Lock()
+	ret, specificReturn := fake.sourcePathReturnsOnCall[len(fake.sourcePathArgsForCall)]
+	fake.sourcePathArgsForCall = append(fake.sourcePathArgsForCall, struct {
+	}{})
+	stub := fake.SourcePathStub
+	fakeReturns := fake.sourcePathReturns
+	fake.recordInvocation("SourcePath", []interface{}{})
+	fake.sourcePathMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) SourcePathCallCount() int {
+	fake.sourcePathMutex.RLock()
+	defer fake.sourcePathMutex.RUnlock()
+	return len(fake.sourcePathArgsForCall)
+}
+
+func (fake *FakePipeline) SourcePathCalls(stub func() string) {
+	fake.sourcePathMutex.Lock()
+	defer fake.sourcePathMutex.Unlock()
+	fake.SourcePathStub = stub
+}
+
+func (fake *FakePipeline) SourcePathReturns(result1 string) {
+	fake.sourcePathMutex.Lock()
+	defer fake.sourcePathMutex.Unlock()
+	fake.SourcePathStub = nil
+	fake.sourcePathReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipeline) SourcePathReturnsOnCall(i int, result1 string) {
+	fake.sourcePathMutex.Lock()
+	defer fake.sourcePathMutex.Unlock()
+	fake.SourcePathStub = nil
+	if fake.sourcePathReturnsOnCall == nil {
+		fake.sourcePathReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.sourcePathReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -3410,6 +3786,59 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Templates() atc.StepTemplates {
+	fake.templatesMutex.Lock()
+	ret, specificReturn := fake.templatesReturnsOnCall[len(fake.templatesArgsForCall)]
+	fake.templatesArgsForCall = append(fake.templatesArgsForCall, struct {
+	}{})
+	stub := fake.TemplatesStub
+	fakeReturns := fake.templatesReturns
+	fake.recordInvocation("Templates", []interface{}{})
+	fake.templatesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) TemplatesCallCount() int {
+	fake.templatesMutex.RLock()
+	defer fake.templatesMutex.RUnlock()
+	return len(fake.templatesArgsForCall)
+}
+
+func (fake *FakePipeline) TemplatesCalls(stub func() atc.StepTemplates) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = stub
+}
+
+func (fake *FakePipeline) TemplatesReturns(result1 atc.StepTemplates) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = nil
+	fake.templatesReturns = struct {
+		result1 atc.StepTemplates
+	}{result1}
+}
+
+func (fake *FakePipeline) TemplatesReturnsOnCall(i int, result1 atc.StepTemplates) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = nil
+	if fake.templatesReturnsOnCall == nil {
+		fake.templatesReturnsOnCall = make(map[int]struct {
+			result1 atc.StepTemplates
+		})
+	}
+	fake.templatesReturnsOnCall[i] = struct {
+		result1 atc.StepTemplates
+	}{result1}
+}
+
 func (fake *FakePipeline) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -3597,6 +4026,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.checkPausedMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
@@ -3669,6 +4102,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.secretsReportMutex.RLock()
+	defer fake.secretsReportMutex.RUnlock()
 	fake.setParentIDsMutex.RLock()
 	defer fake.setParentIDsMutex.RUnlock()
 	fake.setResourceConfigScopeForPrototypeMutex.RLock()
@@ -3677,10 +4112,16 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.setResourceConfigScopeForResourceMutex.RUnlock()
 	fake.setResourceConfigScopeForResourceTypeMutex.RLock()
 	defer fake.setResourceConfigScopeForResourceTypeMutex.RUnlock()
+	fake.setSourcePathMutex.RLock()
+	defer fake.setSourcePathMutex.RUnlock()
+	fake.sourcePathMutex.RLock()
+	defer fake.sourcePathMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.templatesMutex.RLock()
+	defer fake.templatesMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
 	fake.varSourcesMutex.RLock()
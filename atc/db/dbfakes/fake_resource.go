@@ -398,6 +398,20 @@ type FakeResource struct {
 	resourceConfigScopeIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	SaveVersionsStub        func(db.SpanContext, []atc.Version) (bool, error)
+	saveVersionsMutex       sync.RWMutex
+	saveVersionsArgsForCall []struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+	}
+	saveVersionsReturns struct {
+		result1 bool
+		result2 error
+	}
+	saveVersionsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SetPinCommentStub        func(string) error
 	setPinCommentMutex       sync.RWMutex
 	setPinCommentArgsForCall []struct {
@@ -2462,6 +2476,76 @@ func (fake *FakeResource) ResourceConfigScopeIDReturnsOnCall(i int, result1 int)
 	}{result1}
 }
 
+func (fake *FakeResource) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) (bool, error) {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveVersionsMutex.Lock()
+	ret, specificReturn := fake.saveVersionsReturnsOnCall[len(fake.saveVersionsArgsForCall)]
+	fake.saveVersionsArgsForCall = append(fake.saveVersionsArgsForCall, struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+	}{arg1, arg2Copy})
+	stub := fake.SaveVersionsStub
+	fakeReturns := fake.saveVersionsReturns
+	fake.recordInvocation("SaveVersions", []interface{}{arg1, arg2Copy})
+	fake.saveVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) SaveVersionsCallCount() int {
+	fake.saveVersionsMutex.RLock()
+	defer fake.saveVersionsMutex.RUnlock()
+	return len(fake.saveVersionsArgsForCall)
+}
+
+func (fake *FakeResource) SaveVersionsCalls(stub func(db.SpanContext, []atc.Version) (bool, error)) {
+	fake.saveVersionsMutex.Lock()
+	defer fake.saveVersionsMutex.Unlock()
+	fake.SaveVersionsStub = stub
+}
+
+func (fake *FakeResource) SaveVersionsArgsForCall(i int) (db.SpanContext, []atc.Version) {
+	fake.saveVersionsMutex.RLock()
+	defer fake.saveVersionsMutex.RUnlock()
+	argsForCall := fake.saveVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) SaveVersionsReturns(result1 bool, result2 error) {
+	fake.saveVersionsMutex.Lock()
+	defer fake.saveVersionsMutex.Unlock()
+	fake.SaveVersionsStub = nil
+	fake.saveVersionsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) SaveVersionsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.saveVersionsMutex.Lock()
+	defer fake.saveVersionsMutex.Unlock()
+	fake.SaveVersionsStub = nil
+	if fake.saveVersionsReturnsOnCall == nil {
+		fake.saveVersionsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.saveVersionsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) SetPinComment(arg1 string) error {
 	fake.setPinCommentMutex.Lock()
 	ret, specificReturn := fake.setPinCommentReturnsOnCall[len(fake.setPinCommentArgsForCall)]
@@ -3218,6 +3302,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.resourceConfigIDMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
 	defer fake.resourceConfigScopeIDMutex.RUnlock()
+	fake.saveVersionsMutex.RLock()
+	defer fake.saveVersionsMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
 	fake.setResourceConfigScopeMutex.RLock()
@@ -0,0 +1,187 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretAccessLog struct {
+	RecordStub        func(...creds.SecretAccess) error
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 []creds.SecretAccess
+	}
+	recordReturns struct {
+		result1 error
+	}
+	recordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RemoveExpiredAccessesStub        func(time.Duration) error
+	removeExpiredAccessesMutex       sync.RWMutex
+	removeExpiredAccessesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeExpiredAccessesReturns struct {
+		result1 error
+	}
+	removeExpiredAccessesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretAccessLog) Record(arg1 ...creds.SecretAccess) error {
+	fake.recordMutex.Lock()
+	ret, specificReturn := fake.recordReturnsOnCall[len(fake.recordArgsForCall)]
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 []creds.SecretAccess
+	}{arg1})
+	stub := fake.RecordStub
+	fakeReturns := fake.recordReturns
+	fake.recordInvocation("Record", []interface{}{arg1})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretAccessLog) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeSecretAccessLog) RecordCalls(stub func(...creds.SecretAccess) error) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *FakeSecretAccessLog) RecordArgsForCall(i int) []creds.SecretAccess {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessLog) RecordReturns(result1 error) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = nil
+	fake.recordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessLog) RecordReturnsOnCall(i int, result1 error) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = nil
+	if fake.recordReturnsOnCall == nil {
+		fake.recordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccesses(arg1 time.Duration) error {
+	fake.removeExpiredAccessesMutex.Lock()
+	ret, specificReturn := fake.removeExpiredAccessesReturnsOnCall[len(fake.removeExpiredAccessesArgsForCall)]
+	fake.removeExpiredAccessesArgsForCall = append(fake.removeExpiredAccessesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RemoveExpiredAccessesStub
+	fakeReturns := fake.removeExpiredAccessesReturns
+	fake.recordInvocation("RemoveExpiredAccesses", []interface{}{arg1})
+	fake.removeExpiredAccessesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccessesCallCount() int {
+	fake.removeExpiredAccessesMutex.RLock()
+	defer fake.removeExpiredAccessesMutex.RUnlock()
+	return len(fake.removeExpiredAccessesArgsForCall)
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccessesCalls(stub func(time.Duration) error) {
+	fake.removeExpiredAccessesMutex.Lock()
+	defer fake.removeExpiredAccessesMutex.Unlock()
+	fake.RemoveExpiredAccessesStub = stub
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccessesArgsForCall(i int) time.Duration {
+	fake.removeExpiredAccessesMutex.RLock()
+	defer fake.removeExpiredAccessesMutex.RUnlock()
+	argsForCall := fake.removeExpiredAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccessesReturns(result1 error) {
+	fake.removeExpiredAccessesMutex.Lock()
+	defer fake.removeExpiredAccessesMutex.Unlock()
+	fake.RemoveExpiredAccessesStub = nil
+	fake.removeExpiredAccessesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessLog) RemoveExpiredAccessesReturnsOnCall(i int, result1 error) {
+	fake.removeExpiredAccessesMutex.Lock()
+	defer fake.removeExpiredAccessesMutex.Unlock()
+	fake.RemoveExpiredAccessesStub = nil
+	if fake.removeExpiredAccessesReturnsOnCall == nil {
+		fake.removeExpiredAccessesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeExpiredAccessesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretAccessLog) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	fake.removeExpiredAccessesMutex.RLock()
+	defer fake.removeExpiredAccessesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretAccessLog) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretAccessLog = new(FakeSecretAccessLog)
@@ -98,6 +98,16 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CredentialManagersStub        func() []string
+	credentialManagersMutex       sync.RWMutex
+	credentialManagersArgsForCall []struct {
+	}
+	credentialManagersReturns struct {
+		result1 []string
+	}
+	credentialManagersReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -274,6 +284,29 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationDeliveriesStub        func(int) ([]db.NotificationDelivery, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		arg1 int
+	}
+	notificationDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	NotificationsStub        func() atc.NotificationConfigs
+	notificationsMutex       sync.RWMutex
+	notificationsArgsForCall []struct {
+	}
+	notificationsReturns struct {
+		result1 atc.NotificationConfigs
+	}
+	notificationsReturnsOnCall map[int]struct {
+		result1 atc.NotificationConfigs
+	}
 	OrderPipelinesStub        func([]string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
@@ -312,6 +345,30 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	PipelineSourceStub        func() *atc.PipelineSourceConfig
+	pipelineSourceMutex       sync.RWMutex
+	pipelineSourceArgsForCall []struct {
+	}
+	pipelineSourceReturns struct {
+		result1 *atc.PipelineSourceConfig
+	}
+	pipelineSourceReturnsOnCall map[int]struct {
+		result1 *atc.PipelineSourceConfig
+	}
+	PipelineSourceStatusStub        func() (atc.PipelineSourceStatus, bool, error)
+	pipelineSourceStatusMutex       sync.RWMutex
+	pipelineSourceStatusArgsForCall []struct {
+	}
+	pipelineSourceStatusReturns struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}
+	pipelineSourceStatusReturnsOnCall map[int]struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}
 	PipelinesStub        func() ([]db.Pipeline, error)
 	pipelinesMutex       sync.RWMutex
 	pipelinesArgsForCall []struct {
@@ -394,6 +451,25 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SavePipelineAsStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.ConfigAuthor) (db.Pipeline, bool, error)
+	savePipelineAsMutex       sync.RWMutex
+	savePipelineAsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 bool
+		arg5 db.ConfigAuthor
+	}
+	savePipelineAsReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineAsReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -408,6 +484,60 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	StepTemplatesStub        func() atc.StepTemplates
+	stepTemplatesMutex       sync.RWMutex
+	stepTemplatesArgsForCall []struct {
+	}
+	stepTemplatesReturns struct {
+		result1 atc.StepTemplates
+	}
+	stepTemplatesReturnsOnCall map[int]struct {
+		result1 atc.StepTemplates
+	}
+	UpdateCredentialManagersStub        func([]string) error
+	updateCredentialManagersMutex       sync.RWMutex
+	updateCredentialManagersArgsForCall []struct {
+		arg1 []string
+	}
+	updateCredentialManagersReturns struct {
+		result1 error
+	}
+	updateCredentialManagersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateNotificationsStub        func(atc.NotificationConfigs) error
+	updateNotificationsMutex       sync.RWMutex
+	updateNotificationsArgsForCall []struct {
+		arg1 atc.NotificationConfigs
+	}
+	updateNotificationsReturns struct {
+		result1 error
+	}
+	updateNotificationsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePipelineSourceStub        func(*atc.PipelineSourceConfig) error
+	updatePipelineSourceMutex       sync.RWMutex
+	updatePipelineSourceArgsForCall []struct {
+		arg1 *atc.PipelineSourceConfig
+	}
+	updatePipelineSourceReturns struct {
+		result1 error
+	}
+	updatePipelineSourceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatePipelineSourceStatusStub        func(atc.PipelineSourceStatus) error
+	updatePipelineSourceStatusMutex       sync.RWMutex
+	updatePipelineSourceStatusArgsForCall []struct {
+		arg1 atc.PipelineSourceStatus
+	}
+	updatePipelineSourceStatusReturns struct {
+		result1 error
+	}
+	updatePipelineSourceStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -419,6 +549,38 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateStepTemplatesStub        func(atc.StepTemplates) error
+	updateStepTemplatesMutex       sync.RWMutex
+	updateStepTemplatesArgsForCall []struct {
+		arg1 atc.StepTemplates
+	}
+	updateStepTemplatesReturns struct {
+		result1 error
+	}
+	updateStepTemplatesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateVarSourcesStub        func(atc.VarSourceConfigs) error
+	updateVarSourcesMutex       sync.RWMutex
+	updateVarSourcesArgsForCall []struct {
+		arg1 atc.VarSourceConfigs
+	}
+	updateVarSourcesReturns struct {
+		result1 error
+	}
+	updateVarSourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VarSourcesStub        func() atc.VarSourceConfigs
+	varSourcesMutex       sync.RWMutex
+	varSourcesArgsForCall []struct {
+	}
+	varSourcesReturns struct {
+		result1 atc.VarSourceConfigs
+	}
+	varSourcesReturnsOnCall map[int]struct {
+		result1 atc.VarSourceConfigs
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -851,6 +1013,59 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CredentialManagers() []string {
+	fake.credentialManagersMutex.Lock()
+	ret, specificReturn := fake.credentialManagersReturnsOnCall[len(fake.credentialManagersArgsForCall)]
+	fake.credentialManagersArgsForCall = append(fake.credentialManagersArgsForCall, struct {
+	}{})
+	stub := fake.CredentialManagersStub
+	fakeReturns := fake.credentialManagersReturns
+	fake.recordInvocation("CredentialManagers", []interface{}{})
+	fake.credentialManagersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) CredentialManagersCallCount() int {
+	fake.credentialManagersMutex.RLock()
+	defer fake.credentialManagersMutex.RUnlock()
+	return len(fake.credentialManagersArgsForCall)
+}
+
+func (fake *FakeTeam) CredentialManagersCalls(stub func() []string) {
+	fake.credentialManagersMutex.Lock()
+	defer fake.credentialManagersMutex.Unlock()
+	fake.CredentialManagersStub = stub
+}
+
+func (fake *FakeTeam) CredentialManagersReturns(result1 []string) {
+	fake.credentialManagersMutex.Lock()
+	defer fake.credentialManagersMutex.Unlock()
+	fake.CredentialManagersStub = nil
+	fake.credentialManagersReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeTeam) CredentialManagersReturnsOnCall(i int, result1 []string) {
+	fake.credentialManagersMutex.Lock()
+	defer fake.credentialManagersMutex.Unlock()
+	fake.CredentialManagersStub = nil
+	if fake.credentialManagersReturnsOnCall == nil {
+		fake.credentialManagersReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.credentialManagersReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1672,6 +1887,123 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NotificationDeliveries(arg1 int) ([]db.NotificationDelivery, error) {
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.NotificationDeliveriesStub
+	fakeReturns := fake.notificationDeliveriesReturns
+	fake.recordInvocation("NotificationDeliveries", []interface{}{arg1})
+	fake.notificationDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCalls(stub func(int) ([]db.NotificationDelivery, error)) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = stub
+}
+
+func (fake *FakeTeam) NotificationDeliveriesArgsForCall(i int) int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	argsForCall := fake.notificationDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Notifications() atc.NotificationConfigs {
+	fake.notificationsMutex.Lock()
+	ret, specificReturn := fake.notificationsReturnsOnCall[len(fake.notificationsArgsForCall)]
+	fake.notificationsArgsForCall = append(fake.notificationsArgsForCall, struct {
+	}{})
+	stub := fake.NotificationsStub
+	fakeReturns := fake.notificationsReturns
+	fake.recordInvocation("Notifications", []interface{}{})
+	fake.notificationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) NotificationsCallCount() int {
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
+	return len(fake.notificationsArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationsCalls(stub func() atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = stub
+}
+
+func (fake *FakeTeam) NotificationsReturns(result1 atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	fake.notificationsReturns = struct {
+		result1 atc.NotificationConfigs
+	}{result1}
+}
+
+func (fake *FakeTeam) NotificationsReturnsOnCall(i int, result1 atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	if fake.notificationsReturnsOnCall == nil {
+		fake.notificationsReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationConfigs
+		})
+	}
+	fake.notificationsReturnsOnCall[i] = struct {
+		result1 atc.NotificationConfigs
+	}{result1}
+}
+
 func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -1872,6 +2204,118 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PipelineSource() *atc.PipelineSourceConfig {
+	fake.pipelineSourceMutex.Lock()
+	ret, specificReturn := fake.pipelineSourceReturnsOnCall[len(fake.pipelineSourceArgsForCall)]
+	fake.pipelineSourceArgsForCall = append(fake.pipelineSourceArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSourceStub
+	fakeReturns := fake.pipelineSourceReturns
+	fake.recordInvocation("PipelineSource", []interface{}{})
+	fake.pipelineSourceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) PipelineSourceCallCount() int {
+	fake.pipelineSourceMutex.RLock()
+	defer fake.pipelineSourceMutex.RUnlock()
+	return len(fake.pipelineSourceArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSourceCalls(stub func() *atc.PipelineSourceConfig) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = stub
+}
+
+func (fake *FakeTeam) PipelineSourceReturns(result1 *atc.PipelineSourceConfig) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = nil
+	fake.pipelineSourceReturns = struct {
+		result1 *atc.PipelineSourceConfig
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineSourceReturnsOnCall(i int, result1 *atc.PipelineSourceConfig) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = nil
+	if fake.pipelineSourceReturnsOnCall == nil {
+		fake.pipelineSourceReturnsOnCall = make(map[int]struct {
+			result1 *atc.PipelineSourceConfig
+		})
+	}
+	fake.pipelineSourceReturnsOnCall[i] = struct {
+		result1 *atc.PipelineSourceConfig
+	}{result1}
+}
+
+func (fake *FakeTeam) PipelineSourceStatus() (atc.PipelineSourceStatus, bool, error) {
+	fake.pipelineSourceStatusMutex.Lock()
+	ret, specificReturn := fake.pipelineSourceStatusReturnsOnCall[len(fake.pipelineSourceStatusArgsForCall)]
+	fake.pipelineSourceStatusArgsForCall = append(fake.pipelineSourceStatusArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSourceStatusStub
+	fakeReturns := fake.pipelineSourceStatusReturns
+	fake.recordInvocation("PipelineSourceStatus", []interface{}{})
+	fake.pipelineSourceStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineSourceStatusCallCount() int {
+	fake.pipelineSourceStatusMutex.RLock()
+	defer fake.pipelineSourceStatusMutex.RUnlock()
+	return len(fake.pipelineSourceStatusArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSourceStatusCalls(stub func() (atc.PipelineSourceStatus, bool, error)) {
+	fake.pipelineSourceStatusMutex.Lock()
+	defer fake.pipelineSourceStatusMutex.Unlock()
+	fake.PipelineSourceStatusStub = stub
+}
+
+func (fake *FakeTeam) PipelineSourceStatusReturns(result1 atc.PipelineSourceStatus, result2 bool, result3 error) {
+	fake.pipelineSourceStatusMutex.Lock()
+	defer fake.pipelineSourceStatusMutex.Unlock()
+	fake.PipelineSourceStatusStub = nil
+	fake.pipelineSourceStatusReturns = struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineSourceStatusReturnsOnCall(i int, result1 atc.PipelineSourceStatus, result2 bool, result3 error) {
+	fake.pipelineSourceStatusMutex.Lock()
+	defer fake.pipelineSourceStatusMutex.Unlock()
+	fake.PipelineSourceStatusStub = nil
+	if fake.pipelineSourceStatusReturnsOnCall == nil {
+		fake.pipelineSourceStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSourceStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineSourceStatusReturnsOnCall[i] = struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
 	fake.pipelinesMutex.Lock()
 	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
@@ -2247,6 +2691,77 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SavePipelineAs(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool, arg5 db.ConfigAuthor) (db.Pipeline, bool, error) {
+	fake.savePipelineAsMutex.Lock()
+	ret, specificReturn := fake.savePipelineAsReturnsOnCall[len(fake.savePipelineAsArgsForCall)]
+	fake.savePipelineAsArgsForCall = append(fake.savePipelineAsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 bool
+		arg5 db.ConfigAuthor
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SavePipelineAsStub
+	fakeReturns := fake.savePipelineAsReturns
+	fake.recordInvocation("SavePipelineAs", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.savePipelineAsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineAsCallCount() int {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	return len(fake.savePipelineAsArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineAsCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.ConfigAuthor) (db.Pipeline, bool, error)) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineAsArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.ConfigAuthor) {
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
+	argsForCall := fake.savePipelineAsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeTeam) SavePipelineAsReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = nil
+	fake.savePipelineAsReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineAsReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineAsMutex.Lock()
+	defer fake.savePipelineAsMutex.Unlock()
+	fake.SavePipelineAsStub = nil
+	if fake.savePipelineAsReturnsOnCall == nil {
+		fake.savePipelineAsReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineAsReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -2312,6 +2827,308 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) StepTemplates() atc.StepTemplates {
+	fake.stepTemplatesMutex.Lock()
+	ret, specificReturn := fake.stepTemplatesReturnsOnCall[len(fake.stepTemplatesArgsForCall)]
+	fake.stepTemplatesArgsForCall = append(fake.stepTemplatesArgsForCall, struct {
+	}{})
+	stub := fake.StepTemplatesStub
+	fakeReturns := fake.stepTemplatesReturns
+	fake.recordInvocation("StepTemplates", []interface{}{})
+	fake.stepTemplatesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) StepTemplatesCallCount() int {
+	fake.stepTemplatesMutex.RLock()
+	defer fake.stepTemplatesMutex.RUnlock()
+	return len(fake.stepTemplatesArgsForCall)
+}
+
+func (fake *FakeTeam) StepTemplatesCalls(stub func() atc.StepTemplates) {
+	fake.stepTemplatesMutex.Lock()
+	defer fake.stepTemplatesMutex.Unlock()
+	fake.StepTemplatesStub = stub
+}
+
+func (fake *FakeTeam) StepTemplatesReturns(result1 atc.StepTemplates) {
+	fake.stepTemplatesMutex.Lock()
+	defer fake.stepTemplatesMutex.Unlock()
+	fake.StepTemplatesStub = nil
+	fake.stepTemplatesReturns = struct {
+		result1 atc.StepTemplates
+	}{result1}
+}
+
+func (fake *FakeTeam) StepTemplatesReturnsOnCall(i int, result1 atc.StepTemplates) {
+	fake.stepTemplatesMutex.Lock()
+	defer fake.stepTemplatesMutex.Unlock()
+	fake.StepTemplatesStub = nil
+	if fake.stepTemplatesReturnsOnCall == nil {
+		fake.stepTemplatesReturnsOnCall = make(map[int]struct {
+			result1 atc.StepTemplates
+		})
+	}
+	fake.stepTemplatesReturnsOnCall[i] = struct {
+		result1 atc.StepTemplates
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCredentialManagers(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.updateCredentialManagersMutex.Lock()
+	ret, specificReturn := fake.updateCredentialManagersReturnsOnCall[len(fake.updateCredentialManagersArgsForCall)]
+	fake.updateCredentialManagersArgsForCall = append(fake.updateCredentialManagersArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.UpdateCredentialManagersStub
+	fakeReturns := fake.updateCredentialManagersReturns
+	fake.recordInvocation("UpdateCredentialManagers", []interface{}{arg1Copy})
+	fake.updateCredentialManagersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCredentialManagersCallCount() int {
+	fake.updateCredentialManagersMutex.RLock()
+	defer fake.updateCredentialManagersMutex.RUnlock()
+	return len(fake.updateCredentialManagersArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCredentialManagersCalls(stub func([]string) error) {
+	fake.updateCredentialManagersMutex.Lock()
+	defer fake.updateCredentialManagersMutex.Unlock()
+	fake.UpdateCredentialManagersStub = stub
+}
+
+func (fake *FakeTeam) UpdateCredentialManagersArgsForCall(i int) []string {
+	fake.updateCredentialManagersMutex.RLock()
+	defer fake.updateCredentialManagersMutex.RUnlock()
+	argsForCall := fake.updateCredentialManagersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateCredentialManagersReturns(result1 error) {
+	fake.updateCredentialManagersMutex.Lock()
+	defer fake.updateCredentialManagersMutex.Unlock()
+	fake.UpdateCredentialManagersStub = nil
+	fake.updateCredentialManagersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateCredentialManagersReturnsOnCall(i int, result1 error) {
+	fake.updateCredentialManagersMutex.Lock()
+	defer fake.updateCredentialManagersMutex.Unlock()
+	fake.UpdateCredentialManagersStub = nil
+	if fake.updateCredentialManagersReturnsOnCall == nil {
+		fake.updateCredentialManagersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateCredentialManagersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateNotifications(arg1 atc.NotificationConfigs) error {
+	fake.updateNotificationsMutex.Lock()
+	ret, specificReturn := fake.updateNotificationsReturnsOnCall[len(fake.updateNotificationsArgsForCall)]
+	fake.updateNotificationsArgsForCall = append(fake.updateNotificationsArgsForCall, struct {
+		arg1 atc.NotificationConfigs
+	}{arg1})
+	stub := fake.UpdateNotificationsStub
+	fakeReturns := fake.updateNotificationsReturns
+	fake.recordInvocation("UpdateNotifications", []interface{}{arg1})
+	fake.updateNotificationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateNotificationsCallCount() int {
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	return len(fake.updateNotificationsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateNotificationsCalls(stub func(atc.NotificationConfigs) error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = stub
+}
+
+func (fake *FakeTeam) UpdateNotificationsArgsForCall(i int) atc.NotificationConfigs {
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	argsForCall := fake.updateNotificationsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateNotificationsReturns(result1 error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = nil
+	fake.updateNotificationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateNotificationsReturnsOnCall(i int, result1 error) {
+	fake.updateNotificationsMutex.Lock()
+	defer fake.updateNotificationsMutex.Unlock()
+	fake.UpdateNotificationsStub = nil
+	if fake.updateNotificationsReturnsOnCall == nil {
+		fake.updateNotificationsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateNotificationsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineSource(arg1 *atc.PipelineSourceConfig) error {
+	fake.updatePipelineSourceMutex.Lock()
+	ret, specificReturn := fake.updatePipelineSourceReturnsOnCall[len(fake.updatePipelineSourceArgsForCall)]
+	fake.updatePipelineSourceArgsForCall = append(fake.updatePipelineSourceArgsForCall, struct {
+		arg1 *atc.PipelineSourceConfig
+	}{arg1})
+	stub := fake.UpdatePipelineSourceStub
+	fakeReturns := fake.updatePipelineSourceReturns
+	fake.recordInvocation("UpdatePipelineSource", []interface{}{arg1})
+	fake.updatePipelineSourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceCallCount() int {
+	fake.updatePipelineSourceMutex.RLock()
+	defer fake.updatePipelineSourceMutex.RUnlock()
+	return len(fake.updatePipelineSourceArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceCalls(stub func(*atc.PipelineSourceConfig) error) {
+	fake.updatePipelineSourceMutex.Lock()
+	defer fake.updatePipelineSourceMutex.Unlock()
+	fake.UpdatePipelineSourceStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceArgsForCall(i int) *atc.PipelineSourceConfig {
+	fake.updatePipelineSourceMutex.RLock()
+	defer fake.updatePipelineSourceMutex.RUnlock()
+	argsForCall := fake.updatePipelineSourceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceReturns(result1 error) {
+	fake.updatePipelineSourceMutex.Lock()
+	defer fake.updatePipelineSourceMutex.Unlock()
+	fake.UpdatePipelineSourceStub = nil
+	fake.updatePipelineSourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceReturnsOnCall(i int, result1 error) {
+	fake.updatePipelineSourceMutex.Lock()
+	defer fake.updatePipelineSourceMutex.Unlock()
+	fake.UpdatePipelineSourceStub = nil
+	if fake.updatePipelineSourceReturnsOnCall == nil {
+		fake.updatePipelineSourceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelineSourceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatus(arg1 atc.PipelineSourceStatus) error {
+	fake.updatePipelineSourceStatusMutex.Lock()
+	ret, specificReturn := fake.updatePipelineSourceStatusReturnsOnCall[len(fake.updatePipelineSourceStatusArgsForCall)]
+	fake.updatePipelineSourceStatusArgsForCall = append(fake.updatePipelineSourceStatusArgsForCall, struct {
+		arg1 atc.PipelineSourceStatus
+	}{arg1})
+	stub := fake.UpdatePipelineSourceStatusStub
+	fakeReturns := fake.updatePipelineSourceStatusReturns
+	fake.recordInvocation("UpdatePipelineSourceStatus", []interface{}{arg1})
+	fake.updatePipelineSourceStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatusCallCount() int {
+	fake.updatePipelineSourceStatusMutex.RLock()
+	defer fake.updatePipelineSourceStatusMutex.RUnlock()
+	return len(fake.updatePipelineSourceStatusArgsForCall)
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatusCalls(stub func(atc.PipelineSourceStatus) error) {
+	fake.updatePipelineSourceStatusMutex.Lock()
+	defer fake.updatePipelineSourceStatusMutex.Unlock()
+	fake.UpdatePipelineSourceStatusStub = stub
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatusArgsForCall(i int) atc.PipelineSourceStatus {
+	fake.updatePipelineSourceStatusMutex.RLock()
+	defer fake.updatePipelineSourceStatusMutex.RUnlock()
+	argsForCall := fake.updatePipelineSourceStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatusReturns(result1 error) {
+	fake.updatePipelineSourceStatusMutex.Lock()
+	defer fake.updatePipelineSourceStatusMutex.Unlock()
+	fake.UpdatePipelineSourceStatusStub = nil
+	fake.updatePipelineSourceStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdatePipelineSourceStatusReturnsOnCall(i int, result1 error) {
+	fake.updatePipelineSourceStatusMutex.Lock()
+	defer fake.updatePipelineSourceStatusMutex.Unlock()
+	fake.UpdatePipelineSourceStatusStub = nil
+	if fake.updatePipelineSourceStatusReturnsOnCall == nil {
+		fake.updatePipelineSourceStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updatePipelineSourceStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2373,6 +3190,181 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateStepTemplates(arg1 atc.StepTemplates) error {
+	fake.updateStepTemplatesMutex.Lock()
+	ret, specificReturn := fake.updateStepTemplatesReturnsOnCall[len(fake.updateStepTemplatesArgsForCall)]
+	fake.updateStepTemplatesArgsForCall = append(fake.updateStepTemplatesArgsForCall, struct {
+		arg1 atc.StepTemplates
+	}{arg1})
+	stub := fake.UpdateStepTemplatesStub
+	fakeReturns := fake.updateStepTemplatesReturns
+	fake.recordInvocation("UpdateStepTemplates", []interface{}{arg1})
+	fake.updateStepTemplatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateStepTemplatesCallCount() int {
+	fake.updateStepTemplatesMutex.RLock()
+	defer fake.updateStepTemplatesMutex.RUnlock()
+	return len(fake.updateStepTemplatesArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateStepTemplatesCalls(stub func(atc.StepTemplates) error) {
+	fake.updateStepTemplatesMutex.Lock()
+	defer fake.updateStepTemplatesMutex.Unlock()
+	fake.UpdateStepTemplatesStub = stub
+}
+
+func (fake *FakeTeam) UpdateStepTemplatesArgsForCall(i int) atc.StepTemplates {
+	fake.updateStepTemplatesMutex.RLock()
+	defer fake.updateStepTemplatesMutex.RUnlock()
+	argsForCall := fake.updateStepTemplatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateStepTemplatesReturns(result1 error) {
+	fake.updateStepTemplatesMutex.Lock()
+	defer fake.updateStepTemplatesMutex.Unlock()
+	fake.UpdateStepTemplatesStub = nil
+	fake.updateStepTemplatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateStepTemplatesReturnsOnCall(i int, result1 error) {
+	fake.updateStepTemplatesMutex.Lock()
+	defer fake.updateStepTemplatesMutex.Unlock()
+	fake.UpdateStepTemplatesStub = nil
+	if fake.updateStepTemplatesReturnsOnCall == nil {
+		fake.updateStepTemplatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStepTemplatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateVarSources(arg1 atc.VarSourceConfigs) error {
+	fake.updateVarSourcesMutex.Lock()
+	ret, specificReturn := fake.updateVarSourcesReturnsOnCall[len(fake.updateVarSourcesArgsForCall)]
+	fake.updateVarSourcesArgsForCall = append(fake.updateVarSourcesArgsForCall, struct {
+		arg1 atc.VarSourceConfigs
+	}{arg1})
+	stub := fake.UpdateVarSourcesStub
+	fakeReturns := fake.updateVarSourcesReturns
+	fake.recordInvocation("UpdateVarSources", []interface{}{arg1})
+	fake.updateVarSourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateVarSourcesCallCount() int {
+	fake.updateVarSourcesMutex.RLock()
+	defer fake.updateVarSourcesMutex.RUnlock()
+	return len(fake.updateVarSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateVarSourcesCalls(stub func(atc.VarSourceConfigs) error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = stub
+}
+
+func (fake *FakeTeam) UpdateVarSourcesArgsForCall(i int) atc.VarSourceConfigs {
+	fake.updateVarSourcesMutex.RLock()
+	defer fake.updateVarSourcesMutex.RUnlock()
+	argsForCall := fake.updateVarSourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateVarSourcesReturns(result1 error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = nil
+	fake.updateVarSourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateVarSourcesReturnsOnCall(i int, result1 error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = nil
+	if fake.updateVarSourcesReturnsOnCall == nil {
+		fake.updateVarSourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVarSourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) VarSources() atc.VarSourceConfigs {
+	fake.varSourcesMutex.Lock()
+	ret, specificReturn := fake.varSourcesReturnsOnCall[len(fake.varSourcesArgsForCall)]
+	fake.varSourcesArgsForCall = append(fake.varSourcesArgsForCall, struct {
+	}{})
+	stub := fake.VarSourcesStub
+	fakeReturns := fake.varSourcesReturns
+	fake.recordInvocation("VarSources", []interface{}{})
+	fake.varSourcesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) VarSourcesCallCount() int {
+	fake.varSourcesMutex.RLock()
+	defer fake.varSourcesMutex.RUnlock()
+	return len(fake.varSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) VarSourcesCalls(stub func() atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = stub
+}
+
+func (fake *FakeTeam) VarSourcesReturns(result1 atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = nil
+	fake.varSourcesReturns = struct {
+		result1 atc.VarSourceConfigs
+	}{result1}
+}
+
+func (fake *FakeTeam) VarSourcesReturnsOnCall(i int, result1 atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = nil
+	if fake.varSourcesReturnsOnCall == nil {
+		fake.varSourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.VarSourceConfigs
+		})
+	}
+	fake.varSourcesReturnsOnCall[i] = struct {
+		result1 atc.VarSourceConfigs
+	}{result1}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2446,6 +3438,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
+	fake.credentialManagersMutex.RLock()
+	defer fake.credentialManagersMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
@@ -2472,12 +3466,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isContainerWithinTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.orderPipelinesWithinGroupMutex.RLock()
 	defer fake.orderPipelinesWithinGroupMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
+	fake.pipelineSourceMutex.RLock()
+	defer fake.pipelineSourceMutex.RUnlock()
+	fake.pipelineSourceStatusMutex.RLock()
+	defer fake.pipelineSourceStatusMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
@@ -2490,10 +3492,28 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.savePipelineAsMutex.RLock()
+	defer fake.savePipelineAsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.stepTemplatesMutex.RLock()
+	defer fake.stepTemplatesMutex.RUnlock()
+	fake.updateCredentialManagersMutex.RLock()
+	defer fake.updateCredentialManagersMutex.RUnlock()
+	fake.updateNotificationsMutex.RLock()
+	defer fake.updateNotificationsMutex.RUnlock()
+	fake.updatePipelineSourceMutex.RLock()
+	defer fake.updatePipelineSourceMutex.RUnlock()
+	fake.updatePipelineSourceStatusMutex.RLock()
+	defer fake.updatePipelineSourceStatusMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateStepTemplatesMutex.RLock()
+	defer fake.updateStepTemplatesMutex.RUnlock()
+	fake.updateVarSourcesMutex.RLock()
+	defer fake.updateVarSourcesMutex.RUnlock()
+	fake.varSourcesMutex.RLock()
+	defer fake.varSourcesMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -49,6 +49,16 @@ type FakeWorkerArtifact struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PublishedStub        func() bool
+	publishedMutex       sync.RWMutex
+	publishedArgsForCall []struct {
+	}
+	publishedReturns struct {
+		result1 bool
+	}
+	publishedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	VolumeStub        func(int) (db.CreatedVolume, bool, error)
 	volumeMutex       sync.RWMutex
 	volumeArgsForCall []struct {
@@ -280,6 +290,59 @@ func (fake *FakeWorkerArtifact) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorkerArtifact) Published() bool {
+	fake.publishedMutex.Lock()
+	ret, specificReturn := fake.publishedReturnsOnCall[len(fake.publishedArgsForCall)]
+	fake.publishedArgsForCall = append(fake.publishedArgsForCall, struct {
+	}{})
+	stub := fake.PublishedStub
+	fakeReturns := fake.publishedReturns
+	fake.recordInvocation("Published", []interface{}{})
+	fake.publishedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerArtifact) PublishedCallCount() int {
+	fake.publishedMutex.RLock()
+	defer fake.publishedMutex.RUnlock()
+	return len(fake.publishedArgsForCall)
+}
+
+func (fake *FakeWorkerArtifact) PublishedCalls(stub func() bool) {
+	fake.publishedMutex.Lock()
+	defer fake.publishedMutex.Unlock()
+	fake.PublishedStub = stub
+}
+
+func (fake *FakeWorkerArtifact) PublishedReturns(result1 bool) {
+	fake.publishedMutex.Lock()
+	defer fake.publishedMutex.Unlock()
+	fake.PublishedStub = nil
+	fake.publishedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorkerArtifact) PublishedReturnsOnCall(i int, result1 bool) {
+	fake.publishedMutex.Lock()
+	defer fake.publishedMutex.Unlock()
+	fake.PublishedStub = nil
+	if fake.publishedReturnsOnCall == nil {
+		fake.publishedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.publishedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorkerArtifact) Volume(arg1 int) (db.CreatedVolume, bool, error) {
 	fake.volumeMutex.Lock()
 	ret, specificReturn := fake.volumeReturnsOnCall[len(fake.volumeArgsForCall)]
@@ -358,6 +421,8 @@ func (fake *FakeWorkerArtifact) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.publishedMutex.RLock()
+	defer fake.publishedMutex.RUnlock()
 	fake.volumeMutex.RLock()
 	defer fake.volumeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -34,6 +34,36 @@ type InputResult struct {
 	Input          *AlgorithmInput
 	PassedBuildIDs []int
 	ResolveError   ResolutionFailure
+
+	// ResolveReason says in more detail why the input, or another input it
+	// was resolved together with, could not be resolved.
+	ResolveReason *ResolutionReason
+}
+
+// ResolutionReason records which input could not be resolved and what it
+// was up against, in terms of the IDs the algorithm works with.
+type ResolutionReason struct {
+	Failure ResolutionFailure
+
+	Input      string
+	ResourceID int
+
+	// PinnedVersion is set if the input's pinned version could not be found.
+	PinnedVersion atc.Version
+
+	// PassedJobID is the job in the input's passed constraints which had no
+	// suitable build. Version is the version already chosen for the input, if
+	// any, and Constraints are the versions chosen for the other inputs which
+	// a build of the passed job had to have used as well.
+	PassedJobID int
+	Version     ResourceVersion
+	Constraints []ResolutionConstraint
+}
+
+type ResolutionConstraint struct {
+	Input      string
+	ResourceID int
+	Version    ResourceVersion
 }
 
 type ResourceVersion string
@@ -63,6 +63,8 @@ type Job interface {
 	Tags() []string
 	Public() bool
 	ScheduleRequestedTime() time.Time
+	ScheduleNextFire() time.Time
+	SchedulingDiagnostics() []atc.SchedulingDiagnostic
 	MaxInFlight() int
 	DisableManualTrigger() bool
 
@@ -85,9 +87,11 @@ type Job interface {
 
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
+	UpdateScheduleNextFire(time.Time) error
 
 	ChronoBuilds(page Page) ([]BuildForAPI, Pagination, error)
 	Builds(page Page) ([]BuildForAPI, Pagination, error)
+	TestHistory(builds int) ([]atc.TestHistory, error)
 	BuildsWithTime(page Page) ([]BuildForAPI, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
@@ -99,6 +103,7 @@ type Job interface {
 	GetNextBuildInputs() ([]BuildInput, error)
 	GetFullNextBuildInputs() ([]BuildInput, bool, error)
 	SaveNextInputMapping(inputMapping InputMapping, inputsDetermined bool) error
+	BuildInputsForMapping(inputMapping InputMapping) ([]BuildInput, error)
 
 	ClearTaskCache(string, string) (int64, error)
 
@@ -127,7 +132,9 @@ var jobsQuery = psql.Select(
 	"j.max_in_flight",
 	"j.disable_manual_trigger",
 	"j.paused_by",
-	"j.paused_at").
+	"j.paused_at",
+	"j.schedule_next_fire",
+	"j.scheduling_diagnostics").
 	From("jobs j").
 	LeftJoin("pipelines p ON j.pipeline_id = p.id").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -157,6 +164,8 @@ type job struct {
 	tags                  []string
 	hasNewInputs          bool
 	scheduleRequestedTime time.Time
+	scheduleNextFire      time.Time
+	schedulingDiagnostics []atc.SchedulingDiagnostic
 	maxInFlight           int
 	disableManualTrigger  bool
 
@@ -220,9 +229,14 @@ func (j *job) TeamName() string                 { return j.teamName }
 func (j *job) Tags() []string                   { return j.tags }
 func (j *job) HasNewInputs() bool               { return j.hasNewInputs }
 func (j *job) ScheduleRequestedTime() time.Time { return j.scheduleRequestedTime }
+func (j *job) ScheduleNextFire() time.Time      { return j.scheduleNextFire }
 func (j *job) MaxInFlight() int                 { return j.maxInFlight }
 func (j *job) DisableManualTrigger() bool       { return j.disableManualTrigger }
 
+func (j *job) SchedulingDiagnostics() []atc.SchedulingDiagnostic {
+	return j.schedulingDiagnostics
+}
+
 func (j *job) LatestCompletedBuildId() (int, error) {
 	var id int
 	err := psql.Select("latest_completed_build_id").
@@ -1108,6 +1122,20 @@ func (j *job) UpdateLastScheduled(requestedTime time.Time) error {
 	return err
 }
 
+// UpdateScheduleNextFire records the next time the job's schedule fires, once
+// the current fire has been dealt with.
+func (j *job) UpdateScheduleNextFire(nextFire time.Time) error {
+	_, err := psql.Update("jobs").
+		Set("schedule_next_fire", sql.NullTime{Time: nextFire, Valid: !nextFire.IsZero()}).
+		Where(sq.Eq{
+			"id": j.id,
+		}).
+		RunWith(j.conn).
+		Exec()
+
+	return err
+}
+
 func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Build, error) {
 	rows, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
@@ -1194,8 +1222,24 @@ func (j *job) SaveNextInputMapping(inputMapping InputMapping, inputsDetermined b
 
 	defer Rollback(tx)
 
+	diagnostics, err := schedulingDiagnostics(tx, inputMapping)
+	if err != nil {
+		return err
+	}
+
+	var diagnosticsPayload sql.NullString
+	if len(diagnostics) != 0 {
+		payload, err := json.Marshal(diagnostics)
+		if err != nil {
+			return err
+		}
+
+		diagnosticsPayload = sql.NullString{String: string(payload), Valid: true}
+	}
+
 	_, err = psql.Update("jobs").
 		Set("inputs_determined", inputsDetermined).
+		Set("scheduling_diagnostics", diagnosticsPayload).
 		Where(sq.Eq{"id": j.id}).
 		RunWith(tx).
 		Exec()
@@ -1269,6 +1313,48 @@ func (j *job) SaveNextInputMapping(inputMapping InputMapping, inputsDetermined b
 	return tx.Commit()
 }
 
+// BuildInputsForMapping looks up the version of each input in the mapping,
+// without saving it as the job's next build inputs.
+func (j *job) BuildInputsForMapping(inputMapping InputMapping) ([]BuildInput, error) {
+	names := make([]string, 0, len(inputMapping))
+	for name := range inputMapping {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	buildInputs := []BuildInput{}
+	for _, name := range names {
+		inputResult := inputMapping[name]
+
+		if inputResult.ResolveError != "" {
+			buildInputs = append(buildInputs, BuildInput{
+				Name:         name,
+				ResolveError: string(inputResult.ResolveError),
+			})
+			continue
+		}
+
+		if inputResult.Input == nil {
+			return nil, InputVersionEmptyError{name}
+		}
+
+		version, err := resourceVersionByMD5(j.conn, inputResult.Input.ResourceID, inputResult.Input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		buildInputs = append(buildInputs, BuildInput{
+			Name:            name,
+			ResourceID:      inputResult.Input.ResourceID,
+			Version:         version,
+			FirstOccurrence: inputResult.Input.FirstOccurrence,
+		})
+	}
+
+	return buildInputs, nil
+}
+
 func (j *job) nextBuild(tx Tx) (Build, error) {
 	var next Build
 
@@ -1433,9 +1519,11 @@ func scanJob(j *job, row scannable) error {
 		pipelineInstanceVars sql.NullString
 		pausedBy             sql.NullString
 		pausedAt             sql.NullTime
+		scheduleNextFire     sql.NullTime
+		diagnostics          sql.NullString
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &pausedBy, &pausedAt)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &pausedBy, &pausedAt, &scheduleNextFire, &diagnostics)
 	if err != nil {
 		return err
 	}
@@ -1463,6 +1551,15 @@ func scanJob(j *job, row scannable) error {
 		j.pausedAt = pausedAt.Time
 	}
 
+	if scheduleNextFire.Valid {
+		j.scheduleNextFire = scheduleNextFire.Time
+	}
+
+	j.schedulingDiagnostics, err = scanSchedulingDiagnostics(diagnostics)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -84,12 +84,17 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	defer tx.Rollback()
 
 	rows, err := jobsQuery.
-		Where(sq.Expr("j.schedule_requested > j.last_scheduled")).
+		Where(sq.Or{
+			sq.Expr("j.schedule_requested > j.last_scheduled"),
+			sq.Expr("j.schedule_next_fire <= now()"),
+		}).
 		Where(sq.Eq{
 			"j.active": true,
 			"j.paused": false,
 			"p.paused": false,
 		}).
+		// matrix jobs are never scheduled themselves, only their cells are
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM jobs c WHERE c.matrix_parent_id = j.id AND c.active)")).
 		RunWith(tx).
 		Query()
 	if err != nil {
@@ -322,7 +327,9 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 		"n.id", "n.name", "n.status", "n.start_time", "n.end_time",
 		"t.id", "t.name", "t.status", "t.start_time", "t.end_time",
 		"j.paused_by",
-		"j.paused_at").
+		"j.paused_at",
+		"j.schedule_next_fire",
+		"j.scheduling_diagnostics").
 		From("jobs j").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Join("teams tm ON p.team_id = tm.id").
@@ -354,6 +361,8 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			f, n, t              nullableBuild
 			jobPausedBy          sql.NullString
 			jobPausedAt          sql.NullTime
+			jobScheduleNextFire  sql.NullTime
+			jobDiagnostics       sql.NullString
 			pipelineInstanceVars sql.NullString
 		)
 
@@ -362,7 +371,7 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			&f.id, &f.name, &f.status, &f.startTime, &f.endTime,
 			&n.id, &n.name, &n.status, &n.startTime, &n.endTime,
 			&t.id, &t.name, &t.status, &t.startTime, &t.endTime,
-			&jobPausedBy, &jobPausedAt)
+			&jobPausedBy, &jobPausedAt, &jobScheduleNextFire, &jobDiagnostics)
 		if err != nil {
 			return nil, err
 		}
@@ -375,6 +384,15 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			j.PausedAt = jobPausedAt.Time.Unix()
 		}
 
+		if jobScheduleNextFire.Valid {
+			j.NextFireTime = jobScheduleNextFire.Time.Unix()
+		}
+
+		j.SchedulingDiagnostics, err = scanSchedulingDiagnostics(jobDiagnostics)
+		if err != nil {
+			return nil, err
+		}
+
 		if pipelineInstanceVars.Valid {
 			err = json.Unmarshal([]byte(pipelineInstanceVars.String), &j.PipelineInstanceVars)
 			if err != nil {
@@ -1533,6 +1533,194 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("BuildInputsForMapping", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-base-resource-type",
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"version": "v1"},
+				),
+			)
+		})
+
+		It("looks up the version of each input without saving them", func() {
+			buildInputs, err := scenario.Job("some-job").BuildInputsForMapping(db.InputMapping{
+				"some-input": db.InputResult{
+					Input: &db.AlgorithmInput{
+						AlgorithmVersion: db.AlgorithmVersion{
+							Version:    db.ResourceVersion(convertToMD5(atc.Version{"version": "v1"})),
+							ResourceID: scenario.Resource("some-resource").ID(),
+						},
+						FirstOccurrence: true,
+					},
+				},
+				"other-input": db.InputResult{
+					ResolveError: "disaster",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildInputs).To(Equal([]db.BuildInput{
+				{
+					Name:         "other-input",
+					ResolveError: "disaster",
+				},
+				{
+					Name:            "some-input",
+					ResourceID:      scenario.Resource("some-resource").ID(),
+					Version:         atc.Version{"version": "v1"},
+					FirstOccurrence: true,
+				},
+			}))
+
+			nextBuildInputs, err := scenario.Job("some-job").GetNextBuildInputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextBuildInputs).To(BeEmpty())
+		})
+	})
+
+	Describe("SchedulingDiagnostics", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:     "some-input",
+										Resource: "some-resource",
+										Passed:   []string{"upstream-job"},
+									},
+								},
+								{
+									Config: &atc.GetStep{
+										Name:     "other-input",
+										Resource: "other-resource",
+										Passed:   []string{"upstream-job"},
+									},
+								},
+							},
+						},
+						{
+							Name: "upstream-job",
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-base-resource-type",
+						},
+						{
+							Name: "other-resource",
+							Type: "some-base-resource-type",
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"version": "v1"},
+				),
+				builder.WithResourceVersions(
+					"other-resource",
+					atc.Version{"version": "v2"},
+				),
+			)
+		})
+
+		It("saves why the inputs could not be resolved until they are", func() {
+			reason := &db.ResolutionReason{
+				Failure:     db.NoSatisfiableBuilds,
+				Input:       "some-input",
+				ResourceID:  scenario.Resource("some-resource").ID(),
+				PassedJobID: scenario.Job("upstream-job").ID(),
+				Version:     db.ResourceVersion(convertToMD5(atc.Version{"version": "v1"})),
+				Constraints: []db.ResolutionConstraint{
+					{
+						Input:      "other-input",
+						ResourceID: scenario.Resource("other-resource").ID(),
+						Version:    db.ResourceVersion(convertToMD5(atc.Version{"version": "v2"})),
+					},
+				},
+			}
+
+			err := scenario.Job("some-job").SaveNextInputMapping(db.InputMapping{
+				"some-input": db.InputResult{
+					ResolveError:  db.NoSatisfiableBuilds,
+					ResolveReason: reason,
+				},
+				"other-input": db.InputResult{
+					ResolveError:  db.NoSatisfiableBuilds,
+					ResolveReason: reason,
+				},
+			}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(scenario.Job("some-job").SchedulingDiagnostics()).To(Equal([]atc.SchedulingDiagnostic{
+				{
+					Input:     "some-input",
+					Resource:  "some-resource",
+					PassedJob: "upstream-job",
+					Version:   atc.Version{"version": "v1"},
+					With: []atc.SchedulingDiagnosticInput{
+						{
+							Input:    "other-input",
+							Resource: "other-resource",
+							Version:  atc.Version{"version": "v2"},
+						},
+					},
+					Message: "version version:v1 of some-resource has not passed job upstream-job together with version version:v2 of other-resource",
+				},
+			}))
+
+			err = scenario.Job("some-job").SaveNextInputMapping(db.InputMapping{
+				"some-input": db.InputResult{
+					Input: &db.AlgorithmInput{
+						AlgorithmVersion: db.AlgorithmVersion{
+							Version:    db.ResourceVersion(convertToMD5(atc.Version{"version": "v1"})),
+							ResourceID: scenario.Resource("some-resource").ID(),
+						},
+					},
+				},
+				"other-input": db.InputResult{
+					Input: &db.AlgorithmInput{
+						AlgorithmVersion: db.AlgorithmVersion{
+							Version:    db.ResourceVersion(convertToMD5(atc.Version{"version": "v2"})),
+							ResourceID: scenario.Resource("other-resource").ID(),
+						},
+					},
+				},
+			}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(scenario.Job("some-job").SchedulingDiagnostics()).To(BeEmpty())
+		})
+	})
+
 	Describe("GetFullNextBuildInputs", func() {
 		var (
 			versions          []atc.ResourceVersion
@@ -2796,4 +2984,58 @@ var _ = Describe("Job", func() {
 			}))
 		})
 	})
+
+	Describe("TestHistory", func() {
+		BeforeEach(func() {
+			for _, status := range []atc.TestStatus{atc.TestStatusFailed, atc.TestStatusPassed, atc.TestStatusFailed} {
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveTestResults("unit", []atc.TestResult{
+					{Suite: "math", Name: "adds", Status: atc.TestStatusPassed},
+					{Suite: "math", Name: "divides", Status: status},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			_, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns how each test has fared across the builds with results, flaky tests first", func() {
+			history, err := job.TestHistory(10)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(history).To(Equal([]atc.TestHistory{
+				{
+					Suite:      "math",
+					Name:       "divides",
+					Runs:       3,
+					Passed:     1,
+					Failed:     2,
+					Flaky:      true,
+					LastStatus: atc.TestStatusFailed,
+					LastBuild:  "3",
+				},
+				{
+					Suite:      "math",
+					Name:       "adds",
+					Runs:       3,
+					Passed:     3,
+					LastStatus: atc.TestStatusPassed,
+					LastBuild:  "3",
+				},
+			}))
+		})
+
+		It("only looks at the most recent builds", func() {
+			history, err := job.TestHistory(1)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(history).To(HaveLen(2))
+			Expect(history[0].Name).To(Equal("divides"))
+			Expect(history[0].Runs).To(Equal(1))
+			Expect(history[0].Flaky).To(BeFalse())
+		})
+	})
 })
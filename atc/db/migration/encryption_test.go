@@ -63,6 +63,89 @@ var _ = Describe("Encryption", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(isEncryptedWith(db, key, "test")).To(BeTrue())
 		})
+
+		It("encrypts team notifications with their own nonce", func() {
+			migrator := migration.NewMigrator(db, lockFactory)
+
+			err := migrator.Up(nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			insertIntoEncryptedColumn(db, encryption.NewNoEncryption(), "test")
+
+			_, err = db.Exec(`UPDATE teams SET notifications = $1 WHERE name = 'test'`, `[{"name":"chat","secret":"some-secret"}]`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = migrator.Up(key, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			var (
+				ciphertext string
+				nonce      *string
+			)
+			err = db.QueryRow(`SELECT notifications, notifications_nonce FROM teams WHERE name = 'test'`).Scan(&ciphertext, &nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			plaintext, err := key.Decrypt(ciphertext, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(plaintext)).To(Equal(`[{"name":"chat","secret":"some-secret"}]`))
+		})
+	})
+
+	Context("starting with unencrypted team var sources", func() {
+		var key *encryption.Key
+
+		BeforeEach(func() {
+			key = createKey("AES256Key-32Characters1234567890")
+		})
+
+		It("encrypts them with their own nonce", func() {
+			migrator := migration.NewMigrator(db, lockFactory)
+
+			err := migrator.Up(nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			insertIntoEncryptedColumn(db, encryption.NewNoEncryption(), "test")
+
+			_, err = db.Exec(`UPDATE teams SET var_sources = $1 WHERE name = 'test'`, `[{"name":"vault","type":"vault"}]`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = migrator.Up(key, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamVarSources(db, key)).To(Equal(`[{"name":"vault","type":"vault"}]`))
+		})
+	})
+
+	Context("starting with encrypted team var sources", func() {
+		var (
+			key1 *encryption.Key
+			key2 *encryption.Key
+		)
+
+		BeforeEach(func() {
+			key1 = createKey("AES256Key-32Characters1234567890")
+			key2 = createKey("AES256Key-32Characters0987654321")
+		})
+
+		It("re-encrypts them when rotating the key", func() {
+			migrator := migration.NewMigrator(db, lockFactory)
+
+			err := migrator.Up(key2, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			insertIntoEncryptedColumn(db, key2, "test")
+
+			ciphertext, nonce, err := key2.Encrypt([]byte(`[{"name":"vault","type":"vault"}]`))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = db.Exec(`UPDATE teams SET var_sources = $1, var_sources_nonce = $2 WHERE name = 'test'`, ciphertext, nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = migrator.Up(key1, key2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamVarSources(db, key1)).To(Equal(`[{"name":"vault","type":"vault"}]`))
+		})
 	})
 
 	Context("starting with encrypted DB", func() {
@@ -225,3 +308,18 @@ func createKey(key string) *encryption.Key {
 
 	return encryption.NewKey(aesgcm)
 }
+
+func teamVarSources(db *sql.DB, key *encryption.Key) string {
+	var (
+		ciphertext string
+		nonce      *string
+	)
+	err := db.QueryRow(`SELECT var_sources, var_sources_nonce FROM teams WHERE name = 'test'`).Scan(&ciphertext, &nonce)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(nonce).ToNot(BeNil())
+
+	plaintext, err := key.Decrypt(ciphertext, nonce)
+	Expect(err).ToNot(HaveOccurred())
+
+	return string(plaintext)
+}
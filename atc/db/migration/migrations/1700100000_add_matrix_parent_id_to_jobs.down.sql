@@ -0,0 +1,4 @@
+DROP INDEX jobs_matrix_parent_id_idx;
+
+ALTER TABLE jobs
+    DROP COLUMN matrix_parent_id;
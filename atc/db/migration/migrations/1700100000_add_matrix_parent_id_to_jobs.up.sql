@@ -0,0 +1,6 @@
+-- Cell jobs expanded from a matrix job reference the job they were expanded
+-- from, so that the pipeline config can be rebuilt without them.
+ALTER TABLE jobs
+    ADD COLUMN matrix_parent_id integer REFERENCES jobs (id) ON DELETE CASCADE;
+
+CREATE INDEX jobs_matrix_parent_id_idx ON jobs (matrix_parent_id);
@@ -0,0 +1,5 @@
+DROP INDEX jobs_schedule_next_fire_idx;
+
+ALTER TABLE jobs
+    DROP COLUMN schedule,
+    DROP COLUMN schedule_next_fire;
@@ -0,0 +1,7 @@
+-- The schedule is kept alongside the (encrypted) job config so that saving a
+-- pipeline only resets the next fire time when the schedule has changed.
+ALTER TABLE jobs
+    ADD COLUMN schedule text,
+    ADD COLUMN schedule_next_fire timestamp with time zone;
+
+CREATE INDEX jobs_schedule_next_fire_idx ON jobs (schedule_next_fire) WHERE schedule_next_fire IS NOT NULL;
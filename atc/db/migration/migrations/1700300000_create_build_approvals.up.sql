@@ -0,0 +1,13 @@
+-- A row is created when an approval step starts waiting, and records who
+-- approved or rejected it once it has been decided.
+CREATE TABLE build_approvals (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    name text NOT NULL,
+    approvers text NOT NULL DEFAULT '[]',
+    approved boolean,
+    decided_by text,
+    requested_at timestamp with time zone NOT NULL DEFAULT now(),
+    decided_at timestamp with time zone,
+    PRIMARY KEY (build_id, plan_id)
+);
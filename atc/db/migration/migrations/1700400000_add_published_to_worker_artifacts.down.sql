@@ -0,0 +1,2 @@
+ALTER TABLE worker_artifacts
+    DROP COLUMN published;
@@ -0,0 +1,4 @@
+-- Published artifacts are outputs that a build asked to keep around after it
+-- finished, rather than the short-lived artifacts used by `fly execute`.
+ALTER TABLE worker_artifacts
+    ADD COLUMN published boolean NOT NULL DEFAULT false;
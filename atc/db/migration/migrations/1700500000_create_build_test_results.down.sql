@@ -0,0 +1,2 @@
+DROP TABLE build_test_summaries;
+DROP TABLE build_test_results;
@@ -0,0 +1,27 @@
+-- Results of the individual test cases read from task test reports. The job
+-- is recorded alongside the build so that a job's test history can be read
+-- without joining against builds.
+CREATE TABLE build_test_results (
+    id bigserial PRIMARY KEY,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    job_id integer REFERENCES jobs (id) ON DELETE CASCADE,
+    step text NOT NULL,
+    suite text NOT NULL DEFAULT '',
+    name text NOT NULL,
+    status text NOT NULL,
+    duration double precision NOT NULL DEFAULT 0,
+    message text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX build_test_results_build_id_idx ON build_test_results (build_id);
+CREATE INDEX build_test_results_job_id_build_id_idx ON build_test_results (job_id, build_id) WHERE job_id IS NOT NULL;
+
+-- Counts are kept separately so that listing builds doesn't have to
+-- aggregate their test results.
+CREATE TABLE build_test_summaries (
+    build_id bigint PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+    total integer NOT NULL DEFAULT 0,
+    passed integer NOT NULL DEFAULT 0,
+    failed integer NOT NULL DEFAULT 0,
+    skipped integer NOT NULL DEFAULT 0
+);
@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN events_archived boolean NOT NULL DEFAULT false;
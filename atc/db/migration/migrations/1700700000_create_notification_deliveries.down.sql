@@ -0,0 +1,4 @@
+DROP TABLE notification_dead_letters;
+DROP TABLE notification_deliveries;
+
+ALTER TABLE teams DROP COLUMN notifications;
@@ -0,0 +1,35 @@
+ALTER TABLE teams ADD COLUMN notifications text;
+
+-- A row is created for each of a team's notifications when one of its builds
+-- finishes, and is retried by the notifier until it is delivered or runs out
+-- of attempts.
+CREATE TABLE notification_deliveries (
+    id bigserial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    notification text NOT NULL,
+    url text NOT NULL,
+    payload text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    response_status integer,
+    last_error text,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX notification_deliveries_team_id_idx ON notification_deliveries (team_id, id);
+CREATE INDEX notification_deliveries_pending_idx ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
+
+-- Deliveries which failed on every attempt, kept along with the payload so
+-- that they can be inspected and replayed.
+CREATE TABLE notification_dead_letters (
+    delivery_id bigint PRIMARY KEY REFERENCES notification_deliveries (id) ON DELETE CASCADE,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    notification text NOT NULL,
+    url text NOT NULL,
+    payload text NOT NULL,
+    error text NOT NULL,
+    failed_at timestamp with time zone NOT NULL DEFAULT now()
+);
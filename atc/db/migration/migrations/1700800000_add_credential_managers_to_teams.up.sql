@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN credential_managers text[];
@@ -0,0 +1,23 @@
+-- A row is recorded each time a build reads a secret from a credential
+-- manager. Secret values are never stored. Rows outlive the teams, pipelines
+-- and builds they refer to, and are removed once they are older than the
+-- configured retention.
+CREATE TABLE secret_accesses (
+    id bigserial PRIMARY KEY,
+    team_id integer NOT NULL,
+    team_name text NOT NULL,
+    pipeline_id integer,
+    pipeline_name text,
+    build_id bigint NOT NULL,
+    build_name text NOT NULL,
+    job_name text,
+    step_name text,
+    var_name text NOT NULL,
+    manager text,
+    path text NOT NULL,
+    accessed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX secret_accesses_team_id_accessed_at_idx ON secret_accesses (team_id, accessed_at);
+CREATE INDEX secret_accesses_build_id_idx ON secret_accesses (build_id);
+CREATE INDEX secret_accesses_accessed_at_idx ON secret_accesses (accessed_at);
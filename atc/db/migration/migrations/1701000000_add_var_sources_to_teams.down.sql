@@ -0,0 +1 @@
+ALTER TABLE teams DROP COLUMN var_sources, DROP COLUMN var_sources_nonce;
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN var_sources text, ADD COLUMN var_sources_nonce text;
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN step_templates text;
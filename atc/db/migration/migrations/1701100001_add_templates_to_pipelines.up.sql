@@ -0,0 +1 @@
+ALTER TABLE pipelines ADD COLUMN templates jsonb;
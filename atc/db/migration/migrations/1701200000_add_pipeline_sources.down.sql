@@ -0,0 +1,3 @@
+ALTER TABLE pipelines DROP COLUMN source_path;
+
+ALTER TABLE teams DROP COLUMN pipeline_source, DROP COLUMN pipeline_source_status;
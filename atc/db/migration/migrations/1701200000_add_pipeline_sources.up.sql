@@ -0,0 +1,3 @@
+ALTER TABLE teams ADD COLUMN pipeline_source text, ADD COLUMN pipeline_source_status text;
+
+ALTER TABLE pipelines ADD COLUMN source_path text;
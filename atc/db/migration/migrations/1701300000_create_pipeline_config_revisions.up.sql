@@ -0,0 +1,15 @@
+-- A row is created each time a pipeline's config is saved, so that earlier
+-- configs can be diffed against and rolled back to.
+CREATE TABLE pipeline_config_revisions (
+    id bigserial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    version bigint NOT NULL,
+    config text NOT NULL,
+    nonce text,
+    source text NOT NULL,
+    created_by text,
+    build_id bigint REFERENCES builds (id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX pipeline_config_revisions_pipeline_id_version_idx ON pipeline_config_revisions (pipeline_id, version);
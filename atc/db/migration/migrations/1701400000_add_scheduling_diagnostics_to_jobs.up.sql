@@ -0,0 +1,3 @@
+-- Why the scheduler last failed to resolve the job's inputs, as JSON, or NULL
+-- if they were all resolved.
+ALTER TABLE jobs ADD COLUMN scheduling_diagnostics jsonb;
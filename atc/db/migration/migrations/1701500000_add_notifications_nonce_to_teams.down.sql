@@ -0,0 +1,5 @@
+-- encrypted notifications can't be read without their nonce, so they have to
+-- be configured again
+UPDATE teams SET notifications = NULL WHERE notifications_nonce IS NOT NULL;
+
+ALTER TABLE teams DROP COLUMN notifications_nonce;
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN notifications_nonce text;
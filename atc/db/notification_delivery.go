@@ -0,0 +1,368 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+)
+
+//counterfeiter:generate . NotificationDeliveryFactory
+type NotificationDeliveryFactory interface {
+	// PendingDeliveries returns up to limit deliveries which are due to be
+	// attempted, oldest first.
+	PendingDeliveries(limit int) ([]NotificationDelivery, error)
+}
+
+//counterfeiter:generate . NotificationDelivery
+type NotificationDelivery interface {
+	ID() int
+	TeamID() int
+	BuildID() int
+	Notification() string
+	URL() string
+	Payload() []byte
+	Status() atc.NotificationDeliveryStatus
+	Attempts() int
+	ResponseStatus() int
+	LastError() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	// Config returns the team's current config for the delivery's
+	// notification, or false if it has since been removed.
+	Config() (atc.NotificationConfig, bool)
+
+	Succeeded(responseStatus int) error
+	Retry(responseStatus int, lastError string, retryAt time.Time) error
+
+	// Fail gives up on the delivery, moving it to the dead letters.
+	Fail(responseStatus int, lastError string) error
+}
+
+var notificationDeliveriesQuery = psql.Select(
+	"d.id",
+	"d.team_id",
+	"d.build_id",
+	"d.notification",
+	"d.url",
+	"d.payload",
+	"d.status",
+	"d.attempts",
+	"d.response_status",
+	"d.last_error",
+	"d.created_at",
+	"d.updated_at",
+	"t.notifications",
+	"t.notifications_nonce",
+).
+	From("notification_deliveries d").
+	Join("teams t ON t.id = d.team_id")
+
+type notificationDelivery struct {
+	conn Conn
+
+	id             int
+	teamID         int
+	buildID        int
+	notification   string
+	url            string
+	payload        []byte
+	status         atc.NotificationDeliveryStatus
+	attempts       int
+	responseStatus int
+	lastError      string
+	createdAt      time.Time
+	updatedAt      time.Time
+
+	config     atc.NotificationConfig
+	configured bool
+}
+
+func (d *notificationDelivery) ID() int                                { return d.id }
+func (d *notificationDelivery) TeamID() int                            { return d.teamID }
+func (d *notificationDelivery) BuildID() int                           { return d.buildID }
+func (d *notificationDelivery) Notification() string                   { return d.notification }
+func (d *notificationDelivery) URL() string                            { return d.url }
+func (d *notificationDelivery) Payload() []byte                        { return d.payload }
+func (d *notificationDelivery) Status() atc.NotificationDeliveryStatus { return d.status }
+func (d *notificationDelivery) Attempts() int                          { return d.attempts }
+func (d *notificationDelivery) ResponseStatus() int                    { return d.responseStatus }
+func (d *notificationDelivery) LastError() string                      { return d.lastError }
+func (d *notificationDelivery) CreatedAt() time.Time                   { return d.createdAt }
+func (d *notificationDelivery) UpdatedAt() time.Time                   { return d.updatedAt }
+
+func (d *notificationDelivery) Config() (atc.NotificationConfig, bool) {
+	return d.config, d.configured
+}
+
+func (d *notificationDelivery) Succeeded(responseStatus int) error {
+	return d.update(psql.Update("notification_deliveries").
+		Set("status", atc.NotificationDeliverySucceeded).
+		Set("response_status", nullableStatus(responseStatus)).
+		Set("last_error", nil), d.conn)
+}
+
+func (d *notificationDelivery) Retry(responseStatus int, lastError string, retryAt time.Time) error {
+	return d.update(psql.Update("notification_deliveries").
+		Set("response_status", nullableStatus(responseStatus)).
+		Set("last_error", lastError).
+		Set("next_attempt_at", retryAt), d.conn)
+}
+
+func (d *notificationDelivery) Fail(responseStatus int, lastError string) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = d.update(psql.Update("notification_deliveries").
+		Set("status", atc.NotificationDeliveryFailed).
+		Set("response_status", nullableStatus(responseStatus)).
+		Set("last_error", lastError), tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("notification_dead_letters").
+		Columns("delivery_id", "team_id", "notification", "url", "payload", "error").
+		Values(d.id, d.teamID, d.notification, d.url, string(d.payload), lastError).
+		Suffix("ON CONFLICT (delivery_id) DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *notificationDelivery) update(update sq.UpdateBuilder, runner sq.Runner) error {
+	var responseStatus sql.NullInt64
+	var lastError sql.NullString
+	err := update.
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": d.id}).
+		Suffix("RETURNING status, attempts, response_status, last_error, updated_at").
+		RunWith(runner).
+		QueryRow().
+		Scan(&d.status, &d.attempts, &responseStatus, &lastError, &d.updatedAt)
+	if err != nil {
+		return err
+	}
+
+	d.responseStatus = int(responseStatus.Int64)
+	d.lastError = lastError.String
+
+	return nil
+}
+
+type notificationDeliveryFactory struct {
+	conn Conn
+}
+
+func NewNotificationDeliveryFactory(conn Conn) NotificationDeliveryFactory {
+	return &notificationDeliveryFactory{
+		conn: conn,
+	}
+}
+
+func (f *notificationDeliveryFactory) PendingDeliveries(limit int) ([]NotificationDelivery, error) {
+	rows, err := notificationDeliveriesQuery.
+		Where(sq.Eq{"d.status": atc.NotificationDeliveryPending}).
+		Where(sq.Expr("d.next_attempt_at <= now()")).
+		OrderBy("d.id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotificationDeliveries(f.conn, rows)
+}
+
+func (t *team) NotificationDeliveries(limit int) ([]NotificationDelivery, error) {
+	rows, err := notificationDeliveriesQuery.
+		Where(sq.Eq{"d.team_id": t.id}).
+		OrderBy("d.id DESC").
+		Limit(uint64(limit)).
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotificationDeliveries(t.conn, rows)
+}
+
+func scanNotificationDeliveries(conn Conn, rows *sql.Rows) ([]NotificationDelivery, error) {
+	defer Close(rows)
+
+	deliveries := []NotificationDelivery{}
+	for rows.Next() {
+		delivery := &notificationDelivery{conn: conn}
+
+		var payload string
+		var responseStatus sql.NullInt64
+		var lastError, notifications, notificationsNonce sql.NullString
+		err := rows.Scan(
+			&delivery.id,
+			&delivery.teamID,
+			&delivery.buildID,
+			&delivery.notification,
+			&delivery.url,
+			&payload,
+			&delivery.status,
+			&delivery.attempts,
+			&responseStatus,
+			&lastError,
+			&delivery.createdAt,
+			&delivery.updatedAt,
+			&notifications,
+			&notificationsNonce,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.payload = []byte(payload)
+		delivery.responseStatus = int(responseStatus.Int64)
+		delivery.lastError = lastError.String
+
+		configs, err := decryptNotifications(conn.EncryptionStrategy(), notifications, notificationsNonce)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.config, delivery.configured = configs.Lookup(delivery.notification)
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// enqueueNotifications creates a delivery for each of the team's
+// notifications that are subscribed to the build finishing with the given
+// status.
+func (b *build) enqueueNotifications(tx Tx, status BuildStatus, endTime time.Time) error {
+	if b.isForCheck() {
+		return nil
+	}
+
+	var notifications, nonce sql.NullString
+	err := psql.Select("notifications", "notifications_nonce").
+		From("teams").
+		Where(sq.Eq{"id": b.teamID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&notifications, &nonce)
+	if err != nil {
+		return err
+	}
+
+	configs, err := decryptNotifications(b.conn.EncryptionStrategy(), notifications, nonce)
+	if err != nil {
+		return err
+	}
+
+	build := atc.Build{
+		ID:                   b.id,
+		TeamName:             b.teamName,
+		Name:                 b.name,
+		Status:               atc.BuildStatus(status),
+		APIURL:               fmt.Sprintf("/api/v1/builds/%d", b.id),
+		JobName:              b.jobName,
+		PipelineID:           b.pipelineID,
+		PipelineName:         b.pipelineName,
+		PipelineInstanceVars: b.pipelineInstanceVars,
+		StartTime:            b.startTime.Unix(),
+		EndTime:              endTime.Unix(),
+	}
+
+	for _, config := range configs {
+		if !config.Subscribed(b.pipelineName, build.Status) {
+			continue
+		}
+
+		payload, err := json.Marshal(atc.BuildNotification{
+			Notification: config.Name,
+			Build:        build,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("notification_deliveries").
+			Columns("team_id", "build_id", "notification", "url", "payload").
+			Values(b.teamID, b.id, config.Name, config.URL, string(payload)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encryptNotifications encrypts the team's notifications, as they may contain
+// the secrets that their payloads are signed with.
+func encryptNotifications(strategy encryption.Strategy, notifications atc.NotificationConfigs) (sql.NullString, sql.NullString, error) {
+	if len(notifications) == 0 {
+		return sql.NullString{}, sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(notifications)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	encrypted, nonce, err := strategy.Encrypt(payload)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	encryptedNonce := sql.NullString{}
+	if nonce != nil {
+		encryptedNonce = sql.NullString{String: *nonce, Valid: true}
+	}
+
+	return sql.NullString{String: encrypted, Valid: true}, encryptedNonce, nil
+}
+
+func decryptNotifications(strategy encryption.Strategy, payload sql.NullString, nonce sql.NullString) (atc.NotificationConfigs, error) {
+	if !payload.Valid {
+		return nil, nil
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := strategy.Decrypt(payload.String, noncense)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications atc.NotificationConfigs
+	err = json.Unmarshal(decrypted, &notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func nullableStatus(status int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(status), Valid: status != 0}
+}
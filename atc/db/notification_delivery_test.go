@@ -0,0 +1,217 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationDelivery", func() {
+	var (
+		deliveryFactory db.NotificationDeliveryFactory
+		notifications   atc.NotificationConfigs
+	)
+
+	BeforeEach(func() {
+		deliveryFactory = db.NewNotificationDeliveryFactory(dbConn)
+
+		notifications = atc.NotificationConfigs{
+			{
+				Name:     "failures",
+				URL:      "https://chat.example.com/failures",
+				Secret:   "some-secret",
+				Statuses: []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+			},
+			{
+				Name:      "other-pipeline",
+				URL:       "https://chat.example.com/other",
+				Pipelines: []string{"other-pipeline"},
+			},
+			{
+				Name: "everything",
+				URL:  "https://chat.example.com/everything",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err := defaultTeam.UpdateNotifications(notifications)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("finishing a build", func() {
+		var build db.Build
+
+		JustBeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates a delivery for each subscribed notification", func() {
+			deliveries, err := defaultTeam.NotificationDeliveries(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].Notification()).To(Equal("everything"))
+			Expect(deliveries[1].Notification()).To(Equal("failures"))
+
+			delivery := deliveries[1]
+			Expect(delivery.URL()).To(Equal("https://chat.example.com/failures"))
+			Expect(delivery.BuildID()).To(Equal(build.ID()))
+			Expect(delivery.Status()).To(Equal(atc.NotificationDeliveryPending))
+			Expect(delivery.Attempts()).To(BeZero())
+
+			config, found := delivery.Config()
+			Expect(found).To(BeTrue())
+			Expect(config.Secret).To(Equal("some-secret"))
+
+			var notification atc.BuildNotification
+			err = json.Unmarshal(delivery.Payload(), &notification)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notification.Notification).To(Equal("failures"))
+			Expect(notification.Build.ID).To(Equal(build.ID()))
+			Expect(notification.Build.Status).To(Equal(atc.StatusFailed))
+			Expect(notification.Build.JobName).To(Equal("some-job"))
+			Expect(notification.Build.PipelineName).To(Equal(defaultPipeline.Name()))
+		})
+
+		Context("when the team has no notifications", func() {
+			BeforeEach(func() {
+				notifications = nil
+			})
+
+			It("creates no deliveries", func() {
+				deliveries, err := defaultTeam.NotificationDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("delivering", func() {
+		var delivery db.NotificationDelivery
+
+		JustBeforeEach(func() {
+			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			deliveries, err := deliveryFactory.PendingDeliveries(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+
+			delivery = deliveries[0]
+		})
+
+		It("returns due deliveries as pending", func() {
+			Expect(delivery.Notification()).To(Equal("everything"))
+		})
+
+		Context("when the delivery succeeds", func() {
+			JustBeforeEach(func() {
+				err := delivery.Succeeded(204)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer pending", func() {
+				Expect(delivery.Status()).To(Equal(atc.NotificationDeliverySucceeded))
+				Expect(delivery.Attempts()).To(Equal(1))
+				Expect(delivery.ResponseStatus()).To(Equal(204))
+
+				deliveries, err := deliveryFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+
+		Context("when the delivery is retried", func() {
+			var retryAt time.Time
+
+			BeforeEach(func() {
+				retryAt = time.Now().Add(time.Hour)
+			})
+
+			JustBeforeEach(func() {
+				err := delivery.Retry(502, "bad gateway", retryAt)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the failed attempt", func() {
+				Expect(delivery.Status()).To(Equal(atc.NotificationDeliveryPending))
+				Expect(delivery.Attempts()).To(Equal(1))
+				Expect(delivery.ResponseStatus()).To(Equal(502))
+				Expect(delivery.LastError()).To(Equal("bad gateway"))
+			})
+
+			It("is not pending until it is due", func() {
+				deliveries, err := deliveryFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			Context("when it is due", func() {
+				BeforeEach(func() {
+					retryAt = time.Now().Add(-time.Second)
+				})
+
+				It("is pending again", func() {
+					deliveries, err := deliveryFactory.PendingDeliveries(10)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deliveries).To(HaveLen(1))
+					Expect(deliveries[0].Attempts()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the delivery fails", func() {
+			JustBeforeEach(func() {
+				err := delivery.Fail(0, "connection refused")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer pending", func() {
+				Expect(delivery.Status()).To(Equal(atc.NotificationDeliveryFailed))
+
+				deliveries, err := deliveryFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			It("moves it to the dead letters", func() {
+				var payload, deadLetterError string
+				err := dbConn.QueryRow("SELECT payload, error FROM notification_dead_letters WHERE delivery_id = $1", delivery.ID()).Scan(&payload, &deadLetterError)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(payload).To(MatchJSON(delivery.Payload()))
+				Expect(deadLetterError).To(Equal("connection refused"))
+			})
+		})
+
+		Context("when the notification is removed from the team", func() {
+			JustBeforeEach(func() {
+				err := defaultTeam.UpdateNotifications(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				deliveries, err := deliveryFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+
+				delivery = deliveries[0]
+			})
+
+			It("is no longer configured", func() {
+				_, found := delivery.Config()
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
+	BuildLogStore() BuildLogStore
 
 	Ping() error
 	Driver() driver.Driver
@@ -130,6 +131,12 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+// BuildLogStore returns nil, as build events are kept in the database unless
+// the connection is wrapped with WithBuildLogStore.
+func (db *db) BuildLogStore() BuildLogStore {
+	return nil
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
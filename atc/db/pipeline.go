@@ -1,10 +1,12 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"github.com/lib/pq"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/lager/v3"
@@ -63,6 +65,7 @@ type Pipeline interface {
 	Groups() atc.GroupConfigs
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	Templates() atc.StepTemplates
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
@@ -124,8 +127,17 @@ type Pipeline interface {
 	Destroy() error
 
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
+	SecretsReport(lager.Logger, creds.Secrets, creds.VarSourcePool) (atc.PipelineSecretsReport, error)
 
 	SetParentIDs(jobID, buildID int) error
+
+	ConfigRevisions() ([]atc.ConfigRevision, error)
+	ConfigRevision(version ConfigVersion) (atc.ConfigRevision, bool, error)
+
+	// SourcePath is the config file the pipeline is set from in its team's
+	// pipeline source, if it is managed by one.
+	SourcePath() string
+	SetSourcePath(path string) error
 }
 
 type pipeline struct {
@@ -139,6 +151,8 @@ type pipeline struct {
 	groups        atc.GroupConfigs
 	varSources    atc.VarSourceConfigs
 	display       *atc.DisplayConfig
+	templates     atc.StepTemplates
+	sourcePath    string
 	configVersion ConfigVersion
 	paused        bool
 	pausedBy      string
@@ -172,7 +186,9 @@ var pipelinesQuery = psql.Select(`
 		p.parent_build_id,
 		p.instance_vars,
 		p.paused_by,
-		p.paused_at`).
+		p.paused_at,
+		p.templates,
+		p.source_path`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
@@ -193,6 +209,7 @@ func (p *pipeline) InstanceVars() atc.InstanceVars   { return p.instanceVars }
 func (p *pipeline) Groups() atc.GroupConfigs         { return p.groups }
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
+func (p *pipeline) Templates() atc.StepTemplates     { return p.templates }
 func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
 func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
@@ -200,6 +217,7 @@ func (p *pipeline) PausedAt() time.Time              { return p.pausedAt }
 func (p *pipeline) PausedBy() string                 { return p.pausedBy }
 func (p *pipeline) Archived() bool                   { return p.archived }
 func (p *pipeline) LastUpdated() time.Time           { return p.lastUpdated }
+func (p *pipeline) SourcePath() string               { return p.sourcePath }
 
 func (p *pipeline) CheckPaused() (bool, error) {
 	var paused bool
@@ -234,7 +252,7 @@ func (p *pipeline) Reload() (bool, error) {
 }
 
 func (p *pipeline) Config() (atc.Config, error) {
-	jobs, err := p.Jobs()
+	jobs, err := p.configuredJobs()
 	if err != nil {
 		return atc.Config{}, fmt.Errorf("failed to get jobs: %w", err)
 	}
@@ -267,6 +285,7 @@ func (p *pipeline) Config() (atc.Config, error) {
 		Prototypes:    prototypes.Configs(),
 		Jobs:          jobConfigs,
 		Display:       p.Display(),
+		Templates:     p.Templates(),
 	}
 
 	return config, nil
@@ -633,6 +652,25 @@ func (p *pipeline) Jobs() (Jobs, error) {
 	return jobs, err
 }
 
+// configuredJobs returns the jobs as they were configured, i.e. without the
+// cells of any matrix jobs.
+func (p *pipeline) configuredJobs() (Jobs, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"j.pipeline_id":      p.id,
+			"j.matrix_parent_id": nil,
+			"active":             true,
+		}).
+		OrderBy("j.id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobs(p.conn, p.lockFactory, rows)
+}
+
 func (p *pipeline) Dashboard() ([]atc.JobSummary, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -992,6 +1030,15 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return nil
 	}
 
+	if store := p.conn.BuildLogStore(); store != nil {
+		for _, buildID := range buildIDs {
+			err := store.Delete(context.Background(), buildLogName(p.eventsTable(), buildID))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return err
@@ -1115,48 +1162,185 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 // var_sources, a vars.MultiVars containing all pipeline specific var_sources
 // plug the global variables, otherwise just return the global variables.
 func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
-	globalVars := creds.NewVariables(globalSecrets, p.TeamName(), p.Name(), false)
+	globalSecrets, err := teamSecrets(p.conn, p.teamID, globalSecrets, varSourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, _, err := p.variables(logger, globalSecrets, varSourcePool)
+	return variables, err
+}
+
+// variables returns the pipeline's variables along with the secrets of each
+// of its var_sources, by name.
+func (p *pipeline) variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, map[string]creds.Secrets, error) {
+	varSources, err := p.allVarSources()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return varSourceVariables(logger, globalSecrets, varSourcePool, varSources, p.TeamName(), p.Name())
+}
+
+// allVarSources returns the var sources shared by the pipeline's team,
+// overridden by the pipeline's own.
+func (p *pipeline) allVarSources() (atc.VarSourceConfigs, error) {
+	teamVarSources, err := teamVarSources(p.conn, p.teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return teamVarSources.Override(p.varSources), nil
+}
+
+// varSourceVariables returns the global variables combined with those of each
+// var source, along with the secrets of each var source, by name.
+func varSourceVariables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool, varSources atc.VarSourceConfigs, teamName string, pipelineName string) (vars.Variables, map[string]creds.Secrets, error) {
+	globalVars := creds.NewVariables(globalSecrets, teamName, pipelineName, false)
 	namedVarsMap := vars.NamedVariables{}
+	varSourceSecrets := map[string]creds.Secrets{}
 
 	// It's safe to add NamedVariables to allVars via an array here, because
 	// a map is passed by reference.
 	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars})
 
-	orderedVarSources, err := p.varSources.OrderByDependency()
+	orderedVarSources, err := varSources.OrderByDependency()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, cm := range orderedVarSources {
 		factory := creds.ManagerFactories()[cm.Type]
 		if factory == nil {
-			return nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
+			return nil, nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
 		}
 
 		// Interpolate variables in pipeline credential manager's config
 		newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
 		if err != nil {
-			return nil, errors.Wrapf(err, "evaluate var_source '%s' error", cm.Name)
+			return nil, nil, errors.Wrapf(err, "evaluate var_source '%s' error", cm.Name)
 		}
 
 		config, ok := newConfig["config"].(map[string]interface{})
 		if !ok {
-			return nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
+			return nil, nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
 		}
 		secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
 		if err != nil {
-			return nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
+			return nil, nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
 		}
-		namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
+		namedVarsMap[cm.Name] = creds.NewVariables(creds.AuditVarSource(globalSecrets, secrets, cm.Name), teamName, pipelineName, true)
+		varSourceSecrets[cm.Name] = secrets
 	}
 
 	// If there is no var_source from the pipeline, then just return the global
 	// vars.
 	if len(namedVarsMap) == 0 {
-		return globalVars, nil
+		return globalVars, varSourceSecrets, nil
 	}
 
-	return allVars, nil
+	return allVars, varSourceSecrets, nil
+}
+
+// SecretsReport looks up each of the credentials referred to by the
+// pipeline's config, without revealing their values.
+func (p *pipeline) SecretsReport(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (atc.PipelineSecretsReport, error) {
+	report := atc.PipelineSecretsReport{
+		PipelineID:           p.id,
+		PipelineName:         p.name,
+		PipelineInstanceVars: p.instanceVars,
+		Secrets:              []atc.SecretReference{},
+	}
+
+	config, err := p.Config()
+	if err != nil {
+		return atc.PipelineSecretsReport{}, err
+	}
+
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return atc.PipelineSecretsReport{}, err
+	}
+
+	globalSecrets, err = teamSecrets(p.conn, p.teamID, globalSecrets, varSourcePool)
+	if err != nil {
+		return atc.PipelineSecretsReport{}, err
+	}
+
+	varSources, err := p.allVarSources()
+	if err != nil {
+		return atc.PipelineSecretsReport{}, err
+	}
+
+	_, varSourceSecrets, err := varSourceVariables(logger, globalSecrets, varSourcePool, varSources, p.TeamName(), p.Name())
+	if err != nil {
+		report.Error = err.Error()
+		return report, nil
+	}
+
+	usedVarSources := map[string]bool{}
+	seen := map[string]bool{}
+
+	names := vars.NewTemplate(payload).ExtraVarNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			report.Secrets = append(report.Secrets, atc.SecretReference{
+				Name:    name,
+				Lookups: []atc.SecretLookup{},
+				Error:   err.Error(),
+			})
+			continue
+		}
+
+		if ref.Source == "." {
+			// local vars are set by the build, e.g. by load_var
+			continue
+		}
+
+		if ref.Source == "" {
+			reference := creds.Resolve(globalSecrets, "", p.TeamName(), p.Name(), false, ref)
+			reference.Name = name
+			report.Secrets = append(report.Secrets, reference)
+			continue
+		}
+
+		usedVarSources[ref.Source] = true
+
+		secrets, found := varSourceSecrets[ref.Source]
+		if !found {
+			report.Secrets = append(report.Secrets, atc.SecretReference{
+				Name:      name,
+				VarSource: ref.Source,
+				Lookups:   []atc.SecretLookup{},
+				Error:     fmt.Sprintf("unknown var_source '%s'", ref.Source),
+			})
+			continue
+		}
+
+		varSource, _ := varSources.Lookup(ref.Source)
+
+		reference := creds.Resolve(secrets, varSource.Type, p.TeamName(), p.Name(), true, ref.WithoutSource())
+		reference.Name = name
+		reference.VarSource = ref.Source
+		report.Secrets = append(report.Secrets, reference)
+	}
+
+	for _, varSource := range p.varSources {
+		if !usedVarSources[varSource.Name] {
+			report.UnusedVarSources = append(report.UnusedVarSources, varSource.Name)
+		}
+	}
+
+	return report, nil
 }
 
 func (p *pipeline) SetParentIDs(jobID, buildID int) error {
@@ -1196,6 +1380,21 @@ func (p *pipeline) SetParentIDs(jobID, buildID int) error {
 	return tx.Commit()
 }
 
+func (p *pipeline) SetSourcePath(path string) error {
+	_, err := psql.Update("pipelines").
+		Set("source_path", sql.NullString{String: path, Valid: path != ""}).
+		Where(sq.Eq{"id": p.id}).
+		RunWith(p.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	p.sourcePath = path
+
+	return nil
+}
+
 func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
 	var buildName string
 	var jobID int
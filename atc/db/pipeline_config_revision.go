@@ -0,0 +1,144 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// ConfigAuthor is recorded alongside each revision of a pipeline's config,
+// saying how and by whom it was saved.
+type ConfigAuthor struct {
+	Source    atc.ConfigSource
+	CreatedBy string
+}
+
+var configRevisionsQuery = psql.Select(
+	"r.version",
+	"r.source",
+	"r.created_by",
+	"r.build_id",
+	"r.created_at",
+).
+	From("pipeline_config_revisions r")
+
+func saveConfigRevision(tx Tx, pipelineID int, version ConfigVersion, config atc.Config, author ConfigAuthor, buildID sql.NullInt64) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_config_revisions").
+		SetMap(map[string]interface{}{
+			"pipeline_id": pipelineID,
+			"version":     version,
+			"config":      encryptedPayload,
+			"nonce":       nonce,
+			"source":      author.Source,
+			"created_by":  sql.NullString{String: author.CreatedBy, Valid: author.CreatedBy != ""},
+			"build_id":    buildID,
+		}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// ConfigRevisions returns the revisions of the pipeline's config, newest
+// first, without their configs.
+func (p *pipeline) ConfigRevisions() ([]atc.ConfigRevision, error) {
+	rows, err := configRevisionsQuery.
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		OrderBy("r.version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	revisions := []atc.ConfigRevision{}
+	for rows.Next() {
+		revision, err := scanConfigRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// ConfigRevision returns the revision of the pipeline's config with the given
+// version, including the config.
+func (p *pipeline) ConfigRevision(version ConfigVersion) (atc.ConfigRevision, bool, error) {
+	var payload string
+	var nonce sql.NullString
+
+	row := configRevisionsQuery.
+		Columns("r.config", "r.nonce").
+		Where(sq.Eq{
+			"r.pipeline_id": p.id,
+			"r.version":     version,
+		}).
+		RunWith(p.conn).
+		QueryRow()
+
+	revision, err := scanConfigRevision(row, &payload, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.ConfigRevision{}, false, nil
+		}
+
+		return atc.ConfigRevision{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := p.conn.EncryptionStrategy().Decrypt(payload, noncense)
+	if err != nil {
+		return atc.ConfigRevision{}, false, err
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(decrypted, &config)
+	if err != nil {
+		return atc.ConfigRevision{}, false, err
+	}
+
+	revision.Config = &config
+
+	return revision, true, nil
+}
+
+func scanConfigRevision(row scannable, extra ...interface{}) (atc.ConfigRevision, error) {
+	var (
+		revision  atc.ConfigRevision
+		createdBy sql.NullString
+		buildID   sql.NullInt64
+		createdAt time.Time
+	)
+
+	err := row.Scan(append([]interface{}{&revision.Version, &revision.Source, &createdBy, &buildID, &createdAt}, extra...)...)
+	if err != nil {
+		return atc.ConfigRevision{}, err
+	}
+
+	revision.CreatedBy = createdBy.String
+	revision.BuildID = int(buildID.Int64)
+	revision.CreatedAt = createdAt.Unix()
+
+	return revision, nil
+}
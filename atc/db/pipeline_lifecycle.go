@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -132,8 +133,19 @@ func (p *pipelineLifecycle) RemoveBuildEventsForDeletedPipelines() error {
 		return nil
 	}
 
+	store := p.conn.BuildLogStore()
+
 	for _, id := range idsToDelete {
-		_, err = p.conn.Exec(fmt.Sprintf("DROP TABLE IF EXISTS pipeline_build_events_%d", id))
+		eventsTable := fmt.Sprintf("pipeline_build_events_%d", id)
+
+		if store != nil {
+			err = store.DeleteAll(context.Background(), eventsTable+"/")
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = p.conn.Exec("DROP TABLE IF EXISTS " + eventsTable)
 		if err != nil {
 			return err
 		}
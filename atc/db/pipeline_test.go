@@ -55,6 +55,15 @@ var _ = Describe("Pipeline", func() {
 			Display: &atc.DisplayConfig{
 				BackgroundImage: "background.jpg",
 			},
+			Templates: atc.StepTemplates{
+				{
+					Name:   "notify",
+					Params: map[string]interface{}{"channel": "#builds"},
+					Steps: []atc.Step{
+						{Config: &atc.PutStep{Name: "some-resource"}},
+					},
+				},
+			},
 			Jobs: atc.JobConfigs{
 				{
 					Name: "job-name",
@@ -2196,6 +2205,170 @@ var _ = Describe("Pipeline", func() {
 				Expect(v.(string)).To(Equal("pv"))
 			})
 		})
+
+		Context("with var_sources shared by the team", func() {
+			BeforeEach(func() {
+				err := team.UpdateVarSources(atc.VarSourceConfigs{
+					{
+						Name: "team-var-source",
+						Type: "dummy",
+						Config: map[string]interface{}{
+							"vars": map[string]interface{}{"tk": "tv"},
+						},
+					},
+					{
+						Name: "some-var-source",
+						Type: "dummy",
+						Config: map[string]interface{}{
+							"vars": map[string]interface{}{"pk": "team-pv"},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should get var from the team's var source", func() {
+				v, found, err := pvars.Get(vars.Reference{Source: "team-var-source", Path: "tk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v.(string)).To(Equal("tv"))
+			})
+
+			It("should let the pipeline's var source override the team's of the same name", func() {
+				v, found, err := pvars.Get(vars.Reference{Source: "some-var-source", Path: "pk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v.(string)).To(Equal("pv"))
+			})
+		})
+
+		Context("with a chain of global credential managers", func() {
+			var chain creds.SecretsChain
+
+			BeforeEach(func() {
+				chain = creds.SecretsChain{}
+				for _, name := range []string{"vault", "credhub"} {
+					value := name + "-value"
+
+					fakeSecrets := new(credsfakes.FakeSecrets)
+					fakeSecrets.GetStub = func(key string) (interface{}, *time.Time, bool, error) {
+						if key == "gk" {
+							return value, nil, true, nil
+						}
+						return nil, nil, false, nil
+					}
+
+					chain = append(chain, creds.NamedSecrets{Name: name, Secrets: fakeSecrets})
+				}
+			})
+
+			It("looks up the credential managers in the cluster's order", func() {
+				chainVars, err := pipeline.Variables(logger, chain, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				v, found, err := chainVars.Get(vars.Reference{Path: "gk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v).To(Equal("vault-value"))
+			})
+
+			It("looks up the credential managers in the team's order", func() {
+				Expect(team.UpdateCredentialManagers([]string{"credhub"})).To(Succeed())
+
+				chainVars, err := pipeline.Variables(logger, chain, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				v, found, err := chainVars.Get(vars.Reference{Path: "gk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v).To(Equal("credhub-value"))
+			})
+		})
+	})
+
+	Describe("SecretsReport", func() {
+		var (
+			fakeGlobalSecrets *credsfakes.FakeSecrets
+			pool              creds.VarSourcePool
+			report            atc.PipelineSecretsReport
+		)
+
+		BeforeEach(func() {
+			pool = creds.NewVarSourcePool(logger, creds.CredentialManagementConfig{}, 1*time.Minute, 1*time.Second, clock.NewClock())
+
+			pipelineConfig.Resources[0].Source = atc.Source{
+				"global":     "((gk))",
+				"missing":    "((missing))",
+				"var-source": "((some-var-source:pk))",
+				"local":      "((.:local))",
+			}
+
+			var err error
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeGlobalSecrets = new(credsfakes.FakeSecrets)
+			fakeGlobalSecrets.GetStub = func(key string) (interface{}, *time.Time, bool, error) {
+				if key == "gk" {
+					return "gv", nil, true, nil
+				}
+				return nil, nil, false, nil
+			}
+		})
+
+		AfterEach(func() {
+			pool.Close()
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			report, err = pipeline.SecretsReport(logger, fakeGlobalSecrets, pool)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reports each credential the config refers to", func() {
+			Expect(report.PipelineName).To(Equal("fake-pipeline"))
+			Expect(report.Error).To(BeEmpty())
+			Expect(report.Secrets).To(ConsistOf(
+				atc.SecretReference{
+					Name:     "gk",
+					Lookups:  []atc.SecretLookup{{Path: "gk"}},
+					Resolved: true,
+					Path:     "gk",
+				},
+				atc.SecretReference{
+					Name:    "missing",
+					Lookups: []atc.SecretLookup{{Path: "missing"}},
+				},
+				atc.SecretReference{
+					Name:      "some-var-source:pk",
+					VarSource: "some-var-source",
+					Lookups: []atc.SecretLookup{
+						{Manager: "dummy", Path: "some-team/fake-pipeline/pk"},
+						{Manager: "dummy", Path: "some-team/pk"},
+						{Manager: "dummy", Path: "pk"},
+					},
+					Resolved: true,
+					Manager:  "dummy",
+					Path:     "pk",
+				},
+			))
+			Expect(report.UnusedVarSources).To(BeEmpty())
+		})
+
+		Context("when a var_source is not referred to", func() {
+			BeforeEach(func() {
+				pipelineConfig.Resources[0].Source = atc.Source{"global": "((gk))"}
+
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("reports it as unused", func() {
+				Expect(report.UnusedVarSources).To(Equal([]string{"some-var-source"}))
+			})
+		})
 	})
 
 	Describe("SetParentIDs", func() {
@@ -2244,11 +2417,101 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SetSourcePath", func() {
+		It("marks the pipeline as managed by the file", func() {
+			Expect(pipeline.SourcePath()).To(BeEmpty())
+			Expect(pipeline.SetSourcePath("pipelines/some-pipeline.yml")).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.SourcePath()).To(Equal("pipelines/some-pipeline.yml"))
+		})
+
+		It("stops managing the pipeline", func() {
+			Expect(pipeline.SetSourcePath("pipelines/some-pipeline.yml")).To(Succeed())
+			Expect(pipeline.SetSourcePath("")).To(Succeed())
+
+			_, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.SourcePath()).To(BeEmpty())
+		})
+	})
+
 	Context("Config", func() {
 		It("should return config correctly", func() {
 			Expect(pipeline.Config()).To(Equal(pipelineConfig))
 		})
 	})
+
+	Describe("ConfigRevisions", func() {
+		var updatedConfig atc.Config
+
+		BeforeEach(func() {
+			updatedConfig = pipelineConfig
+			updatedConfig.Jobs = append(atc.JobConfigs{}, pipelineConfig.Jobs...)
+			updatedConfig.Jobs[0].Public = !updatedConfig.Jobs[0].Public
+
+			var err error
+			pipeline, _, err = team.SavePipelineAs(atc.PipelineRef{Name: "fake-pipeline"}, updatedConfig, pipeline.ConfigVersion(), false, db.ConfigAuthor{
+				Source:    atc.ConfigSourceFly,
+				CreatedBy: "some-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns a revision for each save, newest first", func() {
+			revisions, err := pipeline.ConfigRevisions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+
+			Expect(revisions[0].Version).To(Equal(int(pipeline.ConfigVersion())))
+			Expect(revisions[0].Source).To(Equal(atc.ConfigSourceFly))
+			Expect(revisions[0].CreatedBy).To(Equal("some-user"))
+			Expect(revisions[0].CreatedAt).ToNot(BeZero())
+			Expect(revisions[0].Config).To(BeNil())
+
+			Expect(revisions[1].Version).To(BeNumerically("<", revisions[0].Version))
+			Expect(revisions[1].CreatedBy).To(BeEmpty())
+		})
+
+		It("returns the config of a revision", func() {
+			revisions, err := pipeline.ConfigRevisions()
+			Expect(err).ToNot(HaveOccurred())
+
+			revision, found, err := pipeline.ConfigRevision(db.ConfigVersion(revisions[1].Version))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*revision.Config).To(Equal(pipelineConfig))
+
+			revision, found, err = pipeline.ConfigRevision(pipeline.ConfigVersion())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(*revision.Config).To(Equal(updatedConfig))
+		})
+
+		It("does not find a revision which was never saved", func() {
+			_, found, err := pipeline.ConfigRevision(pipeline.ConfigVersion() + 100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the config is saved by a build", func() {
+			It("records the build", func() {
+				build, err := defaultJob.CreateBuild("some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = build.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, team.ID(), pipelineConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				revisions, err := pipeline.ConfigRevisions()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revisions[0].Source).To(Equal(atc.ConfigSourceSetPipeline))
+				Expect(revisions[0].BuildID).To(Equal(build.ID()))
+				Expect(revisions[0].CreatedBy).To(Equal("some-user"))
+			})
+		})
+	})
 })
 
 func intptr(i int) *int {
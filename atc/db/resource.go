@@ -117,6 +117,7 @@ type Resource interface {
 	Causality(rcvID int, direction CausalityDirection) (atc.Causality, bool, error)
 
 	SetResourceConfigScope(ResourceConfigScope) error
+	SaveVersions(SpanContext, []atc.Version) (bool, error)
 
 	CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, from atc.Version, interval atc.CheckEvery, sourceDefaults atc.Source, skipInterval bool, skipIntervalRecursively bool) atc.Plan
 	CreateBuild(context.Context, bool, atc.Plan) (Build, bool, error)
@@ -256,6 +257,22 @@ func (r *resource) Reload() (bool, error) {
 	return true, nil
 }
 
+// SaveVersions saves versions to the resource's version history as if they
+// had been found by a check. It returns false if the resource has not been
+// checked yet, in which case there is no version history to save them to.
+func (r *resource) SaveVersions(spanContext SpanContext, versions []atc.Version) (bool, error) {
+	if r.resourceConfigScopeID == 0 {
+		return false, nil
+	}
+
+	err := saveVersions(r.conn, r.resourceConfigScopeID, versions, spanContext)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *resource) SetResourceConfigScope(scope ResourceConfigScope) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
@@ -478,6 +478,30 @@ var _ = Describe("Resource", func() {
 			Expect(job.ScheduleRequestedTime()).Should(BeTemporally(">", requestedSchedule))
 			Expect(otherJob.ScheduleRequestedTime()).Should(Equal(otherRequestedSchedule))
 		})
+
+		Describe("SaveVersions", func() {
+			It("does not save versions before the scope is set", func() {
+				saved, err := resource.SaveVersions(nil, []atc.Version{{"ref": "abc"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved).To(BeFalse())
+			})
+
+			It("saves versions to the resource's scope", func() {
+				Expect(resource.SetResourceConfigScope(scope)).To(Succeed())
+
+				_, err := resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+
+				saved, err := resource.SaveVersions(nil, []atc.Version{{"ref": "abc"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(saved).To(BeTrue())
+
+				latest, found, err := scope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(latest.Version()).To(Equal(db.Version{"ref": "abc"}))
+			})
+		})
 	})
 
 	Describe("CreateBuild", func() {
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// schedulingDiagnostics turns the reasons the algorithm gave for failing to
+// resolve inputs into diagnostics which name the resources, jobs and versions
+// involved. Inputs which are resolved together share a reason, so each reason
+// is only rendered once.
+func schedulingDiagnostics(runner sq.BaseRunner, inputMapping InputMapping) ([]atc.SchedulingDiagnostic, error) {
+	seen := map[*ResolutionReason]bool{}
+	reasons := []*ResolutionReason{}
+	for _, inputResult := range inputMapping {
+		reason := inputResult.ResolveReason
+		if reason == nil || seen[reason] {
+			continue
+		}
+
+		seen[reason] = true
+		reasons = append(reasons, reason)
+	}
+
+	sort.Slice(reasons, func(i, j int) bool {
+		return reasons[i].Input < reasons[j].Input
+	})
+
+	diagnostics := []atc.SchedulingDiagnostic{}
+	for _, reason := range reasons {
+		diagnostic, err := schedulingDiagnostic(runner, reason)
+		if err != nil {
+			return nil, err
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics, nil
+}
+
+func schedulingDiagnostic(runner sq.BaseRunner, reason *ResolutionReason) (atc.SchedulingDiagnostic, error) {
+	resourceName, err := resourceNameByID(runner, reason.ResourceID)
+	if err != nil {
+		return atc.SchedulingDiagnostic{}, err
+	}
+
+	diagnostic := atc.SchedulingDiagnostic{
+		Input:    reason.Input,
+		Resource: resourceName,
+	}
+
+	if reason.PinnedVersion != nil {
+		diagnostic.Version = reason.PinnedVersion
+		diagnostic.Message = fmt.Sprintf("pinned version %s of %s not found", presentVersion(reason.PinnedVersion), resourceName)
+		return diagnostic, nil
+	}
+
+	if reason.PassedJobID == 0 {
+		diagnostic.Message = fmt.Sprintf("no versions of %s have been found", resourceName)
+		return diagnostic, nil
+	}
+
+	err = psql.Select("name").
+		From("jobs").
+		Where(sq.Eq{"id": reason.PassedJobID}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&diagnostic.PassedJob)
+	if err != nil {
+		return atc.SchedulingDiagnostic{}, err
+	}
+
+	if reason.Version != "" {
+		diagnostic.Version, err = resourceVersionByMD5(runner, reason.ResourceID, reason.Version)
+		if err != nil {
+			return atc.SchedulingDiagnostic{}, err
+		}
+
+		diagnostic.Message = fmt.Sprintf("version %s of %s has not passed job %s", presentVersion(diagnostic.Version), resourceName, diagnostic.PassedJob)
+	} else {
+		diagnostic.Message = fmt.Sprintf("no version of %s has passed job %s", resourceName, diagnostic.PassedJob)
+	}
+
+	with := []string{}
+	for _, constraint := range reason.Constraints {
+		constraintResource, err := resourceNameByID(runner, constraint.ResourceID)
+		if err != nil {
+			return atc.SchedulingDiagnostic{}, err
+		}
+
+		constraintVersion, err := resourceVersionByMD5(runner, constraint.ResourceID, constraint.Version)
+		if err != nil {
+			return atc.SchedulingDiagnostic{}, err
+		}
+
+		diagnostic.With = append(diagnostic.With, atc.SchedulingDiagnosticInput{
+			Input:    constraint.Input,
+			Resource: constraintResource,
+			Version:  constraintVersion,
+		})
+
+		with = append(with, fmt.Sprintf("version %s of %s", presentVersion(constraintVersion), constraintResource))
+	}
+
+	if len(with) != 0 {
+		diagnostic.Message += " together with " + strings.Join(with, ", ")
+	}
+
+	return diagnostic, nil
+}
+
+func resourceNameByID(runner sq.BaseRunner, resourceID int) (string, error) {
+	var name string
+	err := psql.Select("name").
+		From("resources").
+		Where(sq.Eq{"id": resourceID}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&name)
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// resourceVersionByMD5 looks up a version of a resource by the md5 that the
+// algorithm refers to it by.
+func resourceVersionByMD5(runner sq.BaseRunner, resourceID int, versionMD5 ResourceVersion) (atc.Version, error) {
+	var versionBlob string
+	err := psql.Select("v.version").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"r.id":          resourceID,
+			"v.version_md5": versionMD5,
+		}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&versionBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionBlob), &version)
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+func presentVersion(version atc.Version) string {
+	pairs := []string{}
+	for k, v := range version {
+		pairs = append(pairs, k+":"+v)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func scanSchedulingDiagnostics(diagnostics sql.NullString) ([]atc.SchedulingDiagnostic, error) {
+	if !diagnostics.Valid {
+		return nil, nil
+	}
+
+	var scanned []atc.SchedulingDiagnostic
+	err := json.Unmarshal([]byte(diagnostics.String), &scanned)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanned, nil
+}
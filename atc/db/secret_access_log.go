@@ -0,0 +1,92 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+//counterfeiter:generate . SecretAccessLog
+type SecretAccessLog interface {
+	Record(...creds.SecretAccess) error
+	RemoveExpiredAccesses(retention time.Duration) error
+}
+
+type secretAccessLog struct {
+	conn Conn
+}
+
+func NewSecretAccessLog(conn Conn) SecretAccessLog {
+	return &secretAccessLog{
+		conn: conn,
+	}
+}
+
+// Record inserts all of the accesses at once.
+func (log *secretAccessLog) Record(accesses ...creds.SecretAccess) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert("secret_accesses").
+		Columns(
+			"team_id",
+			"team_name",
+			"pipeline_id",
+			"pipeline_name",
+			"build_id",
+			"build_name",
+			"job_name",
+			"step_name",
+			"var_name",
+			"manager",
+			"path",
+			"accessed_at",
+		)
+
+	for _, access := range accesses {
+		accessedAt := access.AccessedAt
+		if accessedAt.IsZero() {
+			accessedAt = time.Now()
+		}
+
+		insert = insert.Values(
+			access.TeamID,
+			access.TeamName,
+			sql.NullInt64{Int64: int64(access.PipelineID), Valid: access.PipelineID != 0},
+			sql.NullString{String: access.PipelineName, Valid: access.PipelineName != ""},
+			access.BuildID,
+			access.BuildName,
+			sql.NullString{String: access.JobName, Valid: access.JobName != ""},
+			sql.NullString{String: access.StepName, Valid: access.StepName != ""},
+			access.VarName,
+			sql.NullString{String: access.Manager, Valid: access.Manager != ""},
+			access.Path,
+			accessedAt,
+		)
+	}
+
+	_, err := insert.
+		RunWith(log.conn).
+		Exec()
+
+	return err
+}
+
+// RemoveExpiredAccesses removes the accesses recorded longer ago than the
+// retention period. They are kept forever if it is zero.
+func (log *secretAccessLog) RemoveExpiredAccesses(retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	_, err := psql.Delete("secret_accesses").
+		Where(sq.Expr(fmt.Sprintf("accessed_at < NOW() - interval '%d seconds'", int(retention.Seconds())))).
+		RunWith(log.conn).
+		Exec()
+
+	return err
+}
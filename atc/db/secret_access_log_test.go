@@ -0,0 +1,118 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessLog", func() {
+	var accessLog db.SecretAccessLog
+
+	BeforeEach(func() {
+		accessLog = db.NewSecretAccessLog(dbConn)
+	})
+
+	countAccesses := func() int {
+		var count int
+		err := dbConn.QueryRow(`SELECT COUNT(*) FROM secret_accesses`).Scan(&count)
+		Expect(err).ToNot(HaveOccurred())
+		return count
+	}
+
+	It("records the access without any secret value", func() {
+		err := accessLog.Record(creds.SecretAccess{
+			TeamID:       defaultTeam.ID(),
+			TeamName:     defaultTeam.Name(),
+			PipelineID:   defaultPipeline.ID(),
+			PipelineName: defaultPipeline.Name(),
+			BuildID:      42,
+			BuildName:    "1",
+			JobName:      "some-job",
+			StepName:     "some-task",
+			VarName:      "some-var",
+			Manager:      "vault",
+			Path:         "/concourse/main/some-var",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		var (
+			teamName, stepName, varName, manager, path string
+			buildID                                    int
+		)
+		err = dbConn.QueryRow(`
+			SELECT team_name, build_id, step_name, var_name, manager, path
+			FROM secret_accesses
+		`).Scan(&teamName, &buildID, &stepName, &varName, &manager, &path)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(teamName).To(Equal(defaultTeam.Name()))
+		Expect(buildID).To(Equal(42))
+		Expect(stepName).To(Equal("some-task"))
+		Expect(varName).To(Equal("some-var"))
+		Expect(manager).To(Equal("vault"))
+		Expect(path).To(Equal("/concourse/main/some-var"))
+	})
+
+	It("records many accesses at once", func() {
+		err := accessLog.Record(
+			creds.SecretAccess{
+				TeamID:    defaultTeam.ID(),
+				TeamName:  defaultTeam.Name(),
+				BuildID:   42,
+				BuildName: "1",
+				VarName:   "some-var",
+				Path:      "some-var",
+			},
+			creds.SecretAccess{
+				TeamID:    defaultTeam.ID(),
+				TeamName:  defaultTeam.Name(),
+				BuildID:   42,
+				BuildName: "1",
+				VarName:   "other-var",
+				Path:      "other-var",
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(countAccesses()).To(Equal(2))
+	})
+
+	It("does nothing when there are no accesses", func() {
+		err := accessLog.Record()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(countAccesses()).To(Equal(0))
+	})
+
+	Describe("RemoveExpiredAccesses", func() {
+		BeforeEach(func() {
+			for _, accessedAt := range []time.Time{time.Now().Add(-48 * time.Hour), time.Now()} {
+				err := accessLog.Record(creds.SecretAccess{
+					TeamID:     defaultTeam.ID(),
+					TeamName:   defaultTeam.Name(),
+					BuildID:    42,
+					BuildName:  "1",
+					VarName:    "some-var",
+					Path:       "some-var",
+					AccessedAt: accessedAt,
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("removes the accesses older than the retention", func() {
+			err := accessLog.RemoveExpiredAccesses(24 * time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countAccesses()).To(Equal(1))
+		})
+
+		It("keeps all of them when there is no retention", func() {
+			err := accessLog.RemoveExpiredAccesses(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countAccesses()).To(Equal(2))
+		})
+	})
+})
@@ -6,6 +6,21 @@ import (
 	sq "github.com/Masterminds/squirrel"
 )
 
+// taskMemoPrefix starts the paths of the task caches that memoized task steps
+// keep, which are otherwise made up of the key of the run they were kept for.
+const taskMemoPrefix = "memoize:"
+
+// TaskMemoPath is the path of the task cache which records that a memoized
+// task step succeeded with the given key or, given the name of one of its
+// outputs, the path of the task cache which keeps that output.
+func TaskMemoPath(key string, output string) string {
+	if output == "" {
+		return taskMemoPrefix + key
+	}
+
+	return taskMemoPrefix + key + ":" + output
+}
+
 type usedTaskCache struct {
 	id       int
 	jobID    int
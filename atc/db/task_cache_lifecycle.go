@@ -40,8 +40,21 @@ func (f *taskCacheLifecycle) CleanUpInvalidTaskCaches() ([]int, error) {
 		return nil, err
 	}
 
+	// a memoized task step only keeps what it produced for the latest key
+	// that it ran with
+	supersededTaskMemos, _, err := psql.Select("tc.id").
+		From("task_caches tc").
+		Join("task_caches newer ON newer.job_id = tc.job_id AND newer.step_name = tc.step_name AND newer.id > tc.id").
+		Where(sq.Expr("tc.path LIKE '" + taskMemoPrefix + "%'")).
+		Where(sq.Expr("newer.path LIKE '" + taskMemoPrefix + "%'")).
+		Where(sq.Expr("split_part(newer.path, ':', 2) <> split_part(tc.path, ':', 2)")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := psql.Delete("task_caches").
-		Where("id IN (" + inactiveTaskCaches + ")").
+		Where("id IN (" + inactiveTaskCaches + ") OR id IN (" + supersededTaskMemos + ")").
 		Suffix("RETURNING id").
 		RunWith(f.conn).
 		Query()
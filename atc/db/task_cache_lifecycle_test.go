@@ -129,4 +129,38 @@ var _ = Describe("TaskCacheLifecycle", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(deletedCacheIDs).To(ConsistOf(taskCache.ID()))
 	})
+
+	It("cleans up what a memoized task step kept for keys it has since run with", func() {
+		scenario := dbtest.Setup(
+			builder.WithPipeline(atc.Config{
+				Jobs: []atc.JobConfig{
+					{Name: "some-job"},
+				},
+			}),
+		)
+
+		jobID := scenario.Job("some-job").ID()
+
+		oldOutput, err := taskCacheFactory.FindOrCreate(jobID, "some-step", db.TaskMemoPath("old-key", "some-output"))
+		Expect(err).ToNot(HaveOccurred())
+
+		oldMemo, err := taskCacheFactory.FindOrCreate(jobID, "some-step", db.TaskMemoPath("old-key", ""))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = taskCacheFactory.FindOrCreate(jobID, "some-step", db.TaskMemoPath("new-key", "some-output"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = taskCacheFactory.FindOrCreate(jobID, "some-step", db.TaskMemoPath("new-key", ""))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = taskCacheFactory.FindOrCreate(jobID, "some-step", "some-path")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = taskCacheFactory.FindOrCreate(jobID, "some-other-step", db.TaskMemoPath("other-key", ""))
+		Expect(err).ToNot(HaveOccurred())
+
+		deletedCacheIDs, err := taskCacheLifecycle.CleanUpInvalidTaskCaches()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deletedCacheIDs).To(ConsistOf(oldOutput.ID(), oldMemo.ID()))
+	})
 })
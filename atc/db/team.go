@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
@@ -36,6 +38,11 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	Notifications() atc.NotificationConfigs
+	CredentialManagers() []string
+	VarSources() atc.VarSourceConfigs
+	StepTemplates() atc.StepTemplates
+	PipelineSource() *atc.PipelineSourceConfig
 
 	Delete() error
 	Rename(string) error
@@ -46,6 +53,13 @@ type Team interface {
 		from ConfigVersion,
 		initiallyPaused bool,
 	) (Pipeline, bool, error)
+	SavePipelineAs(
+		pipelineRef atc.PipelineRef,
+		config atc.Config,
+		from ConfigVersion,
+		initiallyPaused bool,
+		author ConfigAuthor,
+	) (Pipeline, bool, error)
 	RenamePipeline(oldName string, newName string) (bool, error)
 
 	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
@@ -78,6 +92,16 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int, shouldBeValidBefore time.Time) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateNotifications(atc.NotificationConfigs) error
+	UpdateCredentialManagers([]string) error
+	UpdateVarSources(atc.VarSourceConfigs) error
+	UpdateStepTemplates(atc.StepTemplates) error
+	UpdatePipelineSource(*atc.PipelineSourceConfig) error
+
+	PipelineSourceStatus() (atc.PipelineSourceStatus, bool, error)
+	UpdatePipelineSourceStatus(atc.PipelineSourceStatus) error
+
+	NotificationDeliveries(limit int) ([]NotificationDelivery, error)
 }
 
 type team struct {
@@ -88,16 +112,36 @@ type team struct {
 	name  string
 	admin bool
 
-	auth atc.TeamAuth
+	auth               atc.TeamAuth
+	notifications      atc.NotificationConfigs
+	credentialManagers []string
+	varSources         atc.VarSourceConfigs
+	stepTemplates      atc.StepTemplates
+	pipelineSource     *atc.PipelineSourceConfig
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
-func (t *team) Auth() atc.TeamAuth { return t.auth }
+func (t *team) Auth() atc.TeamAuth                     { return t.auth }
+func (t *team) Notifications() atc.NotificationConfigs { return t.notifications }
+func (t *team) CredentialManagers() []string           { return t.credentialManagers }
+func (t *team) VarSources() atc.VarSourceConfigs       { return t.varSources }
+func (t *team) StepTemplates() atc.StepTemplates       { return t.stepTemplates }
+
+func (t *team) PipelineSource() *atc.PipelineSourceConfig { return t.pipelineSource }
 
 func (t *team) Delete() error {
+	// the logs of the team's pipelines are removed along with their events
+	// tables, which leaves those of its one-off builds
+	if store := t.conn.BuildLogStore(); store != nil {
+		err := store.DeleteAll(context.Background(), fmt.Sprintf("team_build_events_%d/", t.id))
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
 			"name": t.name,
@@ -413,6 +457,7 @@ func savePipeline(
 	teamID int,
 	jobID sql.NullInt64,
 	buildID sql.NullInt64,
+	author ConfigAuthor,
 ) (int, bool, error) {
 
 	var instanceVars sql.NullString
@@ -462,13 +507,20 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	templatesPayload, err := marshalStepTemplates(config.Templates)
+	if err != nil {
+		return 0, false, err
+	}
+
 	var pipelineID int
+	var version ConfigVersion
 	if !existingConfig {
 		values := map[string]interface{}{
 			"name":            pipelineRef.Name,
 			"groups":          groupsPayload,
 			"var_sources":     encryptedVarSourcesPayload,
 			"display":         displayPayload,
+			"templates":       templatesPayload,
 			"nonce":           nonce,
 			"version":         sq.Expr("nextval('config_version_seq')"),
 			"paused":          initiallyPaused,
@@ -501,9 +553,9 @@ func savePipeline(
 		}
 		err = psql.Insert("pipelines").
 			SetMap(values).
-			Suffix("RETURNING id").
+			Suffix("RETURNING id, version").
 			RunWith(tx).
-			QueryRow().Scan(&pipelineID)
+			QueryRow().Scan(&pipelineID, &version)
 		if err != nil {
 			return 0, false, err
 		}
@@ -514,6 +566,7 @@ func savePipeline(
 			Set("groups", groupsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("templates", templatesPayload).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -535,10 +588,10 @@ func savePipeline(
 			q = q.Where(sq.Or{sq.Lt{"parent_build_id": buildID}, sq.Eq{"parent_build_id": nil}})
 		}
 
-		err := q.Suffix("RETURNING id").
+		err := q.Suffix("RETURNING id, version").
 			RunWith(tx).
 			QueryRow().
-			Scan(&pipelineID)
+			Scan(&pipelineID, &version)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				var currentParentBuildID sql.NullInt64
@@ -566,6 +619,11 @@ func savePipeline(
 		}
 	}
 
+	err = saveConfigRevision(tx, pipelineID, version, config, author, buildID)
+	if err != nil {
+		return 0, false, err
+	}
+
 	err = updateResourcesName(tx, config.Resources, pipelineID)
 	if err != nil {
 		return 0, false, err
@@ -591,17 +649,22 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	scheduledJobs, matrixCells, err := expandMatrixJobs(config.Jobs)
+	if err != nil {
+		return 0, false, err
+	}
+
 	jobNameToID, err := saveJobsAndSerialGroups(tx, config.Jobs, config.Groups, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
+	err = removeUnusedWorkerTaskCaches(tx, pipelineID, scheduledJobs)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID)
+	err = insertJobPipes(tx, scheduledJobs, matrixCells, resourceNameToID, jobNameToID, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
@@ -619,6 +682,18 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
+) (Pipeline, bool, error) {
+	return t.SavePipelineAs(pipelineRef, config, from, initiallyPaused, ConfigAuthor{})
+}
+
+// SavePipelineAs saves the pipeline's config as SavePipeline does, recording
+// who it was saved by in its revision.
+func (t *team) SavePipelineAs(
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	from ConfigVersion,
+	initiallyPaused bool,
+	author ConfigAuthor,
 ) (Pipeline, bool, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -628,7 +703,7 @@ func (t *team) SavePipeline(
 	defer Rollback(tx)
 
 	nullID := sql.NullInt64{Valid: false}
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID, author)
 	if err != nil {
 		return nil, false, err
 	}
@@ -977,6 +1052,276 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+func (t *team) UpdateNotifications(notifications atc.NotificationConfigs) error {
+	encoded, nonce, err := encryptNotifications(t.conn.EncryptionStrategy(), notifications)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("notifications", encoded).
+		Set("notifications_nonce", nonce).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.notifications = notifications
+
+	return nil
+}
+
+func (t *team) UpdateCredentialManagers(credentialManagers []string) error {
+	_, err := psql.Update("teams").
+		Set("credential_managers", pq.Array(credentialManagers)).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.credentialManagers = credentialManagers
+
+	return nil
+}
+
+func (t *team) UpdateVarSources(varSources atc.VarSourceConfigs) error {
+	payload, nonce, err := encryptVarSources(t.conn.EncryptionStrategy(), varSources)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("var_sources", payload).
+		Set("var_sources_nonce", nonce).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.varSources = varSources
+
+	return nil
+}
+
+func (t *team) UpdateStepTemplates(templates atc.StepTemplates) error {
+	encoded, err := marshalStepTemplates(templates)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("step_templates", encoded).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.stepTemplates = templates
+
+	return nil
+}
+
+// UpdatePipelineSource sets the repository the team's pipelines are set from.
+// When it is removed, the pipelines which were set from it are left as they
+// are, and can then be set in any other way.
+func (t *team) UpdatePipelineSource(source *atc.PipelineSourceConfig) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	encoded := sql.NullString{}
+	if source != nil {
+		payload, err := json.Marshal(source)
+		if err != nil {
+			return err
+		}
+
+		encoded = sql.NullString{String: string(payload), Valid: true}
+	}
+
+	q := psql.Update("teams").
+		Set("pipeline_source", encoded).
+		Where(sq.Eq{"id": t.id})
+
+	if source == nil {
+		q = q.Set("pipeline_source_status", nil)
+	}
+
+	_, err = q.RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+
+	if source == nil {
+		_, err = psql.Update("pipelines").
+			Set("source_path", nil).
+			Where(sq.Eq{"team_id": t.id}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	t.pipelineSource = source
+
+	return nil
+}
+
+func (t *team) PipelineSourceStatus() (atc.PipelineSourceStatus, bool, error) {
+	var payload sql.NullString
+	err := psql.Select("pipeline_source_status").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		return atc.PipelineSourceStatus{}, false, err
+	}
+
+	if !payload.Valid {
+		return atc.PipelineSourceStatus{}, false, nil
+	}
+
+	var status atc.PipelineSourceStatus
+	err = json.Unmarshal([]byte(payload.String), &status)
+	if err != nil {
+		return atc.PipelineSourceStatus{}, false, err
+	}
+
+	return status, true, nil
+}
+
+func (t *team) UpdatePipelineSourceStatus(status atc.PipelineSourceStatus) error {
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("pipeline_source_status", string(payload)).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func marshalStepTemplates(templates atc.StepTemplates) (sql.NullString, error) {
+	if len(templates) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(templates)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(payload), Valid: true}, nil
+}
+
+// encryptVarSources encrypts the team's var sources, as their config may
+// contain credentials for the credential manager.
+func encryptVarSources(strategy encryption.Strategy, varSources atc.VarSourceConfigs) (sql.NullString, sql.NullString, error) {
+	if len(varSources) == 0 {
+		return sql.NullString{}, sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(varSources)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	encrypted, nonce, err := strategy.Encrypt(payload)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	encryptedNonce := sql.NullString{}
+	if nonce != nil {
+		encryptedNonce = sql.NullString{String: *nonce, Valid: true}
+	}
+
+	return sql.NullString{String: encrypted, Valid: true}, encryptedNonce, nil
+}
+
+func decryptVarSources(strategy encryption.Strategy, payload sql.NullString, nonce sql.NullString) (atc.VarSourceConfigs, error) {
+	if !payload.Valid {
+		return nil, nil
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := strategy.Decrypt(payload.String, noncense)
+	if err != nil {
+		return nil, err
+	}
+
+	var varSources atc.VarSourceConfigs
+	err = json.Unmarshal(decrypted, &varSources)
+	if err != nil {
+		return nil, err
+	}
+
+	return varSources, nil
+}
+
+// teamVarSources finds the var sources shared by all of a team's pipelines.
+func teamVarSources(conn Conn, teamID int) (atc.VarSourceConfigs, error) {
+	var payload, nonce sql.NullString
+	err := psql.Select("var_sources", "var_sources_nonce").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(conn).
+		QueryRow().
+		Scan(&payload, &nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptVarSources(conn.EncryptionStrategy(), payload, nonce)
+}
+
+// teamSecrets applies the team's order of credential managers to the global
+// secrets, if more than one credential manager is configured.
+func teamSecrets(runner sq.Runner, teamID int, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (creds.Secrets, error) {
+	if len(creds.Members(globalSecrets, "")) < 2 {
+		return globalSecrets, nil
+	}
+
+	var credentialManagers []string
+	err := psql.Select("credential_managers").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(runner).
+		QueryRow().
+		Scan(pq.Array(&credentialManagers))
+	if err != nil {
+		return nil, err
+	}
+
+	return varSourcePool.TeamSecrets(globalSecrets, credentialManagers), nil
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -1206,7 +1551,7 @@ func sortUpdateNames(updateNames []UpdateName) []UpdateName {
 	return updateNames
 }
 
-func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, error) {
+func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string, matrixParentID sql.NullInt64) (int, error) {
 	configPayload, err := json.Marshal(job)
 	if err != nil {
 		return 0, err
@@ -1218,11 +1563,32 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 		return 0, err
 	}
 
+	// Builds are only ever run for the cells of a matrix job, never for the
+	// matrix job itself.
+	disableManualTrigger := job.DisableManualTrigger || job.IsMatrix()
+
+	var schedule sql.NullString
+	var scheduleNextFire sql.NullTime
+	if job.Schedule != nil {
+		schedulePayload, err := json.Marshal(job.Schedule)
+		if err != nil {
+			return 0, err
+		}
+
+		nextFire, err := job.Schedule.Next(time.Now())
+		if err != nil {
+			return 0, err
+		}
+
+		schedule = sql.NullString{String: string(schedulePayload), Valid: true}
+		scheduleNextFire = sql.NullTime{Time: nextFire, Valid: !nextFire.IsZero()}
+	}
+
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags", "matrix_parent_id", "schedule", "schedule_next_fire").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), disableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups), matrixParentID, schedule, scheduleNextFire).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags, matrix_parent_id = EXCLUDED.matrix_parent_id, schedule = EXCLUDED.schedule, schedule_next_fire = CASE WHEN jobs.schedule IS NOT DISTINCT FROM EXCLUDED.schedule THEN jobs.schedule_next_fire ELSE EXCLUDED.schedule_next_fire END").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -1332,6 +1698,8 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		groups        sql.NullString
 		varSources    sql.NullString
 		display       sql.NullString
+		templates     sql.NullString
+		sourcePath    sql.NullString
 		nonce         sql.NullString
 		nonceStr      *string
 		lastUpdated   pq.NullTime
@@ -1341,7 +1709,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		pausedBy      sql.NullString
 		pausedAt      sql.NullTime
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt, &templates, &sourcePath)
 	if err != nil {
 		return err
 	}
@@ -1374,6 +1742,13 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.display = displayConfig
 	}
 
+	if templates.Valid {
+		err = json.Unmarshal([]byte(templates.String), &p.templates)
+		if err != nil {
+			return err
+		}
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -1399,6 +1774,8 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.pausedBy = pausedBy.String
 	}
 
+	p.sourcePath = sourcePath.String
+
 	if pausedAt.Valid {
 		p.pausedAt = pausedAt.Time
 	}
@@ -1729,34 +2106,93 @@ func saveJobsAndSerialGroups(tx Tx, jobs atc.JobConfigs, groups atc.GroupConfigs
 
 	jobNameToID := make(map[string]int)
 	for _, job := range jobs {
-		jobID, err := saveJob(tx, job, pipelineID, jobGroups[job.Name])
+		jobID, err := saveJob(tx, job, pipelineID, jobGroups[job.Name], sql.NullInt64{})
 		if err != nil {
 			return nil, err
 		}
 
 		jobNameToID[job.Name] = jobID
 
-		if len(job.SerialGroups) != 0 {
-			for _, sg := range job.SerialGroups {
-				err = registerSerialGroup(tx, sg, jobID)
+		if job.IsMatrix() {
+			cells, err := job.MatrixCells()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, cell := range cells {
+				cellID, err := saveJob(tx, cell, pipelineID, jobGroups[job.Name], sql.NullInt64{Int64: int64(jobID), Valid: true})
 				if err != nil {
 					return nil, err
 				}
-			}
-		} else {
-			if job.Serial || job.RawMaxInFlight > 0 {
-				err = registerSerialGroup(tx, job.Name, jobID)
+
+				jobNameToID[cell.Name] = cellID
+
+				err = registerSerialGroups(tx, cell, cellID)
 				if err != nil {
 					return nil, err
 				}
 			}
+
+			continue
+		}
+
+		err = registerSerialGroups(tx, job, jobID)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return jobNameToID, nil
 }
 
-func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
+func registerSerialGroups(tx Tx, job atc.JobConfig, jobID int) error {
+	if len(job.SerialGroups) != 0 {
+		for _, sg := range job.SerialGroups {
+			err := registerSerialGroup(tx, sg, jobID)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		if job.Serial || job.RawMaxInFlight > 0 {
+			err := registerSerialGroup(tx, job.Name, jobID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandMatrixJobs returns the jobs that builds are scheduled for, i.e. every
+// job that isn't a matrix job plus every cell of each matrix job, along with
+// the names of the cells of each matrix job.
+func expandMatrixJobs(jobs atc.JobConfigs) (atc.JobConfigs, map[string][]string, error) {
+	var scheduledJobs atc.JobConfigs
+	matrixCells := map[string][]string{}
+
+	for _, job := range jobs {
+		if !job.IsMatrix() {
+			scheduledJobs = append(scheduledJobs, job)
+			continue
+		}
+
+		cells, err := job.MatrixCells()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, cell := range cells {
+			scheduledJobs = append(scheduledJobs, cell)
+			matrixCells[job.Name] = append(matrixCells[job.Name], cell.Name)
+		}
+	}
+
+	return scheduledJobs, matrixCells, nil
+}
+
+func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, matrixCells map[string][]string, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
 	_, err := psql.Delete("job_inputs").
 		Where(sq.Expr(`job_id in (
         SELECT j.id
@@ -1784,7 +2220,7 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	for _, jobConfig := range jobConfigs {
 		err := jobConfig.StepConfig().Visit(atc.StepRecursor{
 			OnGet: func(step *atc.GetStep) error {
-				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
+				return insertJobInput(tx, step, jobConfig.Name, matrixCells, resourceNameToID, jobNameToID)
 			},
 			OnPut: func(step *atc.PutStep) error {
 				return insertJobOutput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
@@ -1798,9 +2234,20 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	return nil
 }
 
-func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
+func insertJobInput(tx Tx, step *atc.GetStep, jobName string, matrixCells map[string][]string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
 	if len(step.Passed) != 0 {
+		// A version has only passed a matrix job once it has passed every one
+		// of its cells.
+		var passedJobs []string
 		for _, passedJob := range step.Passed {
+			if cells, isMatrix := matrixCells[passedJob]; isMatrix {
+				passedJobs = append(passedJobs, cells...)
+			} else {
+				passedJobs = append(passedJobs, passedJob)
+			}
+		}
+
+		for _, passedJob := range passedJobs {
 			var version sql.NullString
 			if step.Version != nil {
 				versionJSON, err := step.Version.MarshalJSON()
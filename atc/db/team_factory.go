@@ -9,6 +9,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 //counterfeiter:generate . TeamFactory
@@ -51,10 +52,35 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	notifications, notificationsNonce, err := encryptNotifications(factory.conn.EncryptionStrategy(), t.Notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	varSources, varSourcesNonce, err := encryptVarSources(factory.conn.EncryptionStrategy(), t.VarSources)
+	if err != nil {
+		return nil, err
+	}
+
+	stepTemplates, err := marshalStepTemplates(t.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineSource := sql.NullString{}
+	if t.PipelineSource != nil {
+		payload, err := json.Marshal(t.PipelineSource)
+		if err != nil {
+			return nil, err
+		}
+
+		pipelineSource = sql.NullString{String: string(payload), Valid: true}
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, notifications, notifications_nonce, credential_managers, var_sources, var_sources_nonce, step_templates, pipeline_source").
+		Values(t.Name, auth, admin, notifications, notificationsNonce, pq.Array(t.CredentialManagers), varSources, varSourcesNonce, stepTemplates, pipelineSource).
+		Suffix("RETURNING id, name, admin, auth, notifications, notifications_nonce, credential_managers, var_sources, var_sources_nonce, step_templates, pipeline_source").
 		RunWith(tx).
 		QueryRow()
 
@@ -90,7 +116,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, notifications, notifications_nonce, credential_managers, var_sources, var_sources_nonce, step_templates, pipeline_source").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +135,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, notifications, notifications_nonce, credential_managers, var_sources, var_sources_nonce, step_templates, pipeline_source").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -175,14 +201,24 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, notifications, notificationsNonce, varSources, varSourcesNonce, stepTemplates, pipelineSource sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&notifications,
+		&notificationsNonce,
+		pq.Array(&t.credentialManagers),
+		&varSources,
+		&varSourcesNonce,
+		&stepTemplates,
+		&pipelineSource,
 	)
+	if err != nil {
+		return err
+	}
 
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
@@ -191,5 +227,29 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
-	return err
+	t.notifications, err = decryptNotifications(factory.conn.EncryptionStrategy(), notifications, notificationsNonce)
+	if err != nil {
+		return err
+	}
+
+	t.varSources, err = decryptVarSources(factory.conn.EncryptionStrategy(), varSources, varSourcesNonce)
+	if err != nil {
+		return err
+	}
+
+	if stepTemplates.Valid {
+		err = json.Unmarshal([]byte(stepTemplates.String), &t.stepTemplates)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pipelineSource.Valid {
+		err = json.Unmarshal([]byte(pipelineSource.String), &t.pipelineSource)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo/v2"
@@ -63,6 +64,34 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(exists).To(BeFalse())
 		})
+
+		Context("when build logs are kept in a store", func() {
+			var (
+				fakeStore   *dbfakes.FakeBuildLogStore
+				storeTeamID int
+			)
+
+			BeforeEach(func() {
+				fakeStore = new(dbfakes.FakeBuildLogStore)
+
+				storeTeamFactory := db.NewTeamFactory(db.WithBuildLogStore(dbConn, fakeStore), lockFactory)
+
+				storeTeam, err := storeTeamFactory.CreateTeam(atc.Team{Name: "some-store-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				storeTeamID = storeTeam.ID()
+
+				err = storeTeam.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("deletes the logs of the team's one-off builds", func() {
+				Expect(fakeStore.DeleteAllCallCount()).To(Equal(1))
+
+				_, prefix := fakeStore.DeleteAllArgsForCall(0)
+				Expect(prefix).To(Equal(fmt.Sprintf("team_build_events_%d/", storeTeamID)))
+			})
+		})
 	})
 
 	Describe("Rename", func() {
@@ -1096,6 +1125,202 @@ var _ = Describe("Team", func() {
 				})
 			})
 		})
+
+		Describe("UpdateNotifications", func() {
+			var notifications atc.NotificationConfigs
+
+			BeforeEach(func() {
+				notifications = atc.NotificationConfigs{
+					{Name: "chat", URL: "https://chat.example.com/hook", Secret: "some-secret"},
+				}
+			})
+
+			It("saves the notifications to the team", func() {
+				err := team.UpdateNotifications(notifications)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Notifications()).To(Equal(notifications))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.Notifications()).To(Equal(notifications))
+			})
+
+			It("clears the notifications", func() {
+				err := team.UpdateNotifications(notifications)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateNotifications(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				reloaded, _, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded.Notifications()).To(BeEmpty())
+			})
+		})
+
+		Describe("UpdateVarSources", func() {
+			varSources := atc.VarSourceConfigs{
+				{
+					Name: "vault",
+					Type: "vault",
+					Config: map[string]interface{}{
+						"url":          "https://vault.example.com",
+						"client_token": "some-token",
+					},
+				},
+			}
+
+			It("saves the var sources to the team", func() {
+				err := team.UpdateVarSources(varSources)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.VarSources()).To(Equal(varSources))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.VarSources()).To(Equal(varSources))
+			})
+
+			It("removes them", func() {
+				Expect(team.UpdateVarSources(varSources)).To(Succeed())
+				Expect(team.UpdateVarSources(nil)).To(Succeed())
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.VarSources()).To(BeEmpty())
+			})
+		})
+
+		Describe("UpdateStepTemplates", func() {
+			templates := atc.StepTemplates{
+				{
+					Name:   "notify",
+					Params: map[string]interface{}{"channel": "#builds"},
+					Steps: []atc.Step{
+						{Config: &atc.PutStep{Name: "slack"}},
+					},
+				},
+			}
+
+			It("saves the templates to the team", func() {
+				err := team.UpdateStepTemplates(templates)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.StepTemplates()).To(Equal(templates))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.StepTemplates()).To(Equal(templates))
+			})
+
+			It("removes them", func() {
+				Expect(team.UpdateStepTemplates(templates)).To(Succeed())
+				Expect(team.UpdateStepTemplates(nil)).To(Succeed())
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.StepTemplates()).To(BeEmpty())
+			})
+		})
+
+		Describe("UpdatePipelineSource", func() {
+			source := &atc.PipelineSourceConfig{
+				URI:    "https://example.com/pipelines.git",
+				Branch: "main",
+				Dir:    "pipelines",
+			}
+
+			It("saves the pipeline source to the team", func() {
+				err := team.UpdatePipelineSource(source)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.PipelineSource()).To(Equal(source))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.PipelineSource()).To(Equal(source))
+			})
+
+			Context("when it is removed", func() {
+				var pipeline db.Pipeline
+
+				BeforeEach(func() {
+					Expect(team.UpdatePipelineSource(source)).To(Succeed())
+					Expect(team.UpdatePipelineSourceStatus(atc.PipelineSourceStatus{Source: *source, Commit: "abc123"})).To(Succeed())
+
+					var err error
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "managed"}, atc.Config{}, db.ConfigVersion(0), false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pipeline.SetSourcePath("pipelines/managed.yml")).To(Succeed())
+
+					Expect(team.UpdatePipelineSource(nil)).To(Succeed())
+				})
+
+				It("removes it from the team", func() {
+					reloaded, found, err := teamFactory.FindTeam(team.Name())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(reloaded.PipelineSource()).To(BeNil())
+				})
+
+				It("removes the status of the latest sync", func() {
+					_, found, err := team.PipelineSourceStatus()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+
+				It("stops managing the pipelines set from it", func() {
+					_, err := pipeline.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pipeline.SourcePath()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("UpdatePipelineSourceStatus", func() {
+			It("saves the status of the latest sync", func() {
+				_, found, err := team.PipelineSourceStatus()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				status := atc.PipelineSourceStatus{
+					Source:   atc.PipelineSourceConfig{URI: "https://example.com/pipelines.git"},
+					Commit:   "abc123",
+					SyncedAt: 1000,
+					Pipelines: []atc.ManagedPipelineStatus{
+						{Name: "some-pipeline", Path: "some-pipeline.yml"},
+					},
+				}
+
+				Expect(team.UpdatePipelineSourceStatus(status)).To(Succeed())
+
+				saved, found, err := team.PipelineSourceStatus()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(saved).To(Equal(status))
+			})
+		})
+
+		Describe("UpdateCredentialManagers", func() {
+			It("saves the order of credential managers to the team", func() {
+				err := team.UpdateCredentialManagers([]string{"vault", "credhub"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.CredentialManagers()).To(Equal([]string{"vault", "credhub"}))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.CredentialManagers()).To(Equal([]string{"vault", "credhub"}))
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -2445,6 +2670,87 @@ var _ = Describe("Team", func() {
 			Expect(job.Public()).To(BeFalse())
 		})
 
+		Context("when a job has a matrix", func() {
+			BeforeEach(func() {
+				config.Jobs[1].Matrix = []atc.MatrixVarConfig{
+					{Var: "os", Values: []interface{}{"linux", "darwin"}},
+				}
+			})
+
+			It("creates a job for each cell of the matrix", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, name := range []string{"job-1-linux", "job-1-darwin"} {
+					cell, found, err := savedPipeline.Job(name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(cell.Config()).To(Equal(atc.JobConfig{Name: name}))
+				}
+			})
+
+			It("does not allow the matrix job to be manually triggered", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := savedPipeline.Job("job-1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(job.DisableManualTrigger()).To(BeTrue())
+			})
+
+			It("leaves the cells out of the pipeline config", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				savedConfig, err := savedPipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(savedConfig.Jobs).To(Equal(config.Jobs))
+			})
+
+			It("requires inputs to have passed every cell", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := savedPipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				inputs, err := job.AlgorithmInputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+
+				var passedIDs []int
+				for id := range inputs[0].Passed {
+					passedIDs = append(passedIDs, id)
+				}
+
+				var expectedIDs []int
+				for _, name := range []string{"job-1-linux", "job-1-darwin", "job-2"} {
+					passedJob, found, err := savedPipeline.Job(name)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					expectedIDs = append(expectedIDs, passedJob.ID())
+				}
+
+				Expect(passedIDs).To(ConsistOf(expectedIDs))
+			})
+
+			It("marks cells inactive when they are no longer in the matrix", func() {
+				savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Jobs[1].Matrix[0].Values = []interface{}{"linux"}
+
+				savedPipeline, _, err = team.SavePipeline(pipelineRef, config, savedPipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, found, err := savedPipeline.Job("job-1-darwin")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		It("marks job inactive when it is no longer in pipeline", func() {
 			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
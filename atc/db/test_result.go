@@ -0,0 +1,214 @@
+package db
+
+import (
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// postgres limits the number of parameters in a single statement, so test
+// results are inserted in batches
+const testResultsBatchSize = 1000
+
+// SaveTestResults stores the results read from the test reports of the given
+// step, replacing any saved by an earlier attempt of the step, and updates the
+// build's test summary to match.
+func (b *build) SaveTestResults(step string, results []atc.TestResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	// steps running in parallel each recount the summary, so they take turns
+	// to keep one from missing the other's results
+	_, err = tx.Exec(`SELECT 1 FROM builds WHERE id = $1 FOR NO KEY UPDATE`, b.id)
+	if err != nil {
+		return err
+	}
+
+	var jobID any
+	if b.jobID != 0 {
+		jobID = b.jobID
+	}
+
+	_, err = psql.Delete("build_test_results").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"step":     step,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(results); start += testResultsBatchSize {
+		end := min(start+testResultsBatchSize, len(results))
+
+		insert := psql.Insert("build_test_results").
+			Columns("build_id", "job_id", "step", "suite", "name", "status", "duration", "message")
+
+		for _, result := range results[start:end] {
+			insert = insert.Values(b.id, jobID, step, result.Suite, result.Name, string(result.Status), result.Duration, result.Message)
+		}
+
+		_, err = insert.RunWith(tx).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_test_summaries (build_id, total, passed, failed, skipped)
+		SELECT build_id,
+			count(*),
+			count(*) FILTER (WHERE status = $2),
+			count(*) FILTER (WHERE status = $3),
+			count(*) FILTER (WHERE status = $4)
+		FROM build_test_results
+		WHERE build_id = $1
+		GROUP BY build_id
+		ON CONFLICT (build_id) DO UPDATE SET
+			total = EXCLUDED.total,
+			passed = EXCLUDED.passed,
+			failed = EXCLUDED.failed,
+			skipped = EXCLUDED.skipped
+	`, b.id, string(atc.TestStatusPassed), string(atc.TestStatusFailed), string(atc.TestStatusSkipped))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) TestResults() ([]atc.TestResult, error) {
+	rows, err := psql.Select("step", "suite", "name", "status", "duration", "message").
+		From("build_test_results").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	results := []atc.TestResult{}
+	for rows.Next() {
+		var (
+			result atc.TestResult
+			status string
+		)
+
+		err = rows.Scan(&result.Step, &result.Suite, &result.Name, &status, &result.Duration, &result.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Status = atc.TestStatus(status)
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// TestHistory returns how each test case has fared across the job's most
+// recent builds that reported test results. Flaky tests come first, followed
+// by the tests that failed most often.
+func (j *job) TestHistory(builds int) ([]atc.TestHistory, error) {
+	rows, err := psql.Select("r.suite", "r.name", "r.status", "b.name").
+		From("build_test_results r").
+		Join("builds b ON b.id = r.build_id").
+		Where(sq.Eq{"r.job_id": j.id}).
+		Where(sq.Expr(`r.build_id IN (
+			SELECT DISTINCT build_id
+			FROM build_test_results
+			WHERE job_id = ?
+			ORDER BY build_id DESC
+			LIMIT ?
+		)`, j.id, builds)).
+		OrderBy("r.build_id ASC", "r.id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	type testKey struct {
+		suite, name string
+	}
+
+	histories := map[testKey]*atc.TestHistory{}
+	for rows.Next() {
+		var (
+			key       testKey
+			status    string
+			buildName string
+		)
+
+		err = rows.Scan(&key.suite, &key.name, &status, &buildName)
+		if err != nil {
+			return nil, err
+		}
+
+		history, found := histories[key]
+		if !found {
+			history = &atc.TestHistory{
+				Suite: key.suite,
+				Name:  key.name,
+			}
+
+			histories[key] = history
+		}
+
+		history.Runs++
+		history.LastStatus = atc.TestStatus(status)
+		history.LastBuild = buildName
+
+		switch history.LastStatus {
+		case atc.TestStatusPassed:
+			history.Passed++
+		case atc.TestStatusFailed:
+			history.Failed++
+		case atc.TestStatusSkipped:
+			history.Skipped++
+		}
+	}
+
+	result := []atc.TestHistory{}
+	for _, history := range histories {
+		history.Flaky = history.Passed > 0 && history.Failed > 0
+		result = append(result, *history)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+
+		if a.Flaky != b.Flaky {
+			return a.Flaky
+		}
+
+		if a.Failed != b.Failed {
+			return a.Failed > b.Failed
+		}
+
+		if a.Suite != b.Suite {
+			return a.Suite < b.Suite
+		}
+
+		return a.Name < b.Name
+	})
+
+	return result, nil
+}
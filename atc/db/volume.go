@@ -141,7 +141,7 @@ func (volume *creatingVolume) Failed() (FailedVolume, error) {
 }
 
 func (volume *creatingVolume) InitializeArtifact() (WorkerArtifact, error) {
-	return initializeArtifact(volume.conn, volume.id, "", 0)
+	return initializeArtifact(volume.conn, volume.id, "", 0, false)
 }
 
 //counterfeiter:generate . CreatedVolume
@@ -162,6 +162,7 @@ type CreatedVolume interface {
 	InitializeStreamedResourceCache(ResourceCache, int) (*UsedWorkerResourceCache, error)
 	GetResourceCacheID() int
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
+	PublishArtifact(name string, buildID int) (WorkerArtifact, error)
 	InitializeTaskCache(jobID int, stepName string, path string) error
 
 	ContainerHandle() string
@@ -499,10 +500,16 @@ func (volume *createdVolume) GetResourceCacheID() int {
 }
 
 func (volume *createdVolume) InitializeArtifact(name string, buildID int) (WorkerArtifact, error) {
-	return initializeArtifact(volume.conn, volume.id, name, buildID)
+	return initializeArtifact(volume.conn, volume.id, name, buildID, false)
 }
 
-func initializeArtifact(conn Conn, volumeID int, name string, buildID int) (WorkerArtifact, error) {
+// PublishArtifact registers the volume as an artifact of the build which is
+// kept around after the build has finished, so that it can be downloaded.
+func (volume *createdVolume) PublishArtifact(name string, buildID int) (WorkerArtifact, error) {
+	return initializeArtifact(volume.conn, volume.id, name, buildID, true)
+}
+
+func initializeArtifact(conn Conn, volumeID int, name string, buildID int, published bool) (WorkerArtifact, error) {
 	tx, err := conn.Begin()
 	if err != nil {
 		return nil, err
@@ -511,8 +518,9 @@ func initializeArtifact(conn Conn, volumeID int, name string, buildID int) (Work
 	defer Rollback(tx)
 
 	atcWorkerArtifact := atc.WorkerArtifact{
-		Name:    name,
-		BuildID: buildID,
+		Name:      name,
+		BuildID:   buildID,
+		Published: published,
 	}
 
 	workerArtifact, err := saveWorkerArtifact(tx, conn, atcWorkerArtifact)
@@ -18,6 +18,7 @@ type WorkerArtifact interface {
 	Name() string
 	BuildID() int
 	CreatedAt() time.Time
+	Published() bool
 	Volume(teamID int) (CreatedVolume, bool, error)
 }
 
@@ -28,12 +29,14 @@ type artifact struct {
 	name      string
 	buildID   int
 	createdAt time.Time
+	published bool
 }
 
 func (a *artifact) ID() int              { return a.id }
 func (a *artifact) Name() string         { return a.name }
 func (a *artifact) BuildID() int         { return a.buildID }
 func (a *artifact) CreatedAt() time.Time { return a.createdAt }
+func (a *artifact) Published() bool      { return a.published }
 
 func (a *artifact) Volume(teamID int) (CreatedVolume, bool, error) {
 	where := map[string]interface{}{
@@ -65,6 +68,10 @@ func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (Worke
 		values["build_id"] = atcArtifact.BuildID
 	}
 
+	if atcArtifact.Published {
+		values["published"] = true
+	}
+
 	err := psql.Insert("worker_artifacts").
 		SetMap(values).
 		Suffix("RETURNING id").
@@ -97,14 +104,14 @@ func getWorkerArtifact(tx Tx, conn Conn, id int) (WorkerArtifact, bool, error) {
 
 	artifact := &artifact{conn: conn}
 
-	err := psql.Select("id", "created_at", "name", "build_id").
+	err := psql.Select("id", "created_at", "name", "build_id", "published").
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"id": id,
 		}).
 		RunWith(tx).
 		QueryRow().
-		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID)
+		Scan(&artifact.id, &createdAtTime, &artifact.name, &buildID, &artifact.published)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -1,12 +1,15 @@
 package db
 
 import (
+	"fmt"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 )
 
 //counterfeiter:generate . WorkerArtifactLifecycle
 type WorkerArtifactLifecycle interface {
-	RemoveExpiredArtifacts() error
+	RemoveExpiredArtifacts(publishedRetention time.Duration) error
 }
 
 type artifactLifecycle struct {
@@ -19,10 +22,32 @@ func NewArtifactLifecycle(conn Conn) *artifactLifecycle {
 	}
 }
 
-func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts() error {
+// RemoveExpiredArtifacts removes artifacts that are more than 12 hours old,
+// unless they were published by a build. Published artifacts are kept for the
+// given retention period (forever if it is zero), or until their build is
+// deleted.
+func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts(publishedRetention time.Duration) error {
+	expiredPublished := sq.Or{
+		sq.Eq{"build_id": nil},
+	}
+
+	if publishedRetention > 0 {
+		expiredPublished = append(expiredPublished,
+			sq.Expr(fmt.Sprintf("created_at < NOW() - interval '%d seconds'", int(publishedRetention.Seconds()))),
+		)
+	}
 
 	_, err := psql.Delete("worker_artifacts").
-		Where(sq.Expr("created_at < NOW() - interval '12 hours'")).
+		Where(sq.Or{
+			sq.And{
+				sq.Eq{"published": false},
+				sq.Expr("created_at < NOW() - interval '12 hours'"),
+			},
+			sq.And{
+				sq.Eq{"published": true},
+				expiredPublished,
+			},
+		}).
 		RunWith(lifecycle.conn).
 		Exec()
 
@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -8,14 +10,16 @@ import (
 
 var _ = Describe("WorkerArtifactLifecycle", func() {
 	var workerArtifactLifecycle db.WorkerArtifactLifecycle
+	var publishedRetention time.Duration
 
 	BeforeEach(func() {
 		workerArtifactLifecycle = db.NewArtifactLifecycle(dbConn)
+		publishedRetention = 48 * time.Hour
 	})
 
 	Describe("RemoveExpiredArtifacts", func() {
 		JustBeforeEach(func() {
-			err := workerArtifactLifecycle.RemoveExpiredArtifacts()
+			err := workerArtifactLifecycle.RemoveExpiredArtifacts(publishedRetention)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -51,5 +55,51 @@ var _ = Describe("WorkerArtifactLifecycle", func() {
 				Expect(count).To(Equal(1))
 			})
 		})
+
+		Context("when artifacts are published", func() {
+			var buildID int
+
+			BeforeEach(func() {
+				build, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+				buildID = build.ID()
+
+				_, err = dbConn.Exec("INSERT INTO worker_artifacts(name, build_id, published, created_at) VALUES('kept', $1, true, NOW() - '13 hours'::interval)", buildID)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("INSERT INTO worker_artifacts(name, build_id, published, created_at) VALUES('expired', $1, true, NOW() - '49 hours'::interval)", buildID)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec("INSERT INTO worker_artifacts(name, published, created_at) VALUES('orphaned', true, NOW())")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps them for the retention period while their build exists", func() {
+				rows, err := dbConn.Query("SELECT name FROM worker_artifacts")
+				Expect(err).ToNot(HaveOccurred())
+
+				var names []string
+				for rows.Next() {
+					var name string
+					Expect(rows.Scan(&name)).To(Succeed())
+					names = append(names, name)
+				}
+
+				Expect(names).To(ConsistOf("kept"))
+			})
+
+			Context("when the retention period is zero", func() {
+				BeforeEach(func() {
+					publishedRetention = 0
+				})
+
+				It("keeps them until their build is deleted", func() {
+					var count int
+					err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(count).To(Equal(2))
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+func NewApprovalDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+	policyChecker policy.Checker,
+) *approvalDelegate {
+	return &approvalDelegate{
+		buildStepDelegate{
+			build:         build,
+			planID:        planID,
+			clock:         clock,
+			state:         state,
+			stdout:        nil,
+			stderr:        nil,
+			policyChecker: policyChecker,
+		},
+	}
+}
+
+type approvalDelegate struct {
+	buildStepDelegate
+}
+
+func (delegate *approvalDelegate) WaitForApproval(ctx context.Context, logger lager.Logger, name string, approvers []string) (db.BuildApproval, error) {
+	err := delegate.build.RequestApproval(delegate.planID, name, approvers)
+	if err != nil {
+		return db.BuildApproval{}, fmt.Errorf("request approval: %w", err)
+	}
+
+	notifier, err := delegate.build.ApprovalNotifier(delegate.planID)
+	if err != nil {
+		return db.BuildApproval{}, fmt.Errorf("watch approval: %w", err)
+	}
+
+	defer notifier.Close()
+
+	err = delegate.build.SaveEvent(event.WaitingForApproval{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:      delegate.clock.Now().Unix(),
+		Approvers: approvers,
+	})
+	if err != nil {
+		return db.BuildApproval{}, fmt.Errorf("save waiting for approval event: %w", err)
+	}
+
+	logger.Info("waiting-for-approval")
+
+	for {
+		approval, found, err := delegate.build.Approval(delegate.planID)
+		if err != nil {
+			return db.BuildApproval{}, fmt.Errorf("get approval: %w", err)
+		}
+
+		if !found {
+			return db.BuildApproval{}, errors.New("approval disappeared")
+		}
+
+		if approval.Decided() {
+			err = delegate.build.SaveEvent(event.ApprovalDecided{
+				Origin: event.Origin{
+					ID: event.OriginID(delegate.planID),
+				},
+				Time:      approval.DecidedAt.Unix(),
+				Approved:  *approval.Approved,
+				DecidedBy: approval.DecidedBy,
+			})
+			if err != nil {
+				return db.BuildApproval{}, fmt.Errorf("save approval decided event: %w", err)
+			}
+
+			logger.Info("approval-decided", lager.Data{
+				"approved":   *approval.Approved,
+				"decided-by": approval.DecidedBy,
+			})
+
+			return approval, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return db.BuildApproval{}, ctx.Err()
+		case <-notifier.Notify():
+		}
+	}
+}
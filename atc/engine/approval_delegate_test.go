@@ -0,0 +1,168 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("ApprovalDelegate", func() {
+	var (
+		logger       *lagertest.TestLogger
+		fakeBuild    *dbfakes.FakeBuild
+		fakeClock    *fakeclock.FakeClock
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		now       = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		decidedAt = time.Date(1991, 6, 3, 6, 0, 0, 0, time.UTC)
+		delegate  exec.ApprovalDelegate
+
+		approval db.BuildApproval
+		waitErr  error
+	)
+
+	pending := db.BuildApproval{PlanID: "some-plan-id", Name: "qa"}
+
+	decided := func(approved bool) db.BuildApproval {
+		return db.BuildApproval{
+			PlanID:    "some-plan-id",
+			Name:      "qa",
+			Approved:  &approved,
+			DecidedBy: "some-user",
+			DecidedAt: decidedAt,
+		}
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+		fakeBuild.ApprovalNotifierReturns(fakeNotifier, nil)
+
+		state := exec.NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		delegate = engine.NewApprovalDelegate(fakeBuild, "some-plan-id", state, fakeClock, new(policyfakes.FakeChecker))
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		approval, waitErr = delegate.WaitForApproval(ctx, logger, "qa", []string{"owner"})
+	})
+
+	Context("when the approval is decided straight away", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturns(decided(true), true, nil)
+		})
+
+		It("requests the approval", func() {
+			Expect(fakeBuild.RequestApprovalCallCount()).To(Equal(1))
+			planID, name, approvers := fakeBuild.RequestApprovalArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(name).To(Equal("qa"))
+			Expect(approvers).To(Equal([]string{"owner"}))
+		})
+
+		It("returns the decision", func() {
+			Expect(waitErr).ToNot(HaveOccurred())
+			Expect(approval).To(Equal(decided(true)))
+		})
+
+		It("saves events for the request and the decision", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitingForApproval{
+				Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+				Time:      now.Unix(),
+				Approvers: []string{"owner"},
+			}))
+			Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.ApprovalDecided{
+				Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+				Time:      decidedAt.Unix(),
+				Approved:  true,
+				DecidedBy: "some-user",
+			}))
+		})
+
+		It("stops watching for the decision", func() {
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the approval is decided later on", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturnsOnCall(0, pending, true, nil)
+			fakeBuild.ApprovalReturnsOnCall(1, decided(false), true, nil)
+			notify <- struct{}{}
+		})
+
+		It("waits for the decision", func() {
+			Expect(waitErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.ApprovalCallCount()).To(Equal(2))
+			Expect(approval).To(Equal(decided(false)))
+		})
+	})
+
+	Context("when the context is cancelled while waiting", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturns(pending, true, nil)
+			cancel()
+		})
+
+		It("returns the context's error", func() {
+			Expect(waitErr).To(Equal(context.Canceled))
+		})
+
+		It("does not save a decision", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when requesting the approval fails", func() {
+		BeforeEach(func() {
+			fakeBuild.RequestApprovalReturns(errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(waitErr).To(MatchError(ContainSubstring("nope")))
+			Expect(fakeBuild.ApprovalNotifierCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the approval disappears", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturns(db.BuildApproval{}, false, nil)
+		})
+
+		It("errors", func() {
+			Expect(waitErr).To(MatchError("approval disappeared"))
+		})
+	})
+})
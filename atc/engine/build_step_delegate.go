@@ -262,6 +262,21 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *buildStepDelegate) Skipped(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.Skipped{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+		return
+	}
+
+	logger.Info("skipped")
+}
+
 func (delegate *buildStepDelegate) FetchImage(
 	ctx context.Context,
 	getPlan atc.Plan,
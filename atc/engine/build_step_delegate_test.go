@@ -783,6 +783,43 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("Skipped", func() {
+		JustBeforeEach(func() {
+			delegate.Skipped(logger)
+		})
+
+		Context("when saving the event succeeds", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(nil)
+			})
+
+			It("saves it with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Skipped{
+					Time: now.Unix(),
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(disaster)
+			})
+
+			It("logs an error", func() {
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(1))
+				Expect(logs[0].Message).To(Equal("test.failed-to-save-skipped-event"))
+				Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+			})
+		})
+	})
+
 	Describe("No line buffer without secrets redaction", func() {
 		var runState exec.RunState
 
@@ -24,6 +24,8 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	SetVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ApprovalStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -94,6 +96,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildDoStep(build, plan)
 	}
 
+	if plan.If != nil {
+		return factory.buildIfStep(build, plan)
+	}
+
 	if plan.Timeout != nil {
 		return factory.buildTimeoutStep(build, plan)
 	}
@@ -138,6 +144,14 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildLoadVarStep(build, plan)
 	}
 
+	if plan.SetVar != nil {
+		return factory.buildSetVarStep(build, plan)
+	}
+
+	if plan.Approval != nil {
+		return factory.buildApprovalStep(build, plan)
+	}
+
 	if plan.Check != nil {
 		return factory.buildCheckStep(build, plan)
 	}
@@ -214,6 +228,70 @@ func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) e
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
+func (factory *stepperFactory) buildIfStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.If.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+
+	var skippedDelegateFactories []exec.BuildStepDelegateFactory
+	for _, skippedPlan := range skippableSteps(innerPlan) {
+		skippedDelegateFactories = append(skippedDelegateFactories, factory.buildDelegateFactory(build, skippedPlan))
+	}
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	ifStep := exec.If(
+		plan.If.Condition,
+		step,
+		skippedDelegateFactories,
+		stepMetadata,
+	)
+
+	return exec.LogError(ifStep, factory.buildDelegateFactory(build, innerPlan))
+}
+
+// skippableSteps returns the steps shown in the build for the given plan, so
+// that they can be marked as skipped. Image checks and gets are left out, as
+// they only show up for steps that run.
+func skippableSteps(plan atc.Plan) []atc.Plan {
+	images := map[atc.PlanID]bool{}
+	plan.Each(func(p *atc.Plan) {
+		markImage := func(image *atc.Plan) {
+			images[image.ID] = true
+		}
+
+		if p.Get != nil {
+			p.Get.TypeImage.EachPlan(markImage)
+		}
+
+		if p.Put != nil {
+			p.Put.TypeImage.EachPlan(markImage)
+		}
+
+		if p.Check != nil {
+			p.Check.TypeImage.EachPlan(markImage)
+		}
+	})
+
+	var steps []atc.Plan
+	plan.Each(func(p *atc.Plan) {
+		if images[p.ID] {
+			return
+		}
+
+		if p.Get != nil || p.Put != nil || p.Check != nil || p.Task != nil || p.Run != nil ||
+			p.SetPipeline != nil || p.LoadVar != nil || p.SetVar != nil || p.Approval != nil || p.Across != nil {
+			steps = append(steps, *p)
+		}
+	})
+
+	return steps
+}
+
 func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -417,6 +495,36 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	)
 }
 
+func (factory *stepperFactory) buildSetVarStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.SetVarStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
+func (factory *stepperFactory) buildApprovalStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+		false,
+	)
+
+	return factory.coreFactory.ApprovalStep(
+		plan,
+		stepMetadata,
+		factory.buildDelegateFactory(build, plan),
+	)
+}
+
 func (factory *stepperFactory) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
 	return factory.coreFactory.ArtifactInputStep(
 		plan,
@@ -598,6 +598,21 @@ var _ = Describe("Builder", func() {
 						})
 					})
 
+					Context("that contains a set_var step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.SetVarPlan{
+								Name: "some-var",
+								File: "some-input/token",
+							})
+						})
+
+						It("constructs set_var correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.SetVarStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						})
+					})
+
 					Context("that contains a check step", func() {
 						BeforeEach(func() {
 							expectedPlan = planFactory.NewPlan(atc.CheckPlan{
@@ -43,6 +43,14 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 	return NewBuildStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
 }
 
+func (delegate DelegateFactory) ApprovalDelegate(state exec.RunState) exec.ApprovalDelegate {
+	return NewApprovalDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+}
+
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
 	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
 }
+
+func (delegate DelegateFactory) SetVarStepDelegate(state exec.RunState) exec.SetVarStepDelegate {
+	return NewSetVarStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+}
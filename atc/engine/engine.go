@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -25,6 +26,7 @@ func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	secretAccessSink creds.SecretAccessSink,
 ) Engine {
 	return Engine{
 		stepperFactory: stepperFactory,
@@ -32,8 +34,9 @@ func NewEngine(
 		trackedStates:  new(sync.Map),
 		waitGroup:      new(sync.WaitGroup),
 
-		globalSecrets: secrets,
-		varSourcePool: varSourcePool,
+		globalSecrets:    secrets,
+		varSourcePool:    varSourcePool,
+		secretAccessSink: secretAccessSink,
 	}
 }
 
@@ -43,8 +46,9 @@ type Engine struct {
 	trackedStates  *sync.Map
 	waitGroup      *sync.WaitGroup
 
-	globalSecrets creds.Secrets
-	varSourcePool creds.VarSourcePool
+	globalSecrets    creds.Secrets
+	varSourcePool    creds.VarSourcePool
+	secretAccessSink creds.SecretAccessSink
 }
 
 func (engine Engine) Drain(ctx context.Context) {
@@ -66,6 +70,7 @@ func (engine Engine) NewBuild(build db.Build) builds.Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.secretAccessSink,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -77,6 +82,7 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	secretAccessSink creds.SecretAccessSink,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -85,8 +91,9 @@ func NewBuild(
 		build:   build,
 		builder: builder,
 
-		globalSecrets: globalSecrets,
-		varSourcePool: varSourcePool,
+		globalSecrets:    globalSecrets,
+		varSourcePool:    varSourcePool,
+		secretAccessSink: secretAccessSink,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -98,8 +105,9 @@ type engineBuild struct {
 	build   db.Build
 	builder StepperFactory
 
-	globalSecrets creds.Secrets
-	varSourcePool creds.VarSourcePool
+	globalSecrets    creds.Secrets
+	varSourcePool    creds.VarSourcePool
+	secretAccessSink creds.SecretAccessSink
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -334,14 +342,43 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if ok {
 		return existingState.(exec.RunState), nil
 	}
-	credVars, err := b.build.Variables(logger, b.globalSecrets, b.varSourcePool)
+	credVars, err := b.variables(logger, "")
 	if err != nil {
 		return nil, err
 	}
+
+	if b.secretAccessSink != nil {
+		credVars = &stepVariables{
+			Variables: credVars,
+			forStep: func(step string) (vars.Variables, error) {
+				return b.variables(logger, step)
+			},
+		}
+	}
 	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets))
 	return state.(exec.RunState), nil
 }
 
+// variables looks up the build's credentials, recording each of them which
+// is accessed to the secret access sink, if there is one.
+func (b *engineBuild) variables(logger lager.Logger, step string) (vars.Variables, error) {
+	globalSecrets := b.globalSecrets
+	if b.secretAccessSink != nil {
+		globalSecrets = creds.NewAuditedSecrets(globalSecrets, b.secretAccessSink, creds.SecretAccess{
+			TeamID:       b.build.TeamID(),
+			TeamName:     b.build.TeamName(),
+			PipelineID:   b.build.PipelineID(),
+			PipelineName: b.build.PipelineName(),
+			BuildID:      b.build.ID(),
+			BuildName:    b.build.Name(),
+			JobName:      b.build.JobName(),
+			StepName:     step,
+		})
+	}
+
+	return b.build.Variables(logger, globalSecrets, b.varSourcePool)
+}
+
 func (b *engineBuild) clearRunState() {
 	b.trackedStates.Delete(b.build.RunStateID())
 }
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -32,8 +33,9 @@ var _ = Describe("Engine", func() {
 		fakeBuild          *dbfakes.FakeBuild
 		fakeStepperFactory *enginefakes.FakeStepperFactory
 
-		fakeGlobalCreds   *credsfakes.FakeSecrets
-		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+		fakeGlobalCreds      *credsfakes.FakeSecrets
+		fakeVarSourcePool    *credsfakes.FakeVarSourcePool
+		fakeSecretAccessSink creds.SecretAccessSink
 	)
 
 	BeforeEach(func() {
@@ -44,6 +46,7 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds = new(credsfakes.FakeSecrets)
 		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+		fakeSecretAccessSink = nil
 	})
 
 	Describe("NewBuild", func() {
@@ -53,7 +56,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, fakeSecretAccessSink)
 		})
 
 		JustBeforeEach(func() {
@@ -72,7 +75,7 @@ var _ = Describe("Engine", func() {
 			waitGroup *sync.WaitGroup
 		)
 
-		BeforeEach(func() {
+		JustBeforeEach(func() {
 
 			release = make(chan bool)
 			trackedStates := new(sync.Map)
@@ -83,6 +86,7 @@ var _ = Describe("Engine", func() {
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				fakeSecretAccessSink,
 				release,
 				trackedStates,
 				waitGroup,
@@ -191,6 +195,54 @@ var _ = Describe("Engine", func() {
 									Expect(val).To(Equal("bar"))
 								})
 
+								It("does not audit the secrets", func() {
+									<-invokedState
+
+									_, secrets, _ := fakeBuild.VariablesArgsForCall(0)
+									Expect(secrets).To(Equal(fakeGlobalCreds))
+								})
+
+								Context("when secret accesses are audited", func() {
+									var sink *credsfakes.FakeSecretAccessSink
+
+									BeforeEach(func() {
+										sink = new(credsfakes.FakeSecretAccessSink)
+										fakeSecretAccessSink = sink
+
+										fakeBuild.TeamNameReturns("some-team")
+										fakeBuild.PipelineNameReturns("some-pipeline")
+										fakeBuild.JobNameReturns("some-job")
+										fakeBuild.NameReturns("42")
+
+										fakeGlobalCreds.GetReturns("some-value", nil, true, nil)
+									})
+
+									It("attributes the secrets looked up by each step to it", func() {
+										state := <-invokedState
+
+										_, found, err := state.WithStep("some-step").Get(vars.Reference{Path: "foo"})
+										Expect(err).ToNot(HaveOccurred())
+										Expect(found).To(BeTrue())
+
+										Expect(fakeBuild.VariablesCallCount()).To(Equal(2))
+										_, secrets, _ := fakeBuild.VariablesArgsForCall(1)
+
+										_, _, found, err = secrets.Get("some-path")
+										Expect(err).ToNot(HaveOccurred())
+										Expect(found).To(BeTrue())
+
+										Expect(sink.SecretAccessedCallCount()).To(Equal(1))
+										access := sink.SecretAccessedArgsForCall(0)
+										Expect(access.TeamName).To(Equal("some-team"))
+										Expect(access.PipelineName).To(Equal("some-pipeline"))
+										Expect(access.JobName).To(Equal("some-job"))
+										Expect(access.BuildID).To(Equal(128))
+										Expect(access.BuildName).To(Equal("42"))
+										Expect(access.StepName).To(Equal("some-step"))
+										Expect(access.Path).To(Equal("some-path"))
+									})
+								})
+
 								Context("when the build is released", func() {
 									BeforeEach(func() {
 										readyToRelease := make(chan bool)
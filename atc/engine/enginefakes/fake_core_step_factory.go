@@ -11,6 +11,19 @@ import (
 )
 
 type FakeCoreStepFactory struct {
+	ApprovalStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	approvalStepMutex       sync.RWMutex
+	approvalStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	approvalStepReturns struct {
+		result1 exec.Step
+	}
+	approvalStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -117,6 +130,19 @@ type FakeCoreStepFactory struct {
 	setPipelineStepReturnsOnCall map[int]struct {
 		result1 exec.Step
 	}
+	SetVarStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	setVarStepMutex       sync.RWMutex
+	setVarStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	setVarStepReturns struct {
+		result1 exec.Step
+	}
+	setVarStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	TaskStepStub        func(atc.Plan, exec.StepMetadata, db.ContainerMetadata, engine.DelegateFactory) exec.Step
 	taskStepMutex       sync.RWMutex
 	taskStepArgsForCall []struct {
@@ -135,6 +161,69 @@ type FakeCoreStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCoreStepFactory) ApprovalStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.approvalStepMutex.Lock()
+	ret, specificReturn := fake.approvalStepReturnsOnCall[len(fake.approvalStepArgsForCall)]
+	fake.approvalStepArgsForCall = append(fake.approvalStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.ApprovalStepStub
+	fakeReturns := fake.approvalStepReturns
+	fake.recordInvocation("ApprovalStep", []interface{}{arg1, arg2, arg3})
+	fake.approvalStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCallCount() int {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	return len(fake.approvalStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	argsForCall := fake.approvalStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturns(result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	fake.approvalStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	if fake.approvalStepReturnsOnCall == nil {
+		fake.approvalStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approvalStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
@@ -641,6 +730,69 @@ func (fake *FakeCoreStepFactory) SetPipelineStepReturnsOnCall(i int, result1 exe
 	}{result1}
 }
 
+func (fake *FakeCoreStepFactory) SetVarStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.setVarStepMutex.Lock()
+	ret, specificReturn := fake.setVarStepReturnsOnCall[len(fake.setVarStepArgsForCall)]
+	fake.setVarStepArgsForCall = append(fake.setVarStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.SetVarStepStub
+	fakeReturns := fake.setVarStepReturns
+	fake.recordInvocation("SetVarStep", []interface{}{arg1, arg2, arg3})
+	fake.setVarStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) SetVarStepCallCount() int {
+	fake.setVarStepMutex.RLock()
+	defer fake.setVarStepMutex.RUnlock()
+	return len(fake.setVarStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) SetVarStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.setVarStepMutex.Lock()
+	defer fake.setVarStepMutex.Unlock()
+	fake.SetVarStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) SetVarStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.setVarStepMutex.RLock()
+	defer fake.setVarStepMutex.RUnlock()
+	argsForCall := fake.setVarStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) SetVarStepReturns(result1 exec.Step) {
+	fake.setVarStepMutex.Lock()
+	defer fake.setVarStepMutex.Unlock()
+	fake.SetVarStepStub = nil
+	fake.setVarStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) SetVarStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.setVarStepMutex.Lock()
+	defer fake.setVarStepMutex.Unlock()
+	fake.SetVarStepStub = nil
+	if fake.setVarStepReturnsOnCall == nil {
+		fake.setVarStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.setVarStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) TaskStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 db.ContainerMetadata, arg4 engine.DelegateFactory) exec.Step {
 	fake.taskStepMutex.Lock()
 	ret, specificReturn := fake.taskStepReturnsOnCall[len(fake.taskStepArgsForCall)]
@@ -708,6 +860,8 @@ func (fake *FakeCoreStepFactory) TaskStepReturnsOnCall(i int, result1 exec.Step)
 func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
 	fake.artifactInputStepMutex.RLock()
 	defer fake.artifactInputStepMutex.RUnlock()
 	fake.artifactOutputStepMutex.RLock()
@@ -724,6 +878,8 @@ func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	defer fake.runStepMutex.RUnlock()
 	fake.setPipelineStepMutex.RLock()
 	defer fake.setPipelineStepMutex.RUnlock()
+	fake.setVarStepMutex.RLock()
+	defer fake.setVarStepMutex.RUnlock()
 	fake.taskStepMutex.RLock()
 	defer fake.taskStepMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
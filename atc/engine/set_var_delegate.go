@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+func NewSetVarStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+	policyChecker policy.Checker,
+) *setVarStepDelegate {
+	return &setVarStepDelegate{
+		buildStepDelegate{
+			build:         build,
+			planID:        planID,
+			clock:         clock,
+			state:         state,
+			stdout:        nil,
+			stderr:        nil,
+			policyChecker: policyChecker,
+		},
+	}
+}
+
+type setVarStepDelegate struct {
+	buildStepDelegate
+}
+
+// CheckRunSetVarPolicy checks where the var is being written to. The value is
+// never sent to the policy agent.
+func (delegate *setVarStepDelegate) CheckRunSetVarPolicy(varName string, manager string, secretPath string) error {
+	if !delegate.policyChecker.ShouldCheckAction(policy.ActionRunSetVar) {
+		return nil
+	}
+
+	return delegate.checkPolicy(policy.PolicyCheckInput{
+		Action:   policy.ActionRunSetVar,
+		Team:     delegate.build.TeamName(),
+		Pipeline: delegate.build.PipelineName(),
+		Data: map[string]interface{}{
+			"var":     varName,
+			"manager": manager,
+			"path":    secretPath,
+		},
+	})
+}
@@ -0,0 +1,98 @@
+package engine_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("SetVarStepDelegate", func() {
+	var (
+		fakeBuild             *dbfakes.FakeBuild
+		fakeClock             *fakeclock.FakeClock
+		fakePolicyChecker     *policyfakes.FakeChecker
+		fakePolicyCheckResult *policyfakes.FakePolicyCheckResult
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.SetVarStepDelegate
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeClock = fakeclock.NewFakeClock(now)
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, true)
+
+		fakePolicyCheckResult = new(policyfakes.FakePolicyCheckResult)
+		fakePolicyCheckResult.AllowedReturns(true)
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+		fakePolicyChecker.CheckReturns(fakePolicyCheckResult, nil)
+
+		delegate = engine.NewSetVarStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+	})
+
+	Describe("CheckRunSetVarPolicy", func() {
+		var checkErr error
+
+		JustBeforeEach(func() {
+			checkErr = delegate.CheckRunSetVarPolicy("some-var", "vault", "/concourse/some-team/some-var")
+		})
+
+		Context("when the action does not need to be checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(false)
+			})
+
+			It("should not check policy", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the action needs to be checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(true)
+			})
+
+			It("should check where the var is written to", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+					Action:   policy.ActionRunSetVar,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Data: map[string]interface{}{
+						"var":     "some-var",
+						"manager": "vault",
+						"path":    "/concourse/some-team/some-var",
+					},
+				}))
+			})
+
+			Context("when policy check not pass", func() {
+				BeforeEach(func() {
+					fakePolicyCheckResult.AllowedReturns(false)
+					fakePolicyCheckResult.ShouldBlockReturns(true)
+					fakePolicyCheckResult.MessagesReturns([]string{"reasonA"})
+				})
+
+				It("should fail", func() {
+					Expect(checkErr).To(MatchError(ContainSubstring("policy check failed")))
+				})
+			})
+		})
+	})
+})
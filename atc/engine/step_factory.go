@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
@@ -20,8 +21,10 @@ type coreStepFactory struct {
 	lockFactory           lock.LockFactory
 	teamFactory           db.TeamFactory
 	buildFactory          db.BuildFactory
+	secrets               creds.Secrets
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	taskCacheFactory      db.TaskCacheFactory
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.PlacementStrategy
 	noInputStrategy       worker.PlacementStrategy
@@ -38,8 +41,10 @@ func NewCoreStepFactory(
 	lockFactory lock.LockFactory,
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
+	secrets creds.Secrets,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	taskCacheFactory db.TaskCacheFactory,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.PlacementStrategy,
 	noInputStrategy worker.PlacementStrategy,
@@ -55,8 +60,10 @@ func NewCoreStepFactory(
 		lockFactory:           lockFactory,
 		teamFactory:           teamFactory,
 		buildFactory:          buildFactory,
+		secrets:               secrets,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		taskCacheFactory:      taskCacheFactory,
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		noInputStrategy:       noInputStrategy,
@@ -89,6 +96,7 @@ func (factory *coreStepFactory) GetStep(
 		factory.defaultGetTimeout,
 	)
 
+	getStep = exec.Named(getStep, plan.Get.Name)
 	getStep = exec.LogError(getStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		getStep = exec.RetryError(getStep, delegateFactory)
@@ -115,6 +123,7 @@ func (factory *coreStepFactory) PutStep(
 		factory.defaultPutTimeout,
 	)
 
+	putStep = exec.Named(putStep, plan.Put.Name)
 	putStep = exec.LogError(putStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		putStep = exec.RetryError(putStep, delegateFactory)
@@ -143,6 +152,7 @@ func (factory *coreStepFactory) CheckStep(
 		factory.defaultCheckTimeout,
 	)
 
+	checkStep = exec.Named(checkStep, plan.Check.Name)
 	checkStep = exec.LogError(checkStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		checkStep = exec.RetryError(checkStep, delegateFactory)
@@ -164,6 +174,7 @@ func (factory *coreStepFactory) RunStep(
 		delegateFactory,
 	)
 
+	runStep = exec.Named(runStep, plan.Run.Message)
 	runStep = exec.LogError(runStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		runStep = exec.RetryError(runStep, delegateFactory)
@@ -188,11 +199,13 @@ func (factory *coreStepFactory) TaskStep(
 		containerMetadata,
 		factory.strategy,
 		factory.pool,
+		factory.taskCacheFactory,
 		factory.streamer,
 		delegateFactory,
 		factory.defaultTaskTimeout,
 	)
 
+	taskStep = exec.Named(taskStep, plan.Task.Name)
 	taskStep = exec.LogError(taskStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		taskStep = exec.RetryError(taskStep, delegateFactory)
@@ -215,6 +228,7 @@ func (factory *coreStepFactory) SetPipelineStep(
 		factory.streamer,
 	)
 
+	spStep = exec.Named(spStep, plan.SetPipeline.Name)
 	spStep = exec.LogError(spStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		spStep = exec.RetryError(spStep, delegateFactory)
@@ -235,6 +249,7 @@ func (factory *coreStepFactory) LoadVarStep(
 		factory.streamer,
 	)
 
+	loadVarStep = exec.Named(loadVarStep, plan.LoadVar.Name)
 	loadVarStep = exec.LogError(loadVarStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		loadVarStep = exec.RetryError(loadVarStep, delegateFactory)
@@ -242,6 +257,44 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) SetVarStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	setVarStep := exec.NewSetVarStep(
+		plan.ID,
+		*plan.SetVar,
+		stepMetadata,
+		delegateFactory,
+		factory.teamFactory,
+		factory.secrets,
+		factory.streamer,
+	)
+
+	setVarStep = exec.Named(setVarStep, plan.SetVar.Name)
+	setVarStep = exec.LogError(setVarStep, delegateFactory)
+	if atc.EnableBuildRerunWhenWorkerDisappears {
+		setVarStep = exec.RetryError(setVarStep, delegateFactory)
+	}
+	return setVarStep
+}
+
+func (factory *coreStepFactory) ApprovalStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		delegateFactory,
+	)
+
+	return exec.LogError(approvalStep, delegateFactory)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
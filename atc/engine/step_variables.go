@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// stepVariables are a build's credential variables, along with a copy of
+// them for each step which attributes the credentials it looks up to the
+// step.
+type stepVariables struct {
+	vars.Variables
+
+	forStep func(string) (vars.Variables, error)
+	steps   sync.Map
+}
+
+func (v *stepVariables) ForStep(name string) vars.Variables {
+	if stepVars, found := v.steps.Load(name); found {
+		return stepVars.(vars.Variables)
+	}
+
+	stepVars, err := v.forStep(name)
+	if err != nil {
+		// don't cache the failure, so that it's retried on the next lookup
+		return failedVariables{err: err}
+	}
+
+	actual, _ := v.steps.LoadOrStore(name, stepVars)
+	return actual.(vars.Variables)
+}
+
+type failedVariables struct {
+	err error
+}
+
+func (v failedVariables) Get(vars.Reference) (interface{}, bool, error) {
+	return nil, false, v.err
+}
+
+func (v failedVariables) List() ([]vars.Reference, error) {
+	return nil, v.err
+}
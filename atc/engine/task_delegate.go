@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"code.cloudfoundry.org/clock"
@@ -27,10 +28,12 @@ func NewTaskDelegate(
 	return &taskDelegate{
 		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker),
 
-		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		planID:      planID,
-		build:       build,
-		clock:       clock,
+		eventOrigin:   event.Origin{ID: event.OriginID(planID)},
+		planID:        planID,
+		build:         build,
+		state:         state,
+		clock:         clock,
+		policyChecker: policyChecker,
 
 		dbWorkerFactory: dbWorkerFactory,
 		lockFactory:     lockFactory,
@@ -40,11 +43,13 @@ func NewTaskDelegate(
 type taskDelegate struct {
 	exec.BuildStepDelegate
 
-	planID      atc.PlanID
-	config      atc.TaskConfig
-	build       db.Build
-	eventOrigin event.Origin
-	clock       clock.Clock
+	planID        atc.PlanID
+	config        atc.TaskConfig
+	build         db.Build
+	state         exec.RunState
+	eventOrigin   event.Origin
+	clock         clock.Clock
+	policyChecker policy.Checker
 
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
@@ -103,6 +108,21 @@ func (d *taskDelegate) Finished(
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+func (d *taskDelegate) SaveTestResults(logger lager.Logger, step string, results []atc.TestResult) error {
+	err := d.build.SaveTestResults(step, results)
+	if err != nil {
+		return fmt.Errorf("save test results: %w", err)
+	}
+
+	logger.Info("saved-test-results", lager.Data{"count": len(results)})
+
+	return nil
+}
+
+func (d *taskDelegate) ServiceDelegate(planID atc.PlanID) exec.BuildStepDelegate {
+	return NewBuildStepDelegate(d.build, planID, d.state, d.clock, d.policyChecker)
+}
+
 func (d *taskDelegate) FetchImage(
 	ctx context.Context,
 	image atc.ImageResource,
@@ -110,7 +130,7 @@ func (d *taskDelegate) FetchImage(
 	privileged bool,
 	stepTags atc.Tags,
 	skipInterval bool,
-) (runtime.ImageSpec, error) {
+) (runtime.ImageSpec, db.ResourceCache, error) {
 	image.Name = "image"
 
 	getPlan, checkPlan := atc.FetchImagePlan(d.planID, image, types, stepTags, skipInterval, nil)
@@ -124,7 +144,7 @@ func (d *taskDelegate) FetchImage(
 			PublicPlan: checkPlan.Public(),
 		})
 		if err != nil {
-			return runtime.ImageSpec{}, err
+			return runtime.ImageSpec{}, nil, err
 		}
 	}
 
@@ -136,13 +156,8 @@ func (d *taskDelegate) FetchImage(
 		PublicPlan: getPlan.Public(),
 	})
 	if err != nil {
-		return runtime.ImageSpec{}, err
-	}
-
-	imageSpec, _, err := d.BuildStepDelegate.FetchImage(ctx, getPlan, checkPlan, privileged)
-	if err != nil {
-		return runtime.ImageSpec{}, err
+		return runtime.ImageSpec{}, nil, err
 	}
 
-	return imageSpec, nil
+	return d.BuildStepDelegate.FetchImage(ctx, getPlan, checkPlan, privileged)
 }
@@ -3,6 +3,8 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,6 +13,7 @@ import (
 	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/event"
@@ -146,6 +149,66 @@ var _ = Describe("TaskDelegate", func() {
 		})
 	})
 
+	Describe("SaveTestResults", func() {
+		var (
+			results []atc.TestResult
+			saveErr error
+		)
+
+		BeforeEach(func() {
+			results = []atc.TestResult{
+				{Suite: "math", Name: "adds", Status: atc.TestStatusPassed},
+				{Suite: "math", Name: "divides", Status: atc.TestStatusFailed},
+			}
+		})
+
+		JustBeforeEach(func() {
+			saveErr = delegate.SaveTestResults(logger, "unit", results)
+		})
+
+		It("saves the results to the build", func() {
+			Expect(saveErr).ToNot(HaveOccurred())
+			Expect(fakeBuild.SaveTestResultsCallCount()).To(Equal(1))
+
+			step, saved := fakeBuild.SaveTestResultsArgsForCall(0)
+			Expect(step).To(Equal("unit"))
+			Expect(saved).To(Equal(results))
+		})
+
+		Context("when saving fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SaveTestResultsReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(saveErr).To(MatchError(disaster))
+			})
+		})
+	})
+
+	Describe("ServiceDelegate", func() {
+		It("saves the service's output under its own origin", func() {
+			serviceDelegate := delegate.ServiceDelegate("some-plan-id/services/postgres")
+
+			writer := serviceDelegate.Stdout()
+			_, err := writer.Write([]byte("ready to accept connections\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writer.(io.Closer).Close()).To(Succeed())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+				Time:    now.Unix(),
+				Payload: "ready to accept connections\n",
+				Origin: event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     "some-plan-id/services/postgres",
+				},
+			}))
+		})
+	})
+
 	Describe("FetchImage", func() {
 		var delegate exec.TaskDelegate
 
@@ -163,6 +226,7 @@ var _ = Describe("TaskDelegate", func() {
 		var privileged bool
 
 		var imageSpec runtime.ImageSpec
+		var imageCache db.ResourceCache
 		var fetchErr error
 
 		BeforeEach(func() {
@@ -250,7 +314,7 @@ var _ = Describe("TaskDelegate", func() {
 		})
 
 		JustBeforeEach(func() {
-			imageSpec, fetchErr = delegate.FetchImage(context.TODO(), imageResource, types, privileged, tags, false)
+			imageSpec, imageCache, fetchErr = delegate.FetchImage(context.TODO(), imageResource, types, privileged, tags, false)
 		})
 
 		It("succeeds", func() {
@@ -264,6 +328,10 @@ var _ = Describe("TaskDelegate", func() {
 			}))
 		})
 
+		It("returns the resource cache the image was fetched into", func() {
+			Expect(imageCache).To(Equal(fakeResourceCache))
+		})
+
 		It("generates and runs a check and get plan", func() {
 			Expect(runPlans).To(Equal([]atc.Plan{
 				expectedCheckPlan,
@@ -253,6 +253,33 @@ type Finish struct {
 func (Finish) EventType() atc.EventType  { return EventTypeFinish }
 func (Finish) Version() atc.EventVersion { return "1.0" }
 
+type Skipped struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForApproval struct {
+	Origin    Origin   `json:"origin"`
+	Time      int64    `json:"time"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+func (WaitingForApproval) EventType() atc.EventType  { return EventTypeWaitingForApproval }
+func (WaitingForApproval) Version() atc.EventVersion { return "1.0" }
+
+type ApprovalDecided struct {
+	Origin    Origin `json:"origin"`
+	Time      int64  `json:"time"`
+	Approved  bool   `json:"approved"`
+	DecidedBy string `json:"decided_by"`
+}
+
+func (ApprovalDecided) EventType() atc.EventType  { return EventTypeApprovalDecided }
+func (ApprovalDecided) Version() atc.EventVersion { return "1.0" }
+
 type ImageCheck struct {
 	Time       int64            `json:"time"`
 	Origin     Origin           `json:"origin"`
@@ -55,6 +55,9 @@ func init() {
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 	RegisterEvent(AcrossSubsteps{})
+	RegisterEvent(Skipped{})
+	RegisterEvent(WaitingForApproval{})
+	RegisterEvent(ApprovalDecided{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
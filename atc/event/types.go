@@ -62,6 +62,15 @@ const (
 	// finished step
 	EventTypeFinish atc.EventType = "finish"
 
+	// step skipped (its `if` condition did not hold)
+	EventTypeSkipped atc.EventType = "skipped"
+
+	// an approval step is waiting to be approved or rejected
+	EventTypeWaitingForApproval atc.EventType = "waiting-for-approval"
+
+	// an approval step was approved or rejected
+	EventTypeApprovalDecided atc.EventType = "approval-decided"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 
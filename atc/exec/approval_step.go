@@ -0,0 +1,99 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+//counterfeiter:generate . ApprovalDelegateFactory
+type ApprovalDelegateFactory interface {
+	ApprovalDelegate(state RunState) ApprovalDelegate
+}
+
+//counterfeiter:generate . ApprovalDelegate
+type ApprovalDelegate interface {
+	BuildStepDelegate
+
+	// WaitForApproval blocks until the step has been approved or rejected, or
+	// until the context is done.
+	WaitForApproval(context.Context, lager.Logger, string, []string) (db.BuildApproval, error)
+}
+
+// ApprovalStep pauses the build until one of its approvers approves or
+// rejects it. The step succeeds if it is approved and fails if it is
+// rejected.
+type ApprovalStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovalPlan
+	metadata        StepMetadata
+	delegateFactory ApprovalDelegateFactory
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	delegateFactory ApprovalDelegateFactory,
+) Step {
+	return &ApprovalStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.ApprovalDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approval", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApprovalStep) run(ctx context.Context, delegate ApprovalDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approval-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+
+	stdout := delegate.Stdout()
+	delegate.Starting(logger)
+
+	if len(step.plan.Approvers) == 0 {
+		fmt.Fprintf(stdout, "waiting for approval\n")
+	} else {
+		fmt.Fprintf(stdout, "waiting for approval from %s\n", strings.Join(step.plan.Approvers, ", "))
+	}
+
+	approval, err := delegate.WaitForApproval(ctx, logger, step.plan.Name, step.plan.Approvers)
+	if err != nil {
+		return false, err
+	}
+
+	approved := approval.Approved != nil && *approval.Approved
+	if approved {
+		fmt.Fprintf(stdout, "approved by %s\n", approval.DecidedBy)
+	} else {
+		fmt.Fprintf(stdout, "rejected by %s\n", approval.DecidedBy)
+	}
+
+	delegate.Finished(logger, approved)
+
+	return approved, nil
+}
@@ -0,0 +1,143 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx context.Context
+
+		fakeDelegate        *execfakes.FakeApprovalDelegate
+		fakeDelegateFactory *execfakes.FakeApprovalDelegateFactory
+
+		stdout *gbytes.Buffer
+		state  RunState
+
+		plan atc.ApprovalPlan
+		step Step
+
+		stepOk  bool
+		stepErr error
+	)
+
+	decision := func(approved bool) db.BuildApproval {
+		return db.BuildApproval{
+			PlanID:    "some-plan-id",
+			Name:      "qa",
+			Approved:  &approved,
+			DecidedBy: "some-user",
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeApprovalDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+		fakeDelegate.WaitForApprovalReturns(decision(true), nil)
+
+		fakeDelegateFactory = new(execfakes.FakeApprovalDelegateFactory)
+		fakeDelegateFactory.ApprovalDelegateReturns(fakeDelegate)
+
+		state = NewRunState(noopStepper, vars.StaticVariables{}, false)
+
+		plan = atc.ApprovalPlan{
+			Name:      "qa",
+			Approvers: []string{"owner", "github:someone"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = NewApprovalStep(
+			"some-plan-id",
+			plan,
+			StepMetadata{},
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("waits for one of the approvers", func() {
+		Expect(fakeDelegate.WaitForApprovalCallCount()).To(Equal(1))
+		_, _, name, approvers := fakeDelegate.WaitForApprovalArgsForCall(0)
+		Expect(name).To(Equal("qa"))
+		Expect(approvers).To(Equal([]string{"owner", "github:someone"}))
+
+		Expect(stdout).To(gbytes.Say("waiting for approval from owner, github:someone"))
+	})
+
+	It("initializes and starts the step", func() {
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+	})
+
+	Context("when it is approved", func() {
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("finishes successfully", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("says who approved it", func() {
+			Expect(stdout).To(gbytes.Say("approved by some-user"))
+		})
+	})
+
+	Context("when it is rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(decision(false), nil)
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("finishes unsuccessfully", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+
+		It("says who rejected it", func() {
+			Expect(stdout).To(gbytes.Say("rejected by some-user"))
+		})
+	})
+
+	Context("when waiting fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(db.BuildApproval{}, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("does not finish", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+})
@@ -15,6 +15,12 @@ type ArtifactName string
 type ArtifactEntry struct {
 	Artifact  runtime.Artifact
 	FromCache bool
+
+	// Digest identifies the contents of the artifact, if they are known to
+	// be the same whenever the digest is, e.g. because they were fetched into
+	// a resource cache. Memoized task steps use it to tell whether their
+	// inputs have changed.
+	Digest string
 }
 
 // Repository is the mapping from a ArtifactName to an Artifact.
@@ -43,10 +49,17 @@ func NewRepository() *Repository {
 // ArtifactName. Producers of artifacts, e.g. the Get step and the Task step,
 // will call this after they've successfully produced their artifact(s).
 func (repo *Repository) RegisterArtifact(name ArtifactName, artifact runtime.Artifact, fromCache bool) {
+	repo.RegisterDigestedArtifact(name, artifact, fromCache, "")
+}
+
+// RegisterDigestedArtifact is like RegisterArtifact, but also records the
+// digest of the artifact's contents.
+func (repo *Repository) RegisterDigestedArtifact(name ArtifactName, artifact runtime.Artifact, fromCache bool, digest string) {
 	repo.repoL.Lock()
 	repo.repo[name] = ArtifactEntry{
 		Artifact:  artifact,
 		FromCache: fromCache,
+		Digest:    digest,
 	}
 	repo.repoL.Unlock()
 }
@@ -66,6 +79,21 @@ func (repo *Repository) ArtifactFor(name ArtifactName) (runtime.Artifact, bool,
 	return artifactEntry.Artifact, artifactEntry.FromCache, true
 }
 
+// DigestFor looks up the digest of the Artifact for a given ArtifactName. It
+// returns an empty string if the artifact was registered without one.
+func (repo *Repository) DigestFor(name ArtifactName) (string, bool) {
+	repo.repoL.RLock()
+	artifactEntry, found := repo.repo[name]
+	repo.repoL.RUnlock()
+	if !found && repo.parent != nil {
+		return repo.parent.DigestFor(name)
+	}
+	if !found {
+		return "", false
+	}
+	return artifactEntry.Digest, true
+}
+
 // AsMap extracts the current contents of the ArtifactRepository into a new map
 // and returns it. Changes to the returned map or the ArtifactRepository will not
 // affect each other.
@@ -57,6 +57,37 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 
+		Describe("DigestFor", func() {
+			It("yields no digest", func() {
+				digest, found := repo.DigestFor("first-artifact")
+				Expect(found).To(BeTrue())
+				Expect(digest).To(BeEmpty())
+			})
+
+			Context("when the artifact is registered with a digest", func() {
+				BeforeEach(func() {
+					repo.RegisterDigestedArtifact("first-artifact", Artifact("first"), true, "some-digest")
+				})
+
+				It("yields the digest", func() {
+					digest, found := repo.DigestFor("first-artifact")
+					Expect(found).To(BeTrue())
+					Expect(digest).To(Equal("some-digest"))
+				})
+
+				It("yields it from a local scope", func() {
+					digest, found := repo.NewLocalScope().DigestFor("first-artifact")
+					Expect(found).To(BeTrue())
+					Expect(digest).To(Equal("some-digest"))
+				})
+			})
+
+			It("yields nothing for unregistered names", func() {
+				_, found := repo.DigestFor("bogus-artifact")
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		Describe("NewLocalScope", func() {
 			var child *Repository
 
@@ -32,6 +32,7 @@ type BuildStepDelegate interface {
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
 	Errored(lager.Logger, string)
+	Skipped(lager.Logger)
 
 	BeforeSelectWorker(lager.Logger) error
 	WaitingForWorker(lager.Logger)
@@ -44,6 +45,17 @@ type BuildStepDelegate interface {
 	ContainerOwner(planId atc.PlanID) db.ContainerOwner
 }
 
+//counterfeiter:generate . SetVarStepDelegateFactory
+type SetVarStepDelegateFactory interface {
+	SetVarStepDelegate(state RunState) SetVarStepDelegate
+}
+
+//counterfeiter:generate . SetVarStepDelegate
+type SetVarStepDelegate interface {
+	BuildStepDelegate
+	CheckRunSetVarPolicy(varName string, manager string, secretPath string) error
+}
+
 //counterfeiter:generate . SetPipelineStepDelegateFactory
 type SetPipelineStepDelegateFactory interface {
 	SetPipelineStepDelegate(state RunState) SetPipelineStepDelegate
@@ -30,6 +30,10 @@ func newBuildVariables(credVars vars.Variables, enableRedaction bool) *buildVari
 }
 
 func (b *buildVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	return b.get(ref, "")
+}
+
+func (b *buildVariables) get(ref vars.Reference, step string) (interface{}, bool, error) {
 	if ref.Source == "." {
 		b.lock.RLock()
 		val, found, err := b.localVars.Get(ref.WithoutSource())
@@ -38,6 +42,22 @@ func (b *buildVariables) Get(ref vars.Reference) (interface{}, bool, error) {
 			return val, found, err
 		}
 	}
+
+	if step != "" {
+		switch parent := b.parentScope.(type) {
+		case *buildVariables:
+			return parent.get(ref, step)
+		case *vars.CredVarsTracker:
+			if stepVars, ok := parent.CredVars.(StepVariables); ok {
+				tracker := &vars.CredVarsTracker{
+					Tracker:  parent.Tracker,
+					CredVars: stepVars.ForStep(step),
+				}
+				return tracker.Get(ref)
+			}
+		}
+	}
+
 	return b.parentScope.Get(ref)
 }
 
@@ -312,6 +312,7 @@ func (step *CheckStep) runCheck(
 	return resource.Resource{
 		Source:  source,
 		Version: fromVersion,
+		Webhook: step.plan.Webhook,
 	}.Check(ctx, container, delegate.Stderr())
 }
 
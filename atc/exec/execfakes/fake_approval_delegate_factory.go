@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeApprovalDelegateFactory struct {
+	ApprovalDelegateStub        func(exec.RunState) exec.ApprovalDelegate
+	approvalDelegateMutex       sync.RWMutex
+	approvalDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	approvalDelegateReturns struct {
+		result1 exec.ApprovalDelegate
+	}
+	approvalDelegateReturnsOnCall map[int]struct {
+		result1 exec.ApprovalDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegate(arg1 exec.RunState) exec.ApprovalDelegate {
+	fake.approvalDelegateMutex.Lock()
+	ret, specificReturn := fake.approvalDelegateReturnsOnCall[len(fake.approvalDelegateArgsForCall)]
+	fake.approvalDelegateArgsForCall = append(fake.approvalDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.ApprovalDelegateStub
+	fakeReturns := fake.approvalDelegateReturns
+	fake.recordInvocation("ApprovalDelegate", []interface{}{arg1})
+	fake.approvalDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegateCallCount() int {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	return len(fake.approvalDelegateArgsForCall)
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegateCalls(stub func(exec.RunState) exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = stub
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegateArgsForCall(i int) exec.RunState {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	argsForCall := fake.approvalDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegateReturns(result1 exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = nil
+	fake.approvalDelegateReturns = struct {
+		result1 exec.ApprovalDelegate
+	}{result1}
+}
+
+func (fake *FakeApprovalDelegateFactory) ApprovalDelegateReturnsOnCall(i int, result1 exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = nil
+	if fake.approvalDelegateReturnsOnCall == nil {
+		fake.approvalDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ApprovalDelegate
+		})
+	}
+	fake.approvalDelegateReturnsOnCall[i] = struct {
+		result1 exec.ApprovalDelegate
+	}{result1}
+}
+
+func (fake *FakeApprovalDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeApprovalDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ApprovalDelegateFactory = new(FakeApprovalDelegateFactory)
@@ -6,7 +6,7 @@ import (
 	"sync"
 	"time"
 
-	"code.cloudfoundry.org/lager/v3"
+	lager "code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/runtime"
@@ -70,6 +70,26 @@ type FakePool struct {
 		result2 bool
 		result3 error
 	}
+	FindTaskCacheVolumeStub        func(context.Context, int, int, string, string, worker.Spec) (runtime.Volume, bool, error)
+	findTaskCacheVolumeMutex       sync.RWMutex
+	findTaskCacheVolumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+		arg4 string
+		arg5 string
+		arg6 worker.Spec
+	}
+	findTaskCacheVolumeReturns struct {
+		result1 runtime.Volume
+		result2 bool
+		result3 error
+	}
+	findTaskCacheVolumeReturnsOnCall map[int]struct {
+		result1 runtime.Volume
+		result2 bool
+		result3 error
+	}
 	LocateVolumeStub        func(context.Context, int, string) (runtime.Volume, runtime.Worker, bool, error)
 	locateVolumeMutex       sync.RWMutex
 	locateVolumeArgsForCall []struct {
@@ -312,6 +332,78 @@ func (fake *FakePool) FindResourceCacheVolumeOnWorkerReturnsOnCall(i int, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakePool) FindTaskCacheVolume(arg1 context.Context, arg2 int, arg3 int, arg4 string, arg5 string, arg6 worker.Spec) (runtime.Volume, bool, error) {
+	fake.findTaskCacheVolumeMutex.Lock()
+	ret, specificReturn := fake.findTaskCacheVolumeReturnsOnCall[len(fake.findTaskCacheVolumeArgsForCall)]
+	fake.findTaskCacheVolumeArgsForCall = append(fake.findTaskCacheVolumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 int
+		arg4 string
+		arg5 string
+		arg6 worker.Spec
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.FindTaskCacheVolumeStub
+	fakeReturns := fake.findTaskCacheVolumeReturns
+	fake.recordInvocation("FindTaskCacheVolume", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.findTaskCacheVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePool) FindTaskCacheVolumeCallCount() int {
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
+	return len(fake.findTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakePool) FindTaskCacheVolumeCalls(stub func(context.Context, int, int, string, string, worker.Spec) (runtime.Volume, bool, error)) {
+	fake.findTaskCacheVolumeMutex.Lock()
+	defer fake.findTaskCacheVolumeMutex.Unlock()
+	fake.FindTaskCacheVolumeStub = stub
+}
+
+func (fake *FakePool) FindTaskCacheVolumeArgsForCall(i int) (context.Context, int, int, string, string, worker.Spec) {
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
+	argsForCall := fake.findTaskCacheVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakePool) FindTaskCacheVolumeReturns(result1 runtime.Volume, result2 bool, result3 error) {
+	fake.findTaskCacheVolumeMutex.Lock()
+	defer fake.findTaskCacheVolumeMutex.Unlock()
+	fake.FindTaskCacheVolumeStub = nil
+	fake.findTaskCacheVolumeReturns = struct {
+		result1 runtime.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePool) FindTaskCacheVolumeReturnsOnCall(i int, result1 runtime.Volume, result2 bool, result3 error) {
+	fake.findTaskCacheVolumeMutex.Lock()
+	defer fake.findTaskCacheVolumeMutex.Unlock()
+	fake.FindTaskCacheVolumeStub = nil
+	if fake.findTaskCacheVolumeReturnsOnCall == nil {
+		fake.findTaskCacheVolumeReturnsOnCall = make(map[int]struct {
+			result1 runtime.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findTaskCacheVolumeReturnsOnCall[i] = struct {
+		result1 runtime.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePool) LocateVolume(arg1 context.Context, arg2 int, arg3 string) (runtime.Volume, runtime.Worker, bool, error) {
 	fake.locateVolumeMutex.Lock()
 	ret, specificReturn := fake.locateVolumeReturnsOnCall[len(fake.locateVolumeArgsForCall)]
@@ -428,6 +520,8 @@ func (fake *FakePool) Invocations() map[string][][]interface{} {
 	defer fake.findResourceCacheVolumeMutex.RUnlock()
 	fake.findResourceCacheVolumeOnWorkerMutex.RLock()
 	defer fake.findResourceCacheVolumeOnWorkerMutex.RUnlock()
+	fake.findTaskCacheVolumeMutex.RLock()
+	defer fake.findTaskCacheVolumeMutex.RUnlock()
 	fake.locateVolumeMutex.RLock()
 	defer fake.locateVolumeMutex.RUnlock()
 	fake.releaseWorkerMutex.RLock()
@@ -123,6 +123,17 @@ type FakeRunState struct {
 		arg1 atc.PlanID
 		arg2 interface{}
 	}
+	WithStepStub        func(string) exec.RunState
+	withStepMutex       sync.RWMutex
+	withStepArgsForCall []struct {
+		arg1 string
+	}
+	withStepReturns struct {
+		result1 exec.RunState
+	}
+	withStepReturnsOnCall map[int]struct {
+		result1 exec.RunState
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -688,6 +699,67 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, interface{}
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) WithStep(arg1 string) exec.RunState {
+	fake.withStepMutex.Lock()
+	ret, specificReturn := fake.withStepReturnsOnCall[len(fake.withStepArgsForCall)]
+	fake.withStepArgsForCall = append(fake.withStepArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithStepStub
+	fakeReturns := fake.withStepReturns
+	fake.recordInvocation("WithStep", []interface{}{arg1})
+	fake.withStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) WithStepCallCount() int {
+	fake.withStepMutex.RLock()
+	defer fake.withStepMutex.RUnlock()
+	return len(fake.withStepArgsForCall)
+}
+
+func (fake *FakeRunState) WithStepCalls(stub func(string) exec.RunState) {
+	fake.withStepMutex.Lock()
+	defer fake.withStepMutex.Unlock()
+	fake.WithStepStub = stub
+}
+
+func (fake *FakeRunState) WithStepArgsForCall(i int) string {
+	fake.withStepMutex.RLock()
+	defer fake.withStepMutex.RUnlock()
+	argsForCall := fake.withStepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunState) WithStepReturns(result1 exec.RunState) {
+	fake.withStepMutex.Lock()
+	defer fake.withStepMutex.Unlock()
+	fake.WithStepStub = nil
+	fake.withStepReturns = struct {
+		result1 exec.RunState
+	}{result1}
+}
+
+func (fake *FakeRunState) WithStepReturnsOnCall(i int, result1 exec.RunState) {
+	fake.withStepMutex.Lock()
+	defer fake.withStepMutex.Unlock()
+	fake.WithStepStub = nil
+	if fake.withStepReturnsOnCall == nil {
+		fake.withStepReturnsOnCall = make(map[int]struct {
+			result1 exec.RunState
+		})
+	}
+	fake.withStepReturnsOnCall[i] = struct {
+		result1 exec.RunState
+	}{result1}
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -713,6 +785,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.storeResultMutex.RLock()
 	defer fake.storeResultMutex.RUnlock()
+	fake.withStepMutex.RLock()
+	defer fake.withStepMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
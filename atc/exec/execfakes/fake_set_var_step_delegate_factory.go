@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeSetVarStepDelegateFactory struct {
+	SetVarStepDelegateStub        func(exec.RunState) exec.SetVarStepDelegate
+	setVarStepDelegateMutex       sync.RWMutex
+	setVarStepDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	setVarStepDelegateReturns struct {
+		result1 exec.SetVarStepDelegate
+	}
+	setVarStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetVarStepDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegate(arg1 exec.RunState) exec.SetVarStepDelegate {
+	fake.setVarStepDelegateMutex.Lock()
+	ret, specificReturn := fake.setVarStepDelegateReturnsOnCall[len(fake.setVarStepDelegateArgsForCall)]
+	fake.setVarStepDelegateArgsForCall = append(fake.setVarStepDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	stub := fake.SetVarStepDelegateStub
+	fakeReturns := fake.setVarStepDelegateReturns
+	fake.recordInvocation("SetVarStepDelegate", []interface{}{arg1})
+	fake.setVarStepDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegateCallCount() int {
+	fake.setVarStepDelegateMutex.RLock()
+	defer fake.setVarStepDelegateMutex.RUnlock()
+	return len(fake.setVarStepDelegateArgsForCall)
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegateCalls(stub func(exec.RunState) exec.SetVarStepDelegate) {
+	fake.setVarStepDelegateMutex.Lock()
+	defer fake.setVarStepDelegateMutex.Unlock()
+	fake.SetVarStepDelegateStub = stub
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegateArgsForCall(i int) exec.RunState {
+	fake.setVarStepDelegateMutex.RLock()
+	defer fake.setVarStepDelegateMutex.RUnlock()
+	argsForCall := fake.setVarStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegateReturns(result1 exec.SetVarStepDelegate) {
+	fake.setVarStepDelegateMutex.Lock()
+	defer fake.setVarStepDelegateMutex.Unlock()
+	fake.SetVarStepDelegateStub = nil
+	fake.setVarStepDelegateReturns = struct {
+		result1 exec.SetVarStepDelegate
+	}{result1}
+}
+
+func (fake *FakeSetVarStepDelegateFactory) SetVarStepDelegateReturnsOnCall(i int, result1 exec.SetVarStepDelegate) {
+	fake.setVarStepDelegateMutex.Lock()
+	defer fake.setVarStepDelegateMutex.Unlock()
+	fake.SetVarStepDelegateStub = nil
+	if fake.setVarStepDelegateReturnsOnCall == nil {
+		fake.setVarStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetVarStepDelegate
+		})
+	}
+	fake.setVarStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetVarStepDelegate
+	}{result1}
+}
+
+func (fake *FakeSetVarStepDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.setVarStepDelegateMutex.RLock()
+	defer fake.setVarStepDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSetVarStepDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.SetVarStepDelegateFactory = new(FakeSetVarStepDelegateFactory)
@@ -7,8 +7,9 @@ import (
 	"sync"
 	"time"
 
-	"code.cloudfoundry.org/lager/v3"
+	lager "code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
@@ -43,7 +44,7 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
-	FetchImageStub        func(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, error)
+	FetchImageStub        func(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, db.ResourceCache, error)
 	fetchImageMutex       sync.RWMutex
 	fetchImageArgsForCall []struct {
 		arg1 context.Context
@@ -55,11 +56,13 @@ type FakeTaskDelegate struct {
 	}
 	fetchImageReturns struct {
 		result1 runtime.ImageSpec
-		result2 error
+		result2 db.ResourceCache
+		result3 error
 	}
 	fetchImageReturnsOnCall map[int]struct {
 		result1 runtime.ImageSpec
-		result2 error
+		result2 db.ResourceCache
+		result3 error
 	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus)
 	finishedMutex       sync.RWMutex
@@ -72,12 +75,36 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	SaveTestResultsStub        func(lager.Logger, string, []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	ServiceDelegateStub        func(atc.PlanID) exec.BuildStepDelegate
+	serviceDelegateMutex       sync.RWMutex
+	serviceDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	serviceDelegateReturns struct {
+		result1 exec.BuildStepDelegate
+	}
+	serviceDelegateReturnsOnCall map[int]struct {
+		result1 exec.BuildStepDelegate
+	}
 	SetTaskConfigStub        func(atc.TaskConfig)
 	setTaskConfigMutex       sync.RWMutex
 	setTaskConfigArgsForCall []struct {
@@ -294,7 +321,7 @@ func (fake *FakeTaskDelegate) ErroredArgsForCall(i int) (lager.Logger, string) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeTaskDelegate) FetchImage(arg1 context.Context, arg2 atc.ImageResource, arg3 atc.ResourceTypes, arg4 bool, arg5 atc.Tags, arg6 bool) (runtime.ImageSpec, error) {
+func (fake *FakeTaskDelegate) FetchImage(arg1 context.Context, arg2 atc.ImageResource, arg3 atc.ResourceTypes, arg4 bool, arg5 atc.Tags, arg6 bool) (runtime.ImageSpec, db.ResourceCache, error) {
 	fake.fetchImageMutex.Lock()
 	ret, specificReturn := fake.fetchImageReturnsOnCall[len(fake.fetchImageArgsForCall)]
 	fake.fetchImageArgsForCall = append(fake.fetchImageArgsForCall, struct {
@@ -313,9 +340,9 @@ func (fake *FakeTaskDelegate) FetchImage(arg1 context.Context, arg2 atc.ImageRes
 		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *FakeTaskDelegate) FetchImageCallCount() int {
@@ -324,7 +351,7 @@ func (fake *FakeTaskDelegate) FetchImageCallCount() int {
 	return len(fake.fetchImageArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) FetchImageCalls(stub func(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, error)) {
+func (fake *FakeTaskDelegate) FetchImageCalls(stub func(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, db.ResourceCache, error)) {
 	fake.fetchImageMutex.Lock()
 	defer fake.fetchImageMutex.Unlock()
 	fake.FetchImageStub = stub
@@ -337,30 +364,33 @@ func (fake *FakeTaskDelegate) FetchImageArgsForCall(i int) (context.Context, atc
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
-func (fake *FakeTaskDelegate) FetchImageReturns(result1 runtime.ImageSpec, result2 error) {
+func (fake *FakeTaskDelegate) FetchImageReturns(result1 runtime.ImageSpec, result2 db.ResourceCache, result3 error) {
 	fake.fetchImageMutex.Lock()
 	defer fake.fetchImageMutex.Unlock()
 	fake.FetchImageStub = nil
 	fake.fetchImageReturns = struct {
 		result1 runtime.ImageSpec
-		result2 error
-	}{result1, result2}
+		result2 db.ResourceCache
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeTaskDelegate) FetchImageReturnsOnCall(i int, result1 runtime.ImageSpec, result2 error) {
+func (fake *FakeTaskDelegate) FetchImageReturnsOnCall(i int, result1 runtime.ImageSpec, result2 db.ResourceCache, result3 error) {
 	fake.fetchImageMutex.Lock()
 	defer fake.fetchImageMutex.Unlock()
 	fake.FetchImageStub = nil
 	if fake.fetchImageReturnsOnCall == nil {
 		fake.fetchImageReturnsOnCall = make(map[int]struct {
 			result1 runtime.ImageSpec
-			result2 error
+			result2 db.ResourceCache
+			result3 error
 		})
 	}
 	fake.fetchImageReturnsOnCall[i] = struct {
 		result1 runtime.ImageSpec
-		result2 error
-	}{result1, result2}
+		result2 db.ResourceCache
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeTaskDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus) {
@@ -428,6 +458,74 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) SaveTestResults(arg1 lager.Logger, arg2 string, arg3 []atc.TestResult) error {
+	var arg3Copy []atc.TestResult
+	if arg3 != nil {
+		arg3Copy = make([]atc.TestResult, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 []atc.TestResult
+	}{arg1, arg2, arg3Copy})
+	stub := fake.SaveTestResultsStub
+	fakeReturns := fake.saveTestResultsReturns
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1, arg2, arg3Copy})
+	fake.saveTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCalls(stub func(lager.Logger, string, []atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsArgsForCall(i int) (lager.Logger, string, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -461,6 +559,67 @@ func (fake *FakeTaskDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, st
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) ServiceDelegate(arg1 atc.PlanID) exec.BuildStepDelegate {
+	fake.serviceDelegateMutex.Lock()
+	ret, specificReturn := fake.serviceDelegateReturnsOnCall[len(fake.serviceDelegateArgsForCall)]
+	fake.serviceDelegateArgsForCall = append(fake.serviceDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.ServiceDelegateStub
+	fakeReturns := fake.serviceDelegateReturns
+	fake.recordInvocation("ServiceDelegate", []interface{}{arg1})
+	fake.serviceDelegateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ServiceDelegateCallCount() int {
+	fake.serviceDelegateMutex.RLock()
+	defer fake.serviceDelegateMutex.RUnlock()
+	return len(fake.serviceDelegateArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ServiceDelegateCalls(stub func(atc.PlanID) exec.BuildStepDelegate) {
+	fake.serviceDelegateMutex.Lock()
+	defer fake.serviceDelegateMutex.Unlock()
+	fake.ServiceDelegateStub = stub
+}
+
+func (fake *FakeTaskDelegate) ServiceDelegateArgsForCall(i int) atc.PlanID {
+	fake.serviceDelegateMutex.RLock()
+	defer fake.serviceDelegateMutex.RUnlock()
+	argsForCall := fake.serviceDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskDelegate) ServiceDelegateReturns(result1 exec.BuildStepDelegate) {
+	fake.serviceDelegateMutex.Lock()
+	defer fake.serviceDelegateMutex.Unlock()
+	fake.ServiceDelegateStub = nil
+	fake.serviceDelegateReturns = struct {
+		result1 exec.BuildStepDelegate
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ServiceDelegateReturnsOnCall(i int, result1 exec.BuildStepDelegate) {
+	fake.serviceDelegateMutex.Lock()
+	defer fake.serviceDelegateMutex.Unlock()
+	fake.ServiceDelegateStub = nil
+	if fake.serviceDelegateReturnsOnCall == nil {
+		fake.serviceDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.BuildStepDelegate
+		})
+	}
+	fake.serviceDelegateReturnsOnCall[i] = struct {
+		result1 exec.BuildStepDelegate
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) SetTaskConfig(arg1 atc.TaskConfig) {
 	fake.setTaskConfigMutex.Lock()
 	fake.setTaskConfigArgsForCall = append(fake.setTaskConfigArgsForCall, struct {
@@ -813,8 +972,12 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.serviceDelegateMutex.RLock()
+	defer fake.serviceDelegateMutex.RUnlock()
 	fake.setTaskConfigMutex.RLock()
 	defer fake.setTaskConfigMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -241,10 +241,11 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 			ResourceCache: resourceCache,
 		})
 
-		state.ArtifactRepository().RegisterArtifact(
+		state.ArtifactRepository().RegisterDigestedArtifact(
 			build.ArtifactName(step.plan.Name),
 			volume,
 			fromCache,
+			resourceCacheDigest(resourceCache),
 		)
 
 		if step.plan.Resource != "" {
@@ -0,0 +1,78 @@
+package exec
+
+import (
+	"context"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// IfStep runs its step only if a condition holds.
+type IfStep struct {
+	condition string
+	step      Step
+
+	skippedDelegateFactories []BuildStepDelegateFactory
+	metadata                 StepMetadata
+}
+
+// If constructs an IfStep. When the condition does not hold, each of the
+// skippedDelegateFactories is used to mark one of the steps that would have
+// run as skipped.
+func If(
+	condition string,
+	step Step,
+	skippedDelegateFactories []BuildStepDelegateFactory,
+	metadata StepMetadata,
+) IfStep {
+	return IfStep{
+		condition:                condition,
+		step:                     step,
+		skippedDelegateFactories: skippedDelegateFactories,
+		metadata:                 metadata,
+	}
+}
+
+// Run evaluates the condition against the build's vars (including local vars
+// set by load_var or across) and metadata, and runs the step if it holds.
+//
+// A skipped step is considered to have succeeded, so that the rest of the
+// build carries on. The step's own hooks are not run though, as they are
+// planned within the IfStep and so are skipped along with it.
+func (step IfStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("if-step")
+
+	condition, err := atc.ParseCondition(step.condition)
+	if err != nil {
+		return false, err
+	}
+
+	holds, err := condition.Evaluate(state, step.conditionMetadata())
+	if err != nil {
+		return false, err
+	}
+
+	if holds {
+		return step.step.Run(ctx, state)
+	}
+
+	logger.Info("skipping", lager.Data{"condition": step.condition})
+
+	for _, factory := range step.skippedDelegateFactories {
+		factory.BuildStepDelegate(state).Skipped(logger)
+	}
+
+	return true, nil
+}
+
+func (step IfStep) conditionMetadata() map[string]string {
+	metadata := map[string]string{}
+	for _, env := range step.metadata.Env() {
+		name, value, _ := strings.Cut(env, "=")
+		metadata[name] = value
+	}
+
+	return metadata
+}
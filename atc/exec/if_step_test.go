@@ -0,0 +1,150 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("If Step", func() {
+	var (
+		ctx context.Context
+
+		fakeStep *execfakes.FakeStep
+		state    RunState
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		condition string
+		metadata  StepMetadata
+
+		step Step
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		state = NewRunState(noopStepper, vars.StaticVariables{"branch": "main"}, false)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		metadata = StepMetadata{
+			JobName: "some-job",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = If(
+			condition,
+			fakeStep,
+			[]BuildStepDelegateFactory{fakeDelegateFactory, fakeDelegateFactory},
+			metadata,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			condition = "((branch)) == main && $BUILD_JOB_NAME == some-job"
+		})
+
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("does not mark anything as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			condition = "((branch)) != main"
+		})
+
+		It("does not run the step", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+
+		It("marks each step as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(2))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the condition refers to a local var", func() {
+		BeforeEach(func() {
+			condition = "((.:deploy))"
+			state.AddLocalVar("deploy", false, false)
+		})
+
+		It("evaluates it", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the condition refers to an undefined var", func() {
+		BeforeEach(func() {
+			condition = "((bogus))"
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(Equal(vars.UndefinedVarsError{Vars: []string{"bogus"}}))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the condition is invalid", func() {
+		BeforeEach(func() {
+			condition = "((branch)) =="
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
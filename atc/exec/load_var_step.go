@@ -1,12 +1,14 @@
 package exec
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/worker/baggageclaim"
 )
@@ -95,10 +98,22 @@ func (step *LoadVarStep) run(ctx context.Context, state RunState, delegate Build
 	stdout := delegate.Stdout()
 	delegate.Starting(logger)
 
-	value, err := step.fetchVars(ctx, logger, step.plan.File, state)
+	value, err := step.fetchVars(ctx, logger, state)
 	if err != nil {
 		return false, err
 	}
+
+	if step.plan.Select != "" {
+		selector, err := atc.ParseVarSelector(step.plan.Select)
+		if err != nil {
+			return false, err
+		}
+
+		value, err = selector.Select(value)
+		if err != nil {
+			return false, fmt.Errorf("select %s: %w", step.plan.Select, err)
+		}
+	}
 	fmt.Fprintf(stdout, "var %s fetched.\n", step.plan.Name)
 
 	state.AddLocalVar(step.plan.Name, value, !step.plan.Reveal)
@@ -112,30 +127,37 @@ func (step *LoadVarStep) run(ctx context.Context, state RunState, delegate Build
 func (step *LoadVarStep) fetchVars(
 	ctx context.Context,
 	logger lager.Logger,
-	file string,
 	state RunState,
 ) (interface{}, error) {
-
-	segs := strings.SplitN(file, "/", 2)
-	if len(segs) != 2 {
-		return nil, UnspecifiedLoadVarStepFileError{file}
+	if step.plan.Dir != "" {
+		return fetchVarDir(ctx, logger, step.streamer, state, step.plan.Dir, step.plan.Format)
 	}
 
-	artifactName := segs[0]
-	filePath := segs[1]
+	return fetchVarFile(ctx, logger, step.streamer, state, step.plan.File, step.plan.Format)
+}
 
-	format, err := step.fileFormat(file)
+// fetchVarFile reads a var from a file in an artifact, parsing it in the given
+// format or one determined by the file's extension.
+func fetchVarFile(
+	ctx context.Context,
+	logger lager.Logger,
+	streamer Streamer,
+	state RunState,
+	file string,
+	format string,
+) (interface{}, error) {
+	art, artifactName, filePath, err := varFileArtifact(state, file)
 	if err != nil {
 		return nil, err
 	}
-	logger.Debug("figure-out-format", lager.Data{"format": format})
 
-	art, _, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(artifactName))
-	if !found {
-		return nil, UnknownArtifactSourceError{build.ArtifactName(artifactName), filePath}
+	format, err = varFileFormat(file, format)
+	if err != nil {
+		return nil, err
 	}
+	logger.Debug("figure-out-format", lager.Data{"format": format})
 
-	stream, err := step.streamer.StreamFile(lagerctx.NewContext(ctx, logger), art, filePath)
+	stream, err := streamer.StreamFile(lagerctx.NewContext(ctx, logger), art, filePath)
 	if err != nil {
 		if err == baggageclaim.ErrFileNotFound {
 			return nil, FileNotFoundError{
@@ -154,7 +176,115 @@ func (step *LoadVarStep) fetchVars(
 		return nil, err
 	}
 
-	var value interface{}
+	return parseVarFile(file, format, fileContent)
+}
+
+// fetchVarDir reads each of the files directly within a directory in an
+// artifact, keyed by their name without the extension. Hidden files and
+// subdirectories are skipped.
+func fetchVarDir(
+	ctx context.Context,
+	logger lager.Logger,
+	streamer Streamer,
+	state RunState,
+	dir string,
+	format string,
+) (interface{}, error) {
+	art, artifactName, dirPath, err := varFileArtifact(state, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if format != "" && !isValidVarFileFormat(format) {
+		return nil, fmt.Errorf("invalid format %s", format)
+	}
+
+	stream, err := streamer.StreamDir(lagerctx.NewContext(ctx, logger), art, dirPath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, FileNotFoundError{
+				Name:     artifactName,
+				FilePath: dirPath,
+			}
+		}
+
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	values := map[string]interface{}{}
+
+	tarReader := tar.NewReader(stream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || strings.Contains(name, "/") || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		file := path.Join(dir, name)
+
+		fileFormat, err := varFileFormat(file, format)
+		if err != nil {
+			return nil, err
+		}
+
+		fileContent, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseVarFile(file, fileFormat, fileContent)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.TrimSuffix(name, path.Ext(name))
+		if _, found := values[key]; found {
+			return nil, fmt.Errorf("more than one file in %s is named %s", dir, key)
+		}
+
+		values[key] = value
+	}
+
+	logger.Debug("loaded-dir", lager.Data{"files": len(values)})
+
+	return values, nil
+}
+
+// varFileArtifact finds the artifact a file or directory path refers to by
+// its first segment, returning the rest of the path within it.
+func varFileArtifact(state RunState, file string) (runtime.Artifact, string, string, error) {
+	segs := strings.SplitN(file, "/", 2)
+	if len(segs) != 2 {
+		return nil, "", "", UnspecifiedLoadVarStepFileError{file}
+	}
+
+	artifactName := segs[0]
+	filePath := segs[1]
+
+	art, _, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(artifactName))
+	if !found {
+		return nil, "", "", UnknownArtifactSourceError{build.ArtifactName(artifactName), filePath}
+	}
+
+	return art, artifactName, filePath, nil
+}
+
+func parseVarFile(file string, format string, fileContent []byte) (interface{}, error) {
+	var (
+		value interface{}
+		err   error
+	)
 	switch format {
 	case "json":
 		decoder := json.NewDecoder(bytes.NewReader(fileContent))
@@ -171,6 +301,21 @@ func (step *LoadVarStep) fetchVars(
 		if err != nil {
 			return nil, InvalidLocalVarFile{file, "yaml", err}
 		}
+	case "toml":
+		value, err = parseTOML(fileContent)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "toml", err}
+		}
+	case "dotenv":
+		value, err = parseDotenv(fileContent)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "dotenv", err}
+		}
+	case "properties":
+		value, err = parseProperties(fileContent)
+		if err != nil {
+			return nil, InvalidLocalVarFile{file, "properties", err}
+		}
 	case "trim":
 		value = strings.TrimSpace(string(fileContent))
 	case "raw":
@@ -187,25 +332,29 @@ func useJSONNumber(decoder *json.Decoder) *json.Decoder {
 	return decoder
 }
 
-func (step *LoadVarStep) fileFormat(file string) (string, error) {
-	if step.isValidFormat(step.plan.Format) {
-		return step.plan.Format, nil
-	} else if step.plan.Format != "" {
-		return "", fmt.Errorf("invalid format %s", step.plan.Format)
+func varFileFormat(file string, format string) (string, error) {
+	if isValidVarFileFormat(format) {
+		return format, nil
+	} else if format != "" {
+		return "", fmt.Errorf("invalid format %s", format)
 	}
 
 	fileExt := filepath.Ext(file)
-	format := strings.TrimPrefix(fileExt, ".")
-	if step.isValidFormat(format) {
+	format = strings.TrimPrefix(fileExt, ".")
+	if format == "env" {
+		format = "dotenv"
+	}
+
+	if isValidVarFileFormat(format) {
 		return format, nil
 	}
 
 	return "trim", nil
 }
 
-func (step *LoadVarStep) isValidFormat(format string) bool {
+func isValidVarFileFormat(format string) bool {
 	switch format {
-	case "raw", "trim", "yml", "yaml", "json":
+	case "raw", "trim", "yml", "yaml", "json", "toml", "dotenv", "properties":
 		return true
 	}
 	return false
@@ -1,6 +1,8 @@
 package exec_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
 	"strings"
@@ -33,6 +35,77 @@ const jsonString = `
 }
 `
 
+const tomlString = `
+# release metadata
+title = "some-release"
+version = 1.5
+
+[build]
+number = 0x2A
+tags = [
+  "stable",
+  'latest', # trailing comment
+]
+released = 2024-01-02T03:04:05Z
+
+[[artifacts]]
+name = "cli"
+platforms = { linux = true, windows = false }
+
+[[artifacts]]
+name = "server"
+notes = """
+multi-line \
+  notes"""
+`
+
+const dotenvString = `
+# release settings
+VERSION=1.2.3
+export CHANNEL = stable # trailing comment
+GREETING="hello\nworld"
+LITERAL='no \n escapes'
+MULTILINE="first
+second"
+EMPTY=
+`
+
+const propertiesString = `
+# release settings
+! also a comment
+db.host = db.example.com
+db.port:5432
+greeting hello \
+    world
+path=C:\\releases
+unicode=caf\u00e9
+`
+
+func tarStream(files map[string]string) *fakeReadCloser {
+	buf := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(buf)
+
+	err := tarWriter.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755})
+	Expect(err).ToNot(HaveOccurred())
+
+	for name, content := range files {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = tarWriter.Write([]byte(content))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Expect(tarWriter.Close()).To(Succeed())
+
+	return &fakeReadCloser{str: buf.String()}
+}
+
 var _ = Describe("LoadVarStep", func() {
 
 	var (
@@ -245,6 +318,100 @@ var _ = Describe("LoadVarStep", func() {
 				expectLocalVarAdded("some-var", map[string]interface{}{"k1": "yv1", "k2": "yv2", "k3": json.Number("123")}, true)
 			})
 		})
+
+		Context("when format is toml", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name:   "some-var",
+					File:   "some-resource/a.diff",
+					Format: "toml",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: tomlString}, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+
+			It("var should be parsed correctly", func() {
+				expectLocalVarAdded("some-var", map[string]interface{}{
+					"title":   "some-release",
+					"version": json.Number("1.5"),
+					"build": map[string]interface{}{
+						"number":   json.Number("42"),
+						"tags":     []interface{}{"stable", "latest"},
+						"released": "2024-01-02T03:04:05Z",
+					},
+					"artifacts": []interface{}{
+						map[string]interface{}{
+							"name":      "cli",
+							"platforms": map[string]interface{}{"linux": true, "windows": false},
+						},
+						map[string]interface{}{
+							"name":  "server",
+							"notes": "multi-line notes",
+						},
+					},
+				}, true)
+			})
+		})
+
+		Context("when format is dotenv", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name:   "some-var",
+					File:   "some-resource/a.diff",
+					Format: "dotenv",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: dotenvString}, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+
+			It("var should be parsed correctly", func() {
+				expectLocalVarAdded("some-var", map[string]interface{}{
+					"VERSION":   "1.2.3",
+					"CHANNEL":   "stable",
+					"GREETING":  "hello\nworld",
+					"LITERAL":   `no \n escapes`,
+					"MULTILINE": "first\nsecond",
+					"EMPTY":     "",
+				}, true)
+			})
+		})
+
+		Context("when format is properties", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name:   "some-var",
+					File:   "some-resource/a.diff",
+					Format: "properties",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: propertiesString}, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+
+			It("var should be parsed correctly", func() {
+				expectLocalVarAdded("some-var", map[string]interface{}{
+					"db.host":  "db.example.com",
+					"db.port":  "5432",
+					"greeting": "hello world",
+					"path":     `C:\releases`,
+					"unicode":  "café",
+				}, true)
+			})
+		})
 	})
 
 	Context("when format is not specified", func() {
@@ -327,6 +494,168 @@ var _ = Describe("LoadVarStep", func() {
 				expectLocalVarAdded("some-var", map[string]interface{}{"k1": "yv1", "k2": "yv2", "k3": json.Number("123")}, true)
 			})
 		})
+
+		Context("when format is toml", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/release.toml",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: tomlString}, nil)
+			})
+
+			It("var should be parsed correctly", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				_, value, _ := state.AddLocalVarArgsForCall(0)
+				Expect(value).To(HaveKeyWithValue("title", "some-release"))
+			})
+		})
+
+		Context("when format is env", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/release.env",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: dotenvString}, nil)
+			})
+
+			It("var should be parsed correctly", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				_, value, _ := state.AddLocalVarArgsForCall(0)
+				Expect(value).To(HaveKeyWithValue("VERSION", "1.2.3"))
+			})
+		})
+
+		Context("when format is properties", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/release.properties",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: propertiesString}, nil)
+			})
+
+			It("var should be parsed correctly", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				_, value, _ := state.AddLocalVarArgsForCall(0)
+				Expect(value).To(HaveKeyWithValue("db.port", "5432"))
+			})
+		})
+	})
+
+	Context("when select is given", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-resource/release.toml",
+				Select: "$.artifacts[-1].name",
+			}
+
+			fakeStreamer.StreamFileReturns(&fakeReadCloser{str: tomlString}, nil)
+		})
+
+		It("adds the selected value as the var", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			expectLocalVarAdded("some-var", "server", true)
+		})
+
+		Context("when the selected value does not exist", func() {
+			BeforeEach(func() {
+				loadVarPlan.Select = "$.build.commit"
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError("select $.build.commit: no field 'commit' in $.build"))
+				Expect(state.AddLocalVarCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when dir is given", func() {
+		BeforeEach(func() {
+			loadVarPlan = &atc.LoadVarPlan{
+				Name: "some-var",
+				Dir:  "some-resource/some-dir",
+			}
+
+			fakeStreamer.StreamDirReturns(tarStream(map[string]string{
+				"./release.env":     "VERSION=1.2.3\n",
+				"./config.json":     `{"replicas": 3}`,
+				"./commit":          "abc123\n",
+				"./.hidden.yml":     "skipped: true\n",
+				"./nested/file.yml": "skipped: true\n",
+			}), nil)
+		})
+
+		It("streams the directory out of the artifact", func() {
+			Expect(fakeStreamer.StreamDirCallCount()).To(Equal(1))
+			_, _, dir := fakeStreamer.StreamDirArgsForCall(0)
+			Expect(dir).To(Equal("some-dir"))
+		})
+
+		It("loads each file by its name, in the format of its extension", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			expectLocalVarAdded("some-var", map[string]interface{}{
+				"release": map[string]interface{}{"VERSION": "1.2.3"},
+				"config":  map[string]interface{}{"replicas": json.Number("3")},
+				"commit":  "abc123",
+			}, true)
+		})
+
+		Context("when a format is given", func() {
+			BeforeEach(func() {
+				loadVarPlan.Format = "raw"
+			})
+
+			It("loads every file in that format", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				expectLocalVarAdded("some-var", map[string]interface{}{
+					"release": "VERSION=1.2.3\n",
+					"config":  `{"replicas": 3}`,
+					"commit":  "abc123\n",
+				}, true)
+			})
+		})
+
+		Context("when select is given", func() {
+			BeforeEach(func() {
+				loadVarPlan.Select = "release.VERSION"
+			})
+
+			It("selects from the map of files", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				expectLocalVarAdded("some-var", "1.2.3", true)
+			})
+		})
+
+		Context("when two files have the same name", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamDirReturns(tarStream(map[string]string{
+					"./config.json": `{}`,
+					"./config.yml":  `{}`,
+				}), nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError("more than one file in some-resource/some-dir is named config"))
+			})
+		})
+
+		Context("when a file is invalid", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamDirReturns(tarStream(map[string]string{
+					"./config.json": `{`,
+				}), nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("failed to parse some-resource/some-dir/config.json in format json")))
+			})
+		})
 	})
 
 	Context("when file is bad", func() {
@@ -362,6 +691,36 @@ var _ = Describe("LoadVarStep", func() {
 			})
 		})
 
+		Context("when toml file is bad", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.toml",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "[build]\nnumber = 1\nnumber = 2\n"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError("failed to parse some-resource/a.toml in format toml: toml: line 3 (last key \"build.number\"): Key 'build.number' has already been defined."))
+			})
+		})
+
+		Context("when dotenv file is bad", func() {
+			BeforeEach(func() {
+				loadVarPlan = &atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-resource/a.env",
+				}
+
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "VERSION=1.2.3\nGREETING=\"hello\n"}, nil)
+			})
+
+			It("step should fail", func() {
+				Expect(stepErr).To(MatchError("failed to parse some-resource/a.env in format dotenv: line 2: unterminated quoted value"))
+			})
+		})
+
 		Context("when file path artifact is not registered", func() {
 			BeforeEach(func() {
 				loadVarPlan = &atc.LoadVarPlan{
@@ -0,0 +1,24 @@
+package exec
+
+import (
+	"context"
+)
+
+// NamedStep runs a step with the credentials it looks up attributed to the
+// step's name.
+type NamedStep struct {
+	Step
+
+	name string
+}
+
+func Named(step Step, name string) Step {
+	return NamedStep{
+		Step: step,
+		name: name,
+	}
+}
+
+func (step NamedStep) Run(ctx context.Context, state RunState) (bool, error) {
+	return step.Step.Run(ctx, state.WithStep(step.name))
+}
@@ -0,0 +1,46 @@
+package exec_test
+
+import (
+	"context"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NamedStep", func() {
+	var (
+		fakeStep  *execfakes.FakeStep
+		state     *execfakes.FakeRunState
+		stepState *execfakes.FakeRunState
+
+		runOk  bool
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		state = new(execfakes.FakeRunState)
+		stepState = new(execfakes.FakeRunState)
+		state.WithStepReturns(stepState)
+	})
+
+	JustBeforeEach(func() {
+		runOk, runErr = Named(fakeStep, "some-step").Run(context.Background(), state)
+	})
+
+	It("runs the step with its credentials attributed to its name", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(runOk).To(BeTrue())
+
+		Expect(state.WithStepCallCount()).To(Equal(1))
+		Expect(state.WithStepArgsForCall(0)).To(Equal("some-step"))
+
+		Expect(fakeStep.RunCallCount()).To(Equal(1))
+		_, runState := fakeStep.RunArgsForCall(0)
+		Expect(runState).To(Equal(stepState))
+	})
+})
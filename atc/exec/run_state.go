@@ -15,6 +15,10 @@ type runState struct {
 
 	vars *buildVariables
 
+	// step is the name of the step the credentials are being looked up for,
+	// if any.
+	step string
+
 	artifacts *build.Repository
 	results   *sync.Map
 
@@ -61,7 +65,7 @@ func (state *runState) StoreResult(id atc.PlanID, val interface{}) {
 }
 
 func (state *runState) Get(ref vars.Reference) (interface{}, bool, error) {
-	return state.vars.Get(ref)
+	return state.vars.get(ref, state.step)
 }
 
 func (state *runState) List() ([]vars.Reference, error) {
@@ -80,6 +84,12 @@ func (state *runState) NewLocalScope() RunState {
 	return &clone
 }
 
+func (state *runState) WithStep(name string) RunState {
+	clone := *state
+	clone.step = name
+	return &clone
+}
+
 func (state *runState) Parent() RunState {
 	return state.parent
 }
@@ -218,6 +218,56 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("WithStep", func() {
+		var stepVars *fakeStepVariables
+
+		BeforeEach(func() {
+			stepVars = &fakeStepVariables{
+				Variables: credVars,
+				steps:     []string{},
+			}
+
+			state = exec.NewRunState(stepper, stepVars, true)
+		})
+
+		It("looks up credentials on behalf of the step", func() {
+			val, found, err := state.WithStep("some-step").Get(vars.Reference{Path: "k1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("v1"))
+
+			Expect(stepVars.steps).To(Equal([]string{"some-step"}))
+		})
+
+		It("still tracks the credentials for redaction", func() {
+			state.WithStep("some-step").Get(vars.Reference{Path: "k1"})
+
+			mapit := vars.TrackedVarsMap{}
+			state.IterateInterpolatedCreds(mapit)
+			Expect(mapit["k1"]).To(Equal("v1"))
+		})
+
+		It("applies to local scopes created from it", func() {
+			state.WithStep("some-step").NewLocalScope().Get(vars.Reference{Path: "k1"})
+			Expect(stepVars.steps).To(Equal([]string{"some-step"}))
+		})
+
+		It("shares local vars with the original state", func() {
+			state.WithStep("some-step").AddLocalVar("l1", "from step", false)
+
+			val, found, err := state.Get(vars.Reference{Source: ".", Path: "l1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("from step"))
+		})
+
+		It("does not affect the original state", func() {
+			state.WithStep("some-step")
+			state.Get(vars.Reference{Path: "k1"})
+			Expect(stepVars.steps).To(BeEmpty())
+		})
+	})
+
 	Describe("List", func() {
 		It("returns list of names from multiple vars with duplicates", func() {
 			defs, err := state.List()
@@ -373,3 +423,14 @@ var _ = Describe("RunState", func() {
 		})
 	})
 })
+
+type fakeStepVariables struct {
+	vars.Variables
+
+	steps []string
+}
+
+func (v *fakeStepVariables) ForStep(name string) vars.Variables {
+	v.steps = append(v.steps, name)
+	return v.Variables
+}
@@ -115,25 +115,17 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 
 	delegate.Starting(logger)
 
-	warnings, errors := configvalidate.Validate(atcConfig)
-	for _, warning := range warnings {
-		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
-	}
-
-	if len(errors) > 0 {
-		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
-
-		for _, e := range errors {
-			fmt.Fprintf(stderr, "- %s", e)
-		}
-
-		delegate.Finished(logger, false)
-		return false, nil
-	}
-
 	var team db.Team
 	if step.plan.Team == "" {
-		team = step.teamFactory.GetByID(step.metadata.TeamID)
+		currentTeam, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+		if err != nil {
+			return false, err
+		}
+		if !found {
+			return false, fmt.Errorf("team %s not found", step.metadata.TeamName)
+		}
+
+		team = currentTeam
 	} else {
 		fmt.Fprintln(stderr, "\x1b[1;33mWARNING: specifying the team in a set_pipeline step is experimental and may be removed in the future!\x1b[0m")
 		fmt.Fprintln(stderr, "")
@@ -173,6 +165,29 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		team = targetTeam
 	}
 
+	err = atcConfig.ExpandTemplates(team.StepTemplates())
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid pipeline:\n- %s\n", err)
+		delegate.Finished(logger, false)
+		return false, nil
+	}
+
+	warnings, errors := configvalidate.Validate(atcConfig)
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errors) > 0 {
+		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
+
+		for _, e := range errors {
+			fmt.Fprintf(stderr, "- %s", e)
+		}
+
+		delegate.Finished(logger, false)
+		return false, nil
+	}
+
 	pipelineRef := atc.PipelineRef{
 		Name:         step.plan.Name,
 		InstanceVars: step.plan.InstanceVars,
@@ -182,6 +197,10 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, err
 	}
 
+	if found && pipeline.SourcePath() != "" {
+		return false, fmt.Errorf("pipeline '%s' is managed by the team's pipeline source (%s) and cannot be set by a set_pipeline step", pipelineRef.String(), pipeline.SourcePath())
+	}
+
 	fromVersion := db.ConfigVersion(0)
 	var existingConfig atc.Config
 	if !found {
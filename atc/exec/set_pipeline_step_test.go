@@ -170,7 +170,7 @@ jobs:
 
 		fakePipeline.NameReturns("some-pipeline")
 		fakePipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "feature/foo"})
-		fakeTeamFactory.GetByIDReturns(fakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 		fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
 
 		fakeAgent = new(policyfakes.FakeAgent)
@@ -254,6 +254,55 @@ jobs:
 			})
 		})
 
+		Context("when the pipeline uses a team template", func() {
+			BeforeEach(func() {
+				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: `
+---
+jobs:
+- name: some-job
+  plan:
+  - use_template: greet
+`}, nil)
+
+				fakeTeam.StepTemplatesReturns(atc.StepTemplates{
+					{
+						Name:  "greet",
+						Steps: []atc.Step{{Config: &atc.TaskStep{Name: "some-task", ConfigPath: "some-resource/task.yml"}}},
+					},
+				})
+
+				fakeBuild.SavePipelineReturns(fakePipeline, false, nil)
+			})
+
+			It("saves the pipeline with the template expanded", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+
+				_, _, savedConfig, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+				Expect(savedConfig.Jobs[0].PlanSequence[0].Config).To(Equal(&atc.UseTemplateStep{
+					Name:  "greet",
+					Steps: []atc.Step{{Config: &atc.TaskStep{Name: "some-task", ConfigPath: "some-resource/task.yml"}}},
+				}))
+			})
+
+			Context("when the team has no such template", func() {
+				BeforeEach(func() {
+					fakeTeam.StepTemplatesReturns(nil)
+				})
+
+				It("fails without saving the pipeline", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+					Expect(stderr).To(gbytes.Say("- jobs.some-job: unknown template 'greet'"))
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(0))
+
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+					Expect(succeeded).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when pipeline file exists but is empty", func() {
 			BeforeEach(func() {
 				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: badPipelineContentWithEmptyContent}, nil)
@@ -356,6 +405,20 @@ jobs:
 					})
 				})
 
+				Context("when the pipeline is managed by the team's pipeline source", func() {
+					BeforeEach(func() {
+						fakePipeline.SourcePathReturns("pipelines/some-pipeline.yml")
+					})
+
+					It("should return error", func() {
+						Expect(stepErr).To(MatchError(ContainSubstring("is managed by the team's pipeline source (pipelines/some-pipeline.yml)")))
+					})
+
+					It("should not save the pipeline", func() {
+						Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when policy check fails", func() {
 					BeforeEach(func() {
 						fakeDelegate.CheckRunSetPipelinePolicyReturns(errors.New("policy-check-error"))
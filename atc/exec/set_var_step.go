@@ -0,0 +1,142 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+)
+
+// SetVarStep writes a value from a file to a credential manager, so that it
+// can be used as a ((var)) by later builds.
+type SetVarStep struct {
+	planID          atc.PlanID
+	plan            atc.SetVarPlan
+	metadata        StepMetadata
+	delegateFactory SetVarStepDelegateFactory
+	teamFactory     db.TeamFactory
+	secrets         creds.Secrets
+	streamer        Streamer
+}
+
+func NewSetVarStep(
+	planID atc.PlanID,
+	plan atc.SetVarPlan,
+	metadata StepMetadata,
+	delegateFactory SetVarStepDelegateFactory,
+	teamFactory db.TeamFactory,
+	secrets creds.Secrets,
+	streamer Streamer,
+) Step {
+	return &SetVarStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+		teamFactory:     teamFactory,
+		secrets:         secrets,
+		streamer:        streamer,
+	}
+}
+
+func (step *SetVarStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.SetVarStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "set_var", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, state, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *SetVarStep) run(ctx context.Context, state RunState, delegate SetVarStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("set-var-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+
+	interpolatedPlan, err := creds.NewSetVarPlan(state, step.plan).Evaluate()
+	if err != nil {
+		return false, err
+	}
+	step.plan = interpolatedPlan
+
+	stdout := delegate.Stdout()
+	stderr := delegate.Stderr()
+
+	secrets, err := step.teamSecrets()
+	if err != nil {
+		return false, err
+	}
+
+	dest, err := creds.NewSecretDestination(secrets, step.plan.Manager, step.metadata.TeamName, step.metadata.PipelineName, step.plan.Name)
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		delegate.Finished(logger, false)
+		return false, nil
+	}
+
+	err = delegate.CheckRunSetVarPolicy(step.plan.Name, dest.Manager, dest.Path)
+	if err != nil {
+		return false, err
+	}
+
+	delegate.Starting(logger)
+
+	value, err := fetchVarFile(ctx, logger, step.streamer, state, step.plan.File, step.plan.Format)
+	if err != nil {
+		return false, err
+	}
+
+	err = dest.Set(value)
+	if err != nil {
+		if _, ok := err.(creds.SecretsNotWritableError); ok {
+			fmt.Fprintln(stderr, err.Error())
+			delegate.Finished(logger, false)
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if dest.Manager != "" {
+		fmt.Fprintf(stdout, "var %s written to %s:%s.\n", step.plan.Name, dest.Manager, dest.Path)
+	} else {
+		fmt.Fprintf(stdout, "var %s written to %s.\n", step.plan.Name, dest.Path)
+	}
+
+	delegate.Finished(logger, true)
+
+	return true, nil
+}
+
+// teamSecrets orders the credential managers by the team's precedence, so
+// that a var is written to the one it is read from first.
+func (step *SetVarStep) teamSecrets() (creds.Secrets, error) {
+	members := creds.Members(step.secrets, "")
+	if len(members) < 2 {
+		return step.secrets, nil
+	}
+
+	team, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return step.secrets, nil
+	}
+
+	return creds.SecretsChain(members).Reorder(team.CredentialManagers()), nil
+}
@@ -0,0 +1,239 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/atc/creds/noop"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/tracing"
+)
+
+var _ = Describe("SetVarStep", func() {
+	var (
+		ctx        context.Context
+		cancel     func()
+		testLogger *lagertest.TestLogger
+
+		fakeDelegate        *execfakes.FakeSetVarStepDelegate
+		fakeDelegateFactory *execfakes.FakeSetVarStepDelegateFactory
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeStreamer    *execfakes.FakeStreamer
+
+		vaultSecrets   creds.Secrets
+		credhubSecrets creds.Secrets
+		secrets        creds.Secrets
+
+		setVarPlan         *atc.SetVarPlan
+		artifactRepository *build.Repository
+		state              *execfakes.FakeRunState
+
+		step    exec.Step
+		stepOk  bool
+		stepErr error
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "some-build",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+		}
+
+		stdout, stderr *gbytes.Buffer
+
+		planID = "56"
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("set-var-step-test")
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		artifactRepository = build.NewRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactRepositoryReturns(artifactRepository)
+
+		artifactRepository.RegisterArtifact("some-resource", runtimetest.NewVolume("some-handle"), false)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetVarStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+		fakeDelegate.StartSpanReturns(context.Background(), tracing.NoopSpan)
+
+		fakeDelegateFactory = new(execfakes.FakeSetVarStepDelegateFactory)
+		fakeDelegateFactory.SetVarStepDelegateReturns(fakeDelegate)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		fakeStreamer = new(execfakes.FakeStreamer)
+		fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "  some-token\n"}, nil)
+
+		vaultSecrets = dummy.NewSecretsFactory(nil).NewSecrets()
+		credhubSecrets = dummy.NewSecretsFactory(nil).NewSecrets()
+		secrets = creds.SecretsChain{
+			{Name: "vault", Secrets: vaultSecrets},
+			{Name: "credhub", Secrets: credhubSecrets},
+		}
+
+		setVarPlan = &atc.SetVarPlan{
+			Name: "some-var",
+			File: "some-resource/token",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetVarStep(
+			atc.PlanID(planID),
+			*setVarPlan,
+			stepMetadata,
+			fakeDelegateFactory,
+			fakeTeamFactory,
+			secrets,
+			fakeStreamer,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	secretAt := func(secrets creds.Secrets, path string) interface{} {
+		value, _, found, err := secrets.Get(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return value
+	}
+
+	It("writes the value to the first credential manager at the pipeline's path", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(stepOk).To(BeTrue())
+
+		Expect(secretAt(vaultSecrets, "some-team/some-pipeline/some-var")).To(Equal("some-token"))
+		Expect(stdout).To(gbytes.Say("var some-var written to vault:some-team/some-pipeline/some-var."))
+	})
+
+	It("checks the policy without the value", func() {
+		Expect(fakeDelegate.CheckRunSetVarPolicyCallCount()).To(Equal(1))
+		varName, manager, path := fakeDelegate.CheckRunSetVarPolicyArgsForCall(0)
+		Expect(varName).To(Equal("some-var"))
+		Expect(manager).To(Equal("vault"))
+		Expect(path).To(Equal("some-team/some-pipeline/some-var"))
+	})
+
+	It("reads the file from the artifact", func() {
+		Expect(fakeStreamer.StreamFileCallCount()).To(Equal(1))
+		_, _, path := fakeStreamer.StreamFileArgsForCall(0)
+		Expect(path).To(Equal("token"))
+	})
+
+	Context("when the file is yaml", func() {
+		BeforeEach(func() {
+			setVarPlan.File = "some-resource/creds.yml"
+			fakeStreamer.StreamFileReturns(&fakeReadCloser{str: "username: admin\n"}, nil)
+		})
+
+		It("writes the parsed value", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(secretAt(vaultSecrets, "some-team/some-pipeline/some-var")).To(Equal(map[string]interface{}{"username": "admin"}))
+		})
+	})
+
+	Context("when the team prefers another credential manager", func() {
+		BeforeEach(func() {
+			fakeTeam.CredentialManagersReturns([]string{"credhub"})
+		})
+
+		It("writes to the preferred credential manager", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(secretAt(credhubSecrets, "some-team/some-pipeline/some-var")).To(Equal("some-token"))
+		})
+	})
+
+	Context("when a credential manager is specified", func() {
+		BeforeEach(func() {
+			setVarPlan.Manager = "credhub"
+		})
+
+		It("writes to it", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(secretAt(credhubSecrets, "some-team/some-pipeline/some-var")).To(Equal("some-token"))
+		})
+	})
+
+	Context("when the credential manager is unknown", func() {
+		BeforeEach(func() {
+			setVarPlan.Manager = "bogus"
+		})
+
+		It("fails without writing", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(stderr).To(gbytes.Say("unknown credential manager 'bogus'"))
+			Expect(fakeStreamer.StreamFileCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the build is not of a pipeline", func() {
+		BeforeEach(func() {
+			pipelineName := stepMetadata.PipelineName
+			stepMetadata.PipelineName = ""
+			DeferCleanup(func() {
+				stepMetadata.PipelineName = pipelineName
+			})
+		})
+
+		It("fails without writing", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(stderr).To(gbytes.Say("vars can only be set by the builds of a pipeline"))
+			Expect(fakeStreamer.StreamFileCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the credential manager is not writable", func() {
+		BeforeEach(func() {
+			secrets = creds.SecretsChain{{Name: "noop", Secrets: noop.Noop{}}}
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+			Expect(stderr).To(gbytes.Say("the credential manager 'noop' does not support writing secrets"))
+		})
+	})
+
+	Context("when the policy check fails", func() {
+		BeforeEach(func() {
+			fakeDelegate.CheckRunSetVarPolicyReturns(errors.New("policy-check-error"))
+		})
+
+		It("errors without reading the file", func() {
+			Expect(stepErr).To(MatchError("policy-check-error"))
+			Expect(fakeStreamer.StreamFileCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -56,9 +56,21 @@ type RunState interface {
 
 	Run(context.Context, atc.Plan) (bool, error)
 
+	// WithStep returns the same state, but with the credentials it looks up
+	// attributed to the named step.
+	WithStep(name string) RunState
+
 	Parent() RunState
 }
 
+// StepVariables are credential variables which can attribute the credentials
+// looked up by each step to it, e.g. for auditing.
+type StepVariables interface {
+	vars.Variables
+
+	ForStep(name string) vars.Variables
+}
+
 // ExitStatus is the resulting exit code from the process that the step ran.
 // Typically if the ExitStatus result is 0, the Success result is true.
 type ExitStatus int
@@ -76,6 +88,7 @@ type Pool interface {
 	FindOrSelectWorker(context.Context, db.ContainerOwner, runtime.ContainerSpec, worker.Spec, worker.PlacementStrategy, worker.PoolCallback) (runtime.Worker, error)
 	FindResourceCacheVolume(context.Context, int, db.ResourceCache, worker.Spec, time.Time) (runtime.Volume, bool, error)
 	FindResourceCacheVolumeOnWorker(context.Context, db.ResourceCache, worker.Spec, string, time.Time) (runtime.Volume, bool, error)
+	FindTaskCacheVolume(ctx context.Context, teamID int, jobID int, stepName string, path string, workerSpec worker.Spec) (runtime.Volume, bool, error)
 	ReleaseWorker(lager.Logger, runtime.ContainerSpec, runtime.Worker, worker.PlacementStrategy)
 	LocateVolume(ctx context.Context, teamID int, handle string) (runtime.Volume, runtime.Worker, bool, error)
 }
@@ -84,4 +97,8 @@ type Pool interface {
 
 type Streamer interface {
 	StreamFile(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error)
+
+	// StreamDir streams the directory at path out of the artifact as an
+	// uncompressed tar stream.
+	StreamDir(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error)
 }
@@ -0,0 +1,170 @@
+package exec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+// taskMemo is everything that goes into a run of a memoized task. A task
+// which has already succeeded with the same taskMemo is not run again.
+type taskMemo struct {
+	Config     atc.TaskConfig `json:"config"`
+	Privileged bool           `json:"privileged"`
+
+	// Image and Inputs are the digests of the task's image and of the
+	// artifacts given to each of its inputs.
+	Image  string            `json:"image,omitempty"`
+	Inputs map[string]string `json:"inputs"`
+}
+
+func resourceCacheDigest(resourceCache db.ResourceCache) string {
+	return fmt.Sprintf("resource-cache:%d", resourceCache.ID())
+}
+
+// memoKey works out the key that the outputs of the task's run are kept
+// under. If the task can't be memoized because the contents of its image or
+// one of its inputs aren't known, it returns why instead.
+func (step *TaskStep) memoKey(repository *build.Repository, config atc.TaskConfig, imageDigest string) (string, string) {
+	if imageDigest == "" && (step.plan.ImageArtifactName != "" || config.ImageResource != nil) {
+		return "", "the task's image was not fetched by a get step or produced by a memoized task"
+	}
+
+	memo := taskMemo{
+		Config:     config,
+		Privileged: bool(step.plan.Privileged),
+		Image:      imageDigest,
+		Inputs:     map[string]string{},
+	}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.plan.InputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		digest, found := repository.DigestFor(build.ArtifactName(inputName))
+		if !found {
+			// only optional inputs can be missing by now
+			continue
+		}
+
+		if digest == "" {
+			return "", fmt.Sprintf("input '%s' was not fetched by a get step or produced by a memoized task", input.Name)
+		}
+
+		memo.Inputs[input.Name] = digest
+	}
+
+	payload, err := json.Marshal(memo)
+	if err != nil {
+		return "", fmt.Sprintf("failed to encode key: %s", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), ""
+}
+
+// findMemoizedOutputs finds the outputs kept from a successful run of the
+// task with the given key. Every output has to have been kept for the run to
+// be skipped.
+func (step *TaskStep) findMemoizedOutputs(ctx context.Context, config atc.TaskConfig, key string) (map[string]runtime.Volume, bool, error) {
+	_, found, err := step.taskCacheFactory.Find(step.metadata.JobID, step.plan.Name, db.TaskMemoPath(key, ""))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	volumes := map[string]runtime.Volume{}
+	for _, output := range config.Outputs {
+		volume, found, err := step.workerPool.FindTaskCacheVolume(
+			ctx,
+			step.metadata.TeamID,
+			step.metadata.JobID,
+			step.plan.Name,
+			db.TaskMemoPath(key, output.Name),
+			step.workerSpec(config),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !found {
+			return nil, false, nil
+		}
+
+		volumes[output.Name] = volume
+	}
+
+	return volumes, true, nil
+}
+
+func (step *TaskStep) registerMemoizedOutputs(repository *build.Repository, outputs map[string]runtime.Volume, key string) {
+	for name, volume := range outputs {
+		outputName := name
+		if destinationName, ok := step.plan.OutputMapping[name]; ok {
+			outputName = destinationName
+		}
+
+		repository.RegisterDigestedArtifact(build.ArtifactName(outputName), volume, false, db.TaskMemoPath(key, name))
+	}
+}
+
+// memoizedVolumeMounts mounts the kept outputs where the task's run would
+// have had them, so that they can be published and have their reports read
+// as if the task had run.
+func memoizedVolumeMounts(config atc.TaskConfig, outputs map[string]runtime.Volume, metadata db.ContainerMetadata) []runtime.VolumeMount {
+	var volumeMounts []runtime.VolumeMount
+	for _, output := range config.Outputs {
+		volume, found := outputs[output.Name]
+		if !found {
+			continue
+		}
+
+		volumeMounts = append(volumeMounts, runtime.VolumeMount{
+			Volume:    volume,
+			MountPath: artifactPath(metadata.WorkingDirectory, output.Name, output.Path),
+		})
+	}
+
+	return volumeMounts
+}
+
+// saveMemo keeps the outputs of a successful run of the task as task caches,
+// and then records that the run succeeded. The run is only recorded if every
+// output can be kept, as it can't be skipped otherwise.
+func (step *TaskStep) saveMemo(ctx context.Context, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata, key string) error {
+	volumes := map[string]runtime.Volume{}
+	for _, output := range config.Outputs {
+		volume, found := outputVolume(config, output.Name, volumeMounts, metadata)
+		if !found {
+			return nil
+		}
+
+		volumes[output.Name] = volume
+	}
+
+	for _, output := range config.Outputs {
+		err := volumes[output.Name].InitializeTaskCache(
+			ctx,
+			step.metadata.JobID,
+			step.plan.Name,
+			db.TaskMemoPath(key, output.Name),
+			bool(step.plan.Privileged),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := step.taskCacheFactory.FindOrCreate(step.metadata.JobID, step.plan.Name, db.TaskMemoPath(key, ""))
+	return err
+}
@@ -0,0 +1,262 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+const serviceProcessID = "service"
+
+// containerIPProperty is the container property in which workers give the IP
+// that a container has on the worker's network.
+const containerIPProperty = "garden.network.container-ip"
+
+// serviceReadinessInterval is how long to wait between runs of a service's
+// readiness command.
+const serviceReadinessInterval = time.Second
+
+// DefaultServiceReadinessTimeout is how long a service has to become ready
+// if its readiness_timeout isn't set.
+const DefaultServiceReadinessTimeout = 5 * time.Minute
+
+type ServiceExitedError struct {
+	Name       string
+	ExitStatus int
+}
+
+func (e ServiceExitedError) Error() string {
+	return fmt.Sprintf("service '%s' exited with status %d before it was ready", e.Name, e.ExitStatus)
+}
+
+type ServiceNotReadyError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e ServiceNotReadyError) Error() string {
+	return fmt.Sprintf("service '%s' was not ready within %s", e.Name, e.Timeout)
+}
+
+type ServiceAddressUnknownError struct {
+	Name   string
+	Worker string
+}
+
+func (e ServiceAddressUnknownError) Error() string {
+	return fmt.Sprintf("worker '%s' did not give the address of service '%s'", e.Worker, e.Name)
+}
+
+func servicePlanID(planID atc.PlanID, name string) atc.PlanID {
+	return planID + "/services/" + atc.PlanID(name)
+}
+
+// serviceContainerMetadata labels a service's container as the task's
+// service rather than the task itself, e.g. for fly containers and hijack.
+func serviceContainerMetadata(metadata db.ContainerMetadata, name string) db.ContainerMetadata {
+	metadata.StepName = metadata.StepName + "/services/" + name
+	return metadata
+}
+
+// serviceHostEnv is the env var through which the task is given the address
+// of a service, e.g. POSTGRES_HOST for a service named postgres. Service names
+// are made of lowercase letters, numbers and hyphens, so each has its own.
+func serviceHostEnv(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_HOST"
+}
+
+// startServices starts the task's services on the worker that the task runs
+// on and waits for each of them to be ready. It returns the env vars through
+// which the task reaches them, along with a func which stops them.
+//
+// The addresses are given to the task as env vars rather than by changing its
+// container, e.g. its /etc/hosts, so that they are there for images without a
+// shell and aren't added again when the task's container is found again.
+func (step *TaskStep) startServices(ctx context.Context, logger lager.Logger, delegate TaskDelegate, worker runtime.Worker, config atc.TaskConfig) ([]string, func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	wg := new(sync.WaitGroup)
+	stop := func() {
+		cancel()
+		wg.Wait()
+	}
+
+	var env []string
+	for _, service := range config.Services {
+		ip, err := step.startService(ctx, logger, delegate, worker, service, wg)
+		if err != nil {
+			stop()
+			return nil, nil, err
+		}
+
+		env = append(env, serviceHostEnv(service.Name)+"="+ip)
+	}
+
+	return env, stop, nil
+}
+
+func (step *TaskStep) startService(ctx context.Context, logger lager.Logger, delegate TaskDelegate, worker runtime.Worker, service atc.TaskServiceConfig, wg *sync.WaitGroup) (string, error) {
+	logger = logger.Session("service", lager.Data{"service": service.Name})
+
+	planID := servicePlanID(step.planID, service.Name)
+	serviceDelegate := delegate.ServiceDelegate(planID)
+
+	serviceDelegate.Initializing(logger)
+
+	image := *service.ImageResource
+	image.Name = "image"
+
+	getPlan, checkPlan := atc.FetchImagePlan(planID, image, step.plan.ResourceTypes, step.plan.Tags, step.plan.CheckSkipInterval, nil)
+
+	imageSpec, _, err := serviceDelegate.FetchImage(ctx, getPlan, checkPlan, bool(step.plan.Privileged))
+	if err != nil {
+		return "", err
+	}
+
+	containerSpec := runtime.ContainerSpec{
+		TeamID:   step.metadata.TeamID,
+		TeamName: step.metadata.TeamName,
+
+		ImageSpec: imageSpec,
+		Env:       service.Env.Env(),
+		Type:      step.containerMetadata.Type,
+
+		Dir: step.containerMetadata.WorkingDirectory,
+	}
+
+	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, planID, step.metadata.TeamID)
+
+	container, _, err := worker.FindOrCreateContainer(ctx, owner, serviceContainerMetadata(step.containerMetadata, service.Name), containerSpec, serviceDelegate)
+	if err != nil {
+		return "", err
+	}
+
+	properties, err := container.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	ip, found := properties[containerIPProperty]
+	if !found {
+		return "", ServiceAddressUnknownError{Name: service.Name, Worker: worker.Name()}
+	}
+
+	serviceDelegate.Starting(logger)
+
+	process, err := attachOrRun(
+		ctx,
+		container,
+		runtime.ProcessSpec{
+			ID:   serviceProcessID,
+			Path: service.Run.Path,
+			Args: service.Run.Args,
+			Dir:  resolvePath(step.containerMetadata.WorkingDirectory, service.Run.Dir),
+			User: service.Run.User,
+		},
+		runtime.ProcessIO{
+			Stdout: serviceDelegate.Stdout(),
+			Stderr: serviceDelegate.Stderr(),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	exited := make(chan runtime.ProcessResult, 1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		result, err := process.Wait(ctx)
+		if err != nil {
+			// the service is stopped by cancelling ctx once the task is done,
+			// which is how it is expected to finish
+			serviceDelegate.Finished(logger, ctx.Err() != nil)
+			return
+		}
+
+		logger.Info("exited", lager.Data{"exit-status": result.ExitStatus})
+		serviceDelegate.Finished(logger, result.ExitStatus == 0)
+
+		exited <- result
+	}()
+
+	if service.Readiness == nil {
+		return ip, nil
+	}
+
+	timeout := DefaultServiceReadinessTimeout
+	if service.ReadinessTimeout != "" {
+		timeout, err = time.ParseDuration(service.ReadinessTimeout)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	readyCtx, cancelReady := context.WithTimeout(ctx, timeout)
+	defer cancelReady()
+
+	notReady := func() error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ServiceNotReadyError{Name: service.Name, Timeout: timeout}
+	}
+
+	for {
+		ready, err := step.serviceReady(readyCtx, container, serviceDelegate, *service.Readiness)
+		if err != nil {
+			if readyCtx.Err() != nil {
+				return "", notReady()
+			}
+			return "", err
+		}
+
+		if ready {
+			logger.Info("ready")
+			return ip, nil
+		}
+
+		select {
+		case result := <-exited:
+			return "", ServiceExitedError{Name: service.Name, ExitStatus: result.ExitStatus}
+		case <-readyCtx.Done():
+			return "", notReady()
+		case <-time.After(serviceReadinessInterval):
+		}
+	}
+}
+
+func (step *TaskStep) serviceReady(ctx context.Context, container runtime.Container, serviceDelegate BuildStepDelegate, readiness atc.TaskRunConfig) (bool, error) {
+	process, err := container.Run(
+		ctx,
+		runtime.ProcessSpec{
+			Path: readiness.Path,
+			Args: readiness.Args,
+			Dir:  resolvePath(step.containerMetadata.WorkingDirectory, readiness.Dir),
+			User: readiness.User,
+		},
+		runtime.ProcessIO{
+			Stdout: serviceDelegate.Stdout(),
+			Stderr: serviceDelegate.Stderr(),
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := process.Wait(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ExitStatus == 0, nil
+}
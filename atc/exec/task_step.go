@@ -1,10 +1,12 @@
 package exec
 
 import (
+	"archive/tar"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -12,9 +14,11 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/lager/v3/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/testreport"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -61,7 +65,7 @@ type TaskDelegateFactory interface {
 type TaskDelegate interface {
 	StartSpan(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 
-	FetchImage(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, error)
+	FetchImage(context.Context, atc.ImageResource, atc.ResourceTypes, bool, atc.Tags, bool) (runtime.ImageSpec, db.ResourceCache, error)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
@@ -79,6 +83,12 @@ type TaskDelegate interface {
 	StreamingVolume(lager.Logger, string, string, string)
 	WaitingForStreamedVolume(lager.Logger, string, string)
 	BuildStartTime() time.Time
+
+	SaveTestResults(lager.Logger, string, []atc.TestResult) error
+
+	// ServiceDelegate gives the delegate for one of the task's services, under
+	// the given plan ID, so that its events have an origin of their own.
+	ServiceDelegate(atc.PlanID) BuildStepDelegate
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -91,6 +101,7 @@ type TaskStep struct {
 	containerMetadata  db.ContainerMetadata
 	strategy           worker.PlacementStrategy
 	workerPool         Pool
+	taskCacheFactory   db.TaskCacheFactory
 	streamer           Streamer
 	delegateFactory    TaskDelegateFactory
 	defaultTaskTimeout time.Duration
@@ -104,6 +115,7 @@ func NewTaskStep(
 	containerMetadata db.ContainerMetadata,
 	strategy worker.PlacementStrategy,
 	workerPool Pool,
+	taskCacheFactory db.TaskCacheFactory,
 	streamer Streamer,
 	delegateFactory TaskDelegateFactory,
 	defaultTaskTimeout time.Duration,
@@ -116,6 +128,7 @@ func NewTaskStep(
 		containerMetadata:  containerMetadata,
 		strategy:           strategy,
 		workerPool:         workerPool,
+		taskCacheFactory:   taskCacheFactory,
 		streamer:           streamer,
 		delegateFactory:    delegateFactory,
 		defaultTaskTimeout: defaultTaskTimeout,
@@ -130,13 +143,19 @@ func NewTaskStep(
 // If any inputs are not available in the artifact.Repository, MissingInputsError
 // is returned.
 //
-// Once all the inputs are satisfied, the task's script will be executed. If
-// the task is canceled via the context, the script will be interrupted.
+// Once all the inputs are satisfied, the task's services are started on the
+// same worker and the task's script will be executed. If the task is canceled
+// via the context, the script will be interrupted. The services are stopped
+// once the script has finished.
 //
 // If the script exits successfully, the outputs specified in the TaskConfig
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an StreamableArtifactSource under the
 // name of the task.
+//
+// If the task is memoized and has already succeeded with the same config,
+// image and inputs, the outputs kept from then are registered instead of
+// running the script.
 func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.TaskDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "task", tracing.Attrs{
@@ -229,7 +248,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	delegate.Initializing(logger)
 
-	imageSpec, err := step.imageSpec(ctx, logger, state, delegate, config)
+	imageSpec, imageDigest, err := step.imageSpec(ctx, logger, state, delegate, config)
 	if err != nil {
 		return false, err
 	}
@@ -240,6 +259,42 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	}
 	tracing.Inject(ctx, &containerSpec)
 
+	// Do not memoize one-off builds, as with caches
+	var memoKey string
+	if step.plan.Memoize && step.metadata.JobID != 0 {
+		key, reason := step.memoKey(repository, config, imageDigest)
+		if reason != "" {
+			fmt.Fprintln(delegate.Stderr(), "[WARNING] not memoizing:", reason)
+		} else {
+			memoKey = key
+
+			outputs, found, err := step.findMemoizedOutputs(ctx, config, memoKey)
+			if err != nil {
+				return false, err
+			}
+
+			if found {
+				logger.Info("memoized", lager.Data{"key": memoKey})
+				fmt.Fprintln(delegate.Stdout(), "the task has already succeeded with the same config, image and inputs; using its outputs from then")
+
+				step.registerMemoizedOutputs(repository, outputs, memoKey)
+
+				volumeMounts := memoizedVolumeMounts(config, outputs, step.containerMetadata)
+
+				if err := step.publishOutputs(logger, config, volumeMounts, step.containerMetadata); err != nil {
+					return false, err
+				}
+
+				if err := step.readReports(ctx, logger, delegate, config, volumeMounts, step.containerMetadata); err != nil {
+					return false, err
+				}
+
+				delegate.Finished(logger, ExitStatus(0))
+				return true, nil
+			}
+		}
+	}
+
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
 
 	err = delegate.BeforeSelectWorker(logger)
@@ -278,6 +333,14 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	delegate.SelectedWorker(logger, worker.Name())
 
+	serviceEnv, stopServices, err := step.startServices(ctx, logger, delegate, worker, config)
+	if err != nil {
+		return false, err
+	}
+	defer stopServices()
+
+	containerSpec.Env = append(containerSpec.Env, serviceEnv...)
+
 	container, volumeMounts, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec, delegate)
 	if err != nil {
 		return false, err
@@ -313,7 +376,20 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	result, runErr := process.Wait(ctx)
 
-	step.registerOutputs(logger, repository, config, volumeMounts, step.containerMetadata)
+	// only what a successful run produced is memoized
+	if runErr != nil || result.ExitStatus != 0 {
+		memoKey = ""
+	}
+
+	step.registerOutputs(logger, repository, config, volumeMounts, step.containerMetadata, memoKey)
+
+	if err := step.publishOutputs(logger, config, volumeMounts, step.containerMetadata); err != nil {
+		return false, err
+	}
+
+	if err := step.readReports(ctx, logger, delegate, config, volumeMounts, step.containerMetadata); err != nil {
+		return false, err
+	}
 
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
@@ -322,6 +398,12 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		}
 	}
 
+	if memoKey != "" {
+		if err := step.saveMemo(ctx, config, volumeMounts, step.containerMetadata, memoKey); err != nil {
+			return false, err
+		}
+	}
+
 	if runErr != nil {
 		if errors.Is(runErr, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
@@ -343,7 +425,9 @@ func attachOrRun(ctx context.Context, container runtime.Container, spec runtime.
 	return container.Run(ctx, spec, io)
 }
 
-func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state RunState, delegate TaskDelegate, config atc.TaskConfig) (runtime.ImageSpec, error) {
+// imageSpec also returns the digest of the image's contents, if they are
+// known.
+func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state RunState, delegate TaskDelegate, config atc.TaskConfig) (runtime.ImageSpec, string, error) {
 	imageSpec := runtime.ImageSpec{
 		Privileged: bool(step.plan.Privileged),
 	}
@@ -353,13 +437,16 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 	if step.plan.ImageArtifactName != "" {
 		artifact, _, found := state.ArtifactRepository().ArtifactFor(build.ArtifactName(step.plan.ImageArtifactName))
 		if !found {
-			return runtime.ImageSpec{}, MissingTaskImageSourceError{step.plan.ImageArtifactName}
+			return runtime.ImageSpec{}, "", MissingTaskImageSourceError{step.plan.ImageArtifactName}
 		}
 		imageSpec.ImageArtifact = artifact
 
+		digest, _ := state.ArtifactRepository().DigestFor(build.ArtifactName(step.plan.ImageArtifactName))
+		return imageSpec, digest, nil
+
 		//an image_resource
 	} else if config.ImageResource != nil {
-		imageSpec, err := delegate.FetchImage(
+		imageSpec, resourceCache, err := delegate.FetchImage(
 			ctx,
 			*config.ImageResource,
 			step.plan.ResourceTypes,
@@ -367,14 +454,23 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 			step.plan.Tags,
 			step.plan.CheckSkipInterval,
 		)
-		return imageSpec, err
+		if err != nil {
+			return runtime.ImageSpec{}, "", err
+		}
+
+		var digest string
+		if resourceCache != nil {
+			digest = resourceCacheDigest(resourceCache)
+		}
+
+		return imageSpec, digest, nil
 
 		// a rootfs_uri
 	} else if config.RootfsURI != "" {
 		imageSpec.ImageURL = config.RootfsURI
 	}
 
-	return imageSpec, nil
+	return imageSpec, imageSpec.ImageURL, nil
 }
 
 func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, metadata db.ContainerMetadata) ([]runtime.Input, error) {
@@ -459,7 +555,9 @@ func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.Spec {
 	}
 }
 
-func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) {
+// registerOutputs gives the outputs digests if they are being memoized under
+// memoKey.
+func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata, memoKey string) {
 	logger.Debug("registering-outputs", lager.Data{"outputs": config.Outputs})
 
 	for _, output := range config.Outputs {
@@ -470,12 +568,164 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Rep
 
 		outputPath := artifactPath(metadata.WorkingDirectory, output.Name, output.Path)
 
+		var digest string
+		if memoKey != "" {
+			digest = db.TaskMemoPath(memoKey, output.Name)
+		}
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				repository.RegisterDigestedArtifact(build.ArtifactName(outputName), mount.Volume, false, digest)
+			}
+		}
+	}
+}
+
+func (step *TaskStep) publishOutputs(logger lager.Logger, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) error {
+	for _, output := range config.Outputs {
+		if !output.Publish {
+			continue
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		outputPath := artifactPath(metadata.WorkingDirectory, output.Name, output.Path)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) != filepath.Clean(outputPath) {
+				continue
+			}
+
+			artifact, err := mount.Volume.DBVolume().PublishArtifact(outputName, step.metadata.BuildID)
+			if err != nil {
+				return fmt.Errorf("publish output '%s': %w", outputName, err)
+			}
+
+			logger.Info("published-output", lager.Data{
+				"output":      outputName,
+				"handle":      mount.Volume.Handle(),
+				"artifact-id": artifact.ID(),
+			})
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// readReports parses the test reports written to the task's outputs. A report
+// that can't be read is only warned about, as the task itself has already
+// run.
+func (step *TaskStep) readReports(ctx context.Context, logger lager.Logger, delegate TaskDelegate, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) error {
+	var results []atc.TestResult
+
+	for _, report := range config.Reports {
+		volume, found := outputVolume(config, report.Output, volumeMounts, metadata)
+		if !found {
+			fmt.Fprintf(delegate.Stderr(), "[WARNING] output '%s' for %s report not found\n", report.Output, report.Format)
+			continue
+		}
+
+		reportResults, err := readReport(ctx, volume, report)
+		if err != nil {
+			logger.Error("failed-to-read-report", err, lager.Data{"output": report.Output, "path": report.Path})
+			fmt.Fprintf(delegate.Stderr(), "[WARNING] failed to read %s report from '%s': %s\n", report.Format, filepath.Join(report.Output, report.Path), err)
+			continue
+		}
+
+		results = append(results, reportResults...)
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return delegate.SaveTestResults(logger, step.plan.Name, results)
+}
+
+func outputVolume(config atc.TaskConfig, name string, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) (runtime.Volume, bool) {
+	for _, output := range config.Outputs {
+		if output.Name != name {
+			continue
+		}
+
+		outputPath := artifactPath(metadata.WorkingDirectory, output.Name, output.Path)
+
 		for _, mount := range volumeMounts {
 			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
-				repository.RegisterArtifact(build.ArtifactName(outputName), mount.Volume, false)
+				return mount.Volume, true
 			}
 		}
 	}
+
+	return nil, false
+}
+
+func readReport(ctx context.Context, volume runtime.Volume, report atc.TaskReportConfig) ([]atc.TestResult, error) {
+	reportPath := report.Path
+	if reportPath == "" {
+		reportPath = "."
+	}
+
+	gzipCompression := compression.NewGzipCompression()
+
+	stream, err := volume.StreamOut(ctx, reportPath, gzipCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	reader, err := gzipCompression.NewReader(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	tarReader := tar.NewReader(reader)
+
+	var results []atc.TestResult
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// a report given by its path is read whatever it is called, but
+		// files within a report directory are filtered by their extension
+		name := path.Clean(header.Name)
+		if path.Base(name) != path.Base(reportPath) && !testreport.IsReportFile(report.Format, name) {
+			continue
+		}
+
+		fileResults, err := testreport.Parse(report.Format, tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		for i := range fileResults {
+			if fileResults[i].Suite == "" {
+				fileResults[i].Suite = name
+			}
+		}
+
+		results = append(results, fileResults...)
+	}
+
+	return results, nil
 }
 
 func (step *TaskStep) registerCaches(ctx context.Context, repository *build.Repository, config atc.TaskConfig, volumeMounts []runtime.VolumeMount, metadata db.ContainerMetadata) error {
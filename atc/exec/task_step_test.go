@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -32,8 +33,9 @@ var _ = Describe("TaskStep", func() {
 		stdoutBuf *gbytes.Buffer
 		stderrBuf *gbytes.Buffer
 
-		fakePool     *execfakes.FakePool
-		fakeStreamer *execfakes.FakeStreamer
+		fakePool             *execfakes.FakePool
+		fakeTaskCacheFactory *dbfakes.FakeTaskCacheFactory
+		fakeStreamer         *execfakes.FakeStreamer
 
 		fakeDelegate *execfakes.FakeTaskDelegate
 
@@ -77,6 +79,7 @@ var _ = Describe("TaskStep", func() {
 		stderrBuf = gbytes.NewBuffer()
 
 		fakeStreamer = new(execfakes.FakeStreamer)
+		fakeTaskCacheFactory = new(dbfakes.FakeTaskCacheFactory)
 
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
@@ -119,6 +122,7 @@ var _ = Describe("TaskStep", func() {
 			containerMetadata,
 			nil,
 			fakePool,
+			fakeTaskCacheFactory,
 			fakeStreamer,
 			fakeDelegateFactory,
 			defaultTaskTimeout,
@@ -677,6 +681,291 @@ var _ = Describe("TaskStep", func() {
 					},
 				}))
 			})
+
+			It("does not publish them", func() {
+				Expect(outputVolume1.DBVolume_.PublishArtifactCallCount()).To(BeZero())
+				Expect(outputVolume2.DBVolume_.PublishArtifactCallCount()).To(BeZero())
+				Expect(outputVolume3.DBVolume_.PublishArtifactCallCount()).To(BeZero())
+			})
+
+			Context("when outputs are published", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Outputs[1].Publish = true
+
+					outputVolume2.DBVolume_.PublishArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
+				})
+
+				It("publishes them as artifacts of the build under their mapped name", func() {
+					Expect(outputVolume1.DBVolume_.PublishArtifactCallCount()).To(BeZero())
+					Expect(outputVolume2.DBVolume_.PublishArtifactCallCount()).To(Equal(1))
+
+					name, buildID := outputVolume2.DBVolume_.PublishArtifactArgsForCall(0)
+					Expect(name).To(Equal("some-remapped-output"))
+					Expect(buildID).To(Equal(stepMetadata.BuildID))
+				})
+
+				Context("when publishing fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						outputVolume2.DBVolume_.PublishArtifactReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(stepErr).To(MatchError(disaster))
+					})
+				})
+			})
+
+			It("does not save any test results", func() {
+				Expect(fakeDelegate.SaveTestResultsCallCount()).To(BeZero())
+			})
+
+			Context("when the configuration specifies reports", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Reports = []atc.TaskReportConfig{
+						{Format: atc.TestReportFormatJUnit, Output: "some-output", Path: "reports"},
+						{Format: atc.TestReportFormatTAP, Output: "some-other-output", Path: "results.tap"},
+					}
+
+					*outputVolume1 = *outputVolume1.WithContent(runtimetest.VolumeContent{
+						"reports/unit.xml": {Data: []byte(`<testsuite name="unit">
+  <testcase classname="unit" name="passes" time="0.5"/>
+  <testcase classname="unit" name="fails" time="1.5"><failure message="expected true"/></testcase>
+</testsuite>`)},
+						"reports/README.md": {Data: []byte("not a report")},
+					})
+
+					*outputVolume2 = *outputVolume2.WithContent(runtimetest.VolumeContent{
+						"results.tap": {Data: []byte("1..1\nok 1 - works\n")},
+					})
+				})
+
+				It("saves the results of every report under the step name", func() {
+					Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+
+					_, stepName, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+					Expect(stepName).To(Equal("some-task"))
+					Expect(results).To(Equal([]atc.TestResult{
+						{Suite: "unit", Name: "passes", Status: atc.TestStatusPassed, Duration: 0.5},
+						{Suite: "unit", Name: "fails", Status: atc.TestStatusFailed, Duration: 1.5, Message: "expected true"},
+						{Suite: "results.tap", Name: "works", Status: atc.TestStatusPassed},
+					}))
+				})
+
+				Context("when a report cannot be parsed", func() {
+					BeforeEach(func() {
+						*outputVolume1 = *outputVolume1.WithContent(runtimetest.VolumeContent{
+							"reports/unit.xml": {Data: []byte("<testsuite")},
+						})
+					})
+
+					It("warns about it and saves the rest", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] failed to read junit report from 'some-output/reports'`))
+
+						_, _, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+						Expect(results).To(HaveLen(1))
+					})
+				})
+
+				Context("when saving the results fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeDelegate.SaveTestResultsReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(stepErr).To(MatchError(disaster))
+					})
+				})
+			})
+		})
+
+		Context("when the task is memoized", func() {
+			var outputVolume *runtimetest.Volume
+
+			BeforeEach(func() {
+				stepMetadata.JobID = 12
+
+				taskPlan.Memoize = true
+				taskPlan.Config.Inputs = []atc.TaskInputConfig{
+					{Name: "some-input"},
+				}
+				taskPlan.Config.Outputs = []atc.TaskOutputConfig{
+					{Name: "some-output"},
+				}
+
+				repo.RegisterDigestedArtifact("some-input", runtimetest.NewVolume("input"), false, "resource-cache:1")
+
+				outputVolume = runtimetest.NewVolume("output")
+				chosenContainer.Mounts = []runtime.VolumeMount{
+					{
+						Volume:    outputVolume,
+						MountPath: "some-artifact-root/some-output/",
+					},
+				}
+			})
+
+			Context("when it has not succeeded with the same inputs before", func() {
+				It("runs the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+				})
+
+				It("keeps its outputs", func() {
+					Expect(outputVolume.TaskCacheInitialized).To(BeTrue())
+				})
+
+				It("records that it succeeded", func() {
+					Expect(fakeTaskCacheFactory.FindOrCreateCallCount()).To(Equal(1))
+					jobID, stepName, path := fakeTaskCacheFactory.FindOrCreateArgsForCall(0)
+					Expect(jobID).To(Equal(12))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(path).To(HavePrefix("memoize:"))
+				})
+
+				It("registers its outputs with a digest", func() {
+					digest, found := repo.DigestFor("some-output")
+					Expect(found).To(BeTrue())
+					Expect(digest).To(HavePrefix("memoize:"))
+				})
+
+				Context("when one of its outputs has no volume", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Outputs = append(taskPlan.Config.Outputs, atc.TaskOutputConfig{Name: "some-other-output"})
+					})
+
+					It("does not record it", func() {
+						Expect(outputVolume.TaskCacheInitialized).To(BeFalse())
+						Expect(fakeTaskCacheFactory.FindOrCreateCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the task exits nonzero", func() {
+					BeforeEach(func() {
+						chosenContainer.ProcessDefs[0].Stub.ExitStatus = 1
+					})
+
+					It("does not record it", func() {
+						Expect(outputVolume.TaskCacheInitialized).To(BeFalse())
+						Expect(fakeTaskCacheFactory.FindOrCreateCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when it has succeeded with the same inputs before", func() {
+				var memoizedVolume *runtimetest.Volume
+
+				BeforeEach(func() {
+					memoizedVolume = runtimetest.NewVolume("memoized-output")
+
+					fakeTaskCacheFactory.FindReturns(nil, true, nil)
+					fakePool.FindTaskCacheVolumeReturns(memoizedVolume, true, nil)
+				})
+
+				It("succeeds without running the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+				})
+
+				It("looks up the outputs kept for the same key", func() {
+					_, _, markerPath := fakeTaskCacheFactory.FindArgsForCall(0)
+
+					Expect(fakePool.FindTaskCacheVolumeCallCount()).To(Equal(1))
+					_, teamID, jobID, stepName, path, _ := fakePool.FindTaskCacheVolumeArgsForCall(0)
+					Expect(teamID).To(Equal(stepMetadata.TeamID))
+					Expect(jobID).To(Equal(12))
+					Expect(stepName).To(Equal("some-task"))
+					Expect(path).To(Equal(markerPath + ":some-output"))
+				})
+
+				It("registers the kept outputs", func() {
+					artifact, _, found := repo.ArtifactFor("some-output")
+					Expect(found).To(BeTrue())
+					Expect(artifact).To(Equal(memoizedVolume))
+				})
+
+				It("finishes the step", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, status := fakeDelegate.FinishedArgsForCall(0)
+					Expect(status).To(Equal(exec.ExitStatus(0)))
+				})
+
+				Context("when the output is published", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Outputs[0].Publish = true
+						memoizedVolume.DBVolume_.PublishArtifactReturns(new(dbfakes.FakeWorkerArtifact), nil)
+					})
+
+					It("publishes the kept output", func() {
+						Expect(memoizedVolume.DBVolume_.PublishArtifactCallCount()).To(Equal(1))
+
+						name, buildID := memoizedVolume.DBVolume_.PublishArtifactArgsForCall(0)
+						Expect(name).To(Equal("some-output"))
+						Expect(buildID).To(Equal(stepMetadata.BuildID))
+					})
+				})
+
+				Context("when the configuration specifies reports", func() {
+					BeforeEach(func() {
+						taskPlan.Config.Reports = []atc.TaskReportConfig{
+							{Format: atc.TestReportFormatTAP, Output: "some-output", Path: "results.tap"},
+						}
+
+						*memoizedVolume = *memoizedVolume.WithContent(runtimetest.VolumeContent{
+							"results.tap": {Data: []byte("1..1\nok 1 - works\n")},
+						})
+					})
+
+					It("saves the results of the kept reports", func() {
+						Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+
+						_, stepName, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+						Expect(stepName).To(Equal("some-task"))
+						Expect(results).To(Equal([]atc.TestResult{
+							{Suite: "results.tap", Name: "works", Status: atc.TestStatusPassed},
+						}))
+					})
+				})
+
+				Context("when one of the outputs is no longer kept", func() {
+					BeforeEach(func() {
+						fakePool.FindTaskCacheVolumeReturns(nil, false, nil)
+					})
+
+					It("runs the task", func() {
+						Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+					})
+				})
+			})
+
+			Context("when an input has no digest", func() {
+				BeforeEach(func() {
+					repo.RegisterArtifact("some-input", runtimetest.NewVolume("input"), false)
+				})
+
+				It("warns and runs the task", func() {
+					Expect(stderrBuf).To(gbytes.Say("not memoizing: input 'some-input'"))
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+					Expect(fakeTaskCacheFactory.FindOrCreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the task does not belong to a job (one-off build)", func() {
+				BeforeEach(func() {
+					stepMetadata.JobID = 0
+				})
+
+				It("runs the task without memoizing it", func() {
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+					Expect(fakeTaskCacheFactory.FindCallCount()).To(BeZero())
+					Expect(fakeTaskCacheFactory.FindOrCreateCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when missing the platform", func() {
@@ -795,7 +1084,7 @@ var _ = Describe("TaskStep", func() {
 					ImageArtifact: runtimetest.NewVolume("some-volume"),
 				}
 
-				fakeDelegate.FetchImageReturns(fetchedImageSpec, nil)
+				fakeDelegate.FetchImageReturns(fetchedImageSpec, nil, nil)
 			})
 
 			It("succeeds", func() {
@@ -895,6 +1184,162 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the task has services", func() {
+			var (
+				fakeServiceDelegate *execfakes.FakeBuildStepDelegate
+				serviceImageSpec    runtime.ImageSpec
+				serviceOwner        db.ContainerOwner
+				serviceContainer    *runtimetest.Container
+				serviceStub         runtimetest.ProcessStub
+				readinessStub       runtimetest.ProcessStub
+			)
+
+			BeforeEach(func() {
+				taskPlan.Config.Services = []atc.TaskServiceConfig{
+					{
+						Name: "postgres",
+						ImageResource: &atc.ImageResource{
+							Type:   "registry-image",
+							Source: atc.Source{"repository": "postgres"},
+						},
+						Env:       atc.TaskEnv{"POSTGRES_PASSWORD": "password"},
+						Run:       atc.TaskRunConfig{Path: "docker-entrypoint.sh", Args: []string{"postgres"}},
+						Readiness: &atc.TaskRunConfig{Path: "pg_isready"},
+					},
+				}
+
+				fakeServiceDelegate = new(execfakes.FakeBuildStepDelegate)
+				fakeServiceDelegate.StdoutReturns(gbytes.NewBuffer())
+				fakeServiceDelegate.StderrReturns(gbytes.NewBuffer())
+				fakeDelegate.ServiceDelegateReturns(fakeServiceDelegate)
+
+				serviceImageSpec = runtime.ImageSpec{
+					ImageArtifact: runtimetest.NewVolume("postgres-image"),
+				}
+				fakeServiceDelegate.FetchImageReturns(serviceImageSpec, nil, nil)
+
+				serviceStub = runtimetest.ProcessStub{
+					Attachable: true,
+					Do: func(ctx context.Context, _ *runtimetest.Process) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+				}
+				readinessStub = runtimetest.ProcessStub{}
+
+				serviceOwner = db.NewBuildStepContainerOwner(stepMetadata.BuildID, "42/services/postgres", stepMetadata.TeamID)
+			})
+
+			setUpServiceContainer := func() {
+				serviceContainer = runtimetest.NewContainer().
+					WithProcess(
+						runtime.ProcessSpec{
+							ID:   "service",
+							Path: "docker-entrypoint.sh",
+							Args: []string{"postgres"},
+							Dir:  "some-artifact-root",
+						},
+						serviceStub,
+					).
+					WithProcess(
+						runtime.ProcessSpec{
+							Path: "pg_isready",
+							Dir:  "some-artifact-root",
+						},
+						readinessStub,
+					)
+				serviceContainer.Props["garden.network.container-ip"] = "10.80.0.2"
+
+				chosenWorker.AddContainer(serviceOwner, serviceContainer, nil)
+			}
+
+			Context("when the service becomes ready", func() {
+				BeforeEach(setUpServiceContainer)
+
+				It("succeeds", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+				})
+
+				It("fetches the service's image under the service's own plan", func() {
+					Expect(fakeDelegate.ServiceDelegateCallCount()).To(Equal(1))
+					Expect(fakeDelegate.ServiceDelegateArgsForCall(0)).To(Equal(atc.PlanID("42/services/postgres")))
+
+					Expect(fakeServiceDelegate.FetchImageCallCount()).To(Equal(1))
+					_, getPlan, _, _ := fakeServiceDelegate.FetchImageArgsForCall(0)
+					Expect(getPlan.ID).To(Equal(atc.PlanID("42/services/postgres/image-get")))
+					Expect(getPlan.Get.Type).To(Equal("registry-image"))
+					Expect(getPlan.Get.Source).To(Equal(atc.Source{"repository": "postgres"}))
+				})
+
+				It("starts the service on the task's worker", func() {
+					workerContainer, _, found := chosenWorker.FindContainerByOwner(serviceOwner)
+					Expect(found).To(BeTrue())
+					Expect(workerContainer.Metadata.StepName).To(Equal("some-step/services/postgres"))
+					Expect(workerContainer.Spec.ImageSpec).To(Equal(serviceImageSpec))
+					Expect(workerContainer.Spec.Env).To(ConsistOf("POSTGRES_PASSWORD=password"))
+
+					Expect(serviceContainer.RunningProcesses()).To(HaveLen(2))
+					Expect(serviceContainer.RunningProcesses()[0].Spec.ID).To(Equal("service"))
+					Expect(serviceContainer.RunningProcesses()[1].Spec.Path).To(Equal("pg_isready"))
+				})
+
+				It("gives the task the service's address", func() {
+					Expect(chosenContainer.Spec.Env).To(ContainElement("POSTGRES_HOST=10.80.0.2"))
+
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+					Expect(chosenContainer.RunningProcesses()[0].Spec.ID).To(Equal("task"))
+				})
+
+				It("stops the service once the task has finished", func() {
+					Expect(fakeServiceDelegate.StartingCallCount()).To(Equal(1))
+					Expect(fakeServiceDelegate.FinishedCallCount()).To(Equal(1))
+					_, succeeded := fakeServiceDelegate.FinishedArgsForCall(0)
+					Expect(succeeded).To(BeTrue())
+				})
+			})
+
+			Context("when the service exits before it is ready", func() {
+				BeforeEach(func() {
+					serviceStub = runtimetest.ProcessStub{ExitStatus: 1}
+					readinessStub = runtimetest.ProcessStub{ExitStatus: 2}
+
+					setUpServiceContainer()
+				})
+
+				It("returns an error without running the task", func() {
+					Expect(stepErr).To(Equal(exec.ServiceExitedError{Name: "postgres", ExitStatus: 1}))
+					Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+				})
+			})
+
+			Context("when the service never becomes ready", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Services[0].ReadinessTimeout = "10ms"
+					readinessStub = runtimetest.ProcessStub{ExitStatus: 2}
+
+					setUpServiceContainer()
+				})
+
+				It("returns an error without running the task", func() {
+					Expect(stepErr).To(Equal(exec.ServiceNotReadyError{Name: "postgres", Timeout: 10 * time.Millisecond}))
+					Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+				})
+			})
+
+			Context("when the worker does not give the service's address", func() {
+				BeforeEach(func() {
+					setUpServiceContainer()
+					delete(serviceContainer.Props, "garden.network.container-ip")
+				})
+
+				It("returns an error without running the task", func() {
+					Expect(stepErr).To(Equal(exec.ServiceAddressUnknownError{Name: "postgres", Worker: "worker"}))
+					Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+				})
+			})
+		})
+
 		Context("when the task step is interrupted", func() {
 			BeforeEach(func() {
 				cancel()
@@ -0,0 +1,263 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseDotenv parses KEY=VALUE lines, as written for docker or shell scripts,
+// into a map of strings. Values may be single- or double-quoted, and
+// double-quoted values may span lines and contain escapes. Other vars are not
+// expanded within values.
+func parseDotenv(content []byte) (interface{}, error) {
+	values := map[string]interface{}{}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "export "); ok {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", lineNumber)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if comment := strings.Index(value, " #"); comment != -1 {
+				value = strings.TrimSpace(value[:comment])
+			}
+
+			values[key] = value
+			continue
+		}
+
+		unquoted, rest, closed := readDotenvQuoted(value)
+		for !closed && value[0] == '"' && i+1 < len(lines) {
+			i++
+			value += "\n" + lines[i]
+			unquoted, rest, closed = readDotenvQuoted(value)
+		}
+
+		if !closed {
+			return nil, fmt.Errorf("line %d: unterminated quoted value", lineNumber)
+		}
+
+		rest = strings.TrimSpace(rest)
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("line %d: unexpected '%s' after quoted value", lineNumber, rest)
+		}
+
+		values[key] = unquoted
+	}
+
+	return values, nil
+}
+
+// readDotenvQuoted reads the value in the quotes at the start of value,
+// returning the rest of the value after the closing quote and whether one
+// was found at all.
+func readDotenvQuoted(value string) (string, string, bool) {
+	quote := value[0]
+
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == quote:
+			return b.String(), value[i+1:], true
+		case c == '\\' && quote == '"' && i+1 < len(value):
+			i++
+			switch value[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(value[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(value[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", false
+}
+
+// parseProperties parses a Java properties file into a map of strings. Keys
+// are kept as they are, so `db.host` is not nested under `db`.
+func parseProperties(content []byte) (interface{}, error) {
+	values := map[string]interface{}{}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		for continuesProperty(line) {
+			line = line[:len(line)-1]
+			if i+1 == len(lines) {
+				break
+			}
+
+			i++
+			line += strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		keyEnd := 0
+		for keyEnd < len(line) && !strings.ContainsRune("=: \t\f", rune(line[keyEnd])) {
+			if line[keyEnd] == '\\' {
+				keyEnd++
+			}
+			keyEnd++
+		}
+		keyEnd = min(keyEnd, len(line))
+
+		key, err := unescapeProperty(line[:keyEnd])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		value, err := unescapeProperty(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// continuesProperty returns whether the line ends in an unescaped backslash.
+func continuesProperty(line string) bool {
+	backslashes := len(line) - len(strings.TrimRight(line, `\`))
+	return backslashes%2 == 1
+}
+
+func unescapeProperty(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", fmt.Errorf("invalid unicode escape '\\%s'", s[i:])
+			}
+
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape '\\%s'", s[i:i+5])
+			}
+
+			b.WriteRune(rune(code))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// parseTOML parses a TOML document into maps, as the json and yaml formats
+// do. Numbers are converted to json.Numbers, except infinity and NaN, and
+// dates and times are kept as strings.
+func parseTOML(content []byte) (interface{}, error) {
+	var value map[string]interface{}
+	_, err := toml.Decode(string(content), &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromTOML(value), nil
+}
+
+func fromTOML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = fromTOML(val)
+		}
+		return v
+	case []map[string]interface{}:
+		tables := make([]interface{}, len(v))
+		for i, table := range v {
+			tables[i] = fromTOML(table)
+		}
+		return tables
+	case []interface{}:
+		for i, val := range v {
+			v[i] = fromTOML(val)
+		}
+		return v
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return v
+		}
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		// dates and times without an offset are given the zone of the
+		// same name
+		switch v.Location().String() {
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05.999999999")
+		case "date-local":
+			return v.Format("2006-01-02")
+		case "time-local":
+			return v.Format("15:04:05.999999999")
+		default:
+			return v.Format(time.RFC3339Nano)
+		}
+	default:
+		return v
+	}
+}
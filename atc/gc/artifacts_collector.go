@@ -10,12 +10,14 @@ import (
 )
 
 type artifactCollector struct {
-	artifactLifecycle db.WorkerArtifactLifecycle
+	artifactLifecycle  db.WorkerArtifactLifecycle
+	publishedRetention time.Duration
 }
 
-func NewArtifactCollector(artifactLifecycle db.WorkerArtifactLifecycle) *artifactCollector {
+func NewArtifactCollector(artifactLifecycle db.WorkerArtifactLifecycle, publishedRetention time.Duration) *artifactCollector {
 	return &artifactCollector{
-		artifactLifecycle: artifactLifecycle,
+		artifactLifecycle:  artifactLifecycle,
+		publishedRetention: publishedRetention,
 	}
 }
 
@@ -32,5 +34,5 @@ func (a *artifactCollector) Run(ctx context.Context) error {
 		}.Emit(logger)
 	}()
 
-	return a.artifactLifecycle.RemoveExpiredArtifacts()
+	return a.artifactLifecycle.RemoveExpiredArtifacts(a.publishedRetention)
 }
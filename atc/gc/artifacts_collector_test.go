@@ -2,6 +2,7 @@ package gc_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc"
@@ -16,7 +17,7 @@ var _ = Describe("ArtifactCollector", func() {
 	BeforeEach(func() {
 		fakeArtifactLifecycle = new(dbfakes.FakeWorkerArtifactLifecycle)
 
-		collector = gc.NewArtifactCollector(fakeArtifactLifecycle)
+		collector = gc.NewArtifactCollector(fakeArtifactLifecycle, 7*24*time.Hour)
 	})
 
 	Describe("Run", func() {
@@ -25,6 +26,7 @@ var _ = Describe("ArtifactCollector", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeArtifactLifecycle.RemoveExpiredArtifactsCallCount()).To(Equal(1))
+			Expect(fakeArtifactLifecycle.RemoveExpiredArtifactsArgsForCall(0)).To(Equal(7 * 24 * time.Hour))
 		})
 	})
 })
@@ -0,0 +1,50 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildLogArchiver struct {
+	buildFactory db.BuildFactory
+	archiveAfter time.Duration
+	batchSize    int
+}
+
+// NewBuildLogArchiver returns a component which moves the events of builds
+// that finished at least archiveAfter ago out of the database and into the
+// build log store, batchSize builds at a time.
+func NewBuildLogArchiver(buildFactory db.BuildFactory, archiveAfter time.Duration, batchSize int) *buildLogArchiver {
+	return &buildLogArchiver{
+		buildFactory: buildFactory,
+		archiveAfter: archiveAfter,
+		batchSize:    batchSize,
+	}
+}
+
+func (a *buildLogArchiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-log-archiver")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	builds, err := a.buildFactory.GetArchivableBuilds(a.archiveAfter, a.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-archivable-builds", err)
+		return err
+	}
+
+	for _, build := range builds {
+		err := build.ArchiveEvents(ctx)
+		if err != nil {
+			// carry on with the other builds; this one will be retried
+			logger.Error("failed-to-archive-build-events", err, build.LagerData())
+			continue
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildLogArchiver", func() {
+	var (
+		archiver         GcCollector
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild1       *dbfakes.FakeBuild
+		fakeBuild2       *dbfakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuild1 = new(dbfakes.FakeBuild)
+		fakeBuild2 = new(dbfakes.FakeBuild)
+
+		fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{fakeBuild1, fakeBuild2}, nil)
+
+		archiver = gc.NewBuildLogArchiver(fakeBuildFactory, 10*time.Minute, 100)
+	})
+
+	It("archives the events of the builds that finished long enough ago", func() {
+		err := archiver.Run(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeBuildFactory.GetArchivableBuildsCallCount()).To(Equal(1))
+		completedFor, limit := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+		Expect(completedFor).To(Equal(10 * time.Minute))
+		Expect(limit).To(Equal(100))
+
+		Expect(fakeBuild1.ArchiveEventsCallCount()).To(Equal(1))
+		Expect(fakeBuild2.ArchiveEventsCallCount()).To(Equal(1))
+	})
+
+	Context("when archiving a build's events fails", func() {
+		BeforeEach(func() {
+			fakeBuild1.ArchiveEventsReturns(errors.New("disaster"))
+		})
+
+		It("carries on with the other builds", func() {
+			err := archiver.Run(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild2.ArchiveEventsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			err := archiver.Run(context.TODO())
+			Expect(err).To(MatchError("disaster"))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretAccessCollector struct {
+	accessLog db.SecretAccessLog
+	retention time.Duration
+}
+
+func NewSecretAccessCollector(accessLog db.SecretAccessLog, retention time.Duration) *secretAccessCollector {
+	return &secretAccessCollector{
+		accessLog: accessLog,
+		retention: retention,
+	}
+}
+
+func (c *secretAccessCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-access-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := c.accessLog.RemoveExpiredAccesses(c.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-expired-secret-accesses", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package gc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessCollector", func() {
+	var collector GcCollector
+	var fakeAccessLog *dbfakes.FakeSecretAccessLog
+
+	BeforeEach(func() {
+		fakeAccessLog = new(dbfakes.FakeSecretAccessLog)
+
+		collector = gc.NewSecretAccessCollector(fakeAccessLog, 90*24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("tells the secret access log to remove accesses older than the retention", func() {
+			err := collector.Run(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeAccessLog.RemoveExpiredAccessesCallCount()).To(Equal(1))
+			Expect(fakeAccessLog.RemoveExpiredAccessesArgsForCall(0)).To(Equal(90 * 24 * time.Hour))
+		})
+	})
+})
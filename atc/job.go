@@ -15,6 +15,8 @@ type Job struct {
 	PausedAt     int64  `json:"paused_at,omitempty"`
 	HasNewInputs bool   `json:"has_new_inputs,omitempty"`
 
+	NextFireTime int64 `json:"next_fire_time,omitempty"`
+
 	Groups []string `json:"groups,omitempty"`
 
 	FirstLoggedBuildID   int  `json:"first_logged_build_id,omitempty"`
@@ -26,6 +28,33 @@ type Job struct {
 
 	Inputs  []JobInput  `json:"inputs,omitempty"`
 	Outputs []JobOutput `json:"outputs,omitempty"`
+
+	SchedulingDiagnostics []SchedulingDiagnostic `json:"scheduling_diagnostics,omitempty"`
+}
+
+// SchedulingDiagnostic explains why the scheduler could not find versions
+// for the inputs of a job's next build.
+type SchedulingDiagnostic struct {
+	Input    string `json:"input"`
+	Resource string `json:"resource"`
+
+	// PassedJob is the job in the input's passed constraints which has no
+	// build that used the versions needed.
+	PassedJob string `json:"passed_job,omitempty"`
+
+	// Version is the version of the input that had already been chosen, and
+	// With the versions of other inputs that a build of the passed job had to
+	// have used together with it.
+	Version Version                     `json:"version,omitempty"`
+	With    []SchedulingDiagnosticInput `json:"with,omitempty"`
+
+	Message string `json:"message"`
+}
+
+type SchedulingDiagnosticInput struct {
+	Input    string  `json:"input"`
+	Resource string  `json:"resource"`
+	Version  Version `json:"version"`
 }
 
 type JobInput struct {
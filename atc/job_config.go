@@ -1,5 +1,16 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/vars"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -14,6 +25,10 @@ type JobConfig struct {
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	Matrix []MatrixVarConfig `json:"matrix,omitempty"`
+
+	Schedule *ScheduleConfig `json:"schedule,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -29,6 +44,82 @@ type BuildLogRetention struct {
 	Days                   int `json:"days,omitempty"`
 }
 
+// MatrixVarConfig is one dimension of a job's matrix. The job is expanded into
+// one cell job for every combination of values across all of the matrix vars.
+//
+// The values must be strings, which is enforced when the config is validated,
+// so that they are used exactly as written, e.g. as '1.20' rather than '1.2'.
+type MatrixVarConfig struct {
+	Var    string        `json:"var"`
+	Values []interface{} `json:"values"`
+}
+
+// ScheduleConfig triggers builds of a job at the times described by a cron
+// expression.
+type ScheduleConfig struct {
+	Cron string `json:"cron"`
+
+	// Location is the IANA time zone the cron expression is evaluated in,
+	// e.g. "America/Toronto". Defaults to UTC.
+	Location string `json:"location,omitempty"`
+
+	// CatchUp determines what happens to fire times that were missed, e.g.
+	// while the ATC was down or the job was paused. Defaults to
+	// ScheduleCatchUpOnce.
+	CatchUp string `json:"catch_up,omitempty"`
+}
+
+const (
+	// ScheduleCatchUpOnce runs a single build for any number of missed fires.
+	ScheduleCatchUpOnce = "once"
+
+	// ScheduleCatchUpSkip drops missed fires, waiting for the next one.
+	ScheduleCatchUpSkip = "skip"
+)
+
+// ScheduleCatchUpGrace is how late a fire can be before it counts as missed.
+const ScheduleCatchUpGrace = time.Minute
+
+// Next returns the first fire time after the given time, or the zero time if
+// the schedule never fires.
+func (config ScheduleConfig) Next(after time.Time) (time.Time, error) {
+	cron, err := ParseCronSchedule(config.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	location, err := config.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cron.Next(after.In(location)), nil
+}
+
+// ShouldFire reports whether a fire that was due at the given time should
+// still result in a build at the given current time, according to the
+// catch-up policy.
+func (config ScheduleConfig) ShouldFire(due time.Time, now time.Time) bool {
+	if config.CatchUp == ScheduleCatchUpSkip {
+		return now.Sub(due) <= ScheduleCatchUpGrace
+	}
+
+	return true
+}
+
+func (config ScheduleConfig) location() (*time.Location, error) {
+	if config.Location == "" {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(config.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location '%s': %w", config.Location, err)
+	}
+
+	return location, nil
+}
+
 func (config JobConfig) Step() Step {
 	return Step{Config: config.StepConfig()}
 }
@@ -128,3 +219,114 @@ func (config JobConfig) Outputs() []JobOutput {
 
 	return outputs
 }
+
+// IsMatrix returns whether the job is a matrix job, i.e. whether it is
+// expanded into cell jobs rather than being scheduled itself.
+func (config JobConfig) IsMatrix() bool {
+	return len(config.Matrix) > 0
+}
+
+// MatrixCells expands a matrix job into one job config per combination of
+// matrix var values. Each cell is named after the job and its values (e.g.
+// 'unit-1.21-linux') and has the values interpolated into its config wherever
+// they are referenced as local vars, i.e. ((.:go_version)).
+//
+// Any other var references are left as-is to be interpolated at runtime.
+func (config JobConfig) MatrixCells() (JobConfigs, error) {
+	if !config.IsMatrix() {
+		return nil, nil
+	}
+
+	cellTemplate := config
+	cellTemplate.Matrix = nil
+	cellTemplate.OldName = ""
+
+	templateBytes, err := json.Marshal(cellTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	template := vars.NewTemplate(templateBytes)
+
+	var cells JobConfigs
+	for _, values := range matrixCombinations(config.Matrix) {
+		localVars := vars.StaticVariables{}
+		for i, v := range config.Matrix {
+			localVars[v.Var] = values[i]
+		}
+
+		interpolatedBytes, err := template.Evaluate(matrixVariables(localVars), vars.EvaluateOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to interpolate matrix cell: %w", err)
+		}
+
+		var cell JobConfig
+		// This must use sigs.k8s.io/yaml, since gopkg.in/yaml.v2 doesn't
+		// convert from YAML -> JSON first.
+		err = yaml.Unmarshal(interpolatedBytes, &cell)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matrix cell: %w", err)
+		}
+
+		cell.Name = MatrixCellName(config.Name, values)
+
+		cells = append(cells, cell)
+	}
+
+	return cells, nil
+}
+
+// MatrixCellName returns the name of the cell job for the given matrix job
+// name and combination of var values.
+func MatrixCellName(jobName string, values []interface{}) string {
+	parts := []string{jobName}
+	for _, v := range values {
+		parts = append(parts, fmt.Sprint(v))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// matrixCombinations returns every combination of values across the matrix
+// vars, in the order that the vars and values are declared.
+func matrixCombinations(matrix []MatrixVarConfig) [][]interface{} {
+	combinations := [][]interface{}{{}}
+	for _, v := range matrix {
+		var next [][]interface{}
+		for _, combination := range combinations {
+			for _, value := range v.Values {
+				cell := make([]interface{}, len(combination), len(combination)+1)
+				copy(cell, combination)
+				next = append(next, append(cell, value))
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+// matrixVariables resolves local vars, i.e. ((.:foo)), from the matrix values
+// and reports every other var as not found so that it is left uninterpolated.
+type matrixVariables vars.StaticVariables
+
+func (v matrixVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	if ref.Source != "." {
+		return nil, false, nil
+	}
+
+	return vars.StaticVariables(v).Get(ref.WithoutSource())
+}
+
+func (v matrixVariables) List() ([]vars.Reference, error) {
+	refs, err := vars.StaticVariables(v).List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range refs {
+		refs[i].Source = "."
+	}
+
+	return refs, nil
+}
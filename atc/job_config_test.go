@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -671,4 +673,129 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("MatrixCells", func() {
+		var (
+			jobConfig atc.JobConfig
+
+			cells    atc.JobConfigs
+			cellsErr error
+		)
+
+		BeforeEach(func() {
+			jobConfig = atc.JobConfig{
+				Name:    "unit",
+				OldName: "old-unit",
+				Serial:  true,
+				PlanSequence: []atc.Step{
+					{
+						Config: &atc.TaskStep{
+							Name:       "test-((.:os))",
+							ConfigPath: "ci/test.yml",
+							Params: atc.TaskEnv{
+								"GO_VERSION": "((.:go_version))",
+								"TOKEN":      "((token))",
+								"OTHER":      "((vault:other))",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			cells, cellsErr = jobConfig.MatrixCells()
+		})
+
+		Context("when the job has no matrix", func() {
+			It("returns no cells", func() {
+				Expect(cellsErr).ToNot(HaveOccurred())
+				Expect(cells).To(BeEmpty())
+			})
+		})
+
+		Context("when the job has a matrix", func() {
+			BeforeEach(func() {
+				jobConfig.Matrix = []atc.MatrixVarConfig{
+					{Var: "go_version", Values: []interface{}{"1.21", "1.22"}},
+					{Var: "os", Values: []interface{}{"linux", "darwin"}},
+				}
+			})
+
+			It("returns a cell for each combination of values", func() {
+				Expect(cellsErr).ToNot(HaveOccurred())
+
+				var names []string
+				for _, cell := range cells {
+					names = append(names, cell.Name)
+				}
+
+				Expect(names).To(Equal([]string{
+					"unit-1.21-linux",
+					"unit-1.21-darwin",
+					"unit-1.22-linux",
+					"unit-1.22-darwin",
+				}))
+			})
+
+			It("interpolates the matrix vars and leaves other vars alone", func() {
+				Expect(cellsErr).ToNot(HaveOccurred())
+
+				Expect(cells[1].PlanSequence).To(HaveLen(1))
+				task, ok := cells[1].PlanSequence[0].Config.(*atc.TaskStep)
+				Expect(ok).To(BeTrue())
+				Expect(task.Name).To(Equal("test-darwin"))
+				Expect(task.Params).To(Equal(atc.TaskEnv{
+					"GO_VERSION": "1.21",
+					"TOKEN":      "((token))",
+					"OTHER":      "((vault:other))",
+				}))
+			})
+
+			It("keeps the rest of the job config, without the matrix", func() {
+				Expect(cellsErr).ToNot(HaveOccurred())
+
+				for _, cell := range cells {
+					Expect(cell.Serial).To(BeTrue())
+					Expect(cell.Matrix).To(BeEmpty())
+					Expect(cell.OldName).To(BeEmpty())
+				}
+			})
+		})
+	})
+})
+
+var _ = Describe("ScheduleConfig", func() {
+	now := time.Date(2024, time.January, 10, 12, 34, 56, 0, time.UTC)
+
+	Describe("Next", func() {
+		It("defaults to UTC", func() {
+			next, err := atc.ScheduleConfig{Cron: "0 9 * * *"}.Next(now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2024, time.January, 11, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("evaluates the cron expression in the location", func() {
+			next, err := atc.ScheduleConfig{Cron: "0 9 * * *", Location: "Asia/Tokyo"}.Next(now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("errors on an unknown location", func() {
+			_, err := atc.ScheduleConfig{Cron: "0 9 * * *", Location: "Nowhere"}.Next(now)
+			Expect(err).To(MatchError(ContainSubstring("invalid location 'Nowhere'")))
+		})
+	})
+
+	Describe("ShouldFire", func() {
+		It("catches up once by default", func() {
+			Expect(atc.ScheduleConfig{}.ShouldFire(now.Add(-24*time.Hour), now)).To(BeTrue())
+		})
+
+		It("skips fires missed by more than the grace period", func() {
+			schedule := atc.ScheduleConfig{CatchUp: atc.ScheduleCatchUpSkip}
+			Expect(schedule.ShouldFire(now.Add(-atc.ScheduleCatchUpGrace), now)).To(BeTrue())
+			Expect(schedule.ShouldFire(now.Add(-atc.ScheduleCatchUpGrace-time.Second), now)).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,151 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// NotificationConfig subscribes a team to the builds of its pipelines
+// finishing, delivering a BuildNotification to a webhook, e.g.
+//
+//	notifications:
+//	- name: chat
+//	  url: https://chat.example.com/hooks/concourse
+//	  secret: some-secret
+//	  statuses: [failed, errored]
+type NotificationConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// Secret is used to sign the body of each delivery with HMAC-SHA256. The
+	// signature is sent as the X-Concourse-Signature header.
+	Secret string `json:"secret,omitempty"`
+
+	// Statuses are the statuses of the builds to notify about. All finished
+	// builds are notified about if it is empty.
+	Statuses []BuildStatus `json:"statuses,omitempty"`
+
+	// Pipelines limits the notifications to the builds of the given pipelines.
+	Pipelines []string `json:"pipelines,omitempty"`
+}
+
+func (config NotificationConfig) Validate() error {
+	if config.Name == "" {
+		return errors.New("notification has no name")
+	}
+
+	if config.URL == "" {
+		return fmt.Errorf("notification '%s' has no url", config.Name)
+	}
+
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("notification '%s' has an invalid url: '%s'", config.Name, config.URL)
+	}
+
+	for _, status := range config.Statuses {
+		switch status {
+		case StatusSucceeded, StatusFailed, StatusErrored, StatusAborted:
+		default:
+			return fmt.Errorf("notification '%s' has an invalid status: '%s'", config.Name, status)
+		}
+	}
+
+	return nil
+}
+
+// Subscribed returns whether a build of the given pipeline finishing with the
+// given status should be notified about. One-off builds are only notified
+// about if the notification is not limited to any pipelines.
+func (config NotificationConfig) Subscribed(pipelineName string, status BuildStatus) bool {
+	if len(config.Statuses) > 0 && !containsStatus(config.Statuses, status) {
+		return false
+	}
+
+	if len(config.Pipelines) > 0 && !containsString(config.Pipelines, pipelineName) {
+		return false
+	}
+
+	return true
+}
+
+type NotificationConfigs []NotificationConfig
+
+func (configs NotificationConfigs) Validate() error {
+	names := map[string]bool{}
+	for _, config := range configs {
+		err := config.Validate()
+		if err != nil {
+			return err
+		}
+
+		if names[config.Name] {
+			return fmt.Errorf("notification '%s' is configured more than once", config.Name)
+		}
+
+		names[config.Name] = true
+	}
+
+	return nil
+}
+
+func (configs NotificationConfigs) Lookup(name string) (NotificationConfig, bool) {
+	for _, config := range configs {
+		if config.Name == name {
+			return config, true
+		}
+	}
+
+	return NotificationConfig{}, false
+}
+
+// BuildNotification is the body of a notification delivery.
+type BuildNotification struct {
+	Notification string `json:"notification"`
+	Build        Build  `json:"build"`
+
+	// URL is the URL of the build in the web UI.
+	URL string `json:"url,omitempty"`
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliverySucceeded NotificationDeliveryStatus = "succeeded"
+	NotificationDeliveryFailed    NotificationDeliveryStatus = "failed"
+)
+
+type NotificationDelivery struct {
+	ID             int                        `json:"id"`
+	Notification   string                     `json:"notification"`
+	URL            string                     `json:"url"`
+	BuildID        int                        `json:"build_id"`
+	Status         NotificationDeliveryStatus `json:"status"`
+	Attempts       int                        `json:"attempts"`
+	ResponseStatus int                        `json:"response_status,omitempty"`
+	LastError      string                     `json:"last_error,omitempty"`
+	CreatedAt      int64                      `json:"created_at"`
+	UpdatedAt      int64                      `json:"updated_at"`
+}
+
+func containsStatus(statuses []BuildStatus, status BuildStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationConfig", func() {
+	var config atc.NotificationConfig
+
+	BeforeEach(func() {
+		config = atc.NotificationConfig{
+			Name: "chat",
+			URL:  "https://chat.example.com/hook",
+		}
+	})
+
+	Describe("Validate", func() {
+		It("returns no errors", func() {
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		Context("when the name is empty", func() {
+			BeforeEach(func() {
+				config.Name = ""
+			})
+
+			It("returns an error", func() {
+				Expect(config.Validate()).To(MatchError("notification has no name"))
+			})
+		})
+
+		Context("when the url is not http", func() {
+			BeforeEach(func() {
+				config.URL = "ftp://chat.example.com/hook"
+			})
+
+			It("returns an error", func() {
+				Expect(config.Validate()).To(MatchError("notification 'chat' has an invalid url: 'ftp://chat.example.com/hook'"))
+			})
+		})
+
+		Context("when a status is not a finished build status", func() {
+			BeforeEach(func() {
+				config.Statuses = []atc.BuildStatus{atc.StatusFailed, atc.StatusStarted}
+			})
+
+			It("returns an error", func() {
+				Expect(config.Validate()).To(MatchError("notification 'chat' has an invalid status: 'started'"))
+			})
+		})
+
+		Context("when a notification is configured twice", func() {
+			It("returns an error", func() {
+				Expect(atc.NotificationConfigs{config, config}.Validate()).To(MatchError("notification 'chat' is configured more than once"))
+			})
+		})
+	})
+
+	Describe("Subscribed", func() {
+		It("subscribes to every finished build by default", func() {
+			Expect(config.Subscribed("some-pipeline", atc.StatusSucceeded)).To(BeTrue())
+			Expect(config.Subscribed("", atc.StatusErrored)).To(BeTrue())
+		})
+
+		Context("when limited to statuses", func() {
+			BeforeEach(func() {
+				config.Statuses = []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored}
+			})
+
+			It("only subscribes to builds with those statuses", func() {
+				Expect(config.Subscribed("some-pipeline", atc.StatusFailed)).To(BeTrue())
+				Expect(config.Subscribed("some-pipeline", atc.StatusSucceeded)).To(BeFalse())
+			})
+		})
+
+		Context("when limited to pipelines", func() {
+			BeforeEach(func() {
+				config.Pipelines = []string{"some-pipeline"}
+			})
+
+			It("only subscribes to builds of those pipelines", func() {
+				Expect(config.Subscribed("some-pipeline", atc.StatusFailed)).To(BeTrue())
+				Expect(config.Subscribed("other-pipeline", atc.StatusFailed)).To(BeFalse())
+				Expect(config.Subscribed("", atc.StatusFailed)).To(BeFalse())
+			})
+		})
+	})
+})
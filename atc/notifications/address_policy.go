@@ -0,0 +1,144 @@
+package notifications
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// DefaultDeniedNetworks are the networks that notifications are never
+// delivered to unless they are allowed explicitly, so that a team can't use a
+// notification to reach the web node itself or the services on its network,
+// e.g. a cloud provider's metadata endpoint at 169.254.169.254.
+var DefaultDeniedNetworks = []string{
+	// "this" network, which connects to the local host
+	"0.0.0.0/8",
+	"::/128",
+
+	// loopback
+	"127.0.0.0/8",
+	"::1/128",
+
+	// link-local
+	"169.254.0.0/16",
+	"fe80::/10",
+
+	// private
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+}
+
+// Network is a network given in CIDR notation, e.g. '10.0.0.0/8'.
+type Network struct {
+	*net.IPNet
+}
+
+func (network *Network) UnmarshalFlag(value string) error {
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return fmt.Errorf("invalid network '%s': must be in CIDR notation, e.g. '10.0.0.0/8'", value)
+	}
+
+	network.IPNet = ipNet
+
+	return nil
+}
+
+// AddressPolicy decides which addresses notifications may be delivered to.
+// An address is allowed unless it is in one of the denied networks, and it is
+// always allowed if it is in one of the allowed networks.
+type AddressPolicy struct {
+	Allowed []Network
+	Denied  []Network
+}
+
+// NewAddressPolicy returns a policy which denies the DefaultDeniedNetworks as
+// well as the given denied networks.
+func NewAddressPolicy(allowed []Network, denied []Network) AddressPolicy {
+	policy := AddressPolicy{
+		Allowed: allowed,
+		Denied:  append([]Network{}, denied...),
+	}
+
+	for _, cidr := range DefaultDeniedNetworks {
+		var network Network
+		err := network.UnmarshalFlag(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		policy.Denied = append(policy.Denied, network)
+	}
+
+	return policy
+}
+
+func (policy AddressPolicy) Allows(ip net.IP) bool {
+	for _, network := range policy.Allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	for _, network := range policy.Denied {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Client returns an HTTP client which only connects to the addresses that the
+// policy allows.
+//
+// The address is checked by the dialer once the host has been resolved, so
+// the policy holds for every address that a host resolves to, however its DNS
+// records change, and for every redirect that is followed. For the same
+// reason, no proxy is used even if one is configured in the environment, as
+// the policy would then be checked against the proxy.
+func (policy AddressPolicy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   policy.control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+func (policy AddressPolicy) control(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !policy.Allows(ip) {
+		return ErrAddressDenied{Address: host}
+	}
+
+	return nil
+}
+
+// ErrAddressDenied is returned when a notification would have been delivered
+// to an address that the AddressPolicy does not allow.
+type ErrAddressDenied struct {
+	Address string
+}
+
+func (err ErrAddressDenied) Error() string {
+	return fmt.Sprintf("notifications may not be delivered to %s", err.Address)
+}
@@ -0,0 +1,134 @@
+package notifications_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/concourse/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AddressPolicy", func() {
+	network := func(cidr string) notifications.Network {
+		var network notifications.Network
+		Expect(network.UnmarshalFlag(cidr)).To(Succeed())
+		return network
+	}
+
+	DescribeTable("Allows",
+		func(policy notifications.AddressPolicy, address string, allowed bool) {
+			Expect(policy.Allows(net.ParseIP(address))).To(Equal(allowed))
+		},
+		Entry("a public address", notifications.NewAddressPolicy(nil, nil), "203.0.113.10", true),
+		Entry("a public IPv6 address", notifications.NewAddressPolicy(nil, nil), "2001:db8::1", true),
+		Entry("loopback", notifications.NewAddressPolicy(nil, nil), "127.0.0.1", false),
+		Entry("IPv6 loopback", notifications.NewAddressPolicy(nil, nil), "::1", false),
+		Entry("loopback mapped to IPv6", notifications.NewAddressPolicy(nil, nil), "::ffff:127.0.0.1", false),
+		Entry("the unspecified address", notifications.NewAddressPolicy(nil, nil), "0.0.0.0", false),
+		Entry("the metadata endpoint", notifications.NewAddressPolicy(nil, nil), "169.254.169.254", false),
+		Entry("IPv6 link-local", notifications.NewAddressPolicy(nil, nil), "fe80::1", false),
+		Entry("a private address", notifications.NewAddressPolicy(nil, nil), "10.1.2.3", false),
+		Entry("another private address", notifications.NewAddressPolicy(nil, nil), "172.16.0.1", false),
+		Entry("yet another private address", notifications.NewAddressPolicy(nil, nil), "192.168.1.1", false),
+		Entry("an IPv6 unique local address", notifications.NewAddressPolicy(nil, nil), "fd00::1", false),
+		Entry("an allowed private address",
+			notifications.NewAddressPolicy([]notifications.Network{network("10.1.0.0/16")}, nil),
+			"10.1.2.3", true),
+		Entry("a private address outside of the allowed network",
+			notifications.NewAddressPolicy([]notifications.Network{network("10.1.0.0/16")}, nil),
+			"10.2.2.3", false),
+		Entry("a denied public address",
+			notifications.NewAddressPolicy(nil, []notifications.Network{network("203.0.113.0/24")}),
+			"203.0.113.10", false),
+	)
+
+	It("refuses a network that is not in CIDR notation", func() {
+		var network notifications.Network
+		Expect(network.UnmarshalFlag("10.0.0.1")).To(MatchError("invalid network '10.0.0.1': must be in CIDR notation, e.g. '10.0.0.0/8'"))
+	})
+
+	Describe("Client", func() {
+		var (
+			server *httptest.Server
+			policy notifications.AddressPolicy
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			policy = notifications.NewAddressPolicy(nil, nil)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		post := func(url string) (*http.Response, error) {
+			resp, err := policy.Client(time.Second).Post(url, "application/json", nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+
+			return resp, err
+		}
+
+		It("does not connect to a denied address", func() {
+			_, err := post(server.URL)
+			Expect(err).To(MatchError(notifications.ErrAddressDenied{Address: "127.0.0.1"}))
+		})
+
+		It("does not connect to a denied address that a host name resolves to", func() {
+			_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = post("http://localhost:" + port)
+			Expect(err).To(MatchError(ContainSubstring("notifications may not be delivered to")))
+		})
+
+		Context("when the address is allowed", func() {
+			BeforeEach(func() {
+				policy = notifications.NewAddressPolicy([]notifications.Network{network("127.0.0.1/32")}, nil)
+			})
+
+			It("connects to it", func() {
+				resp, err := post(server.URL)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			Context("when it redirects to a denied address", func() {
+				var deniedServer *httptest.Server
+
+				BeforeEach(func() {
+					listener, err := net.Listen("tcp", "127.0.0.2:0")
+					if err != nil {
+						Skip("cannot listen on 127.0.0.2: " + err.Error())
+					}
+
+					deniedServer = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}))
+					deniedServer.Listener.Close()
+					deniedServer.Listener = listener
+					deniedServer.Start()
+
+					server.Config.Handler = http.RedirectHandler(deniedServer.URL, http.StatusTemporaryRedirect)
+				})
+
+				AfterEach(func() {
+					deniedServer.Close()
+				})
+
+				It("does not follow the redirect", func() {
+					_, err := post(server.URL)
+					Expect(err).To(MatchError(notifications.ErrAddressDenied{Address: "127.0.0.2"}))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,183 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the body of a delivery,
+	// signed with the notification's secret, as "sha256=<hex digest>".
+	SignatureHeader = "X-Concourse-Signature"
+
+	// DeliveryHeader carries the ID of the delivery, which stays the same
+	// across its attempts.
+	DeliveryHeader = "X-Concourse-Delivery"
+)
+
+// the most of a response body to keep as the error of a failed attempt
+const maxResponseBodyInError = 1024
+
+type Notifier struct {
+	deliveryFactory db.NotificationDeliveryFactory
+	client          *http.Client
+	clock           clock.Clock
+
+	externalURL   string
+	maxAttempts   int
+	retryInterval time.Duration
+	batchSize     int
+}
+
+// NewNotifier returns a component which delivers pending notifications,
+// batchSize at a time. Failed deliveries are retried with an exponential
+// backoff starting at retryInterval, and are moved to the dead letters after
+// maxAttempts.
+func NewNotifier(
+	deliveryFactory db.NotificationDeliveryFactory,
+	client *http.Client,
+	clock clock.Clock,
+	externalURL string,
+	maxAttempts int,
+	retryInterval time.Duration,
+	batchSize int,
+) *Notifier {
+	return &Notifier{
+		deliveryFactory: deliveryFactory,
+		client:          client,
+		clock:           clock,
+		externalURL:     externalURL,
+		maxAttempts:     maxAttempts,
+		retryInterval:   retryInterval,
+		batchSize:       batchSize,
+	}
+}
+
+func (n *Notifier) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("notifier")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	deliveries, err := n.deliveryFactory.PendingDeliveries(n.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		err := n.deliver(ctx, logger, delivery)
+		if err != nil {
+			// carry on with the other deliveries; this one will be retried
+			logger.Error("failed-to-update-delivery", err, lager.Data{"delivery": delivery.ID()})
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) deliver(ctx context.Context, logger lager.Logger, delivery db.NotificationDelivery) error {
+	logger = logger.Session("deliver", lager.Data{
+		"delivery":     delivery.ID(),
+		"notification": delivery.Notification(),
+		"attempt":      delivery.Attempts() + 1,
+	})
+
+	config, found := delivery.Config()
+	if !found {
+		logger.Info("notification-no-longer-configured")
+		return delivery.Fail(0, "notification is no longer configured")
+	}
+
+	status, err := n.post(ctx, delivery, config.Secret)
+	if err == nil {
+		logger.Debug("delivered", lager.Data{"status": status})
+		return delivery.Succeeded(status)
+	}
+
+	logger.Info("failed-to-deliver", lager.Data{"status": status, "error": err.Error()})
+
+	if delivery.Attempts()+1 >= n.maxAttempts {
+		return delivery.Fail(status, err.Error())
+	}
+
+	return delivery.Retry(status, err.Error(), n.clock.Now().Add(n.backoff(delivery.Attempts())))
+}
+
+func (n *Notifier) post(ctx context.Context, delivery db.NotificationDelivery, secret string) (int, error) {
+	body, err := n.body(delivery)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID()))
+
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyInError))
+		return resp.StatusCode, fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// body fills in the URL of the build in the payload of the delivery.
+func (n *Notifier) body(delivery db.NotificationDelivery) ([]byte, error) {
+	var notification atc.BuildNotification
+	err := json.Unmarshal(delivery.Payload(), &notification)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.URL = fmt.Sprintf("%s/builds/%d", strings.TrimSuffix(n.externalURL, "/"), notification.Build.ID)
+
+	return json.Marshal(notification)
+}
+
+// the most the retry interval is doubled, to keep the backoff from overflowing
+const maxBackoffDoublings = 16
+
+func (n *Notifier) backoff(attempts int) time.Duration {
+	return n.retryInterval * time.Duration(1<<min(attempts, maxBackoffDoublings))
+}
+
+// Sign returns the value of the signature header for a body signed with the
+// given secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,209 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		server              *ghttp.Server
+		fakeDeliveryFactory *dbfakes.FakeNotificationDeliveryFactory
+		fakeDelivery        *dbfakes.FakeNotificationDelivery
+		fakeClock           *fakeclock.FakeClock
+
+		notifier *notifications.Notifier
+		runErr   error
+	)
+
+	payload := `{"notification":"chat","build":{"id":42,"team_name":"main","name":"1","status":"failed","api_url":"/api/v1/builds/42"}}`
+	body := `{"notification":"chat","build":{"id":42,"team_name":"main","name":"1","status":"failed","api_url":"/api/v1/builds/42"},"url":"https://ci.example.com/builds/42"}`
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.AllowUnhandledRequests = true
+
+		fakeDelivery = new(dbfakes.FakeNotificationDelivery)
+		fakeDelivery.IDReturns(7)
+		fakeDelivery.NotificationReturns("chat")
+		fakeDelivery.URLReturns(server.URL() + "/hooks/concourse")
+		fakeDelivery.PayloadReturns([]byte(payload))
+		fakeDelivery.ConfigReturns(atc.NotificationConfig{
+			Name:   "chat",
+			URL:    server.URL() + "/hooks/concourse",
+			Secret: "some-secret",
+		}, true)
+
+		fakeDeliveryFactory = new(dbfakes.FakeNotificationDeliveryFactory)
+		fakeDeliveryFactory.PendingDeliveriesReturns([]db.NotificationDelivery{fakeDelivery}, nil)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+
+		notifier = notifications.NewNotifier(
+			fakeDeliveryFactory,
+			http.DefaultClient,
+			fakeClock,
+			"https://ci.example.com/",
+			3,
+			time.Minute,
+			100,
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = notifier.Run(context.TODO())
+	})
+
+	It("fetches a batch of pending deliveries", func() {
+		Expect(fakeDeliveryFactory.PendingDeliveriesCallCount()).To(Equal(1))
+		Expect(fakeDeliveryFactory.PendingDeliveriesArgsForCall(0)).To(Equal(100))
+	})
+
+	Context("when the webhook accepts the delivery", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hooks/concourse"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyHeaderKV(notifications.DeliveryHeader, "7"),
+				ghttp.VerifyHeaderKV(notifications.SignatureHeader, notifications.Sign("some-secret", []byte(body))),
+				ghttp.VerifyJSON(body),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("posts the signed payload with the build's url", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("marks the delivery as succeeded", func() {
+			Expect(fakeDelivery.SucceededCallCount()).To(Equal(1))
+			Expect(fakeDelivery.SucceededArgsForCall(0)).To(Equal(http.StatusNoContent))
+		})
+
+		Context("when the notification has no secret", func() {
+			BeforeEach(func() {
+				fakeDelivery.ConfigReturns(atc.NotificationConfig{Name: "chat"}, true)
+
+				server.SetHandler(0, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.Header).ToNot(HaveKey(notifications.SignatureHeader))
+					},
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+			})
+
+			It("does not sign the payload", func() {
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+				Expect(fakeDelivery.SucceededCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when the webhook rejects the delivery", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, "upstream is down\n"))
+		})
+
+		Context("when the delivery has attempts left", func() {
+			BeforeEach(func() {
+				fakeDelivery.AttemptsReturns(1)
+			})
+
+			It("retries it with a backoff", func() {
+				Expect(fakeDelivery.RetryCallCount()).To(Equal(1))
+				status, lastError, retryAt := fakeDelivery.RetryArgsForCall(0)
+				Expect(status).To(Equal(http.StatusBadGateway))
+				Expect(lastError).To(Equal("unexpected response 502 Bad Gateway: upstream is down"))
+				Expect(retryAt).To(Equal(fakeClock.Now().Add(2 * time.Minute)))
+
+				Expect(fakeDelivery.FailCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when it was the last attempt", func() {
+			BeforeEach(func() {
+				fakeDelivery.AttemptsReturns(2)
+			})
+
+			It("moves it to the dead letters", func() {
+				Expect(fakeDelivery.FailCallCount()).To(Equal(1))
+				status, lastError := fakeDelivery.FailArgsForCall(0)
+				Expect(status).To(Equal(http.StatusBadGateway))
+				Expect(lastError).To(ContainSubstring("upstream is down"))
+
+				Expect(fakeDelivery.RetryCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the webhook can not be reached", func() {
+		BeforeEach(func() {
+			server.Close()
+		})
+
+		It("retries the delivery", func() {
+			Expect(fakeDelivery.RetryCallCount()).To(Equal(1))
+			status, lastError, _ := fakeDelivery.RetryArgsForCall(0)
+			Expect(status).To(BeZero())
+			Expect(lastError).ToNot(BeEmpty())
+		})
+	})
+
+	Context("when the notification is no longer configured", func() {
+		BeforeEach(func() {
+			fakeDelivery.ConfigReturns(atc.NotificationConfig{}, false)
+		})
+
+		It("moves the delivery to the dead letters without sending it", func() {
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+			Expect(fakeDelivery.FailCallCount()).To(Equal(1))
+			_, lastError := fakeDelivery.FailArgsForCall(0)
+			Expect(lastError).To(Equal("notification is no longer configured"))
+		})
+	})
+
+	Context("when updating a delivery fails", func() {
+		var otherDelivery *dbfakes.FakeNotificationDelivery
+
+		BeforeEach(func() {
+			fakeDelivery.ConfigReturns(atc.NotificationConfig{}, false)
+			fakeDelivery.FailReturns(errors.New("nope"))
+
+			otherDelivery = new(dbfakes.FakeNotificationDelivery)
+			otherDelivery.ConfigReturns(atc.NotificationConfig{}, false)
+
+			fakeDeliveryFactory.PendingDeliveriesReturns([]db.NotificationDelivery{fakeDelivery, otherDelivery}, nil)
+		})
+
+		It("carries on with the other deliveries", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(otherDelivery.FailCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when fetching the pending deliveries fails", func() {
+		BeforeEach(func() {
+			fakeDeliveryFactory.PendingDeliveriesReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
@@ -0,0 +1,104 @@
+package atc
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// PipelineSourceConfig declares a git repository as the source of truth for
+// a team's pipelines. Each pipeline config file in the repository's Dir is set
+// as the pipeline named after the file, and pipelines whose files are removed
+// are archived. Pipelines set from the repository cannot be set in any other
+// way.
+type PipelineSourceConfig struct {
+	URI string `json:"uri"`
+
+	// Branch defaults to the repository's default branch.
+	Branch string `json:"branch,omitempty"`
+
+	// Dir is the directory within the repository containing the pipeline
+	// config files. It defaults to the root of the repository.
+	Dir string `json:"dir,omitempty"`
+}
+
+func (config PipelineSourceConfig) Validate() error {
+	if config.URI == "" {
+		return errors.New("pipeline source has no uri")
+	}
+
+	if !isRemoteGitURI(config.URI) {
+		return errors.New("pipeline source uri must be an https, ssh or git url")
+	}
+
+	if strings.HasPrefix(config.Branch, "-") {
+		return errors.New("pipeline source has an invalid branch")
+	}
+
+	if path.IsAbs(config.Dir) || strings.HasPrefix(path.Clean(config.Dir), "..") {
+		return errors.New("pipeline source dir must be within the repository")
+	}
+
+	return nil
+}
+
+// isRemoteGitURI returns whether the URI is cloned over https, ssh or git,
+// including the scp-like user@host:path form of ssh, rather than from a path
+// on the web node.
+func isRemoteGitURI(uri string) bool {
+	for _, scheme := range []string{"https://", "ssh://", "git://"} {
+		if strings.HasPrefix(uri, scheme) {
+			return true
+		}
+	}
+
+	if strings.HasPrefix(uri, "-") || strings.Contains(uri, "://") {
+		return false
+	}
+
+	// host::address is handed to a remote helper, which can run anything
+	host, address, found := strings.Cut(uri, ":")
+	return found && host != "" && !strings.Contains(host, "/") && !strings.HasPrefix(address, ":")
+}
+
+// PipelineSourceStatus is the outcome of the latest sync of a team's
+// pipelines from its pipeline source.
+type PipelineSourceStatus struct {
+	Source PipelineSourceConfig `json:"source"`
+
+	// Commit is the commit the pipelines were last synced from.
+	Commit   string `json:"commit,omitempty"`
+	SyncedAt int64  `json:"synced_at,omitempty"`
+
+	// Error is set if the repository could not be synced at all.
+	Error string `json:"error,omitempty"`
+
+	Pipelines []ManagedPipelineStatus `json:"pipelines"`
+}
+
+// Drifted returns whether any of the pipelines differ from their files.
+func (status PipelineSourceStatus) Drifted() bool {
+	for _, pipeline := range status.Pipelines {
+		if pipeline.Drifted {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ManagedPipelineStatus struct {
+	Name string `json:"name"`
+
+	// Path is the pipeline's config file, relative to the repository.
+	Path string `json:"path"`
+
+	// Drifted is set when the pipeline's config differs from its file, e.g.
+	// because the file is invalid and could not be set.
+	Drifted bool `json:"drifted"`
+
+	// Archived is set when the pipeline's file has been removed.
+	Archived bool `json:"archived,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
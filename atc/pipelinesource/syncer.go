@@ -0,0 +1,379 @@
+package pipelinesource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Syncer is a component which sets each team's pipelines from the config
+// files in the team's pipeline source. Pipelines are created or updated to
+// match their files, and those whose files have been removed are archived.
+type Syncer struct {
+	teamFactory db.TeamFactory
+	clock       clock.Clock
+
+	// workDir is where each repository is cloned while it is being synced.
+	workDir string
+
+	// allowedProtocols are the transports that git may use to clone a
+	// pipeline source.
+	allowedProtocols []string
+
+	// gitTimeout is how long each git command run to sync a pipeline source
+	// may take.
+	gitTimeout time.Duration
+}
+
+func NewSyncer(
+	teamFactory db.TeamFactory,
+	clock clock.Clock,
+	workDir string,
+	allowedProtocols []string,
+	gitTimeout time.Duration,
+) *Syncer {
+	return &Syncer{
+		teamFactory:      teamFactory,
+		clock:            clock,
+		workDir:          workDir,
+		allowedProtocols: allowedProtocols,
+		gitTimeout:       gitTimeout,
+	}
+}
+
+func (s *Syncer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipeline-source-syncer")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	teams, err := s.teamFactory.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return err
+	}
+
+	for _, team := range teams {
+		source := team.PipelineSource()
+		if source == nil {
+			continue
+		}
+
+		status := s.sync(ctx, logger.Session("sync", lager.Data{"team": team.Name()}), team, *source)
+
+		err := team.UpdatePipelineSourceStatus(status)
+		if err != nil {
+			// carry on with the other teams; this one will be synced again
+			logger.Error("failed-to-update-pipeline-source-status", err, lager.Data{"team": team.Name()})
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) sync(ctx context.Context, logger lager.Logger, team db.Team, source atc.PipelineSourceConfig) atc.PipelineSourceStatus {
+	status := atc.PipelineSourceStatus{
+		Source:    source,
+		SyncedAt:  s.clock.Now().Unix(),
+		Pipelines: []atc.ManagedPipelineStatus{},
+	}
+
+	// nothing is archived unless the repository can be read, so that a
+	// failure to clone it doesn't look like all of the files were removed
+	files, commit, err := s.fetch(ctx, source)
+	if err != nil {
+		logger.Info("failed-to-fetch", lager.Data{"error": err.Error()})
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Commit = commit
+
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		status.Error = fmt.Sprintf("failed to get pipelines: %s", err)
+		return status
+	}
+
+	existing := map[string]db.Pipeline{}
+	for _, pipeline := range pipelines {
+		if len(pipeline.InstanceVars()) == 0 {
+			existing[pipeline.Name()] = pipeline
+		}
+	}
+
+	set := map[string]string{}
+	for _, file := range files {
+		name := strings.TrimSuffix(file.name, path.Ext(file.name))
+		filePath := path.Join(source.Dir, file.name)
+
+		if other, found := set[name]; found {
+			status.Pipelines = append(status.Pipelines, atc.ManagedPipelineStatus{
+				Name:    name,
+				Path:    filePath,
+				Drifted: true,
+				Error:   fmt.Sprintf("pipeline '%s' is already set from %s", name, other),
+			})
+			continue
+		}
+
+		set[name] = filePath
+
+		pipelineStatus := s.setPipeline(team, existing[name], name, filePath, file.content)
+		if pipelineStatus.Error != "" {
+			logger.Info("failed-to-set-pipeline", lager.Data{"pipeline": name, "error": pipelineStatus.Error})
+		}
+
+		status.Pipelines = append(status.Pipelines, pipelineStatus)
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.SourcePath() == "" {
+			continue
+		}
+
+		if _, found := set[pipeline.Name()]; found && len(pipeline.InstanceVars()) == 0 {
+			continue
+		}
+
+		pipelineStatus := atc.ManagedPipelineStatus{
+			Name:     pipeline.Name(),
+			Path:     pipeline.SourcePath(),
+			Archived: true,
+		}
+
+		err := pipeline.Archive()
+		if err == nil {
+			// the pipeline is no longer managed, so it can be set again in
+			// any other way
+			err = pipeline.SetSourcePath("")
+		}
+
+		if err != nil {
+			logger.Error("failed-to-archive-pipeline", err, lager.Data{"pipeline": pipeline.Name()})
+			pipelineStatus.Drifted = true
+			pipelineStatus.Error = fmt.Sprintf("failed to archive pipeline: %s", err)
+		}
+
+		status.Pipelines = append(status.Pipelines, pipelineStatus)
+	}
+
+	return status
+}
+
+// setPipeline sets the pipeline to the config in its file, unless it already
+// matches.
+func (s *Syncer) setPipeline(team db.Team, existing db.Pipeline, name string, filePath string, content []byte) atc.ManagedPipelineStatus {
+	status := atc.ManagedPipelineStatus{
+		Name: name,
+		Path: filePath,
+	}
+
+	drifted := func(message string, args ...interface{}) atc.ManagedPipelineStatus {
+		status.Drifted = true
+		status.Error = fmt.Sprintf(message, args...)
+		return status
+	}
+
+	_, err := atc.ValidateIdentifier(name, "pipeline")
+	if err != nil {
+		return drifted(err.Error())
+	}
+
+	var config atc.Config
+	err = atc.UnmarshalConfig(content, &config)
+	if err != nil {
+		return drifted("malformed config: %s", err)
+	}
+
+	err = config.ExpandTemplates(team.StepTemplates())
+	if err != nil {
+		return drifted("invalid config: %s", err)
+	}
+
+	_, errorMessages := configvalidate.Validate(config)
+	if len(errorMessages) > 0 {
+		return drifted("invalid config: %s", strings.Join(errorMessages, "\n"))
+	}
+
+	pipeline := existing
+	from := db.ConfigVersion(0)
+	changed := true
+
+	if existing != nil {
+		// a pipeline that was set in some other way is left to whoever set
+		// it, rather than being overwritten by a file that happens to share
+		// its name
+		if existing.SourcePath() == "" {
+			return drifted("pipeline exists and is not managed by the pipeline source")
+		}
+
+		existingConfig, err := existing.Config()
+		if err != nil {
+			return drifted("failed to get pipeline config: %s", err)
+		}
+
+		from = existing.ConfigVersion()
+		changed = existing.Archived() || existingConfig.DiffExpanded(io.Discard, config)
+	}
+
+	if changed {
+		pipeline, _, err = team.SavePipelineAs(atc.PipelineRef{Name: name}, config, from, false, db.ConfigAuthor{
+			Source: atc.ConfigSourcePipelineSource,
+		})
+		if err != nil {
+			return drifted("failed to set pipeline: %s", err)
+		}
+	}
+
+	if pipeline.SourcePath() != filePath {
+		err = pipeline.SetSourcePath(filePath)
+		if err != nil {
+			return drifted("failed to set pipeline source: %s", err)
+		}
+	}
+
+	return status
+}
+
+type configFile struct {
+	name    string
+	content []byte
+}
+
+// fetch clones the repository, returning the pipeline config files in its
+// dir and the commit they are from.
+func (s *Syncer) fetch(ctx context.Context, source atc.PipelineSourceConfig) ([]configFile, string, error) {
+	dir, err := os.MkdirTemp(s.workDir, "pipeline-source-")
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if source.Branch != "" {
+		args = append(args, "--branch", source.Branch)
+	}
+
+	_, err = s.git(ctx, "", append(args, "--", source.URI, dir)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	commit, err := s.git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, "", err
+	}
+
+	configDir, err := s.resolveDir(dir, source.Dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var files []configFile
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(configDir, entry.Name()))
+		if err != nil {
+			return nil, "", err
+		}
+
+		files = append(files, configFile{
+			name:    entry.Name(),
+			content: content,
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	return files, strings.TrimSpace(commit), nil
+}
+
+// resolveDir returns the path of the dir within the clone, following any
+// symlinks, so long as it does not lead out of the clone.
+func (s *Syncer) resolveDir(clone string, dir string) (string, error) {
+	clone, err := filepath.EvalSymlinks(clone)
+	if err != nil {
+		return "", err
+	}
+
+	configDir, err := filepath.EvalSymlinks(filepath.Join(clone, filepath.FromSlash(path.Clean("/"+dir))))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("dir '%s' does not exist in the repository", dir)
+		}
+
+		return "", err
+	}
+
+	rel, err := filepath.Rel(clone, configDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("dir '%s' is not within the repository", dir)
+	}
+
+	return configDir, nil
+}
+
+// git runs git with none of the web node's environment, so that it can't
+// pick up its config or credentials, and only over the allowed protocols, so
+// that a pipeline source can't be cloned from the web node's filesystem.
+func (s *Syncer) git(ctx context.Context, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + s.workDir,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL=" + os.DevNull,
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL=" + strings.Join(s.allowedProtocols, ":"),
+	}
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("git %s timed out after %s", args[0], s.gitTimeout)
+		}
+
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,419 @@
+package pipelinesource_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/pipelinesource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const helloPipeline = `
+jobs:
+- name: hello
+  plan:
+  - task: say-hello
+    config:
+      platform: linux
+      run: {path: echo, args: [hello]}
+`
+
+var _ = Describe("Syncer", func() {
+	var (
+		remote   string
+		checkout string
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeClock       *fakeclock.FakeClock
+
+		source atc.PipelineSourceConfig
+
+		workDir          string
+		allowedProtocols []string
+		gitTimeout       time.Duration
+
+		syncer *pipelinesource.Syncer
+		runErr error
+	)
+
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=ci", "-c", "user.email=ci@example.com"}, args...)...)
+		cmd.Dir = dir
+
+		output, err := cmd.CombinedOutput()
+		Expect(err).ToNot(HaveOccurred(), string(output))
+
+		return strings.TrimSpace(string(output))
+	}
+
+	writeFile := func(name string, content string) {
+		path := filepath.Join(checkout, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	push := func() string {
+		git(checkout, "add", "-A")
+		git(checkout, "commit", "--quiet", "--allow-empty", "-m", "update pipelines")
+		git(checkout, "push", "--quiet", "origin", "HEAD:main")
+		return git(checkout, "rev-parse", "HEAD")
+	}
+
+	parse := func(content string) atc.Config {
+		var config atc.Config
+		Expect(atc.UnmarshalConfig([]byte(content), &config)).To(Succeed())
+		return config
+	}
+
+	status := func() atc.PipelineSourceStatus {
+		Expect(fakeTeam.UpdatePipelineSourceStatusCallCount()).To(Equal(1))
+		return fakeTeam.UpdatePipelineSourceStatusArgsForCall(0)
+	}
+
+	BeforeEach(func() {
+		tmp := GinkgoT().TempDir()
+
+		remote = filepath.Join(tmp, "pipelines.git")
+		git(tmp, "init", "--quiet", "--bare", "-b", "main", remote)
+
+		checkout = filepath.Join(tmp, "checkout")
+		git(tmp, "clone", "--quiet", remote, checkout)
+		git(checkout, "checkout", "--quiet", "-b", "main")
+
+		source = atc.PipelineSourceConfig{
+			URI: "file://" + remote,
+			Dir: "pipelines",
+		}
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeam.PipelineSourceStub = func() *atc.PipelineSourceConfig {
+			return &source
+		}
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetTeamsReturns([]db.Team{fakeTeam}, nil)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+
+		// the repository is cloned from the local filesystem
+		allowedProtocols = []string{"file"}
+		gitTimeout = time.Minute
+		workDir = tmp
+	})
+
+	JustBeforeEach(func() {
+		syncer = pipelinesource.NewSyncer(fakeTeamFactory, fakeClock, workDir, allowedProtocols, gitTimeout)
+		runErr = syncer.Run(context.TODO())
+	})
+
+	Context("when a team has no pipeline source", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineSourceStub = nil
+			fakeTeam.PipelineSourceReturns(nil)
+		})
+
+		It("leaves its pipelines alone", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.PipelinesCallCount()).To(BeZero())
+			Expect(fakeTeam.UpdatePipelineSourceStatusCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the repository has pipeline config files", func() {
+		var (
+			commit        string
+			savedPipeline *dbfakes.FakePipeline
+		)
+
+		BeforeEach(func() {
+			writeFile("pipelines/hello.yml", helloPipeline)
+			writeFile("pipelines/README.md", "not a pipeline")
+			writeFile("ci/tasks/unit.yml", "not in the dir")
+			commit = push()
+
+			savedPipeline = new(dbfakes.FakePipeline)
+			fakeTeam.SavePipelineAsReturns(savedPipeline, true, nil)
+		})
+
+		Context("when the pipeline does not exist", func() {
+			It("sets it unpaused from its file", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				ref, config, from, initiallyPaused, author := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(ref).To(Equal(atc.PipelineRef{Name: "hello"}))
+				Expect(config).To(Equal(parse(helloPipeline)))
+				Expect(from).To(Equal(db.ConfigVersion(0)))
+				Expect(initiallyPaused).To(BeFalse())
+				Expect(author).To(Equal(db.ConfigAuthor{Source: atc.ConfigSourcePipelineSource}))
+			})
+
+			It("marks it as managed by its file", func() {
+				Expect(savedPipeline.SetSourcePathCallCount()).To(Equal(1))
+				Expect(savedPipeline.SetSourcePathArgsForCall(0)).To(Equal("pipelines/hello.yml"))
+			})
+
+			It("records that it is in sync with the commit", func() {
+				Expect(status()).To(Equal(atc.PipelineSourceStatus{
+					Source:   source,
+					Commit:   commit,
+					SyncedAt: 1000,
+					Pipelines: []atc.ManagedPipelineStatus{
+						{Name: "hello", Path: "pipelines/hello.yml"},
+					},
+				}))
+			})
+		})
+
+		Context("when the pipeline already matches its file", func() {
+			var existing *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				existing = new(dbfakes.FakePipeline)
+				existing.NameReturns("hello")
+				existing.SourcePathReturns("pipelines/hello.yml")
+				existing.ConfigReturns(parse(helloPipeline), nil)
+
+				fakeTeam.PipelinesReturns([]db.Pipeline{existing}, nil)
+			})
+
+			It("does not set it again", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+				Expect(existing.SetSourcePathCallCount()).To(BeZero())
+				Expect(status().Drifted()).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline differs from its file", func() {
+			var existing *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				existing = new(dbfakes.FakePipeline)
+				existing.NameReturns("hello")
+				existing.SourcePathReturns("pipelines/hello.yml")
+				existing.ConfigVersionReturns(42)
+				existing.ConfigReturns(parse(strings.Replace(helloPipeline, "[hello]", "[goodbye]", 1)), nil)
+
+				fakeTeam.PipelinesReturns([]db.Pipeline{existing}, nil)
+			})
+
+			It("sets it from its current version", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				_, config, from, _, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(config).To(Equal(parse(helloPipeline)))
+				Expect(from).To(Equal(db.ConfigVersion(42)))
+			})
+
+			Context("when setting it fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SavePipelineAsReturns(nil, false, errors.New("disaster"))
+				})
+
+				It("records that it has drifted", func() {
+					Expect(runErr).ToNot(HaveOccurred())
+					Expect(status().Pipelines).To(Equal([]atc.ManagedPipelineStatus{
+						{
+							Name:    "hello",
+							Path:    "pipelines/hello.yml",
+							Drifted: true,
+							Error:   "failed to set pipeline: disaster",
+						},
+					}))
+				})
+			})
+		})
+
+		Context("when a pipeline that is not managed has the same name as a file", func() {
+			var existing *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				existing = new(dbfakes.FakePipeline)
+				existing.NameReturns("hello")
+				existing.ConfigReturns(parse(strings.Replace(helloPipeline, "[hello]", "[goodbye]", 1)), nil)
+
+				fakeTeam.PipelinesReturns([]db.Pipeline{existing}, nil)
+			})
+
+			It("leaves it alone", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+				Expect(existing.SetSourcePathCallCount()).To(BeZero())
+			})
+
+			It("records that it has drifted", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(status().Pipelines).To(Equal([]atc.ManagedPipelineStatus{
+					{
+						Name:    "hello",
+						Path:    "pipelines/hello.yml",
+						Drifted: true,
+						Error:   "pipeline exists and is not managed by the pipeline source",
+					},
+				}))
+			})
+		})
+
+		Context("when a file is invalid", func() {
+			BeforeEach(func() {
+				writeFile("pipelines/broken.yaml", "jobs: [{name: broken, plan: [{get: unknown-resource}]}]")
+				commit = push()
+			})
+
+			It("sets the other pipelines", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(1))
+
+				ref, _, _, _, _ := fakeTeam.SavePipelineAsArgsForCall(0)
+				Expect(ref.Name).To(Equal("hello"))
+			})
+
+			It("records that its pipeline has drifted", func() {
+				pipelines := status().Pipelines
+				Expect(pipelines).To(HaveLen(2))
+				Expect(pipelines[0].Name).To(Equal("broken"))
+				Expect(pipelines[0].Path).To(Equal("pipelines/broken.yaml"))
+				Expect(pipelines[0].Drifted).To(BeTrue())
+				Expect(pipelines[0].Error).To(ContainSubstring("invalid config:"))
+				Expect(pipelines[1].Drifted).To(BeFalse())
+			})
+		})
+
+		Context("when a managed pipeline's file has been removed", func() {
+			var removed, unmanaged *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				removed = new(dbfakes.FakePipeline)
+				removed.NameReturns("removed")
+				removed.SourcePathReturns("pipelines/removed.yml")
+
+				unmanaged = new(dbfakes.FakePipeline)
+				unmanaged.NameReturns("unmanaged")
+
+				fakeTeam.PipelinesReturns([]db.Pipeline{removed, unmanaged}, nil)
+			})
+
+			It("archives it and stops managing it", func() {
+				Expect(removed.ArchiveCallCount()).To(Equal(1))
+				Expect(removed.SetSourcePathCallCount()).To(Equal(1))
+				Expect(removed.SetSourcePathArgsForCall(0)).To(BeEmpty())
+
+				Expect(status().Pipelines).To(ContainElement(atc.ManagedPipelineStatus{
+					Name:     "removed",
+					Path:     "pipelines/removed.yml",
+					Archived: true,
+				}))
+			})
+
+			It("leaves pipelines which are not managed alone", func() {
+				Expect(unmanaged.ArchiveCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a branch is configured", func() {
+			BeforeEach(func() {
+				git(checkout, "checkout", "--quiet", "-b", "release")
+				writeFile("pipelines/release.yml", helloPipeline)
+				git(checkout, "add", "-A")
+				git(checkout, "commit", "--quiet", "-m", "add release pipeline")
+				git(checkout, "push", "--quiet", "origin", "release")
+
+				source.Branch = "release"
+			})
+
+			It("sets the pipelines from the branch", func() {
+				Expect(fakeTeam.SavePipelineAsCallCount()).To(Equal(2))
+
+				ref, _, _, _, _ := fakeTeam.SavePipelineAsArgsForCall(1)
+				Expect(ref.Name).To(Equal("release"))
+			})
+		})
+	})
+
+	Context("when the repository cannot be cloned", func() {
+		var managed *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			source.URI = "file://" + filepath.Join(filepath.Dir(remote), "bogus.git")
+
+			managed = new(dbfakes.FakePipeline)
+			managed.NameReturns("managed")
+			managed.SourcePathReturns("pipelines/managed.yml")
+			fakeTeam.PipelinesReturns([]db.Pipeline{managed}, nil)
+		})
+
+		It("records the error without archiving anything", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(status().Error).To(ContainSubstring("git clone failed"))
+			Expect(managed.ArchiveCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the repository's protocol is not allowed", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/hello.yml", helloPipeline)
+			push()
+
+			allowedProtocols = []string{"https", "ssh", "git"}
+		})
+
+		It("records the error without setting anything", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(status().Error).To(ContainSubstring("git clone failed"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when git takes too long", func() {
+		BeforeEach(func() {
+			writeFile("pipelines/hello.yml", helloPipeline)
+			push()
+
+			gitTimeout = time.Nanosecond
+		})
+
+		It("records the error without setting anything", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(status().Error).To(Equal("git clone timed out after 1ns"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the dir is a symlink out of the repository", func() {
+		BeforeEach(func() {
+			outside := filepath.Join(filepath.Dir(remote), "outside")
+			Expect(os.MkdirAll(outside, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(outside, "hello.yml"), []byte(helloPipeline), 0644)).To(Succeed())
+
+			Expect(os.Symlink(outside, filepath.Join(checkout, "pipelines"))).To(Succeed())
+			push()
+		})
+
+		It("records the error without setting anything", func() {
+			Expect(status().Error).To(Equal("dir 'pipelines' is not within the repository"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the dir does not exist in the repository", func() {
+		BeforeEach(func() {
+			writeFile("other/hello.yml", helloPipeline)
+			push()
+		})
+
+		It("records the error", func() {
+			Expect(status().Error).To(Equal("dir 'pipelines' does not exist in the repository"))
+			Expect(fakeTeam.SavePipelineAsCallCount()).To(BeZero())
+		})
+	})
+})
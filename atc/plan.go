@@ -11,6 +11,8 @@ type Plan struct {
 	Run         *RunPlan         `json:"run,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	SetVar      *SetVarPlan      `json:"set_var,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
@@ -25,6 +27,7 @@ type Plan struct {
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
+	If      *IfPlan      `json:"if,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -84,6 +87,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.Timeout.Step.Each(f)
 	}
 
+	if plan.If != nil {
+		plan.If.Step.Each(f)
+	}
+
 	if plan.Retry != nil {
 		for i, p := range *plan.Retry {
 			p.Each(f)
@@ -170,6 +177,11 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type IfPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -298,6 +310,10 @@ type CheckPlan struct {
 
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
+
+	// The webhook request that triggered the check, if any, which is passed
+	// on to the resource.
+	Webhook *Webhook `json:"webhook,omitempty"`
 }
 
 func (plan CheckPlan) IsResourceCheck() bool {
@@ -317,6 +333,10 @@ type TaskPlan struct {
 	// the container to external will be dropped.
 	Hermetic bool `json:"hermetic"`
 
+	// Skip running the task if it has already succeeded with the same config,
+	// image and inputs, and use the outputs it produced then instead.
+	Memoize bool `json:"memoize,omitempty"`
+
 	// Worker tags to influence placement of the container.
 	Tags Tags `json:"tags,omitempty"`
 
@@ -392,11 +412,25 @@ type SetPipelinePlan struct {
 
 type LoadVarPlan struct {
 	Name   string `json:"name"`
-	File   string `json:"file"`
+	File   string `json:"file,omitempty"`
+	Dir    string `json:"dir,omitempty"`
 	Format string `json:"format,omitempty"`
+	Select string `json:"select,omitempty"`
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type SetVarPlan struct {
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Format  string `json:"format,omitempty"`
+	Manager string `json:"manager,omitempty"`
+}
+
+type ApprovalPlan struct {
+	Name      string   `json:"name"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
@@ -12,6 +12,7 @@ import (
 
 const ActionUseImage = "UseImage"
 const ActionRunSetPipeline = "SetPipeline"
+const ActionRunSetVar = "SetVar"
 
 type PolicyCheckNotPass struct {
 	Messages []string
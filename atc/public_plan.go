@@ -7,6 +7,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		return nil
 	}
 
+	if plan.If != nil {
+		return plan.If.Public()
+	}
+
 	var public struct {
 		ID PlanID `json:"id,omitempty"`
 
@@ -20,6 +24,8 @@ func (plan *Plan) Public() *json.RawMessage {
 		Run            *json.RawMessage `json:"run,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		SetVar         *json.RawMessage `json:"set_var,omitempty"`
+		Approval       *json.RawMessage `json:"approval,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
@@ -75,6 +81,14 @@ func (plan *Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.SetVar != nil {
+		public.SetVar = plan.SetVar.Public()
+	}
+
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -325,6 +339,26 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan SetVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name    string `json:"name"`
+		Manager string `json:"manager,omitempty"`
+	}{
+		Name:    plan.Name,
+		Manager: plan.Manager,
+	})
+}
+
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name      string   `json:"name"`
+		Approvers []string `json:"approvers,omitempty"`
+	}{
+		Name:      plan.Name,
+		Approvers: plan.Approvers,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -335,6 +369,12 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+// Public returns the guarded step itself; an `if` has no representation of
+// its own, as the step reports whether it was skipped.
+func (plan IfPlan) Public() *json.RawMessage {
+	return plan.Step.Public()
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
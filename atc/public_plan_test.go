@@ -963,5 +963,72 @@ var _ = Describe("Plan", func() {
 }
 `))
 		})
+
+		It("returns the guarded step in place of an if", func() {
+			plan := atc.Plan{
+				ID: "0",
+				If: &atc.IfPlan{
+					Condition: "((branch)) == main",
+					Step: atc.Plan{
+						ID: "1",
+						LoadVar: &atc.LoadVarPlan{
+							Name: "some-name",
+							File: "some-file",
+						},
+					},
+				},
+			}
+
+			json := plan.Public()
+			Expect(json).ToNot(BeNil())
+			Expect([]byte(*json)).To(MatchJSON(`{
+				"id": "1",
+				"load_var": {
+					"name": "some-name"
+				}
+			}`))
+		})
+
+		It("includes the name and manager of a set_var, but not its file", func() {
+			plan := atc.Plan{
+				ID: "0",
+				SetVar: &atc.SetVarPlan{
+					Name:    "some-name",
+					File:    "some-file",
+					Format:  "json",
+					Manager: "vault",
+				},
+			}
+
+			json := plan.Public()
+			Expect(json).ToNot(BeNil())
+			Expect([]byte(*json)).To(MatchJSON(`{
+				"id": "0",
+				"set_var": {
+					"name": "some-name",
+					"manager": "vault"
+				}
+			}`))
+		})
+
+		It("includes the approvers of an approval", func() {
+			plan := atc.Plan{
+				ID: "0",
+				Approval: &atc.ApprovalPlan{
+					Name:      "qa",
+					Approvers: []string{"owner"},
+				},
+			}
+
+			json := plan.Public()
+			Expect(json).ToNot(BeNil())
+			Expect([]byte(*json)).To(MatchJSON(`{
+				"id": "0",
+				"approval": {
+					"name": "qa",
+					"approvers": ["owner"]
+				}
+			}`))
+		})
 	})
 })
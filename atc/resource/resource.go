@@ -22,9 +22,10 @@ type VersionResult struct {
 }
 
 type Resource struct {
-	Source  atc.Source  `json:"source"`
-	Params  atc.Params  `json:"params,omitempty"`
-	Version atc.Version `json:"version,omitempty"`
+	Source  atc.Source   `json:"source"`
+	Params  atc.Params   `json:"params,omitempty"`
+	Version atc.Version  `json:"version,omitempty"`
+	Webhook *atc.Webhook `json:"webhook,omitempty"`
 }
 
 func (resource Resource) Signature() ([]byte, error) {
@@ -3,6 +3,7 @@ package resource
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/concourse/concourse/atc"
@@ -68,6 +69,32 @@ func TestResourceCheck(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 123, processResult.ExitStatus)
 	})
+
+	t.Run("with a webhook", func(t *testing.T) {
+		resource := resource
+		resource.Webhook = &atc.Webhook{
+			Headers: map[string][]string{"X-Github-Event": {"push"}},
+			Payload: json.RawMessage(`{"after":"abc"}`),
+		}
+
+		var request map[string]interface{}
+		container := runtimetest.NewContainer().
+			WithProcess(
+				expectedSpec,
+				runtimetest.ProcessStub{
+					Do: func(_ context.Context, p *runtimetest.Process) error {
+						return json.NewDecoder(p.Stdin()).Decode(&request)
+					},
+					Output: []atc.Version{},
+				},
+			)
+		_, _, err := resource.Check(ctx, container, new(bytes.Buffer))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"headers": map[string]interface{}{"X-Github-Event": []interface{}{"push"}},
+			"payload": map[string]interface{}{"after": "abc"},
+		}, request["webhook"])
+	})
 }
 
 func TestResourceGet(t *testing.T) {
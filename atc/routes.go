@@ -6,6 +6,10 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
+	ListConfigRevisions = "ListConfigRevisions"
+	GetConfigRevision   = "GetConfigRevision"
+	DiffConfigRevisions = "DiffConfigRevisions"
+
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
 	CreateBuild         = "CreateBuild"
@@ -15,21 +19,26 @@ const (
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
-
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	RerunJobBuild  = "RerunJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	ScheduleJob    = "ScheduleJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	ApproveBuild        = "ApproveBuild"
+	RejectBuild         = "RejectBuild"
+	GetBuildTestResults = "GetBuildTestResults"
+
+	GetJob          = "GetJob"
+	CreateJobBuild  = "CreateJobBuild"
+	RerunJobBuild   = "RerunJobBuild"
+	ListAllJobs     = "ListAllJobs"
+	ListJobs        = "ListJobs"
+	ListJobBuilds   = "ListJobBuilds"
+	ListJobInputs   = "ListJobInputs"
+	PreviewJobBuild = "PreviewJobBuild"
+	GetJobBuild     = "GetJobBuild"
+	PauseJob        = "PauseJob"
+	UnpauseJob      = "UnpauseJob"
+	ScheduleJob     = "ScheduleJob"
+	JobTestHistory  = "JobTestHistory"
+	GetVersionsDB   = "GetVersionsDB"
+	JobBadge        = "JobBadge"
+	MainJobBadge    = "MainJobBadge"
 
 	ClearTaskCache = "ClearTaskCache"
 
@@ -109,9 +118,14 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	ListNotificationDeliveries = "ListNotificationDeliveries"
+	GetSecretsReport           = "GetSecretsReport"
+	GetPipelineSource          = "GetPipelineSource"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
+	GetBuildArtifact   = "GetBuildArtifact"
 
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
@@ -124,11 +138,15 @@ const (
 const (
 	ClearTaskCacheQueryPath = "cache_path"
 	SaveConfigCheckCreds    = "check_creds"
+	TestHistoryQueryBuilds  = "builds"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", Method: "GET", Name: ListConfigRevisions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:version", Method: "GET", Name: GetConfigRevision},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:version/diff", Method: "GET", Name: DiffConfigRevisions},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -140,7 +158,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_id", Method: "GET", Name: GetBuildArtifact},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
+	{Path: "/api/v1/builds/:build_id/approve", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/reject", Method: "PUT", Name: RejectBuild},
+	{Path: "/api/v1/builds/:build_id/test-results", Method: "GET", Name: GetBuildTestResults},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -149,10 +171,12 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "POST", Name: RerunJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/preview", Method: "GET", Name: PreviewJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule", Method: "PUT", Name: ScheduleJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-history", Method: "GET", Name: JobTestHistory},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
 
@@ -238,6 +262,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/notifications/deliveries", Method: "GET", Name: ListNotificationDeliveries},
+	{Path: "/api/v1/teams/:team_name/secrets-report", Method: "GET", Name: GetSecretsReport},
+	{Path: "/api/v1/teams/:team_name/pipeline-source", Method: "GET", Name: GetPipelineSource},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
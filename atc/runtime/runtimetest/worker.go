@@ -12,9 +12,10 @@ import (
 
 type WorkerContainer struct {
 	*Container
-	Mounts []runtime.VolumeMount
-	Owner  db.ContainerOwner
-	Spec   *runtime.ContainerSpec
+	Mounts   []runtime.VolumeMount
+	Owner    db.ContainerOwner
+	Spec     *runtime.ContainerSpec
+	Metadata db.ContainerMetadata
 }
 
 type Worker struct {
@@ -79,6 +80,7 @@ func (w *Worker) FindOrCreateContainer(ctx context.Context, owner db.ContainerOw
 			fmt.Sprintf("missing owner: %+v", owner))
 	}
 	c.Spec = &spec
+	c.Metadata = metadata
 	return c.Container, c.Mounts, nil
 }
 
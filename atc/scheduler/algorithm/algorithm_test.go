@@ -373,6 +373,10 @@ var _ = DescribeTable("Input resolving",
 				"resource-x": "no satisfiable builds from passed jobs found for set of inputs",
 				"resource-y": "no satisfiable builds from passed jobs found for set of inputs",
 			},
+			Reasons: map[string]Reason{
+				"resource-x": {Input: "resource-x", PassedJob: "simple-b", Version: "rxv1", Constraints: map[string]string{"resource-y": "ryv1"}},
+				"resource-y": {Input: "resource-x", PassedJob: "simple-b", Version: "rxv1", Constraints: map[string]string{"resource-y": "ryv1"}},
+			},
 		},
 	}),
 
@@ -669,6 +673,9 @@ var _ = DescribeTable("Input resolving",
 			Errors: map[string]string{
 				"resource-x": "no satisfiable builds from passed jobs found for set of inputs",
 			},
+			Reasons: map[string]Reason{
+				"resource-x": {Input: "resource-x", PassedJob: "simple-b", Version: "rxv2"},
+			},
 		},
 	}),
 
@@ -1804,6 +1811,10 @@ var _ = DescribeTable("Input resolving",
 				"input-1": "no satisfiable builds from passed jobs found for set of inputs",
 				"input-2": "no satisfiable builds from passed jobs found for set of inputs",
 			},
+			Reasons: map[string]Reason{
+				"input-1": {Input: "input-2", PassedJob: "job-2", Version: "new-r2-common-to-shared-and-j2"},
+				"input-2": {Input: "input-2", PassedJob: "job-2", Version: "new-r2-common-to-shared-and-j2"},
+			},
 		},
 	}),
 
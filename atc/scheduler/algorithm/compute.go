@@ -9,7 +9,7 @@ import (
 )
 
 type Resolver interface {
-	Resolve(context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error)
+	Resolve(context.Context) (map[string]*versionCandidate, *db.ResolutionReason, error)
 	InputConfigs() db.InputConfigs
 }
 
@@ -51,19 +51,19 @@ func (a *Algorithm) computeResolvers(
 	finalMapping := db.InputMapping{}
 
 	for _, resolver := range resolvers {
-		versionCandidates, resolveErr, err := resolver.Resolve(ctx)
+		versionCandidates, reason, err := resolver.Resolve(ctx)
 		if err != nil {
 			return nil, false, false, fmt.Errorf("resolve: %w", err)
 		}
 
 		// determines if the algorithm successfully resolved all inputs depending
-		// on if all resolvers did not return a resolve error
-		finalResolved = finalResolved && (resolveErr == "")
+		// on if all resolvers did not return a reason for failing
+		finalResolved = finalResolved && reason == nil
 
 		// converts the version candidates into an object that is recognizable by
 		// other components. also computes the first occurrence for all satisfiable
 		// inputs
-		finalMapping, err = a.candidatesToInputMapping(ctx, finalMapping, resolver.InputConfigs(), versionCandidates, resolveErr)
+		finalMapping, err = a.candidatesToInputMapping(ctx, finalMapping, resolver.InputConfigs(), versionCandidates, reason)
 		if err != nil {
 			return nil, false, false, fmt.Errorf("candidates to input mapping: %w", err)
 		}
@@ -86,11 +86,12 @@ func (a *Algorithm) finalizeHasNext(versionCandidates map[string]*versionCandida
 	return hasNextCombined
 }
 
-func (a *Algorithm) candidatesToInputMapping(ctx context.Context, mapping db.InputMapping, inputConfigs db.InputConfigs, candidates map[string]*versionCandidate, resolveErr db.ResolutionFailure) (db.InputMapping, error) {
+func (a *Algorithm) candidatesToInputMapping(ctx context.Context, mapping db.InputMapping, inputConfigs db.InputConfigs, candidates map[string]*versionCandidate, reason *db.ResolutionReason) (db.InputMapping, error) {
 	for _, input := range inputConfigs {
-		if resolveErr != "" {
+		if reason != nil {
 			mapping[input.Name] = db.InputResult{
-				ResolveError: resolveErr,
+				ResolveError:  reason.Failure,
+				ResolveReason: reason,
 			}
 		} else {
 			firstOcc, err := a.versionsDB.IsFirstOccurrence(ctx, input.JobID, input.Name, candidates[input.Name].Version, input.ResourceID)
@@ -38,6 +38,13 @@ type groupResolver struct {
 	doomedCandidates []*versionCandidate
 
 	lastUsedPassedBuilds map[int]db.BuildCursor
+
+	// reason is why the first set of candidates that was tried did not work
+	// out, which is the one made up of the newest versions. It is returned as
+	// a whole rather than mixed with the failures that came after it, which
+	// only get vaguer as candidates are given up on; the outermost one would
+	// just be that no build of the first passed job worked out.
+	reason *db.ResolutionReason
 }
 
 func NewGroupResolver(vdb db.VersionsDB, inputConfigs db.InputConfigs) Resolver {
@@ -55,7 +62,7 @@ func (r *groupResolver) InputConfigs() db.InputConfigs {
 	return r.inputConfigs
 }
 
-func (r *groupResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
+func (r *groupResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, *db.ResolutionReason, error) {
 	ctx, span := tracing.StartSpan(ctx, "groupResolver.Resolve", tracing.Attrs{
 		"inputs": r.inputConfigs.String(),
 	})
@@ -69,28 +76,35 @@ func (r *groupResolver) Resolve(ctx context.Context) (map[string]*versionCandida
 		version, found, err := r.vdb.FindVersionOfResource(ctx, cfg.ResourceID, cfg.PinnedVersion)
 		if err != nil {
 			tracing.End(span, err)
-			return nil, "", err
+			return nil, nil, err
 		}
 
 		if !found {
 			notFoundErr := db.PinnedVersionNotFound{PinnedVersion: cfg.PinnedVersion}
 			span.SetStatus(codes.Error, "pinned version not found")
-			return nil, notFoundErr.String(), nil
+			return nil, &db.ResolutionReason{
+				Failure:       notFoundErr.String(),
+				Input:         cfg.Name,
+				ResourceID:    cfg.ResourceID,
+				PinnedVersion: cfg.PinnedVersion,
+			}, nil
 		}
 
 		r.pins[i] = version
 	}
 
-	resolved, failure, err := r.tryResolve(ctx)
+	resolved, err := r.tryResolve(ctx)
 	if err != nil {
 		tracing.End(span, err)
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	if !resolved {
-		span.SetAttributes(attribute.String("failure", string(failure)))
+		// the only way to fail is for an input to run out of builds of a
+		// passed job, which always records the reason
+		span.SetAttributes(attribute.String("failure", string(r.reason.Failure)))
 		span.SetStatus(codes.Error, "")
-		return nil, failure, nil
+		return nil, r.reason, nil
 	}
 
 	finalCandidates := map[string]*versionCandidate{}
@@ -99,35 +113,35 @@ func (r *groupResolver) Resolve(ctx context.Context) (map[string]*versionCandida
 	}
 
 	span.SetStatus(codes.Ok, "")
-	return finalCandidates, "", nil
+	return finalCandidates, nil, nil
 }
 
-func (r *groupResolver) tryResolve(ctx context.Context) (bool, db.ResolutionFailure, error) {
+func (r *groupResolver) tryResolve(ctx context.Context) (bool, error) {
 	ctx, span := tracing.StartSpan(ctx, "groupResolver.tryResolve", tracing.Attrs{
 		"inputs": r.inputConfigs.String(),
 	})
 	defer span.End()
 
 	for inputIndex := range r.inputConfigs {
-		worked, failure, err := r.trySatisfyPassedConstraintsForInput(ctx, inputIndex)
+		worked, err := r.trySatisfyPassedConstraintsForInput(ctx, inputIndex)
 		if err != nil {
 			tracing.End(span, err)
-			return false, "", err
+			return false, err
 		}
 
 		if !worked {
 			// input was not satisfiable
 			span.SetStatus(codes.Error, "")
-			return false, failure, nil
+			return false, nil
 		}
 	}
 
 	// got to the end of all the inputs
 	span.SetStatus(codes.Ok, "")
-	return true, "", nil
+	return true, nil
 }
 
-func (r *groupResolver) trySatisfyPassedConstraintsForInput(ctx context.Context, inputIndex int) (bool, db.ResolutionFailure, error) {
+func (r *groupResolver) trySatisfyPassedConstraintsForInput(ctx context.Context, inputIndex int) (bool, error) {
 	inputConfig := r.inputConfigs[inputIndex]
 	currentJobID := inputConfig.JobID
 
@@ -154,7 +168,7 @@ func (r *groupResolver) trySatisfyPassedConstraintsForInput(ctx context.Context,
 		builds, skip, err := r.paginatedBuilds(ctx, inputConfig, currentCandidate, currentJobID, passedJobID)
 		if err != nil {
 			tracing.End(span, err)
-			return false, "", err
+			return false, err
 		}
 
 		if skip {
@@ -167,21 +181,25 @@ func (r *groupResolver) trySatisfyPassedConstraintsForInput(ctx context.Context,
 		worked, err := r.tryJobBuilds(ctx, inputIndex, passedJobID, builds)
 		if err != nil {
 			tracing.End(span, err)
-			return false, "", err
+			return false, err
 		}
 
 		if worked {
 			// resolving recursively worked!
 			break
 		} else {
+			if r.reason == nil {
+				r.reason = r.resolutionReason(inputIndex, passedJobID)
+			}
+
 			span.SetStatus(codes.Error, "")
-			return false, db.NoSatisfiableBuilds, nil
+			return false, nil
 		}
 	}
 
 	// all passed constraints were satisfied
 	span.SetStatus(codes.Ok, "")
-	return true, "", nil
+	return true, nil
 }
 
 func (r *groupResolver) tryJobBuilds(ctx context.Context, inputIndex int, passedJobID int, builds db.PaginatedBuilds) (bool, error) {
@@ -288,7 +306,7 @@ outputs:
 		if r.candidatesAreDoomed() {
 			span.AddEvent("candidates are doomed")
 		} else {
-			worked, _, err := r.tryResolve(ctx)
+			worked, err := r.tryResolve(ctx)
 			if err != nil {
 				tracing.End(span, err)
 				return false, err
@@ -406,6 +424,37 @@ func (r *groupResolver) paginatedBuilds(ctx context.Context, currentInputConfig
 	return paginatedBuilds, false, err
 }
 
+// resolutionReason records that no build of the passed job used the versions
+// currently chosen for the inputs which have to have passed it.
+func (r *groupResolver) resolutionReason(inputIndex int, passedJobID int) *db.ResolutionReason {
+	inputConfig := r.inputConfigs[inputIndex]
+
+	reason := &db.ResolutionReason{
+		Failure:     db.NoSatisfiableBuilds,
+		Input:       inputConfig.Name,
+		ResourceID:  inputConfig.ResourceID,
+		PassedJobID: passedJobID,
+	}
+
+	if r.candidates[inputIndex] != nil {
+		reason.Version = r.candidates[inputIndex].Version
+	}
+
+	for passedIndex, passedInput := range r.inputConfigs {
+		if passedIndex == inputIndex || !passedInput.Passed[passedJobID] || r.candidates[passedIndex] == nil {
+			continue
+		}
+
+		reason.Constraints = append(reason.Constraints, db.ResolutionConstraint{
+			Input:      passedInput.Name,
+			ResourceID: passedInput.ResourceID,
+			Version:    r.candidates[passedIndex].Version,
+		})
+	}
+
+	return reason
+}
+
 func (r *groupResolver) constrainingCandidates(passedJobID int) map[string][]string {
 	constrainingCandidates := map[string][]string{}
 	for passedIndex, passedInput := range r.inputConfigs {
@@ -28,7 +28,7 @@ func (r *individualResolver) InputConfigs() db.InputConfigs {
 
 // Handles two different configurations of a resource without passed
 // constraints: every and latest
-func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
+func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, *db.ResolutionReason, error) {
 	ctx, span := tracing.StartSpan(ctx, "individualResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
 	})
@@ -42,13 +42,13 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		version, hasNext, found, err = r.vdb.NextEveryVersion(ctx, r.inputConfig.JobID, r.inputConfig.ResourceID)
 		if err != nil {
 			tracing.End(span, err)
-			return nil, "", err
+			return nil, nil, err
 		}
 
 		if !found {
 			span.AddEvent("next every version not found")
 			span.SetStatus(codes.Error, "next every version not found")
-			return nil, db.VersionNotFound, nil
+			return nil, r.reason(db.VersionNotFound), nil
 		}
 
 		span.AddEvent("found via every", trace.WithAttributes(
@@ -61,13 +61,13 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		version, found, err = r.vdb.LatestVersionOfResource(ctx, r.inputConfig.ResourceID)
 		if err != nil {
 			tracing.End(span, err)
-			return nil, "", err
+			return nil, nil, err
 		}
 
 		if !found {
 			span.AddEvent("latest version not found")
 			span.SetStatus(codes.Error, "latest version not found")
-			return nil, db.LatestVersionNotFound, nil
+			return nil, r.reason(db.LatestVersionNotFound), nil
 		}
 
 		span.AddEvent("found via latest", trace.WithAttributes(
@@ -83,5 +83,13 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 	}
 
 	span.SetStatus(codes.Ok, "")
-	return versionCandidates, "", nil
+	return versionCandidates, nil, nil
+}
+
+func (r *individualResolver) reason(failure db.ResolutionFailure) *db.ResolutionReason {
+	return &db.ResolutionReason{
+		Failure:    failure,
+		Input:      r.inputConfig.Name,
+		ResourceID: r.inputConfig.ResourceID,
+	}
 }
@@ -26,7 +26,7 @@ func (r *pinnedResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
-func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
+func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, *db.ResolutionReason, error) {
 	ctx, span := tracing.StartSpan(ctx, "pinnedResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
 	})
@@ -35,13 +35,18 @@ func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandid
 	version, found, err := r.vdb.FindVersionOfResource(ctx, r.inputConfig.ResourceID, r.inputConfig.PinnedVersion)
 	if err != nil {
 		tracing.End(span, err)
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	if !found {
 		span.AddEvent("pinned version not found")
 		span.SetStatus(codes.Error, "pinned version not found")
-		return nil, db.PinnedVersionNotFound{PinnedVersion: r.inputConfig.PinnedVersion}.String(), nil
+		return nil, &db.ResolutionReason{
+			Failure:       db.PinnedVersionNotFound{PinnedVersion: r.inputConfig.PinnedVersion}.String(),
+			Input:         r.inputConfig.Name,
+			ResourceID:    r.inputConfig.ResourceID,
+			PinnedVersion: r.inputConfig.PinnedVersion,
+		}, nil
 	}
 
 	span.AddEvent("found via pin", trace.WithAttributes(
@@ -53,5 +58,5 @@ func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandid
 	}
 
 	span.SetStatus(codes.Ok, "found via pin")
-	return versionCandidate, "", nil
+	return versionCandidate, nil, nil
 }
@@ -78,11 +78,22 @@ type Result struct {
 	Values           map[string]string
 	PassedBuildIDs   map[string][]int
 	Errors           map[string]string
+	Reasons          map[string]Reason
 	ExpectedMigrated map[int]map[int][]string
 	HasNext          bool
 	NoNext           bool
 }
 
+// Reason is the db.ResolutionReason of an input which could not be resolved,
+// by the names of the jobs and versions rather than their IDs.
+type Reason struct {
+	Input         string
+	PinnedVersion string
+	PassedJob     string
+	Version       string
+	Constraints   map[string]string
+}
+
 type StringMapping map[string]int
 
 func (mapping StringMapping) ID(str string) int {
@@ -580,25 +591,18 @@ func (example Example) assert(
 
 		prettyValues := map[string]string{}
 		erroredValues := map[string]string{}
+		prettyReasons := map[string]Reason{}
 		passedJobs := map[string][]int{}
 		for name, inputSource := range resolved {
 			if inputSource.ResolveError != "" {
 				erroredValues[name] = string(inputSource.ResolveError)
+
+				if inputSource.ResolveReason != nil {
+					prettyReasons[name] = setup.prettyReason(*inputSource.ResolveReason)
+				}
 			} else {
 				if ok {
-					var versionID int
-					err := setup.psql.Select("v.id").
-						From("resource_config_versions v").
-						Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
-						Where(sq.Eq{
-							"v.version_md5": inputSource.Input.AlgorithmVersion.Version,
-							"r.id":          inputSource.Input.ResourceID,
-						}).
-						QueryRow().
-						Scan(&versionID)
-					Expect(err).ToNot(HaveOccurred())
-
-					prettyValues[name] = setup.versionIDs.Name(versionID)
+					prettyValues[name] = setup.versionName(inputSource.Input.ResourceID, inputSource.Input.AlgorithmVersion.Version)
 
 					passedJobs[name] = inputSource.PassedBuildIDs
 				}
@@ -622,6 +626,10 @@ func (example Example) assert(
 		Expect(actualResult.Errors).To(Equal(example.Result.Errors))
 		Expect(actualResult.Values).To(Equal(example.Result.Values))
 
+		if example.Result.Reasons != nil {
+			Expect(prettyReasons).To(Equal(example.Result.Reasons))
+		}
+
 		for input, buildIDs := range example.Result.PassedBuildIDs {
 			Expect(actualResult.PassedBuildIDs[input]).To(ConsistOf(buildIDs))
 		}
@@ -710,6 +718,47 @@ type setupDB struct {
 	psql sq.StatementBuilderType
 }
 
+func (s setupDB) versionName(resourceID int, version db.ResourceVersion) string {
+	var versionID int
+	err := s.psql.Select("v.id").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"v.version_md5": version,
+			"r.id":          resourceID,
+		}).
+		QueryRow().
+		Scan(&versionID)
+	Expect(err).ToNot(HaveOccurred())
+
+	return s.versionIDs.Name(versionID)
+}
+
+func (s setupDB) prettyReason(reason db.ResolutionReason) Reason {
+	pretty := Reason{
+		Input:         reason.Input,
+		PinnedVersion: reason.PinnedVersion["ver"],
+	}
+
+	if reason.PassedJobID != 0 {
+		pretty.PassedJob = s.jobIDs.Name(reason.PassedJobID)
+	}
+
+	if reason.Version != "" {
+		pretty.Version = s.versionName(reason.ResourceID, reason.Version)
+	}
+
+	for _, constraint := range reason.Constraints {
+		if pretty.Constraints == nil {
+			pretty.Constraints = map[string]string{}
+		}
+
+		pretty.Constraints[constraint.Input] = s.versionName(constraint.ResourceID, constraint.Version)
+	}
+
+	return pretty
+}
+
 func (s setupDB) insertJob(jobName string) int {
 	id := s.jobIDs.ID(jobName)
 	_, err := s.psql.Insert("jobs").
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc/db"
@@ -58,6 +59,11 @@ func (s *Scheduler) Schedule(
 		return false, err
 	}
 
+	err = s.fireSchedule(ctx, logger, job)
+	if err != nil {
+		return false, err
+	}
+
 	return s.BuildStarter.TryStartPendingBuildsForJob(logger, job, jobInputs)
 }
 
@@ -121,3 +127,58 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+// fireSchedule ensures a pending build exists once the job's schedule is due,
+// and moves the schedule on to its next fire time.
+func (s *Scheduler) fireSchedule(
+	ctx context.Context,
+	logger lager.Logger,
+	job db.SchedulerJob,
+) error {
+	due := job.ScheduleNextFire()
+	if due.IsZero() {
+		return nil
+	}
+
+	now := time.Now()
+	if due.After(now) {
+		return nil
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	if config.Schedule == nil {
+		return nil
+	}
+
+	if config.Schedule.ShouldFire(due, now) {
+		spanCtx, _ := tracing.StartSpan(ctx, "job.EnsurePendingBuildExists", tracing.Attrs{
+			"team":     job.TeamName(),
+			"pipeline": job.PipelineName(),
+			"job":      job.Name(),
+			"schedule": config.Schedule.Cron,
+		})
+
+		err := job.EnsurePendingBuildExists(spanCtx)
+		if err != nil {
+			return fmt.Errorf("ensure pending build exists: %w", err)
+		}
+	} else {
+		logger.Info("skipping-missed-schedule", lager.Data{"due": due})
+	}
+
+	next, err := config.Schedule.Next(now)
+	if err != nil {
+		return fmt.Errorf("next fire time: %w", err)
+	}
+
+	err = job.UpdateScheduleNextFire(next)
+	if err != nil {
+		return fmt.Errorf("update schedule next fire: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -477,5 +478,104 @@ var _ = Describe("Scheduler", func() {
 				Expect(scheduleErr).To(Equal(fmt.Errorf("inputs: %w", disaster)))
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			var schedule atc.ScheduleConfig
+
+			BeforeEach(func() {
+				fakeJob.NameReturns("some-job")
+				fakeAlgorithm.ComputeReturns(db.InputMapping{}, true, false, nil)
+
+				schedule = atc.ScheduleConfig{Cron: "*/5 * * * *"}
+				fakeJob.ConfigStub = func() (atc.JobConfig, error) {
+					return atc.JobConfig{Name: "some-job", Schedule: &schedule}, nil
+				}
+			})
+
+			Context("when the schedule is not due yet", func() {
+				BeforeEach(func() {
+					fakeJob.ScheduleNextFireReturns(time.Now().Add(time.Minute))
+				})
+
+				It("does not create a pending build", func() {
+					Expect(scheduleErr).ToNot(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					Expect(fakeJob.UpdateScheduleNextFireCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the schedule is due", func() {
+				BeforeEach(func() {
+					fakeJob.ScheduleNextFireReturns(time.Now().Add(-time.Second))
+				})
+
+				It("creates a pending build", func() {
+					Expect(scheduleErr).ToNot(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+				})
+
+				It("moves the schedule on to its next fire time", func() {
+					Expect(fakeJob.UpdateScheduleNextFireCallCount()).To(Equal(1))
+
+					next := fakeJob.UpdateScheduleNextFireArgsForCall(0)
+					Expect(next).To(BeTemporally(">", time.Now()))
+					Expect(next).To(BeTemporally("<=", time.Now().Add(5*time.Minute)))
+					Expect(next.Minute() % 5).To(BeZero())
+				})
+
+				Context("when creating the pending build fails", func() {
+					BeforeEach(func() {
+						fakeJob.EnsurePendingBuildExistsReturns(disaster)
+					})
+
+					It("returns the error without moving the schedule on", func() {
+						Expect(scheduleErr).To(Equal(fmt.Errorf("ensure pending build exists: %w", disaster)))
+						Expect(fakeJob.UpdateScheduleNextFireCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when updating the next fire time fails", func() {
+					BeforeEach(func() {
+						fakeJob.UpdateScheduleNextFireReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(fmt.Errorf("update schedule next fire: %w", disaster)))
+					})
+				})
+			})
+
+			Context("when fires were missed", func() {
+				BeforeEach(func() {
+					fakeJob.ScheduleNextFireReturns(time.Now().Add(-time.Hour))
+				})
+
+				Context("when the catch-up policy is 'once'", func() {
+					BeforeEach(func() {
+						schedule.CatchUp = atc.ScheduleCatchUpOnce
+					})
+
+					It("creates a single pending build", func() {
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+						Expect(fakeJob.UpdateScheduleNextFireCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the catch-up policy is 'skip'", func() {
+					BeforeEach(func() {
+						schedule.CatchUp = atc.ScheduleCatchUpSkip
+					})
+
+					It("does not create a pending build", func() {
+						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+					})
+
+					It("still moves the schedule on", func() {
+						Expect(fakeJob.UpdateScheduleNextFireCallCount()).To(Equal(1))
+						Expect(fakeJob.UpdateScheduleNextFireArgsForCall(0)).To(BeTemporally(">", time.Now()))
+					})
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,45 @@
+package atc
+
+// PipelineSecretsReport lists each of the credentials a pipeline's config
+// refers to, and where each of them is looked up.
+type PipelineSecretsReport struct {
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+
+	Secrets []SecretReference `json:"secrets"`
+
+	// UnusedVarSources are the pipeline's var_sources which none of its
+	// config refers to.
+	UnusedVarSources []string `json:"unused_var_sources,omitempty"`
+
+	// Error is set if the pipeline's credentials could not be looked up at
+	// all, e.g. because one of its var_sources is misconfigured.
+	Error string `json:"error,omitempty"`
+}
+
+// SecretReference is a ((var)) in a pipeline's config, along with the secret
+// paths it is looked up at. The value of the secret is never included.
+type SecretReference struct {
+	// Name is the reference as written in the config, e.g. 'source:path.field'.
+	Name      string `json:"name"`
+	VarSource string `json:"var_source,omitempty"`
+
+	// Lookups are the secret paths which were looked up, in order, up to the
+	// one that resolved the reference.
+	Lookups []SecretLookup `json:"lookups"`
+
+	Resolved bool `json:"resolved"`
+
+	// Manager and Path are the credential manager and secret path which
+	// resolved the reference.
+	Manager string `json:"manager,omitempty"`
+	Path    string `json:"path,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+type SecretLookup struct {
+	Manager string `json:"manager,omitempty"`
+	Path    string `json:"path"`
+}
@@ -25,6 +25,17 @@ type StepRecursor struct {
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnSetVar will be invoked for any *SetVarStep present in the StepConfig.
+	OnSetVar func(*SetVarStep) error
+
+	// OnApproval will be invoked for any *ApprovalStep present in the StepConfig.
+	OnApproval func(*ApprovalStep) error
+
+	// OnUseTemplate will be invoked for any *UseTemplateStep present in the
+	// StepConfig, before recursing through the steps expanded from the
+	// template.
+	OnUseTemplate func(*UseTemplateStep) error
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -81,6 +92,44 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitSetVar calls the OnSetVar hook if configured.
+func (recursor StepRecursor) VisitSetVar(step *SetVarStep) error {
+	if recursor.OnSetVar != nil {
+		return recursor.OnSetVar(step)
+	}
+
+	return nil
+}
+
+// VisitUseTemplate calls the OnUseTemplate hook if configured, and then
+// recurses through to the expanded steps.
+func (recursor StepRecursor) VisitUseTemplate(step *UseTemplateStep) error {
+	if recursor.OnUseTemplate != nil {
+		err := recursor.OnUseTemplate(step)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range step.Steps {
+		err := sub.Config.Visit(recursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VisitApproval calls the OnApproval hook if configured.
+func (recursor StepRecursor) VisitApproval(step *ApprovalStep) error {
+	if recursor.OnApproval != nil {
+		return recursor.OnApproval(step)
+	}
+
+	return nil
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -174,3 +223,8 @@ func (recursor StepRecursor) VisitEnsure(step *EnsureStep) error {
 
 	return step.Hook.Config.Visit(recursor)
 }
+
+// VisitIf recurses through to the wrapped step.
+func (recursor StepRecursor) VisitIf(step *IfStep) error {
+	return step.Step.Visit(recursor)
+}
@@ -0,0 +1,266 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+// TemplateParamSource is the var source which refers to a template's params
+// within its steps, i.e. ((param:name)).
+const TemplateParamSource = "param"
+
+// StepTemplate is a named sequence of steps which jobs can share with the
+// use_template step. Templates are declared in a pipeline's templates, or
+// shared by all of a team's pipelines.
+type StepTemplate struct {
+	Name string `json:"name"`
+
+	// Params are the params accepted by the template, with their default
+	// values. A param without a default must be given by each use_template
+	// step.
+	Params map[string]interface{} `json:"params,omitempty"`
+
+	Steps []Step `json:"steps"`
+}
+
+type StepTemplates []StepTemplate
+
+func (templates StepTemplates) Lookup(name string) (StepTemplate, bool) {
+	for _, template := range templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+
+	return StepTemplate{}, false
+}
+
+// Override returns the templates with any of the same name replaced by the
+// overrides, followed by the rest of the overrides.
+func (templates StepTemplates) Override(overrides StepTemplates) StepTemplates {
+	var merged StepTemplates
+	for _, template := range templates {
+		if _, found := overrides.Lookup(template.Name); !found {
+			merged = append(merged, template)
+		}
+	}
+
+	return append(merged, overrides...)
+}
+
+// Expand returns a copy of the template's steps with the given params, and
+// the defaults for any which are not given, interpolated.
+func (template StepTemplate) Expand(params Params) ([]Step, error) {
+	values := vars.StaticVariables{}
+	for name, value := range template.Params {
+		values[name] = value
+	}
+
+	for name, value := range params {
+		if _, declared := template.Params[name]; !declared {
+			return nil, fmt.Errorf("template '%s' has no param '%s'", template.Name, name)
+		}
+
+		values[name] = value
+	}
+
+	var missing []string
+	for name, value := range values {
+		if value == nil {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("template '%s' is missing params: %s", template.Name, strings.Join(missing, ", "))
+	}
+
+	stepsBytes, err := json.Marshal(template.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	stepsTemplate := vars.NewTemplate(stepsBytes)
+	for _, name := range stepsTemplate.ExtraVarNames() {
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			continue
+		}
+
+		if _, declared := template.Params[ref.Path]; ref.Source == TemplateParamSource && !declared {
+			return nil, fmt.Errorf("template '%s' refers to undeclared param '%s'", template.Name, ref.Path)
+		}
+	}
+
+	interpolatedBytes, err := stepsTemplate.Evaluate(templateParamVariables(values), vars.EvaluateOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate template '%s': %w", template.Name, err)
+	}
+
+	var steps []Step
+	// This must use sigs.k8s.io/yaml, since gopkg.in/yaml.v2 doesn't convert
+	// from YAML -> JSON first.
+	err = yaml.Unmarshal(interpolatedBytes, &steps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template '%s': %w", template.Name, err)
+	}
+
+	return steps, nil
+}
+
+// usedTemplates returns the names of the templates used by the template's
+// steps.
+func (template StepTemplate) usedTemplates() []string {
+	var names []string
+	for _, step := range template.Steps {
+		_ = step.Config.Visit(StepRecursor{
+			OnUseTemplate: func(step *UseTemplateStep) error {
+				names = append(names, step.Name)
+				return nil
+			},
+		})
+	}
+
+	return names
+}
+
+// CheckCycles returns an error if any of the templates use themselves,
+// directly or through other templates.
+func (templates StepTemplates) CheckCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	states := map[string]int{}
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		template, found := templates.Lookup(name)
+		if !found {
+			return nil
+		}
+
+		path = append(path, name)
+
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("templates use each other in a cycle: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+
+		states[name] = visiting
+		for _, used := range template.usedTemplates() {
+			err := visit(used, path)
+			if err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+
+		return nil
+	}
+
+	for _, template := range templates {
+		err := visit(template.Name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExpandTemplates fills in the steps of each use_template step in the
+// pipeline's jobs from the pipeline's templates or, failing that, the team's
+// shared templates.
+func (config *Config) ExpandTemplates(teamTemplates StepTemplates) error {
+	templates := teamTemplates.Override(config.Templates)
+
+	err := templates.CheckCycles()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range config.Jobs {
+		err := job.StepConfig().Visit(StepRecursor{
+			OnUseTemplate: func(step *UseTemplateStep) error {
+				template, found := templates.Lookup(step.Name)
+				if !found {
+					return fmt.Errorf("unknown template '%s'", step.Name)
+				}
+
+				steps, err := template.Expand(step.Params)
+				if err != nil {
+					return err
+				}
+
+				step.Steps = steps
+				return nil
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("jobs.%s: %w", job.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// withoutTemplateExpansions returns a copy of the config without the steps
+// expanded from templates, i.e. as it was written.
+func (config Config) withoutTemplateExpansions() Config {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return config
+	}
+
+	var stripped Config
+	err = json.Unmarshal(payload, &stripped)
+	if err != nil {
+		return config
+	}
+
+	for _, job := range stripped.Jobs {
+		_ = job.StepConfig().Visit(StepRecursor{
+			OnUseTemplate: func(step *UseTemplateStep) error {
+				step.Steps = nil
+				return nil
+			},
+		})
+	}
+
+	return stripped
+}
+
+// templateParamVariables resolves template params, i.e. ((param:foo)), and
+// reports every other var as not found so that it is left uninterpolated.
+type templateParamVariables vars.StaticVariables
+
+func (v templateParamVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	if ref.Source != TemplateParamSource {
+		return nil, false, nil
+	}
+
+	return vars.StaticVariables(v).Get(ref.WithoutSource())
+}
+
+func (v templateParamVariables) List() ([]vars.Reference, error) {
+	refs, err := vars.StaticVariables(v).List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range refs {
+		refs[i].Source = TemplateParamSource
+	}
+
+	return refs, nil
+}
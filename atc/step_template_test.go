@@ -0,0 +1,199 @@
+package atc_test
+
+import (
+	"bytes"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepTemplate", func() {
+	var template atc.StepTemplate
+
+	BeforeEach(func() {
+		template = atc.StepTemplate{
+			Name: "unit-tests",
+			Params: map[string]interface{}{
+				"package": "./...",
+				"image":   nil,
+			},
+			Steps: []atc.Step{
+				{
+					Config: &atc.TaskStep{
+						Name:              "unit",
+						ConfigPath:        "ci/tasks/unit.yml",
+						ImageArtifactName: "((param:image))",
+						Params: atc.TaskEnv{
+							"PACKAGE": "((param:package))",
+							"TOKEN":   "((token))",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Expand", func() {
+		It("interpolates the params, leaving other vars alone", func() {
+			steps, err := template.Expand(atc.Params{"image": "golang"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps).To(Equal([]atc.Step{
+				{
+					Config: &atc.TaskStep{
+						Name:              "unit",
+						ConfigPath:        "ci/tasks/unit.yml",
+						ImageArtifactName: "golang",
+						Params: atc.TaskEnv{
+							"PACKAGE": "./...",
+							"TOKEN":   "((token))",
+						},
+					},
+				},
+			}))
+		})
+
+		It("overrides the defaults with the given params", func() {
+			steps, err := template.Expand(atc.Params{"image": "golang", "package": "./atc/..."})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(steps[0].Config.(*atc.TaskStep).Params["PACKAGE"]).To(Equal("./atc/..."))
+		})
+
+		It("does not modify the template", func() {
+			_, err := template.Expand(atc.Params{"image": "golang"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.Steps[0].Config.(*atc.TaskStep).ImageArtifactName).To(Equal("((param:image))"))
+		})
+
+		It("errors when a param without a default is not given", func() {
+			_, err := template.Expand(nil)
+			Expect(err).To(MatchError("template 'unit-tests' is missing params: image"))
+		})
+
+		It("errors when an unknown param is given", func() {
+			_, err := template.Expand(atc.Params{"image": "golang", "race": true})
+			Expect(err).To(MatchError("template 'unit-tests' has no param 'race'"))
+		})
+
+		It("errors when the steps refer to an undeclared param", func() {
+			delete(template.Params, "package")
+
+			_, err := template.Expand(atc.Params{"image": "golang"})
+			Expect(err).To(MatchError("template 'unit-tests' refers to undeclared param 'package'"))
+		})
+	})
+
+	Describe("StepTemplates", func() {
+		useTemplate := func(name string) atc.Step {
+			return atc.Step{Config: &atc.UseTemplateStep{Name: name}}
+		}
+
+		Describe("Override", func() {
+			It("replaces templates of the same name", func() {
+				teamTemplates := atc.StepTemplates{
+					{Name: "a", Steps: []atc.Step{useTemplate("team")}},
+					{Name: "b", Steps: []atc.Step{useTemplate("team")}},
+				}
+
+				merged := teamTemplates.Override(atc.StepTemplates{
+					{Name: "b", Steps: []atc.Step{useTemplate("pipeline")}},
+				})
+
+				Expect(merged).To(Equal(atc.StepTemplates{
+					{Name: "a", Steps: []atc.Step{useTemplate("team")}},
+					{Name: "b", Steps: []atc.Step{useTemplate("pipeline")}},
+				}))
+			})
+		})
+
+		Describe("CheckCycles", func() {
+			It("allows templates to use each other", func() {
+				templates := atc.StepTemplates{
+					{Name: "a", Steps: []atc.Step{useTemplate("b"), useTemplate("c")}},
+					{Name: "b", Steps: []atc.Step{useTemplate("c")}},
+					{Name: "c", Steps: []atc.Step{useTemplate("unknown")}},
+				}
+
+				Expect(templates.CheckCycles()).To(Succeed())
+			})
+
+			It("errors when templates use each other in a cycle", func() {
+				templates := atc.StepTemplates{
+					{Name: "a", Steps: []atc.Step{useTemplate("b")}},
+					{Name: "b", Steps: []atc.Step{{Config: &atc.DoStep{Steps: []atc.Step{useTemplate("a")}}}}},
+				}
+
+				Expect(templates.CheckCycles()).To(MatchError("templates use each other in a cycle: a -> b -> a"))
+			})
+		})
+	})
+
+	Describe("Config.ExpandTemplates", func() {
+		var config atc.Config
+
+		pipelineConfig := func() atc.Config {
+			return atc.Config{
+				Templates: atc.StepTemplates{template},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.UseTemplateStep{
+									Name:   "unit-tests",
+									Params: atc.Params{"image": "golang"},
+								},
+							},
+							{
+								Config: &atc.UseTemplateStep{
+									Name: "lint",
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			config = pipelineConfig()
+		})
+
+		It("expands templates from the pipeline, then the team", func() {
+			err := config.ExpandTemplates(atc.StepTemplates{
+				{
+					Name:  "unit-tests",
+					Steps: []atc.Step{{Config: &atc.LoadVarStep{Name: "overridden"}}},
+				},
+				{
+					Name:  "lint",
+					Steps: []atc.Step{{Config: &atc.LoadVarStep{Name: "lint", File: "lint.yml"}}},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			plan := config.Jobs[0].PlanSequence
+			Expect(plan[0].Config.(*atc.UseTemplateStep).Steps[0].Config.(*atc.TaskStep).ImageArtifactName).To(Equal("golang"))
+			Expect(plan[1].Config.(*atc.UseTemplateStep).Steps).To(Equal([]atc.Step{
+				{Config: &atc.LoadVarStep{Name: "lint", File: "lint.yml"}},
+			}))
+		})
+
+		It("errors when a template is unknown", func() {
+			err := config.ExpandTemplates(nil)
+			Expect(err).To(MatchError("jobs.some-job: unknown template 'lint'"))
+		})
+
+		It("ignores the expanded steps when diffing", func() {
+			err := config.ExpandTemplates(atc.StepTemplates{
+				{
+					Name:  "lint",
+					Steps: []atc.Step{{Config: &atc.LoadVarStep{Name: "lint", File: "lint.yml"}}},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipelineConfig().Diff(new(bytes.Buffer), config)).To(BeFalse())
+		})
+	})
+})
@@ -25,12 +25,21 @@ type StepValidator struct {
 	config  Config
 	context []string
 
-	seenGetName    scope
-	localVarScopes []scope
+	seenGetName     scope
+	localVarScopes  []scope
+	templateSources []templateSource
 }
 
 type scope map[string]bool
 
+// templateSource records that the steps being validated were expanded from a
+// template, so that errors can also point at where they came from.
+type templateSource struct {
+	name   string
+	source string
+	depth  int
+}
+
 // NewStepValidator is a constructor which initializes internal data.
 //
 // The Config specified is used to validate the existence of resources and jobs
@@ -252,6 +261,36 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 
 	validator.declareLocalVar(step.Name)
 
+	if step.File == "" && step.Dir == "" {
+		validator.recordError("no file specified")
+	}
+
+	if step.File != "" && step.Dir != "" {
+		validator.recordError("cannot specify both file and dir")
+	}
+
+	if step.Select != "" {
+		_, err := ParseVarSelector(step.Select)
+		if err != nil {
+			validator.recordError(err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (validator *StepValidator) VisitSetVar(step *SetVarStep) error {
+	validator.pushContext(".set_var(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
 	if step.File == "" {
 		validator.recordError("no file specified")
 	}
@@ -259,6 +298,50 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitApproval(step *ApprovalStep) error {
+	validator.pushContext(".approval(%s)", step.Name)
+	defer validator.popContext()
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	for i, approver := range step.Approvers {
+		if isApprovalRole(approver) {
+			continue
+		}
+
+		validator.pushContext(".approvers[%d]", i)
+
+		// anything else is matched against users, so a mistyped role would
+		// silently never match anyone
+		connector, user, isUser := strings.Cut(approver, ":")
+		if !isUser {
+			validator.recordError("unknown role '%s'; must be one of %s, or a user given as 'connector:user'", approver, strings.Join(ApprovalRoles, ", "))
+		} else if connector == "" || user == "" || strings.ContainsAny(approver, " \t") {
+			validator.recordError("invalid user '%s'; users must be given as 'connector:user'", approver)
+		}
+
+		validator.popContext()
+	}
+
+	return nil
+}
+
+func isApprovalRole(approver string) bool {
+	for _, role := range ApprovalRoles {
+		if approver == role {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -333,6 +416,59 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 	return step.Step.Visit(validator)
 }
 
+func (validator *StepValidator) VisitUseTemplate(step *UseTemplateStep) error {
+	validator.pushContext(".use_template(%s)", step.Name)
+	defer validator.popContext()
+
+	for _, source := range validator.templateSources {
+		if source.name == step.Name {
+			validator.recordError("template '%s' uses itself", step.Name)
+			return nil
+		}
+	}
+
+	source := "team templates." + step.Name
+	steps := step.Steps
+
+	template, found := validator.config.Templates.Lookup(step.Name)
+	if found {
+		source = "templates." + step.Name
+
+		if len(steps) == 0 {
+			var err error
+			steps, err = template.Expand(step.Params)
+			if err != nil {
+				validator.recordError(err.Error())
+				return nil
+			}
+		}
+	}
+
+	// Team templates are only known to the server, so their steps are only
+	// validated once they have been expanded.
+	validator.templateSources = append(validator.templateSources, templateSource{
+		name:   step.Name,
+		source: source,
+		depth:  len(validator.context),
+	})
+	defer func() {
+		validator.templateSources = validator.templateSources[:len(validator.templateSources)-1]
+	}()
+
+	for i, sub := range steps {
+		validator.pushContext(".steps[%d]", i)
+
+		err := validator.Validate(sub)
+		if err != nil {
+			return err
+		}
+
+		validator.popContext()
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
@@ -426,6 +562,17 @@ func (validator *StepValidator) VisitEnsure(step *EnsureStep) error {
 	return validator.Validate(step.Hook)
 }
 
+func (validator *StepValidator) VisitIf(step *IfStep) error {
+	validator.pushContext(".if")
+	_, err := ParseCondition(step.Condition)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	validator.popContext()
+
+	return step.Step.Visit(validator)
+}
+
 func (validator *StepValidator) recordWarning(warning ConfigWarning) {
 	validator.Warnings = append(validator.Warnings, warning)
 }
@@ -435,7 +582,14 @@ func (validator *StepValidator) recordError(message string, args ...interface{})
 }
 
 func (validator *StepValidator) annotate(message string) string {
-	return fmt.Sprintf("%s: %s", strings.Join(validator.context, ""), message)
+	annotated := fmt.Sprintf("%s: %s", strings.Join(validator.context, ""), message)
+
+	if len(validator.templateSources) > 0 {
+		source := validator.templateSources[len(validator.templateSources)-1]
+		annotated += fmt.Sprintf(" (from %s%s)", source.source, strings.Join(validator.context[source.depth:], ""))
+	}
+
+	return annotated
 }
 
 func (validator *StepValidator) pushContext(ctx string, args ...interface{}) {
@@ -194,6 +194,9 @@ type StepVisitor interface {
 	VisitRun(*RunStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitSetVar(*SetVarStep) error
+	VisitUseTemplate(*UseTemplateStep) error
+	VisitApproval(*ApprovalStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
@@ -205,6 +208,7 @@ type StepVisitor interface {
 	VisitOnAbort(*OnAbortStep) error
 	VisitOnError(*OnErrorStep) error
 	VisitEnsure(*EnsureStep) error
+	VisitIf(*IfStep) error
 }
 
 // StepDetector is a simple structure used to detect whether a step type is
@@ -246,6 +250,10 @@ var StepPrecedence = []StepDetector{
 		Key: "across",
 		New: func() StepConfig { return &AcrossStep{} },
 	},
+	{
+		Key: "if",
+		New: func() StepConfig { return &IfStep{} },
+	},
 	{
 		Key: "attempts",
 		New: func() StepConfig { return &RetryStep{} },
@@ -278,6 +286,18 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "set_var",
+		New: func() StepConfig { return &SetVarStep{} },
+	},
+	{
+		Key: "use_template",
+		New: func() StepConfig { return &UseTemplateStep{} },
+	},
+	{
+		Key: "approval",
+		New: func() StepConfig { return &ApprovalStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -342,6 +362,7 @@ type TaskStep struct {
 	Name              string            `json:"task"`
 	Privileged        bool              `json:"privileged,omitempty"`
 	Hermetic          bool              `json:"hermetic,omitempty"`
+	Memoize           bool              `json:"memoize,omitempty"`
 	ConfigPath        string            `json:"file,omitempty"`
 	Limits            *ContainerLimits  `json:"container_limits,omitempty"`
 	Config            *TaskConfig       `json:"config,omitempty"`
@@ -394,16 +415,70 @@ func (step *SetPipelineStep) Visit(v StepVisitor) error {
 }
 
 type LoadVarStep struct {
-	Name   string `json:"load_var"`
-	File   string `json:"file,omitempty"`
+	Name string `json:"load_var"`
+	File string `json:"file,omitempty"`
+
+	// Dir loads each of the files directly within a directory instead, as a
+	// map keyed by their names without the extension.
+	Dir string `json:"dir,omitempty"`
+
 	Format string `json:"format,omitempty"`
-	Reveal bool   `json:"reveal,omitempty"`
+
+	// Select extracts a value from within the loaded var. See VarSelector.
+	Select string `json:"select,omitempty"`
+
+	Reveal bool `json:"reveal,omitempty"`
 }
 
 func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+// SetVarStep writes the value in a file to a credential manager, at the path
+// the var would be looked up at first by the pipeline.
+type SetVarStep struct {
+	Name   string `json:"set_var"`
+	File   string `json:"file,omitempty"`
+	Format string `json:"format,omitempty"`
+
+	// Manager is the name of the credential manager to write to, if more
+	// than one is configured. It defaults to the one which takes precedence
+	// for the team.
+	Manager string `json:"manager,omitempty"`
+}
+
+func (step *SetVarStep) Visit(v StepVisitor) error {
+	return v.VisitSetVar(step)
+}
+
+// UseTemplateStep runs the steps of a StepTemplate with the given params.
+type UseTemplateStep struct {
+	Name   string `json:"use_template"`
+	Params Params `json:"params,omitempty"`
+
+	// Steps are expanded from the template when the pipeline is saved, so
+	// changes to a template only take effect once the pipeline is set again.
+	Steps []Step `json:"steps,omitempty"`
+}
+
+func (step *UseTemplateStep) Visit(v StepVisitor) error {
+	return v.VisitUseTemplate(step)
+}
+
+// ApprovalRoles are the team roles that may be listed as approvers of an
+// approval step. Any other approver must be a user, given in the same
+// `connector:user` form as in a team's auth config.
+var ApprovalRoles = []string{"owner", "member", "pipeline-operator"}
+
+type ApprovalStep struct {
+	Name      string   `json:"approval"`
+	Approvers []string `json:"approvers,omitempty"`
+}
+
+func (step *ApprovalStep) Visit(v StepVisitor) error {
+	return v.VisitApproval(step)
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -626,6 +701,30 @@ func (step *EnsureStep) Visit(v StepVisitor) error {
 	return v.VisitEnsure(step)
 }
 
+// IfStep runs its step only if the condition holds. It is parsed within any
+// across on the same step, so that the condition is evaluated for each
+// combination of the across vars, and within the step's hooks, which run as
+// they would had the step succeeded.
+type IfStep struct {
+	Step StepConfig `json:"-"`
+
+	// Condition is parsed with ParseCondition; it is kept as a string so that
+	// it round-trips through the pipeline config unchanged.
+	Condition string `json:"if"`
+}
+
+func (step *IfStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *IfStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *IfStep) Visit(v StepVisitor) error {
+	return v.VisitIf(step)
+}
+
 // MaxInFlightConfig can represent either running all values in an AcrossStep
 // in parallel or a applying a limit to the sub-steps that can run at once.
 type MaxInFlightConfig struct {
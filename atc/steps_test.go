@@ -74,6 +74,7 @@ var factoryTests = []StepTest{
 			task: some-task
 			privileged: true
 			hermetic: true
+			memoize: true
 			config:
 			  platform: linux
 			  run: {path: hello}
@@ -91,6 +92,7 @@ var factoryTests = []StepTest{
 			Name:       "some-task",
 			Privileged: true,
 			Hermetic:   true,
+			Memoize:    true,
 			Config: &atc.TaskConfig{
 				Platform: "linux",
 				Run:      atc.TaskRunConfig{Path: "hello"},
@@ -233,6 +235,89 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "load_var step loading a directory",
+
+		ConfigYAML: `
+			load_var: some-var
+			dir: some-artifact/some-dir
+			format: dotenv
+			select: $.release.VERSION
+		`,
+
+		StepConfig: &atc.LoadVarStep{
+			Name:   "some-var",
+			Dir:    "some-artifact/some-dir",
+			Format: "dotenv",
+			Select: "$.release.VERSION",
+		},
+	},
+	{
+		Title: "set_var step",
+
+		ConfigYAML: `
+			set_var: some-var
+			file: some-var-file
+			format: json
+			manager: vault
+		`,
+
+		StepConfig: &atc.SetVarStep{
+			Name:    "some-var",
+			File:    "some-var-file",
+			Format:  "json",
+			Manager: "vault",
+		},
+	},
+	{
+		Title: "approval step",
+
+		ConfigYAML: `
+			approval: qa
+			approvers: [owner, github:some-user]
+		`,
+
+		StepConfig: &atc.ApprovalStep{
+			Name:      "qa",
+			Approvers: []string{"owner", "github:some-user"},
+		},
+	},
+	{
+		Title: "use_template step",
+
+		ConfigYAML: `
+			use_template: unit-tests
+			params:
+			  package: ./atc/...
+		`,
+
+		StepConfig: &atc.UseTemplateStep{
+			Name:   "unit-tests",
+			Params: atc.Params{"package": "./atc/..."},
+		},
+	},
+	{
+		Title: "use_template step which has been expanded",
+
+		ConfigYAML: `
+			use_template: unit-tests
+			steps:
+			- load_var: some-var
+			  file: some-file
+		`,
+
+		StepConfig: &atc.UseTemplateStep{
+			Name: "unit-tests",
+			Steps: []atc.Step{
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+			},
+		},
+	},
 	{
 		Title: "try step",
 
@@ -448,6 +533,83 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "if modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: ((branch)) == main
+		`,
+
+		StepConfig: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "((branch)) == main",
+		},
+	},
+	{
+		Title: "if modifier with hooks",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: ((deploy))
+			ensure:
+			  load_var: ensure-var
+			  file: ensure-file
+		`,
+
+		StepConfig: &atc.EnsureStep{
+			Step: &atc.IfStep{
+				Step: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+				Condition: "((deploy))",
+			},
+			Hook: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "ensure-var",
+					File: "ensure-file",
+				},
+			},
+		},
+	},
+	{
+		Title: "if modifier with across",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: ((.:env)) != prod
+			attempts: 3
+			across:
+			- var: env
+			  values: [dev, prod]
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.IfStep{
+				Step: &atc.RetryStep{
+					Step: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+					Attempts: 3,
+				},
+				Condition: "((.:env)) != prod",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "env",
+					Values: []interface{}{"dev", "prod"},
+				},
+			},
+		},
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
@@ -17,6 +17,8 @@ type JobSummary struct {
 	PausedAt     int64  `json:"paused_at,omitempty"`
 	HasNewInputs bool   `json:"has_new_inputs,omitempty"`
 
+	NextFireTime int64 `json:"next_fire_time,omitempty"`
+
 	Groups []string `json:"groups,omitempty"`
 
 	FinishedBuild   *BuildSummary `json:"finished_build,omitempty"`
@@ -25,6 +27,8 @@ type JobSummary struct {
 
 	Inputs  []JobInputSummary  `json:"inputs,omitempty"`
 	Outputs []JobOutputSummary `json:"outputs,omitempty"`
+
+	SchedulingDiagnostics []SchedulingDiagnostic `json:"scheduling_diagnostics,omitempty"`
 }
 
 type BuildSummary struct {
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/yaml"
 )
@@ -36,6 +38,14 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Test reports written to the task's outputs, which are parsed and stored
+	// once the task has finished.
+	Reports []TaskReportConfig `json:"reports,omitempty"`
+
+	// Containers started alongside the task for it to talk to, such as a
+	// database, which it can reach by their names.
+	Services []TaskServiceConfig `json:"services,omitempty"`
 }
 
 type ImageResource struct {
@@ -99,6 +109,8 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validateReports()...)
+	errors = append(errors, config.validateServices()...)
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -121,6 +133,79 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateReports() []string {
+	var messages []string
+
+	for i, report := range config.Reports {
+		switch report.Format {
+		case TestReportFormatJUnit, TestReportFormatTAP:
+		case "":
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing a format", i))
+		default:
+			messages = append(messages, fmt.Sprintf("  report in position %d has unknown format '%s' (must be '%s' or '%s')", i, report.Format, TestReportFormatJUnit, TestReportFormatTAP))
+		}
+
+		if report.Output == "" {
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing an output", i))
+			continue
+		}
+
+		found := false
+		for _, output := range config.Outputs {
+			if output.Name == report.Output {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			messages = append(messages, fmt.Sprintf("  report in position %d refers to unknown output '%s'", i, report.Output))
+		}
+	}
+
+	return messages
+}
+
+var validServiceName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+func (config TaskConfig) validateServices() []string {
+	var messages []string
+
+	names := map[string]bool{}
+	for i, service := range config.Services {
+		switch {
+		case service.Name == "":
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing a name", i))
+		case !validServiceName.MatchString(service.Name):
+			messages = append(messages, fmt.Sprintf("  service in position %d has invalid name '%s' (must be made of lowercase letters, numbers and hyphens)", i, service.Name))
+		case names[service.Name]:
+			messages = append(messages, fmt.Sprintf("  service in position %d has the same name as another service ('%s')", i, service.Name))
+		}
+
+		names[service.Name] = true
+
+		if service.ImageResource == nil {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing an image_resource", i))
+		}
+
+		if service.Run.Path == "" {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing path to executable to run", i))
+		}
+
+		if service.Readiness != nil && service.Readiness.Path == "" {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing path to readiness executable to run", i))
+		}
+
+		if service.ReadinessTimeout != "" {
+			if _, err := time.ParseDuration(service.ReadinessTimeout); err != nil {
+				messages = append(messages, fmt.Sprintf("  service in position %d has invalid readiness_timeout '%s'", i, service.ReadinessTimeout))
+			}
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -151,6 +236,53 @@ type TaskInputConfig struct {
 type TaskOutputConfig struct {
 	Name string `json:"name"`
 	Path string `json:"path,omitempty"`
+
+	// Publish keeps the output around after the build has finished so that it
+	// can be downloaded from the build's artifacts.
+	Publish bool `json:"publish,omitempty"`
+}
+
+const (
+	TestReportFormatJUnit = "junit"
+	TestReportFormatTAP   = "tap"
+)
+
+type TaskReportConfig struct {
+	// The format of the report, either "junit" or "tap".
+	Format string `json:"format"`
+
+	// The output that the report is written to.
+	Output string `json:"output"`
+
+	// The path of the report within the output. If it is a directory, every
+	// report file within it is read. Defaults to the whole output.
+	Path string `json:"path,omitempty"`
+}
+
+type TaskServiceConfig struct {
+	// The name of the service. The task is given the service's address in an
+	// env var named after it, e.g. $POSTGRES_HOST for a service named
+	// postgres.
+	Name string `json:"name"`
+
+	// The image the service runs in.
+	ImageResource *ImageResource `json:"image_resource"`
+
+	// Environment variables to run the service with.
+	Env TaskEnv `json:"env,omitempty"`
+
+	// The command which runs the service. It is expected to keep running until
+	// the task has finished, when it is stopped.
+	Run TaskRunConfig `json:"run"`
+
+	// A command which is run in the service's container until it succeeds
+	// before the task is started. Without it, the task is started as soon as
+	// the service has been.
+	Readiness *TaskRunConfig `json:"readiness,omitempty"`
+
+	// How long the readiness command has to succeed within, e.g. '1m'. It
+	// defaults to 5 minutes.
+	ReadinessTimeout string `json:"readiness_timeout,omitempty"`
 }
 
 type TaskCacheConfig struct {
@@ -385,6 +385,153 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has reports", func() {
+			BeforeEach(func() {
+				validConfig.Outputs = []TaskOutputConfig{{Name: "test-results"}}
+				validConfig.Reports = []TaskReportConfig{
+					{Format: TestReportFormatJUnit, Output: "test-results", Path: "junit.xml"},
+					{Format: TestReportFormatTAP, Output: "test-results"},
+				}
+
+				invalidConfig = validConfig
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when report.format is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{{Output: "test-results"}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("report in position 0 is missing a format")))
+				})
+			})
+
+			Context("when report.format is unknown", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{{Format: "xunit", Output: "test-results"}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("report in position 0 has unknown format 'xunit' (must be 'junit' or 'tap')")))
+				})
+			})
+
+			Context("when report.output is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{{Format: TestReportFormatJUnit}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("report in position 0 is missing an output")))
+				})
+			})
+
+			Context("when report.output is not one of the task's outputs", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = []TaskReportConfig{{Format: TestReportFormatJUnit, Output: "nope"}}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("report in position 0 refers to unknown output 'nope'")))
+				})
+			})
+		})
+
+		Context("when the task has services", func() {
+			BeforeEach(func() {
+				validConfig.Services = []TaskServiceConfig{
+					{
+						Name:          "postgres",
+						ImageResource: &ImageResource{Type: "registry-image", Source: Source{"repository": "postgres"}},
+						Env:           TaskEnv{"POSTGRES_PASSWORD": "password"},
+						Run:           TaskRunConfig{Path: "docker-entrypoint.sh", Args: []string{"postgres"}},
+						Readiness:     &TaskRunConfig{Path: "pg_isready"},
+					},
+				}
+
+				invalidConfig = validConfig
+				invalidConfig.Services = []TaskServiceConfig{validConfig.Services[0]}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when service.name is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].Name = ""
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 is missing a name")))
+				})
+			})
+
+			Context("when service.name is not a hostname", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].Name = "Post_gres"
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 has invalid name 'Post_gres'")))
+				})
+			})
+
+			Context("when two services have the same name", func() {
+				BeforeEach(func() {
+					invalidConfig.Services = append(invalidConfig.Services, validConfig.Services[0])
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 1 has the same name as another service ('postgres')")))
+				})
+			})
+
+			Context("when service.image_resource is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].ImageResource = nil
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 is missing an image_resource")))
+				})
+			})
+
+			Context("when service.run is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].Run.Path = ""
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 is missing path to executable to run")))
+				})
+			})
+
+			Context("when service.readiness has no path", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].Readiness = &TaskRunConfig{}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 is missing path to readiness executable to run")))
+				})
+			})
+
+			Context("when service.readiness_timeout is not a duration", func() {
+				BeforeEach(func() {
+					invalidConfig.Services[0].ReadinessTimeout = "forever"
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 has invalid readiness_timeout 'forever'")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -13,10 +13,36 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	Notifications NotificationConfigs `json:"notifications,omitempty"`
+
+	// CredentialManagers is the order in which the team's credentials are
+	// looked up in the cluster's credential managers, when more than one is
+	// configured. Any which are not listed are looked up afterwards.
+	CredentialManagers []string `json:"credential_managers,omitempty"`
+
+	// VarSources are available to all of the team's pipelines, as if they
+	// were declared in each of them. A pipeline's own var source of the same
+	// name takes precedence.
+	VarSources VarSourceConfigs `json:"var_sources,omitempty"`
+
+	// Templates are step templates which any of the team's pipelines can use,
+	// as if they were declared in each of them. A pipeline's own template of
+	// the same name takes precedence.
+	Templates StepTemplates `json:"templates,omitempty"`
+
+	// PipelineSource is a git repository from which all of the team's
+	// pipelines are set, if any.
+	PipelineSource *PipelineSourceConfig `json:"pipeline_source,omitempty"`
 }
 
 func (team Team) Validate() error {
-	return team.Auth.Validate()
+	err := team.Auth.Validate()
+	if err != nil {
+		return err
+	}
+
+	return team.Notifications.Validate()
 }
 
 type TeamAuth map[string]map[string][]string
@@ -0,0 +1,64 @@
+package atc
+
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+// TestResult is the outcome of a single test case, as read from a task's test
+// reports.
+type TestResult struct {
+	Step     string     `json:"step,omitempty"`
+	Suite    string     `json:"suite,omitempty"`
+	Name     string     `json:"name"`
+	Status   TestStatus `json:"status"`
+	Duration float64    `json:"duration,omitempty"`
+	Message  string     `json:"message,omitempty"`
+}
+
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+func SummarizeTestResults(results []TestResult) TestSummary {
+	var summary TestSummary
+	for _, result := range results {
+		summary.Total++
+
+		switch result.Status {
+		case TestStatusPassed:
+			summary.Passed++
+		case TestStatusFailed:
+			summary.Failed++
+		case TestStatusSkipped:
+			summary.Skipped++
+		}
+	}
+
+	return summary
+}
+
+type BuildTestResults struct {
+	Summary TestSummary  `json:"summary"`
+	Results []TestResult `json:"results"`
+}
+
+// TestHistory is how a single test case has fared across the recent builds of
+// a job. A test is flaky if it has both passed and failed within those builds.
+type TestHistory struct {
+	Suite      string     `json:"suite,omitempty"`
+	Name       string     `json:"name"`
+	Runs       int        `json:"runs"`
+	Passed     int        `json:"passed"`
+	Failed     int        `json:"failed"`
+	Skipped    int        `json:"skipped"`
+	Flaky      bool       `json:"flaky"`
+	LastStatus TestStatus `json:"last_status"`
+	LastBuild  string     `json:"last_build"`
+}
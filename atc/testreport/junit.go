@@ -0,0 +1,140 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+type junitSuites struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *junitProblem `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (problem junitProblem) String() string {
+	if problem.Message == "" {
+		return problem.Text
+	}
+
+	if strings.TrimSpace(problem.Text) == "" {
+		return problem.Message
+	}
+
+	return problem.Message + "\n" + problem.Text
+}
+
+// ParseJUnit reads a JUnit XML report, whose root element may either be a
+// <testsuites> or a single <testsuite>. Test cases that report an error are
+// counted as failed.
+func ParseJUnit(r io.Reader) ([]atc.TestResult, error) {
+	decoder := xml.NewDecoder(r)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("no test suites found")
+			}
+
+			return nil, fmt.Errorf("invalid junit report: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var suites []junitSuite
+		switch start.Name.Local {
+		case "testsuites":
+			var root junitSuites
+			err = decoder.DecodeElement(&root, &start)
+			suites = root.Suites
+		case "testsuite":
+			var root junitSuite
+			err = decoder.DecodeElement(&root, &start)
+			suites = []junitSuite{root}
+		default:
+			return nil, fmt.Errorf("invalid junit report: unexpected root element <%s>", start.Name.Local)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid junit report: %w", err)
+		}
+
+		var results []atc.TestResult
+		for _, suite := range suites {
+			results = suite.appendResults(results)
+		}
+
+		return results, nil
+	}
+}
+
+func (suite junitSuite) appendResults(results []atc.TestResult) []atc.TestResult {
+	for _, testCase := range suite.Cases {
+		result := atc.TestResult{
+			Suite:    testCase.ClassName,
+			Name:     testCase.Name,
+			Status:   atc.TestStatusPassed,
+			Duration: parseDuration(testCase.Time),
+		}
+
+		if result.Suite == "" {
+			result.Suite = suite.Name
+		}
+
+		switch {
+		case testCase.Failure != nil:
+			result.Status = atc.TestStatusFailed
+			result.Message = truncate(testCase.Failure.String())
+		case testCase.Error != nil:
+			result.Status = atc.TestStatusFailed
+			result.Message = truncate(testCase.Error.String())
+		case testCase.Skipped != nil:
+			result.Status = atc.TestStatusSkipped
+			result.Message = truncate(testCase.Skipped.String())
+		}
+
+		results = append(results, result)
+	}
+
+	for _, nested := range suite.Suites {
+		results = nested.appendResults(results)
+	}
+
+	return results
+}
+
+func parseDuration(seconds string) float64 {
+	duration, err := strconv.ParseFloat(strings.ReplaceAll(seconds, ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
@@ -0,0 +1,103 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/testreport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseJUnit", func() {
+	var (
+		report  string
+		results []atc.TestResult
+		err     error
+	)
+
+	JustBeforeEach(func() {
+		results, err = testreport.ParseJUnit(strings.NewReader(report))
+	})
+
+	Context("with a testsuites root element", func() {
+		BeforeEach(func() {
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="math" tests="4">
+    <testcase classname="math.Add" name="adds" time="0.5"/>
+    <testcase classname="math.Add" name="overflows" time="1,200.25">
+      <failure message="expected 1 to equal 2">math_test.go:12</failure>
+    </testcase>
+    <testcase name="divides">
+      <error message="panic: division by zero"/>
+    </testcase>
+    <testcase name="rounds">
+      <skipped message="not implemented"/>
+    </testcase>
+  </testsuite>
+  <testsuite name="strings">
+    <testsuite name="nested">
+      <testcase name="trims"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+		})
+
+		It("reads every test case", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "math.Add", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+				{Suite: "math.Add", Name: "overflows", Status: atc.TestStatusFailed, Duration: 1200.25, Message: "expected 1 to equal 2\nmath_test.go:12"},
+				{Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "panic: division by zero"},
+				{Suite: "math", Name: "rounds", Status: atc.TestStatusSkipped, Message: "not implemented"},
+				{Suite: "nested", Name: "trims", Status: atc.TestStatusPassed},
+			}))
+		})
+	})
+
+	Context("with a testsuite root element", func() {
+		BeforeEach(func() {
+			report = `<testsuite name="only"><testcase name="works"/></testsuite>`
+		})
+
+		It("reads its test cases", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "only", Name: "works", Status: atc.TestStatusPassed},
+			}))
+		})
+	})
+
+	Context("with a very long failure message", func() {
+		BeforeEach(func() {
+			report = `<testsuite name="s"><testcase name="t"><failure>` + strings.Repeat("x", 5000) + `</failure></testcase></testsuite>`
+		})
+
+		It("truncates the message", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results[0].Message).To(HaveLen(4096 + len("...")))
+		})
+	})
+
+	Context("with an unexpected root element", func() {
+		BeforeEach(func() {
+			report = `<html></html>`
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("unexpected root element <html>")))
+		})
+	})
+
+	Context("with invalid XML", func() {
+		BeforeEach(func() {
+			report = `<testsuite name="broken"><testcase`
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid junit report")))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package testreport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var tapTestLine = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?(.*)$`)
+
+// ParseTAP reads a Test Anything Protocol report. Only top-level test points
+// are read; indented subtests are ignored. Tests marked with a SKIP directive
+// are skipped, as are failing tests marked with a TODO directive.
+func ParseTAP(r io.Reader) ([]atc.TestResult, error) {
+	var results []atc.TestResult
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	inDiagnostics := false
+	var diagnostics []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if inDiagnostics {
+			if strings.TrimSpace(line) == "..." {
+				inDiagnostics = false
+
+				if len(results) > 0 && results[len(results)-1].Status == atc.TestStatusFailed {
+					results[len(results)-1].Message = truncate(strings.Join(diagnostics, "\n"))
+				}
+
+				diagnostics = nil
+			} else {
+				diagnostics = append(diagnostics, strings.TrimPrefix(line, "  "))
+			}
+
+			continue
+		}
+
+		if strings.TrimSpace(line) == "---" {
+			inDiagnostics = true
+			continue
+		}
+
+		if strings.HasPrefix(line, "Bail out!") {
+			break
+		}
+
+		match := tapTestLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		description, directive, _ := strings.Cut(match[3], "#")
+		description = strings.TrimSpace(description)
+		directive = strings.TrimSpace(directive)
+
+		result := atc.TestResult{
+			Name:   description,
+			Status: atc.TestStatusPassed,
+		}
+
+		if result.Name == "" {
+			number := match[2]
+			if number == "" {
+				number = strconv.Itoa(len(results) + 1)
+			}
+
+			result.Name = "test " + number
+		}
+
+		if match[1] == "not ok" {
+			result.Status = atc.TestStatusFailed
+		}
+
+		_, reason, _ := strings.Cut(directive, " ")
+
+		switch {
+		case hasDirective(directive, "skip"):
+			result.Status = atc.TestStatusSkipped
+			result.Message = truncate(reason)
+		case hasDirective(directive, "todo") && result.Status == atc.TestStatusFailed:
+			result.Status = atc.TestStatusSkipped
+			result.Message = truncate(reason)
+		}
+
+		results = append(results, result)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid tap report: %w", err)
+	}
+
+	return results, nil
+}
+
+func hasDirective(directive string, name string) bool {
+	return len(directive) >= len(name) && strings.EqualFold(directive[:len(name)], name)
+}
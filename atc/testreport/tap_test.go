@@ -0,0 +1,41 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/testreport"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseTAP", func() {
+	It("reads top-level test points and their directives", func() {
+		results, err := testreport.ParseTAP(strings.NewReader(`TAP version 13
+1..6
+ok 1 - adds numbers
+not ok 2 - divides numbers
+  ---
+  message: division by zero
+  severity: fail
+  ...
+ok 3 # SKIP no network
+not ok 4 - rounds # TODO not implemented
+    ok 1 - ignored subtest
+ok 5 trims
+not ok
+Bail out! database went away
+ok 7 - never read
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(results).To(Equal([]atc.TestResult{
+			{Name: "adds numbers", Status: atc.TestStatusPassed},
+			{Name: "divides numbers", Status: atc.TestStatusFailed, Message: "message: division by zero\nseverity: fail"},
+			{Name: "test 3", Status: atc.TestStatusSkipped, Message: "no network"},
+			{Name: "rounds", Status: atc.TestStatusSkipped, Message: "not implemented"},
+			{Name: "trims", Status: atc.TestStatusPassed},
+			{Name: "test 6", Status: atc.TestStatusFailed},
+		}))
+	})
+})
@@ -0,0 +1,49 @@
+// Package testreport reads the results of individual test cases out of the
+// reports written by test runners.
+package testreport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// messages longer than this are truncated, as they often contain a whole
+// test log
+const maxMessageLength = 4096
+
+// Parse reads a report in the given format.
+func Parse(format string, r io.Reader) ([]atc.TestResult, error) {
+	switch format {
+	case atc.TestReportFormatJUnit:
+		return ParseJUnit(r)
+	case atc.TestReportFormatTAP:
+		return ParseTAP(r)
+	default:
+		return nil, fmt.Errorf("unknown report format '%s'", format)
+	}
+}
+
+// IsReportFile reports whether a file found in a report directory should be
+// read as a report of the given format.
+func IsReportFile(format string, path string) bool {
+	switch format {
+	case atc.TestReportFormatJUnit:
+		return strings.HasSuffix(path, ".xml")
+	case atc.TestReportFormatTAP:
+		return strings.HasSuffix(path, ".tap")
+	default:
+		return false
+	}
+}
+
+func truncate(message string) string {
+	message = strings.TrimSpace(message)
+	if len(message) > maxMessageLength {
+		return message[:maxMessageLength] + "..."
+	}
+
+	return message
+}
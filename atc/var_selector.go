@@ -0,0 +1,146 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VarSelector selects a value nested within a var, given as a JSONPath-like
+// expression such as `$.releases[0].version` or `labels["app.kubernetes.io/name"]`.
+// The leading `$` is optional, and negative indexes count back from the end
+// of an array.
+type VarSelector []VarSelectorField
+
+type VarSelectorField struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+func (field VarSelectorField) String() string {
+	if field.IsIndex {
+		return fmt.Sprintf("[%d]", field.Index)
+	}
+
+	if field.Key == "" || strings.ContainsAny(field.Key, `.[]"' `) {
+		return fmt.Sprintf("[%q]", field.Key)
+	}
+
+	return "." + field.Key
+}
+
+func (selector VarSelector) String() string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, field := range selector {
+		b.WriteString(field.String())
+	}
+	return b.String()
+}
+
+func ParseVarSelector(expression string) (VarSelector, error) {
+	rest := strings.TrimSpace(expression)
+	if strings.HasPrefix(rest, "$") {
+		rest = rest[1:]
+	} else if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	selector := VarSelector{}
+	for rest != "" {
+		var (
+			field VarSelectorField
+			err   error
+		)
+
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			field.Key = rest[1 : end+1]
+			if field.Key == "" {
+				return nil, fmt.Errorf("invalid selector '%s': empty field", expression)
+			}
+
+			rest = rest[end+1:]
+		case '[':
+			field, rest, err = parseVarSelectorBracket(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector '%s': %w", expression, err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid selector '%s': unexpected '%c'", expression, rest[0])
+		}
+
+		selector = append(selector, field)
+	}
+
+	return selector, nil
+}
+
+func parseVarSelectorBracket(expression string) (VarSelectorField, string, error) {
+	if len(expression) > 1 && (expression[1] == '"' || expression[1] == '\'') {
+		quote := expression[1]
+		end := strings.IndexByte(expression[2:], quote)
+		if end == -1 || !strings.HasPrefix(expression[end+3:], "]") {
+			return VarSelectorField{}, "", fmt.Errorf("unterminated key")
+		}
+
+		return VarSelectorField{Key: expression[2 : end+2]}, expression[end+4:], nil
+	}
+
+	end := strings.IndexByte(expression, ']')
+	if end == -1 {
+		return VarSelectorField{}, "", fmt.Errorf("missing ']'")
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(expression[1:end]))
+	if err != nil {
+		return VarSelectorField{}, "", fmt.Errorf("invalid index '%s'", expression[1:end])
+	}
+
+	return VarSelectorField{Index: index, IsIndex: true}, expression[end+1:], nil
+}
+
+// Select returns the value the selector refers to within the given value.
+func (selector VarSelector) Select(value interface{}) (interface{}, error) {
+	for i, field := range selector {
+		at := selector[:i]
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if field.IsIndex {
+				return nil, fmt.Errorf("cannot index %s, which is a map", at)
+			}
+
+			var found bool
+			value, found = v[field.Key]
+			if !found {
+				return nil, fmt.Errorf("no field '%s' in %s", field.Key, at)
+			}
+		case []interface{}:
+			if !field.IsIndex {
+				return nil, fmt.Errorf("cannot select field '%s' of %s, which is an array", field.Key, at)
+			}
+
+			index := field.Index
+			if index < 0 {
+				index += len(v)
+			}
+
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("index %d is out of range for %s, which has %d elements", field.Index, at, len(v))
+			}
+
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("cannot select %s of %s, which is a %T", field, at, value)
+		}
+	}
+
+	return value, nil
+}
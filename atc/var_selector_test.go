@@ -0,0 +1,67 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VarSelector", func() {
+	value := map[string]interface{}{
+		"releases": []interface{}{
+			map[string]interface{}{"version": "1.0.0"},
+			map[string]interface{}{"version": "1.1.0"},
+		},
+		"labels": map[string]interface{}{
+			"app.kubernetes.io/name": "some-app",
+		},
+		"count": json.Number("2"),
+	}
+
+	DescribeTable("selecting a value",
+		func(expression string, expected interface{}) {
+			selector, err := atc.ParseVarSelector(expression)
+			Expect(err).ToNot(HaveOccurred())
+
+			selected, err := selector.Select(value)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(Equal(expected))
+		},
+		Entry("the whole value", "$", value),
+		Entry("a field", "$.count", json.Number("2")),
+		Entry("a field without the leading $", "count", json.Number("2")),
+		Entry("an array element", "$.releases[1].version", "1.1.0"),
+		Entry("an array element from the end", "releases[-2].version", "1.0.0"),
+		Entry("a quoted field", `$.labels["app.kubernetes.io/name"]`, "some-app"),
+		Entry("a single-quoted field", `labels['app.kubernetes.io/name']`, "some-app"),
+	)
+
+	DescribeTable("invalid expressions",
+		func(expression string, message string) {
+			_, err := atc.ParseVarSelector(expression)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("empty field", "$.releases..version", "empty field"),
+		Entry("unterminated index", "$.releases[0", "missing ']'"),
+		Entry("non-numeric index", "$.releases[first]", "invalid index 'first'"),
+		Entry("unterminated key", `$.labels["app`, "unterminated key"),
+		Entry("garbage after $", "$releases", "unexpected 'r'"),
+	)
+
+	DescribeTable("values which cannot be selected",
+		func(expression string, message string) {
+			selector, err := atc.ParseVarSelector(expression)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = selector.Select(value)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("a missing field", "$.releases[0].name", "no field 'name' in $.releases[0]"),
+		Entry("an index out of range", "$.releases[2]", "index 2 is out of range for $.releases, which has 2 elements"),
+		Entry("an index of a map", "$.labels[0]", "cannot index $.labels, which is a map"),
+		Entry("a field of an array", "$.releases.version", "cannot select field 'version' of $.releases, which is an array"),
+		Entry("a field of a scalar", "$.count.value", "cannot select .value of $.count, which is a json.Number"),
+	)
+})
@@ -0,0 +1,190 @@
+package atc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Webhook is what a check gets to know about the request that triggered it
+// through a resource's webhook. It is passed to the resource's check script
+// as the `webhook` field of the check request.
+//
+// Only the headers and fields of the payload that the resource asks for with
+// webhook_headers and webhook_payload are kept, as the webhook is stored with
+// the check's plan.
+type Webhook struct {
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Payload holds the fields selected from the body of the request.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewWebhook selects the headers and fields of the payload of a webhook
+// request that are passed on to the resource's check. It returns nil if the
+// resource asks for neither.
+func NewWebhook(config ResourceConfig, headers map[string][]string, payload json.RawMessage) *Webhook {
+	if len(config.WebhookHeaders) == 0 && len(config.WebhookPayload) == 0 {
+		return nil
+	}
+
+	webhook := &Webhook{}
+
+	for _, name := range config.WebhookHeaders {
+		values, found := headers[textproto.CanonicalMIMEHeaderKey(name)]
+		if !found {
+			continue
+		}
+
+		if webhook.Headers == nil {
+			webhook.Headers = map[string][]string{}
+		}
+
+		webhook.Headers[textproto.CanonicalMIMEHeaderKey(name)] = values
+	}
+
+	if len(config.WebhookPayload) > 0 {
+		webhook.Payload = config.WebhookPayload.Select(payload)
+	}
+
+	return webhook
+}
+
+// WebhookPayload maps each field of the payload that is passed on to a
+// resource's check to a JSON pointer (RFC 6901) into the payload of a
+// webhook, e.g.
+//
+//	webhook_payload:
+//	  ref: /ref
+//	  after: /after
+type WebhookPayload map[string]string
+
+func (p WebhookPayload) Validate() error {
+	return validateJSONPointers(p)
+}
+
+// Select returns an object with the fields of the payload, leaving out those
+// that the payload does not have.
+func (p WebhookPayload) Select(payload json.RawMessage) json.RawMessage {
+	selected := map[string]interface{}{}
+
+	doc, ok := decodeWebhookPayload(payload)
+	if ok {
+		for field, pointer := range p {
+			value, found := resolveJSONPointer(doc, pointer)
+			if found {
+				selected[field] = value
+			}
+		}
+	}
+
+	// a map of decoded JSON values always marshals
+	selectedPayload, _ := json.Marshal(selected)
+
+	return selectedPayload
+}
+
+// WebhookVersion maps each field of a version to a JSON pointer (RFC 6901)
+// into the payload of a webhook, e.g.
+//
+//	webhook_version:
+//	  ref: /after
+//
+// which lets a webhook save the version it is about without running a check.
+type WebhookVersion map[string]string
+
+func (v WebhookVersion) Validate() error {
+	return validateJSONPointers(v)
+}
+
+// Version extracts the version from the payload of a webhook. It returns
+// false if the payload is missing any of the fields, or if any of them is
+// not a string or number, e.g. for events other than the expected one.
+func (v WebhookVersion) Version(payload json.RawMessage) (Version, bool) {
+	if len(v) == 0 {
+		return nil, false
+	}
+
+	doc, ok := decodeWebhookPayload(payload)
+	if !ok {
+		return nil, false
+	}
+
+	version := Version{}
+	for field, pointer := range v {
+		value, found := resolveJSONPointer(doc, pointer)
+		if !found {
+			return nil, false
+		}
+
+		switch value := value.(type) {
+		case string:
+			version[field] = value
+		case json.Number:
+			version[field] = value.String()
+		default:
+			return nil, false
+		}
+	}
+
+	return version, true
+}
+
+func validateJSONPointers(pointers map[string]string) error {
+	for field, pointer := range pointers {
+		if pointer != "" && !strings.HasPrefix(pointer, "/") {
+			return fmt.Errorf("invalid json pointer for field '%s': '%s' must be empty or start with '/'", field, pointer)
+		}
+	}
+
+	return nil
+}
+
+// decodeWebhookPayload decodes a payload keeping its numbers as they are, so
+// that they are not rounded through float64.
+func decodeWebhookPayload(payload json.RawMessage) (interface{}, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var doc interface{}
+	err := decoder.Decode(&doc)
+	if err != nil {
+		return nil, false
+	}
+
+	return doc, true
+}
+
+func resolveJSONPointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			child, found := node[token]
+			if !found {
+				return nil, false
+			}
+
+			doc = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+
+			doc = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 )
 
 func NewDB(
@@ -49,3 +50,13 @@ func (db DB) ToGardenRuntimeDB() gardenruntime.DB {
 		LockFactory:                   db.LockFactory,
 	}
 }
+
+func (db DB) ToK8sRuntimeDB() k8sruntime.DB {
+	return k8sruntime.DB{
+		VolumeRepo:             db.VolumeRepo,
+		TaskCacheFactory:       db.TaskCacheFactory,
+		WorkerTaskCacheFactory: db.WorkerTaskCacheFactory,
+		ResourceCacheFactory:   db.ResourceCacheFactory,
+		LockFactory:            db.LockFactory,
+	}
+}
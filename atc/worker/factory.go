@@ -10,12 +10,18 @@ import (
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/gclient"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 	bclient "github.com/concourse/concourse/worker/baggageclaim/client"
 	"github.com/concourse/retryhttp"
+	"k8s.io/client-go/kubernetes"
 )
 
 type Factory interface {
 	NewWorker(lager.Logger, db.Worker) runtime.Worker
+
+	// CanStream tells whether artifacts can be streamed in and out of the
+	// worker.
+	CanStream(db.Worker) bool
 }
 
 type DefaultFactory struct {
@@ -26,12 +32,36 @@ type DefaultFactory struct {
 	GardenRequestTimeout              time.Duration
 	BaggageclaimResponseHeaderTimeout time.Duration
 	HTTPRetryTimeout                  time.Duration
+
+	// Kubernetes is the cluster that is registered as a worker, if any.
+	Kubernetes *KubernetesWorker
+}
+
+type KubernetesWorker struct {
+	Name   string
+	Client kubernetes.Interface
+	Config k8sruntime.Config
 }
 
 func (f DefaultFactory) NewWorker(logger lager.Logger, dbWorker db.Worker) runtime.Worker {
+	if f.Kubernetes != nil && dbWorker.Name() == f.Kubernetes.Name {
+		return k8sruntime.NewWorker(
+			dbWorker,
+			f.Kubernetes.Client,
+			f.Kubernetes.Config,
+			f.DB.ToK8sRuntimeDB(),
+		)
+	}
+
 	return f.newGardenWorker(logger, dbWorker)
 }
 
+// CanStream is false for the Kubernetes worker, whose volumes are persistent
+// volume claims in the cluster.
+func (f DefaultFactory) CanStream(dbWorker db.Worker) bool {
+	return f.Kubernetes == nil || dbWorker.Name() != f.Kubernetes.Name
+}
+
 func (f DefaultFactory) newGardenWorker(logger lager.Logger, dbWorker db.Worker) *gardenruntime.Worker {
 	gcf := gclient.NewGardenClientFactory(
 		f.DB.WorkerFactory,
@@ -0,0 +1,186 @@
+package k8sruntime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Beacon registers the cluster as a worker, and does what a worker's beacon
+// would otherwise do for it: keeping the worker's registration alive, and
+// destroying the containers and volumes that the ATC has marked for
+// destruction.
+type Beacon struct {
+	worker    atc.Worker
+	client    kubernetes.Interface
+	namespace string
+	ttl       time.Duration
+
+	workerFactory       db.WorkerFactory
+	containerRepository db.ContainerRepository
+	volumeRepository    db.VolumeRepository
+}
+
+// NewBeacon returns a Beacon for the given worker. Only the worker's name,
+// team, tags and resource types (where the image is an image reference) need
+// to be set.
+func NewBeacon(
+	worker atc.Worker,
+	client kubernetes.Interface,
+	namespace string,
+	ttl time.Duration,
+	workerFactory db.WorkerFactory,
+	containerRepository db.ContainerRepository,
+	volumeRepository db.VolumeRepository,
+) *Beacon {
+	worker.Platform = "linux"
+	worker.Version = concourse.WorkerVersion
+	worker.StartTime = time.Now().Unix()
+
+	return &Beacon{
+		worker:    worker,
+		client:    client,
+		namespace: namespace,
+		ttl:       ttl,
+
+		workerFactory:       workerFactory,
+		containerRepository: containerRepository,
+		volumeRepository:    volumeRepository,
+	}
+}
+
+func (beacon *Beacon) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("kubernetes-beacon", lager.Data{"worker": beacon.worker.Name})
+
+	err := beacon.register(ctx)
+	if err != nil {
+		logger.Error("failed-to-register-worker", err)
+		return err
+	}
+
+	err = beacon.destroyContainers(ctx, logger)
+	if err != nil {
+		logger.Error("failed-to-destroy-containers", err)
+		return err
+	}
+
+	err = beacon.destroyVolumes(ctx, logger)
+	if err != nil {
+		logger.Error("failed-to-destroy-volumes", err)
+		return err
+	}
+
+	return nil
+}
+
+func (beacon *Beacon) register(ctx context.Context) error {
+	selector := metav1.ListOptions{LabelSelector: workerLabel + "=" + beacon.worker.Name}
+
+	records, err := beacon.client.CoreV1().ConfigMaps(beacon.namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list container records: %w", err)
+	}
+
+	claims, err := beacon.client.CoreV1().PersistentVolumeClaims(beacon.namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list persistent volume claims: %w", err)
+	}
+
+	worker := beacon.worker
+	worker.ActiveContainers = len(records.Items)
+	worker.ActiveVolumes = len(claims.Items)
+
+	_, err = beacon.workerFactory.SaveWorker(worker, beacon.ttl)
+	if err != nil {
+		return fmt.Errorf("save worker: %w", err)
+	}
+
+	return nil
+}
+
+// destroyContainers deletes the pods, stdin secrets and record of each
+// destroying container. Containers for which this fails are left in the
+// database, to be retried on the next run.
+func (beacon *Beacon) destroyContainers(ctx context.Context, logger lager.Logger) error {
+	handles, err := beacon.containerRepository.FindDestroyingContainers(beacon.worker.Name)
+	if err != nil {
+		return err
+	}
+
+	failed := []string{}
+	for _, handle := range handles {
+		err := beacon.destroyContainer(ctx, handle)
+		if err != nil {
+			logger.Error("failed-to-destroy-container", err, lager.Data{"handle": handle})
+			failed = append(failed, handle)
+		}
+	}
+
+	_, err = beacon.containerRepository.RemoveDestroyingContainers(beacon.worker.Name, failed)
+	return err
+}
+
+func (beacon *Beacon) destroyContainer(ctx context.Context, handle string) error {
+	core := beacon.client.CoreV1()
+	selector := metav1.ListOptions{LabelSelector: containerLabel + "=" + handle}
+
+	pods, err := core.Pods(beacon.namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		err := core.Pods(beacon.namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if ignoreNotFound(err) != nil {
+			return fmt.Errorf("delete pod: %w", err)
+		}
+	}
+
+	secrets, err := core.Secrets(beacon.namespace).List(ctx, selector)
+	if err != nil {
+		return fmt.Errorf("list secrets: %w", err)
+	}
+
+	for _, secret := range secrets.Items {
+		err := core.Secrets(beacon.namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if ignoreNotFound(err) != nil {
+			return fmt.Errorf("delete secret: %w", err)
+		}
+	}
+
+	err = core.ConfigMaps(beacon.namespace).Delete(ctx, handle, metav1.DeleteOptions{})
+	if ignoreNotFound(err) != nil {
+		return fmt.Errorf("delete container record: %w", err)
+	}
+
+	return nil
+}
+
+// destroyVolumes deletes the persistent volume claim of each destroying
+// volume.
+func (beacon *Beacon) destroyVolumes(ctx context.Context, logger lager.Logger) error {
+	handles, err := beacon.volumeRepository.GetDestroyingVolumes(beacon.worker.Name)
+	if err != nil {
+		return err
+	}
+
+	failed := []string{}
+	for _, handle := range handles {
+		err := beacon.client.CoreV1().PersistentVolumeClaims(beacon.namespace).Delete(ctx, handle, metav1.DeleteOptions{})
+		if ignoreNotFound(err) != nil {
+			logger.Error("failed-to-delete-persistent-volume-claim", err, lager.Data{"handle": handle})
+			failed = append(failed, handle)
+		}
+	}
+
+	_, err = beacon.volumeRepository.RemoveDestroyingVolumes(beacon.worker.Name, failed)
+	return err
+}
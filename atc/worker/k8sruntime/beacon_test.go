@@ -0,0 +1,118 @@
+package k8sruntime_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Beacon", func() {
+	var (
+		ctx context.Context
+
+		client                  *fake.Clientset
+		fakeWorkerFactory       *dbfakes.FakeWorkerFactory
+		fakeContainerRepository *dbfakes.FakeContainerRepository
+		fakeVolumeRepository    *dbfakes.FakeVolumeRepository
+
+		beacon *k8sruntime.Beacon
+	)
+
+	labelled := func(name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		containerLabels := map[string]string{
+			"concourse-ci.org/worker":    "k8s",
+			"concourse-ci.org/container": "destroying-handle",
+		}
+
+		client = fake.NewSimpleClientset(
+			&corev1.ConfigMap{ObjectMeta: labelled("destroying-handle", containerLabels)},
+			&corev1.Pod{ObjectMeta: labelled("destroying-handle-abcd", containerLabels)},
+			&corev1.Secret{ObjectMeta: labelled("destroying-handle-abcd", containerLabels)},
+			&corev1.ConfigMap{ObjectMeta: labelled("other-handle", map[string]string{
+				"concourse-ci.org/worker":    "k8s",
+				"concourse-ci.org/container": "other-handle",
+			})},
+			&corev1.PersistentVolumeClaim{ObjectMeta: labelled("volume-handle", map[string]string{
+				"concourse-ci.org/worker": "k8s",
+			})},
+		)
+
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeContainerRepository = new(dbfakes.FakeContainerRepository)
+		fakeContainerRepository.FindDestroyingContainersReturns([]string{"destroying-handle"}, nil)
+		fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
+		fakeVolumeRepository.GetDestroyingVolumesReturns([]string{"volume-handle", "missing-handle"}, nil)
+
+		beacon = k8sruntime.NewBeacon(
+			atc.Worker{
+				Name: "k8s",
+				Tags: []string{"k8s"},
+			},
+			client,
+			namespace,
+			30*time.Second,
+			fakeWorkerFactory,
+			fakeContainerRepository,
+			fakeVolumeRepository,
+		)
+	})
+
+	JustBeforeEach(func() {
+		Expect(beacon.Run(ctx)).To(Succeed())
+	})
+
+	It("registers the cluster as a worker", func() {
+		Expect(fakeWorkerFactory.SaveWorkerCallCount()).To(Equal(1))
+
+		worker, ttl := fakeWorkerFactory.SaveWorkerArgsForCall(0)
+		Expect(worker.Name).To(Equal("k8s"))
+		Expect(worker.Tags).To(Equal(atc.Tags{"k8s"}))
+		Expect(worker.Platform).To(Equal("linux"))
+		Expect(worker.Version).To(Equal(concourse.WorkerVersion))
+		Expect(worker.ActiveContainers).To(Equal(2))
+		Expect(worker.ActiveVolumes).To(Equal(1))
+		Expect(ttl).To(Equal(30 * time.Second))
+	})
+
+	It("destroys the pods, secrets and records of destroying containers", func() {
+		_, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, "destroying-handle", metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+		_, err = client.CoreV1().Pods(namespace).Get(ctx, "destroying-handle-abcd", metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+		_, err = client.CoreV1().Secrets(namespace).Get(ctx, "destroying-handle-abcd", metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+
+		_, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, "other-handle", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeContainerRepository.RemoveDestroyingContainersCallCount()).To(Equal(1))
+		workerName, handlesToIgnore := fakeContainerRepository.RemoveDestroyingContainersArgsForCall(0)
+		Expect(workerName).To(Equal("k8s"))
+		Expect(handlesToIgnore).To(BeEmpty())
+	})
+
+	It("destroys the persistent volume claims of destroying volumes", func() {
+		_, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, "volume-handle", metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+
+		Expect(fakeVolumeRepository.RemoveDestroyingVolumesCallCount()).To(Equal(1))
+		workerName, handlesToIgnore := fakeVolumeRepository.RemoveDestroyingVolumesArgsForCall(0)
+		Expect(workerName).To(Equal("k8s"))
+		Expect(handlesToIgnore).To(BeEmpty())
+	})
+})
@@ -0,0 +1,307 @@
+package k8sruntime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// Keys of the data in a container's record.
+const (
+	podSpecKey    = "pod-spec"
+	typeKey       = "type"
+	propertiesKey = "properties"
+)
+
+const (
+	processAnnotation = "concourse-ci.org/process"
+	markerAnnotation  = "concourse-ci.org/stdout-marker"
+
+	userPropertyName       = "user"
+	exitStatusPropertyName = "concourse:exit-status"
+
+	stdinVolumeName = "concourse-stdin"
+	stdinDir        = "/tmp/concourse-stdin"
+	stdinKey        = "stdin"
+)
+
+type Container struct {
+	worker      *Worker
+	dbContainer db.CreatedContainer
+	record      *corev1.ConfigMap
+}
+
+func (worker *Worker) newContainer(dbContainer db.CreatedContainer, record *corev1.ConfigMap) *Container {
+	return &Container{
+		worker:      worker,
+		dbContainer: dbContainer,
+		record:      record,
+	}
+}
+
+// Run starts the process as a new pod. As the pods of a container share its
+// volumes, a container can't run more than one process at a time. Pods of
+// processes that have exited are deleted first.
+//
+// The kubelet interleaves a container's stdout and stderr in its logs. Task
+// output is therefore all written to the process's stdout. For other
+// containers (i.e. resource scripts, whose stdout is their response) stdout is
+// written to a file, which is printed to stderr after a marker once the
+// process exits, so that the two can be told apart.
+func (c *Container) Run(ctx context.Context, spec runtime.ProcessSpec, pio runtime.ProcessIO) (runtime.Process, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	err := c.deleteExitedPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var template corev1.PodSpec
+	err = json.Unmarshal([]byte(c.record.Data[podSpecKey]), &template)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal pod spec: %w", err)
+	}
+
+	properties, err := c.Properties()
+	if err != nil {
+		return nil, fmt.Errorf("get properties: %w", err)
+	}
+
+	id := spec.ID
+	if id == "" {
+		id = randomHex(8)
+	}
+
+	name := c.dbContainer.Handle() + "-" + randomHex(4)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      c.worker.labels(c.dbContainer.Handle()),
+			Annotations: map[string]string{processAnnotation: id},
+		},
+		Spec: template,
+	}
+
+	main := &pod.Spec.Containers[0]
+
+	env := make([]string, 0, len(main.Env)+len(spec.Env))
+	for _, v := range main.Env {
+		env = append(env, v.Name+"="+v.Value)
+	}
+	main.Env = toEnvVars(append(env, spec.Env...))
+
+	if spec.Dir != "" {
+		main.WorkingDir = spec.Dir
+	}
+
+	user := spec.User
+	if user == "" {
+		user = properties[userPropertyName]
+	}
+	main.SecurityContext = toSecurityContext(main.SecurityContext, user)
+
+	var stdin string
+	if pio.Stdin != nil {
+		payload, err := io.ReadAll(pio.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read stdin: %w", err)
+		}
+
+		_, err = c.worker.client.CoreV1().Secrets(c.worker.config.Namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: c.worker.labels(c.dbContainer.Handle()),
+			},
+			Data: map[string][]byte{stdinKey: payload},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("create stdin secret: %w", err)
+		}
+
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: stdinVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: name},
+			},
+		})
+		main.VolumeMounts = append(main.VolumeMounts, corev1.VolumeMount{
+			Name:      stdinVolumeName,
+			MountPath: stdinDir,
+			ReadOnly:  true,
+		})
+
+		stdin = stdinDir + "/" + stdinKey
+	}
+
+	var marker string
+	if c.isTask() {
+		if stdin == "" {
+			main.Command = append([]string{spec.Path}, spec.Args...)
+		} else {
+			main.Command = append([]string{"sh", "-c", fmt.Sprintf(`exec "$0" "$@" <%s`, stdin), spec.Path}, spec.Args...)
+		}
+	} else {
+		marker = randomHex(16)
+		pod.Annotations[markerAnnotation] = marker
+
+		if stdin == "" {
+			stdin = "/dev/null"
+		}
+
+		script := fmt.Sprintf(
+			`"$0" "$@" <%[1]s >%[2]s/stdout; status=$?; printf '\n%%s\n' %[3]s >&2; cat %[2]s/stdout >&2; exit $status`,
+			stdin, ioDir, marker,
+		)
+
+		main.Command = append([]string{"sh", "-c", script, spec.Path}, spec.Args...)
+	}
+	main.Args = nil
+
+	_, err = c.worker.client.CoreV1().Pods(c.worker.config.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		logger.Error("failed-to-create-pod", err)
+		return nil, fmt.Errorf("start process: %w", err)
+	}
+
+	return c.newProcess(id, name, marker, nil, pio), nil
+}
+
+// Attach attaches to the pod that is running the process with the given ID.
+// Output that was logged before attaching is not replayed.
+func (c *Container) Attach(ctx context.Context, id string, pio runtime.ProcessIO) (runtime.Process, error) {
+	properties, _ := c.Properties()
+	statusStr, ok := properties[exitStatusPropertyName]
+	if ok {
+		if status, err := strconv.Atoi(statusStr); err == nil {
+			return ExitedProcess{id: id, Result: runtime.ProcessResult{ExitStatus: status}}, nil
+		}
+	}
+
+	pods, err := c.pods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("attach to process: %w", err)
+	}
+
+	for _, pod := range pods {
+		if pod.Annotations[processAnnotation] != id {
+			continue
+		}
+
+		now := metav1.Now()
+		return c.newProcess(id, pod.Name, pod.Annotations[markerAnnotation], &now, pio), nil
+	}
+
+	return nil, fmt.Errorf("attach to process: process '%s' not found", id)
+}
+
+func (c *Container) Properties() (map[string]string, error) {
+	record, err := c.worker.client.CoreV1().ConfigMaps(c.worker.config.Namespace).Get(context.TODO(), c.dbContainer.Handle(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{}
+	err = json.Unmarshal([]byte(record.Data[propertiesKey]), &properties)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal properties: %w", err)
+	}
+
+	return properties, nil
+}
+
+func (c *Container) SetProperty(name string, value string) error {
+	configMaps := c.worker.client.CoreV1().ConfigMaps(c.worker.config.Namespace)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		record, err := configMaps.Get(context.TODO(), c.dbContainer.Handle(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		properties := map[string]string{}
+		err = json.Unmarshal([]byte(record.Data[propertiesKey]), &properties)
+		if err != nil {
+			return fmt.Errorf("unmarshal properties: %w", err)
+		}
+
+		properties[name] = value
+
+		payload, err := json.Marshal(properties)
+		if err != nil {
+			return err
+		}
+
+		record.Data[propertiesKey] = string(payload)
+
+		_, err = configMaps.Update(context.TODO(), record, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (c *Container) DBContainer() db.CreatedContainer {
+	return c.dbContainer
+}
+
+func (c *Container) isTask() bool {
+	return db.ContainerType(c.record.Data[typeKey]) == db.ContainerTypeTask
+}
+
+func (c *Container) pods(ctx context.Context) ([]corev1.Pod, error) {
+	pods, err := c.worker.client.CoreV1().Pods(c.worker.config.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: containerLabel + "=" + c.dbContainer.Handle(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
+
+func (c *Container) deleteExitedPods(ctx context.Context) error {
+	pods, err := c.pods(ctx)
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			return ProcessAlreadyRunningError{Handle: c.dbContainer.Handle()}
+		}
+	}
+
+	for _, pod := range pods {
+		err := c.worker.client.CoreV1().Pods(c.worker.config.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if ignoreNotFound(err) != nil {
+			return fmt.Errorf("delete pod: %w", err)
+		}
+
+		err = c.worker.client.CoreV1().Secrets(c.worker.config.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if ignoreNotFound(err) != nil {
+			return fmt.Errorf("delete stdin secret: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+package k8sruntime
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedResourceType = errors.New("unsupported resource type")
+var ErrNoImage = errors.New("no image specified")
+var ErrStreamingNotSupported = errors.New("artifacts cannot be streamed in or out of a kubernetes worker")
+
+type ContainerRecordNotFoundError struct {
+	Handle string
+}
+
+func (e ContainerRecordNotFoundError) Error() string {
+	return fmt.Sprintf("record of container '%s' disappeared from the cluster", e.Handle)
+}
+
+type UnsupportedImageURLError struct {
+	URL string
+}
+
+func (e UnsupportedImageURLError) Error() string {
+	return fmt.Sprintf("unsupported image url '%s': only docker:// urls are supported", e.URL)
+}
+
+type ArtifactNotInClusterError struct {
+	Source     string
+	WorkerName string
+}
+
+func (e ArtifactNotInClusterError) Error() string {
+	return fmt.Sprintf("artifact on worker '%s' cannot be used on kubernetes worker '%s', as artifacts cannot be streamed into the cluster", e.Source, e.WorkerName)
+}
+
+type ProcessAlreadyRunningError struct {
+	Handle string
+}
+
+func (e ProcessAlreadyRunningError) Error() string {
+	return fmt.Sprintf("container '%s' is already running a process", e.Handle)
+}
+
+type PodFailedError struct {
+	Pod     string
+	Reason  string
+	Message string
+}
+
+func (e PodFailedError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("pod '%s' failed: %s", e.Pod, e.Reason)
+	}
+
+	return fmt.Sprintf("pod '%s' failed: %s: %s", e.Pod, e.Reason, e.Message)
+}
@@ -0,0 +1,82 @@
+package k8sruntime
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runHelper runs the command in a pod using the helper image, with the given
+// volumes mounted at /volumes/0, /volumes/1, etc. It returns the command's
+// output once it has exited successfully.
+func (worker *Worker) runHelper(ctx context.Context, name string, volumes []Volume, command []string) ([]byte, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("helper-%s-%s", name, randomHex(8)),
+			Labels: map[string]string{workerLabel: worker.Name()},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: boolPtr(false),
+			Containers: []corev1.Container{
+				{
+					Name:    mainContainerName,
+					Image:   worker.config.HelperImage,
+					Command: command,
+				},
+			},
+		},
+	}
+
+	for i, volume := range volumes {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, pvcVolume(volume.Handle()))
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      podVolumeName(volume.Handle()),
+			MountPath: fmt.Sprintf("/volumes/%d", i),
+		})
+	}
+
+	pods := worker.client.CoreV1().Pods(worker.config.Namespace)
+
+	_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create helper pod: %w", err)
+	}
+
+	defer pods.Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+
+	var exitCode int32
+	err = worker.waitForPod(ctx, pod.Name, func(pod *corev1.Pod) (bool, error) {
+		status, err := mainContainerStatus(pod)
+		if err != nil || status == nil || status.State.Terminated == nil {
+			return false, err
+		}
+
+		exitCode = status.State.Terminated.ExitCode
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for helper pod: %w", err)
+	}
+
+	logs, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{Container: mainContainerName}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get helper pod logs: %w", err)
+	}
+
+	defer logs.Close()
+
+	output, err := io.ReadAll(logs)
+	if err != nil {
+		return nil, fmt.Errorf("read helper pod logs: %w", err)
+	}
+
+	if exitCode != 0 {
+		return nil, fmt.Errorf("helper pod exited with status %d: %s", exitCode, output)
+	}
+
+	return output, nil
+}
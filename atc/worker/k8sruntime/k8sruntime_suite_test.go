@@ -0,0 +1,19 @@
+package k8sruntime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestK8sRuntime(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kubernetes Runtime Suite")
+}
+
+var _ = BeforeSuite(func() {
+	k8sruntime.PodPollingInterval = 10 * time.Millisecond
+})
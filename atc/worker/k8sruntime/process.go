@@ -0,0 +1,297 @@
+package k8sruntime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodPollingInterval is how often the status of a pod is checked while
+// waiting for it to exit.
+var PodPollingInterval = 1 * time.Second
+
+// Reasons for which a container is waiting that it won't recover from without
+// the pod being changed.
+var fatalWaitingReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+type Process struct {
+	id        string
+	pod       string
+	marker    string
+	since     *metav1.Time
+	io        runtime.ProcessIO
+	container *Container
+}
+
+func (c *Container) newProcess(id string, pod string, marker string, since *metav1.Time, pio runtime.ProcessIO) Process {
+	return Process{
+		id:        id,
+		pod:       pod,
+		marker:    marker,
+		since:     since,
+		io:        pio,
+		container: c,
+	}
+}
+
+func (p Process) ID() string {
+	return p.id
+}
+
+// Wait polls the pod until its main container has terminated, streaming the
+// container's logs as soon as it has started. If the context is cancelled,
+// the pod is deleted.
+func (p Process) Wait(ctx context.Context) (runtime.ProcessResult, error) {
+	logger := lagerctx.FromContext(ctx)
+	worker := p.container.worker
+
+	var (
+		logsStreamed chan error
+		terminated   *corev1.ContainerStateTerminated
+	)
+
+	err := worker.waitForPod(ctx, p.pod, func(pod *corev1.Pod) (bool, error) {
+		status, err := mainContainerStatus(pod)
+		if err != nil || status == nil {
+			return false, err
+		}
+
+		if logsStreamed == nil && (status.State.Running != nil || status.State.Terminated != nil) {
+			logsStreamed = make(chan error, 1)
+			go func() {
+				logsStreamed <- p.streamLogs()
+			}()
+		}
+
+		terminated = status.State.Terminated
+		return terminated != nil, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			deleteErr := worker.client.CoreV1().Pods(worker.config.Namespace).Delete(context.Background(), p.pod, metav1.DeleteOptions{})
+			return runtime.ProcessResult{}, multierror.Append(ctx.Err(), ignoreNotFound(deleteErr))
+		}
+
+		return runtime.ProcessResult{}, fmt.Errorf("wait for process completion: %w", err)
+	}
+
+	err = <-logsStreamed
+	if err != nil {
+		logger.Error("failed-to-stream-logs", err)
+	}
+
+	if terminated.Reason == "StartError" || terminated.Reason == "ContainerCannotRun" {
+		if strings.Contains(terminated.Message, "executable file not found") {
+			return runtime.ProcessResult{}, runtime.ExecutableNotFoundError{Message: terminated.Message}
+		}
+
+		return runtime.ProcessResult{}, PodFailedError{Pod: p.pod, Reason: terminated.Reason, Message: terminated.Message}
+	}
+
+	exitStatus := int(terminated.ExitCode)
+	p.container.SetProperty(exitStatusPropertyName, strconv.Itoa(exitStatus))
+
+	return runtime.ProcessResult{ExitStatus: exitStatus}, nil
+}
+
+func (p Process) SetTTY(tty runtime.TTYSpec) error {
+	return nil
+}
+
+func (p Process) streamLogs() error {
+	worker := p.container.worker
+
+	// Using context.Background() so that the logs aren't cut short when the
+	// context of Wait is cancelled. The stream ends once the container exits,
+	// or the pod is deleted.
+	logs, err := worker.client.CoreV1().Pods(worker.config.Namespace).GetLogs(p.pod, &corev1.PodLogOptions{
+		Container: mainContainerName,
+		Follow:    true,
+		SinceTime: p.since,
+	}).Stream(context.Background())
+	if err != nil {
+		return err
+	}
+
+	defer logs.Close()
+
+	stdout := p.io.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+
+	stderr := p.io.Stderr
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	if p.marker == "" {
+		_, err = io.Copy(stdout, logs)
+		return err
+	}
+
+	demux := &stdoutDemuxer{
+		marker: []byte("\n" + p.marker + "\n"),
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	_, err = io.Copy(demux, logs)
+	if err != nil {
+		return err
+	}
+
+	return demux.Flush()
+}
+
+// stdoutDemuxer writes everything up to the marker to stderr, and everything
+// following it to stdout. Enough of the output is held back to be able to
+// recognize the marker when it is split across writes.
+type stdoutDemuxer struct {
+	marker []byte
+	stdout io.Writer
+	stderr io.Writer
+
+	buf   []byte
+	found bool
+}
+
+func (d *stdoutDemuxer) Write(p []byte) (int, error) {
+	if d.found {
+		return d.stdout.Write(p)
+	}
+
+	d.buf = append(d.buf, p...)
+
+	if i := bytes.Index(d.buf, d.marker); i >= 0 {
+		d.found = true
+
+		_, err := d.stderr.Write(d.buf[:i])
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = d.stdout.Write(d.buf[i+len(d.marker):])
+		if err != nil {
+			return 0, err
+		}
+
+		d.buf = nil
+		return len(p), nil
+	}
+
+	n := len(d.buf) - (len(d.marker) - 1)
+	if n > 0 {
+		_, err := d.stderr.Write(d.buf[:n])
+		if err != nil {
+			return 0, err
+		}
+
+		d.buf = append([]byte(nil), d.buf[n:]...)
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any output that was held back to stderr, in case the process
+// was killed before the marker was written.
+func (d *stdoutDemuxer) Flush() error {
+	if d.found || len(d.buf) == 0 {
+		return nil
+	}
+
+	_, err := d.stderr.Write(d.buf)
+	d.buf = nil
+	return err
+}
+
+type ExitedProcess struct {
+	id     string
+	Result runtime.ProcessResult
+}
+
+func (p ExitedProcess) ID() string {
+	return p.id
+}
+
+func (p ExitedProcess) Wait(ctx context.Context) (runtime.ProcessResult, error) {
+	return p.Result, nil
+}
+
+func (p ExitedProcess) SetTTY(tty runtime.TTYSpec) error {
+	return nil
+}
+
+// waitForPod polls the pod until done returns true or an error.
+func (worker *Worker) waitForPod(ctx context.Context, name string, done func(*corev1.Pod) (bool, error)) error {
+	for {
+		pod, err := worker.client.CoreV1().Pods(worker.config.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		ok, err := done(pod)
+		if err != nil || ok {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(PodPollingInterval):
+		}
+	}
+}
+
+// mainContainerStatus returns the status of the pod's main container, which
+// is nil until the container has been created. An error is returned if the
+// container will never run.
+func mainContainerStatus(pod *corev1.Pod) (*corev1.ContainerStatus, error) {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return nil, PodFailedError{
+				Pod:     pod.Name,
+				Reason:  fmt.Sprintf("init container '%s' exited with status %d", status.Name, terminated.ExitCode),
+				Message: terminated.Message,
+			}
+		}
+
+		if waiting := status.State.Waiting; waiting != nil && fatalWaitingReasons[waiting.Reason] {
+			return nil, PodFailedError{Pod: pod.Name, Reason: waiting.Reason, Message: waiting.Message}
+		}
+	}
+
+	for i, status := range pod.Status.ContainerStatuses {
+		if status.Name != mainContainerName {
+			continue
+		}
+
+		if waiting := status.State.Waiting; waiting != nil && fatalWaitingReasons[waiting.Reason] {
+			return nil, PodFailedError{Pod: pod.Name, Reason: waiting.Reason, Message: waiting.Message}
+		}
+
+		return &pod.Status.ContainerStatuses[i], nil
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		return nil, PodFailedError{Pod: pod.Name, Reason: pod.Status.Reason, Message: pod.Status.Message}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,424 @@
+package k8sruntime
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/runtime"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const creatingVolumeRetryDelay = 1 * time.Second
+
+// Volume is backed by a persistent volume claim named after the volume's
+// handle.
+type Volume struct {
+	dbVolume db.CreatedVolume
+	worker   *Worker
+}
+
+func (worker *Worker) newVolume(dbVolume db.CreatedVolume) Volume {
+	return Volume{dbVolume: dbVolume, worker: worker}
+}
+
+func (v Volume) Handle() string {
+	return v.dbVolume.Handle()
+}
+
+func (v Volume) Source() string {
+	return v.dbVolume.WorkerName()
+}
+
+func (v Volume) DBVolume() db.CreatedVolume {
+	return v.dbVolume
+}
+
+func (v Volume) StreamOut(ctx context.Context, path string, compression compression.Compression) (io.ReadCloser, error) {
+	return nil, ErrStreamingNotSupported
+}
+
+func (v Volume) StreamIn(ctx context.Context, path string, compression compression.Compression, limitInMB float64, reader io.Reader) error {
+	return ErrStreamingNotSupported
+}
+
+func (v Volume) InitializeResourceCache(ctx context.Context, cache db.ResourceCache) (*db.UsedWorkerResourceCache, error) {
+	uwrc, err := v.dbVolume.InitializeResourceCache(cache)
+	if err != nil {
+		lagerctx.FromContext(ctx).Error("failed-to-initialize-resource-cache", err)
+		return nil, err
+	}
+	return uwrc, nil
+}
+
+func (v Volume) InitializeStreamedResourceCache(ctx context.Context, cache db.ResourceCache, sourceWorkerResourceCacheID int) (*db.UsedWorkerResourceCache, error) {
+	uwrc, err := v.dbVolume.InitializeStreamedResourceCache(cache, sourceWorkerResourceCacheID)
+	if err != nil {
+		lagerctx.FromContext(ctx).Error("failed-to-initialize-resource-cache", err)
+		return nil, err
+	}
+	return uwrc, nil
+}
+
+func (v Volume) InitializeTaskCache(ctx context.Context, jobID int, stepName string, path string, privileged bool) error {
+	logger := lagerctx.FromContext(ctx)
+	path = filepath.Clean(path)
+
+	if v.dbVolume.ParentHandle() == "" {
+		return v.dbVolume.InitializeTaskCache(jobID, stepName, path)
+	}
+
+	logger.Debug("creating-an-import-volume", lager.Data{"volume": v.Handle()})
+	importVolume, err := v.worker.createVolumeForTaskCache(ctx, v, v.dbVolume.TeamID(), jobID, stepName, path)
+	if err != nil {
+		logger.Error("failed-to-create-import-volume", err, lager.Data{"volume": v.Handle()})
+		return err
+	}
+
+	return importVolume.InitializeTaskCache(ctx, jobID, stepName, path, privileged)
+}
+
+var _ runtime.Volume = Volume{}
+
+func (worker *Worker) LookupVolume(ctx context.Context, handle string) (runtime.Volume, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	createdVolume, found, err := worker.db.VolumeRepo.FindVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-db", err)
+		return Volume{}, false, err
+	}
+
+	if !found {
+		return Volume{}, false, nil
+	}
+
+	_, err = worker.client.CoreV1().PersistentVolumeClaims(worker.config.Namespace).Get(ctx, handle, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return Volume{}, false, nil
+		}
+
+		logger.Error("failed-to-get-persistent-volume-claim", err)
+		return Volume{}, false, err
+	}
+
+	return worker.newVolume(createdVolume), true, nil
+}
+
+func (worker *Worker) CreateVolumeForArtifact(ctx context.Context, teamID int) (runtime.Volume, db.WorkerArtifact, error) {
+	logger := lagerctx.FromContext(ctx)
+	creatingVolume, err := worker.db.VolumeRepo.CreateVolume(teamID, worker.Name(), db.VolumeTypeArtifact)
+	if err != nil {
+		logger.Error("failed-to-create-volume-in-db", err)
+		return nil, nil, err
+	}
+
+	workerArtifact, err := creatingVolume.InitializeArtifact()
+	if err != nil {
+		logger.Error("failed-to-initialize-artifact", err)
+		return nil, nil, err
+	}
+
+	err = worker.createPersistentVolumeClaim(ctx, creatingVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-create-persistent-volume-claim", err)
+		return nil, nil, err
+	}
+
+	createdVolume, err := creatingVolume.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-created", err)
+		return nil, nil, err
+	}
+
+	return worker.newVolume(createdVolume), workerArtifact, nil
+}
+
+func (worker *Worker) findOrCreateVolumeForContainer(
+	ctx context.Context,
+	container db.CreatingContainer,
+	teamID int,
+	mountPath string,
+) (Volume, error) {
+	ctx = lagerctx.NewContext(ctx, lagerctx.FromContext(ctx).Session("find-or-create-volume-for-container"))
+	return worker.findOrCreateVolume(
+		ctx,
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return worker.db.VolumeRepo.FindContainerVolume(teamID, worker.Name(), container, mountPath)
+		},
+		func() (db.CreatingVolume, error) {
+			return worker.db.VolumeRepo.CreateContainerVolume(teamID, worker.Name(), container, mountPath)
+		},
+	)
+}
+
+// findOrCreateChildVolume creates an empty volume to which the parent is
+// copied when the container's pod starts. It is recorded as a child of the
+// parent, so that the parent isn't garbage collected in the meantime.
+func (worker *Worker) findOrCreateChildVolume(
+	ctx context.Context,
+	container db.CreatingContainer,
+	parent Volume,
+	teamID int,
+	mountPath string,
+) (Volume, error) {
+	ctx = lagerctx.NewContext(ctx, lagerctx.FromContext(ctx).Session("find-or-create-child-volume"))
+	return worker.findOrCreateVolume(
+		ctx,
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return worker.db.VolumeRepo.FindContainerVolume(teamID, worker.Name(), container, mountPath)
+		},
+		func() (db.CreatingVolume, error) {
+			return parent.dbVolume.CreateChildForContainer(container, mountPath)
+		},
+	)
+}
+
+func (worker *Worker) findVolumeForTaskCache(
+	ctx context.Context,
+	teamID int,
+	jobID int,
+	stepName string,
+	path string,
+) (Volume, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	usedTaskCache, found, err := worker.db.TaskCacheFactory.Find(jobID, stepName, path)
+	if err != nil {
+		logger.Error("failed-to-lookup-task-cache-in-db", err)
+		return Volume{}, false, err
+	}
+	if !found {
+		return Volume{}, false, nil
+	}
+
+	dbVolume, found, err := worker.db.VolumeRepo.FindTaskCacheVolume(teamID, worker.Name(), usedTaskCache)
+	if err != nil {
+		logger.Error("failed-to-lookup-task-cache-volume-in-db", err)
+		return Volume{}, false, err
+	}
+	if !found {
+		return Volume{}, false, nil
+	}
+
+	return worker.newVolume(dbVolume), true, nil
+}
+
+func (worker *Worker) createVolumeForTaskCache(
+	ctx context.Context,
+	importFromVolume Volume,
+	teamID int,
+	jobID int,
+	stepName string,
+	path string,
+) (Volume, error) {
+	logger := lagerctx.FromContext(ctx)
+	usedTaskCache, err := worker.db.TaskCacheFactory.FindOrCreate(jobID, stepName, path)
+	if err != nil {
+		logger.Error("failed-to-find-or-create-task-cache-in-db", err)
+		return Volume{}, err
+	}
+
+	usedWorkerTaskCache, err := worker.db.WorkerTaskCacheFactory.FindOrCreate(db.WorkerTaskCache{
+		WorkerName: worker.Name(),
+		TaskCache:  usedTaskCache,
+	})
+	if err != nil {
+		logger.Error("failed-to-find-or-create-worker-task-cache-in-db", err)
+		return Volume{}, err
+	}
+
+	volume, err := worker.findOrCreateVolume(
+		lagerctx.NewContext(ctx, logger.Session("create-volume-for-task-cache")),
+		func() (db.CreatingVolume, db.CreatedVolume, error) {
+			return nil, nil, nil
+		},
+		func() (db.CreatingVolume, error) {
+			return worker.db.VolumeRepo.CreateTaskCacheVolume(teamID, usedWorkerTaskCache)
+		},
+	)
+	if err != nil {
+		return Volume{}, err
+	}
+
+	_, err = worker.runHelper(ctx, "cache", []Volume{importFromVolume, volume}, []string{
+		"cp", "-a", "/volumes/0/.", "/volumes/1/",
+	})
+	if err != nil {
+		logger.Error("failed-to-copy-task-cache", err)
+		return Volume{}, err
+	}
+
+	return volume, nil
+}
+
+// findVolumeForArtifact finds the volume in the cluster for the given
+// artifact. This is either the artifact itself, or an equivalent resource
+// cache volume.
+func (worker *Worker) findVolumeForArtifact(
+	ctx context.Context,
+	teamID int,
+	artifact runtime.Artifact,
+	volumeShouldBeValidBefore time.Time,
+) (Volume, error) {
+	logger := lagerctx.FromContext(ctx).Session("find-volume-for-artifact", lager.Data{"worker": worker.Name()})
+
+	notInCluster := ArtifactNotInClusterError{
+		Source:     artifact.Source(),
+		WorkerName: worker.Name(),
+	}
+
+	volume, ok := artifact.(runtime.Volume)
+	if !ok {
+		return Volume{}, notInCluster
+	}
+
+	if volume.DBVolume().WorkerName() == worker.Name() {
+		return worker.newVolume(volume.DBVolume()), nil
+	}
+
+	resourceCacheID := volume.DBVolume().GetResourceCacheID()
+	if resourceCacheID == 0 {
+		return Volume{}, notInCluster
+	}
+
+	resourceCache, found, err := worker.db.ResourceCacheFactory.FindResourceCacheByID(resourceCacheID)
+	if err != nil {
+		logger.Error("failed-to-find-resource-cache-by-id", err, lager.Data{"resource-cache": resourceCacheID})
+		return Volume{}, err
+	}
+	if !found {
+		return Volume{}, notInCluster
+	}
+
+	dbCacheVolume, found, err := worker.db.VolumeRepo.FindResourceCacheVolume(worker.Name(), resourceCache, volumeShouldBeValidBefore)
+	if err != nil {
+		logger.Error("failed-to-find-resource-cache-volume", err, lager.Data{"resource-cache": resourceCacheID})
+		return Volume{}, err
+	}
+	if !found {
+		return Volume{}, notInCluster
+	}
+
+	return worker.newVolume(dbCacheVolume), nil
+}
+
+func (worker *Worker) findOrCreateVolume(
+	ctx context.Context,
+	findVolumeFunc func() (db.CreatingVolume, db.CreatedVolume, error),
+	createVolumeFunc func() (db.CreatingVolume, error),
+) (Volume, error) {
+	logger := lagerctx.FromContext(ctx)
+	creatingVolume, createdVolume, err := findVolumeFunc()
+	if err != nil {
+		logger.Error("failed-to-find-volume-in-db", err)
+		return Volume{}, err
+	}
+
+	if createdVolume != nil {
+		logger.Debug("found-created-volume", lager.Data{"volume": createdVolume.Handle()})
+		return worker.newVolume(createdVolume), nil
+	}
+
+	if creatingVolume != nil {
+		logger = logger.WithData(lager.Data{"volume": creatingVolume.Handle()})
+		logger.Debug("found-creating-volume")
+	} else {
+		creatingVolume, err = createVolumeFunc()
+		if err != nil {
+			logger.Error("failed-to-create-volume-in-db", err)
+			return Volume{}, err
+		}
+
+		logger = logger.WithData(lager.Data{"volume": creatingVolume.Handle()})
+		logger.Debug("created-creating-volume")
+	}
+
+	lock, acquired, err := worker.db.LockFactory.Acquire(logger, lock.NewVolumeCreatingLockID(creatingVolume.ID()))
+	if err != nil {
+		logger.Error("failed-to-acquire-volume-creating-lock", err)
+		return Volume{}, err
+	}
+	if !acquired {
+		logger.Debug("lock-already-held", lager.Data{"retry-in": creatingVolumeRetryDelay})
+		time.Sleep(creatingVolumeRetryDelay)
+		return worker.findOrCreateVolume(ctx, findVolumeFunc, createVolumeFunc)
+	}
+	defer lock.Release()
+
+	err = worker.createPersistentVolumeClaim(ctx, creatingVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-create-persistent-volume-claim", err)
+
+		_, failedErr := creatingVolume.Failed()
+		if failedErr != nil {
+			logger.Error("failed-to-mark-volume-as-failed", failedErr)
+		}
+
+		metric.Metrics.FailedVolumes.Inc()
+
+		return Volume{}, err
+	}
+
+	metric.Metrics.VolumesCreated.Inc()
+
+	createdVolume, err = creatingVolume.Created()
+	if err != nil {
+		logger.Error("failed-to-initialize-volume", err)
+		return Volume{}, err
+	}
+
+	logger.Debug("created")
+
+	return worker.newVolume(createdVolume), nil
+}
+
+// createPersistentVolumeClaim creates the claim backing a volume, unless it
+// was already created by an earlier attempt.
+func (worker *Worker) createPersistentVolumeClaim(ctx context.Context, handle string) error {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   handle,
+			Labels: map[string]string{workerLabel: worker.Name()},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{worker.config.VolumeAccessMode},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: worker.config.VolumeSize,
+				},
+			},
+		},
+	}
+
+	if worker.config.StorageClass != "" {
+		claim.Spec.StorageClassName = &worker.config.StorageClass
+	}
+
+	_, err := worker.client.CoreV1().PersistentVolumeClaims(worker.config.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+type byMountPath []runtime.VolumeMount
+
+func (p byMountPath) Len() int {
+	return len(p)
+}
+func (p byMountPath) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+func (p byMountPath) Less(i, j int) bool {
+	return p[i].MountPath < p[j].MountPath
+}
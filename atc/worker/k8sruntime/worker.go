@@ -0,0 +1,649 @@
+package k8sruntime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/runtime"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	workerLabel    = "concourse-ci.org/worker"
+	containerLabel = "concourse-ci.org/container"
+	processLabel   = "concourse-ci.org/process"
+	hermeticLabel  = "concourse-ci.org/hermetic"
+
+	mainContainerName = "main"
+)
+
+// Config defines how containers and volumes are created in the cluster.
+type Config struct {
+	// Namespace is the namespace in which pods, persistent volume claims and
+	// the records of containers are created.
+	Namespace string
+
+	// StorageClass is the storage class of the persistent volume claims that
+	// back volumes. The cluster's default storage class is used if empty.
+	StorageClass string
+	// VolumeSize is the storage requested by each persistent volume claim.
+	VolumeSize resource.Quantity
+	// VolumeAccessMode is the access mode of each persistent volume claim. As
+	// volumes are mounted by the pods of multiple steps, which may be
+	// scheduled on different nodes, ReadWriteMany is preferable when the
+	// storage class supports it.
+	VolumeAccessMode corev1.PersistentVolumeAccessMode
+
+	// HelperImage is the image used to copy volumes and to read image
+	// references from image artifacts. It must provide sh, cat and cp.
+	HelperImage string
+}
+
+type DB struct {
+	VolumeRepo             db.VolumeRepository
+	TaskCacheFactory       db.TaskCacheFactory
+	WorkerTaskCacheFactory db.WorkerTaskCacheFactory
+	ResourceCacheFactory   db.ResourceCacheFactory
+	LockFactory            lock.LockFactory
+}
+
+// Worker is a runtime.Worker that runs containers as pods in a Kubernetes
+// cluster, which is registered as a single, virtual worker (see Beacon).
+//
+// A container is recorded as a config map when it is created, and each
+// process that is run in it is a pod of its own, with the process as the
+// pod's main container. As such, a container can only run one process at a
+// time, and there is no way to hijack a running process.
+//
+// Volumes are persistent volume claims. Rather than being copied on write,
+// inputs and caches are copied into the container's own volumes by init
+// containers. Artifacts cannot be streamed in or out of the cluster, so all
+// of the inputs of a step that runs in the cluster must come from steps that
+// ran in the cluster too.
+type Worker struct {
+	dbWorker db.Worker
+	client   kubernetes.Interface
+	config   Config
+
+	db DB
+}
+
+func NewWorker(dbWorker db.Worker, client kubernetes.Interface, config Config, db DB) *Worker {
+	return &Worker{
+		dbWorker: dbWorker,
+		client:   client,
+		config:   config,
+
+		db: db,
+	}
+}
+
+func (worker *Worker) Name() string {
+	return worker.dbWorker.Name()
+}
+
+func (worker *Worker) DBWorker() db.Worker {
+	return worker.dbWorker
+}
+
+func (worker *Worker) FindOrCreateContainer(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec runtime.ContainerSpec,
+	delegate runtime.BuildStepDelegate,
+) (runtime.Container, []runtime.VolumeMount, error) {
+	c, mounts, err := worker.findOrCreateContainer(ctx, owner, metadata, containerSpec, delegate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find or create container on worker %s: %w", worker.Name(), err)
+	}
+	return c, mounts, err
+}
+
+func (worker *Worker) findOrCreateContainer(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec runtime.ContainerSpec,
+	delegate runtime.BuildStepDelegate,
+) (*Container, []runtime.VolumeMount, error) {
+	logger := lagerctx.FromContext(ctx)
+	creatingContainer, createdContainer, err := worker.dbWorker.FindContainer(owner)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find in db: %w", err)
+	}
+
+	var containerHandle string
+	if creatingContainer != nil {
+		containerHandle = creatingContainer.Handle()
+	} else if createdContainer != nil {
+		containerHandle = createdContainer.Handle()
+	} else {
+		logger.Debug("creating-container-in-db")
+		creatingContainer, err = worker.dbWorker.CreateContainer(owner, metadata)
+		if err != nil {
+			logger.Error("failed-to-create-container-in-db", err)
+			return nil, nil, fmt.Errorf("create container: %w", err)
+		}
+		logger.Debug("created-creating-container-in-db")
+		containerHandle = creatingContainer.Handle()
+	}
+
+	logger = logger.WithData(lager.Data{"container": containerHandle})
+
+	record, found, err := worker.findContainerRecord(ctx, containerHandle)
+	if err != nil {
+		logger.Error("failed-to-find-container-record", err)
+		return nil, nil, err
+	}
+
+	if createdContainer != nil {
+		logger.Debug("found-created-container-in-db")
+
+		if !found {
+			return nil, nil, ContainerRecordNotFoundError{Handle: containerHandle}
+		}
+
+		return worker.constructContainer(ctx, createdContainer, record)
+	}
+
+	if !found {
+		record, err = worker.createContainerRecord(ctx, containerSpec, creatingContainer, delegate)
+		if err != nil {
+			logger.Error("failed-to-create-container-record", err)
+			markContainerAsFailed(logger, creatingContainer)
+			return nil, nil, err
+		}
+	}
+
+	createdContainer, err = creatingContainer.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-created", err)
+		return nil, nil, err
+	}
+
+	logger.Debug("created-container-in-db")
+	metric.Metrics.ContainersCreated.Inc()
+
+	return worker.constructContainer(ctx, createdContainer, record)
+}
+
+func (worker *Worker) LookupContainer(ctx context.Context, handle string) (runtime.Container, bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("lookup-container", lager.Data{"handle": handle, "worker": worker.Name()})
+
+	_, createdContainer, err := worker.dbWorker.FindContainer(db.NewFixedHandleContainerOwner(handle))
+	if err != nil {
+		logger.Error("failed-to-lookup-container-in-db", err)
+		return nil, false, err
+	}
+
+	if createdContainer == nil {
+		return nil, false, nil
+	}
+
+	record, found, err := worker.findContainerRecord(ctx, handle)
+	if err != nil {
+		logger.Error("failed-to-find-container-record", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return worker.newContainer(createdContainer, record), true, nil
+}
+
+func (worker *Worker) constructContainer(
+	ctx context.Context,
+	createdContainer db.CreatedContainer,
+	record *corev1.ConfigMap,
+) (*Container, []runtime.VolumeMount, error) {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"container": createdContainer.Handle(),
+		"worker":    worker.Name(),
+	})
+
+	createdVolumes, err := worker.db.VolumeRepo.FindVolumesForContainer(createdContainer)
+	if err != nil {
+		logger.Error("failed-to-find-container-volumes", err)
+		return nil, nil, err
+	}
+
+	var volumeMounts []runtime.VolumeMount
+	for _, dbVolume := range createdVolumes {
+		volumeMounts = append(volumeMounts, runtime.VolumeMount{
+			Volume:    worker.newVolume(dbVolume),
+			MountPath: dbVolume.Path(),
+		})
+	}
+
+	sort.Sort(byMountPath(volumeMounts))
+
+	return worker.newContainer(createdContainer, record), volumeMounts, nil
+}
+
+// createContainerRecord creates the volumes of the container, and records
+// the template of the pods that will run its processes.
+func (worker *Worker) createContainerRecord(
+	ctx context.Context,
+	containerSpec runtime.ContainerSpec,
+	creatingContainer db.CreatingContainer,
+	delegate runtime.BuildStepDelegate,
+) (*corev1.ConfigMap, error) {
+	image, privileged, err := worker.imageForContainer(ctx, containerSpec.ImageSpec, containerSpec.TeamID, delegate)
+	if err != nil {
+		return nil, fmt.Errorf("image: %w", err)
+	}
+
+	podSpec, err := worker.podSpec(ctx, containerSpec, creatingContainer, delegate, image, privileged)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(podSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := worker.labels(creatingContainer.Handle())
+	if containerSpec.Hermetic {
+		labels[hermeticLabel] = "true"
+	}
+
+	record, err := worker.client.CoreV1().ConfigMaps(worker.config.Namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   creatingContainer.Handle(),
+			Labels: labels,
+		},
+		Data: map[string]string{
+			podSpecKey:    string(payload),
+			typeKey:       string(containerSpec.Type),
+			propertiesKey: "{}",
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create container record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (worker *Worker) findContainerRecord(ctx context.Context, handle string) (*corev1.ConfigMap, bool, error) {
+	record, err := worker.client.CoreV1().ConfigMaps(worker.config.Namespace).Get(ctx, handle, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return record, true, nil
+}
+
+// podSpec creates the volumes required to run the container, and returns the
+// spec of the pods that will run its processes:
+//   - scratch and working dir (emptyDir volumes)
+//   - inputs (copied from the artifact's volume by an init container)
+//   - outputs (empty volumes)
+//   - caches (copied if they exist, empty volumes otherwise)
+func (worker *Worker) podSpec(
+	ctx context.Context,
+	spec runtime.ContainerSpec,
+	container db.CreatingContainer,
+	delegate runtime.BuildStepDelegate,
+	image string,
+	privileged bool,
+) (corev1.PodSpec, error) {
+	var (
+		mounts []runtime.VolumeMount
+		copies []volumeCopy
+	)
+
+	inputPaths := map[string]bool{}
+	for _, input := range spec.Inputs {
+		mountPath := absPath(spec.Dir, input.DestinationPath)
+		inputPaths[mountPath] = true
+
+		src, err := worker.findVolumeForArtifact(ctx, spec.TeamID, input.Artifact, delegate.BuildStartTime())
+		if err != nil {
+			return corev1.PodSpec{}, err
+		}
+
+		dst, err := worker.findOrCreateChildVolume(ctx, container, src, spec.TeamID, mountPath)
+		if err != nil {
+			return corev1.PodSpec{}, err
+		}
+
+		mounts = append(mounts, runtime.VolumeMount{Volume: dst, MountPath: mountPath})
+		copies = append(copies, volumeCopy{src: src, dst: dst})
+	}
+
+	for _, outputPath := range spec.Outputs {
+		mountPath := absPath(spec.Dir, outputPath)
+
+		// reuse volume if output path is the same as input
+		if inputPaths[mountPath] {
+			continue
+		}
+
+		volume, err := worker.findOrCreateVolumeForContainer(ctx, container, spec.TeamID, mountPath)
+		if err != nil {
+			return corev1.PodSpec{}, err
+		}
+
+		mounts = append(mounts, runtime.VolumeMount{Volume: volume, MountPath: mountPath})
+	}
+
+	for _, cachePath := range spec.Caches {
+		mountPath := absPath(spec.Dir, cachePath)
+
+		cache, found, err := worker.findVolumeForTaskCache(ctx, spec.TeamID, spec.JobID, spec.StepName, filepath.Clean(cachePath))
+		if err != nil {
+			return corev1.PodSpec{}, err
+		}
+
+		var volume Volume
+		if found {
+			volume, err = worker.findOrCreateChildVolume(ctx, container, cache, spec.TeamID, mountPath)
+			if err != nil {
+				return corev1.PodSpec{}, err
+			}
+
+			copies = append(copies, volumeCopy{src: cache, dst: volume})
+		} else {
+			volume, err = worker.findOrCreateVolumeForContainer(ctx, container, spec.TeamID, mountPath)
+			if err != nil {
+				return corev1.PodSpec{}, err
+			}
+		}
+
+		mounts = append(mounts, runtime.VolumeMount{Volume: volume, MountPath: mountPath})
+	}
+
+	sort.Sort(byMountPath(mounts))
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:                corev1.RestartPolicyNever,
+		AutomountServiceAccountToken: boolPtr(false),
+		Volumes: []corev1.Volume{
+			emptyDirVolume(scratchVolumeName),
+			emptyDirVolume(ioVolumeName),
+		},
+	}
+
+	main := corev1.Container{
+		Name:       mainContainerName,
+		Image:      image,
+		WorkingDir: spec.Dir,
+		Env:        toEnvVars(worker.containerEnv(spec)),
+		Resources:  toResourceRequirements(spec.Limits),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: boolPtr(privileged),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: scratchVolumeName, MountPath: "/scratch"},
+			{Name: ioVolumeName, MountPath: ioDir},
+		},
+	}
+
+	if spec.Dir != "" && !anyMountTo(spec.Dir, mounts) {
+		podSpec.Volumes = append(podSpec.Volumes, emptyDirVolume(workDirVolumeName))
+		main.VolumeMounts = append(main.VolumeMounts, corev1.VolumeMount{
+			Name:      workDirVolumeName,
+			MountPath: spec.Dir,
+		})
+	}
+
+	for _, mount := range mounts {
+		podSpec.Volumes = append(podSpec.Volumes, pvcVolume(mount.Volume.Handle()))
+		main.VolumeMounts = append(main.VolumeMounts, corev1.VolumeMount{
+			Name:      podVolumeName(mount.Volume.Handle()),
+			MountPath: mount.MountPath,
+		})
+	}
+
+	for i, c := range copies {
+		podSpec.Volumes = append(podSpec.Volumes, pvcVolume(c.src.Handle()))
+		podSpec.InitContainers = append(podSpec.InitContainers, worker.copyContainer(fmt.Sprintf("copy-%d", i), c))
+	}
+
+	podSpec.Containers = []corev1.Container{main}
+
+	return podSpec, nil
+}
+
+func (worker *Worker) containerEnv(spec runtime.ContainerSpec) []string {
+	env := spec.Env
+
+	if worker.dbWorker.HTTPProxyURL() != "" {
+		env = append(env, fmt.Sprintf("http_proxy=%s", worker.dbWorker.HTTPProxyURL()))
+	}
+
+	if worker.dbWorker.HTTPSProxyURL() != "" {
+		env = append(env, fmt.Sprintf("https_proxy=%s", worker.dbWorker.HTTPSProxyURL()))
+	}
+
+	if worker.dbWorker.NoProxy() != "" {
+		env = append(env, fmt.Sprintf("no_proxy=%s", worker.dbWorker.NoProxy()))
+	}
+
+	return env
+}
+
+// imageForContainer resolves the image reference of a container. Images are
+// pulled by the cluster, so rather than being a rootfs, an image artifact
+// must be the output of a registry-image get, from which the repository and
+// digest are read.
+func (worker *Worker) imageForContainer(
+	ctx context.Context,
+	imageSpec runtime.ImageSpec,
+	teamID int,
+	delegate runtime.BuildStepDelegate,
+) (string, bool, error) {
+	if imageSpec.ImageArtifact != nil {
+		volume, err := worker.findVolumeForArtifact(ctx, teamID, imageSpec.ImageArtifact, delegate.BuildStartTime())
+		if err != nil {
+			return "", false, err
+		}
+
+		output, err := worker.runHelper(ctx, "image", []Volume{volume}, []string{
+			"sh", "-c", `printf '%s@%s' "$(cat /volumes/0/repository)" "$(cat /volumes/0/digest)"`,
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("read image reference: %w", err)
+		}
+
+		return string(output), imageSpec.Privileged, nil
+	}
+
+	if imageSpec.ResourceType != "" {
+		for _, resourceType := range worker.dbWorker.ResourceTypes() {
+			if resourceType.Type == imageSpec.ResourceType {
+				return resourceType.Image, resourceType.Privileged, nil
+			}
+		}
+
+		return "", false, ErrUnsupportedResourceType
+	}
+
+	if imageSpec.ImageURL != "" {
+		ref, ok := strings.CutPrefix(imageSpec.ImageURL, "docker://")
+		if !ok {
+			return "", false, UnsupportedImageURLError{URL: imageSpec.ImageURL}
+		}
+
+		return strings.Replace(strings.TrimPrefix(ref, "/"), "#", ":", 1), imageSpec.Privileged, nil
+	}
+
+	return "", false, ErrNoImage
+}
+
+func (worker *Worker) labels(handle string) map[string]string {
+	return map[string]string{
+		workerLabel:    worker.Name(),
+		containerLabel: handle,
+	}
+}
+
+type volumeCopy struct {
+	src Volume
+	dst Volume
+}
+
+func (worker *Worker) copyContainer(name string, c volumeCopy) corev1.Container {
+	return corev1.Container{
+		Name:    name,
+		Image:   worker.config.HelperImage,
+		Command: []string{"cp", "-a", "/src/.", "/dst/"},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: podVolumeName(c.src.Handle()), MountPath: "/src", ReadOnly: true},
+			{Name: podVolumeName(c.dst.Handle()), MountPath: "/dst"},
+		},
+	}
+}
+
+const (
+	scratchVolumeName = "scratch"
+	workDirVolumeName = "workdir"
+	ioVolumeName      = "concourse-io"
+
+	ioDir = "/tmp/concourse-io"
+)
+
+func emptyDirVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func pvcVolume(handle string) corev1.Volume {
+	return corev1.Volume{
+		Name: podVolumeName(handle),
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: handle,
+			},
+		},
+	}
+}
+
+func podVolumeName(handle string) string {
+	return "v-" + handle
+}
+
+func absPath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(dir, path)
+}
+
+func anyMountTo(path string, volumeMounts []runtime.VolumeMount) bool {
+	for _, mnt := range volumeMounts {
+		if filepath.Clean(mnt.MountPath) == filepath.Clean(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toEnvVars converts NAME=VALUE pairs to environment variables, with later
+// pairs taking precedence over earlier ones with the same name.
+func toEnvVars(env []string) []corev1.EnvVar {
+	var vars []corev1.EnvVar
+	indices := map[string]int{}
+	for _, e := range env {
+		name, value, _ := strings.Cut(e, "=")
+
+		if i, found := indices[name]; found {
+			vars[i].Value = value
+			continue
+		}
+
+		indices[name] = len(vars)
+		vars = append(vars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return vars
+}
+
+// toResourceRequirements converts CPU shares, of which there are 1024 per
+// core, to millicores.
+func toResourceRequirements(limits runtime.ContainerLimits) corev1.ResourceRequirements {
+	resources := corev1.ResourceList{}
+
+	if limits.CPU != nil && *limits.CPU > 0 {
+		resources[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(*limits.CPU*1000/1024), resource.DecimalSI)
+	}
+
+	if limits.Memory != nil && *limits.Memory > 0 {
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(*limits.Memory), resource.BinarySI)
+	}
+
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}
+	}
+
+	return corev1.ResourceRequirements{Limits: resources}
+}
+
+// toSecurityContext runs the process as the given user if it is numeric (or
+// root). Otherwise, the user of the image is used, as a user name can't be
+// resolved without the image's /etc/passwd.
+func toSecurityContext(sc *corev1.SecurityContext, user string) *corev1.SecurityContext {
+	if user == "root" {
+		user = "0"
+	}
+
+	uid, err := strconv.ParseInt(user, 10, 64)
+	if err != nil {
+		return sc
+	}
+
+	sc = sc.DeepCopy()
+	sc.RunAsUser = &uid
+	return sc
+}
+
+func markContainerAsFailed(logger lager.Logger, container db.CreatingContainer) {
+	_, err := container.Failed()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-failed", err)
+	}
+	metric.Metrics.FailedContainers.Inc()
+}
+
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+var _ runtime.Worker = (*Worker)(nil)
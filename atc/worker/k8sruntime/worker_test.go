@@ -0,0 +1,396 @@
+package k8sruntime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const namespace = "some-namespace"
+
+var _ = Describe("Worker", func() {
+	var (
+		ctx context.Context
+
+		client             *fake.Clientset
+		fakeDBWorker       *dbfakes.FakeWorker
+		fakeVolumeRepo     *dbfakes.FakeVolumeRepository
+		fakeLockFactory    *lockfakes.FakeLockFactory
+		fakeDelegate       *execfakes.FakeBuildStepDelegate
+		fakeCreatingCont   *dbfakes.FakeCreatingContainer
+		fakeCreatedCont    *dbfakes.FakeCreatedContainer
+		fakeCreatingVolume *dbfakes.FakeCreatingVolume
+		fakeCreatedVolume  *dbfakes.FakeCreatedVolume
+
+		worker *k8sruntime.Worker
+
+		containerSpec runtime.ContainerSpec
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		client = fake.NewSimpleClientset()
+
+		fakeDBWorker = new(dbfakes.FakeWorker)
+		fakeDBWorker.NameReturns("k8s")
+		fakeDBWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git", Image: "concourse/git-resource:latest"},
+		})
+
+		fakeCreatedCont = new(dbfakes.FakeCreatedContainer)
+		fakeCreatedCont.HandleReturns("some-handle")
+
+		fakeCreatingCont = new(dbfakes.FakeCreatingContainer)
+		fakeCreatingCont.HandleReturns("some-handle")
+		fakeCreatingCont.CreatedReturns(fakeCreatedCont, nil)
+		fakeDBWorker.CreateContainerReturns(fakeCreatingCont, nil)
+
+		fakeCreatedVolume = new(dbfakes.FakeCreatedVolume)
+		fakeCreatedVolume.HandleReturns("output-handle")
+		fakeCreatedVolume.WorkerNameReturns("k8s")
+
+		fakeCreatingVolume = new(dbfakes.FakeCreatingVolume)
+		fakeCreatingVolume.IDReturns(1)
+		fakeCreatingVolume.HandleReturns("output-handle")
+		fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+
+		fakeVolumeRepo = new(dbfakes.FakeVolumeRepository)
+		fakeVolumeRepo.CreateContainerVolumeReturns(fakeCreatingVolume, nil)
+
+		fakeLockFactory = new(lockfakes.FakeLockFactory)
+		fakeLockFactory.AcquireReturns(new(lockfakes.FakeLock), true, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+
+		worker = k8sruntime.NewWorker(fakeDBWorker, client, k8sruntime.Config{
+			Namespace:        namespace,
+			VolumeSize:       resource.MustParse("1Gi"),
+			VolumeAccessMode: corev1.ReadWriteOnce,
+			HelperImage:      "busybox",
+		}, k8sruntime.DB{
+			VolumeRepo:  fakeVolumeRepo,
+			LockFactory: fakeLockFactory,
+		})
+
+		containerSpec = runtime.ContainerSpec{
+			TeamID: 1,
+			Type:   db.ContainerTypeTask,
+			Dir:    "/workdir",
+			Env:    []string{"FOO=foo", "BAR=bar", "FOO=overridden"},
+			ImageSpec: runtime.ImageSpec{
+				ImageURL: "docker:///busybox#1.36",
+			},
+			Outputs: runtime.OutputPaths{"out": "out"},
+		}
+	})
+
+	findOrCreateContainer := func() (runtime.Container, error) {
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("some-handle"),
+			db.ContainerMetadata{},
+			containerSpec,
+			fakeDelegate,
+		)
+		return container, err
+	}
+
+	recordedPodSpec := func() corev1.PodSpec {
+		record, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, "some-handle", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var podSpec corev1.PodSpec
+		Expect(json.Unmarshal([]byte(record.Data["pod-spec"]), &podSpec)).To(Succeed())
+		return podSpec
+	}
+
+	onlyPod := func() corev1.Pod {
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pods.Items).To(HaveLen(1))
+		return pods.Items[0]
+	}
+
+	setMainContainerState := func(name string, state corev1.ContainerState) {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "main", State: state}}
+		if state.Terminated != nil {
+			pod.Status.Phase = corev1.PodSucceeded
+		}
+
+		_, err = client.CoreV1().Pods(namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Describe("FindOrCreateContainer", func() {
+		It("records the spec of the pods that will run the container's processes", func() {
+			_, err := findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+
+			podSpec := recordedPodSpec()
+			Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(podSpec.Containers).To(HaveLen(1))
+
+			main := podSpec.Containers[0]
+			Expect(main.Image).To(Equal("busybox:1.36"))
+			Expect(main.WorkingDir).To(Equal("/workdir"))
+			Expect(main.Env).To(Equal([]corev1.EnvVar{
+				{Name: "FOO", Value: "overridden"},
+				{Name: "BAR", Value: "bar"},
+			}))
+			Expect(main.VolumeMounts).To(ContainElements(
+				corev1.VolumeMount{Name: "workdir", MountPath: "/workdir"},
+				corev1.VolumeMount{Name: "v-output-handle", MountPath: "/workdir/out"},
+			))
+
+			Expect(fakeCreatingCont.CreatedCallCount()).To(Equal(1))
+		})
+
+		It("creates a persistent volume claim for each output", func() {
+			_, err := findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+
+			claim, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, "output-handle", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claim.Labels).To(HaveKeyWithValue("concourse-ci.org/worker", "k8s"))
+			Expect(claim.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+			Expect(claim.Spec.Resources.Requests.Storage().String()).To(Equal("1Gi"))
+
+			_, _, _, mountPath := fakeVolumeRepo.CreateContainerVolumeArgsForCall(0)
+			Expect(mountPath).To(Equal("/workdir/out"))
+			Expect(fakeCreatingVolume.CreatedCallCount()).To(Equal(1))
+		})
+
+		It("runs resource types with the image the worker provides", func() {
+			containerSpec.ImageSpec = runtime.ImageSpec{ResourceType: "git"}
+
+			_, err := findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(recordedPodSpec().Containers[0].Image).To(Equal("concourse/git-resource:latest"))
+		})
+
+		It("fails for images that can't be pulled by the cluster", func() {
+			containerSpec.ImageSpec = runtime.ImageSpec{ImageURL: "raw:///img/rootfs"}
+
+			_, err := findOrCreateContainer()
+			Expect(errors.As(err, &k8sruntime.UnsupportedImageURLError{})).To(BeTrue())
+
+			Expect(fakeCreatingCont.FailedCallCount()).To(Equal(1))
+		})
+
+		It("fails for inputs that are on other workers", func() {
+			input := runtimetest.NewVolume("input")
+			input.DBVolume_.WorkerNameReturns("garden-worker")
+
+			containerSpec.Inputs = []runtime.Input{{Artifact: input, DestinationPath: "/workdir/in"}}
+
+			_, err := findOrCreateContainer()
+			Expect(errors.As(err, &k8sruntime.ArtifactNotInClusterError{})).To(BeTrue())
+		})
+
+		It("finds the container again once it has been created", func() {
+			_, err := findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeDBWorker.FindContainerReturns(nil, fakeCreatedCont, nil)
+
+			_, err = findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeDBWorker.CreateContainerCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("running a process", func() {
+		var container runtime.Container
+
+		BeforeEach(func() {
+			var err error
+			container, err = findOrCreateContainer()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("runs the process in a pod and waits for it to exit", func() {
+			stdout := new(bytes.Buffer)
+			process, err := container.Run(ctx, runtime.ProcessSpec{
+				ID:   "task",
+				Path: "/bin/sh",
+				Args: []string{"-c", "exit 3"},
+				Env:  []string{"BAR=baz"},
+				User: "root",
+			}, runtime.ProcessIO{Stdout: stdout})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("task"))
+
+			pod := onlyPod()
+			Expect(pod.Labels).To(HaveKeyWithValue("concourse-ci.org/container", "some-handle"))
+
+			main := pod.Spec.Containers[0]
+			Expect(main.Command).To(Equal([]string{"/bin/sh", "-c", "exit 3"}))
+			Expect(main.Env).To(ContainElement(corev1.EnvVar{Name: "BAR", Value: "baz"}))
+			Expect(*main.SecurityContext.RunAsUser).To(BeZero())
+
+			setMainContainerState(pod.Name, corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 3},
+			})
+
+			result, err := process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(3))
+			Expect(stdout.String()).To(Equal("fake logs"))
+
+			By("attaching to the process after it has exited", func() {
+				process, err := container.Attach(ctx, "task", runtime.ProcessIO{})
+				Expect(err).ToNot(HaveOccurred())
+
+				result, err := process.Wait(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ExitStatus).To(Equal(3))
+			})
+		})
+
+		It("does not run a second process while one is running", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{Path: "sleep"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = container.Run(ctx, runtime.ProcessSpec{Path: "sleep"}, runtime.ProcessIO{})
+			Expect(err).To(Equal(k8sruntime.ProcessAlreadyRunningError{Handle: "some-handle"}))
+		})
+
+		It("replaces the pods of processes that have exited", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{Path: "true"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			first := onlyPod()
+			setMainContainerState(first.Name, corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			})
+
+			_, err = container.Run(ctx, runtime.ProcessSpec{Path: "true"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(onlyPod().Name).ToNot(Equal(first.Name))
+		})
+
+		It("attaches to a running process", func() {
+			_, err := container.Run(ctx, runtime.ProcessSpec{ID: "task", Path: "sleep"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			process, err := container.Attach(ctx, "task", runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("task"))
+
+			_, err = container.Attach(ctx, "other", runtime.ProcessIO{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails if the image can't be pulled", func() {
+			process, err := container.Run(ctx, runtime.ProcessSpec{Path: "true"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			setMainContainerState(onlyPod().Name, corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+			})
+
+			_, err = process.Wait(ctx)
+			Expect(errors.As(err, &k8sruntime.PodFailedError{})).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("ImagePullBackOff: not found")))
+		})
+
+		It("deletes the pod when the context is cancelled", func() {
+			process, err := container.Run(ctx, runtime.ProcessSpec{Path: "sleep"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			_, err = process.Wait(waitCtx)
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+
+		Context("when the container is for a resource", func() {
+			BeforeEach(func() {
+				containerSpec.Type = db.ContainerTypeGet
+				fakeCreatingCont.HandleReturns("resource-handle")
+				fakeCreatedCont.HandleReturns("resource-handle")
+
+				var err error
+				container, _, err = worker.FindOrCreateContainer(
+					ctx,
+					db.NewFixedHandleContainerOwner("resource-handle"),
+					db.ContainerMetadata{},
+					containerSpec,
+					fakeDelegate,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("passes stdin through a secret, and keeps stdout apart from stderr", func() {
+				stdout := new(bytes.Buffer)
+				stderr := new(bytes.Buffer)
+				process, err := container.Run(ctx, runtime.ProcessSpec{
+					Path: "/opt/resource/in",
+					Args: []string{"/tmp/build/get"},
+				}, runtime.ProcessIO{
+					Stdin:  strings.NewReader(`{"source":{}}`),
+					Stdout: stdout,
+					Stderr: stderr,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+					LabelSelector: "concourse-ci.org/container=resource-handle",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pods.Items).To(HaveLen(1))
+				pod := pods.Items[0]
+
+				secret, err := client.CoreV1().Secrets(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(secret.Data["stdin"])).To(Equal(`{"source":{}}`))
+
+				main := pod.Spec.Containers[0]
+				Expect(main.Command[:2]).To(Equal([]string{"sh", "-c"}))
+				Expect(main.Command[2]).To(ContainSubstring("</tmp/concourse-stdin/stdin"))
+				Expect(main.Command[2]).To(ContainSubstring(pod.Annotations["concourse-ci.org/stdout-marker"]))
+				Expect(main.Command[3:]).To(Equal([]string{"/opt/resource/in", "/tmp/build/get"}))
+
+				setMainContainerState(pod.Name, corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+				})
+
+				_, err = process.Wait(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				// the fake clientset's logs never contain the marker
+				Expect(stderr.String()).To(Equal("fake logs"))
+				Expect(stdout.String()).To(BeEmpty())
+			})
+		})
+	})
+})
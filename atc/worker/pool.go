@@ -110,7 +110,7 @@ func (pool Pool) FindOrSelectWorker(
 }
 
 func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy) (db.Worker, error) {
-	worker, compatibleWorkers, found, err := pool.findWorkerForContainer(logger, owner, workerSpec)
+	worker, compatibleWorkers, found, err := pool.findWorkerForContainer(logger, owner, workerSpec, containerArtifacts(containerSpec))
 	if err != nil {
 		return nil, err
 	}
@@ -215,8 +215,66 @@ func (pool Pool) findResourceCacheVolumeOnWorker(ctx context.Context, dbWorker d
 	return worker.LookupVolume(ctx, volume.Handle())
 }
 
+// FindTaskCacheVolume finds a volume for the task cache on any compatible
+// worker that has one.
+func (pool Pool) FindTaskCacheVolume(ctx context.Context, teamID int, jobID int, stepName string, path string, workerSpec Spec) (runtime.Volume, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	usedTaskCache, found, err := pool.db.TaskCacheFactory.Find(jobID, stepName, path)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	workerNames, err := pool.db.VolumeRepo.FindWorkersForTaskCache(usedTaskCache)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rand.Shuffle(len(workerNames), func(i, j int) {
+		workerNames[i], workerNames[j] = workerNames[j], workerNames[i]
+	})
+
+	for _, workerName := range workerNames {
+		dbWorker, found, err := pool.db.WorkerFactory.GetWorker(workerName)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found || !pool.isWorkerCompatibleAndRunning(logger, dbWorker, workerSpec) {
+			continue
+		}
+
+		dbVolume, found, err := pool.db.VolumeRepo.FindTaskCacheVolume(teamID, workerName, usedTaskCache)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			continue
+		}
+
+		worker := pool.factory.NewWorker(logger, dbWorker)
+		volume, found, err := worker.LookupVolume(ctx, dbVolume.Handle())
+		if err != nil {
+			logger.Debug("ignore-lookup-task-cache-volume-error", lager.Data{
+				"error":  err,
+				"worker": workerName,
+				"path":   path,
+			})
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		return volume, true, nil
+	}
+
+	return nil, false, nil
+}
+
 func (pool Pool) FindWorkerForContainer(logger lager.Logger, owner db.ContainerOwner, workerSpec Spec) (runtime.Worker, bool, error) {
-	worker, _, found, err := pool.findWorkerForContainer(logger, owner, workerSpec)
+	worker, _, found, err := pool.findWorkerForContainer(logger, owner, workerSpec, nil)
 	if err != nil {
 		return nil, false, err
 	}
@@ -226,7 +284,7 @@ func (pool Pool) FindWorkerForContainer(logger lager.Logger, owner db.ContainerO
 	return pool.factory.NewWorker(logger, worker), true, nil
 }
 
-func (pool Pool) findWorkerForContainer(logger lager.Logger, owner db.ContainerOwner, workerSpec Spec) (db.Worker, []db.Worker, bool, error) {
+func (pool Pool) findWorkerForContainer(logger lager.Logger, owner db.ContainerOwner, workerSpec Spec, artifacts []runtime.Artifact) (db.Worker, []db.Worker, bool, error) {
 	workersWithContainer, err := pool.db.WorkerFactory.FindWorkersForContainerByOwner(owner)
 	if err != nil {
 		return nil, nil, false, err
@@ -243,7 +301,7 @@ func (pool Pool) findWorkerForContainer(logger lager.Logger, owner db.ContainerO
 		}
 	}
 
-	compatibleWorkers, err := pool.allCompatibleAndRunningWorkers(logger, workerSpec)
+	compatibleWorkers, err := pool.allCompatibleAndRunningWorkers(logger, workerSpec, artifacts)
 	if err != nil {
 		return nil, nil, false, err
 	}
@@ -344,16 +402,40 @@ func (pool Pool) LocateContainer(ctx context.Context, teamID int, handle string)
 
 func (pool Pool) CreateVolumeForArtifact(ctx context.Context, spec Spec) (runtime.Volume, db.WorkerArtifact, error) {
 	logger := lagerctx.FromContext(ctx)
-	compatibleWorkers, err := pool.allCompatibleAndRunningWorkers(logger, spec)
+	compatibleWorkers, err := pool.allCompatibleAndRunningWorkers(logger, spec, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	worker := pool.factory.NewWorker(logger, compatibleWorkers[rand.Intn(len(compatibleWorkers))])
+	// the artifact is streamed in once it has been created
+	var streamingWorkers []db.Worker
+	for _, worker := range compatibleWorkers {
+		if pool.factory.CanStream(worker) {
+			streamingWorkers = append(streamingWorkers, worker)
+		}
+	}
+
+	if len(streamingWorkers) == 0 {
+		return nil, nil, NoCompatibleWorkersError{
+			Spec:          spec,
+			WorkerVersion: pool.workerVersion,
+		}
+	}
+
+	worker := pool.factory.NewWorker(logger, streamingWorkers[rand.Intn(len(streamingWorkers))])
 	return worker.CreateVolumeForArtifact(ctx, spec.TeamID)
 }
 
-func (pool Pool) allCompatibleAndRunningWorkers(logger lager.Logger, spec Spec) ([]db.Worker, error) {
+// CompatibleWorkers returns the running workers that a container with the
+// given spec could be placed on. The placement strategy picks one of them.
+func (pool Pool) CompatibleWorkers(ctx context.Context, spec Spec) ([]db.Worker, error) {
+	return pool.allCompatibleAndRunningWorkers(lagerctx.FromContext(ctx), spec, nil)
+}
+
+// allCompatibleAndRunningWorkers leaves out workers which can't stream the
+// given artifacts in, unless the step is tagged to run on them and they
+// already have every artifact.
+func (pool Pool) allCompatibleAndRunningWorkers(logger lager.Logger, spec Spec, artifacts []runtime.Artifact) ([]db.Worker, error) {
 	workers, err := pool.db.WorkerFactory.Workers()
 	if err != nil {
 		return nil, err
@@ -366,7 +448,7 @@ func (pool Pool) allCompatibleAndRunningWorkers(logger lager.Logger, spec Spec)
 	var compatibleTeamWorkers []db.Worker
 	var compatibleGeneralWorkers []db.Worker
 	for _, worker := range workers {
-		if pool.isWorkerCompatibleAndRunning(logger, worker, spec) {
+		if pool.isWorkerCompatibleAndRunning(logger, worker, spec) && pool.canPlaceOn(logger, worker, spec, artifacts) {
 			if worker.TeamID() != 0 {
 				compatibleTeamWorkers = append(compatibleTeamWorkers, worker)
 			} else {
@@ -465,6 +547,44 @@ func (pool Pool) isWorkerCompatibleAndRunning(logger lager.Logger, worker db.Wor
 	return true
 }
 
+func (pool Pool) canPlaceOn(logger lager.Logger, worker db.Worker, spec Spec, artifacts []runtime.Artifact) bool {
+	if pool.factory.CanStream(worker) {
+		return true
+	}
+
+	if len(spec.Tags) == 0 {
+		logger.Debug("untagged-step-not-placed-on-non-streaming-worker", lager.Data{"worker": worker.Name()})
+		return false
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Source() != worker.Name() {
+			logger.Debug("artifact-not-on-non-streaming-worker", lager.Data{
+				"worker":   worker.Name(),
+				"artifact": artifact.Handle(),
+			})
+			return false
+		}
+	}
+
+	return true
+}
+
+// containerArtifacts gives the artifacts which the container needs to be on
+// the same worker or streamed to it.
+func containerArtifacts(spec runtime.ContainerSpec) []runtime.Artifact {
+	var artifacts []runtime.Artifact
+	if spec.ImageSpec.ImageArtifact != nil {
+		artifacts = append(artifacts, spec.ImageSpec.ImageArtifact)
+	}
+
+	for _, input := range spec.Inputs {
+		artifacts = append(artifacts, input.Artifact)
+	}
+
+	return artifacts
+}
+
 func tagsMatch(worker db.Worker, tags []string) bool {
 	if len(worker.Tags()) > 0 && len(tags) == 0 {
 		return false
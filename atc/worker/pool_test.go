@@ -216,6 +216,101 @@ var _ = Describe("Pool", func() {
 			Expect(err).To(MatchError(ContainSubstring("no workers satisfying")))
 		})
 
+		Test("filters out workers which can't stream when the step isn't tagged for them", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)),
+				),
+				workertest.WithNonStreamingWorkers(fmt.Sprintf("worker1-%d", concurrentId)),
+			)
+
+			for i := 0; i < 10; i++ {
+				worker, err := scenario.Pool.FindOrSelectWorker(
+					ctx,
+					db.NewFixedHandleContainerOwner(fmt.Sprintf("my-container-%d", i)),
+					runtime.ContainerSpec{},
+					worker.Spec{},
+					nil,
+					nil,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(worker.Name()).To(Equal(fmt.Sprintf("worker2-%d", concurrentId)))
+			}
+		})
+
+		Test("filters out workers which can't stream when an input is on another worker", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).WithTags("A"),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)).WithTags("A").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("input"),
+						),
+				),
+				workertest.WithNonStreamingWorkers(fmt.Sprintf("worker1-%d", concurrentId)),
+			)
+
+			input := scenario.WorkerVolume(fmt.Sprintf("worker2-%d", concurrentId), "input")
+
+			for i := 0; i < 10; i++ {
+				worker, err := scenario.Pool.FindOrSelectWorker(
+					ctx,
+					db.NewFixedHandleContainerOwner(fmt.Sprintf("my-container-%d", i)),
+					runtime.ContainerSpec{
+						Inputs: []runtime.Input{
+							{Artifact: input, DestinationPath: "/input"},
+						},
+					},
+					worker.Spec{
+						Tags: []string{"A"},
+					},
+					nil,
+					nil,
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(worker.Name()).To(Equal(fmt.Sprintf("worker2-%d", concurrentId)))
+			}
+		})
+
+		Test("selects a worker which can't stream when the step is tagged for it and its inputs are on it", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).WithTags("A").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("input"),
+						),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)),
+				),
+				workertest.WithNonStreamingWorkers(fmt.Sprintf("worker1-%d", concurrentId)),
+			)
+
+			input := scenario.WorkerVolume(fmt.Sprintf("worker1-%d", concurrentId), "input")
+
+			worker, err := scenario.Pool.FindOrSelectWorker(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-container"),
+				runtime.ContainerSpec{
+					Inputs: []runtime.Input{
+						{Artifact: input, DestinationPath: "/input"},
+					},
+				},
+				worker.Spec{
+					Tags: []string{"A"},
+				},
+				nil,
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(worker.Name()).To(Equal(fmt.Sprintf("worker1-%d", concurrentId)))
+		})
+
 		Test("only considers team workers when any team worker is compatible", func() {
 			concurrentId := GinkgoParallelProcess()
 			scenario := Setup(
@@ -341,6 +436,64 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("CompatibleWorkers", func() {
+		Test("returns the running workers compatible with the spec", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).
+						WithPlatform("linux"),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)).
+						WithPlatform("darwin"),
+					grt.NewWorker(fmt.Sprintf("worker3-%d", concurrentId)).
+						WithPlatform("linux").
+						WithState(db.WorkerStateStalled),
+				),
+			)
+
+			workers, err := scenario.Pool.CompatibleWorkers(ctx, worker.Spec{Platform: "linux"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workers).To(HaveLen(1))
+			Expect(workers[0].Name()).To(Equal(fmt.Sprintf("worker1-%d", concurrentId)))
+		})
+	})
+
+	Describe("FindTaskCacheVolume", func() {
+		Test("finds a task cache volume on a compatible worker", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).
+						WithPlatform("linux").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("task-cache-1"),
+						),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)).
+						WithPlatform("darwin").
+						WithVolumesCreatedInDBAndBaggageclaim(
+							grt.NewVolume("task-cache-2"),
+						),
+				),
+			)
+
+			err := scenario.WorkerVolume(fmt.Sprintf("worker1-%d", concurrentId), "task-cache-1").InitializeTaskCache(ctx, scenario.JobID, "some-step", "some-path", false)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = scenario.WorkerVolume(fmt.Sprintf("worker2-%d", concurrentId), "task-cache-2").InitializeTaskCache(ctx, scenario.JobID, "some-step", "some-path", false)
+			Expect(err).ToNot(HaveOccurred())
+
+			cacheVolume, found, err := scenario.Pool.FindTaskCacheVolume(ctx, 0, scenario.JobID, "some-step", "some-path", worker.Spec{Platform: "darwin"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(cacheVolume.Handle()).To(Equal("task-cache-2"))
+
+			_, found, err = scenario.Pool.FindTaskCacheVolume(ctx, 0, scenario.JobID, "some-step", "some-other-path", worker.Spec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Describe("FindResourceCacheVolume", func() {
 		Test("finds a resource cache volume among multiple workers", func() {
 			concurrentId := GinkgoParallelProcess()
@@ -197,6 +197,26 @@ func (s Streamer) StreamFile(ctx context.Context, artifact runtime.Artifact, pat
 	}, nil
 }
 
+func (s Streamer) StreamDir(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error) {
+	out, err := artifact.StreamOut(ctx, path, s.compression)
+	if err != nil {
+		return nil, err
+	}
+
+	compressionReader, err := s.compression.NewReader(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileReadMultiCloser{
+		Reader: compressionReader,
+		closers: []io.Closer{
+			out,
+			compressionReader,
+		},
+	}, nil
+}
+
 type fileReadMultiCloser struct {
 	io.Reader
 	closers []io.Closer
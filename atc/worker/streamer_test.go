@@ -1,6 +1,7 @@
 package worker_test
 
 import (
+	"archive/tar"
 	"context"
 	"io"
 	"time"
@@ -261,6 +262,45 @@ var _ = Describe("Streamer", func() {
 
 		Expect(fileContent).To(Equal([]byte("content 2")))
 	})
+
+	Test("stream dir from artifact", func() {
+		artifact := runtimetest.Artifact{
+			Content: runtimetest.VolumeContent{
+				"file":              {Data: []byte("content 1")},
+				"folder/file":       {Data: []byte("content 2")},
+				"folder/other-file": {Data: []byte("content 3")},
+			},
+		}
+		streamer := Setup().Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		stream, err := streamer.StreamDir(ctx, artifact, "folder")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer stream.Close()
+
+		files := map[string]string{}
+		tarReader := tar.NewReader(stream)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+
+			content, err := io.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+
+			files[header.Name] = string(content)
+		}
+
+		Expect(files).To(Equal(map[string]string{
+			"folder/file":       "content 2",
+			"folder/other-file": "content 3",
+		}))
+	})
 })
 
 func baggageclaimVolume(volume runtime.Volume) *grt.Volume {
@@ -103,6 +103,14 @@ func WithWorkers(workers ...Worker) SetupFunc {
 	}
 }
 
+// WithNonStreamingWorkers marks workers as ones which artifacts can't be
+// streamed in or out of, like the Kubernetes worker.
+func WithNonStreamingWorkers(names ...string) SetupFunc {
+	return func(s *Scenario) {
+		s.Factory.NonStreaming = append(s.Factory.NonStreaming, names...)
+	}
+}
+
 func (s *Scenario) Team(name string) db.Team {
 	team, found, err := s.DBBuilder.TeamFactory.FindTeam(name)
 	Expect(err).ToNot(HaveOccurred())
@@ -20,6 +20,10 @@ type Worker interface {
 type Factory struct {
 	Workers []Worker
 	DB      worker.DB
+
+	// NonStreaming names the workers which artifacts can't be streamed in or
+	// out of.
+	NonStreaming []string
 }
 
 func (f Factory) NewWorker(_ lager.Logger, dbWorker db.Worker) runtime.Worker {
@@ -29,6 +33,15 @@ func (f Factory) NewWorker(_ lager.Logger, dbWorker db.Worker) runtime.Worker {
 	return worker.Build(f.DB, dbWorker)
 }
 
+func (f Factory) CanStream(dbWorker db.Worker) bool {
+	for _, name := range f.NonStreaming {
+		if name == dbWorker.Name() {
+			return false
+		}
+	}
+	return true
+}
+
 func (f Factory) FindWorker(name string) (Worker, int, bool) {
 	for i, w := range f.Workers {
 		if w.Name() == name {
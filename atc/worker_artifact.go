@@ -5,4 +5,5 @@ type WorkerArtifact struct {
 	Name      string `json:"name"`
 	BuildID   int    `json:"build_id"`
 	CreatedAt int64  `json:"created_at"`
+	Published bool   `json:"published,omitempty"`
 }
@@ -47,12 +47,16 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.ListBuildArtifacts,
+			atc.GetBuildArtifact,
+			atc.GetBuildTestResults:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
 		case atc.AbortBuild,
-			atc.SetBuildComment:
+			atc.SetBuildComment,
+			atc.ApproveBuild,
+			atc.RejectBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -73,6 +77,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.JobTestHistory,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
@@ -127,6 +132,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetTeam,
 			atc.SetTeam,
 			atc.RenameTeam,
+			atc.ListNotificationDeliveries,
+			atc.GetSecretsReport,
+			atc.GetPipelineSource,
 			atc.ListContainers,
 			atc.GetContainer,
 			atc.HijackContainer,
@@ -145,8 +153,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
+			atc.ListConfigRevisions,
+			atc.GetConfigRevision,
+			atc.DiffConfigRevisions,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.PreviewJobBuild,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
 			atc.PauseJob,
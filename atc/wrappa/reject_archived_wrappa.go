@@ -28,6 +28,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpausePipeline,
 			atc.CreateJobBuild,
 			atc.ScheduleJob,
+			atc.PreviewJobBuild,
 			atc.CheckResource,
 			atc.CheckResourceType,
 			atc.CheckPrototype,
@@ -43,14 +44,21 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			// leave the handler as-is
 		case
 			atc.GetConfig,
+			atc.ListConfigRevisions,
+			atc.GetConfigRevision,
+			atc.DiffConfigRevisions,
 			atc.GetBuild,
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.GetBuildArtifact,
+			atc.GetBuildTestResults,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
 			atc.AbortBuild,
 			atc.SetBuildComment,
+			atc.ApproveBuild,
+			atc.RejectBuild,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
@@ -65,6 +73,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.JobTestHistory,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
@@ -81,6 +90,9 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListContainers,
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
+			atc.ListNotificationDeliveries,
+			atc.GetSecretsReport,
+			atc.GetPipelineSource,
 			atc.ListWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
@@ -35,6 +35,7 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.UnpausePipeline,
 			atc.CreateJobBuild,
 			atc.ScheduleJob,
+			atc.PreviewJobBuild,
 			atc.CheckResource,
 			atc.CheckResourceType,
 			atc.CheckPrototype,
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type ApproveBuildCommand struct {
+	Job   flaghelpers.JobFlag  `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to approve"`
+	Build string               `short:"b" long:"build" required:"true" description:"If job is specified: build number to approve. If job not specified: build id"`
+	Step  string               `short:"s" long:"step" description:"Name of the approval step, if the build is waiting for more than one"`
+	Team  flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *ApproveBuildCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	build, err := findApprovalBuild(target, command.Team, command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Client().ApproveBuild(strconv.Itoa(build.ID), command.Step); err != nil {
+		return err
+	}
+
+	fmt.Println("build successfully approved")
+	return nil
+}
+
+func findApprovalBuild(target rc.Target, teamFlag flaghelpers.TeamFlag, job flaghelpers.JobFlag, buildName string) (atc.Build, error) {
+	var team concourse.Team
+	team, err := teamFlag.LoadTeam(target)
+	if err != nil {
+		return atc.Build{}, err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineRef.Name == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildName)
+	} else {
+		build, exists, err = team.JobBuild(job.PipelineRef, job.JobName, buildName)
+	}
+	if err != nil {
+		return atc.Build{}, err
+	}
+
+	if !exists {
+		return atc.Build{}, fmt.Errorf("build does not exist")
+	}
+
+	return build, nil
+}
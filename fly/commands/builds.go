@@ -213,6 +213,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 			{Contents: "duration", Color: color.New(color.Bold)},
 			{Contents: "team", Color: color.New(color.Bold)},
 			{Contents: "created by", Color: color.New(color.Bold)},
+			{Contents: "tests passed", Color: color.New(color.Bold)},
 		},
 	}
 
@@ -257,6 +258,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 			durationCell,
 			{Contents: b.TeamName},
 			{Contents: createdBy},
+			testsCell(b.TestSummary),
 		})
 	}
 
@@ -324,6 +326,19 @@ func populateTimeCells(startTime time.Time, endTime time.Time) (ui.TableCell, ui
 	return startTimeCell, endTimeCell, durationCell
 }
 
+func testsCell(summary *atc.TestSummary) ui.TableCell {
+	if summary == nil || summary.Total == 0 {
+		return ui.TableCell{Contents: "n/a"}
+	}
+
+	cell := ui.TableCell{Contents: fmt.Sprintf("%d/%d", summary.Passed, summary.Total)}
+	if summary.Failed > 0 {
+		cell.Color = ui.FailedColor
+	}
+
+	return cell
+}
+
 func roundSecondsOffDuration(d time.Duration) time.Duration {
 	return d - (d % time.Second)
 }
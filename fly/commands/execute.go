@@ -21,11 +21,14 @@ import (
 	"github.com/concourse/concourse/fly/ui/progress"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/vbauerster/mpb/v8"
+	"sigs.k8s.io/yaml"
 )
 
 type ExecuteCommand struct {
 	Background     bool                               `short:"b" long:"background"                            description:"Create the build and exit, i.e. neither watch logs nor retrieve outputs."`
-	TaskConfig     atc.PathFlag                       `short:"c" long:"config" required:"true"                description:"The task config to execute"`
+	TaskConfig     atc.PathFlag                       `short:"c" long:"config"                               description:"The task config to execute"`
+	Job            flaghelpers.JobFlag                `          long:"job"         value-name:"PIPELINE/JOB" description:"Execute the plan of a job instead of a task, getting the versions of its next build's inputs. Put steps are skipped"`
+	Steps          []string                           `          long:"step"        value-name:"NAME"         description:"When executing a job, only run the step with this name; get steps are always run (can be specified multiple times)"`
 	Privileged     bool                               `short:"p" long:"privileged"                            description:"Run the task with full privileges"`
 	IncludeIgnored bool                               `          long:"include-ignored"                       description:"Including .gitignored paths. Disregards .gitignore entries and uploads everything"`
 	Inputs         []flaghelpers.InputPairFlag        `short:"i" long:"input"       value-name:"NAME=PATH"    description:"An input to provide to the task (can be specified multiple times)"`
@@ -50,62 +53,39 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		return err
 	}
 
-	taskConfig, err := command.CreateTaskConfig(args)
-	if err != nil {
-		return err
-	}
-
 	planFactory := atc.NewPlanFactory(time.Now().Unix())
 
-	inputs, inputMappings, imageResource, resourceTypes, err := executehelpers.DetermineInputs(
-		planFactory,
-		target.Team(),
-		taskConfig.Inputs,
-		command.Inputs,
-		command.InputMappings,
-		command.Image,
-		command.InputsFrom,
-		command.IncludeIgnored,
-		taskConfig.Platform,
-		command.Tags,
-	)
-	if err != nil {
-		return err
-	}
+	var plan atc.Plan
+	var outputs []executehelpers.Output
 
-	if imageResource != nil {
-		taskConfig.ImageResource = imageResource
-	}
+	pipelineRef := command.InputsFrom.PipelineRef
 
-	outputs, err := executehelpers.DetermineOutputs(
-		planFactory,
-		taskConfig.Outputs,
-		command.Outputs,
-	)
-	if err != nil {
-		return err
+	if command.Job.JobName != "" {
+		if command.TaskConfig != "" || command.InputsFrom.JobName != "" {
+			return fmt.Errorf("--job cannot be used with --config or --inputs-from")
+		}
+
+		plan, outputs, err = command.createJobBuildPlan(planFactory, target)
+		if err != nil {
+			return err
+		}
+
+		pipelineRef = command.Job.PipelineRef
+	} else {
+		if command.TaskConfig == "" {
+			return fmt.Errorf("either --config or --job must be given")
+		}
+
+		plan, outputs, err = command.createTaskBuildPlan(planFactory, target, args)
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(outputs) > 0 && command.Background {
 		return fmt.Errorf("background execution cannot withstand outputs")
 	}
 
-	plan, err := executehelpers.CreateBuildPlan(
-		planFactory,
-		target,
-		command.Privileged,
-		inputs,
-		inputMappings,
-		resourceTypes,
-		outputs,
-		taskConfig,
-		command.Tags,
-	)
-
-	if err != nil {
-		return err
-	}
-
 	client := target.Client()
 	clientURL, err := url.Parse(client.URL())
 	if err != nil {
@@ -115,8 +95,8 @@ func (command *ExecuteCommand) Execute(args []string) error {
 	var build atc.Build
 	var buildURL *url.URL
 
-	if command.InputsFrom.PipelineRef.Name != "" {
-		build, err = target.Team().CreatePipelineBuild(command.InputsFrom.PipelineRef, plan)
+	if pipelineRef.Name != "" {
+		build, err = target.Team().CreatePipelineBuild(pipelineRef, plan)
 		if err != nil {
 			return err
 		}
@@ -193,6 +173,164 @@ func (command *ExecuteCommand) Execute(args []string) error {
 	return nil
 }
 
+func (command *ExecuteCommand) createTaskBuildPlan(planFactory atc.PlanFactory, target rc.Target, args []string) (atc.Plan, []executehelpers.Output, error) {
+	taskConfig, err := command.CreateTaskConfig(args)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	inputs, inputMappings, imageResource, resourceTypes, err := executehelpers.DetermineInputs(
+		planFactory,
+		target.Team(),
+		taskConfig.Inputs,
+		command.Inputs,
+		command.InputMappings,
+		command.Image,
+		command.InputsFrom,
+		command.IncludeIgnored,
+		taskConfig.Platform,
+		command.Tags,
+	)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	if imageResource != nil {
+		taskConfig.ImageResource = imageResource
+	}
+
+	outputs, err := executehelpers.DetermineOutputs(
+		planFactory,
+		taskConfig.Outputs,
+		command.Outputs,
+	)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	plan, err := executehelpers.CreateBuildPlan(
+		planFactory,
+		target,
+		command.Privileged,
+		inputs,
+		inputMappings,
+		resourceTypes,
+		outputs,
+		taskConfig,
+		command.Tags,
+	)
+
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	return plan, outputs, nil
+}
+
+// createJobBuildPlan converts the plan of the job into a one-off build plan,
+// running its get steps with the inputs of its next build unless they are
+// given locally.
+func (command *ExecuteCommand) createJobBuildPlan(planFactory atc.PlanFactory, target rc.Target) (atc.Plan, []executehelpers.Output, error) {
+	team := target.Team()
+
+	config, _, found, err := team.PipelineConfig(command.Job.PipelineRef)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	if !found {
+		return atc.Plan{}, nil, fmt.Errorf("pipeline '%s' not found", command.Job.PipelineRef.String())
+	}
+
+	job, found := config.Jobs.Lookup(command.Job.JobName)
+	if !found {
+		return atc.Plan{}, nil, fmt.Errorf("job '%s' not found in pipeline '%s'", command.Job.JobName, command.Job.PipelineRef.String())
+	}
+
+	job, err = command.interpolateJob(job)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	inputs, _, _, resourceTypes, err := executehelpers.DetermineInputs(
+		planFactory,
+		team,
+		executehelpers.JobInputs(job),
+		command.Inputs,
+		command.InputMappings,
+		"",
+		command.Job,
+		command.IncludeIgnored,
+		"",
+		command.Tags,
+	)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	outputs, err := executehelpers.DetermineOutputs(
+		planFactory,
+		executehelpers.JobOutputs(job),
+		command.Outputs,
+	)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	plan, skipped, err := executehelpers.CreateJobBuildPlan(
+		planFactory,
+		config,
+		job,
+		inputs,
+		resourceTypes,
+		outputs,
+		command.Steps,
+	)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	for _, name := range skipped {
+		fmt.Printf("skipping step '%s'\n", name)
+	}
+
+	return plan, outputs, nil
+}
+
+// interpolateJob fills in the job's config with the vars given on the command
+// line. Any other vars are left to be resolved by the build.
+func (command *ExecuteCommand) interpolateJob(job atc.JobConfig) (atc.JobConfig, error) {
+	if len(command.Var) == 0 && len(command.YAMLVar) == 0 && len(command.VarsFrom) == 0 {
+		return job, nil
+	}
+
+	payload, err := yaml.Marshal(job)
+	if err != nil {
+		return atc.JobConfig{}, err
+	}
+
+	jobTemplate := templatehelpers.NewYamlTemplateWithParams(
+		"",
+		command.VarsFrom,
+		command.Var,
+		command.YAMLVar,
+		nil,
+	)
+
+	payload, err = jobTemplate.Interpolate(payload, false)
+	if err != nil {
+		return atc.JobConfig{}, err
+	}
+
+	var interpolated atc.JobConfig
+	err = yaml.Unmarshal(payload, &interpolated)
+	if err != nil {
+		return atc.JobConfig{}, err
+	}
+
+	return interpolated, nil
+}
+
 func (command *ExecuteCommand) CreateTaskConfig(args []string) (atc.TaskConfig, error) {
 
 	taskTemplate := templatehelpers.NewYamlTemplateWithParams(
@@ -32,6 +32,9 @@ type FlyCommand struct {
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
 
+	SecretsReport  SecretsReportCommand  `command:"secrets-report" alias:"sr" description:"List the credentials referred to by a team's pipelines and where they are looked up"`
+	PipelineSource PipelineSourceCommand `command:"pipeline-source" alias:"psrc" description:"Show whether a team's pipelines are in sync with its pipeline source"`
+
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
 	Execute ExecuteCommand `command:"execute" alias:"e" description:"Execute a one-off build using local bits"`
@@ -61,6 +64,8 @@ type FlyCommand struct {
 	FormatPipeline            FormatPipelineCommand          `command:"format-pipeline"           alias:"fp"   description:"Format a pipeline config"`
 	OrderPipelines            OrderPipelinesCommand          `command:"order-pipelines"           alias:"op"   description:"Orders pipelines"`
 	OrderPipelinesWithinGroup OrderInstancedPipelinesCommand `command:"order-instanced-pipelines" alias:"oip"  description:"Orders instanced pipelines within an instance group"`
+	PipelineHistory           PipelineHistoryCommand         `command:"pipeline-history"          alias:"ph"   description:"List the revisions of a pipeline's configuration"`
+	RollbackPipeline          RollbackPipelineCommand        `command:"rollback-pipeline"         alias:"rbp"  description:"Roll back a pipeline's configuration to an earlier revision"`
 
 	Resources              ResourcesCommand              `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions       ResourceVersionsCommand       `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
@@ -76,9 +81,13 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds       BuildsCommand       `command:"builds"        alias:"bs"  description:"List builds data"`
+	AbortBuild   AbortBuildCommand   `command:"abort-build"   alias:"ab"  description:"Abort a build"`
+	RerunBuild   RerunBuildCommand   `command:"rerun-build"   alias:"rb"  description:"Rerun a build"`
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve a build that is waiting for approval"`
+	RejectBuild  RejectBuildCommand  `command:"reject-build"  alias:"rjb" description:"Reject a build that is waiting for approval"`
+	TestResults  TestResultsCommand  `command:"test-results"  alias:"trs" description:"List the test results of a build, or the history of a job's tests"`
+	PreviewBuild PreviewBuildCommand `command:"preview-build" alias:"pvb" description:"Show what the next build of a job would run, without creating it"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
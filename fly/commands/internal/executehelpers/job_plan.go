@@ -0,0 +1,475 @@
+package executehelpers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// JobInputs returns the inputs of the job, one for each of its get steps, so
+// that they can be determined like the inputs of a task.
+func JobInputs(job atc.JobConfig) []atc.TaskInputConfig {
+	var inputs []atc.TaskInputConfig
+
+	seen := map[string]bool{}
+	for _, input := range job.Inputs() {
+		if seen[input.Name] {
+			continue
+		}
+
+		seen[input.Name] = true
+		inputs = append(inputs, atc.TaskInputConfig{Name: input.Name})
+	}
+
+	return inputs
+}
+
+// JobOutputs returns the artifacts which can be fetched from a build of the
+// job, i.e. the outputs of its tasks and the resources it gets.
+func JobOutputs(job atc.JobConfig) []atc.TaskOutputConfig {
+	var outputs []atc.TaskOutputConfig
+
+	_ = job.StepConfig().Visit(atc.StepRecursor{
+		OnTask: func(step *atc.TaskStep) error {
+			if step.Config == nil {
+				return nil
+			}
+
+			for _, output := range step.Config.Outputs {
+				name := output.Name
+				if mapped, found := step.OutputMapping[name]; found {
+					name = mapped
+				}
+
+				outputs = append(outputs, atc.TaskOutputConfig{Name: name})
+			}
+
+			return nil
+		},
+		OnGet: func(step *atc.GetStep) error {
+			outputs = append(outputs, atc.TaskOutputConfig{Name: step.Name})
+			return nil
+		},
+	})
+
+	return outputs
+}
+
+// CreateJobBuildPlan converts the job's plan into a one-off build plan. Each
+// get step is replaced by its input, and put steps are skipped. If steps are
+// given, only the steps with those names are run, along with the get steps
+// which provide their inputs.
+//
+// The names of the steps which were skipped are returned.
+func CreateJobBuildPlan(
+	fact atc.PlanFactory,
+	config atc.Config,
+	job atc.JobConfig,
+	inputs []Input,
+	resourceTypes atc.ResourceTypes,
+	outputs []Output,
+	steps []string,
+) (atc.Plan, []string, error) {
+	visitor := &jobPlanVisitor{
+		planFactory:   fact,
+		prototypes:    config.Prototypes,
+		resourceTypes: resourceTypes,
+		inputs:        map[string]atc.Plan{},
+	}
+
+	for _, input := range inputs {
+		visitor.inputs[input.Name] = input.Plan
+	}
+
+	if len(steps) > 0 {
+		visitor.steps = map[string]bool{}
+		for _, name := range steps {
+			visitor.steps[name] = true
+		}
+	}
+
+	err := job.StepConfig().Visit(visitor)
+	if err != nil {
+		return atc.Plan{}, nil, err
+	}
+
+	plan := visitor.plan
+	if len(outputs) > 0 {
+		buildOutputs := atc.InParallelPlan{}
+		for _, output := range outputs {
+			buildOutputs.Steps = append(buildOutputs.Steps, output.Plan)
+		}
+
+		plan = fact.NewPlan(atc.EnsurePlan{
+			Step: plan,
+			Next: fact.NewPlan(buildOutputs),
+		})
+	}
+
+	return plan, visitor.skipped, nil
+}
+
+type jobPlanVisitor struct {
+	planFactory atc.PlanFactory
+
+	prototypes    atc.Prototypes
+	resourceTypes atc.ResourceTypes
+	inputs        map[string]atc.Plan
+
+	// steps are the names of the steps to run; all steps are run if nil
+	steps map[string]bool
+
+	plan    atc.Plan
+	skipped []string
+}
+
+// skip replaces the step with one which does nothing, so that any hooks on
+// it still run.
+func (visitor *jobPlanVisitor) skip(name string) {
+	visitor.plan = visitor.planFactory.NewPlan(atc.DoPlan{})
+	visitor.skipped = append(visitor.skipped, name)
+}
+
+func (visitor *jobPlanVisitor) selected(name string) bool {
+	return visitor.steps == nil || visitor.steps[name]
+}
+
+func (visitor *jobPlanVisitor) VisitTask(step *atc.TaskStep) error {
+	if !visitor.selected(step.Name) {
+		visitor.skip(step.Name)
+		return nil
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.TaskPlan{
+		Name:              step.Name,
+		Privileged:        step.Privileged,
+		Hermetic:          step.Hermetic,
+		Limits:            step.Limits,
+		Config:            step.Config,
+		ConfigPath:        step.ConfigPath,
+		Vars:              step.Vars,
+		Tags:              step.Tags,
+		Params:            step.Params,
+		InputMapping:      step.InputMapping,
+		OutputMapping:     step.OutputMapping,
+		ImageArtifactName: step.ImageArtifactName,
+		Timeout:           step.Timeout,
+
+		ResourceTypes: visitor.resourceTypes,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitRun(step *atc.RunStep) error {
+	if !visitor.selected(step.Message) {
+		visitor.skip(step.Message)
+		return nil
+	}
+
+	prototype, found := visitor.prototypes.Lookup(step.Type)
+	if !found {
+		return fmt.Errorf("unknown prototype: %s", step.Type)
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.RunPlan{
+		Message:    step.Message,
+		Type:       step.Type,
+		Object:     atc.Params(prototype.Defaults.Merge(atc.Source(step.Params))),
+		Privileged: step.Privileged,
+		Tags:       step.Tags,
+		Limits:     step.Limits,
+		Timeout:    step.Timeout,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitGet(step *atc.GetStep) error {
+	plan, found := visitor.inputs[step.Name]
+	if !found {
+		return fmt.Errorf("missing required input `%s`", step.Name)
+	}
+
+	visitor.plan = plan
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitPut(step *atc.PutStep) error {
+	visitor.skip(step.Name)
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
+	if !visitor.selected(step.Name) {
+		visitor.skip(step.Name)
+		return nil
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.SetPipelinePlan{
+		Name:         step.Name,
+		File:         step.File,
+		Team:         step.Team,
+		Vars:         step.Vars,
+		VarFiles:     step.VarFiles,
+		InstanceVars: step.InstanceVars,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
+	if !visitor.selected(step.Name) {
+		visitor.skip(step.Name)
+		return nil
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.LoadVarPlan{
+		Name:   step.Name,
+		File:   step.File,
+		Dir:    step.Dir,
+		Format: step.Format,
+		Select: step.Select,
+		Reveal: step.Reveal,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitSetVar(step *atc.SetVarStep) error {
+	if !visitor.selected(step.Name) {
+		visitor.skip(step.Name)
+		return nil
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.SetVarPlan{
+		Name:    step.Name,
+		File:    step.File,
+		Format:  step.Format,
+		Manager: step.Manager,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	if !visitor.selected(step.Name) {
+		visitor.skip(step.Name)
+		return nil
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name:      step.Name,
+		Approvers: step.Approvers,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitUseTemplate(step *atc.UseTemplateStep) error {
+	if len(step.Steps) == 0 {
+		return fmt.Errorf("use_template(%s) has not been expanded; the pipeline must be set again", step.Name)
+	}
+
+	return visitor.visitSequence(step.Steps)
+}
+
+func (visitor *jobPlanVisitor) VisitDo(step *atc.DoStep) error {
+	return visitor.visitSequence(step.Steps)
+}
+
+func (visitor *jobPlanVisitor) visitSequence(steps []atc.Step) error {
+	do := atc.DoPlan{}
+
+	for _, step := range steps {
+		err := step.Config.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		do = append(do, visitor.plan)
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(do)
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitInParallel(step *atc.InParallelStep) error {
+	var steps []atc.Plan
+
+	for _, sub := range step.Config.Steps {
+		err := sub.Config.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		steps = append(steps, visitor.plan)
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.InParallelPlan{
+		Steps:    steps,
+		Limit:    step.Config.Limit,
+		FailFast: step.Config.FailFast,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitAcross(step *atc.AcrossStep) error {
+	vars := make([]atc.AcrossVar, len(step.Vars))
+	for i, v := range step.Vars {
+		vars[i] = atc.AcrossVar(v)
+	}
+
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	template, err := json.Marshal(visitor.plan)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.AcrossPlan{
+		Vars:            vars,
+		SubStepTemplate: string(template),
+		FailFast:        step.FailFast,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitTry(step *atc.TryStep) error {
+	err := step.Step.Config.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.TryPlan{
+		Step: visitor.plan,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitTimeout(step *atc.TimeoutStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.TimeoutPlan{
+		Duration: step.Duration,
+		Step:     visitor.plan,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitIf(step *atc.IfStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.IfPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitRetry(step *atc.RetryStep) error {
+	retry := make(atc.RetryPlan, step.Attempts)
+
+	for i := 0; i < step.Attempts; i++ {
+		err := step.Step.Visit(visitor)
+		if err != nil {
+			return err
+		}
+
+		retry[i] = visitor.plan
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(retry)
+
+	return nil
+}
+
+// visitHook visits a step and the hook attached to it, returning the plans
+// for both.
+func (visitor *jobPlanVisitor) visitHook(step atc.StepConfig, hook atc.Step) (atc.Plan, atc.Plan, error) {
+	err := step.Visit(visitor)
+	if err != nil {
+		return atc.Plan{}, atc.Plan{}, err
+	}
+
+	stepPlan := visitor.plan
+
+	err = hook.Config.Visit(visitor)
+	if err != nil {
+		return atc.Plan{}, atc.Plan{}, err
+	}
+
+	return stepPlan, visitor.plan, nil
+}
+
+func (visitor *jobPlanVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
+	stepPlan, hookPlan, err := visitor.visitHook(step.Step, step.Hook)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.OnSuccessPlan{Step: stepPlan, Next: hookPlan})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
+	stepPlan, hookPlan, err := visitor.visitHook(step.Step, step.Hook)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.OnFailurePlan{Step: stepPlan, Next: hookPlan})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
+	stepPlan, hookPlan, err := visitor.visitHook(step.Step, step.Hook)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.OnAbortPlan{Step: stepPlan, Next: hookPlan})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitOnError(step *atc.OnErrorStep) error {
+	stepPlan, hookPlan, err := visitor.visitHook(step.Step, step.Hook)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.OnErrorPlan{Step: stepPlan, Next: hookPlan})
+
+	return nil
+}
+
+func (visitor *jobPlanVisitor) VisitEnsure(step *atc.EnsureStep) error {
+	stepPlan, hookPlan, err := visitor.visitHook(step.Step, step.Hook)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.EnsurePlan{Step: stepPlan, Next: hookPlan})
+
+	return nil
+}
@@ -55,6 +55,12 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(
 		}
 	}
 
+	return yamlTemplate.Interpolate(config, allowEmpty)
+}
+
+// Interpolate fills in the given config with the template's variables. Any
+// variables which are not given are left in place.
+func (yamlTemplate YamlTemplateWithParams) Interpolate(config []byte, allowEmpty bool) ([]byte, error) {
 	var params []vars.Variables
 
 	// first, we take explicitly specified variables on the command line
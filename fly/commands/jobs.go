@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -15,6 +17,7 @@ import (
 type JobsCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get jobs in this pipeline"`
 	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Explain  bool                     `long:"explain" description:"Also print why the scheduler could not find inputs for each job's next build"`
 	Team     flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
 }
 
@@ -53,7 +56,7 @@ func (command *JobsCommand) Execute([]string) error {
 		return nil
 	}
 
-	headers = []string{"name", "paused", "status", "next"}
+	headers = []string{"name", "paused", "status", "next", "next fire"}
 	table := ui.Table{Headers: ui.TableRow{}}
 	for _, h := range headers {
 		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
@@ -89,8 +92,51 @@ func (command *JobsCommand) Execute([]string) error {
 		}
 		row = append(row, nextColumn)
 
+		var nextFireColumn ui.TableCell
+		if p.NextFireTime != 0 {
+			nextFireColumn.Contents = time.Unix(p.NextFireTime, 0).Format(timeDateLayout)
+		} else {
+			nextFireColumn.Contents = "n/a"
+		}
+		row = append(row, nextFireColumn)
+
 		table.Data = append(table.Data, row)
 	}
 
-	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if !command.Explain {
+		return nil
+	}
+
+	explainTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "job", Color: color.New(color.Bold)},
+			{Contents: "input", Color: color.New(color.Bold)},
+			{Contents: "reason", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, job := range jobs {
+		for _, diagnostic := range job.SchedulingDiagnostics {
+			explainTable.Data = append(explainTable.Data, ui.TableRow{
+				{Contents: job.Name},
+				{Contents: diagnostic.Input},
+				{Contents: diagnostic.Message, Color: ui.FailedColor},
+			})
+		}
+	}
+
+	if len(explainTable.Data) == 0 {
+		fmt.Println()
+		fmt.Println("the inputs of every job could be resolved")
+		return nil
+	}
+
+	fmt.Println()
+
+	return explainTable.Render(os.Stdout, Fly.PrintTableHeaders)
 }
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline whose config history to list"`
+	Diff     int                      `short:"d" long:"diff" value-name:"VERSION" description:"Show the changes made by this version of the config"`
+	From     int                      `long:"from" value-name:"VERSION" description:"Version to compare with when showing changes, instead of the previous one"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *PipelineHistoryCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	if command.Diff != 0 {
+		diff, found, err := team.DiffConfigRevisions(command.Pipeline.Ref(), command.Diff, command.From)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errors.New("pipeline config version not found")
+		}
+
+		fmt.Print(diff)
+		return nil
+	}
+
+	revisions, found, err := team.ConfigRevisions(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(revisions)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "source", Color: color.New(color.Bold)},
+			{Contents: "created by", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "created at", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, revision := range revisions {
+		createdByCell := ui.TableCell{Contents: revision.CreatedBy}
+		if revision.CreatedBy == "" {
+			createdByCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		buildCell := ui.TableCell{Contents: strconv.Itoa(revision.BuildID)}
+		if revision.BuildID == 0 {
+			buildCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(revision.Version)},
+			{Contents: string(revision.Source)},
+			createdByCell,
+			buildCell,
+			{Contents: time.Unix(revision.CreatedAt, 0).Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineSourceCommand struct {
+	Json bool                 `long:"json" description:"Print command result as JSON"`
+	Team flaghelpers.TeamFlag `long:"team" description:"Name of the team whose pipeline source to show, if different from the target default"`
+}
+
+func (command *PipelineSourceCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	status, found, err := team.PipelineSource()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("team '%s' has no pipeline source", team.Name())
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(status)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	source := status.Source.URI
+	if status.Source.Branch != "" {
+		source += " (" + status.Source.Branch + ")"
+	}
+
+	fmt.Println("source:", source)
+
+	if status.Source.Dir != "" {
+		fmt.Println("dir:   ", status.Source.Dir)
+	}
+
+	if status.SyncedAt == 0 {
+		fmt.Println("synced:", ui.OffColor.Sprint("not yet"))
+	} else {
+		fmt.Println("synced:", time.Unix(status.SyncedAt, 0).Format(timeDateLayout))
+	}
+
+	if status.Commit != "" {
+		fmt.Println("commit:", status.Commit)
+	}
+
+	if status.Error != "" {
+		fmt.Println("error: ", ui.ErroredColor.Sprint(status.Error))
+	}
+
+	fmt.Println()
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "path", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, pipeline := range status.Pipelines {
+		var statusCell ui.TableCell
+		switch {
+		case pipeline.Drifted && pipeline.Error != "":
+			statusCell = ui.TableCell{Contents: "drifted: " + pipeline.Error, Color: ui.FailedColor}
+		case pipeline.Drifted:
+			statusCell = ui.TableCell{Contents: "drifted", Color: ui.FailedColor}
+		case pipeline.Archived:
+			statusCell = ui.TableCell{Contents: "archived", Color: ui.OffColor}
+		default:
+			statusCell = ui.TableCell{Contents: "in sync", Color: ui.SucceededColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: pipeline.Name},
+			{Contents: pipeline.Path},
+			statusCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
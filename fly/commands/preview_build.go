@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PreviewBuildCommand struct {
+	Job  flaghelpers.JobFlag  `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to preview the next build of"`
+	Json bool                 `long:"json" description:"Print command result as JSON, including the build's plan"`
+	Team flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PreviewBuildCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	preview, found, err := team.PreviewJobBuild(command.Job.PipelineRef, command.Job.JobName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("job '%s' not found", command.Job.JobName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(preview)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	inputsTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "input", Color: color.New(color.Bold)},
+			{Contents: "resource", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "passed builds", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, input := range preview.Inputs {
+		versionCell := ui.TableCell{Contents: presentMap(input.Version)}
+		if input.ResolveError != "" {
+			versionCell = ui.TableCell{Contents: input.ResolveError, Color: ui.FailedColor}
+		}
+
+		passedCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if len(input.PassedBuildIDs) != 0 {
+			ids := []string{}
+			for _, id := range input.PassedBuildIDs {
+				ids = append(ids, strconv.Itoa(id))
+			}
+
+			passedCell = ui.TableCell{Contents: strings.Join(ids, ",")}
+		}
+
+		inputsTable.Data = append(inputsTable.Data, ui.TableRow{
+			{Contents: input.Name},
+			{Contents: input.Resource},
+			versionCell,
+			passedCell,
+		})
+	}
+
+	err = inputsTable.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if !preview.Resolved {
+		fmt.Println()
+		fmt.Println(ui.FailedColor.Sprint("inputs could not be resolved, so no build would be created"))
+		return nil
+	}
+
+	params := map[atc.PlanID]interface{}{}
+	if preview.Plan != nil {
+		preview.Plan.Each(func(p *atc.Plan) {
+			switch {
+			case p.Get != nil && len(p.Get.Params) != 0:
+				params[p.ID] = p.Get.Params
+			case p.Put != nil && len(p.Put.Params) != 0:
+				params[p.ID] = p.Put.Params
+			case p.Task != nil && len(p.Task.Params) != 0:
+				params[p.ID] = p.Task.Params
+			}
+		})
+	}
+
+	stepsTable := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "type", Color: color.New(color.Bold)},
+			{Contents: "resource type", Color: color.New(color.Bold)},
+			{Contents: "params", Color: color.New(color.Bold)},
+			{Contents: "workers", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, step := range preview.Steps {
+		resourceTypeCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if step.ResourceType != "" {
+			resourceType := step.ResourceType
+			if step.BaseResourceType != "" && step.BaseResourceType != step.ResourceType {
+				resourceType += " (" + step.BaseResourceType + ")"
+			}
+
+			resourceTypeCell = ui.TableCell{Contents: resourceType}
+		}
+
+		paramsCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if stepParams, found := params[step.ID]; found {
+			paramsCell = ui.TableCell{Contents: presentMap(stepParams)}
+		}
+
+		workersCell := ui.TableCell{Contents: strings.Join(step.Workers, ",")}
+		if step.Error != "" {
+			workersCell = ui.TableCell{Contents: step.Error, Color: ui.FailedColor}
+		}
+
+		stepsTable.Data = append(stepsTable.Data, ui.TableRow{
+			{Contents: step.Name},
+			{Contents: step.Kind},
+			resourceTypeCell,
+			paramsCell,
+			workersCell,
+		})
+	}
+
+	fmt.Println()
+
+	return stepsTable.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RejectBuildCommand struct {
+	Job   flaghelpers.JobFlag  `short:"j" long:"job" value-name:"PIPELINE/JOB"   description:"Name of a job to reject"`
+	Build string               `short:"b" long:"build" required:"true" description:"If job is specified: build number to reject. If job not specified: build id"`
+	Step  string               `short:"s" long:"step" description:"Name of the approval step, if the build is waiting for more than one"`
+	Team  flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RejectBuildCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	build, err := findApprovalBuild(target, command.Team, command.Job, command.Build)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Client().RejectBuild(strconv.Itoa(build.ID), command.Step); err != nil {
+		return err
+	}
+
+	fmt.Println("build successfully rejected")
+	return nil
+}
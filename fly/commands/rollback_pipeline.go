@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type RollbackPipelineCommand struct {
+	Pipeline        flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to roll back"`
+	Version         int                      `short:"v" long:"version" required:"true" value-name:"VERSION" description:"Version of the config to roll back to, as listed by pipeline-history"`
+	SkipInteractive bool                     `short:"n" long:"non-interactive" description:"Skips interactions, uses default values"`
+	Team            flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *RollbackPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+
+	revision, found, err := team.ConfigRevision(pipelineRef, command.Version)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("version %d of the pipeline config not found", command.Version)
+	}
+
+	existingConfig, existingConfigVersion, found, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !existingConfig.Diff(stdout, *revision.Config) {
+		fmt.Println("no changes to apply")
+		return nil
+	}
+
+	if !command.confirmRollback() {
+		fmt.Println("bailing out")
+		return nil
+	}
+
+	payload, err := yaml.Marshal(revision.Config)
+	if err != nil {
+		return err
+	}
+
+	// the config is saved as a new version, so the rollback is itself
+	// recorded in the pipeline's history
+	_, _, warnings, err := team.CreateOrUpdatePipelineConfig(pipelineRef, existingConfigVersion, payload, false)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("rolled back '%s' to version %d\n", pipelineRef.String(), command.Version)
+
+	return nil
+}
+
+func (command *RollbackPipelineCommand) confirmRollback() bool {
+	if command.SkipInteractive {
+		return true
+	}
+
+	var confirm bool
+	err := interact.NewInteraction("apply configuration?").Resolve(&confirm)
+	if err != nil {
+		return false
+	}
+
+	return confirm
+}
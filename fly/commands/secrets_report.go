@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SecretsReportCommand struct {
+	Missing bool                 `short:"m" long:"missing" description:"Only list credentials which could not be found, and var_sources which are not used"`
+	Json    bool                 `long:"json" description:"Print command result as JSON"`
+	Team    flaghelpers.TeamFlag `long:"team" description:"Name of the team whose pipelines to report on, if different from the target default"`
+}
+
+func (command *SecretsReportCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	reports, err := team.SecretsReport()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(reports)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "credential", Color: color.New(color.Bold)},
+			{Contents: "manager", Color: color.New(color.Bold)},
+			{Contents: "path", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, report := range reports {
+		pipelineName := atc.PipelineRef{
+			Name:         report.PipelineName,
+			InstanceVars: report.PipelineInstanceVars,
+		}.String()
+
+		if report.Error != "" {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: pipelineName},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "errored: " + report.Error, Color: ui.ErroredColor},
+			})
+			continue
+		}
+
+		for _, secret := range report.Secrets {
+			if command.Missing && secret.Resolved {
+				continue
+			}
+
+			table.Data = append(table.Data, command.secretRow(pipelineName, secret))
+		}
+
+		for _, varSource := range report.UnusedVarSources {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: pipelineName},
+				{Contents: varSource},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "unused", Color: ui.PendingColor},
+			})
+		}
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *SecretsReportCommand) secretRow(pipelineName string, secret atc.SecretReference) ui.TableRow {
+	row := ui.TableRow{
+		{Contents: pipelineName},
+		{Contents: secret.Name},
+	}
+
+	if secret.Resolved {
+		manager := ui.TableCell{Contents: secret.Manager}
+		if secret.Manager == "" {
+			manager = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		return append(row,
+			manager,
+			ui.TableCell{Contents: secret.Path},
+			ui.TableCell{Contents: "found", Color: ui.SucceededColor},
+		)
+	}
+
+	// list every path that was looked up, so it's clear where the credential
+	// is expected to be
+	paths := []string{}
+	for _, lookup := range secret.Lookups {
+		if lookup.Manager != "" {
+			paths = append(paths, lookup.Manager+":"+lookup.Path)
+		} else {
+			paths = append(paths, lookup.Path)
+		}
+	}
+
+	pathCell := ui.TableCell{Contents: strings.Join(paths, ", ")}
+	if len(paths) == 0 {
+		pathCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	status := ui.TableCell{Contents: "missing", Color: ui.FailedColor}
+	if secret.Error != "" {
+		status = ui.TableCell{Contents: "errored: " + secret.Error, Color: ui.ErroredColor}
+	}
+
+	return append(row,
+		ui.TableCell{Contents: "n/a", Color: ui.OffColor},
+		pathCell,
+		status,
+	)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
@@ -14,6 +16,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -31,6 +34,63 @@ type SetTeamCommand struct {
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
+// settings reads the notifications, the order of credential managers, the
+// shared var_sources and templates and the pipeline source from the team's
+// config file, if given, alongside its roles.
+func (command *SetTeamCommand) settings() (atc.Team, error) {
+	path := command.AuthFlags.Config.Path()
+	if path == "" {
+		return atc.Team{}, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return atc.Team{}, err
+	}
+
+	var config struct {
+		Notifications      atc.NotificationConfigs   `json:"notifications"`
+		CredentialManagers []string                  `json:"credential_managers"`
+		VarSources         atc.VarSourceConfigs      `json:"var_sources"`
+		Templates          atc.StepTemplates         `json:"templates"`
+		PipelineSource     *atc.PipelineSourceConfig `json:"pipeline_source"`
+	}
+	err = yaml.Unmarshal(content, &config)
+	if err != nil {
+		return atc.Team{}, err
+	}
+
+	err = config.Notifications.Validate()
+	if err != nil {
+		return atc.Team{}, err
+	}
+
+	_, err = configvalidate.ValidateVarSources(config.VarSources)
+	if err != nil {
+		return atc.Team{}, err
+	}
+
+	_, err = configvalidate.ValidateStepTemplates(config.Templates)
+	if err != nil {
+		return atc.Team{}, err
+	}
+
+	if config.PipelineSource != nil {
+		err = config.PipelineSource.Validate()
+		if err != nil {
+			return atc.Team{}, err
+		}
+	}
+
+	return atc.Team{
+		Notifications:      config.Notifications,
+		CredentialManagers: config.CredentialManagers,
+		VarSources:         config.VarSources,
+		Templates:          config.Templates,
+		PipelineSource:     config.PipelineSource,
+	}, nil
+}
+
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
 	var warnings []concourse.ConfigWarning
 	warning, err := atc.ValidateIdentifier(command.Team.Name(), "team")
@@ -68,6 +128,12 @@ func (command *SetTeamCommand) Execute([]string) error {
 		os.Exit(1)
 	}
 
+	settings, err := command.settings()
+	if err != nil {
+		fmt.Fprintln(ui.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
 	roles := []string{}
 	for role := range authRoles {
 		roles = append(roles, role)
@@ -103,6 +169,53 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if len(settings.Notifications) > 0 {
+		fmt.Println()
+		fmt.Println("notifications:")
+		for _, notification := range settings.Notifications {
+			statuses := "all builds"
+			if len(notification.Statuses) > 0 {
+				names := []string{}
+				for _, status := range notification.Statuses {
+					names = append(names, string(status))
+				}
+				statuses = strings.Join(names, ", ")
+			}
+
+			fmt.Printf("  %s: %s (%s)\n", ui.Embolden("%s", notification.Name), notification.URL, statuses)
+		}
+	}
+
+	if len(settings.CredentialManagers) > 0 {
+		fmt.Println()
+		fmt.Println("credential managers:", strings.Join(settings.CredentialManagers, ", "))
+	}
+
+	if len(settings.VarSources) > 0 {
+		names := make([]string, len(settings.VarSources))
+		for i, varSource := range settings.VarSources {
+			names[i] = varSource.Name
+		}
+
+		fmt.Println()
+		fmt.Println("var sources:", strings.Join(names, ", "))
+	}
+
+	if len(settings.Templates) > 0 {
+		names := make([]string, len(settings.Templates))
+		for i, template := range settings.Templates {
+			names[i] = template.Name
+		}
+
+		fmt.Println()
+		fmt.Println("templates:", strings.Join(names, ", "))
+	}
+
+	if settings.PipelineSource != nil {
+		fmt.Println()
+		fmt.Println("pipeline source:", settings.PipelineSource.URI)
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -120,7 +233,14 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:               authRoles,
+		Notifications:      settings.Notifications,
+		CredentialManagers: settings.CredentialManagers,
+		VarSources:         settings.VarSources,
+		Templates:          settings.Templates,
+		PipelineSource:     settings.PipelineSource,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
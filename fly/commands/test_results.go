@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type TestResultsCommand struct {
+	Job    flaghelpers.JobFlag  `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Show the history of the tests of this job, or of one of its builds if --build is also given"`
+	Build  string               `short:"b" long:"build" description:"If job is specified: build number to show the test results of. If job not specified: build id"`
+	Builds int                  `long:"builds" default:"10" description:"Number of recent builds of the job to look at when showing its history"`
+	Json   bool                 `long:"json" description:"Print command result as JSON"`
+	Team   flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *TestResultsCommand) Execute([]string) error {
+	if command.Job.JobName == "" && command.Build == "" {
+		return errors.New("either --job or --build must be specified")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.Build == "" {
+		return command.showHistory(target)
+	}
+
+	return command.showBuildResults(target)
+}
+
+func (command *TestResultsCommand) showBuildResults(target rc.Target) error {
+	// the build is looked up by its id unless a job is given
+	var team concourse.Team
+	if command.Job.JobName != "" {
+		var err error
+		team, err = command.Team.LoadTeam(target)
+		if err != nil {
+			return err
+		}
+	}
+
+	build, err := GetBuild(target.Client(), team, command.Job.JobName, command.Build, command.Job.PipelineRef)
+	if err != nil {
+		return err
+	}
+
+	results, found, err := target.Client().BuildTestResults(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("build not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(results)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "suite", Color: color.New(color.Bold)},
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, result := range results.Results {
+		durationCell := ui.TableCell{Contents: "n/a"}
+		if result.Duration > 0 {
+			durationCell.Contents = time.Duration(result.Duration * float64(time.Second)).Round(time.Millisecond).String()
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: result.Step},
+			{Contents: result.Suite},
+			{Contents: result.Name},
+			ui.TestStatusCell(result.Status),
+			durationCell,
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	summary := results.Summary
+	fmt.Printf("\n%d passed, %d failed, %d skipped (%d total)\n", summary.Passed, summary.Failed, summary.Skipped, summary.Total)
+
+	return nil
+}
+
+func (command *TestResultsCommand) showHistory(target rc.Target) error {
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	history, found, err := team.JobTestHistory(command.Job.PipelineRef, command.Job.JobName, command.Builds)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("job not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(history)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "suite", Color: color.New(color.Bold)},
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "runs", Color: color.New(color.Bold)},
+			{Contents: "passed", Color: color.New(color.Bold)},
+			{Contents: "failed", Color: color.New(color.Bold)},
+			{Contents: "skipped", Color: color.New(color.Bold)},
+			{Contents: "flaky", Color: color.New(color.Bold)},
+			{Contents: "last status", Color: color.New(color.Bold)},
+			{Contents: "last build", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, test := range history {
+		flakyCell := ui.TableCell{Contents: "no"}
+		if test.Flaky {
+			flakyCell.Contents = "yes"
+			flakyCell.Color = ui.StartedColor
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: test.Suite},
+			{Contents: test.Name},
+			{Contents: strconv.Itoa(test.Runs)},
+			{Contents: strconv.Itoa(test.Passed)},
+			{Contents: strconv.Itoa(test.Failed)},
+			{Contents: strconv.Itoa(test.Skipped)},
+			flakyCell,
+			ui.TestStatusCell(test.LastStatus),
+			{Contents: test.LastBuild},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,129 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var expectedBuild = atc.Build{
+		ID:      23,
+		Name:    "42",
+		Status:  "started",
+		JobName: "my-job",
+		APIURL:  "api/v1/builds/23",
+	}
+
+	Context("when the build id is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve", ""),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("build successfully approved"))
+		})
+	})
+
+	Context("when the job and step are specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/builds/42"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve", "step=qa"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves that step of the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-j", "my-pipeline/my-job", "-b", "42", "-s", "qa")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("build successfully approved"))
+		})
+	})
+
+	Context("when the build is not waiting for approval", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve"),
+					ghttp.RespondWith(http.StatusConflict, "build is not waiting for approval"),
+				),
+			)
+		})
+
+		It("shows the reason", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("build is not waiting for approval"))
+		})
+	})
+
+	Context("when the user is not an approver", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve"),
+					ghttp.RespondWith(http.StatusForbidden, ""),
+				),
+			)
+		})
+
+		It("fails", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("forbidden"))
+		})
+	})
+})
@@ -69,6 +69,7 @@ var _ = Describe("Fly CLI", func() {
 				{Contents: "duration", Color: color.New(color.Bold)},
 				{Contents: "team", Color: color.New(color.Bold)},
 				{Contents: "created by", Color: color.New(color.Bold)},
+				{Contents: "tests passed", Color: color.New(color.Bold)},
 			}
 		})
 
@@ -106,6 +107,7 @@ var _ = Describe("Fly CLI", func() {
 						StartTime:            runningBuildStartTime.Unix(),
 						EndTime:              0,
 						TeamName:             "team1",
+						TestSummary:          &atc.TestSummary{Total: 15, Passed: 12, Failed: 2, Skipped: 1},
 					},
 					{
 						ID:           999,
@@ -177,7 +179,13 @@ var _ = Describe("Fly CLI", func() {
                 "pipeline_instance_vars": {
                   "branch": "master"
                 },
-                "start_time": 1448101815
+                "start_time": 1448101815,
+                "test_summary": {
+                  "total": 15,
+                  "passed": 12,
+                  "failed": 2,
+                  "skipped": 1
+                }
               },
               {
                 "id": 999,
@@ -253,6 +261,7 @@ var _ = Describe("Fly CLI", func() {
 							},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "12/15"},
 						},
 						{
 							{Contents: "999"},
@@ -263,6 +272,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: "some-team"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 						{
 							{Contents: "3"},
@@ -273,6 +283,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 						{
 							{Contents: "1000001"},
@@ -283,6 +294,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "2h45m0s"},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 						{
 							{Contents: "1002"},
@@ -293,6 +305,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 						{
 							{Contents: "39"},
@@ -303,6 +316,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "team1"},
 							{Contents: "someone"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -395,7 +409,9 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "n/a"},
 							{Contents: "n/a"},
+							{Contents: ""},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -409,7 +425,9 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "n/a"},
 							{Contents: "n/a"},
+							{Contents: ""},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -452,6 +470,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: ""},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -533,6 +552,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: ""},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -574,6 +594,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: ""},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -624,6 +645,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: ""},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -668,6 +690,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -709,6 +732,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -750,6 +774,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -817,6 +842,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 
 							{
@@ -828,6 +854,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: "team2"},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
@@ -916,6 +943,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 						{
 							{Contents: "4"},
@@ -926,6 +954,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: "team2"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -977,6 +1006,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -1019,6 +1049,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "1h15m0s"},
 							{Contents: ""},
 							{Contents: "system"},
+							{Contents: "n/a"},
 						},
 					},
 				}))
@@ -1081,6 +1112,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: "1h15m0s"},
 								{Contents: ""},
 								{Contents: "system"},
+								{Contents: "n/a"},
 							},
 						},
 					}))
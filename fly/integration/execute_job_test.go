@@ -0,0 +1,308 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/vito/go-sse/sse"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("execute --job", func() {
+		var (
+			inputDir string
+
+			pipelineConfig atc.Config
+			expectedPlan   atc.Plan
+
+			streaming chan struct{}
+			events    chan atc.Event
+			uploading chan struct{}
+
+			args []string
+		)
+
+		workerArtifact := atc.WorkerArtifact{
+			ID:   125,
+			Name: "some-input",
+		}
+
+		taskConfig := func(foo string) *atc.TaskConfig {
+			return &atc.TaskConfig{
+				Platform: "linux",
+				ImageResource: &atc.ImageResource{
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "ubuntu"},
+				},
+				Inputs: []atc.TaskInputConfig{
+					{Name: "some-input"},
+				},
+				Params: atc.TaskEnv{"FOO": foo},
+				Run: atc.TaskRunConfig{
+					Path: "ls",
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			var err error
+			inputDir, err = os.MkdirTemp("", "fly-input-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = os.WriteFile(filepath.Join(inputDir, "some-file"), []byte("blob"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = atc.UnmarshalConfig([]byte(`
+resources:
+- name: some-input
+  type: git
+  source: {uri: https://example.com/input}
+- name: some-other-input
+  type: git
+  source: {uri: https://example.com/other-input}
+- name: some-output
+  type: git
+  source: {uri: https://example.com/output}
+
+jobs:
+- name: some-job
+  plan:
+  - in_parallel:
+    - get: some-input
+    - get: some-other-input
+  - task: unit
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ubuntu}
+      inputs:
+      - name: some-input
+      params:
+        FOO: ((foo))
+      run: {path: ls}
+  - task: integration
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ubuntu}
+      inputs:
+      - name: some-input
+      params:
+        FOO: ((foo))
+      run: {path: ls}
+  - put: some-output
+    params: {repository: some-input}
+`), &pipelineConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			streaming = make(chan struct{})
+			events = make(chan atc.Event)
+			uploading = make(chan struct{})
+
+			args = []string{
+				"--job", "some-pipeline/some-job",
+				"--input", fmt.Sprintf("some-input=%s", inputDir),
+			}
+
+			planFactory := atc.NewPlanFactory(0)
+
+			expectedPlan = planFactory.NewPlan(atc.DoPlan{
+				planFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						planFactory.NewPlan(atc.ArtifactInputPlan{
+							ArtifactID: 125,
+							Name:       "some-input",
+						}),
+						planFactory.NewPlan(atc.GetPlan{
+							Name:      "some-other-input",
+							Type:      "git",
+							TypeImage: atc.TypeImage{BaseType: "git"},
+							Source:    atc.Source{"uri": "https://example.com/other-input"},
+							Version:   &atc.Version{"some": "version"},
+						}),
+					},
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:   "unit",
+					Config: taskConfig("((foo))"),
+				}),
+				planFactory.NewPlan(atc.TaskPlan{
+					Name:   "integration",
+					Config: taskConfig("((foo))"),
+				}),
+				planFactory.NewPlan(atc.DoPlan{}),
+			})
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(inputDir)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/some-pipeline/config",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: pipelineConfig}, http.Header{atc.ConfigVersionHeader: {"1"}}),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/inputs",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.BuildInput{
+					{
+						Name:     "some-input",
+						Type:     "git",
+						Resource: "some-input",
+						Source:   atc.Source{"uri": "https://example.com/input"},
+						Version:  atc.Version{"some": "version"},
+					},
+					{
+						Name:     "some-other-input",
+						Type:     "git",
+						Resource: "some-other-input",
+						Source:   atc.Source{"uri": "https://example.com/other-input"},
+						Version:  atc.Version{"some": "version"},
+					},
+				}),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/some-pipeline/resource-types",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+			)
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/artifacts",
+				ghttp.CombineHandlers(
+					func(w http.ResponseWriter, req *http.Request) {
+						close(uploading)
+					},
+					ghttp.RespondWithJSONEncoded(201, workerArtifact),
+				),
+			)
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/pipelines/some-pipeline/builds",
+				ghttp.CombineHandlers(
+					VerifyPlan(expectedPlan),
+					ghttp.RespondWith(201, `{"id":128}`),
+				),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/events",
+				func(w http.ResponseWriter, r *http.Request) {
+					flusher := w.(http.Flusher)
+
+					w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+					w.WriteHeader(http.StatusOK)
+					flusher.Flush()
+
+					close(streaming)
+
+					id := 0
+					for e := range events {
+						payload, err := json.Marshal(event.Message{Event: e})
+						Expect(err).NotTo(HaveOccurred())
+
+						err = sse.Event{ID: fmt.Sprintf("%d", id), Name: "event", Data: payload}.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+
+						flusher.Flush()
+						id++
+					}
+
+					err := sse.Event{Name: "end"}.Write(w)
+					Expect(err).NotTo(HaveOccurred())
+				},
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/artifacts",
+				ghttp.RespondWithJSONEncoded(200, []atc.WorkerArtifact{workerArtifact}),
+			)
+		})
+
+		run := func() *gexec.Session {
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "execute"}, args...)...)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			return sess
+		}
+
+		succeed := func(sess *gexec.Session) {
+			Eventually(uploading).Should(BeClosed())
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Payload: "sup"}
+			close(events)
+
+			Eventually(sess.Out).Should(gbytes.Say("sup"))
+			Eventually(sess).Should(gexec.Exit(0))
+		}
+
+		It("runs the job's plan with the local inputs, skipping its puts", func() {
+			sess := run()
+			Eventually(sess.Out).Should(gbytes.Say("skipping step 'some-output'"))
+			succeed(sess)
+		})
+
+		Context("when steps are chosen", func() {
+			BeforeEach(func() {
+				args = append(args, "--step", "integration")
+
+				planFactory := atc.NewPlanFactory(0)
+				expectedPlan.Do = &atc.DoPlan{
+					(*expectedPlan.Do)[0],
+					planFactory.NewPlan(atc.DoPlan{}),
+					(*expectedPlan.Do)[2],
+					planFactory.NewPlan(atc.DoPlan{}),
+				}
+			})
+
+			It("only runs those steps and the get steps", func() {
+				sess := run()
+				Eventually(sess.Out).Should(gbytes.Say("skipping step 'unit'"))
+				Eventually(sess.Out).Should(gbytes.Say("skipping step 'some-output'"))
+				succeed(sess)
+			})
+		})
+
+		Context("when vars are given", func() {
+			BeforeEach(func() {
+				args = append(args, "--var", "foo=bar")
+
+				(*expectedPlan.Do)[1].Task.Config = taskConfig("bar")
+				(*expectedPlan.Do)[2].Task.Config = taskConfig("bar")
+			})
+
+			It("fills them in", func() {
+				succeed(run())
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				args = []string{"--job", "some-pipeline/bogus-job"}
+			})
+
+			It("errors", func() {
+				sess := run()
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("job 'bogus-job' not found in pipeline 'some-pipeline'"))
+			})
+		})
+
+		Context("when a task config is also given", func() {
+			BeforeEach(func() {
+				args = append(args, "--config", filepath.Join(inputDir, "some-file"))
+			})
+
+			It("errors", func() {
+				sess := run()
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("--job cannot be used with --config or --inputs-from"))
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os/exec"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/ui"
@@ -32,6 +33,7 @@ var _ = Describe("Fly CLI", func() {
                   "branch": "master"
                 },
                 "team_name": "main",
+                "next_fire_time": 1700000000,
                 "next_build": {
                   "id": 0,
                   "team_name": "",
@@ -132,6 +134,8 @@ var _ = Describe("Fly CLI", func() {
 				createJob(3, pipelineRef, false, "", ""),
 			}
 
+			sampleJobs[0].NextFireTime = 1700000000
+
 			BeforeEach(func() {
 				flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "--pipeline", "pipeline/branch:master")
 				atcServer.AppendHandlers(
@@ -163,14 +167,80 @@ var _ = Describe("Fly CLI", func() {
 
 				Expect(sess.Out).To(PrintTable(ui.Table{
 					Data: []ui.TableRow{
-						{{Contents: "job-1"}, {Contents: "no"}, {Contents: "succeeded"}, {Contents: "started", Color: color.New(color.FgGreen)}},
-						{{Contents: "job-2"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "failed", Color: color.New(color.FgRed)}, {Contents: "n/a"}},
-						{{Contents: "job-3"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}},
+						{{Contents: "job-1"}, {Contents: "no"}, {Contents: "succeeded"}, {Contents: "started", Color: color.New(color.FgGreen)}, {Contents: time.Unix(1700000000, 0).Format("2006-01-02@15:04:05-0700")}},
+						{{Contents: "job-2"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "failed", Color: color.New(color.FgRed)}, {Contents: "n/a"}, {Contents: "n/a"}},
+						{{Contents: "job-3"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}, {Contents: "n/a"}},
 					},
 				}))
 			})
 		})
 
+		Context("when --explain is given", func() {
+			var jobs []atc.Job
+
+			BeforeEach(func() {
+				jobs = []atc.Job{
+					{
+						ID:           1,
+						Name:         "job-1",
+						PipelineName: "pipeline",
+						TeamName:     teamName,
+					},
+					{
+						ID:           2,
+						Name:         "job-2",
+						PipelineName: "pipeline",
+						TeamName:     teamName,
+						SchedulingDiagnostics: []atc.SchedulingDiagnostic{
+							{
+								Input:     "some-input",
+								Resource:  "some-resource",
+								PassedJob: "job-1",
+								Message:   "no version of some-resource has passed job job-1",
+							},
+						},
+					},
+				}
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "-p", "pipeline", "--explain")
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(200, jobs),
+					),
+				)
+			})
+
+			It("shows why each job's inputs could not be resolved", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Data: []ui.TableRow{
+						{{Contents: "job-2"}, {Contents: "some-input"}, {Contents: "no version of some-resource has passed job job-1", Color: color.New(color.FgRed)}},
+					},
+				}))
+			})
+
+			Context("when every job's inputs could be resolved", func() {
+				BeforeEach(func() {
+					jobs[1].SchedulingDiagnostics = nil
+				})
+
+				It("says so", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(gbytes.Say("the inputs of every job could be resolved"))
+				})
+			})
+		})
+
 		Context("when the api returns an internal server error", func() {
 			BeforeEach(func() {
 				flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "-p", "pipeline")
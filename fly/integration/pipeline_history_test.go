@@ -0,0 +1,114 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-history", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/revisions"),
+						ghttp.RespondWithJSONEncoded(200, []atc.ConfigRevision{
+							{Version: 3, Source: atc.ConfigSourceSetPipeline, CreatedBy: "some-user", BuildID: 42, CreatedAt: 2000},
+							{Version: 1, Source: atc.ConfigSourceFly, CreatedAt: 1000},
+						}),
+					),
+				)
+			})
+
+			It("lists the revisions of its config", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "version", Color: color.New(color.Bold)},
+						{Contents: "source", Color: color.New(color.Bold)},
+						{Contents: "created by", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "created at", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "3"},
+							{Contents: "set_pipeline"},
+							{Contents: "some-user"},
+							{Contents: "42"},
+							{Contents: time.Unix(2000, 0).Format("2006-01-02@15:04:05-0700")},
+						},
+						{
+							{Contents: "1"},
+							{Contents: "fly"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: time.Unix(1000, 0).Format("2006-01-02@15:04:05-0700")},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when showing the changes made by a version", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline", "--diff", "3", "--from", "1")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/revisions/3/diff", "from=1"),
+						ghttp.RespondWith(200, "job some-job has changed:\n"),
+					),
+				)
+			})
+
+			It("prints the diff", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("job some-job has changed:"))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/revisions"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+			})
+		})
+	})
+})
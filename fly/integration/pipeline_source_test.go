@@ -0,0 +1,157 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("pipeline-source", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-source")
+		})
+
+		Context("when the team has a pipeline source", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline-source"),
+						ghttp.RespondWithJSONEncoded(200, atc.PipelineSourceStatus{
+							Source: atc.PipelineSourceConfig{
+								URI:    "https://example.com/pipelines.git",
+								Branch: "main",
+								Dir:    "ci/pipelines",
+							},
+							Commit:   "abc123",
+							SyncedAt: 1000,
+							Pipelines: []atc.ManagedPipelineStatus{
+								{Name: "some-pipeline", Path: "ci/pipelines/some-pipeline.yml"},
+								{Name: "broken", Path: "ci/pipelines/broken.yml", Drifted: true, Error: "invalid config: oops"},
+								{Name: "removed", Path: "ci/pipelines/removed.yml", Archived: true},
+							},
+						}),
+					),
+				)
+			})
+
+			It("shows the latest sync and the status of each pipeline", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`source: https://example.com/pipelines.git \(main\)`))
+				Expect(sess.Out).To(gbytes.Say(`dir:\s+ci/pipelines`))
+				Expect(sess.Out).To(gbytes.Say(`synced: \S+`))
+				Expect(sess.Out).To(gbytes.Say(`commit: abc123`))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "path", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-pipeline"},
+							{Contents: "ci/pipelines/some-pipeline.yml"},
+							{Contents: "in sync"},
+						},
+						{
+							{Contents: "broken"},
+							{Contents: "ci/pipelines/broken.yml"},
+							{Contents: "drifted: invalid config: oops"},
+						},
+						{
+							{Contents: "removed"},
+							{Contents: "ci/pipelines/removed.yml"},
+							{Contents: "archived"},
+						},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"source": {
+							"uri": "https://example.com/pipelines.git",
+							"branch": "main",
+							"dir": "ci/pipelines"
+						},
+						"commit": "abc123",
+						"synced_at": 1000,
+						"pipelines": [
+							{"name": "some-pipeline", "path": "ci/pipelines/some-pipeline.yml", "drifted": false},
+							{"name": "broken", "path": "ci/pipelines/broken.yml", "drifted": true, "error": "invalid config: oops"},
+							{"name": "removed", "path": "ci/pipelines/removed.yml", "drifted": false, "archived": true}
+						]
+					}`))
+				})
+			})
+		})
+
+		Context("when the pipelines could not be synced", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline-source"),
+						ghttp.RespondWithJSONEncoded(200, atc.PipelineSourceStatus{
+							Source:    atc.PipelineSourceConfig{URI: "https://example.com/pipelines.git"},
+							SyncedAt:  1000,
+							Error:     "git clone failed: exit status 128",
+							Pipelines: []atc.ManagedPipelineStatus{},
+						}),
+					),
+				)
+			})
+
+			It("shows the error", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`error:\s+git clone failed: exit status 128`))
+			})
+		})
+
+		Context("when the team has no pipeline source", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipeline-source"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("fails", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("team 'main' has no pipeline source"))
+			})
+		})
+	})
+})
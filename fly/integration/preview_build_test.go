@@ -0,0 +1,185 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("preview-build", func() {
+		var (
+			flyCmd  *exec.Cmd
+			status  int
+			preview atc.BuildPreview
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "preview-build", "-j", "some-pipeline/some-job")
+			status = 200
+
+			taskPlan := atc.Plan{
+				ID: "2",
+				Task: &atc.TaskPlan{
+					Name:   "some-task",
+					Params: atc.TaskEnv{"BRANCH": "main"},
+				},
+			}
+
+			preview = atc.BuildPreview{
+				Resolved: true,
+				Inputs: []atc.BuildPreviewInput{
+					{
+						Name:           "some-input",
+						Resource:       "some-resource",
+						Version:        atc.Version{"ref": "abc"},
+						PassedBuildIDs: []int{7, 8},
+					},
+				},
+				Plan: &atc.Plan{
+					ID: "3",
+					Do: &atc.DoPlan{
+						{ID: "1", Get: &atc.GetPlan{Name: "some-input", Type: "git"}},
+						taskPlan,
+					},
+				},
+				Steps: []atc.BuildPreviewStep{
+					{
+						ID:               "1",
+						Name:             "some-input",
+						Kind:             "get",
+						ResourceType:     "git",
+						BaseResourceType: "git",
+						Workers:          []string{"worker-1", "worker-2"},
+					},
+					{
+						ID:      "2",
+						Name:    "some-task",
+						Kind:    "task",
+						Workers: []string{},
+						Error:   "no workers satisfying: platform 'darwin'",
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/preview"),
+					ghttp.RespondWithJSONEncoded(status, preview),
+				),
+			)
+		})
+
+		It("shows the inputs and the workers each step could run on", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "input", Color: color.New(color.Bold)},
+					{Contents: "resource", Color: color.New(color.Bold)},
+					{Contents: "version", Color: color.New(color.Bold)},
+					{Contents: "passed builds", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-input"},
+						{Contents: "some-resource"},
+						{Contents: "ref:abc"},
+						{Contents: "7,8"},
+					},
+				},
+			}))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "type", Color: color.New(color.Bold)},
+					{Contents: "resource type", Color: color.New(color.Bold)},
+					{Contents: "params", Color: color.New(color.Bold)},
+					{Contents: "workers", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-input"},
+						{Contents: "get"},
+						{Contents: "git"},
+						{Contents: "n/a"},
+						{Contents: "worker-1,worker-2"},
+					},
+					{
+						{Contents: "some-task"},
+						{Contents: "task"},
+						{Contents: "n/a"},
+						{Contents: "BRANCH:main"},
+						{Contents: "no workers satisfying: platform 'darwin'"},
+					},
+				},
+			}))
+		})
+
+		Context("when the inputs cannot be resolved", func() {
+			BeforeEach(func() {
+				preview = atc.BuildPreview{
+					Inputs: []atc.BuildPreviewInput{
+						{
+							Name:         "some-input",
+							Resource:     "some-resource",
+							ResolveError: "latest version of resource not found",
+						},
+					},
+				}
+			})
+
+			It("says that no build would be created", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(`some-input\s+some-resource\s+latest version of resource not found\s+n/a`))
+				Expect(sess.Out).To(gbytes.Say(`inputs could not be resolved, so no build would be created`))
+			})
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the preview, including the plan", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`"plan": {`))
+				Expect(sess.Out).To(gbytes.Say(`"BRANCH": "main"`))
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				status = 404
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("job 'some-job' not found"))
+			})
+		})
+	})
+})
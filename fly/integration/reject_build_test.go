@@ -0,0 +1,45 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("RejectBuild", func() {
+	BeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{
+					ID:     23,
+					Name:   "42",
+					Status: "started",
+				}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/builds/23/reject", "step=qa"),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			),
+		)
+	})
+
+	It("rejects the build", func() {
+		flyCmd := exec.Command(flyPath, "-t", targetName, "reject-build", "-b", "23", "--step", "qa")
+
+		sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(sess).Should(gexec.Exit(0))
+
+		Expect(sess.Out).To(gbytes.Say("build successfully rejected"))
+	})
+})
@@ -0,0 +1,161 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("rollback-pipeline", func() {
+		var (
+			currentConfig  atc.Config
+			revisionConfig atc.Config
+		)
+
+		yes := func(stdin io.Writer) {
+			fmt.Fprintf(stdin, "y\n")
+		}
+
+		no := func(stdin io.Writer) {
+			fmt.Fprintf(stdin, "n\n")
+		}
+
+		BeforeEach(func() {
+			revisionConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job", Public: true}},
+			}
+
+			currentConfig = atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			}
+		})
+
+		Context("when the version exists", func() {
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/revisions/3"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigRevision{
+							Version: 3,
+							Source:  atc.ConfigSourceFly,
+							Config:  &revisionConfig,
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: currentConfig}, http.Header{atc.ConfigVersionHeader: {"7"}}),
+					),
+				)
+			})
+
+			Context("when the user confirms", func() {
+				BeforeEach(func() {
+					atcServer.RouteToHandler("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config",
+						ghttp.CombineHandlers(
+							ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "7"),
+							func(w http.ResponseWriter, r *http.Request) {
+								var receivedConfig atc.Config
+								Expect(yaml.Unmarshal(getConfig(r), &receivedConfig)).To(Succeed())
+								Expect(receivedConfig).To(Equal(revisionConfig))
+
+								w.WriteHeader(http.StatusOK)
+								w.Write([]byte(`{}`))
+							},
+						),
+					)
+				})
+
+				It("saves the config of that version over the current version", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--version", "3")
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say("job some-job has changed"))
+					Eventually(sess).Should(gbytes.Say(`apply configuration\?`))
+					yes(stdin)
+
+					Eventually(sess).Should(gbytes.Say("rolled back 'some-pipeline' to version 3"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+
+				It("does not prompt when running in non-interactive mode", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-n", "-p", "some-pipeline", "--version", "3")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say("rolled back 'some-pipeline' to version 3"))
+				})
+			})
+
+			Context("when the user declines", func() {
+				It("does not save the config", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--version", "3")
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`apply configuration\?`))
+					no(stdin)
+
+					Eventually(sess).Should(gbytes.Say("bailing out"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+
+			Context("when the version matches the current config", func() {
+				BeforeEach(func() {
+					currentConfig = revisionConfig
+				})
+
+				It("does not save the config", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--version", "3")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say("no changes to apply"))
+				})
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/revisions/3"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--version", "3")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("version 3 of the pipeline config not found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,196 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secrets-report", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secrets-report")
+		})
+
+		Context("when the report is returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets-report"),
+						ghttp.RespondWithJSONEncoded(200, []atc.PipelineSecretsReport{
+							{
+								PipelineID:           1,
+								PipelineName:         "some-pipeline",
+								PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+								Secrets: []atc.SecretReference{
+									{
+										Name: "found-secret",
+										Lookups: []atc.SecretLookup{
+											{Manager: "vault", Path: "/concourse/main/some-pipeline/found-secret"},
+										},
+										Resolved: true,
+										Manager:  "vault",
+										Path:     "/concourse/main/some-pipeline/found-secret",
+									},
+									{
+										Name: "missing-secret",
+										Lookups: []atc.SecretLookup{
+											{Manager: "vault", Path: "/concourse/main/some-pipeline/missing-secret"},
+											{Manager: "vault", Path: "/concourse/main/missing-secret"},
+										},
+									},
+								},
+								UnusedVarSources: []string{"some-var-source"},
+							},
+							{
+								PipelineID:   2,
+								PipelineName: "broken-pipeline",
+								Error:        "invalid var_source",
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists each credential and where it was looked up", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "credential", Color: color.New(color.Bold)},
+						{Contents: "manager", Color: color.New(color.Bold)},
+						{Contents: "path", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-pipeline/branch:master"},
+							{Contents: "found-secret"},
+							{Contents: "vault"},
+							{Contents: "/concourse/main/some-pipeline/found-secret"},
+							{Contents: "found"},
+						},
+						{
+							{Contents: "some-pipeline/branch:master"},
+							{Contents: "missing-secret"},
+							{Contents: "n/a"},
+							{Contents: "vault:/concourse/main/some-pipeline/missing-secret, vault:/concourse/main/missing-secret"},
+							{Contents: "missing"},
+						},
+						{
+							{Contents: "some-pipeline/branch:master"},
+							{Contents: "some-var-source"},
+							{Contents: "n/a"},
+							{Contents: "n/a"},
+							{Contents: "unused"},
+						},
+						{
+							{Contents: "broken-pipeline"},
+							{Contents: "n/a"},
+							{Contents: "n/a"},
+							{Contents: "n/a"},
+							{Contents: "errored: invalid var_source"},
+						},
+					},
+				}))
+			})
+
+			Context("when --missing is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--missing")
+				})
+
+				It("omits the credentials which were found", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say("missing-secret"))
+					Expect(sess.Out.Contents()).NotTo(ContainSubstring("found-secret"))
+				})
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+              {
+                "pipeline_id": 1,
+                "pipeline_name": "some-pipeline",
+                "pipeline_instance_vars": {
+                  "branch": "master"
+                },
+                "secrets": [
+                  {
+                    "name": "found-secret",
+                    "lookups": [
+                      {"manager": "vault", "path": "/concourse/main/some-pipeline/found-secret"}
+                    ],
+                    "resolved": true,
+                    "manager": "vault",
+                    "path": "/concourse/main/some-pipeline/found-secret"
+                  },
+                  {
+                    "name": "missing-secret",
+                    "lookups": [
+                      {"manager": "vault", "path": "/concourse/main/some-pipeline/missing-secret"},
+                      {"manager": "vault", "path": "/concourse/main/missing-secret"}
+                    ],
+                    "resolved": false
+                  }
+                ],
+                "unused_var_sources": ["some-var-source"]
+              },
+              {
+                "pipeline_id": 2,
+                "pipeline_name": "broken-pipeline",
+                "secrets": null,
+                "error": "invalid var_source"
+              }
+            ]`))
+				})
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/secrets-report"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
@@ -404,6 +404,300 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("notifications", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_notifications.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:some-admin"],
+									"groups": []
+								}
+							},
+							"notifications": [
+								{
+									"name": "chat",
+									"url": "https://chat.example.com/hooks/concourse",
+									"secret": "some-secret",
+									"statuses": ["failed", "errored"]
+								}
+							]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the notifications from the config file", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("notifications:"))
+				Eventually(sess.Out).Should(gbytes.Say(`chat: https://chat.example.com/hooks/concourse \(failed, errored\)`))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a notification is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_invalid_notifications.yml"}
+				})
+
+				It("fails without sending the team", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("notification 'chat' has an invalid url: 'chat.example.com'"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
+		Describe("credential managers", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_credential_managers.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:some-admin"],
+									"groups": []
+								}
+							},
+							"credential_managers": ["vault", "credhub"]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the order of credential managers from the config file", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("credential managers: vault, credhub"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Describe("var sources", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_var_sources.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:some-admin"],
+									"groups": []
+								}
+							},
+							"var_sources": [
+								{
+									"name": "shared-vault",
+									"type": "vault",
+									"config": {
+										"url": "https://vault.example.com",
+										"client_token": "some-token"
+									}
+								}
+							]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the var sources from the config file", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("var sources: shared-vault"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a var source is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_invalid_var_sources.yml"}
+				})
+
+				It("fails without sending the team", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("unknown credential manager type: bogus"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
+		Describe("templates", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_templates.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:some-admin"],
+									"groups": []
+								}
+							},
+							"templates": [
+								{
+									"name": "unit-tests",
+									"params": {"package": "./..."},
+									"steps": [
+										{
+											"task": "unit",
+											"file": "ci/tasks/unit.yml",
+											"params": {"PACKAGE": "((param:package))"}
+										}
+									]
+								}
+							]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the templates from the config file", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("templates: unit-tests"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a template is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_invalid_templates.yml"}
+				})
+
+				It("fails without sending the team", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("templates.unit-tests has no steps"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
+		Describe("pipeline source", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_pipeline_source.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:some-admin"],
+									"groups": []
+								}
+							},
+							"pipeline_source": {
+								"uri": "https://example.com/pipelines.git",
+								"branch": "main",
+								"dir": "ci/pipelines"
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the pipeline source from the config file", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("pipeline source: https://example.com/pipelines.git"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the pipeline source is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"-c", "fixtures/team_config_with_invalid_pipeline_source.yml"}
+				})
+
+				It("fails without sending the team", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("pipeline source has no uri"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
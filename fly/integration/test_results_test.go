@@ -0,0 +1,159 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/fatih/color"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+var _ = Describe("TestResults", func() {
+	Context("when neither the job nor the build is specified", func() {
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "test-results")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("either --job or --build must be specified"))
+		})
+	})
+
+	Context("when the build is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23, Name: "42"}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/test-results"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.BuildTestResults{
+						Summary: atc.TestSummary{Total: 3, Passed: 1, Failed: 1, Skipped: 1},
+						Results: []atc.TestResult{
+							{Step: "unit", Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 1.5},
+							{Step: "unit", Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "division by zero"},
+							{Step: "unit", Suite: "math", Name: "integrates", Status: atc.TestStatusSkipped},
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the build's test results and their summary", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "test-results", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "suite", Color: color.New(color.Bold)},
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "status", Color: color.New(color.Bold)},
+					{Contents: "duration", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "unit"}, {Contents: "math"}, {Contents: "adds"}, {Contents: "passed"}, {Contents: "1.5s"}},
+					{{Contents: "unit"}, {Contents: "math"}, {Contents: "divides"}, {Contents: "failed"}, {Contents: "n/a"}},
+					{{Contents: "unit"}, {Contents: "math"}, {Contents: "integrates"}, {Contents: "skipped"}, {Contents: "n/a"}},
+				},
+			}))
+
+			Expect(sess.Out).To(gbytes.Say(`1 passed, 1 failed, 1 skipped \(3 total\)`))
+		})
+	})
+
+	Context("when only the job is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/test-history", "builds=5"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.TestHistory{
+						{
+							Suite:      "math",
+							Name:       "divides",
+							Runs:       5,
+							Passed:     3,
+							Failed:     2,
+							Flaky:      true,
+							LastStatus: atc.TestStatusFailed,
+							LastBuild:  "42",
+						},
+						{
+							Suite:      "math",
+							Name:       "adds",
+							Runs:       5,
+							Passed:     5,
+							LastStatus: atc.TestStatusPassed,
+							LastBuild:  "42",
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the history of the job's tests", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "test-results", "-j", "my-pipeline/my-job", "--builds", "5")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "suite", Color: color.New(color.Bold)},
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "runs", Color: color.New(color.Bold)},
+					{Contents: "passed", Color: color.New(color.Bold)},
+					{Contents: "failed", Color: color.New(color.Bold)},
+					{Contents: "skipped", Color: color.New(color.Bold)},
+					{Contents: "flaky", Color: color.New(color.Bold)},
+					{Contents: "last status", Color: color.New(color.Bold)},
+					{Contents: "last build", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{{Contents: "math"}, {Contents: "divides"}, {Contents: "5"}, {Contents: "3"}, {Contents: "2"}, {Contents: "0"}, {Contents: "yes"}, {Contents: "failed"}, {Contents: "42"}},
+					{{Contents: "math"}, {Contents: "adds"}, {Contents: "5"}, {Contents: "5"}, {Contents: "0"}, {Contents: "0"}, {Contents: "no"}, {Contents: "passed"}, {Contents: "42"}},
+				},
+			}))
+		})
+	})
+
+	Context("when the job does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/my-pipeline/jobs/my-job/test-history"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "test-results", "-j", "my-pipeline/my-job")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("job not found"))
+		})
+	})
+})
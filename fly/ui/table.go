@@ -102,7 +102,7 @@ func (table Table) renderRow(dst io.Writer, row TableRow, widths map[int]int, is
 			return err
 		}
 
-		if i+1 < len(widths) {
+		if i+1 < len(row) {
 			_, err := fmt.Fprintf(dst, "  ")
 			if err != nil {
 				return err
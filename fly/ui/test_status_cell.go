@@ -0,0 +1,19 @@
+package ui
+
+import "github.com/concourse/concourse/atc"
+
+func TestStatusCell(status atc.TestStatus) TableCell {
+	var statusCell TableCell
+	statusCell.Contents = string(status)
+
+	switch status {
+	case atc.TestStatusPassed:
+		statusCell.Color = SucceededColor
+	case atc.TestStatusFailed:
+		statusCell.Color = FailedColor
+	case atc.TestStatusSkipped:
+		statusCell.Color = PendingColor
+	}
+
+	return statusCell
+}
@@ -86,4 +86,39 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetBuildArtifact", func() {
+		Context("when the artifact does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/17"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.GetBuildArtifact("123", 17)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the artifact exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/artifacts/17"),
+						ghttp.RespondWith(http.StatusOK, "some-tarball"),
+					),
+				)
+			})
+
+			It("returns the contents", func() {
+				contents, err := client.GetBuildArtifact("123", 17)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(io.ReadAll(contents)).To(Equal([]byte("some-tarball")))
+			})
+		})
+	})
 })
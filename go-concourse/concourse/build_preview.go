@@ -0,0 +1,33 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PreviewJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.BuildPreview, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+		"team_name":     team.Name(),
+	}
+
+	var preview atc.BuildPreview
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.PreviewJobBuild,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &preview,
+	})
+
+	switch err.(type) {
+	case nil:
+		return preview, true, nil
+	case internal.ResourceNotFoundError:
+		return preview, false, nil
+	default:
+		return preview, false, err
+	}
+}
@@ -0,0 +1,75 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Preview", func() {
+	Describe("PreviewJobBuild", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/preview"
+		queryParams := "vars.branch=%22master%22"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		Context("when pipeline/job exists", func() {
+			var expectedPreview atc.BuildPreview
+
+			BeforeEach(func() {
+				expectedPreview = atc.BuildPreview{
+					Resolved: true,
+					Inputs: []atc.BuildPreviewInput{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Version:  atc.Version{"ref": "abc"},
+						},
+					},
+					Steps: []atc.BuildPreviewStep{
+						{
+							ID:      "some-id",
+							Name:    "some-input",
+							Kind:    "get",
+							Workers: []string{"some-worker"},
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedPreview),
+					),
+				)
+			})
+
+			It("returns the preview of the job's next build", func() {
+				preview, found, err := team.PreviewJobBuild(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(preview).To(Equal(expectedPreview))
+			})
+		})
+
+		Context("when pipeline/job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false in the found value and no error", func() {
+				_, found, err := team.PreviewJobBuild(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -195,6 +198,31 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+func (client *client) ApproveBuild(buildID string, step string) error {
+	return client.decideApproval(atc.ApproveBuild, buildID, step)
+}
+
+func (client *client) RejectBuild(buildID string, step string) error {
+	return client.decideApproval(atc.RejectBuild, buildID, step)
+}
+
+func (client *client) decideApproval(requestName string, buildID string, step string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	query := url.Values{}
+	if step != "" {
+		query.Set("step", step)
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Query:       query,
+	}, nil)
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
@@ -242,3 +270,22 @@ func (client *client) ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact,
 
 	return artifacts, err
 }
+
+func (client *client) GetBuildArtifact(buildID string, artifactID int) (io.ReadCloser, error) {
+	params := rata.Params{
+		"build_id":    buildID,
+		"artifact_id": strconv.Itoa(artifactID),
+	}
+
+	response := internal.Response{}
+	err := client.connection.Send(internal.Request{
+		RequestName:        atc.GetBuildArtifact,
+		Params:             params,
+		ReturnResponseBody: true,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Result.(io.ReadCloser), nil
+}
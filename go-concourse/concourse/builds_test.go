@@ -467,6 +467,39 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ApproveBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approve", "step=qa"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends an approve request to ATC", func() {
+			err := client.ApproveBuild("123", "qa")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("RejectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/reject", ""),
+					ghttp.RespondWith(http.StatusConflict, "build is not waiting for approval"),
+				),
+			)
+		})
+
+		It("returns the error from ATC", func() {
+			err := client.RejectBuild("123", "")
+			Expect(err).To(MatchError(ContainSubstring("build is not waiting for approval")))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
@@ -20,7 +20,11 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	GetBuildArtifact(buildID string, artifactID int) (io.ReadCloser, error)
+	BuildTestResults(buildID string) (atc.BuildTestResults, bool, error)
 	AbortBuild(buildID string) error
+	ApproveBuild(buildID string, step string) error
+	RejectBuild(buildID string, step string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
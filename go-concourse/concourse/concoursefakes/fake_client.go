@@ -23,6 +23,18 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string, string) error
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	approveBuildReturns struct {
+		result1 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -81,6 +93,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTestResultsStub        func(string) (atc.BuildTestResults, bool, error)
+	buildTestResultsMutex       sync.RWMutex
+	buildTestResultsArgsForCall []struct {
+		arg1 string
+	}
+	buildTestResultsReturns struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}
+	buildTestResultsReturnsOnCall map[int]struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -109,6 +136,20 @@ type FakeClient struct {
 		result1 concourse.Team
 		result2 error
 	}
+	GetBuildArtifactStub        func(string, int) (io.ReadCloser, error)
+	getBuildArtifactMutex       sync.RWMutex
+	getBuildArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	getBuildArtifactReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getBuildArtifactReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	GetCLIReaderStub        func(string, string) (io.ReadCloser, http.Header, error)
 	getCLIReaderMutex       sync.RWMutex
 	getCLIReaderArgsForCall []struct {
@@ -243,6 +284,18 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RejectBuildStub        func(string, string) error
+	rejectBuildMutex       sync.RWMutex
+	rejectBuildArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	rejectBuildReturns struct {
+		result1 error
+	}
+	rejectBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -355,6 +408,68 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string, arg2 string) error {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ApproveBuildStub
+	fakeReturns := fake.approveBuildReturns
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2})
+	fake.approveBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string, string) error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (string, string) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -620,6 +735,73 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTestResults(arg1 string) (atc.BuildTestResults, bool, error) {
+	fake.buildTestResultsMutex.Lock()
+	ret, specificReturn := fake.buildTestResultsReturnsOnCall[len(fake.buildTestResultsArgsForCall)]
+	fake.buildTestResultsArgsForCall = append(fake.buildTestResultsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildTestResultsStub
+	fakeReturns := fake.buildTestResultsReturns
+	fake.recordInvocation("BuildTestResults", []interface{}{arg1})
+	fake.buildTestResultsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTestResultsCallCount() int {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	return len(fake.buildTestResultsArgsForCall)
+}
+
+func (fake *FakeClient) BuildTestResultsCalls(stub func(string) (atc.BuildTestResults, bool, error)) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = stub
+}
+
+func (fake *FakeClient) BuildTestResultsArgsForCall(i int) string {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	argsForCall := fake.buildTestResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTestResultsReturns(result1 atc.BuildTestResults, result2 bool, result3 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	fake.buildTestResultsReturns = struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTestResultsReturnsOnCall(i int, result1 atc.BuildTestResults, result2 bool, result3 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	if fake.buildTestResultsReturnsOnCall == nil {
+		fake.buildTestResultsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTestResults
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTestResultsReturnsOnCall[i] = struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -751,6 +933,71 @@ func (fake *FakeClient) FindTeamReturnsOnCall(i int, result1 concourse.Team, res
 	}{result1, result2}
 }
 
+func (fake *FakeClient) GetBuildArtifact(arg1 string, arg2 int) (io.ReadCloser, error) {
+	fake.getBuildArtifactMutex.Lock()
+	ret, specificReturn := fake.getBuildArtifactReturnsOnCall[len(fake.getBuildArtifactArgsForCall)]
+	fake.getBuildArtifactArgsForCall = append(fake.getBuildArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.GetBuildArtifactStub
+	fakeReturns := fake.getBuildArtifactReturns
+	fake.recordInvocation("GetBuildArtifact", []interface{}{arg1, arg2})
+	fake.getBuildArtifactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetBuildArtifactCallCount() int {
+	fake.getBuildArtifactMutex.RLock()
+	defer fake.getBuildArtifactMutex.RUnlock()
+	return len(fake.getBuildArtifactArgsForCall)
+}
+
+func (fake *FakeClient) GetBuildArtifactCalls(stub func(string, int) (io.ReadCloser, error)) {
+	fake.getBuildArtifactMutex.Lock()
+	defer fake.getBuildArtifactMutex.Unlock()
+	fake.GetBuildArtifactStub = stub
+}
+
+func (fake *FakeClient) GetBuildArtifactArgsForCall(i int) (string, int) {
+	fake.getBuildArtifactMutex.RLock()
+	defer fake.getBuildArtifactMutex.RUnlock()
+	argsForCall := fake.getBuildArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) GetBuildArtifactReturns(result1 io.ReadCloser, result2 error) {
+	fake.getBuildArtifactMutex.Lock()
+	defer fake.getBuildArtifactMutex.Unlock()
+	fake.GetBuildArtifactStub = nil
+	fake.getBuildArtifactReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBuildArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getBuildArtifactMutex.Lock()
+	defer fake.getBuildArtifactMutex.Unlock()
+	fake.GetBuildArtifactStub = nil
+	if fake.getBuildArtifactReturnsOnCall == nil {
+		fake.getBuildArtifactReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getBuildArtifactReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) GetCLIReader(arg1 string, arg2 string) (io.ReadCloser, http.Header, error) {
 	fake.getCLIReaderMutex.Lock()
 	ret, specificReturn := fake.getCLIReaderReturnsOnCall[len(fake.getCLIReaderArgsForCall)]
@@ -1402,6 +1649,68 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RejectBuild(arg1 string, arg2 string) error {
+	fake.rejectBuildMutex.Lock()
+	ret, specificReturn := fake.rejectBuildReturnsOnCall[len(fake.rejectBuildArgsForCall)]
+	fake.rejectBuildArgsForCall = append(fake.rejectBuildArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RejectBuildStub
+	fakeReturns := fake.rejectBuildReturns
+	fake.recordInvocation("RejectBuild", []interface{}{arg1, arg2})
+	fake.rejectBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RejectBuildCallCount() int {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	return len(fake.rejectBuildArgsForCall)
+}
+
+func (fake *FakeClient) RejectBuildCalls(stub func(string, string) error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = stub
+}
+
+func (fake *FakeClient) RejectBuildArgsForCall(i int) (string, string) {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	argsForCall := fake.rejectBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RejectBuildReturns(result1 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	fake.rejectBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RejectBuildReturnsOnCall(i int, result1 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	if fake.rejectBuildReturnsOnCall == nil {
+		fake.rejectBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rejectBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1642,6 +1951,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
@@ -1650,10 +1961,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
+	fake.getBuildArtifactMutex.RLock()
+	defer fake.getBuildArtifactMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
@@ -1676,6 +1991,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
@@ -221,6 +221,37 @@ type FakeTeam struct {
 		result1 int64
 		result2 error
 	}
+	ConfigRevisionStub        func(atc.PipelineRef, int) (atc.ConfigRevision, bool, error)
+	configRevisionMutex       sync.RWMutex
+	configRevisionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}
+	configRevisionReturns struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	configRevisionReturnsOnCall map[int]struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	ConfigRevisionsStub        func(atc.PipelineRef) ([]atc.ConfigRevision, bool, error)
+	configRevisionsMutex       sync.RWMutex
+	configRevisionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	configRevisionsReturns struct {
+		result1 []atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
+	configRevisionsReturnsOnCall map[int]struct {
+		result1 []atc.ConfigRevision
+		result2 bool
+		result3 error
+	}
 	CreateArtifactStub        func(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	createArtifactMutex       sync.RWMutex
 	createArtifactArgsForCall []struct {
@@ -340,6 +371,23 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiffConfigRevisionsStub        func(atc.PipelineRef, int, int) (string, bool, error)
+	diffConfigRevisionsMutex       sync.RWMutex
+	diffConfigRevisionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 int
+		arg3 int
+	}
+	diffConfigRevisionsReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	diffConfigRevisionsReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
 	DisableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
@@ -484,6 +532,23 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	JobTestHistoryStub        func(atc.PipelineRef, string, int) ([]atc.TestHistory, bool, error)
+	jobTestHistoryMutex       sync.RWMutex
+	jobTestHistoryArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	jobTestHistoryReturns struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}
+	jobTestHistoryReturnsOnCall map[int]struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}
 	ListContainersStub        func(map[string]string) ([]atc.Container, error)
 	listContainersMutex       sync.RWMutex
 	listContainersArgsForCall []struct {
@@ -704,6 +769,36 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineSourceStub        func() (atc.PipelineSourceStatus, bool, error)
+	pipelineSourceMutex       sync.RWMutex
+	pipelineSourceArgsForCall []struct {
+	}
+	pipelineSourceReturns struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}
+	pipelineSourceReturnsOnCall map[int]struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}
+	PreviewJobBuildStub        func(atc.PipelineRef, string) (atc.BuildPreview, bool, error)
+	previewJobBuildMutex       sync.RWMutex
+	previewJobBuildArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	previewJobBuildReturns struct {
+		result1 atc.BuildPreview
+		result2 bool
+		result3 error
+	}
+	previewJobBuildReturnsOnCall map[int]struct {
+		result1 atc.BuildPreview
+		result2 bool
+		result3 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -816,6 +911,18 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SecretsReportStub        func() ([]atc.PipelineSecretsReport, error)
+	secretsReportMutex       sync.RWMutex
+	secretsReportArgsForCall []struct {
+	}
+	secretsReportReturns struct {
+		result1 []atc.PipelineSecretsReport
+		result2 error
+	}
+	secretsReportReturnsOnCall map[int]struct {
+		result1 []atc.PipelineSecretsReport
+		result2 error
+	}
 	SetJobBuildCommentStub        func(atc.PipelineRef, string, string, string) (bool, error)
 	setJobBuildCommentMutex       sync.RWMutex
 	setJobBuildCommentArgsForCall []struct {
@@ -1808,6 +1915,141 @@ func (fake *FakeTeam) ClearTaskCacheReturnsOnCall(i int, result1 int64, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ConfigRevision(arg1 atc.PipelineRef, arg2 int) (atc.ConfigRevision, bool, error) {
+	fake.configRevisionMutex.Lock()
+	ret, specificReturn := fake.configRevisionReturnsOnCall[len(fake.configRevisionArgsForCall)]
+	fake.configRevisionArgsForCall = append(fake.configRevisionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ConfigRevisionStub
+	fakeReturns := fake.configRevisionReturns
+	fake.recordInvocation("ConfigRevision", []interface{}{arg1, arg2})
+	fake.configRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ConfigRevisionCallCount() int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	return len(fake.configRevisionArgsForCall)
+}
+
+func (fake *FakeTeam) ConfigRevisionCalls(stub func(atc.PipelineRef, int) (atc.ConfigRevision, bool, error)) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = stub
+}
+
+func (fake *FakeTeam) ConfigRevisionArgsForCall(i int) (atc.PipelineRef, int) {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	argsForCall := fake.configRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ConfigRevisionReturns(result1 atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	fake.configRevisionReturns = struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ConfigRevisionReturnsOnCall(i int, result1 atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	if fake.configRevisionReturnsOnCall == nil {
+		fake.configRevisionReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configRevisionReturnsOnCall[i] = struct {
+		result1 atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ConfigRevisions(arg1 atc.PipelineRef) ([]atc.ConfigRevision, bool, error) {
+	fake.configRevisionsMutex.Lock()
+	ret, specificReturn := fake.configRevisionsReturnsOnCall[len(fake.configRevisionsArgsForCall)]
+	fake.configRevisionsArgsForCall = append(fake.configRevisionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.ConfigRevisionsStub
+	fakeReturns := fake.configRevisionsReturns
+	fake.recordInvocation("ConfigRevisions", []interface{}{arg1})
+	fake.configRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ConfigRevisionsCallCount() int {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	return len(fake.configRevisionsArgsForCall)
+}
+
+func (fake *FakeTeam) ConfigRevisionsCalls(stub func(atc.PipelineRef) ([]atc.ConfigRevision, bool, error)) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = stub
+}
+
+func (fake *FakeTeam) ConfigRevisionsArgsForCall(i int) atc.PipelineRef {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	argsForCall := fake.configRevisionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ConfigRevisionsReturns(result1 []atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	fake.configRevisionsReturns = struct {
+		result1 []atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ConfigRevisionsReturnsOnCall(i int, result1 []atc.ConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	if fake.configRevisionsReturnsOnCall == nil {
+		fake.configRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configRevisionsReturnsOnCall[i] = struct {
+		result1 []atc.ConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) CreateArtifact(arg1 io.Reader, arg2 string, arg3 []string) (atc.WorkerArtifact, error) {
 	var arg3Copy []string
 	if arg3 != nil {
@@ -2349,6 +2591,75 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) DiffConfigRevisions(arg1 atc.PipelineRef, arg2 int, arg3 int) (string, bool, error) {
+	fake.diffConfigRevisionsMutex.Lock()
+	ret, specificReturn := fake.diffConfigRevisionsReturnsOnCall[len(fake.diffConfigRevisionsArgsForCall)]
+	fake.diffConfigRevisionsArgsForCall = append(fake.diffConfigRevisionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.DiffConfigRevisionsStub
+	fakeReturns := fake.diffConfigRevisionsReturns
+	fake.recordInvocation("DiffConfigRevisions", []interface{}{arg1, arg2, arg3})
+	fake.diffConfigRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) DiffConfigRevisionsCallCount() int {
+	fake.diffConfigRevisionsMutex.RLock()
+	defer fake.diffConfigRevisionsMutex.RUnlock()
+	return len(fake.diffConfigRevisionsArgsForCall)
+}
+
+func (fake *FakeTeam) DiffConfigRevisionsCalls(stub func(atc.PipelineRef, int, int) (string, bool, error)) {
+	fake.diffConfigRevisionsMutex.Lock()
+	defer fake.diffConfigRevisionsMutex.Unlock()
+	fake.DiffConfigRevisionsStub = stub
+}
+
+func (fake *FakeTeam) DiffConfigRevisionsArgsForCall(i int) (atc.PipelineRef, int, int) {
+	fake.diffConfigRevisionsMutex.RLock()
+	defer fake.diffConfigRevisionsMutex.RUnlock()
+	argsForCall := fake.diffConfigRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) DiffConfigRevisionsReturns(result1 string, result2 bool, result3 error) {
+	fake.diffConfigRevisionsMutex.Lock()
+	defer fake.diffConfigRevisionsMutex.Unlock()
+	fake.DiffConfigRevisionsStub = nil
+	fake.diffConfigRevisionsReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) DiffConfigRevisionsReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.diffConfigRevisionsMutex.Lock()
+	defer fake.diffConfigRevisionsMutex.Unlock()
+	fake.DiffConfigRevisionsStub = nil
+	if fake.diffConfigRevisionsReturnsOnCall == nil {
+		fake.diffConfigRevisionsReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.diffConfigRevisionsReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.disableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
@@ -2999,6 +3310,75 @@ func (fake *FakeTeam) JobBuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) JobTestHistory(arg1 atc.PipelineRef, arg2 string, arg3 int) ([]atc.TestHistory, bool, error) {
+	fake.jobTestHistoryMutex.Lock()
+	ret, specificReturn := fake.jobTestHistoryReturnsOnCall[len(fake.jobTestHistoryArgsForCall)]
+	fake.jobTestHistoryArgsForCall = append(fake.jobTestHistoryArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.JobTestHistoryStub
+	fakeReturns := fake.jobTestHistoryReturns
+	fake.recordInvocation("JobTestHistory", []interface{}{arg1, arg2, arg3})
+	fake.jobTestHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) JobTestHistoryCallCount() int {
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
+	return len(fake.jobTestHistoryArgsForCall)
+}
+
+func (fake *FakeTeam) JobTestHistoryCalls(stub func(atc.PipelineRef, string, int) ([]atc.TestHistory, bool, error)) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = stub
+}
+
+func (fake *FakeTeam) JobTestHistoryArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
+	argsForCall := fake.jobTestHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) JobTestHistoryReturns(result1 []atc.TestHistory, result2 bool, result3 error) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = nil
+	fake.jobTestHistoryReturns = struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobTestHistoryReturnsOnCall(i int, result1 []atc.TestHistory, result2 bool, result3 error) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = nil
+	if fake.jobTestHistoryReturnsOnCall == nil {
+		fake.jobTestHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestHistory
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobTestHistoryReturnsOnCall[i] = struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ListContainers(arg1 map[string]string) ([]atc.Container, error) {
 	fake.listContainersMutex.Lock()
 	ret, specificReturn := fake.listContainersReturnsOnCall[len(fake.listContainersArgsForCall)]
@@ -4028,6 +4408,133 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineSource() (atc.PipelineSourceStatus, bool, error) {
+	fake.pipelineSourceMutex.Lock()
+	ret, specificReturn := fake.pipelineSourceReturnsOnCall[len(fake.pipelineSourceArgsForCall)]
+	fake.pipelineSourceArgsForCall = append(fake.pipelineSourceArgsForCall, struct {
+	}{})
+	stub := fake.PipelineSourceStub
+	fakeReturns := fake.pipelineSourceReturns
+	fake.recordInvocation("PipelineSource", []interface{}{})
+	fake.pipelineSourceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineSourceCallCount() int {
+	fake.pipelineSourceMutex.RLock()
+	defer fake.pipelineSourceMutex.RUnlock()
+	return len(fake.pipelineSourceArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineSourceCalls(stub func() (atc.PipelineSourceStatus, bool, error)) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = stub
+}
+
+func (fake *FakeTeam) PipelineSourceReturns(result1 atc.PipelineSourceStatus, result2 bool, result3 error) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = nil
+	fake.pipelineSourceReturns = struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineSourceReturnsOnCall(i int, result1 atc.PipelineSourceStatus, result2 bool, result3 error) {
+	fake.pipelineSourceMutex.Lock()
+	defer fake.pipelineSourceMutex.Unlock()
+	fake.PipelineSourceStub = nil
+	if fake.pipelineSourceReturnsOnCall == nil {
+		fake.pipelineSourceReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineSourceStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineSourceReturnsOnCall[i] = struct {
+		result1 atc.PipelineSourceStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PreviewJobBuild(arg1 atc.PipelineRef, arg2 string) (atc.BuildPreview, bool, error) {
+	fake.previewJobBuildMutex.Lock()
+	ret, specificReturn := fake.previewJobBuildReturnsOnCall[len(fake.previewJobBuildArgsForCall)]
+	fake.previewJobBuildArgsForCall = append(fake.previewJobBuildArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PreviewJobBuildStub
+	fakeReturns := fake.previewJobBuildReturns
+	fake.recordInvocation("PreviewJobBuild", []interface{}{arg1, arg2})
+	fake.previewJobBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PreviewJobBuildCallCount() int {
+	fake.previewJobBuildMutex.RLock()
+	defer fake.previewJobBuildMutex.RUnlock()
+	return len(fake.previewJobBuildArgsForCall)
+}
+
+func (fake *FakeTeam) PreviewJobBuildCalls(stub func(atc.PipelineRef, string) (atc.BuildPreview, bool, error)) {
+	fake.previewJobBuildMutex.Lock()
+	defer fake.previewJobBuildMutex.Unlock()
+	fake.PreviewJobBuildStub = stub
+}
+
+func (fake *FakeTeam) PreviewJobBuildArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.previewJobBuildMutex.RLock()
+	defer fake.previewJobBuildMutex.RUnlock()
+	argsForCall := fake.previewJobBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PreviewJobBuildReturns(result1 atc.BuildPreview, result2 bool, result3 error) {
+	fake.previewJobBuildMutex.Lock()
+	defer fake.previewJobBuildMutex.Unlock()
+	fake.PreviewJobBuildStub = nil
+	fake.previewJobBuildReturns = struct {
+		result1 atc.BuildPreview
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PreviewJobBuildReturnsOnCall(i int, result1 atc.BuildPreview, result2 bool, result3 error) {
+	fake.previewJobBuildMutex.Lock()
+	defer fake.previewJobBuildMutex.Unlock()
+	fake.PreviewJobBuildStub = nil
+	if fake.previewJobBuildReturnsOnCall == nil {
+		fake.previewJobBuildReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildPreview
+			result2 bool
+			result3 error
+		})
+	}
+	fake.previewJobBuildReturnsOnCall[i] = struct {
+		result1 atc.BuildPreview
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -4503,6 +5010,62 @@ func (fake *FakeTeam) ScheduleJobReturnsOnCall(i int, result1 bool, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SecretsReport() ([]atc.PipelineSecretsReport, error) {
+	fake.secretsReportMutex.Lock()
+	ret, specificReturn := fake.secretsReportReturnsOnCall[len(fake.secretsReportArgsForCall)]
+	fake.secretsReportArgsForCall = append(fake.secretsReportArgsForCall, struct {
+	}{})
+	stub := fake.SecretsReportStub
+	fakeReturns := fake.secretsReportReturns
+	fake.recordInvocation("SecretsReport", []interface{}{})
+	fake.secretsReportMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SecretsReportCallCount() int {
+	fake.secretsReportMutex.RLock()
+	defer fake.secretsReportMutex.RUnlock()
+	return len(fake.secretsReportArgsForCall)
+}
+
+func (fake *FakeTeam) SecretsReportCalls(stub func() ([]atc.PipelineSecretsReport, error)) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = stub
+}
+
+func (fake *FakeTeam) SecretsReportReturns(result1 []atc.PipelineSecretsReport, result2 error) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = nil
+	fake.secretsReportReturns = struct {
+		result1 []atc.PipelineSecretsReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretsReportReturnsOnCall(i int, result1 []atc.PipelineSecretsReport, result2 error) {
+	fake.secretsReportMutex.Lock()
+	defer fake.secretsReportMutex.Unlock()
+	fake.SecretsReportStub = nil
+	if fake.secretsReportReturnsOnCall == nil {
+		fake.secretsReportReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineSecretsReport
+			result2 error
+		})
+	}
+	fake.secretsReportReturnsOnCall[i] = struct {
+		result1 []atc.PipelineSecretsReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SetJobBuildComment(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (bool, error) {
 	fake.setJobBuildCommentMutex.Lock()
 	ret, specificReturn := fake.setJobBuildCommentReturnsOnCall[len(fake.setJobBuildCommentArgsForCall)]
@@ -4861,6 +5424,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.clearResourceVersionsMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
 	defer fake.createArtifactMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -4877,6 +5444,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.deletePipelineMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
 	defer fake.destroyTeamMutex.RUnlock()
+	fake.diffConfigRevisionsMutex.RLock()
+	defer fake.diffConfigRevisionsMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
@@ -4897,6 +5466,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.jobBuildMutex.RUnlock()
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
 	fake.listContainersMutex.RLock()
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
@@ -4929,6 +5500,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.pipelineSourceMutex.RLock()
+	defer fake.pipelineSourceMutex.RUnlock()
+	fake.previewJobBuildMutex.RLock()
+	defer fake.previewJobBuildMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
@@ -4943,6 +5518,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionsMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
+	fake.secretsReportMutex.RLock()
+	defer fake.secretsReportMutex.RUnlock()
 	fake.setJobBuildCommentMutex.RLock()
 	defer fake.setJobBuildCommentMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
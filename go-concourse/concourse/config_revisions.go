@@ -0,0 +1,104 @@
+package concourse
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ConfigRevisions(pipelineRef atc.PipelineRef) ([]atc.ConfigRevision, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	var revisions []atc.ConfigRevision
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListConfigRevisions,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &revisions,
+	})
+
+	switch err.(type) {
+	case nil:
+		return revisions, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) ConfigRevision(pipelineRef atc.PipelineRef, version int) (atc.ConfigRevision, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+		"version":       strconv.Itoa(version),
+	}
+
+	var revision atc.ConfigRevision
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetConfigRevision,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &revision,
+	})
+
+	switch err.(type) {
+	case nil:
+		return revision, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.ConfigRevision{}, false, nil
+	default:
+		return atc.ConfigRevision{}, false, err
+	}
+}
+
+// DiffConfigRevisions returns the rendered changes made by a revision of the
+// pipeline's config since the revision 'from', or since the revision before
+// it when 'from' is 0.
+func (team *team) DiffConfigRevisions(pipelineRef atc.PipelineRef, version int, from int) (string, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+		"version":       strconv.Itoa(version),
+	}
+
+	queryParams := url.Values{}
+	if from != 0 {
+		queryParams.Add("from", strconv.Itoa(from))
+	}
+
+	response := internal.Response{}
+	err := team.connection.Send(internal.Request{
+		RequestName:        atc.DiffConfigRevisions,
+		Params:             params,
+		Query:              merge(queryParams, pipelineRef.QueryParams()),
+		ReturnResponseBody: true,
+	}, &response)
+
+	switch err.(type) {
+	case nil:
+	case internal.ResourceNotFoundError:
+		return "", false, nil
+	default:
+		return "", false, err
+	}
+
+	body := response.Result.(io.ReadCloser)
+	defer body.Close()
+
+	diff, err := io.ReadAll(body)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(diff), true, nil
+}
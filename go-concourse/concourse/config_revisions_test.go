@@ -0,0 +1,171 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Config Revisions", func() {
+	var pipelineRef atc.PipelineRef
+
+	BeforeEach(func() {
+		pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+	})
+
+	Describe("ConfigRevisions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions"
+
+		Context("when the pipeline exists", func() {
+			var expectedRevisions []atc.ConfigRevision
+
+			BeforeEach(func() {
+				expectedRevisions = []atc.ConfigRevision{
+					{Version: 2, Source: atc.ConfigSourceSetPipeline, BuildID: 42, CreatedAt: 200},
+					{Version: 1, Source: atc.ConfigSourceFly, CreatedBy: "some-user", CreatedAt: 100},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedRevisions),
+					),
+				)
+			})
+
+			It("returns its revisions", func() {
+				revisions, found, err := team.ConfigRevisions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(revisions).To(Equal(expectedRevisions))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.ConfigRevisions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ConfigRevision", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions/2"
+
+		Context("when the revision exists", func() {
+			var expectedRevision atc.ConfigRevision
+
+			BeforeEach(func() {
+				expectedRevision = atc.ConfigRevision{
+					Version: 2,
+					Source:  atc.ConfigSourceFly,
+					Config: &atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedRevision),
+					),
+				)
+			})
+
+			It("returns the revision with its config", func() {
+				revision, found, err := team.ConfigRevision(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(revision).To(Equal(expectedRevision))
+			})
+		})
+
+		Context("when the revision does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.ConfigRevision(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("DiffConfigRevisions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions/3/diff"
+
+		Context("when comparing with the previous revision", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWith(http.StatusOK, "job some-job has changed"),
+					),
+				)
+			})
+
+			It("returns the rendered diff", func() {
+				diff, found, err := team.DiffConfigRevisions(pipelineRef, 3, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diff).To(Equal("job some-job has changed"))
+			})
+		})
+
+		Context("when comparing with a given revision", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "from=1&vars.branch=%22master%22"),
+						ghttp.RespondWith(http.StatusOK, "no changes"),
+					),
+				)
+			})
+
+			It("asks for the changes since that revision", func() {
+				diff, found, err := team.DiffConfigRevisions(pipelineRef, 3, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diff).To(Equal("no changes"))
+			})
+		})
+
+		Context("when a revision does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.DiffConfigRevisions(pipelineRef, 3, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) PipelineSource() (atc.PipelineSourceStatus, bool, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var status atc.PipelineSourceStatus
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetPipelineSource,
+		Params:      params,
+	}, &internal.Response{
+		Result: &status,
+	})
+
+	switch err.(type) {
+	case nil:
+		return status, true, nil
+	case internal.ResourceNotFoundError:
+		return status, false, nil
+	default:
+		return status, false, err
+	}
+}
@@ -0,0 +1,63 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Pipeline Source", func() {
+	Describe("PipelineSource", func() {
+		expectedURL := "/api/v1/teams/some-team/pipeline-source"
+
+		Context("when the team has a pipeline source", func() {
+			var expectedStatus atc.PipelineSourceStatus
+
+			BeforeEach(func() {
+				expectedStatus = atc.PipelineSourceStatus{
+					Source:   atc.PipelineSourceConfig{URI: "https://example.com/pipelines.git"},
+					Commit:   "abc123",
+					SyncedAt: 1000,
+					Pipelines: []atc.ManagedPipelineStatus{
+						{Name: "some-pipeline", Path: "some-pipeline.yml"},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStatus),
+					),
+				)
+			})
+
+			It("returns the status of its latest sync", func() {
+				status, found, err := team.PipelineSource()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(expectedStatus))
+			})
+		})
+
+		Context("when the team has no pipeline source", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.PipelineSource()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
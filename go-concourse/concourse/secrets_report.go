@@ -0,0 +1,23 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) SecretsReport() ([]atc.PipelineSecretsReport, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var reports []atc.PipelineSecretsReport
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetSecretsReport,
+		Params:      params,
+	}, &internal.Response{
+		Result: &reports,
+	})
+
+	return reports, err
+}
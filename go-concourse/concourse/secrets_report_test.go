@@ -0,0 +1,50 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Secrets Report", func() {
+	Describe("SecretsReport", func() {
+		var expectedReports []atc.PipelineSecretsReport
+
+		BeforeEach(func() {
+			expectedURL := "/api/v1/teams/some-team/secrets-report"
+
+			expectedReports = []atc.PipelineSecretsReport{
+				{
+					PipelineID:   1,
+					PipelineName: "some-pipeline",
+					Secrets: []atc.SecretReference{
+						{
+							Name:     "password",
+							Lookups:  []atc.SecretLookup{{Manager: "vault", Path: "/concourse/some-team/password"}},
+							Resolved: true,
+							Manager:  "vault",
+							Path:     "/concourse/some-team/password",
+						},
+					},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedReports),
+				),
+			)
+		})
+
+		It("returns the report of each pipeline", func() {
+			reports, err := team.SecretsReport()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reports).To(Equal(expectedReports))
+		})
+	})
+})
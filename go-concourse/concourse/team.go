@@ -30,10 +30,14 @@ type Team interface {
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	ConfigRevisions(pipelineRef atc.PipelineRef) ([]atc.ConfigRevision, bool, error)
+	ConfigRevision(pipelineRef atc.PipelineRef, version int) (atc.ConfigRevision, bool, error)
+	DiffConfigRevisions(pipelineRef atc.PipelineRef, version int, from int) (string, bool, error)
 
 	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
 	BuildInputsForJob(pipelineRef atc.PipelineRef, jobName string) ([]atc.BuildInput, bool, error)
+	PreviewJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.BuildPreview, bool, error)
 
 	Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, bool, error)
 	JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error)
@@ -43,6 +47,7 @@ type Team interface {
 	SetJobBuildComment(pipelineRef atc.PipelineRef, jobName string, buildName string, comment string) (bool, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 	ScheduleJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
+	JobTestHistory(pipelineRef atc.PipelineRef, jobName string, builds int) ([]atc.TestHistory, bool, error)
 
 	PauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
 	UnpauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
@@ -79,6 +84,9 @@ type Team interface {
 	OrderingPipelines(pipelineNames []string) error
 	OrderingPipelinesWithinGroup(groupName string, instanceVars []atc.InstanceVars) error
 
+	SecretsReport() ([]atc.PipelineSecretsReport, error)
+	PipelineSource() (atc.PipelineSourceStatus, bool, error)
+
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
 }
@@ -0,0 +1,147 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Test Results", func() {
+	Describe("BuildTestResults", func() {
+		Context("when the build exists", func() {
+			var expectedResults atc.BuildTestResults
+
+			BeforeEach(func() {
+				expectedResults = atc.BuildTestResults{
+					Summary: atc.TestSummary{Total: 2, Passed: 1, Failed: 1},
+					Results: []atc.TestResult{
+						{Step: "unit", Suite: "math", Name: "adds", Status: atc.TestStatusPassed},
+						{Step: "unit", Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "division by zero"},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/test-results"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+					),
+				)
+			})
+
+			It("returns the build's test results", func() {
+				results, found, err := client.BuildTestResults("123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(results).To(Equal(expectedResults))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/test-results"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := client.BuildTestResults("123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/test-results"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, _, err := client.BuildTestResults("123")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("JobTestHistory", func() {
+		var pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+		var expectedURL = "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/test-history"
+
+		Context("when the job exists", func() {
+			var expectedHistory []atc.TestHistory
+
+			BeforeEach(func() {
+				expectedHistory = []atc.TestHistory{
+					{
+						Suite:      "math",
+						Name:       "divides",
+						Runs:       3,
+						Passed:     2,
+						Failed:     1,
+						Flaky:      true,
+						LastStatus: atc.TestStatusFailed,
+						LastBuild:  "7",
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "builds=20&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedHistory),
+					),
+				)
+			})
+
+			It("returns the history of the job's tests", func() {
+				history, found, err := team.JobTestHistory(pipelineRef, "myjob", 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(history).To(Equal(expectedHistory))
+			})
+		})
+
+		Context("when the number of builds is not given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.TestHistory{}),
+					),
+				)
+			})
+
+			It("leaves it to the server", func() {
+				_, found, err := team.JobTestHistory(pipelineRef, "myjob", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.JobTestHistory(pipelineRef, "myjob", 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
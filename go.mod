@@ -12,10 +12,13 @@ require (
 	code.cloudfoundry.org/localip v0.14.0
 	code.cloudfoundry.org/urljoiner v0.0.0-20170223060717-5cabba6c0a50
 	dario.cat/mergo v1.0.1
+	filippo.io/age v1.2.1
+	github.com/BurntSushi/toml v1.4.0
 	github.com/DataDog/datadog-go/v5 v5.5.0
 	github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace v1.24.3
 	github.com/Masterminds/squirrel v1.5.4
 	github.com/NYTimes/gziphandler v1.1.1
+	github.com/ProtonMail/go-crypto v1.1.3
 	github.com/aryann/difflib v0.0.0-20210328193216-ff5ff6dc229b
 	github.com/aws/aws-sdk-go v1.55.5
 	github.com/caarlos0/env/v11 v11.2.2
@@ -197,6 +200,7 @@ require (
 	github.com/Masterminds/sprig/v3 v3.3.0 // indirect
 	github.com/acarl005/stripansi v0.0.0-20180116102854-5a71ef0e047d // indirect
 	github.com/alessio/shellescape v1.4.2 // indirect
+	github.com/cloudflare/circl v1.3.7 // indirect
 	github.com/concourse/go-archive v1.0.1 // indirect
 	github.com/containerd/cgroups/v3 v3.0.3 // indirect
 	github.com/containerd/errdefs v0.3.0 // indirect
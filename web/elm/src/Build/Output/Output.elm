@@ -220,6 +220,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        Skipped origin time ->
+            ( updateStep origin.id (setStepFinish (Just time) << setStepState StepStateSkipped) model
+            , effects
+            )
+
         InitializeGet origin time ->
             ( updateStep origin.id (setInitialize time) model
             , effects
@@ -255,6 +260,14 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        WaitingForApproval origin _ ->
+            ( updateStep origin.id setRunning model
+            , effects
+            )
+
+        ApprovalDecided _ _ _ _ ->
+            ( model, effects )
+
         BuildStatus status _ ->
             let
                 newSt =
@@ -52,6 +52,8 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | SetVar StepID
+    | Approval StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | Aggregate (Array StepTree)
@@ -112,6 +114,7 @@ type StepState
     | StepStateSucceeded
     | StepStateFailed
     | StepStateErrored
+    | StepStateSkipped
 
 
 showStepState : StepState -> String
@@ -138,6 +141,9 @@ showStepState state =
         StepStateErrored ->
             "errored"
 
+        StepStateSkipped ->
+            "skipped"
+
 
 stepStateOrdering : Ordering StepState
 stepStateOrdering =
@@ -149,6 +155,7 @@ stepStateOrdering =
         , StepStateRunning
         , StepStatePending
         , StepStateSucceeded
+        , StepStateSkipped
         ]
 
 
@@ -201,6 +208,7 @@ type BuildEvent
     | Initialize Origin Time.Posix
     | Start Origin Time.Posix
     | Finish Origin Time.Posix Bool
+    | Skipped Origin Time.Posix
     | InitializeGet Origin Time.Posix
     | StartGet Origin Time.Posix
     | FinishGet Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
@@ -208,6 +216,8 @@ type BuildEvent
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
     | SetPipelineChanged Origin Bool
+    | WaitingForApproval Origin Time.Posix
+    | ApprovalDecided Origin Bool String Time.Posix
     | Log Origin String (Maybe Time.Posix)
     | WaitingForWorker Origin (Maybe Time.Posix)
     | SelectedWorker Origin String (Maybe Time.Posix)
@@ -287,6 +297,12 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        SetVar stepId ->
+            [ stepId ]
+
+        Approval stepId ->
+            [ stepId ]
+
         Aggregate trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
@@ -365,6 +381,12 @@ updateTreeNodeAt id fn tree =
         LoadVar stepId ->
             updateSelf stepId
 
+        SetVar stepId ->
+            updateSelf stepId
+
+        Approval stepId ->
+            updateSelf stepId
+
         Aggregate trees ->
             Aggregate <| Array.map (updateTreeNodeAt id fn) trees
 
@@ -457,4 +479,4 @@ lastActive model trees =
 
 isActive : StepState -> Bool
 isActive state =
-    state /= StepStatePending && state /= StepStateCancelled
+    state /= StepStatePending && state /= StepStateCancelled && state /= StepStateSkipped
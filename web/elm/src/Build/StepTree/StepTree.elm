@@ -108,6 +108,12 @@ init buildId hl resources plan =
         Concourse.BuildStepLoadVar _ ->
             step |> initBottom buildId hl resources plan LoadVar
 
+        Concourse.BuildStepSetVar _ ->
+            step |> initBottom buildId hl resources plan SetVar
+
+        Concourse.BuildStepApproval _ ->
+            step |> initBottom buildId hl resources plan Approval
+
         Concourse.BuildStepAggregate plans ->
             initMultiStep buildId hl resources plan.id Aggregate plans Nothing
 
@@ -597,6 +603,12 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId
 
+        SetVar stepId ->
+            viewStep model session depth stepId
+
+        Approval stepId ->
+            viewStep model session depth stepId
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -1229,6 +1241,16 @@ viewStepState state stepID =
                     ++ attributes
                 )
 
+        StepStateSkipped ->
+            Icon.icon
+                { sizePx = 14
+                , image = Assets.CancelledIcon
+                }
+                (attribute "data-step-state" "skipped"
+                    :: Styles.stepStatusIcon
+                    ++ attributes
+                )
+
 
 viewStepHeader : Step -> Html Message
 viewStepHeader step =
@@ -1262,6 +1284,12 @@ viewStepHeader step =
         Concourse.BuildStepLoadVar name ->
             simpleHeader "load_var:" Nothing name
 
+        Concourse.BuildStepSetVar name ->
+            simpleHeader "set_var:" Nothing name
+
+        Concourse.BuildStepApproval name ->
+            simpleHeader "approval:" Nothing name
+
         Concourse.BuildStepCheck name _ ->
             simpleHeader "check:" Nothing name
 
@@ -1329,6 +1357,12 @@ stepName header =
         Concourse.BuildStepLoadVar name ->
             Just name
 
+        Concourse.BuildStepSetVar name ->
+            Just name
+
+        Concourse.BuildStepApproval name ->
+            Just name
+
         Concourse.BuildStepArtifactInput name ->
             Just name
 
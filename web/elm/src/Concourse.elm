@@ -399,6 +399,12 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepSetVar _ ->
+                    []
+
+                BuildStepApproval _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -489,6 +495,8 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName InstanceVars
     | BuildStepLoadVar StepName
+    | BuildStepSetVar StepName
+    | BuildStepApproval StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName (Maybe ImageBuildPlans)
     | BuildStepGet StepName (Maybe ResourceName) (Maybe Version) (Maybe ImageBuildPlans)
@@ -706,6 +714,10 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "set_var" <|
+                    lazy (\_ -> decodeBuildStepSetVar)
+                , Json.Decode.field "approval" <|
+                    lazy (\_ -> decodeBuildStepApproval)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -861,6 +873,18 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepSetVar : Json.Decode.Decoder BuildStep
+decodeBuildStepSetVar =
+    Json.Decode.succeed BuildStepSetVar
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
+decodeBuildStepApproval : Json.Decode.Decoder BuildStep
+decodeBuildStepApproval =
+    Json.Decode.succeed BuildStepApproval
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
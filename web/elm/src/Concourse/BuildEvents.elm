@@ -184,6 +184,14 @@ decodeBuildEvent =
                                 (Json.Decode.field "succeeded" Json.Decode.bool)
                             )
 
+                    "skipped" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 Skipped
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "initialize-get" ->
                         Json.Decode.field
                             "data"
@@ -230,6 +238,24 @@ decodeBuildEvent =
                                 (Json.Decode.field "changed" Json.Decode.bool)
                             )
 
+                    "waiting-for-approval" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 WaitingForApproval
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "approval-decided" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map4 ApprovalDecided
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "approved" Json.Decode.bool)
+                                (Json.Decode.field "decided_by" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "image-check" ->
                         Json.Decode.field "data"
                             (Json.Decode.map2 ImageCheck
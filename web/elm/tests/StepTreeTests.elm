@@ -31,6 +31,7 @@ all =
         [ initTask
         , initSetPipeline
         , initLoadVar
+        , initSetVar
         , initCheck
         , initRun
         , initGet
@@ -164,6 +165,30 @@ initLoadVar =
         ]
 
 
+initSetVar : Test
+initSetVar =
+    let
+        step =
+            BuildStepSetVar "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with SetVar"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.SetVar "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
 initCheck : Test
 initCheck =
     let
@@ -346,11 +346,25 @@ func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task, cont.ID())
+	ip, err := b.network.Add(ctx, task, cont.ID())
 	if err != nil {
 		return fmt.Errorf("network add: %w", err)
 	}
 
+	// expose the IP as Guardian does, so that other containers on the worker
+	// can be pointed at this one
+	if ip != "" {
+		labels, err := propertiesToLabels(garden.Properties{ContainerIPKey: ip})
+		if err != nil {
+			return fmt.Errorf("convert properties to labels: %w", err)
+		}
+
+		_, err = cont.SetLabels(ctx, labels)
+		if err != nil {
+			return fmt.Errorf("set container ip label: %w", err)
+		}
+	}
+
 	if hermetic {
 		err = b.network.DropContainerTraffic(cont.ID())
 		if err != nil {
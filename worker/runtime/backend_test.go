@@ -145,6 +145,22 @@ func (s *BackendSuite) TestCreateWithContainerNetOutSet() {
 	s.Equal(0, s.network.DropContainerTrafficCallCount())
 }
 
+func (s *BackendSuite) TestCreateSetsContainerIP() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.AddReturns("10.80.0.2", nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, fakeContainer.SetLabelsCallCount())
+	_, labels := fakeContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{"garden.network.container-ip.0": "10.80.0.2"}, labels)
+}
+
 func (s *BackendSuite) TestCreateContainerNewTaskFailure() {
 	fakeContainer := new(libcontainerdfakes.FakeContainer)
 
@@ -534,9 +534,9 @@ func (n cniNetwork) ResumeContainerTraffic(containerHandle string) error {
 	return nil
 }
 
-func (n cniNetwork) Add(ctx context.Context, task containerd.Task, containerHandle string) error {
+func (n cniNetwork) Add(ctx context.Context, task containerd.Task, containerHandle string) (string, error) {
 	if task == nil {
-		return ErrInvalidInput("nil task")
+		return "", ErrInvalidInput("nil task")
 	}
 
 	id, netns := netId(task), netNsPath(task)
@@ -544,21 +544,28 @@ func (n cniNetwork) Add(ctx context.Context, task containerd.Task, containerHand
 	result, err := n.client.Setup(ctx, id, netns)
 
 	if err != nil {
-		return fmt.Errorf("cni net setup: %w", err)
+		return "", fmt.Errorf("cni net setup: %w", err)
 	}
 
 	// Find container IP
 	config, found := result.Interfaces["eth0"]
 	if !found || len(config.IPConfigs) == 0 {
-		return fmt.Errorf("cni net setup: no eth0 interface found")
+		return "", fmt.Errorf("cni net setup: no eth0 interface found")
 	}
 
+	ip := config.IPConfigs[0].IP.String()
+
 	// Update /etc/hosts on container
 	// This could not be done earlier because we only have the container IP after the network has been setup
-	return n.store.Append(
+	err = n.store.Append(
 		filepath.Join(containerHandle, "/hosts"),
-		[]byte(config.IPConfigs[0].IP.String()+" "+containerHandle+"\n"),
+		[]byte(ip+" "+containerHandle+"\n"),
 	)
+	if err != nil {
+		return "", err
+	}
+
+	return ip, nil
 }
 
 func (n cniNetwork) Remove(ctx context.Context, task containerd.Task, handle string) error {
@@ -285,7 +285,7 @@ func (s *CNINetworkSuite) TestSetupHostNetwork() {
 }
 
 func (s *CNINetworkSuite) TestAddNilTask() {
-	err := s.network.Add(context.Background(), nil, "container-handle")
+	_, err := s.network.Add(context.Background(), nil, "container-handle")
 	s.EqualError(err, "nil task")
 }
 
@@ -293,7 +293,7 @@ func (s *CNINetworkSuite) TestAddSetupErrors() {
 	s.cni.SetupReturns(nil, errors.New("setup-err"))
 	task := new(libcontainerdfakes.FakeTask)
 
-	err := s.network.Add(context.Background(), task, "container-handle")
+	_, err := s.network.Add(context.Background(), task, "container-handle")
 	s.EqualError(errors.Unwrap(err), "setup-err")
 }
 
@@ -306,7 +306,7 @@ func (s *CNINetworkSuite) TestAddInterfaceNotFound() {
 		Interfaces: make(map[string]*cni.Config, 0),
 	}
 	s.cni.SetupReturns(result, nil)
-	err := s.network.Add(context.Background(), task, "container-handle")
+	_, err := s.network.Add(context.Background(), task, "container-handle")
 	s.EqualError(err, "cni net setup: no eth0 interface found")
 }
 
@@ -328,8 +328,9 @@ func (s *CNINetworkSuite) TestAdd() {
 
 	s.cni.SetupReturns(result, nil)
 
-	err := s.network.Add(context.Background(), task, "container-handle")
+	ip, err := s.network.Add(context.Background(), task, "container-handle")
 	s.NoError(err)
+	s.Equal("10.8.0.1", ip)
 
 	s.Equal(1, s.cni.SetupCallCount())
 	_, id, netns, _ := s.cni.SetupArgsForCall(0)
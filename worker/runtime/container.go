@@ -19,6 +19,10 @@ const (
 	Path          = "PATH=/usr/local/bin:/usr/bin:/bin"
 
 	GraceTimeKey = "garden.grace-time"
+
+	// ContainerIPKey is the property which holds the IP a container was given
+	// on the worker's network, named as Guardian names it.
+	ContainerIPKey = "garden.network.container-ip"
 )
 
 type UserNotFoundError struct {
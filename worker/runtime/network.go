@@ -19,9 +19,9 @@ type Network interface {
 	//
 	SetupMounts(handle string) (mounts []specs.Mount, err error)
 
-	// Add adds a task to the network.
+	// Add adds a task to the network, returning the IP that it was given.
 	//
-	Add(ctx context.Context, task containerd.Task, containerHandle string) (err error)
+	Add(ctx context.Context, task containerd.Task, containerHandle string) (ip string, err error)
 
 	// Removes a task from the network.
 	//
@@ -11,7 +11,7 @@ import (
 )
 
 type FakeNetwork struct {
-	AddStub        func(context.Context, containerd.Task, string) error
+	AddStub        func(context.Context, containerd.Task, string) (string, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		arg1 context.Context
@@ -19,10 +19,12 @@ type FakeNetwork struct {
 		arg3 string
 	}
 	addReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	addReturnsOnCall map[int]struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	DropContainerTrafficStub        func(string) error
 	dropContainerTrafficMutex       sync.RWMutex
@@ -86,7 +88,7 @@ type FakeNetwork struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task, arg3 string) error {
+func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task, arg3 string) (string, error) {
 	fake.addMutex.Lock()
 	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
 	fake.addArgsForCall = append(fake.addArgsForCall, struct {
@@ -102,9 +104,9 @@ func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task, arg3 st
 		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNetwork) AddCallCount() int {
@@ -113,7 +115,7 @@ func (fake *FakeNetwork) AddCallCount() int {
 	return len(fake.addArgsForCall)
 }
 
-func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task, string) error) {
+func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task, string) (string, error)) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = stub
@@ -126,27 +128,30 @@ func (fake *FakeNetwork) AddArgsForCall(i int) (context.Context, containerd.Task
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeNetwork) AddReturns(result1 error) {
+func (fake *FakeNetwork) AddReturns(result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	fake.addReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
+func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	if fake.addReturnsOnCall == nil {
 		fake.addReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.addReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeNetwork) DropContainerTraffic(arg1 string) error {